@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	stdctx "context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/errhandler"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/logging"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/announce"
+	"github.com/goreleaser/goreleaser/v2/internal/pipeline"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/spf13/cobra"
+)
+
+type announceCmd struct {
+	cmd  *cobra.Command
+	opts announceOpts
+}
+
+type announceOpts struct {
+	config  string
+	dryRun  bool
+	timeout time.Duration
+}
+
+func newAnnounceCmd() *announceCmd {
+	root := &announceCmd{}
+	cmd := &cobra.Command{
+		Use:   "announce",
+		Short: "Announces the current release",
+		Long: `Runs only the announce phase of the release.
+
+If the dist directory has the metadata of a previous release, the tag, version, commit, artifacts and release notes are restored from it, so a failed announcement can be retried.
+
+With --dry-run, the payload of each enabled announcer is printed instead of sent, so the templates can be verified before a real release.`,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return announceProject(cmd.Context(), cmd.OutOrStdout(), root.opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&root.opts.config, "config", "f", "", "Load configuration from file")
+	_ = cmd.MarkFlagFilename("config", "yaml", "yml")
+	cmd.Flags().BoolVar(&root.opts.dryRun, "dry-run", false, "Print what would be announced, without announcing it")
+	cmd.Flags().DurationVar(&root.opts.timeout, "timeout", 30*time.Minute, "Timeout to the entire announce process")
+	_ = cmd.RegisterFlagCompletionFunc("timeout", cobra.NoFileCompletions)
+
+	root.cmd = cmd
+	return root
+}
+
+func announceProject(parent stdctx.Context, w io.Writer, options announceOpts) error {
+	start := time.Now()
+	log.Infof(boldStyle.Render("starting announce"))
+	cfg, err := loadConfig(true, options.config)
+	if err != nil {
+		return decorateWithCtxErr(parent, err, "announce", after(start))
+	}
+
+	ctx, cancel := context.WrapWithTimeout(parent, cfg, options.timeout)
+	defer cancel()
+
+	// the release was already validated, and announcers that need a token
+	// will complain on their own.
+	ctx.SkipTokenCheck = true
+	skips.Set(ctx, skips.Validate)
+
+	for _, pipe := range pipeline.AnnouncePipeline {
+		if err := skip.Maybe(
+			pipe,
+			logging.Log(
+				pipe.String(),
+				errhandler.Handle(pipe.Run),
+			),
+		)(ctx); err != nil {
+			return decorateWithCtxErr(ctx, err, "announce", after(start))
+		}
+	}
+
+	if options.dryRun {
+		if err := announce.Preview(ctx, w); err != nil {
+			return decorateWithCtxErr(ctx, err, "announce", after(start))
+		}
+		log.Infof(boldStyle.Render(fmt.Sprintf("announce dry-run succeeded after %s", after(start))))
+		return nil
+	}
+
+	pipe := announce.Pipe{}
+	if err := skip.Maybe(
+		pipe,
+		logging.Log(
+			pipe.String(),
+			errhandler.Handle(pipe.Run),
+		),
+	)(ctx); err != nil {
+		return decorateWithCtxErr(ctx, err, "announce", after(start))
+	}
+
+	log.Infof(boldStyle.Render(fmt.Sprintf("announce succeeded after %s", after(start))))
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnounceDryRun(t *testing.T) {
+	setup(t)
+	createFile(t, "goreleaser.yml", `version: 2
+release:
+  github:
+    owner: goreleaser
+    name: fake
+announce:
+  twitter:
+    enabled: true
+    message_template: '{{ .ProjectName }} {{ .Tag }} is out! {{ .ReleaseURL }}'
+  mastodon:
+    enabled: false
+`)
+	var out bytes.Buffer
+	cmd := newAnnounceCmd()
+	cmd.cmd.SetOut(&out)
+	cmd.cmd.SetArgs([]string{"--dry-run"})
+	require.NoError(t, cmd.cmd.Execute())
+	require.Equal(t, "--- twitter\nfake v0.0.2 is out! https://github.com/goreleaser/fake/releases/tag/v0.0.2\n\n", out.String())
+}
+
+func TestAnnounceRestore(t *testing.T) {
+	setup(t)
+	createFile(t, "goreleaser.yml", `version: 2
+release:
+  github:
+    owner: goreleaser
+    name: fake
+announce:
+  twitter:
+    enabled: true
+    message_template: '{{ .ProjectName }} {{ .Tag }} ({{ .PreviousTag }}) is out! {{ .ReleaseNotes }}'
+`)
+	require.NoError(t, os.Mkdir("dist", 0o755))
+	createFile(t, "dist/metadata.json", `{"project_name":"fake","tag":"v0.0.1","previous_tag":"v0.0.0","version":"0.0.1","commit":"abcdef1234"}`)
+	createFile(t, "dist/artifacts.json", `[]`)
+	createFile(t, "dist/CHANGELOG.md", "notes")
+
+	var out bytes.Buffer
+	cmd := newAnnounceCmd()
+	cmd.cmd.SetOut(&out)
+	cmd.cmd.SetArgs([]string{"--dry-run"})
+	require.NoError(t, cmd.cmd.Execute())
+	require.Equal(t, "--- twitter\nfake v0.0.1 (v0.0.0) is out! notes\n\n", out.String())
+}
+
+func TestAnnounceInvalidConfig(t *testing.T) {
+	setup(t)
+	createFile(t, "goreleaser.yml", "foo: bar\nversion: 2")
+	cmd := newAnnounceCmd()
+	cmd.cmd.SetArgs([]string{"--dry-run"})
+	require.EqualError(t, cmd.cmd.Execute(), "yaml: unmarshal errors:\n  line 1: field foo not found in type config.Project")
+}
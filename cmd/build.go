@@ -32,17 +32,19 @@ type buildCmd struct {
 }
 
 type buildOpts struct {
-	config       string
-	ids          []string
-	snapshot     bool
-	autoSnapshot bool
-	clean        bool
-	deprecated   bool
-	parallelism  int
-	timeout      time.Duration
-	singleTarget bool
-	output       string
-	skips        []string
+	config             string
+	ids                []string
+	snapshot           bool
+	autoSnapshot       bool
+	clean              bool
+	cleanCache         bool
+	verifyReproducible bool
+	deprecated         bool
+	parallelism        int
+	timeout            time.Duration
+	singleTarget       bool
+	output             string
+	skips              []string
 }
 
 func newBuildCmd() *buildCmd {
@@ -74,6 +76,8 @@ When using ` + "`--single-target`" + `, you use the ` + "`TARGET`, or `GOOS`, `G
 	cmd.Flags().BoolVar(&root.opts.snapshot, "snapshot", false, "Generate an unversioned snapshot build, skipping all validations")
 	cmd.Flags().BoolVar(&root.opts.autoSnapshot, "auto-snapshot", false, "Automatically sets --snapshot if the repository is dirty")
 	cmd.Flags().BoolVar(&root.opts.clean, "clean", false, "Removes the 'dist' directory before building")
+	cmd.Flags().BoolVar(&root.opts.cleanCache, "clean-cache", false, "Removes the 'build_cache.dir' directory before building")
+	cmd.Flags().BoolVar(&root.opts.verifyReproducible, "verify-reproducible", false, "Builds every target twice and fails if the binaries differ")
 	cmd.Flags().IntVarP(&root.opts.parallelism, "parallelism", "p", 0, "Number of tasks to run concurrently (default: number of CPUs)")
 	_ = cmd.RegisterFlagCompletionFunc("parallelism", cobra.NoFileCompletions)
 	cmd.Flags().DurationVar(&root.opts.timeout, "timeout", time.Hour, "Timeout to the entire build process")
@@ -176,6 +180,8 @@ func setupBuildContext(ctx *context.Context, options buildOpts) error {
 
 	ctx.SkipTokenCheck = true
 	ctx.Clean = options.clean
+	ctx.CleanCache = options.cleanCache
+	ctx.VerifyReproducible = options.verifyReproducible
 
 	if options.singleTarget {
 		ctx.SingleTarget = true
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goreleaser/goreleaser/v2/internal/archivemanifest"
+	"github.com/spf13/cobra"
+)
+
+type diffArchivesCmd struct {
+	cmd *cobra.Command
+}
+
+func newDiffArchivesCmd() *diffArchivesCmd {
+	root := &diffArchivesCmd{}
+	cmd := &cobra.Command{
+		Use:   "diff-archives <old> <new>",
+		Short: "Compares the contents of archives between two releases",
+		Long: `Compares the archive manifests between two releases, showing which files were added, removed, or modified in each archive.
+
+Each argument can be a manifest file, or a directory (e.g. the dist directory of a release) containing manifests.
+Manifests are created when 'archives.manifest' is enabled.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			before, err := loadManifests(args[0])
+			if err != nil {
+				return err
+			}
+			after, err := loadManifests(args[1])
+			if err != nil {
+				return err
+			}
+			return diffArchives(cmd.OutOrStdout(), before, after)
+		},
+	}
+
+	root.cmd = cmd
+	return root
+}
+
+// loadManifests loads the manifest at the given path, or all the manifests
+// inside it if it is a directory, indexed by their key.
+func loadManifests(path string) (map[string]archivemanifest.Manifest, error) {
+	result := map[string]archivemanifest.Manifest{}
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || (p != path && !strings.HasSuffix(p, archivemanifest.Extension)) {
+			return nil
+		}
+		m, err := archivemanifest.Read(p)
+		if err != nil {
+			return err
+		}
+		result[m.Key()] = m
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not load manifests: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no archive manifests found in %s", path)
+	}
+	return result, nil
+}
+
+func diffArchives(w io.Writer, before, after map[string]archivemanifest.Manifest) error {
+	var keys []string
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Compare(archiveName(before, after, a), archiveName(before, after, b))
+	})
+
+	var changed bool
+	for _, key := range keys {
+		o, inOld := before[key]
+		n, inNew := after[key]
+		switch {
+		case !inNew:
+			changed = true
+			if _, err := fmt.Fprintf(w, "- %s (removed)\n", o.Archive); err != nil {
+				return err
+			}
+		case !inOld:
+			changed = true
+			if _, err := fmt.Fprintf(w, "+ %s (added)\n", n.Archive); err != nil {
+				return err
+			}
+		default:
+			changes := archivemanifest.Diff(o, n)
+			if len(changes) == 0 {
+				continue
+			}
+			changed = true
+			if _, err := fmt.Fprintf(w, "%s -> %s\n", o.Archive, n.Archive); err != nil {
+				return err
+			}
+			for _, change := range changes {
+				if _, err := fmt.Fprintln(w, "  "+describeChange(change)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if !changed {
+		_, err := fmt.Fprintln(w, "no changes")
+		return err
+	}
+	return nil
+}
+
+func archiveName(before, after map[string]archivemanifest.Manifest, key string) string {
+	if m, ok := after[key]; ok {
+		return m.Archive
+	}
+	return before[key].Archive
+}
+
+func describeChange(change archivemanifest.Change) string {
+	switch change.Kind {
+	case archivemanifest.Added:
+		return "+ " + change.Path
+	case archivemanifest.Removed:
+		return "- " + change.Path
+	}
+	var details []string
+	switch {
+	case change.Old.Size != change.New.Size:
+		details = append(details, fmt.Sprintf("size %d -> %d", change.Old.Size, change.New.Size))
+	case change.Old.SHA256 != change.New.SHA256:
+		details = append(details, "content changed")
+	}
+	if change.Old.Mode != change.New.Mode {
+		details = append(details, fmt.Sprintf("mode %04o -> %04o", change.Old.Mode, change.New.Mode))
+	}
+	return fmt.Sprintf("~ %s (%s)", change.Path, strings.Join(details, ", "))
+}
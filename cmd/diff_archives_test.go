@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/archivemanifest"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifests(tb testing.TB, manifests ...archivemanifest.Manifest) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	for _, m := range manifests {
+		require.NoError(tb, m.Write(filepath.Join(dir, m.Archive+archivemanifest.Extension)))
+	}
+	return dir
+}
+
+func TestDiffArchives(t *testing.T) {
+	before := writeManifests(
+		t,
+		archivemanifest.Manifest{
+			Archive: "foo_1.0.0_linux_amd64.tar.gz",
+			ID:      "default",
+			Format:  "tar.gz",
+			Target:  "linux_amd64_v1",
+			Files: []archivemanifest.File{
+				{Path: "foo", Size: 10, Mode: 0o755, SHA256: "1"},
+				{Path: "README.md", Size: 5, Mode: 0o644, SHA256: "2"},
+				{Path: "LICENSE", Size: 5, Mode: 0o644, SHA256: "3"},
+				{Path: "run.sh", Size: 5, Mode: 0o644, SHA256: "4"},
+			},
+		},
+		archivemanifest.Manifest{
+			Archive: "foo_1.0.0_linux_arm64.tar.gz",
+			ID:      "default",
+			Format:  "tar.gz",
+			Target:  "linux_arm64_v8.0",
+			Files: []archivemanifest.File{
+				{Path: "foo", Size: 10, Mode: 0o755, SHA256: "1"},
+			},
+		},
+		archivemanifest.Manifest{
+			Archive: "foo_1.0.0_windows_amd64.zip",
+			ID:      "default",
+			Format:  "zip",
+			Target:  "windows_amd64_v1",
+			Files: []archivemanifest.File{
+				{Path: "foo.exe", Size: 10, Mode: 0o755, SHA256: "1"},
+			},
+		},
+	)
+	after := writeManifests(
+		t,
+		archivemanifest.Manifest{
+			Archive: "foo_1.1.0_linux_amd64.tar.gz",
+			ID:      "default",
+			Format:  "tar.gz",
+			Target:  "linux_amd64_v1",
+			Files: []archivemanifest.File{
+				{Path: "foo", Size: 12, Mode: 0o755, SHA256: "5"},
+				{Path: "CHANGELOG.md", Size: 5, Mode: 0o644, SHA256: "6"},
+				{Path: "LICENSE", Size: 5, Mode: 0o644, SHA256: "7"},
+				{Path: "run.sh", Size: 5, Mode: 0o755, SHA256: "4"},
+			},
+		},
+		archivemanifest.Manifest{
+			Archive: "foo_1.1.0_linux_arm64.tar.gz",
+			ID:      "default",
+			Format:  "tar.gz",
+			Target:  "linux_arm64_v8.0",
+			Files: []archivemanifest.File{
+				{Path: "foo", Size: 10, Mode: 0o755, SHA256: "1"},
+			},
+		},
+		archivemanifest.Manifest{
+			Archive: "foo_1.1.0_darwin_all.tar.gz",
+			ID:      "default",
+			Format:  "tar.gz",
+			Target:  "darwin_all",
+			Files: []archivemanifest.File{
+				{Path: "foo", Size: 10, Mode: 0o755, SHA256: "1"},
+			},
+		},
+	)
+
+	var out bytes.Buffer
+	cmd := newDiffArchivesCmd().cmd
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{before, after})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, `- foo_1.0.0_windows_amd64.zip (removed)
++ foo_1.1.0_darwin_all.tar.gz (added)
+foo_1.0.0_linux_amd64.tar.gz -> foo_1.1.0_linux_amd64.tar.gz
+  + CHANGELOG.md
+  ~ LICENSE (content changed)
+  - README.md
+  ~ foo (size 10 -> 12)
+  ~ run.sh (mode 0644 -> 0755)
+`, out.String())
+}
+
+func TestDiffArchivesSingleFile(t *testing.T) {
+	m := archivemanifest.Manifest{
+		Archive: "foo.tar.gz",
+		ID:      "default",
+		Format:  "tar.gz",
+		Files: []archivemanifest.File{
+			{Path: "foo", Size: 10, Mode: 0o755, SHA256: "1"},
+		},
+	}
+	path := filepath.Join(writeManifests(t, m), "foo.tar.gz"+archivemanifest.Extension)
+
+	var out bytes.Buffer
+	cmd := newDiffArchivesCmd().cmd
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{path, path})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "no changes\n", out.String())
+}
+
+func TestDiffArchivesNoManifests(t *testing.T) {
+	dir := t.TempDir()
+	cmd := newDiffArchivesCmd().cmd
+	cmd.SetArgs([]string{dir, dir})
+	require.EqualError(t, cmd.Execute(), "no archive manifests found in "+dir)
+}
@@ -24,22 +24,24 @@ type releaseCmd struct {
 }
 
 type releaseOpts struct {
-	config            string
-	releaseNotesFile  string
-	releaseNotesTmpl  string
-	releaseHeaderFile string
-	releaseHeaderTmpl string
-	releaseFooterFile string
-	releaseFooterTmpl string
-	autoSnapshot      bool
-	snapshot          bool
-	draft             bool
-	failFast          bool
-	clean             bool
-	deprecated        bool
-	parallelism       int
-	timeout           time.Duration
-	skips             []string
+	config             string
+	releaseNotesFile   string
+	releaseNotesTmpl   string
+	releaseHeaderFile  string
+	releaseHeaderTmpl  string
+	releaseFooterFile  string
+	releaseFooterTmpl  string
+	autoSnapshot       bool
+	snapshot           bool
+	draft              bool
+	failFast           bool
+	clean              bool
+	cleanCache         bool
+	verifyReproducible bool
+	deprecated         bool
+	parallelism        int
+	timeout            time.Duration
+	skips              []string
 }
 
 func newReleaseCmd() *releaseCmd {
@@ -77,6 +79,8 @@ func newReleaseCmd() *releaseCmd {
 	cmd.Flags().BoolVar(&root.opts.draft, "draft", false, "Whether to set the release to draft. Overrides release.draft in the configuration file")
 	cmd.Flags().BoolVar(&root.opts.failFast, "fail-fast", false, "Whether to abort the release publishing on the first error")
 	cmd.Flags().BoolVar(&root.opts.clean, "clean", false, "Removes the 'dist' directory")
+	cmd.Flags().BoolVar(&root.opts.cleanCache, "clean-cache", false, "Removes the 'build_cache.dir' directory")
+	cmd.Flags().BoolVar(&root.opts.verifyReproducible, "verify-reproducible", false, "Builds every target twice and fails if the binaries differ")
 	cmd.Flags().IntVarP(&root.opts.parallelism, "parallelism", "p", 0, "Amount tasks to run concurrently (default: number of CPUs)")
 	_ = cmd.RegisterFlagCompletionFunc("parallelism", cobra.NoFileCompletions)
 	cmd.Flags().DurationVar(&root.opts.timeout, "timeout", time.Hour, "Timeout to the entire release process")
@@ -145,6 +149,8 @@ func setupReleaseContext(ctx *context.Context, options releaseOpts) error {
 	ctx.Snapshot = options.snapshot
 	ctx.FailFast = options.failFast
 	ctx.Clean = options.clean
+	ctx.CleanCache = options.cleanCache
+	ctx.VerifyReproducible = options.verifyReproducible
 	if options.autoSnapshot && git.CheckDirty(ctx) != nil {
 		log.Info("git repository is dirty and --auto-snapshot is set, implying --snapshot")
 		ctx.Snapshot = true
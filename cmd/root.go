@@ -136,9 +136,11 @@ goreleaser release
 
 	cmd.PersistentFlags().BoolVar(&root.verbose, "verbose", false, "Enable verbose mode")
 	cmd.AddCommand(
+		newAnnounceCmd().cmd,
 		newBuildCmd().cmd,
 		newReleaseCmd().cmd,
 		newCheckCmd().cmd,
+		newDiffArchivesCmd().cmd,
 		newHealthcheckCmd().cmd,
 		newInitCmd().cmd,
 		newManCmd().cmd,
@@ -23,6 +23,8 @@ func setup(tb testing.TB) string {
 	_ = os.Unsetenv("GITHUB_TOKEN")
 	_ = os.Unsetenv("GITLAB_TOKEN")
 	_ = os.Unsetenv("GITEA_TOKEN")
+	_ = os.Unsetenv("FORGEJO_TOKEN")
+	_ = os.Unsetenv("BITBUCKET_TOKEN")
 
 	folder := tb.TempDir()
 	tb.Chdir(folder)
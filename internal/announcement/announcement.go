@@ -0,0 +1,67 @@
+// Package announcement provides functionality shared by the announcers.
+package announcement
+
+import (
+	"unicode/utf8"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// ReleaseURLTemplate returns the download URL template of the release, or an
+// empty string if there is no release.
+func ReleaseURLTemplate(ctx *context.Context) string {
+	cli, err := client.NewReleaseClient(ctx)
+	if err != nil {
+		log.WithError(err).Debug("could not create release client, artifacts will not be linked")
+		return ""
+	}
+	urlTemplate, err := cli.ReleaseURLTemplate(ctx)
+	if err != nil {
+		log.WithError(err).Debug("could not get release url template, artifacts will not be linked")
+		return ""
+	}
+	return urlTemplate
+}
+
+// Buttons templates the given buttons, skipping the ones with an empty URL,
+// e.g. the release URL when the release is disabled.
+func Buttons(ctx *context.Context, buttons []config.AnnounceButton) ([]config.AnnounceButton, error) {
+	t := tmpl.New(ctx).WithMessageTemplates()
+	var result []config.AnnounceButton
+	for _, button := range buttons {
+		if err := t.ApplyAll(&button.Title, &button.URL); err != nil {
+			return nil, err
+		}
+		if button.URL == "" {
+			continue
+		}
+		result = append(result, button)
+	}
+	return result, nil
+}
+
+// CommitRange returns the range of the commits included in the release, or an
+// empty string if there is no previous tag.
+func CommitRange(ctx *context.Context) string {
+	if ctx.Git.PreviousTag == "" {
+		return ""
+	}
+	return ctx.Git.PreviousTag + "..." + ctx.Git.CurrentTag
+}
+
+// Truncate truncates s to at most n bytes, adding an ellipsis and not breaking
+// multi-byte characters.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	i := n - len("...")
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return s[:i] + "..."
+}
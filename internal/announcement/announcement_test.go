@@ -0,0 +1,83 @@
+package announcement
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseURLTemplate(t *testing.T) {
+	t.Run("github", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			GitHubURLs: config.GitHubURLs{
+				Download: "https://github.com",
+			},
+			Release: config.Release{
+				GitHub: config.Repo{Owner: "foo", Name: "bar"},
+			},
+		}, testctx.GitHubTokenType)
+		require.Equal(
+			t,
+			"https://github.com/foo/bar/releases/download/{{ urlPathEscape .Tag }}/{{ .ArtifactName }}",
+			ReleaseURLTemplate(ctx),
+		)
+	})
+
+	t.Run("release disabled", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Release: config.Release{Disable: "true"},
+		}, testctx.GitHubTokenType)
+		require.Empty(t, ReleaseURLTemplate(ctx))
+	})
+
+	t.Run("invalid client", func(t *testing.T) {
+		require.Empty(t, ReleaseURLTemplate(testctx.Wrap(t.Context())))
+	})
+}
+
+func TestButtons(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+	}, testctx.WithCurrentTag("v1.0.0"))
+
+	t.Run("valid", func(t *testing.T) {
+		buttons, err := Buttons(ctx, []config.AnnounceButton{
+			{Title: "{{ .ProjectName }} {{ .Tag }}", URL: "https://example.com/{{ .Tag }}"},
+			{Title: "Release", URL: "{{ .ReleaseURL }}"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []config.AnnounceButton{
+			{Title: "foo v1.0.0", URL: "https://example.com/v1.0.0"},
+		}, buttons)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := Buttons(ctx, []config.AnnounceButton{
+			{Title: "a", URL: "{{ .Foo }"},
+		})
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestCommitRange(t *testing.T) {
+	t.Run("with previous tag", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context(), testctx.WithCurrentTag("v1.1.0"), testctx.WithPreviousTag("v1.0.0"))
+		require.Equal(t, "v1.0.0...v1.1.0", CommitRange(ctx))
+	})
+
+	t.Run("first release", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context(), testctx.WithCurrentTag("v1.0.0"))
+		require.Empty(t, CommitRange(ctx))
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	require.Equal(t, "foo", Truncate("foo", 10))
+	require.Equal(t, "foo...", Truncate("foobarbaz", 6))
+	require.Equal(t, "...", Truncate(strings.Repeat("é", 3), 4))
+	require.Len(t, Truncate(strings.Repeat("a", 200), 100), 100)
+}
@@ -17,8 +17,29 @@ import (
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 )
 
+// Option is an [Eval] option.
+type Option func(*options)
+
+type options struct {
+	strict bool
+}
+
+// Strict makes [Eval] fail if a glob does not match any files, instead of
+// only logging a warning.
+// Default globs are not affected.
+func Strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
 // Eval evaluates the given list of files to their final form.
-func Eval(template *tmpl.Template, files []config.File) ([]config.File, error) {
+func Eval(template *tmpl.Template, files []config.File, opts ...Option) ([]config.File, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var result []config.File
 	for _, f := range files {
 		glob, err := template.Apply(f.Source)
@@ -27,11 +48,14 @@ func Eval(template *tmpl.Template, files []config.File) ([]config.File, error) {
 		}
 
 		files, err := fileglob.Glob(glob)
-		if err != nil {
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
 			return result, fmt.Errorf("globbing failed for pattern %s: %w", glob, err)
 		}
 
 		if len(files) == 0 {
+			if o.strict && !f.Default {
+				return result, fmt.Errorf("no files matched the glob %s", glob)
+			}
 			if !f.Default {
 				// only log if its not a default glob, as those are usually
 				// very generic and are not really warnings for the user.
@@ -70,6 +94,59 @@ func Eval(template *tmpl.Template, files []config.File) ([]config.File, error) {
 	return unique(result), nil
 }
 
+// EvalTemplated renders the given templated files into dir, returning the
+// files to add to the archive.
+func EvalTemplated(template *tmpl.Template, files []config.TemplatedFile, dir string) ([]config.File, error) {
+	result := make([]config.File, 0, len(files))
+	for _, f := range files {
+		src, err := template.Apply(f.Source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply template %s: %w", f.Source, err)
+		}
+		dst, err := template.Apply(f.Destination)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply template %s: %w", f.Destination, err)
+		}
+		if dst == "" {
+			return nil, fmt.Errorf("templated file %s has no destination", src)
+		}
+		if !filepath.IsLocal(dst) {
+			return nil, fmt.Errorf("templated file destination must be a relative path within the archive, got %q", dst)
+		}
+
+		info, err := os.Stat(src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read templated file: %w", err)
+		}
+		bts, err := os.ReadFile(src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read templated file: %w", err)
+		}
+		content, err := template.Apply(string(bts))
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply template %s: %w", src, err)
+		}
+
+		path := filepath.Join(dir, dst)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(content), info.Mode().Perm()); err != nil {
+			return nil, fmt.Errorf("failed to write templated file: %w", err)
+		}
+
+		if err := tmplInfo(template, &f.Info); err != nil {
+			return nil, err
+		}
+		result = append(result, config.File{
+			Source:      filepath.ToSlash(path),
+			Destination: filepath.ToSlash(dst),
+			Info:        f.Info,
+		})
+	}
+	return result, nil
+}
+
 func tmplInfo(template *tmpl.Template, info *config.FileInfo) error {
 	if err := template.ApplyAll(
 		&info.Owner,
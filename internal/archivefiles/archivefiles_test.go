@@ -1,6 +1,9 @@
 package archivefiles
 
 import (
+	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -23,13 +26,35 @@ func TestEval(t *testing.T) {
 	t.Run("invalid glob", func(t *testing.T) {
 		_, err := Eval(tmpl, []config.File{
 			{
-				Source:      "../testdata/**/nope.txt",
+				Source:      "./testdata/[x-",
 				Destination: "var/foobar/d.txt",
 			},
 		})
 		require.Error(t, err)
 	})
 
+	t.Run("strict", func(t *testing.T) {
+		for _, glob := range []string{
+			"./testdata/**/nope.txt",
+			"./testdata/nope.txt",
+		} {
+			files := []config.File{{Source: glob}}
+			result, err := Eval(tmpl, files)
+			require.NoError(t, err)
+			require.Empty(t, result)
+
+			_, err = Eval(tmpl, files, Strict())
+			require.EqualError(t, err, "no files matched the glob "+glob)
+		}
+
+		// default globs are never strict.
+		result, err := Eval(tmpl, []config.File{
+			{Source: "./testdata/**/nope.txt", Default: true},
+		}, Strict())
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
 	t.Run("templated src", func(t *testing.T) {
 		result, err := Eval(tmpl, []config.File{
 			{
@@ -300,6 +325,100 @@ func TestEval(t *testing.T) {
 	})
 }
 
+func TestEvalTemplated(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Env:         []string{"OWNER=carlos"},
+	}, testctx.WithVersion("1.2.3"))
+	tmpl := tmpl.New(ctx)
+
+	src := filepath.Join(t.TempDir(), "install.sh.tpl")
+	require.NoError(t, os.WriteFile(src, []byte("install {{ .ProjectName }} v{{ .Version }}"), 0o755))
+
+	t.Run("render", func(t *testing.T) {
+		dir := t.TempDir()
+		result, err := EvalTemplated(tmpl, []config.TemplatedFile{
+			{
+				Source:      src,
+				Destination: "scripts/install.sh",
+				Info: config.FileInfo{
+					Owner: "{{ .Env.OWNER }}",
+				},
+			},
+		}, dir)
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{
+				Source:      filepath.ToSlash(filepath.Join(dir, "scripts", "install.sh")),
+				Destination: "scripts/install.sh",
+				Info: config.FileInfo{
+					Owner: "carlos",
+				},
+			},
+		}, result)
+
+		bts, err := os.ReadFile(result[0].Source)
+		require.NoError(t, err)
+		require.Equal(t, "install foo v1.2.3", string(bts))
+		if !testlib.IsWindows() {
+			info, err := os.Stat(result[0].Source)
+			require.NoError(t, err)
+			require.Equal(t, fs.FileMode(0o755), info.Mode().Perm())
+		}
+	})
+
+	t.Run("templated src and dst", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "foo.tpl"), []byte("{{ .Version }}"), 0o644))
+		result, err := EvalTemplated(tmpl, []config.TemplatedFile{
+			{
+				Source:      filepath.Join(dir, "{{ .ProjectName }}.tpl"),
+				Destination: "{{ .ProjectName }}.sh",
+			},
+		}, t.TempDir())
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "foo.sh", result[0].Destination)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := EvalTemplated(tmpl, []config.TemplatedFile{
+			{Source: "nope.tpl", Destination: "nope"},
+		}, t.TempDir())
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("no destination", func(t *testing.T) {
+		_, err := EvalTemplated(tmpl, []config.TemplatedFile{
+			{Source: src},
+		}, t.TempDir())
+		require.EqualError(t, err, "templated file "+src+" has no destination")
+	})
+
+	t.Run("destination outside archive", func(t *testing.T) {
+		_, err := EvalTemplated(tmpl, []config.TemplatedFile{
+			{Source: src, Destination: "../install.sh"},
+		}, t.TempDir())
+		require.EqualError(t, err, `templated file destination must be a relative path within the archive, got "../install.sh"`)
+	})
+
+	t.Run("invalid content template", func(t *testing.T) {
+		bad := filepath.Join(t.TempDir(), "bad.tpl")
+		require.NoError(t, os.WriteFile(bad, []byte("{{ .Env.NOPE }}"), 0o644))
+		_, err := EvalTemplated(tmpl, []config.TemplatedFile{
+			{Source: bad, Destination: "bad"},
+		}, t.TempDir())
+		testlib.RequireTemplateError(t, err)
+	})
+
+	t.Run("invalid dst template", func(t *testing.T) {
+		_, err := EvalTemplated(tmpl, []config.TemplatedFile{
+			{Source: src, Destination: "{{ .Nope }}"},
+		}, t.TempDir())
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
 func TestStrlcp(t *testing.T) {
 	for k, v := range map[string][2]string{
 		"/var/":       {"/var/lib/foo", "/var/share/aaa"},
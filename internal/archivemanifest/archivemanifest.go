@@ -0,0 +1,160 @@
+// Package archivemanifest creates, reads, and compares manifests describing
+// the files inside archives.
+package archivemanifest
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// Extension is the extension added to the archive name to form the name of
+// its manifest.
+const Extension = ".manifest.json"
+
+// File is a single file inside an archive.
+type File struct {
+	Path   string      `json:"path"`
+	Size   int64       `json:"size"`
+	Mode   fs.FileMode `json:"mode"`
+	SHA256 string      `json:"sha256"`
+}
+
+// Manifest describes the contents of an archive.
+type Manifest struct {
+	Archive string `json:"archive"`
+	ID      string `json:"id"`
+	Format  string `json:"format"`
+	Target  string `json:"target,omitempty"`
+	Files   []File `json:"files"`
+}
+
+// Key identifies the archive across releases, as its name usually contains
+// the version.
+func (m Manifest) Key() string {
+	return m.ID + "/" + m.Target + "/" + m.Format
+}
+
+// Add adds the given source file to the manifest as dst.
+// Directories are ignored, and mode overrides the source file mode if not
+// zero.
+func (m *Manifest) Add(src, dst string, mode fs.FileMode) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("could not add %s to manifest: %w", src, err)
+	}
+	if info.IsDir() {
+		return nil
+	}
+	sum, err := sha256sum(src)
+	if err != nil {
+		return fmt.Errorf("could not add %s to manifest: %w", src, err)
+	}
+	if mode == 0 {
+		mode = info.Mode().Perm()
+	}
+	m.Files = append(m.Files, File{
+		Path:   dst,
+		Size:   info.Size(),
+		Mode:   mode,
+		SHA256: sum,
+	})
+	return nil
+}
+
+// Write sorts the files and writes the manifest to the given path.
+func (m Manifest) Write(path string) error {
+	slices.SortFunc(m.Files, func(a, b File) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+	bts, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bts, 0o644)
+}
+
+// Read reads the manifest at the given path.
+func Read(path string) (Manifest, error) {
+	var m Manifest
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(bts, &m); err != nil {
+		return m, fmt.Errorf("could not parse manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// ChangeKind is the kind of change a file had between two manifests.
+type ChangeKind string
+
+// Kinds of changes.
+const (
+	Added    ChangeKind = "added"
+	Removed  ChangeKind = "removed"
+	Modified ChangeKind = "modified"
+)
+
+// Change is a file that changed between two manifests.
+type Change struct {
+	Kind ChangeKind
+	Path string
+	Old  *File
+	New  *File
+}
+
+// Diff returns the files that changed between the before and after manifests,
+// sorted by path.
+func Diff(before, after Manifest) []Change {
+	oldFiles := index(before)
+	newFiles := index(after)
+
+	var changes []Change
+	for path, o := range oldFiles {
+		n, ok := newFiles[path]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: Removed, Path: path, Old: o})
+		case *o != *n:
+			changes = append(changes, Change{Kind: Modified, Path: path, Old: o, New: n})
+		}
+	}
+	for path, n := range newFiles {
+		if _, ok := oldFiles[path]; !ok {
+			changes = append(changes, Change{Kind: Added, Path: path, New: n})
+		}
+	}
+	slices.SortFunc(changes, func(a, b Change) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+	return changes
+}
+
+func index(m Manifest) map[string]*File {
+	result := make(map[string]*File, len(m.Files))
+	for i := range m.Files {
+		result[m.Files[i].Path] = &m.Files[i]
+	}
+	return result
+}
+
+func sha256sum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
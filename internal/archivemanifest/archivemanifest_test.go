@@ -0,0 +1,89 @@
+package archivemanifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdd(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(file, []byte("hello"), 0o600))
+
+	var m Manifest
+	require.NoError(t, m.Add(file, "foo/a.txt", 0))
+	require.NoError(t, m.Add(file, "foo/b.txt", 0o755))
+	require.NoError(t, m.Add(dir, "foo", 0))
+	require.Equal(t, []File{
+		{
+			Path:   "foo/a.txt",
+			Size:   5,
+			Mode:   0o600,
+			SHA256: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			Path:   "foo/b.txt",
+			Size:   5,
+			Mode:   0o755,
+			SHA256: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+	}, m.Files)
+
+	require.ErrorIs(t, m.Add(filepath.Join(dir, "nope"), "nope", 0), os.ErrNotExist)
+}
+
+func TestWriteRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foo.tar.gz"+Extension)
+	m := Manifest{
+		Archive: "foo.tar.gz",
+		ID:      "default",
+		Format:  "tar.gz",
+		Target:  "linux_amd64",
+		Files: []File{
+			{Path: "b", Size: 1, Mode: 0o644, SHA256: "b"},
+			{Path: "a", Size: 1, Mode: 0o644, SHA256: "a"},
+		},
+	}
+	require.NoError(t, m.Write(path))
+
+	got, err := Read(path)
+	require.NoError(t, err)
+	require.Equal(t, "default/linux_amd64/tar.gz", got.Key())
+	require.Equal(t, []string{"a", "b"}, []string{got.Files[0].Path, got.Files[1].Path})
+
+	_, err = Read(filepath.Join(t.TempDir(), "nope"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte("nope"), 0o644))
+	_, err = Read(bad)
+	require.ErrorContains(t, err, "could not parse manifest")
+}
+
+func TestDiff(t *testing.T) {
+	before := Manifest{Files: []File{
+		{Path: "bin", Size: 10, Mode: 0o755, SHA256: "1"},
+		{Path: "LICENSE", Size: 5, Mode: 0o644, SHA256: "2"},
+		{Path: "README.md", Size: 5, Mode: 0o644, SHA256: "3"},
+		{Path: "script.sh", Size: 5, Mode: 0o644, SHA256: "4"},
+	}}
+	after := Manifest{Files: []File{
+		{Path: "bin", Size: 12, Mode: 0o755, SHA256: "5"},
+		{Path: "LICENSE", Size: 5, Mode: 0o644, SHA256: "2"},
+		{Path: "CHANGELOG.md", Size: 5, Mode: 0o644, SHA256: "6"},
+		{Path: "script.sh", Size: 5, Mode: 0o755, SHA256: "4"},
+	}}
+
+	changes := Diff(before, after)
+	require.Equal(t, []Change{
+		{Kind: Added, Path: "CHANGELOG.md", New: &after.Files[2]},
+		{Kind: Removed, Path: "README.md", Old: &before.Files[2]},
+		{Kind: Modified, Path: "bin", Old: &before.Files[0], New: &after.Files[0]},
+		{Kind: Modified, Path: "script.sh", Old: &before.Files[3], New: &after.Files[3]},
+	}, changes)
+
+	require.Empty(t, Diff(before, before))
+}
@@ -116,6 +116,41 @@ const (
 	SourceRPM
 	// MSIX is a Windows MSIX package generated by nfpm.
 	MSIX
+	// Jar is a Java archive, built by the jvm builder.
+	Jar
+	// BuildFile is a file generated by a build hook, e.g. shell completions.
+	BuildFile
+	// DebugSymbols are the debug symbols split from a binary.
+	DebugSymbols
+	// ArchiveManifest is a JSON file listing the contents of an archive.
+	ArchiveManifest
+	// DMG is a macOS disk image.
+	DMG
+	// AppBundle is a macOS app bundle, which is a directory.
+	AppBundle
+	// MSI is a Windows installer.
+	MSI
+	// FlatpakManifest is a Flatpak manifest to be published to Flathub.
+	FlatpakManifest
+	// AppImage is a Linux AppImage.
+	AppImage
+	// PackageRepository is a file of an apt, yum, apk, or pacman repository.
+	PackageRepository
+	// BrewBottle is a Homebrew bottle.
+	BrewBottle
+	// AsdfPlugin is an asdf plugin, which is a directory.
+	AsdfPlugin
+	// NPMPackage is a npm package tarball.
+	NPMPackage
+	// OCIArtifact is a file pushed to a registry as an OCI artifact.
+	OCIArtifact
+	// HelmChart is a packaged Helm chart.
+	HelmChart
+	// Provenance is a file with signed SLSA provenance statements.
+	Provenance
+	// LicenseReport is a report of the licenses of the third-party
+	// dependencies.
+	LicenseReport
 
 	// XXX: if it is an uploadable kind of artifact, add it to UploadableTypes
 	// below.
@@ -130,7 +165,7 @@ const (
 // When adding a new artifact type that should be part of a release, add it
 // here and all pipes that use this func will automatically include it.
 //
-// GoReleaser Pro has more formats: MSI, DMG, etc.
+// GoReleaser Pro has more formats: NSIS, etc.
 func ReleaseUploadableTypes() []Type {
 	return []Type{
 		UploadableArchive,
@@ -142,9 +177,19 @@ func ReleaseUploadableTypes() []Type {
 		MSIX,
 		Flatpak,
 		SourceRPM,
+		DMG,
+		MSI,
+		AppImage,
+		BrewBottle,
 		SBOM,
 		PyWheel,
 		PySdist,
+		Jar,
+		DebugSymbols,
+		ArchiveManifest,
+		HelmChart,
+		Provenance,
+		LicenseReport,
 		Checksum,
 		Signature,
 		Certificate,
@@ -154,11 +199,14 @@ func ReleaseUploadableTypes() []Type {
 func (t Type) isUploadable() bool {
 	switch t {
 	case UniversalBinary, Binary, // See: [UploadableBinary].
+		BuildFile,              // Added to archives.
+		AppBundle,              // Directory, added to DMGs.
 		DockerImage,            // See: [PublishableDockerImage].
 		Snapcraft,              // See [PublishableSnapcraft].
 		Metadata,               // Local only.
 		SrcInfo, SourceSrcInfo, // It's always named `.SRCINFO`
-		PkgBuild, SourcePkgBuild: // It's always named `.PKGBUILD`
+		PkgBuild, SourcePkgBuild, // It's always named `.PKGBUILD`
+		AsdfPlugin: // Directory, committed to a repository.
 		return false
 	default:
 		return true
@@ -177,6 +225,14 @@ func (t Type) String() string {
 		return "Linux Package"
 	case MSIX:
 		return "MSIX"
+	case Jar:
+		return "Jar"
+	case BuildFile:
+		return "Build File"
+	case DebugSymbols:
+		return "Debug Symbols"
+	case ArchiveManifest:
+		return "Archive Manifest"
 	case PublishableDockerImage, DockerImageV2:
 		return "Docker Image"
 	case DockerImage:
@@ -231,6 +287,32 @@ func (t Type) String() string {
 		return "Flatpak"
 	case SourceRPM:
 		return "Source RPM"
+	case DMG:
+		return "DMG"
+	case AppBundle:
+		return "App Bundle"
+	case MSI:
+		return "MSI"
+	case FlatpakManifest:
+		return "Flatpak Manifest"
+	case AppImage:
+		return "AppImage"
+	case PackageRepository:
+		return "Package Repository"
+	case BrewBottle:
+		return "Homebrew Bottle"
+	case AsdfPlugin:
+		return "asdf Plugin"
+	case NPMPackage:
+		return "NPM Package"
+	case OCIArtifact:
+		return "OCI Artifact"
+	case HelmChart:
+		return "Helm Chart"
+	case Provenance:
+		return "Provenance"
+	case LicenseReport:
+		return "License Report"
 	default:
 		return "unknown"
 	}
@@ -240,21 +322,25 @@ func (t Type) String() string {
 // If you add or change these, please update the documentation at
 // www/content/customization/general/artifacts.md as well.
 const (
-	ExtraID         = "ID"
-	ExtraBinary     = "Binary"
-	ExtraExt        = "Ext" // should always have the preceding '.'
-	ExtraFormat     = "Format"
-	ExtraWrappedIn  = "WrappedIn"
-	ExtraBinaries   = "Binaries"
-	ExtraFiles      = "Files"
-	ExtraRefresh    = "Refresh"
-	ExtraReplaces   = "Replaces"
-	ExtraDigest     = "Digest"
-	ExtraSize       = "Size"
-	ExtraChecksum   = "Checksum"
-	ExtraChecksumOf = "ChecksumOf"
-	ExtraBuilder    = "Builder"
-	ExtranDynLink   = "DynamicallyLinked"
+	ExtraID          = "ID"
+	ExtraBinary      = "Binary"
+	ExtraExt         = "Ext" // should always have the preceding '.'
+	ExtraFormat      = "Format"
+	ExtraWrappedIn   = "WrappedIn"
+	ExtraBinaries    = "Binaries"
+	ExtraFiles       = "Files"
+	ExtraRefresh     = "Refresh"
+	ExtraReplaces    = "Replaces"
+	ExtraDigest      = "Digest"
+	ExtraSize        = "Size"
+	ExtraChecksum    = "Checksum"
+	ExtraChecksumOf  = "ChecksumOf"
+	ExtraBuilder     = "Builder"
+	ExtranDynLink    = "DynamicallyLinked"
+	ExtraJvmGroup    = "JvmGroup"
+	ExtraJvmArtifact = "JvmArtifact"
+	ExtraJvmVersion  = "JvmVersion"
+	ExtraWasm        = "Wasm"
 )
 
 // Extras represents the extra fields in an artifact.
@@ -358,9 +444,22 @@ func ExtraOr[T any](a Artifact, key string, or T) T {
 }
 
 // Checksum calculates the checksum of the artifact and sets it's Extra field.
+func (a *Artifact) Checksum(algorithm string) (string, error) {
+	check, err := a.Sum(algorithm)
+	if err != nil {
+		return "", err
+	}
+	if a.Extra == nil {
+		a.Extra = make(Extras)
+	}
+	a.Extra[ExtraChecksum] = fmt.Sprintf("%s:%s", algorithm, check)
+	return check, nil
+}
+
+// Sum calculates the checksum of the artifact, without changing it.
 //
 //nolint:gosec
-func (a *Artifact) Checksum(algorithm string) (string, error) {
+func (a Artifact) Sum(algorithm string) (string, error) {
 	log.Debugf("calculating checksum for %s", a.Path)
 	file, err := os.Open(a.Path)
 	if err != nil {
@@ -410,12 +509,7 @@ func (a *Artifact) Checksum(algorithm string) (string, error) {
 	if _, err := io.Copy(h, file); err != nil {
 		return "", fmt.Errorf("failed to checksum: %w", err)
 	}
-	check := hex.EncodeToString(h.Sum(nil))
-	if a.Extra == nil {
-		a.Extra = make(Extras)
-	}
-	a.Extra[ExtraChecksum] = fmt.Sprintf("%s:%s", algorithm, check)
-	return check, nil
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 var noRefresh = func() error { return nil }
@@ -1093,6 +1093,9 @@ func TestArtifactTypeIsUploadable(t *testing.T) {
 		UniversalBinary,
 		DockerImage,
 		Snapcraft,
+		BuildFile,
+		AppBundle,
+		AsdfPlugin,
 	}
 	for i := range lastMarker - 1 {
 		up := i.isUploadable()
@@ -1117,9 +1120,19 @@ func TestReleaseUploadableTypes(t *testing.T) {
 		MSIX,
 		Flatpak,
 		SourceRPM,
+		DMG,
+		MSI,
+		AppImage,
+		BrewBottle,
 		SBOM,
 		PyWheel,
 		PySdist,
+		Jar,
+		DebugSymbols,
+		ArchiveManifest,
+		HelmChart,
+		Provenance,
+		LicenseReport,
 		Checksum,
 		Signature,
 		Certificate,
@@ -2,7 +2,7 @@
 package base
 
 import (
-	"context"
+	stdctx "context"
 	"errors"
 	"fmt"
 	"os/exec"
@@ -14,16 +14,23 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/gio"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
 type options struct {
 	allowBuildMode bool
+	allowLdflags   bool
+	allowTags      bool
 }
 
 type ValidateOption func(o *options)
 
 func WithBuildMode(o *options) { o.allowBuildMode = true }
 
+func WithLdflags(o *options) { o.allowLdflags = true }
+
+func WithTags(o *options) { o.allowTags = true }
+
 // ValidateNonGoConfig makes sure that Go-specific configurations are not set.
 func ValidateNonGoConfig(build config.Build, opts ...ValidateOption) error {
 	var o options
@@ -31,7 +38,7 @@ func ValidateNonGoConfig(build config.Build, opts ...ValidateOption) error {
 		v(&o)
 	}
 
-	if len(build.Ldflags) > 0 {
+	if len(build.Ldflags) > 0 && !o.allowLdflags {
 		return errors.New("ldflags is not used for " + build.Builder)
 	}
 
@@ -57,7 +64,7 @@ func ValidateNonGoConfig(build config.Build, opts ...ValidateOption) error {
 		return errors.New("buildmode is not used for " + build.Builder)
 	}
 
-	if len(build.Tags) > 0 {
+	if len(build.Tags) > 0 && !o.allowTags {
 		return errors.New("tags is not used for " + build.Builder)
 	}
 
@@ -65,13 +72,59 @@ func ValidateNonGoConfig(build config.Build, opts ...ValidateOption) error {
 		return errors.New("asmflags is not used for " + build.Builder)
 	}
 
+	if build.Pgo != "" {
+		return errors.New("pgo is not used for " + build.Builder)
+	}
+
 	if len(build.BuildDetailsOverrides) > 0 {
 		return errors.New("overrides is not used for " + build.Builder)
 	}
 
+	if len(build.Toolchains) > 0 {
+		return errors.New("toolchains is not used for " + build.Builder)
+	}
+
 	return nil
 }
 
+// TargetsFromGo sets the targets of the build to all the combinations of its
+// goos and goarch, and clears them.
+//
+// goos defaults to linux, darwin and windows, and goarch to amd64 and arm64.
+// The target function returns the target for a goos and goarch, and whether it
+// is valid.
+func TargetsFromGo(name string, build config.Build, target func(goos, goarch string) (string, bool)) (config.Build, error) {
+	if len(build.Goos) == 0 && len(build.Goarch) == 0 {
+		return build, nil
+	}
+	if len(build.Targets) > 0 {
+		return build, fmt.Errorf("set either targets or goos/goarch for %s, not both", name)
+	}
+
+	goos, goarch := build.Goos, build.Goarch
+	if len(goos) == 0 {
+		goos = []string{"linux", "darwin", "windows"}
+	}
+	if len(goarch) == 0 {
+		goarch = []string{"amd64", "arm64"}
+	}
+	var targets []string
+	for _, goos := range goos {
+		for _, goarch := range goarch {
+			t, ok := target(goos, goarch)
+			if !ok {
+				return build, fmt.Errorf("no %s target for %s/%s", name, goos, goarch)
+			}
+			targets = append(targets, t)
+		}
+	}
+
+	build.Targets = targets
+	build.Goos = nil
+	build.Goarch = nil
+	return build, nil
+}
+
 // ChTimes sets the mod time for the artifact path, if a mod timestamp is set
 // in the build.
 func ChTimes(build config.Build, tpl *tmpl.Template, a *artifact.Artifact) error {
@@ -106,11 +159,14 @@ func TemplateEnv(input []string, tpl *tmpl.Template) ([]string, error) {
 
 // Exec executes the given command with the given env in the given dir,
 // handling output and errors.
-func Exec(ctx context.Context, command []string, env []string, dir string) error {
+func Exec(ctx stdctx.Context, command []string, env []string, dir string) error {
 	/* #nosec */
 	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
 	cmd.Env = env
 	cmd.Dir = dir
+	if ctx, ok := ctx.(*context.Context); ok {
+		ctx.InToto().Record(command)
+	}
 	log.WithField("cmd", command[0]).Debug("executing")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
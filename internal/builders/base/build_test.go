@@ -54,6 +54,9 @@ func TestValidateNonGoConfig(t *testing.T) {
 		"overrides": {
 			BuildDetailsOverrides: []config.BuildDetailsOverride{{}},
 		},
+		"toolchains": {
+			Toolchains: []config.BuildToolchain{{}},
+		},
 		"buildmode": {
 			BuildDetails: config.BuildDetails{
 				Buildmode: "a",
@@ -69,12 +72,76 @@ func TestValidateNonGoConfig(t *testing.T) {
 				Asmflags: []string{"a"},
 			},
 		},
+		"pgo": {
+			BuildDetails: config.BuildDetails{
+				Pgo: "default.pgo",
+			},
+		},
 	}
 	for k, v := range cases {
 		t.Run(k, func(t *testing.T) {
 			require.Error(t, ValidateNonGoConfig(v))
 		})
 	}
+
+	t.Run("allowed", func(t *testing.T) {
+		require.NoError(t, ValidateNonGoConfig(config.Build{
+			BuildDetails: config.BuildDetails{
+				Buildmode: "a",
+				Ldflags:   []string{"-a"},
+				Tags:      []string{"a"},
+			},
+		}, WithBuildMode, WithLdflags, WithTags))
+	})
+}
+
+func TestTargetsFromGo(t *testing.T) {
+	target := func(goos, goarch string) (string, bool) {
+		return goarch + "-" + goos, goarch != "386"
+	}
+
+	t.Run("no goos nor goarch", func(t *testing.T) {
+		build, err := TargetsFromGo("foo", config.Build{Targets: []string{"a"}}, target)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, build.Targets)
+	})
+
+	t.Run("goos and goarch", func(t *testing.T) {
+		build, err := TargetsFromGo("foo", config.Build{
+			Goos:   []string{"linux", "darwin"},
+			Goarch: []string{"amd64", "arm64"},
+		}, target)
+		require.NoError(t, err)
+		require.Equal(t, []string{"amd64-linux", "arm64-linux", "amd64-darwin", "arm64-darwin"}, build.Targets)
+		require.Empty(t, build.Goos)
+		require.Empty(t, build.Goarch)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		build, err := TargetsFromGo("foo", config.Build{Goarch: []string{"amd64"}}, target)
+		require.NoError(t, err)
+		require.Equal(t, []string{"amd64-linux", "amd64-darwin", "amd64-windows"}, build.Targets)
+
+		build, err = TargetsFromGo("foo", config.Build{Goos: []string{"linux"}}, target)
+		require.NoError(t, err)
+		require.Equal(t, []string{"amd64-linux", "arm64-linux"}, build.Targets)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := TargetsFromGo("foo", config.Build{
+			Goos:   []string{"linux"},
+			Goarch: []string{"386"},
+		}, target)
+		require.EqualError(t, err, "no foo target for linux/386")
+	})
+
+	t.Run("targets too", func(t *testing.T) {
+		_, err := TargetsFromGo("foo", config.Build{
+			Goos:    []string{"linux"},
+			Targets: []string{"a"},
+		}, target)
+		require.EqualError(t, err, "set either targets or goos/goarch for foo, not both")
+	})
 }
 
 func TestChTimes(t *testing.T) {
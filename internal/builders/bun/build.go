@@ -53,6 +53,11 @@ func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
 		log.Warn("you are using the experimental Bun builder")
 	})
 
+	build, err := base.TargetsFromGo("bun", build, targetFromGo)
+	if err != nil {
+		return build, err
+	}
+
 	if len(build.Targets) == 0 {
 		build.Targets = defaultTargets()
 	}
@@ -116,6 +116,38 @@ func TestWithDefaults(t *testing.T) {
 		})
 		require.ErrorContains(t, err, "invalid target")
 	})
+
+	t.Run("goos and goarch", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"linux", "darwin"},
+			Goarch: []string{"amd64", "arm64"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"linux-x64",
+			"linux-arm64",
+			"darwin-x64",
+			"darwin-arm64",
+		}, build.Targets)
+		require.Empty(t, build.Goos)
+		require.Empty(t, build.Goarch)
+	})
+
+	t.Run("invalid goos and goarch", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"windows"},
+			Goarch: []string{"arm64"},
+		})
+		require.ErrorContains(t, err, "no bun target for windows/arm64")
+	})
+
+	t.Run("goos and targets", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos:    []string{"linux"},
+			Targets: defaultTargets(),
+		})
+		require.ErrorContains(t, err, "not both")
+	})
 }
 
 func TestBuild(t *testing.T) {
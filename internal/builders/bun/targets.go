@@ -82,6 +82,16 @@ func convertToGoarch(s string) string {
 	}
 }
 
+// targetFromGo returns the bun target for the given goos and goarch, and
+// whether it is valid.
+func targetFromGo(goos, goarch string) (string, bool) {
+	if goarch == "amd64" {
+		goarch = "x64"
+	}
+	target := goos + "-" + goarch
+	return target, isValid(target)
+}
+
 func isValid(target string) bool {
 	targetsOnce.Do(func() {
 		allTargets = slices.DeleteFunc(strings.Split(string(allTargetsBts), "\n"), func(s string) bool {
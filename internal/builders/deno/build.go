@@ -48,6 +48,11 @@ func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
 		log.Warn("you are using the experimental Deno builder")
 	})
 
+	build, err := base.TargetsFromGo("deno", build, targetFromGo)
+	if err != nil {
+		return build, err
+	}
+
 	if len(build.Targets) == 0 {
 		build.Targets = defaultTargets()
 	}
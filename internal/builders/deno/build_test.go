@@ -75,6 +75,38 @@ func TestWithDefaults(t *testing.T) {
 		})
 		require.ErrorContains(t, err, "invalid target")
 	})
+
+	t.Run("goos and goarch", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"linux", "darwin"},
+			Goarch: []string{"amd64", "arm64"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"x86_64-unknown-linux-gnu",
+			"aarch64-unknown-linux-gnu",
+			"x86_64-apple-darwin",
+			"aarch64-apple-darwin",
+		}, build.Targets)
+		require.Empty(t, build.Goos)
+		require.Empty(t, build.Goarch)
+	})
+
+	t.Run("invalid goos and goarch", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"windows"},
+			Goarch: []string{"arm64"},
+		})
+		require.ErrorContains(t, err, "no deno target for windows/arm64")
+	})
+
+	t.Run("goos and targets", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos:    []string{"linux"},
+			Targets: defaultTargets(),
+		})
+		require.ErrorContains(t, err, "not both")
+	})
 }
 
 func TestBuild(t *testing.T) {
@@ -77,6 +77,20 @@ func convertToGoarch(s string) string {
 	return s
 }
 
+// targetFromGo returns the deno target for the given goos and goarch, and
+// whether it is valid.
+func targetFromGo(goos, goarch string) (string, bool) {
+	target := map[string]string{
+		"amd64": "x86_64",
+		"arm64": "aarch64",
+	}[goarch] + "-" + map[string]string{
+		"linux":   "unknown-linux-gnu",
+		"darwin":  "apple-darwin",
+		"windows": "pc-windows-msvc",
+	}[goos]
+	return target, isValid(target)
+}
+
 func isValid(target string) bool {
 	targetsOnce.Do(func() {
 		allTargets = slices.DeleteFunc(strings.Split(string(allTargetsBts), "\n"), func(s string) bool {
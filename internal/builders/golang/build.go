@@ -191,6 +191,12 @@ func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
 			break
 		}
 	}
+
+	for _, tc := range build.Toolchains {
+		if tc.CC == "" && tc.CXX == "" {
+			return build, errors.New("toolchains must set cc, cxx, or both")
+		}
+	}
 	return build, nil
 }
 
@@ -308,6 +314,13 @@ func (*Builder) Build(ctx *context.Context, build config.Build, options api.Opti
 		WithEnvS(env).
 		WithArtifact(allbinaries[0])
 
+	// the toolchain env goes first, so the build env can override it.
+	tcenv, err := toolchainEnv(build, t, tpl)
+	if err != nil {
+		return err
+	}
+	env = append(env, tcenv...)
+
 	tenv, err := base.TemplateEnv(details.Env, tpl)
 	if err != nil {
 		return err
@@ -329,6 +342,11 @@ func (*Builder) Build(ctx *context.Context, build config.Build, options api.Opti
 		}
 	}
 
+	details.Pgo, err = pgoProfile(ctx, details.Pgo, tpl)
+	if err != nil {
+		return err
+	}
+
 	cmd, err := buildGoBuildLine(ctx, build, details, options, allbinaries[0], mains, env)
 	if err != nil {
 		return err
@@ -351,35 +369,68 @@ func (*Builder) Build(ctx *context.Context, build config.Build, options api.Opti
 }
 
 func withOverrides(ctx *context.Context, build config.Build, target Target) (config.BuildDetails, error) {
-	optsTarget := target.Target
+	o, ok, err := FindOverride(ctx, build, target.Target)
+	if err != nil || !ok {
+		return build.BuildDetails, err
+	}
+
+	dets := config.BuildDetails{
+		Buildmode: build.Buildmode,
+		Ldflags:   build.Ldflags,
+		Tags:      build.Tags,
+		Flags:     build.Flags,
+		Asmflags:  build.Asmflags,
+		Gcflags:   build.Gcflags,
+		Pgo:       build.Pgo,
+	}
+	if err := mergo.Merge(&dets, o.BuildDetails, mergo.WithOverride); err != nil {
+		return build.BuildDetails, err
+	}
+
+	dets.Env = context.ToEnv(append(build.Env, o.BuildDetails.Env...)).Strings()
+	log.WithField("details", dets).Infof("overridden build details for %s", target.Target)
+	return dets, nil
+}
+
+// toolchainEnv returns the environment of the first toolchain matching the
+// given target.
+func toolchainEnv(build config.Build, t Target, tpl *tmpl.Template) ([]string, error) {
+	for _, tc := range build.Toolchains {
+		if (tc.Goos != "" && tc.Goos != t.Goos) || (tc.Goarch != "" && tc.Goarch != t.Goarch) {
+			continue
+		}
+		cc, cxx := tc.CC, tc.CXX
+		if err := tpl.ApplyAll(&cc, &cxx); err != nil {
+			return nil, err
+		}
+		env := []string{"CGO_ENABLED=1"}
+		if cc != "" {
+			env = append(env, "CC="+cc)
+		}
+		if cxx != "" {
+			env = append(env, "CXX="+cxx)
+		}
+		return env, nil
+	}
+	return nil, nil
+}
+
+// FindOverride returns the first override of the given build that matches the
+// given target.
+func FindOverride(ctx *context.Context, build config.Build, target string) (config.BuildDetailsOverride, bool, error) {
 	for _, o := range build.BuildDetailsOverrides {
 		overrideTarget, err := tmpl.New(ctx).Apply(formatBuildTarget(o))
 		if err != nil {
-			return build.BuildDetails, err
+			return config.BuildDetailsOverride{}, false, err
 		}
 		overrideTarget = fixTarget(overrideTarget)
 
-		if optsTarget == overrideTarget {
-			dets := config.BuildDetails{
-				Buildmode: build.Buildmode,
-				Ldflags:   build.Ldflags,
-				Tags:      build.Tags,
-				Flags:     build.Flags,
-				Asmflags:  build.Asmflags,
-				Gcflags:   build.Gcflags,
-			}
-			if err := mergo.Merge(&dets, o.BuildDetails, mergo.WithOverride); err != nil {
-				return build.BuildDetails, err
-			}
-
-			dets.Env = context.ToEnv(append(build.Env, o.BuildDetails.Env...)).Strings()
-			log.WithField("details", dets).Infof("overridden build details for %s", optsTarget)
-			return dets, nil
+		if target == overrideTarget {
+			return o, true, nil
 		}
-		log.Debugf("targets don't match: %s != %s", optsTarget, overrideTarget)
+		log.Debugf("targets don't match: %s != %s", target, overrideTarget)
 	}
-
-	return build.BuildDetails, nil
+	return config.BuildDetailsOverride{}, false, nil
 }
 
 func buildGoBuildLine(
@@ -446,6 +497,10 @@ func buildGoBuildLine(
 		cmd = append(cmd, "-buildmode="+details.Buildmode)
 	}
 
+	if details.Pgo != "" {
+		cmd = append(cmd, "-pgo="+details.Pgo)
+	}
+
 	if mains == nil {
 		// NOTE: build.Main will never be empty here
 		cmd = append(cmd, "-o", options.Path, build.Main)
@@ -467,6 +522,9 @@ func validateUniqueFlags(details config.BuildDetails) {
 		if strings.HasPrefix(flag, "-buildmode") && details.Buildmode != "" {
 			log.WithField("flag", flag).WithField("buildmode", details.Buildmode).Warn("buildmode is defined twice")
 		}
+		if strings.HasPrefix(flag, "-pgo") && details.Pgo != "" {
+			log.WithField("flag", flag).WithField("pgo", details.Pgo).Warn("pgo is defined twice")
+		}
 	}
 }
 
@@ -557,6 +615,9 @@ func getBinaryArtifact(
 	if t.Abi != "" {
 		a.Extra[keyAbi] = t.Abi
 	}
+	if t.Goarch == "wasm" {
+		a.Extra[artifact.ExtraWasm] = true
+	}
 	return a
 }
 
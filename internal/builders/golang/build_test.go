@@ -803,6 +803,7 @@ func TestBuild(t *testing.T) {
 				artifact.ExtraID:      "foo",
 				artifact.ExtraBuilder: "go",
 				"testEnvs":            []string{"TEST_T="},
+				artifact.ExtraWasm:    true,
 			},
 		},
 	}
@@ -952,6 +953,7 @@ func TestBuildVariadic(t *testing.T) {
 				artifact.ExtraExt:     ".wasm",
 				artifact.ExtraBinary:  "a",
 				artifact.ExtraBuilder: "go",
+				artifact.ExtraWasm:    true,
 			},
 		},
 		{
@@ -966,6 +968,7 @@ func TestBuildVariadic(t *testing.T) {
 				artifact.ExtraExt:     ".wasm",
 				artifact.ExtraBinary:  "foo",
 				artifact.ExtraBuilder: "go",
+				artifact.ExtraWasm:    true,
 			},
 		},
 	}
@@ -1557,6 +1560,39 @@ func TestBuildGoBuildLine(t *testing.T) {
 		}, strings.Fields("go build -o foo ."))
 	})
 
+	t.Run("pgo", func(t *testing.T) {
+		requireEqualCmd(t, config.Build{
+			Main:    ".",
+			Tool:    "go",
+			Command: "build",
+			Binary:  "foo",
+			BuildDetails: config.BuildDetails{
+				Pgo: "default.pgo",
+			},
+		}, strings.Fields("go build -pgo=default.pgo -o foo ."))
+	})
+
+	t.Run("pgo with overrides", func(t *testing.T) {
+		requireEqualCmd(t, config.Build{
+			Main:    ".",
+			Tool:    "go",
+			Command: "build",
+			Binary:  "foo",
+			BuildDetails: config.BuildDetails{
+				Pgo: "default.pgo",
+			},
+			BuildDetailsOverrides: []config.BuildDetailsOverride{
+				{
+					Goos:   "linux",
+					Goarch: "amd64",
+					BuildDetails: config.BuildDetails{
+						Pgo: "linux.pgo",
+					},
+				},
+			},
+		}, strings.Fields("go build -pgo=linux.pgo -o foo ."))
+	})
+
 	t.Run("test", func(t *testing.T) {
 		requireEqualCmd(t, config.Build{
 			Main:    ".",
@@ -2139,6 +2175,49 @@ func TestOverrides(t *testing.T) {
 	})
 }
 
+func TestToolchainEnv(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	build := config.Build{
+		Toolchains: []config.BuildToolchain{
+			{Goos: "darwin", CC: "o64-clang", CXX: "o64-clang++"},
+			{Goos: "linux", Goarch: "arm64", CC: "zig cc -target {{ .Env.ZIG_ARCH }}-linux-musl"},
+			{Goos: "linux", CC: "gcc"},
+		},
+	}
+	tpl := tmpl.New(ctx).WithEnvS([]string{"ZIG_ARCH=aarch64"})
+
+	for target, expected := range map[string][]string{
+		"darwin_arm64_v8.0": {"CGO_ENABLED=1", "CC=o64-clang", "CXX=o64-clang++"},
+		"linux_arm64_v8.0":  {"CGO_ENABLED=1", "CC=zig cc -target aarch64-linux-musl"},
+		"linux_amd64_v1":    {"CGO_ENABLED=1", "CC=gcc"},
+		"windows_amd64_v1":  nil,
+	} {
+		t.Run(target, func(t *testing.T) {
+			tt, err := Default.Parse(target)
+			require.NoError(t, err)
+			env, err := toolchainEnv(build, tt.(Target), tpl)
+			require.NoError(t, err)
+			require.Equal(t, expected, env)
+		})
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		tt, err := Default.Parse("linux_amd64")
+		require.NoError(t, err)
+		_, err = toolchainEnv(config.Build{
+			Toolchains: []config.BuildToolchain{{CC: "{{ .Nope }}"}},
+		}, tt.(Target), tpl)
+		testlib.RequireTemplateError(t, err)
+	})
+
+	t.Run("no cc or cxx", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Toolchains: []config.BuildToolchain{{Goos: "linux"}},
+		})
+		require.Error(t, err)
+	})
+}
+
 func TestWarnIfTargetsAndOtherOptionsTogether(t *testing.T) {
 	nonEmpty := []string{"foo", "bar"}
 	for name, fn := range map[string]func(*config.Build){
@@ -0,0 +1,79 @@
+package golang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// pgoDownloads has the download of each remote profile, by its path, so it is
+// only downloaded once, no matter how many targets use it.
+var pgoDownloads sync.Map
+
+// pgoProfile evaluates the given pgo option, downloading the profile if it is
+// an URL.
+// It returns the value to be used in the -pgo flag.
+func pgoProfile(ctx *context.Context, pgo string, tpl *tmpl.Template) (string, error) {
+	pgo, err := tpl.Apply(pgo)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(pgo, "http://") && !strings.HasPrefix(pgo, "https://") {
+		return pgo, nil
+	}
+
+	sum := sha256.Sum256([]byte(pgo))
+	path, err := filepath.Abs(filepath.Join(ctx.Config.Dist, "pgo", hex.EncodeToString(sum[:8])+".pprof"))
+	if err != nil {
+		return "", err
+	}
+	dl, _ := pgoDownloads.LoadOrStore(path, sync.OnceValue(func() error {
+		log.WithField("url", pgo).Info("downloading pgo profile")
+		return download(ctx, pgo, path)
+	}))
+	if err := dl.(func() error)(); err != nil {
+		return "", fmt.Errorf("could not download pgo profile: %w", err)
+	}
+	return path, nil
+}
+
+func download(ctx *context.Context, url, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return retryx.HTTP(err, resp)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return retryx.HTTP(fmt.Errorf("unexpected status: %s", resp.Status), resp)
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		defer f.Close()
+		if _, err := io.Copy(f, resp.Body); err != nil {
+			return retryx.Retriable(err)
+		}
+		return f.Close()
+	}, retryx.IsRetriable)
+}
@@ -0,0 +1,113 @@
+package golang
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPgoProfile(t *testing.T) {
+	target := mustParse(t, "linux_amd64")
+
+	t.Run("empty", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		pgo, err := pgoProfile(ctx, "", tmpl.New(ctx))
+		require.NoError(t, err)
+		require.Empty(t, pgo)
+	})
+
+	t.Run("path", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		tpl := tmpl.New(ctx).WithBuildOptions(api.Options{Target: target})
+		pgo, err := pgoProfile(ctx, "profiles/{{ .Os }}_{{ .Arch }}.pgo", tpl)
+		require.NoError(t, err)
+		require.Equal(t, "profiles/linux_amd64.pgo", pgo)
+	})
+
+	t.Run("auto", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		pgo, err := pgoProfile(ctx, "auto", tmpl.New(ctx))
+		require.NoError(t, err)
+		require.Equal(t, "auto", pgo)
+	})
+
+	t.Run("url", func(t *testing.T) {
+		var hits atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/profiles/linux.pgo", r.URL.Path)
+			hits.Add(1)
+			_, _ = w.Write([]byte("fake profile"))
+		}))
+		t.Cleanup(srv.Close)
+
+		dist := filepath.Join(testlib.Mktmp(t), "dist")
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Dist: dist,
+		}, testctx.WithEnv(map[string]string{"PGO_URL": srv.URL}))
+
+		var paths []string
+		for range 3 {
+			pgo, err := pgoProfile(ctx, "{{ .Env.PGO_URL }}/profiles/linux.pgo", tmpl.New(ctx))
+			require.NoError(t, err)
+			paths = append(paths, pgo)
+		}
+		require.Equal(t, int32(1), hits.Load())
+		require.Equal(t, paths[0], paths[1])
+		require.Equal(t, paths[0], paths[2])
+		require.Equal(t, filepath.Join(dist, "pgo"), filepath.Dir(paths[0]))
+		bts, err := os.ReadFile(paths[0])
+		require.NoError(t, err)
+		require.Equal(t, "fake profile", string(bts))
+	})
+
+	t.Run("url retry", func(t *testing.T) {
+		var hits atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if hits.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte("fake profile"))
+		}))
+		t.Cleanup(srv.Close)
+
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Dist:  filepath.Join(testlib.Mktmp(t), "dist"),
+			Retry: config.Retry{Attempts: 2},
+		})
+		pgo, err := pgoProfile(ctx, srv.URL+"/default.pgo", tmpl.New(ctx))
+		require.NoError(t, err)
+		require.Equal(t, int32(2), hits.Load())
+		bts, err := os.ReadFile(pgo)
+		require.NoError(t, err)
+		require.Equal(t, "fake profile", string(bts))
+	})
+
+	t.Run("url not found", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		t.Cleanup(srv.Close)
+
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Dist: filepath.Join(testlib.Mktmp(t), "dist"),
+		})
+		_, err := pgoProfile(ctx, srv.URL+"/default.pgo", tmpl.New(ctx))
+		require.ErrorContains(t, err, "could not download pgo profile")
+		require.ErrorContains(t, err, "404")
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		_, err := pgoProfile(ctx, "{{ .Nope }}", tmpl.New(ctx))
+		testlib.RequireTemplateError(t, err)
+	})
+}
@@ -0,0 +1,299 @@
+// Package jvm builds JVM projects using Gradle or Maven.
+package jvm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/builders/base"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Default builder instance.
+//
+//nolint:gochecknoglobals
+var Default = &Builder{}
+
+// type constraints
+var (
+	_ api.Builder          = &Builder{}
+	_ api.DependingBuilder = &Builder{}
+
+	errNoProject = errors.New("jvm: could not find a gradle or maven project")
+	errTargets   = errors.New("jvm: only target supported for jars is 'all'")
+)
+
+const (
+	jarTarget = "all"
+
+	buildmodeJar    = "jar"
+	buildmodeNative = "native"
+)
+
+//nolint:gochecknoinits
+func init() {
+	api.Register("jvm", Default)
+}
+
+// Builder is the JVM builder.
+type Builder struct{}
+
+// Dependencies implements build.DependingBuilder.
+func (b *Builder) Dependencies() []string {
+	return []string{"java"}
+}
+
+// Target is a JVM build target.
+type Target struct {
+	Target string
+	Os     string
+	Arch   string
+}
+
+// Fields implements build.Target.
+func (t Target) Fields() map[string]string {
+	return map[string]string{
+		tmpl.KeyOS:   t.Os,
+		tmpl.KeyArch: t.Arch,
+	}
+}
+
+// String implements fmt.Stringer.
+func (t Target) String() string {
+	return t.Target
+}
+
+// Parse implements build.Builder.
+func (b *Builder) Parse(target string) (api.Target, error) {
+	if target == jarTarget {
+		return Target{
+			Target: target,
+			Os:     "all",
+			Arch:   "all",
+		}, nil
+	}
+	goos, goarch, ok := strings.Cut(target, "_")
+	if !ok || goos == "" || goarch == "" {
+		return nil, fmt.Errorf("%s is not a valid build target", target)
+	}
+	return Target{
+		Target: target,
+		Os:     goos,
+		Arch:   goarch,
+	}, nil
+}
+
+var once sync.Once
+
+// WithDefaults implements build.Builder.
+func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
+	once.Do(func() {
+		log.Warn("you are using the experimental JVM builder")
+	})
+
+	if build.Dir == "" {
+		build.Dir = "."
+	}
+
+	if build.Tool == "" {
+		tool, err := detectTool(build.Dir)
+		if err != nil {
+			return build, err
+		}
+		build.Tool = tool
+	}
+
+	if build.Main != "" {
+		return build, errors.New("main is not used for jvm")
+	}
+
+	if err := base.ValidateNonGoConfig(build, base.WithBuildMode); err != nil {
+		return build, err
+	}
+
+	switch build.Buildmode {
+	case "", buildmodeJar:
+		build.Buildmode = buildmodeJar
+		if len(build.Targets) == 0 {
+			build.Targets = []string{jarTarget}
+		}
+		if len(build.Targets) > 1 || build.Targets[0] != jarTarget {
+			return build, fmt.Errorf("%w: %s", errTargets, strings.Join(build.Targets, ","))
+		}
+	case buildmodeNative:
+		if len(build.Targets) == 0 {
+			build.Targets = []string{runtime.GOOS + "_" + runtime.GOARCH}
+		}
+		if len(build.Flags) == 0 && isMaven(build.Tool) {
+			build.Flags = []string{"-Pnative"}
+		}
+	default:
+		return build, fmt.Errorf("jvm: invalid buildmode %q", build.Buildmode)
+	}
+
+	if build.Command == "" {
+		build.Command = defaultCommand(build.Tool, build.Buildmode)
+	}
+
+	return build, nil
+}
+
+// Build implements build.Builder.
+func (b *Builder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	t := options.Target.(Target)
+
+	coords, err := readCoordinates(build.Tool, build.Dir)
+	if err != nil {
+		return err
+	}
+
+	a := &artifact.Artifact{
+		Type:   artifact.Binary,
+		Path:   options.Path,
+		Name:   options.Name,
+		Goos:   t.Os,
+		Goarch: t.Arch,
+		Target: t.Target,
+		Extra: map[string]any{
+			artifact.ExtraBinary:      strings.TrimSuffix(filepath.Base(options.Path), options.Ext),
+			artifact.ExtraExt:         options.Ext,
+			artifact.ExtraID:          build.ID,
+			artifact.ExtraBuilder:     "jvm",
+			artifact.ExtraJvmGroup:    coords.Group,
+			artifact.ExtraJvmArtifact: coords.Artifact,
+			artifact.ExtraJvmVersion:  coords.Version,
+		},
+	}
+
+	var output string
+	switch build.Buildmode {
+	case buildmodeNative:
+		if t.Os != ctx.Runtime.Goos || t.Arch != ctx.Runtime.Goarch {
+			return fmt.Errorf(
+				"jvm: native images can't be cross-compiled: can't build %s on %s/%s",
+				t, ctx.Runtime.Goos, ctx.Runtime.Goarch,
+			)
+		}
+		output = nativeOutput(build, coords, t)
+	default:
+		name := coords.Artifact + "-" + coords.Version + ".jar"
+		a.Type = artifact.Jar
+		a.Name = name
+		a.Path = filepath.Join(filepath.Dir(options.Path), name)
+		a.Extra[artifact.ExtraBinary] = coords.Artifact
+		a.Extra[artifact.ExtraExt] = ".jar"
+		output = jarOutput(build, name)
+	}
+
+	env := []string{}
+	env = append(env, ctx.Env.Strings()...)
+
+	tpl := tmpl.New(ctx).
+		WithBuildOptions(options).
+		WithEnvS(env).
+		WithArtifact(a)
+
+	tool, err := tpl.Apply(build.Tool)
+	if err != nil {
+		return err
+	}
+	command := []string{tool, build.Command}
+
+	tenv, err := base.TemplateEnv(build.Env, tpl)
+	if err != nil {
+		return err
+	}
+	env = append(env, tenv...)
+
+	flags, err := tpl.Slice(build.Flags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	command = append(command, flags...)
+
+	log.WithField("name", a.Name).
+		WithField("target", t.String()).
+		Info("building")
+	if err := base.Exec(ctx, command, env, build.Dir); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(output); err != nil {
+		return fmt.Errorf("jvm: could not find build output: %w", err)
+	}
+	if err := gio.Copy(output, a.Path); err != nil {
+		return err
+	}
+
+	if err := base.ChTimes(build, tpl, a); err != nil {
+		return err
+	}
+
+	ctx.Artifacts.Add(a)
+	return nil
+}
+
+// detectTool returns the tool to use for the project in the given dir,
+// preferring wrappers over globally installed tools.
+func detectTool(dir string) (string, error) {
+	for _, candidate := range []struct {
+		file, tool string
+	}{
+		{"gradlew", "./gradlew"},
+		{"build.gradle.kts", "gradle"},
+		{"build.gradle", "gradle"},
+		{"mvnw", "./mvnw"},
+		{"pom.xml", "mvn"},
+	} {
+		if _, err := os.Stat(filepath.Join(dir, candidate.file)); err == nil {
+			return candidate.tool, nil
+		}
+	}
+	return "", fmt.Errorf("%w in %s", errNoProject, dir)
+}
+
+func isMaven(tool string) bool {
+	return strings.Contains(filepath.Base(tool), "mvn")
+}
+
+func defaultCommand(tool, buildmode string) string {
+	if isMaven(tool) {
+		return "package"
+	}
+	if buildmode == buildmodeNative {
+		return "nativeCompile"
+	}
+	return "assemble"
+}
+
+// jarOutput returns where the given jar will be built.
+func jarOutput(build config.Build, name string) string {
+	if isMaven(build.Tool) {
+		return filepath.Join(build.Dir, "target", name)
+	}
+	return filepath.Join(build.Dir, "build", "libs", name)
+}
+
+// nativeOutput returns where the native image will be built, as configured by
+// the GraalVM plugins.
+func nativeOutput(build config.Build, coords Coordinates, t Target) string {
+	name := coords.Artifact
+	if t.Os == "windows" {
+		name += ".exe"
+	}
+	if isMaven(build.Tool) {
+		return filepath.Join(build.Dir, "target", name)
+	}
+	return filepath.Join(build.Dir, "build", "native", "nativeCompile", name)
+}
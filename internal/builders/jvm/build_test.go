@@ -0,0 +1,300 @@
+package jvm
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencies(t *testing.T) {
+	require.NotEmpty(t, Default.Dependencies())
+}
+
+func TestParse(t *testing.T) {
+	t.Run("jar", func(t *testing.T) {
+		got, err := Default.Parse("all")
+		require.NoError(t, err)
+		require.Equal(t, Target{Target: "all", Os: "all", Arch: "all"}, got)
+	})
+	t.Run("native", func(t *testing.T) {
+		got, err := Default.Parse("linux_amd64")
+		require.NoError(t, err)
+		require.Equal(t, Target{Target: "linux_amd64", Os: "linux", Arch: "amd64"}, got)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Default.Parse("linux")
+		require.Error(t, err)
+	})
+}
+
+func TestWithDefaults(t *testing.T) {
+	t.Run("gradle", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Dir: "./testdata/gradle",
+		})
+		require.NoError(t, err)
+		require.Equal(t, config.Build{
+			Tool:    "gradle",
+			Command: "assemble",
+			Dir:     "./testdata/gradle",
+			Targets: []string{"all"},
+			BuildDetails: config.BuildDetails{
+				Buildmode: "jar",
+			},
+		}, build)
+	})
+
+	t.Run("maven", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Dir: "./testdata/maven",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "mvn", build.Tool)
+		require.Equal(t, "package", build.Command)
+		require.Empty(t, build.Flags)
+	})
+
+	t.Run("wrapper", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "gradlew"), nil, 0o755))
+		build, err := Default.WithDefaults(config.Build{
+			Dir: dir,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "./gradlew", build.Tool)
+	})
+
+	t.Run("native gradle", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Dir: "./testdata/gradle",
+			BuildDetails: config.BuildDetails{
+				Buildmode: "native",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "nativeCompile", build.Command)
+		require.Equal(t, []string{runtime.GOOS + "_" + runtime.GOARCH}, build.Targets)
+		require.Empty(t, build.Flags)
+	})
+
+	t.Run("native maven", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Dir:     "./testdata/maven",
+			Targets: []string{"linux_arm64"},
+			BuildDetails: config.BuildDetails{
+				Buildmode: "native",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "package", build.Command)
+		require.Equal(t, []string{"linux_arm64"}, build.Targets)
+		require.Equal(t, config.FlagArray{"-Pnative"}, build.Flags)
+	})
+
+	t.Run("no project", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Dir: t.TempDir(),
+		})
+		require.ErrorIs(t, err, errNoProject)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Dir:     "./testdata/gradle",
+			Targets: []string{"linux_amd64"},
+		})
+		require.ErrorIs(t, err, errTargets)
+	})
+
+	t.Run("invalid buildmode", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Dir: "./testdata/gradle",
+			BuildDetails: config.BuildDetails{
+				Buildmode: "war",
+			},
+		})
+		require.EqualError(t, err, `jvm: invalid buildmode "war"`)
+	})
+
+	t.Run("invalid config option", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Dir:  "./testdata/gradle",
+			Main: "something",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestCoordinates(t *testing.T) {
+	t.Run("gradle", func(t *testing.T) {
+		c, err := readCoordinates("gradle", "./testdata/gradle")
+		require.NoError(t, err)
+		require.Equal(t, Coordinates{
+			Group:    "com.example",
+			Artifact: "hello",
+			Version:  "1.2.3",
+		}, c)
+	})
+
+	t.Run("gradle properties", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "myproj")
+		require.NoError(t, os.Mkdir(dir, 0o755))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(dir, "gradle.properties"),
+			[]byte("# comment\ngroup=org.example\nversion = 0.1.0\n"),
+			0o644,
+		))
+		c, err := readCoordinates("./gradlew", dir)
+		require.NoError(t, err)
+		require.Equal(t, Coordinates{
+			Group:    "org.example",
+			Artifact: "myproj",
+			Version:  "0.1.0",
+		}, c)
+	})
+
+	t.Run("maven", func(t *testing.T) {
+		c, err := readCoordinates("mvn", "./testdata/maven")
+		require.NoError(t, err)
+		require.Equal(t, Coordinates{
+			Group:    "com.example",
+			Artifact: "hello",
+			Version:  "1.0.0",
+		}, c)
+	})
+
+	t.Run("maven missing pom", func(t *testing.T) {
+		_, err := readCoordinates("./mvnw", t.TempDir())
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+// fakeTool writes a script with the given name that creates the given file,
+// relative to the project dir, to act as gradle or maven.
+func fakeTool(tb testing.TB, name, output string) string {
+	tb.Helper()
+	testlib.SkipIfWindows(tb, "uses a shell script")
+	tool := filepath.Join(tb.TempDir(), name)
+	script := "#!/bin/sh\nmkdir -p \"$(dirname " + output + ")\"\necho \"$@\" > " + output + "\n"
+	require.NoError(tb, os.WriteFile(tool, []byte(script), 0o755))
+	return tool
+}
+
+func TestBuildJar(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"build.gradle.kts", "settings.gradle.kts"} {
+		bts, err := os.ReadFile(filepath.Join("testdata", "gradle", name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), bts, 0o644))
+	}
+
+	dist := t.TempDir()
+	ctx := testctx.Wrap(t.Context())
+	build, err := Default.WithDefaults(config.Build{
+		ID:   "foo",
+		Dir:  dir,
+		Tool: fakeTool(t, "gradle", "build/libs/hello-1.2.3.jar"),
+	})
+	require.NoError(t, err)
+
+	options := api.Options{
+		Name: "foo",
+		Path: filepath.Join(dist, "foo_all", "foo"),
+	}
+	options.Target, err = Default.Parse("all")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(options.Path), 0o755))
+
+	require.NoError(t, Default.Build(ctx, build, options))
+
+	require.Equal(t, []*artifact.Artifact{{
+		Name:   "hello-1.2.3.jar",
+		Path:   filepath.Join(dist, "foo_all", "hello-1.2.3.jar"),
+		Goos:   "all",
+		Goarch: "all",
+		Target: "all",
+		Type:   artifact.Jar,
+		Extra: artifact.Extras{
+			artifact.ExtraBinary:      "hello",
+			artifact.ExtraExt:         ".jar",
+			artifact.ExtraID:          "foo",
+			artifact.ExtraBuilder:     "jvm",
+			artifact.ExtraJvmGroup:    "com.example",
+			artifact.ExtraJvmArtifact: "hello",
+			artifact.ExtraJvmVersion:  "1.2.3",
+		},
+	}}, ctx.Artifacts.List())
+
+	bts, err := os.ReadFile(filepath.Join(dist, "foo_all", "hello-1.2.3.jar"))
+	require.NoError(t, err)
+	require.Equal(t, "assemble\n", string(bts))
+}
+
+func TestBuildNative(t *testing.T) {
+	dir := t.TempDir()
+	bts, err := os.ReadFile(filepath.Join("testdata", "maven", "pom.xml"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pom.xml"), bts, 0o644))
+
+	ctx := testctx.Wrap(t.Context())
+	target := runtime.GOOS + "_" + runtime.GOARCH
+	build, err := Default.WithDefaults(config.Build{
+		ID:   "foo",
+		Dir:  dir,
+		Tool: "mvn",
+		BuildDetails: config.BuildDetails{
+			Buildmode: "native",
+		},
+	})
+	require.NoError(t, err)
+	build.Tool = fakeTool(t, "mvn", "target/hello")
+
+	dist := t.TempDir()
+	options := api.Options{
+		Name: "foo",
+		Path: filepath.Join(dist, "foo_"+target, "foo"),
+	}
+	options.Target, err = Default.Parse(target)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(options.Path), 0o755))
+
+	require.NoError(t, Default.Build(ctx, build, options))
+	bins := ctx.Artifacts.List()
+	require.Len(t, bins, 1)
+	require.Equal(t, artifact.Binary, bins[0].Type)
+	require.Equal(t, options.Path, bins[0].Path)
+	require.Equal(t, "1.0.0", artifact.MustExtra[string](*bins[0], artifact.ExtraJvmVersion))
+
+	bts, err = os.ReadFile(options.Path)
+	require.NoError(t, err)
+	require.Equal(t, "package -Pnative\n", string(bts))
+}
+
+func TestBuildNativeCross(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	options := api.Options{
+		Name: "foo",
+		Path: filepath.Join(t.TempDir(), "foo"),
+	}
+	var err error
+	options.Target, err = Default.Parse("plan9_mips")
+	require.NoError(t, err)
+
+	err = Default.Build(ctx, config.Build{
+		Dir:  "./testdata/maven",
+		Tool: "mvn",
+		BuildDetails: config.BuildDetails{
+			Buildmode: "native",
+		},
+	}, options)
+	require.ErrorContains(t, err, "native images can't be cross-compiled")
+	require.Empty(t, ctx.Artifacts.List())
+}
@@ -0,0 +1,152 @@
+package jvm
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Coordinates are the maven-style coordinates of a JVM project.
+type Coordinates struct {
+	Group    string
+	Artifact string
+	Version  string
+}
+
+type pom struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Parent     struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+}
+
+// readCoordinates reads the coordinates of the project in the given dir,
+// either from its pom.xml or from its gradle files.
+func readCoordinates(tool, dir string) (Coordinates, error) {
+	if isMaven(tool) {
+		return mavenCoordinates(dir)
+	}
+	return gradleCoordinates(dir)
+}
+
+func mavenCoordinates(dir string) (Coordinates, error) {
+	bts, err := os.ReadFile(filepath.Join(dir, "pom.xml"))
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("jvm: could not read pom.xml: %w", err)
+	}
+	var p pom
+	if err := xml.Unmarshal(bts, &p); err != nil {
+		return Coordinates{}, fmt.Errorf("jvm: could not parse pom.xml: %w", err)
+	}
+	c := Coordinates{
+		Group:    p.GroupID,
+		Artifact: p.ArtifactID,
+		Version:  p.Version,
+	}
+	if c.Group == "" {
+		c.Group = p.Parent.GroupID
+	}
+	if c.Version == "" {
+		c.Version = p.Parent.Version
+	}
+	return c, nil
+}
+
+var (
+	gradleAssignRe = regexp.MustCompile(`(?m)^\s*(group|version)\s*=\s*["']([^"']+)["']`)
+	gradleNameRe   = regexp.MustCompile(`(?m)^\s*rootProject\.name\s*=\s*["']([^"']+)["']`)
+)
+
+// gradleCoordinates reads the group and version from gradle.properties or the
+// build script, and the artifact from the settings script, defaulting to the
+// directory name, as gradle does.
+func gradleCoordinates(dir string) (Coordinates, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return Coordinates{}, err
+	}
+	c := Coordinates{
+		Artifact: filepath.Base(abs),
+	}
+
+	props, err := readProperties(filepath.Join(dir, "gradle.properties"))
+	if err != nil {
+		return c, err
+	}
+	c.Group = props["group"]
+	c.Version = props["version"]
+
+	for _, name := range []string{"build.gradle.kts", "build.gradle"} {
+		bts, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return c, err
+		}
+		for _, match := range gradleAssignRe.FindAllStringSubmatch(string(bts), -1) {
+			switch match[1] {
+			case "group":
+				c.Group = match[2]
+			case "version":
+				c.Version = match[2]
+			}
+		}
+		break
+	}
+
+	for _, name := range []string{"settings.gradle.kts", "settings.gradle"} {
+		bts, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return c, err
+		}
+		if match := gradleNameRe.FindStringSubmatch(string(bts)); match != nil {
+			c.Artifact = match[1]
+		}
+		break
+	}
+
+	return c, nil
+}
+
+// readProperties reads a simple java properties file.
+// A missing file yields no properties.
+func readProperties(path string) (map[string]string, error) {
+	result := map[string]string{}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			k, v, ok = strings.Cut(line, ":")
+		}
+		if !ok {
+			continue
+		}
+		result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return result, scanner.Err()
+}
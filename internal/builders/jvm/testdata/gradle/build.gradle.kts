@@ -0,0 +1,6 @@
+plugins {
+    java
+}
+
+group = "com.example"
+version = "1.2.3"
@@ -0,0 +1,139 @@
+// Package prebuilt imports binaries built elsewhere.
+package prebuilt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/builders/base"
+	"github.com/goreleaser/goreleaser/v2/internal/builders/golang"
+	"github.com/goreleaser/goreleaser/v2/internal/elf"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Default builder instance.
+//
+//nolint:gochecknoglobals
+var Default = &Builder{}
+
+// type constraints
+var (
+	_ api.Builder     = &Builder{}
+	_ api.TargetFixer = &Builder{}
+
+	errNoPath    = errors.New("prebuilt.path is required when using the prebuilt builder")
+	errNoTargets = errors.New("goos and goarch, or targets, are required when using the prebuilt builder")
+)
+
+//nolint:gochecknoinits
+func init() {
+	api.Register("prebuilt", Default)
+}
+
+// Builder is the prebuilt builder.
+type Builder struct{}
+
+// Parse implements build.Builder.
+func (b *Builder) Parse(target string) (api.Target, error) {
+	return golang.Default.Parse(target)
+}
+
+// FixTarget implements build.TargetFixer.
+func (b *Builder) FixTarget(target string) string {
+	return golang.Default.FixTarget(target)
+}
+
+// WithDefaults implements build.Builder.
+func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.Prebuilt.Path == "" {
+		return build, errNoPath
+	}
+
+	if build.Main != "" {
+		return build, errors.New("main is not used for prebuilt")
+	}
+
+	if len(build.Targets) == 0 && (len(build.Goos) == 0 || len(build.Goarch) == 0) {
+		return build, errNoTargets
+	}
+
+	// targets are the same as in go builds, so we let the go builder
+	// handle the matrix.
+	gobuild, err := golang.Default.WithDefaults(build)
+	if err != nil {
+		return build, err
+	}
+	build.Targets = gobuild.Targets
+	return build, nil
+}
+
+// Build implements build.Builder.
+func (b *Builder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	t := options.Target.(golang.Target)
+	a := &artifact.Artifact{
+		Type:      artifact.Binary,
+		Path:      options.Path,
+		Name:      options.Name,
+		Goos:      t.Goos,
+		Goarch:    t.Goarch,
+		Goamd64:   t.Goamd64,
+		Go386:     t.Go386,
+		Goarm:     t.Goarm,
+		Goarm64:   t.Goarm64,
+		Gomips:    t.Gomips,
+		Goppc64:   t.Goppc64,
+		Goriscv64: t.Goriscv64,
+		Target:    t.Target,
+		Extra: map[string]any{
+			artifact.ExtraBinary:  strings.TrimSuffix(filepath.Base(options.Path), options.Ext),
+			artifact.ExtraExt:     options.Ext,
+			artifact.ExtraID:      build.ID,
+			artifact.ExtraBuilder: "prebuilt",
+		},
+	}
+
+	tpl := tmpl.New(ctx).
+		WithBuildOptions(options).
+		WithEnvS(ctx.Env.Strings()).
+		WithArtifact(a)
+
+	path, err := tpl.Apply(build.Prebuilt.Path)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return errNoPath
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("could not import prebuilt binary: %w", err)
+	}
+
+	log.WithField("binary", options.Name).
+		WithField("target", options.Target.String()).
+		WithField("path", path).
+		Info("importing")
+	if err := gio.Copy(path, options.Path); err != nil {
+		return err
+	}
+
+	if err := base.ChTimes(build, tpl, a); err != nil {
+		return err
+	}
+
+	if elf.IsDynamicallyLinked(a.Path) {
+		a.Extra[artifact.ExtranDynLink] = true
+	}
+
+	ctx.Artifacts.Add(a)
+	return nil
+}
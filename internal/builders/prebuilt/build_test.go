@@ -0,0 +1,174 @@
+package prebuilt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/builders/golang"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	target, err := Default.Parse("linux_amd64")
+	require.NoError(t, err)
+	require.Equal(t, golang.Target{
+		Target:  "linux_amd64_v1",
+		Goos:    "linux",
+		Goarch:  "amd64",
+		Goamd64: "v1",
+	}, target)
+}
+
+func TestWithDefaults(t *testing.T) {
+	t.Run("goos and goarch", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"linux", "darwin"},
+			Goarch: []string{"amd64", "arm64"},
+			Prebuilt: config.PrebuiltBuildOptions{
+				Path: "output/{{ .Os }}_{{ .Arch }}/bin",
+			},
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			"linux_amd64_v1",
+			"linux_arm64_v8.0",
+			"darwin_amd64_v1",
+			"darwin_arm64_v8.0",
+		}, build.Targets)
+		require.Empty(t, build.Tool)
+		require.Empty(t, build.Ldflags)
+	})
+
+	t.Run("targets", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Targets: []string{"linux_arm64"},
+			Prebuilt: config.PrebuiltBuildOptions{
+				Path: "output/bin",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"linux_arm64_v8.0"}, build.Targets)
+	})
+
+	t.Run("no path", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"linux_arm64"},
+		})
+		require.ErrorIs(t, err, errNoPath)
+	})
+
+	t.Run("no goarch", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos: []string{"linux"},
+			Prebuilt: config.PrebuiltBuildOptions{
+				Path: "output/bin",
+			},
+		})
+		require.ErrorIs(t, err, errNoTargets)
+	})
+
+	t.Run("main", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Main:    "./cmd/foo",
+			Targets: []string{"linux_arm64"},
+			Prebuilt: config.PrebuiltBuildOptions{
+				Path: "output/bin",
+			},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestBuild(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(folder, "output", "linux_arm64"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "output", "linux_arm64", "proj"), []byte("fake"), 0o755))
+
+	modTime := time.Now().AddDate(-1, 0, 0).Round(time.Second).UTC()
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist:        "dist",
+		ProjectName: "proj",
+		Builds: []config.Build{
+			{
+				ID:           "default",
+				Targets:      []string{"linux_arm64"},
+				ModTimestamp: fmt.Sprintf("%d", modTime.Unix()),
+				Prebuilt: config.PrebuiltBuildOptions{
+					Path: "output/{{ .Os }}_{{ .Arch }}/{{ .Name }}",
+				},
+			},
+		},
+	})
+
+	build, err := Default.WithDefaults(ctx.Config.Builds[0])
+	require.NoError(t, err)
+
+	options := api.Options{
+		Name: "proj",
+		Path: filepath.Join("dist", "proj_linux_arm64_v8.0", "proj"),
+	}
+	options.Target, err = Default.Parse("linux_arm64")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(options.Path), 0o755)) // this happens on internal/pipe/build/ when in prod
+
+	require.NoError(t, Default.Build(ctx, build, options))
+
+	bins := ctx.Artifacts.List()
+	require.Len(t, bins, 1)
+
+	bin := bins[0]
+	require.Equal(t, artifact.Artifact{
+		Name:    "proj",
+		Path:    options.Path,
+		Goos:    "linux",
+		Goarch:  "arm64",
+		Goarm64: "v8.0",
+		Target:  "linux_arm64_v8.0",
+		Type:    artifact.Binary,
+		Extra: artifact.Extras{
+			artifact.ExtraBinary:  "proj",
+			artifact.ExtraBuilder: "prebuilt",
+			artifact.ExtraExt:     "",
+			artifact.ExtraID:      "default",
+		},
+	}, *bin)
+
+	bts, err := os.ReadFile(bin.Path)
+	require.NoError(t, err)
+	require.Equal(t, "fake", string(bts))
+	fi, err := os.Stat(bin.Path)
+	require.NoError(t, err)
+	require.True(t, modTime.Equal(fi.ModTime()))
+}
+
+func TestBuildMissingBinary(t *testing.T) {
+	testlib.Mktmp(t)
+	ctx := testctx.Wrap(t.Context())
+	build := config.Build{
+		Targets: []string{"linux_arm64"},
+		Prebuilt: config.PrebuiltBuildOptions{
+			Path: "output/{{ .Os }}_{{ .Arch }}/proj",
+		},
+	}
+
+	options := api.Options{
+		Name: "proj",
+		Path: filepath.Join("dist", "proj_linux_arm64_v8.0", "proj"),
+	}
+	var err error
+	options.Target, err = Default.Parse("linux_arm64")
+	require.NoError(t, err)
+
+	err = Default.Build(ctx, build, options)
+	require.ErrorContains(t, err, "could not import prebuilt binary")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.Empty(t, ctx.Artifacts.List())
+}
@@ -0,0 +1,211 @@
+// Package pyinstaller builds Python applications into standalone binaries
+// using PyInstaller.
+package pyinstaller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/builders/base"
+	"github.com/goreleaser/goreleaser/v2/internal/elf"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Default builder instance.
+//
+//nolint:gochecknoglobals
+var Default = &Builder{}
+
+// type constraints
+var (
+	_ api.Builder          = &Builder{}
+	_ api.DependingBuilder = &Builder{}
+
+	errNoMain = errors.New("pyinstaller: main is required, and should point to the entry script")
+)
+
+//nolint:gochecknoinits
+func init() {
+	api.Register("pyinstaller", Default)
+}
+
+// Builder is the PyInstaller builder.
+type Builder struct{}
+
+// Dependencies implements build.DependingBuilder.
+func (b *Builder) Dependencies() []string {
+	return []string{"pyinstaller"}
+}
+
+// Target is a PyInstaller build target.
+type Target struct {
+	Target string
+	Os     string
+	Arch   string
+}
+
+// Fields implements build.Target.
+func (t Target) Fields() map[string]string {
+	return map[string]string{
+		tmpl.KeyOS:   t.Os,
+		tmpl.KeyArch: t.Arch,
+	}
+}
+
+// String implements fmt.Stringer.
+func (t Target) String() string {
+	return t.Target
+}
+
+// Parse implements build.Builder.
+func (b *Builder) Parse(target string) (api.Target, error) {
+	goos, goarch, ok := strings.Cut(target, "_")
+	if !ok || goos == "" || goarch == "" {
+		return nil, fmt.Errorf("%s is not a valid build target", target)
+	}
+	return Target{
+		Target: target,
+		Os:     goos,
+		Arch:   goarch,
+	}, nil
+}
+
+var once sync.Once
+
+// WithDefaults implements build.Builder.
+func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
+	once.Do(func() {
+		log.Warn("you are using the experimental PyInstaller builder")
+	})
+
+	if len(build.Targets) == 0 {
+		build.Targets = []string{runtime.GOOS + "_" + runtime.GOARCH}
+	}
+
+	if build.Tool == "" {
+		build.Tool = "pyinstaller"
+	}
+
+	if build.Dir == "" {
+		build.Dir = "."
+	}
+
+	if build.Main == "" {
+		return build, errNoMain
+	}
+
+	if err := base.ValidateNonGoConfig(build); err != nil {
+		return build, err
+	}
+
+	return build, nil
+}
+
+// Build implements build.Builder.
+func (b *Builder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	t := options.Target.(Target)
+	if t.Os != ctx.Runtime.Goos || t.Arch != ctx.Runtime.Goarch {
+		return fmt.Errorf(
+			"pyinstaller: binaries can't be cross-compiled: can't build %s on %s/%s",
+			t, ctx.Runtime.Goos, ctx.Runtime.Goarch,
+		)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(options.Path), options.Ext)
+	a := &artifact.Artifact{
+		Type:   artifact.Binary,
+		Path:   options.Path,
+		Name:   options.Name,
+		Goos:   t.Os,
+		Goarch: t.Arch,
+		Target: t.Target,
+		Extra: map[string]any{
+			artifact.ExtraBinary:  name,
+			artifact.ExtraExt:     options.Ext,
+			artifact.ExtraID:      build.ID,
+			artifact.ExtraBuilder: "pyinstaller",
+		},
+	}
+
+	env := []string{}
+	env = append(env, ctx.Env.Strings()...)
+
+	tpl := tmpl.New(ctx).
+		WithBuildOptions(options).
+		WithEnvS(env).
+		WithArtifact(a)
+
+	tool, err := tpl.Apply(build.Tool)
+	if err != nil {
+		return err
+	}
+
+	distpath, err := filepath.Abs(filepath.Dir(options.Path))
+	if err != nil {
+		return err
+	}
+
+	// PyInstaller's intermediary files are of no use afterwards, so we keep
+	// them out of both the project and the dist directories.
+	workpath, err := os.MkdirTemp("", "goreleaser-pyinstaller-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workpath)
+
+	command := []string{tool}
+	command = append(command, strings.Fields(build.Command)...)
+	command = append(
+		command,
+		"--onefile",
+		"--noconfirm",
+		"--name", name,
+		"--distpath", distpath,
+		"--workpath", workpath,
+	)
+
+	tenv, err := base.TemplateEnv(build.Env, tpl)
+	if err != nil {
+		return err
+	}
+	env = append(env, tenv...)
+
+	flags, err := tpl.Slice(build.Flags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	command = append(command, flags...)
+	command = append(command, build.Main)
+
+	log.WithField("binary", options.Name).
+		WithField("target", t.String()).
+		Info("building")
+	if err := base.Exec(ctx, command, env, build.Dir); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(a.Path); err != nil {
+		return fmt.Errorf("pyinstaller: could not find build output: %w", err)
+	}
+
+	if err := base.ChTimes(build, tpl, a); err != nil {
+		return err
+	}
+
+	if elf.IsDynamicallyLinked(a.Path) {
+		a.Extra[artifact.ExtranDynLink] = true
+	}
+
+	ctx.Artifacts.Add(a)
+	return nil
+}
@@ -0,0 +1,166 @@
+package pyinstaller
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencies(t *testing.T) {
+	require.NotEmpty(t, Default.Dependencies())
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		got, err := Default.Parse("linux_amd64")
+		require.NoError(t, err)
+		require.Equal(t, Target{Target: "linux_amd64", Os: "linux", Arch: "amd64"}, got)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Default.Parse("linux")
+		require.Error(t, err)
+	})
+}
+
+func TestWithDefaults(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Main: "main.py",
+		})
+		require.NoError(t, err)
+		require.Equal(t, config.Build{
+			Tool:    "pyinstaller",
+			Dir:     ".",
+			Main:    "main.py",
+			Targets: []string{runtime.GOOS + "_" + runtime.GOARCH},
+		}, build)
+	})
+
+	t.Run("no main", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{})
+		require.ErrorIs(t, err, errNoMain)
+	})
+
+	t.Run("invalid config option", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Main: "main.py",
+			BuildDetails: config.BuildDetails{
+				Buildmode: "wheel",
+			},
+		})
+		require.Error(t, err)
+	})
+}
+
+// fakeTool writes a script that acts as pyinstaller, writing the entry script
+// it was given into <distpath>/<name>.
+func fakeTool(tb testing.TB) string {
+	tb.Helper()
+	testlib.SkipIfWindows(tb, "uses a shell script")
+	tool := filepath.Join(tb.TempDir(), "pyinstaller")
+	script := `#!/bin/sh
+while [ $# -gt 0 ]; do
+	case "$1" in
+		--name) name="$2"; shift ;;
+		--distpath) distpath="$2"; shift ;;
+	esac
+	last="$1"
+	shift
+done
+echo "$last" > "$distpath/$name"
+`
+	require.NoError(tb, os.WriteFile(tool, []byte(script), 0o755))
+	return tool
+}
+
+func TestBuild(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	target := runtime.GOOS + "_" + runtime.GOARCH
+	build, err := Default.WithDefaults(config.Build{
+		ID:   "foo",
+		Dir:  t.TempDir(),
+		Main: "cli.py",
+		Tool: fakeTool(t),
+		BuildDetails: config.BuildDetails{
+			Flags: []string{"--clean"},
+		},
+	})
+	require.NoError(t, err)
+
+	dist := t.TempDir()
+	options := api.Options{
+		Name: "foo",
+		Path: filepath.Join(dist, "foo_"+target, "foo"),
+	}
+	options.Target, err = Default.Parse(target)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(options.Path), 0o755))
+
+	require.NoError(t, Default.Build(ctx, build, options))
+
+	require.Equal(t, []*artifact.Artifact{{
+		Name:   "foo",
+		Path:   options.Path,
+		Goos:   runtime.GOOS,
+		Goarch: runtime.GOARCH,
+		Target: target,
+		Type:   artifact.Binary,
+		Extra: artifact.Extras{
+			artifact.ExtraBinary:  "foo",
+			artifact.ExtraExt:     "",
+			artifact.ExtraID:      "foo",
+			artifact.ExtraBuilder: "pyinstaller",
+		},
+	}}, ctx.Artifacts.List())
+
+	bts, err := os.ReadFile(options.Path)
+	require.NoError(t, err)
+	require.Equal(t, "cli.py\n", string(bts))
+}
+
+func TestBuildNoOutput(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses a shell script")
+	ctx := testctx.Wrap(t.Context())
+	target := runtime.GOOS + "_" + runtime.GOARCH
+	options := api.Options{
+		Name: "foo",
+		Path: filepath.Join(t.TempDir(), "foo"),
+	}
+	var err error
+	options.Target, err = Default.Parse(target)
+	require.NoError(t, err)
+
+	err = Default.Build(ctx, config.Build{
+		Dir:  t.TempDir(),
+		Main: "main.py",
+		Tool: "true",
+	}, options)
+	require.ErrorContains(t, err, "could not find build output")
+	require.Empty(t, ctx.Artifacts.List())
+}
+
+func TestBuildCross(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	options := api.Options{
+		Name: "foo",
+		Path: filepath.Join(t.TempDir(), "foo"),
+	}
+	var err error
+	options.Target, err = Default.Parse("plan9_mips")
+	require.NoError(t, err)
+
+	err = Default.Build(ctx, config.Build{
+		Main: "main.py",
+		Tool: "pyinstaller",
+	}, options)
+	require.ErrorContains(t, err, "can't be cross-compiled")
+	require.Empty(t, ctx.Artifacts.List())
+}
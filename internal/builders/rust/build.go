@@ -52,6 +52,10 @@ func (b *Builder) AllowConcurrentBuilds() bool { return false }
 
 // Prepare implements build.PreparedBuilder.
 func (b *Builder) Prepare(ctx *context.Context, build config.Build) error {
+	if build.Tool == "cross" {
+		// cross builds inside containers that already have the targets.
+		return nil
+	}
 	for _, target := range build.Targets {
 		if clean, ok := stripGlibcVersion(target); ok {
 			target = clean
@@ -102,6 +106,11 @@ func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
 		log.Warn("you are using the experimental Rust builder")
 	})
 
+	build, err := base.TargetsFromGo("rust", build, targetFromGo)
+	if err != nil {
+		return build, err
+	}
+
 	if len(build.Targets) == 0 {
 		build.Targets = defaultTargets()
 	}
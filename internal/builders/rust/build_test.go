@@ -59,6 +59,55 @@ func TestWithDefaults(t *testing.T) {
 		})
 		require.Error(t, err)
 	})
+
+	t.Run("goos and goarch", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"linux", "windows"},
+			Goarch: []string{"amd64", "arm64", "arm"},
+		})
+		require.ErrorContains(t, err, "no rust target for windows/arm")
+
+		build, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"linux", "darwin", "windows"},
+			Goarch: []string{"amd64", "arm64"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"x86_64-unknown-linux-gnu",
+			"aarch64-unknown-linux-gnu",
+			"x86_64-apple-darwin",
+			"aarch64-apple-darwin",
+			"x86_64-pc-windows-gnu",
+			"aarch64-pc-windows-gnullvm",
+		}, build.Targets)
+		require.Empty(t, build.Goos)
+		require.Empty(t, build.Goarch)
+	})
+
+	t.Run("arm", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"linux"},
+			Goarch: []string{"arm"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"armv7-unknown-linux-gnueabihf"}, build.Targets)
+	})
+
+	t.Run("goos and targets", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos:    []string{"linux"},
+			Targets: []string{"x86_64-unknown-linux-gnu"},
+		})
+		require.ErrorContains(t, err, "not both")
+	})
+}
+
+func TestPrepareCross(t *testing.T) {
+	// would fail if it tried to run rustup with an invalid target.
+	require.NoError(t, Default.Prepare(testctx.Wrap(t.Context()), config.Build{
+		Tool:    "cross",
+		Targets: []string{"not-a-target"},
+	}))
 }
 
 func TestCustomGlibc(t *testing.T) {
@@ -106,6 +106,34 @@ func convertToGoarch(s string) string {
 	return s
 }
 
+func targetFromGo(goos, goarch string) (string, bool) {
+	var target string
+	switch {
+	case goos == "linux" && goarch == "arm":
+		target = "armv7-unknown-linux-gnueabihf"
+	case goos == "windows" && goarch == "arm64":
+		// there's no gnu target for windows on arm64.
+		target = "aarch64-pc-windows-gnullvm"
+	default:
+		target = map[string]string{
+			"amd64":   "x86_64",
+			"arm64":   "aarch64",
+			"386":     "i686",
+			"riscv64": "riscv64gc",
+			"ppc64le": "powerpc64le",
+			"s390x":   "s390x",
+		}[goarch] + "-" + map[string]string{
+			"linux":   "unknown-linux-gnu",
+			"darwin":  "apple-darwin",
+			"windows": "pc-windows-gnu",
+			"freebsd": "unknown-freebsd",
+			"netbsd":  "unknown-netbsd",
+			"illumos": "unknown-illumos",
+		}[goos]
+	}
+	return target, isValid(target)
+}
+
 func isValid(target string) bool {
 	targetsOnce.Do(func() {
 		allTargets = slices.DeleteFunc(strings.Split(string(allTargetsBts), "\n"), func(s string) bool {
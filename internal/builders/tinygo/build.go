@@ -0,0 +1,274 @@
+// Package tinygo builds Go programs for microcontrollers and WebAssembly using
+// TinyGo.
+package tinygo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/builders/base"
+	"github.com/goreleaser/goreleaser/v2/internal/elf"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Default builder instance.
+//
+//nolint:gochecknoglobals
+var Default = &Builder{}
+
+// type constraints
+var (
+	_ api.Builder          = &Builder{}
+	_ api.DependingBuilder = &Builder{}
+
+	errNoTargets = errors.New("tinygo: targets are required, e.g. a board like 'pico' or 'wasip1'")
+)
+
+const (
+	keyBoard = "Board"
+
+	// archFirmware is used as the architecture of board targets.
+	archFirmware = "firmware"
+)
+
+// formats are the output formats TinyGo can produce, chosen by the output
+// file extension.
+//
+//nolint:gochecknoglobals
+var formats = []string{"elf", "bin", "hex", "uf2", "img", "zip", "gba", "wasm"}
+
+//nolint:gochecknoinits
+func init() {
+	api.Register("tinygo", Default)
+}
+
+// Builder is the TinyGo builder.
+type Builder struct{}
+
+// Dependencies implements build.DependingBuilder.
+func (b *Builder) Dependencies() []string {
+	return []string{"tinygo"}
+}
+
+// Target is a TinyGo build target.
+type Target struct {
+	// The TinyGo target, e.g. a board name like 'pico', or goos_goarch.
+	Target string
+	// The board name, empty for goos_goarch targets.
+	Board string
+	Os    string
+	Arch  string
+}
+
+// Fields implements build.Target.
+func (t Target) Fields() map[string]string {
+	return map[string]string{
+		tmpl.KeyOS:   t.Os,
+		tmpl.KeyArch: t.Arch,
+		keyBoard:     t.Board,
+	}
+}
+
+// String implements fmt.Stringer.
+func (t Target) String() string {
+	return t.Target
+}
+
+// Parse implements build.Builder.
+func (b *Builder) Parse(target string) (api.Target, error) {
+	if target == "" {
+		return nil, errors.New("tinygo: empty target")
+	}
+	if goos, goarch, ok := strings.Cut(target, "_"); ok {
+		if goos == "" || goarch == "" {
+			return nil, fmt.Errorf("%s is not a valid build target", target)
+		}
+		return Target{
+			Target: target,
+			Os:     goos,
+			Arch:   goarch,
+		}, nil
+	}
+	t := Target{
+		Target: target,
+		Board:  target,
+		Os:     target,
+		Arch:   archFirmware,
+	}
+	switch {
+	case target == "wasm":
+		t.Os = "js"
+		t.Arch = "wasm"
+	case strings.HasPrefix(target, "wasi"), strings.HasPrefix(target, "wasm"):
+		t.Arch = "wasm"
+	}
+	return t, nil
+}
+
+var once sync.Once
+
+// WithDefaults implements build.Builder.
+func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
+	once.Do(func() {
+		log.Warn("you are using the experimental TinyGo builder")
+	})
+
+	if len(build.Targets) == 0 {
+		return build, errNoTargets
+	}
+
+	if build.Tool == "" {
+		build.Tool = "tinygo"
+	}
+
+	if build.Command == "" {
+		build.Command = "build"
+	}
+
+	if build.Dir == "" {
+		build.Dir = "."
+	}
+
+	if build.Main == "" {
+		build.Main = "."
+	}
+
+	if err := base.ValidateNonGoConfig(
+		build,
+		base.WithBuildMode,
+		base.WithLdflags,
+		base.WithTags,
+	); err != nil {
+		return build, err
+	}
+
+	if build.Buildmode != "" && !slices.Contains(formats, build.Buildmode) {
+		return build, fmt.Errorf(
+			"tinygo: invalid buildmode %q, valid options are: %s",
+			build.Buildmode, strings.Join(formats, ", "),
+		)
+	}
+
+	return build, nil
+}
+
+// Build implements build.Builder.
+func (b *Builder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	t := options.Target.(Target)
+
+	ext := outputExt(build, t, options.Ext)
+	name := strings.TrimSuffix(options.Name, options.Ext) + ext
+	path := strings.TrimSuffix(options.Path, options.Ext) + ext
+
+	a := &artifact.Artifact{
+		Type:   artifact.Binary,
+		Path:   path,
+		Name:   name,
+		Goos:   t.Os,
+		Goarch: t.Arch,
+		Target: t.Target,
+		Extra: map[string]any{
+			artifact.ExtraBinary:  strings.TrimSuffix(filepath.Base(path), ext),
+			artifact.ExtraExt:     ext,
+			artifact.ExtraID:      build.ID,
+			artifact.ExtraBuilder: "tinygo",
+		},
+	}
+
+	env := []string{}
+	env = append(env, ctx.Env.Strings()...)
+	if t.Board == "" {
+		env = append(env, "GOOS="+t.Os, "GOARCH="+t.Arch)
+	}
+
+	tpl := tmpl.New(ctx).
+		WithBuildOptions(options).
+		WithEnvS(env).
+		WithArtifact(a)
+
+	tool, err := tpl.Apply(build.Tool)
+	if err != nil {
+		return err
+	}
+
+	command := []string{tool, build.Command, "-o", path}
+	if t.Board != "" {
+		command = append(command, "-target="+t.Board)
+	}
+
+	tenv, err := base.TemplateEnv(build.Env, tpl)
+	if err != nil {
+		return err
+	}
+	env = append(env, tenv...)
+
+	tags, err := tpl.Slice(build.Tags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	if len(tags) > 0 {
+		command = append(command, "-tags="+strings.Join(tags, " "))
+	}
+
+	ldflags, err := tpl.Slice(build.Ldflags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	if len(ldflags) > 0 {
+		command = append(command, "-ldflags="+strings.Join(ldflags, " "))
+	}
+
+	flags, err := tpl.Slice(build.Flags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	command = append(command, flags...)
+	command = append(command, build.Main)
+
+	log.WithField("binary", name).
+		WithField("target", t.String()).
+		Info("building")
+	if err := base.Exec(ctx, command, env, build.Dir); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("tinygo: could not find build output: %w", err)
+	}
+
+	if err := base.ChTimes(build, tpl, a); err != nil {
+		return err
+	}
+
+	if t.Board == "" && elf.IsDynamicallyLinked(path) {
+		a.Extra[artifact.ExtranDynLink] = true
+	}
+
+	ctx.Artifacts.Add(a)
+	return nil
+}
+
+// outputExt returns the extension of the output file, which is how TinyGo
+// decides the output format.
+func outputExt(build config.Build, t Target, ext string) string {
+	switch {
+	case build.Buildmode != "":
+		return "." + build.Buildmode
+	case t.Arch == "wasm":
+		return ".wasm"
+	case t.Board != "":
+		return ".elf"
+	default:
+		return ext
+	}
+}
@@ -0,0 +1,196 @@
+package tinygo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencies(t *testing.T) {
+	require.NotEmpty(t, Default.Dependencies())
+}
+
+func TestParse(t *testing.T) {
+	for target, expected := range map[string]Target{
+		"pico":        {Target: "pico", Board: "pico", Os: "pico", Arch: "firmware"},
+		"wasm":        {Target: "wasm", Board: "wasm", Os: "js", Arch: "wasm"},
+		"wasip1":      {Target: "wasip1", Board: "wasip1", Os: "wasip1", Arch: "wasm"},
+		"linux_arm64": {Target: "linux_arm64", Os: "linux", Arch: "arm64"},
+	} {
+		t.Run(target, func(t *testing.T) {
+			got, err := Default.Parse(target)
+			require.NoError(t, err)
+			require.Equal(t, expected, got)
+		})
+	}
+
+	for _, target := range []string{"", "linux_", "_amd64"} {
+		t.Run("invalid "+target, func(t *testing.T) {
+			_, err := Default.Parse(target)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Targets: []string{"pico"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, config.Build{
+			Tool:    "tinygo",
+			Command: "build",
+			Dir:     ".",
+			Main:    ".",
+			Targets: []string{"pico"},
+		}, build)
+	})
+
+	t.Run("no targets", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{})
+		require.ErrorIs(t, err, errNoTargets)
+	})
+
+	t.Run("valid buildmode", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"pico"},
+			BuildDetails: config.BuildDetails{
+				Buildmode: "uf2",
+				Ldflags:   []string{"-X main.version=1.0.0"},
+				Tags:      []string{"debug"},
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid buildmode", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"pico"},
+			BuildDetails: config.BuildDetails{
+				Buildmode: "c-shared",
+			},
+		})
+		require.ErrorContains(t, err, `tinygo: invalid buildmode "c-shared"`)
+	})
+
+	t.Run("invalid config option", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"pico"},
+			Goos:    []string{"linux"},
+		})
+		require.Error(t, err)
+	})
+}
+
+// fakeTool writes a script that acts as tinygo, writing the arguments it was
+// given into the file passed to -o.
+func fakeTool(tb testing.TB) string {
+	tb.Helper()
+	testlib.SkipIfWindows(tb, "uses a shell script")
+	tool := filepath.Join(tb.TempDir(), "tinygo")
+	script := `#!/bin/sh
+args="$*"
+while [ $# -gt 0 ]; do
+	case "$1" in
+		-o) output="$2"; shift ;;
+	esac
+	shift
+done
+echo "$args" > "$output"
+`
+	require.NoError(tb, os.WriteFile(tool, []byte(script), 0o755))
+	return tool
+}
+
+func TestBuild(t *testing.T) {
+	for target, tc := range map[string]struct {
+		buildmode string
+		ext       string
+		args      string
+	}{
+		"pico": {
+			buildmode: "uf2",
+			ext:       ".uf2",
+			args:      "-target=pico -tags=debug -ldflags=-X main.version=1.2.3 ./cmd/fw",
+		},
+		"arduino-nano33": {
+			ext:  ".elf",
+			args: "-target=arduino-nano33 -tags=debug -ldflags=-X main.version=1.2.3 ./cmd/fw",
+		},
+		"wasip1": {
+			ext:  ".wasm",
+			args: "-target=wasip1 -tags=debug -ldflags=-X main.version=1.2.3 ./cmd/fw",
+		},
+	} {
+		t.Run(target, func(t *testing.T) {
+			ctx := testctx.Wrap(t.Context(), testctx.WithVersion("1.2.3"))
+			build, err := Default.WithDefaults(config.Build{
+				ID:      "fw",
+				Dir:     t.TempDir(),
+				Main:    "./cmd/fw",
+				Tool:    fakeTool(t),
+				Targets: []string{target},
+				BuildDetails: config.BuildDetails{
+					Buildmode: tc.buildmode,
+					Tags:      []string{"debug"},
+					Ldflags:   []string{"-X main.version={{ .Version }}"},
+				},
+			})
+			require.NoError(t, err)
+
+			dist := t.TempDir()
+			options := api.Options{
+				Name: "fw",
+				Path: filepath.Join(dist, "fw_"+target, "fw"),
+			}
+			options.Target, err = Default.Parse(target)
+			require.NoError(t, err)
+			require.NoError(t, os.MkdirAll(filepath.Dir(options.Path), 0o755))
+
+			require.NoError(t, Default.Build(ctx, build, options))
+
+			path := options.Path + tc.ext
+			bins := ctx.Artifacts.List()
+			require.Len(t, bins, 1)
+			require.Equal(t, "fw"+tc.ext, bins[0].Name)
+			require.Equal(t, path, bins[0].Path)
+			require.Equal(t, artifact.Binary, bins[0].Type)
+			require.Equal(t, target, bins[0].Target)
+			require.Equal(t, "fw", artifact.MustExtra[string](*bins[0], artifact.ExtraBinary))
+			require.Equal(t, tc.ext, bins[0].Ext())
+			require.Equal(t, "tinygo", artifact.MustExtra[string](*bins[0], artifact.ExtraBuilder))
+
+			bts, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, "build -o "+path+" "+tc.args+"\n", string(bts))
+		})
+	}
+}
+
+func TestBuildNoOutput(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses a shell script")
+	ctx := testctx.Wrap(t.Context())
+	options := api.Options{
+		Name: "fw",
+		Path: filepath.Join(t.TempDir(), "fw"),
+	}
+	var err error
+	options.Target, err = Default.Parse("pico")
+	require.NoError(t, err)
+
+	err = Default.Build(ctx, config.Build{
+		Dir:  t.TempDir(),
+		Main: ".",
+		Tool: "true",
+	}, options)
+	require.ErrorContains(t, err, "could not find build output")
+	require.Empty(t, ctx.Artifacts.List())
+}
@@ -71,6 +71,11 @@ func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
 		log.Warn("you are using the experimental Zig builder")
 	})
 
+	build, err := base.TargetsFromGo("zig", build, targetFromGo)
+	if err != nil {
+		return build, err
+	}
+
 	if len(build.Targets) == 0 {
 		build.Targets = defaultTargets()
 	}
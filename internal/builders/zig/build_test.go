@@ -49,6 +49,12 @@ func TestParse(t *testing.T) {
 			Os:     "darwin",
 			Arch:   "arm64",
 		},
+		"x86-linux-musl": {
+			Target: "x86-linux-musl",
+			Os:     "linux",
+			Arch:   "386",
+			Abi:    "musl",
+		},
 	} {
 		t.Run(target, func(t *testing.T) {
 			got, err := Default.Parse(target)
@@ -98,6 +104,53 @@ func TestWithDefaults(t *testing.T) {
 		})
 		require.Error(t, err)
 	})
+
+	t.Run("goos and goarch", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"linux", "darwin"},
+			Goarch: []string{"amd64", "arm64", "386"},
+		})
+		require.EqualError(t, err, "no zig target for darwin/386")
+
+		build, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"linux", "darwin"},
+			Goarch: []string{"amd64", "arm64"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"x86_64-linux",
+			"aarch64-linux",
+			"x86_64-macos",
+			"aarch64-macos",
+		}, build.Targets)
+		require.Empty(t, build.Goos)
+		require.Empty(t, build.Goarch)
+	})
+
+	t.Run("only goos", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Goos: []string{"windows"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"x86_64-windows", "aarch64-windows"}, build.Targets)
+	})
+
+	t.Run("386", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Goos:   []string{"linux", "windows"},
+			Goarch: []string{"386"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"x86-linux", "x86-windows"}, build.Targets)
+	})
+
+	t.Run("goos and targets", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos:    []string{"linux"},
+			Targets: []string{"x86_64-linux"},
+		})
+		require.ErrorContains(t, err, "not both")
+	})
 }
 
 func TestBuild(t *testing.T) {
@@ -62,6 +62,37 @@ func convertToGoarch(s string) string {
 		return "arm64"
 	case "x86_64":
 		return "amd64"
+	case "x86":
+		return "386"
+	default:
+		return s
+	}
+}
+
+func convertFromGoos(s string) string {
+	switch s {
+	case "darwin":
+		return "macos"
+	default:
+		return s
+	}
+}
+
+// targetFromGo returns the zig target for the given goos and goarch, and
+// whether it is valid.
+func targetFromGo(goos, goarch string) (string, bool) {
+	target := convertFromGoarch(goarch) + "-" + convertFromGoos(goos)
+	return target, checkTarget(target) != targetInvalid
+}
+
+func convertFromGoarch(s string) string {
+	switch s {
+	case "arm64":
+		return "aarch64"
+	case "amd64":
+		return "x86_64"
+	case "386":
+		return "x86"
 	default:
 		return s
 	}
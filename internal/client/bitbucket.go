@@ -0,0 +1,494 @@
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/changelog"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	// DefaultBitbucketAPIURL is the API URL of Bitbucket Cloud.
+	DefaultBitbucketAPIURL = "https://api.bitbucket.org/2.0"
+
+	// DefaultBitbucketDownloadURL is the download URL of Bitbucket Cloud.
+	DefaultBitbucketDownloadURL = "https://bitbucket.org"
+)
+
+// bitbucketClient talks to both Bitbucket Cloud and Bitbucket Data Center.
+//
+// Bitbucket has no releases: on Cloud, the artifacts are uploaded to the
+// repository's downloads section, Data Center has no such thing.
+type bitbucketClient struct {
+	client     *http.Client
+	apiURL     string
+	auth       string
+	dataCenter bool
+}
+
+var _ Client = &bitbucketClient{}
+
+type bitbucketCloudCommit struct {
+	Hash    string `json:"hash"`
+	Message string `json:"message"`
+	Author  struct {
+		Raw  string `json:"raw"`
+		User *struct {
+			DisplayName string `json:"display_name"`
+			Nickname    string `json:"nickname"`
+		} `json:"user"`
+	} `json:"author"`
+}
+
+type bitbucketDataCenterCommit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Author  struct {
+		Name         string `json:"name"`
+		EmailAddress string `json:"emailAddress"`
+		DisplayName  string `json:"displayName"`
+		Slug         string `json:"slug"`
+	} `json:"author"`
+}
+
+// newBitbucket returns a bitbucket client implementation.
+//
+// API URLs under /rest/api are Bitbucket Data Center ones, anything else is
+// assumed to be Bitbucket Cloud.
+func newBitbucket(ctx *context.Context, token string) (*bitbucketClient, error) {
+	apiURL, err := tmpl.New(ctx).Apply(cmp.Or(ctx.Config.BitbucketURLs.API, DefaultBitbucketAPIURL))
+	if err != nil {
+		return nil, fmt.Errorf("templating Bitbucket API URL: %w", err)
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid Bitbucket API URL: %q", apiURL)
+	}
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{
+			//nolint:gosec
+			InsecureSkipVerify: ctx.Config.BitbucketURLs.SkipTLSVerify,
+		},
+	}
+	return &bitbucketClient{
+		client:     &http.Client{Transport: transport},
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		auth:       bitbucketAuth(token),
+		dataCenter: strings.Contains(u.Path, "/rest/api/"),
+	}, nil
+}
+
+// bitbucketAuth returns the authorization header for the given token.
+//
+// Tokens in the 'username:secret' form, like app passwords and API tokens,
+// use basic auth, anything else, like access tokens, is a bearer token.
+func bitbucketAuth(token string) string {
+	if token == "" {
+		return ""
+	}
+	if strings.Contains(token, ":") {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(token))
+	}
+	return "Bearer " + token
+}
+
+// Changelog fetches the changelog between two revisions.
+func (c *bitbucketClient) Changelog(ctx *context.Context, repo Repo, prev, current string) ([]ChangelogItem, error) {
+	if c.dataCenter {
+		return c.dataCenterChangelog(ctx, repo, prev, current)
+	}
+
+	var log []ChangelogItem
+	next := c.repoPath(repo) + "/commits?" + url.Values{
+		"include": {current},
+		"exclude": {prev},
+		"pagelen": {"100"},
+	}.Encode()
+	for next != "" {
+		var page struct {
+			Values []bitbucketCloudCommit `json:"values"`
+			Next   string                 `json:"next"`
+		}
+		if err := c.get(ctx, next, &page); err != nil {
+			return nil, err
+		}
+		for _, commit := range page.Values {
+			item := ChangelogItem{
+				SHA:     commit.Hash,
+				Message: strings.Split(commit.Message, "\n")[0],
+			}
+			if author := bitbucketParseAuthor(commit.Author.Raw); author.Name != "" || author.Email != "" {
+				if user := commit.Author.User; user != nil {
+					author.Username = user.Nickname
+				}
+				item.Authors = append(item.Authors, author)
+			}
+			item.Authors = append(item.Authors, changelog.ExtractCoAuthors(commit.Message)...)
+			log = append(log, fillDeprecated(item))
+		}
+		next = page.Next
+	}
+	return log, nil
+}
+
+func (c *bitbucketClient) dataCenterChangelog(ctx *context.Context, repo Repo, prev, current string) ([]ChangelogItem, error) {
+	var log []ChangelogItem
+	start := 0
+	for {
+		var page struct {
+			Values        []bitbucketDataCenterCommit `json:"values"`
+			IsLastPage    bool                        `json:"isLastPage"`
+			NextPageStart int                         `json:"nextPageStart"`
+		}
+		path := c.repoPath(repo) + "/commits?" + url.Values{
+			"since": {prev},
+			"until": {current},
+			"limit": {"100"},
+			"start": {strconv.Itoa(start)},
+		}.Encode()
+		if err := c.get(ctx, path, &page); err != nil {
+			return nil, err
+		}
+		for _, commit := range page.Values {
+			item := ChangelogItem{
+				SHA:     commit.ID,
+				Message: strings.Split(commit.Message, "\n")[0],
+				Authors: []Author{{
+					Name:     cmp.Or(commit.Author.DisplayName, commit.Author.Name),
+					Email:    commit.Author.EmailAddress,
+					Username: commit.Author.Slug,
+				}},
+			}
+			item.Authors = append(item.Authors, changelog.ExtractCoAuthors(commit.Message)...)
+			log = append(log, fillDeprecated(item))
+		}
+		if page.IsLastPage || len(page.Values) == 0 {
+			return log, nil
+		}
+		start = page.NextPageStart
+	}
+}
+
+// bitbucketParseAuthor parses a raw 'Name <email>' author.
+func bitbucketParseAuthor(raw string) Author {
+	addr, err := mail.ParseAddress(raw)
+	if err != nil {
+		return Author{Name: strings.TrimSpace(raw)}
+	}
+	return Author{Name: addr.Name, Email: addr.Address}
+}
+
+// CloseMilestone is not supported, as Bitbucket has no milestones.
+func (c *bitbucketClient) CloseMilestone(_ *context.Context, _ Repo, title string) error {
+	return fmt.Errorf("could not close milestone %q: bitbucket: %w", title, ErrNotImplemented)
+}
+
+func (c *bitbucketClient) getDefaultBranch(ctx *context.Context, repo Repo) (string, error) {
+	if c.dataCenter {
+		var result struct {
+			DisplayID string `json:"displayId"`
+		}
+		if err := c.get(ctx, c.repoPath(repo)+"/default-branch", &result); err != nil {
+			return "", err
+		}
+		return result.DisplayID, nil
+	}
+
+	var result struct {
+		MainBranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+	if err := c.get(ctx, c.repoPath(repo), &result); err != nil {
+		return "", err
+	}
+	return result.MainBranch.Name, nil
+}
+
+// CreateFile creates a file in the repository at a given path
+// or updates the file if it exists.
+func (c *bitbucketClient) CreateFile(
+	ctx *context.Context,
+	commitAuthor config.CommitAuthor,
+	repo Repo,
+	content []byte,
+	path,
+	message string,
+) error {
+	branch := repo.Branch
+	if branch == "" {
+		def, err := c.getDefaultBranch(ctx, repo)
+		if err != nil {
+			log.WithField("projectID", repo.String()).
+				WithError(err).
+				Warn("error checking for default branch")
+			return err
+		}
+		branch = def
+	}
+
+	log.
+		WithField("repository", repo.String()).
+		WithField("name", repo.Name).
+		WithField("branch", branch).
+		Info("pushing")
+
+	path = strings.TrimPrefix(path, "/")
+	if c.dataCenter {
+		return c.dataCenterCreateFile(ctx, repo, content, path, branch, message)
+	}
+
+	// creates or updates the file, in a single commit.
+	fields := map[string]string{
+		"message": message,
+		"branch":  branch,
+		"author":  fmt.Sprintf("%s <%s>", commitAuthor.Name, commitAuthor.Email),
+	}
+	err := c.upload(ctx, http.MethodPost, c.repoPath(repo)+"/src", fields, path, path, func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not update %q: %w", path, err)
+	}
+	return nil
+}
+
+// dataCenterCreateFile commits the file with the token user as its author,
+// as Data Center doesn't allow setting it.
+func (c *bitbucketClient) dataCenterCreateFile(ctx *context.Context, repo Repo, content []byte, path, branch, message string) error {
+	// updating a file requires the last commit that changed it.
+	var commits struct {
+		Values []struct {
+			ID string `json:"id"`
+		} `json:"values"`
+	}
+	query := url.Values{
+		"path":  {path},
+		"until": {branch},
+		"limit": {"1"},
+	}.Encode()
+	if err := c.get(ctx, c.repoPath(repo)+"/commits?"+query, &commits); err != nil {
+		return fmt.Errorf("could not get %q: %w", path, err)
+	}
+
+	fields := map[string]string{
+		"message": message,
+		"branch":  branch,
+	}
+	if len(commits.Values) > 0 {
+		fields["sourceCommitId"] = commits.Values[0].ID
+	}
+	err := c.upload(ctx, http.MethodPut, c.repoPath(repo)+"/browse/"+bitbucketEscapePath(path), fields, "content", path, func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not update %q: %w", path, err)
+	}
+	return nil
+}
+
+// CreateRelease does not create anything, as Bitbucket has no releases.
+// The tag is used as the release ID.
+func (c *bitbucketClient) CreateRelease(ctx *context.Context, _ string) (string, error) {
+	if c.dataCenter {
+		log.Warn("bitbucket data center has no releases, nothing will be created")
+	} else {
+		log.Info("bitbucket has no releases, artifacts will be uploaded to the downloads section")
+	}
+	return ctx.Git.CurrentTag, nil
+}
+
+func (c *bitbucketClient) PublishRelease(_ *context.Context, _ string /* releaseID */) error {
+	return nil
+}
+
+func (c *bitbucketClient) ReleaseURLTemplate(ctx *context.Context) (string, error) {
+	if c.dataCenter {
+		return "", fmt.Errorf("bitbucket data center has no downloads section, set a url_template instead: %w", ErrNotImplemented)
+	}
+	downloadURL, err := tmpl.New(ctx).Apply(ctx.Config.BitbucketURLs.Download)
+	if err != nil {
+		return "", fmt.Errorf("templating Bitbucket download URL: %w", err)
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s/downloads/{{ .ArtifactName }}",
+		downloadURL,
+		ctx.Config.Release.Bitbucket.Owner,
+		ctx.Config.Release.Bitbucket.Name,
+	), nil
+}
+
+// Upload uploads a file into the repository downloads section.
+//
+// Downloads are not per release, so a file with the same name is replaced.
+func (c *bitbucketClient) Upload(
+	ctx *context.Context,
+	_ string,
+	artifact *artifact.Artifact,
+) error {
+	if c.dataCenter {
+		return fmt.Errorf("bitbucket data center has no downloads section, set release.skip_upload: %w", ErrNotImplemented)
+	}
+	repo := Repo{
+		Owner: ctx.Config.Release.Bitbucket.Owner,
+		Name:  ctx.Config.Release.Bitbucket.Name,
+	}
+	return c.upload(ctx, http.MethodPost, c.repoPath(repo)+"/downloads", nil, "files", artifact.Name, func() (io.ReadCloser, error) {
+		return os.Open(artifact.Path)
+	})
+}
+
+func (c *bitbucketClient) repoPath(repo Repo) string {
+	if c.dataCenter {
+		// remotes are usually cloned from /scm/PROJECT/repo, and project
+		// keys can't have slashes.
+		project := repo.Owner[strings.LastIndex(repo.Owner, "/")+1:]
+		return "/projects/" + url.PathEscape(project) + "/repos/" + url.PathEscape(repo.Name)
+	}
+	return "/repositories/" + url.PathEscape(repo.Owner) + "/" + url.PathEscape(repo.Name)
+}
+
+// upload sends a multipart form with the given fields and file.
+func (c *bitbucketClient) upload(
+	ctx *context.Context,
+	method, path string,
+	fields map[string]string,
+	fileField, fileName string,
+	open func() (io.ReadCloser, error),
+) error {
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		file, err := open()
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		defer file.Close()
+
+		pr, pw := io.Pipe()
+		mw := multipart.NewWriter(pw)
+		go func() {
+			var err error
+			for k, v := range fields {
+				if err = mw.WriteField(k, v); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				var part io.Writer
+				part, err = mw.CreateFormFile(fileField, fileName)
+				if err == nil {
+					_, err = io.Copy(part, file)
+				}
+			}
+			if err == nil {
+				err = mw.Close()
+			}
+			_ = pw.CloseWithError(err)
+		}()
+		return c.send(ctx, method, path, mw.FormDataContentType(), pr, nil)
+	}, retryx.IsRetriable)
+}
+
+// get sends a GET request, decoding the response into out.
+func (c *bitbucketClient) get(ctx *context.Context, path string, out any) error {
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		return c.send(ctx, http.MethodGet, path, "", nil, out)
+	}, retryx.IsRetriable)
+}
+
+func (c *bitbucketClient) send(ctx *context.Context, method, path, contentType string, body io.Reader, out any) error {
+	// pagination links are absolute.
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		path = c.apiURL + path
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, body)
+	if err != nil {
+		return retryx.Unrecoverable(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.auth != "" {
+		req.Header.Set("Authorization", c.auth)
+	}
+	if c.dataCenter && method != http.MethodGet {
+		// required by Data Center for multipart requests.
+		req.Header.Set("X-Atlassian-Token", "no-check")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return retryx.HTTP(err, nil)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		bts, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return retryx.HTTP(fmt.Errorf("%s %s: %s: %s", method, req.URL.Path, resp.Status, bitbucketErrorMessage(bts)), resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return retryx.Unrecoverable(fmt.Errorf("could not decode Bitbucket response: %w", err))
+	}
+	return nil
+}
+
+// bitbucketErrorMessage extracts the error message from a Cloud or Data
+// Center error response.
+func bitbucketErrorMessage(bts []byte) string {
+	var cloud struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(bts, &cloud) == nil && cloud.Error.Message != "" {
+		return cloud.Error.Message
+	}
+	var dataCenter struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(bts, &dataCenter) == nil && len(dataCenter.Errors) > 0 {
+		msgs := make([]string, 0, len(dataCenter.Errors))
+		for _, e := range dataCenter.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return strings.Join(msgs, ", ")
+	}
+	return strings.TrimSpace(string(bts))
+}
+
+func bitbucketEscapePath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
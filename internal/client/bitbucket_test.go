@@ -0,0 +1,355 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBitbucket(t *testing.T) {
+	t.Run("cloud", func(t *testing.T) {
+		client, err := newBitbucket(testctx.Wrap(t.Context()), "token")
+		require.NoError(t, err)
+		require.Equal(t, "https://api.bitbucket.org/2.0", client.apiURL)
+		require.False(t, client.dataCenter)
+	})
+
+	t.Run("data center", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env: []string{"BITBUCKET_HOST=bitbucket.example.com"},
+			BitbucketURLs: config.BitbucketURLs{
+				API: "https://{{ .Env.BITBUCKET_HOST }}/rest/api/latest/",
+			},
+		})
+		client, err := newBitbucket(ctx, "token")
+		require.NoError(t, err)
+		require.Equal(t, "https://bitbucket.example.com/rest/api/latest", client.apiURL)
+		require.True(t, client.dataCenter)
+	})
+
+	t.Run("template error", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BitbucketURLs: config.BitbucketURLs{API: "{{ .Nope }}"},
+		})
+		_, err := newBitbucket(ctx, "token")
+		testlib.RequireTemplateError(t, err)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BitbucketURLs: config.BitbucketURLs{API: "bitbucket.org"},
+		})
+		_, err := newBitbucket(ctx, "token")
+		require.EqualError(t, err, `invalid Bitbucket API URL: "bitbucket.org"`)
+	})
+}
+
+func TestBitbucketAuth(t *testing.T) {
+	require.Empty(t, bitbucketAuth(""))
+	require.Equal(t, "Bearer token", bitbucketAuth("token"))
+	require.Equal(t, "Basic dXNlcjphcHBwYXNzd29yZA==", bitbucketAuth("user:apppassword"))
+
+	client, ctx := newBitbucketTestClient(t, "/2.0", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer bitbuckettoken", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"mainbranch":{"name":"main"}}`)
+	})
+	branch, err := client.getDefaultBranch(ctx, Repo{Owner: "workspace", Name: "repo"})
+	require.NoError(t, err)
+	require.Equal(t, "main", branch)
+}
+
+func TestBitbucketCloudChangelog(t *testing.T) {
+	var srvURL string
+	client, ctx := newBitbucketTestClient(t, "/2.0", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/2.0/repositories/workspace/repo/commits", r.URL.Path)
+		require.Equal(t, "v1.1.0", r.URL.Query().Get("include"))
+		require.Equal(t, "v1.0.0", r.URL.Query().Get("exclude"))
+		if r.URL.Query().Get("page") == "" {
+			fmt.Fprintf(w, `{"values":[{
+				"hash":"abc123",
+				"message":"feat: foo\n\nCo-authored-by: Jane <jane@example.com>\n",
+				"author":{"raw":"John Doe <jdoe@example.com>","user":{"nickname":"jdoe"}}
+			}],"next":"%s/2.0/repositories/workspace/repo/commits?include=v1.1.0&exclude=v1.0.0&page=2"}`, srvURL)
+			return
+		}
+		fmt.Fprint(w, `{"values":[{
+			"hash":"def456",
+			"message":"fix: bar",
+			"author":{"raw":"bot"}
+		}]}`)
+	})
+	srvURL = client.apiURL[:len(client.apiURL)-len("/2.0")]
+
+	items, err := client.Changelog(ctx, Repo{Owner: "workspace", Name: "repo"}, "v1.0.0", "v1.1.0")
+	require.NoError(t, err)
+	require.Equal(t, []ChangelogItem{
+		{
+			SHA:     "abc123",
+			Message: "feat: foo",
+			Authors: []Author{
+				{Name: "John Doe", Email: "jdoe@example.com", Username: "jdoe"},
+				{Name: "Jane", Email: "jane@example.com"},
+			},
+			AuthorName:     "John Doe",
+			AuthorEmail:    "jdoe@example.com",
+			AuthorUsername: "jdoe",
+		},
+		{
+			SHA:        "def456",
+			Message:    "fix: bar",
+			Authors:    []Author{{Name: "bot"}},
+			AuthorName: "bot",
+		},
+	}, items)
+}
+
+func TestBitbucketDataCenterChangelog(t *testing.T) {
+	client, ctx := newBitbucketTestClient(t, "/rest/api/latest", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rest/api/latest/projects/PROJ/repos/repo/commits", r.URL.Path)
+		require.Equal(t, "v1.0.0", r.URL.Query().Get("since"))
+		require.Equal(t, "v1.1.0", r.URL.Query().Get("until"))
+		switch r.URL.Query().Get("start") {
+		case "0":
+			fmt.Fprint(w, `{"values":[{
+				"id":"abc123",
+				"message":"feat: foo",
+				"author":{"name":"jdoe","emailAddress":"jdoe@example.com","displayName":"John Doe","slug":"jdoe"}
+			}],"isLastPage":false,"nextPageStart":1}`)
+		case "1":
+			fmt.Fprint(w, `{"values":[{
+				"id":"def456",
+				"message":"fix: bar",
+				"author":{"name":"bot","emailAddress":"bot@example.com"}
+			}],"isLastPage":true}`)
+		default:
+			t.Errorf("unexpected page: %s", r.URL.RawQuery)
+		}
+	})
+
+	// remotes are cloned from /scm/PROJ/repo.
+	items, err := client.Changelog(ctx, Repo{Owner: "scm/PROJ", Name: "repo"}, "v1.0.0", "v1.1.0")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, "abc123", items[0].SHA)
+	require.Equal(t, []Author{{Name: "John Doe", Email: "jdoe@example.com", Username: "jdoe"}}, items[0].Authors)
+	require.Equal(t, "def456", items[1].SHA)
+	require.Equal(t, []Author{{Name: "bot", Email: "bot@example.com"}}, items[1].Authors)
+}
+
+func TestBitbucketChangelogError(t *testing.T) {
+	t.Run("cloud", func(t *testing.T) {
+		client, ctx := newBitbucketTestClient(t, "/2.0", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type":"error","error":{"message":"Repository not found"}}`)
+		})
+		_, err := client.Changelog(ctx, Repo{Owner: "workspace", Name: "repo"}, "v1.0.0", "v1.1.0")
+		require.ErrorContains(t, err, "Repository not found")
+	})
+
+	t.Run("data center", func(t *testing.T) {
+		client, ctx := newBitbucketTestClient(t, "/rest/api/latest", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"errors":[{"message":"Authentication failed"}]}`)
+		})
+		_, err := client.Changelog(ctx, Repo{Owner: "PROJ", Name: "repo"}, "v1.0.0", "v1.1.0")
+		require.ErrorContains(t, err, "Authentication failed")
+	})
+}
+
+func TestBitbucketCloseMilestone(t *testing.T) {
+	client, ctx := newBitbucketTestClient(t, "/2.0", nil)
+	err := client.CloseMilestone(ctx, Repo{Owner: "workspace", Name: "repo"}, "v1.0.0")
+	require.ErrorIs(t, err, ErrNotImplemented)
+}
+
+func TestBitbucketCreateFile(t *testing.T) {
+	author := config.CommitAuthor{Name: "bot", Email: "bot@example.com"}
+
+	t.Run("cloud", func(t *testing.T) {
+		client, ctx := newBitbucketTestClient(t, "/2.0", func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/2.0/repositories/workspace/tap":
+				fmt.Fprint(w, `{"mainbranch":{"name":"trunk"}}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/2.0/repositories/workspace/tap/src":
+				require.NoError(t, r.ParseMultipartForm(1<<20))
+				require.Equal(t, "update foo", r.FormValue("message"))
+				require.Equal(t, "trunk", r.FormValue("branch"))
+				require.Equal(t, "bot <bot@example.com>", r.FormValue("author"))
+				require.Equal(t, "content", formFile(t, r, "Formula/foo.rb"))
+				w.WriteHeader(http.StatusCreated)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		})
+
+		require.NoError(t, client.CreateFile(ctx, author, Repo{Owner: "workspace", Name: "tap"}, []byte("content"), "Formula/foo.rb", "update foo"))
+	})
+
+	t.Run("data center update", func(t *testing.T) {
+		client, ctx := newBitbucketTestClient(t, "/rest/api/latest", func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/rest/api/latest/projects/PROJ/repos/tap/commits":
+				require.Equal(t, "Formula/foo.rb", r.URL.Query().Get("path"))
+				require.Equal(t, "main", r.URL.Query().Get("until"))
+				fmt.Fprint(w, `{"values":[{"id":"lastcommit"}]}`)
+			case r.Method == http.MethodPut && r.URL.Path == "/rest/api/latest/projects/PROJ/repos/tap/browse/Formula/foo.rb":
+				require.Equal(t, "no-check", r.Header.Get("X-Atlassian-Token"))
+				require.NoError(t, r.ParseMultipartForm(1<<20))
+				require.Equal(t, "update foo", r.FormValue("message"))
+				require.Equal(t, "main", r.FormValue("branch"))
+				require.Equal(t, "lastcommit", r.FormValue("sourceCommitId"))
+				require.Equal(t, "content", formFile(t, r, "content"))
+				fmt.Fprint(w, `{}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		})
+
+		require.NoError(t, client.CreateFile(ctx, author, Repo{Owner: "PROJ", Name: "tap", Branch: "main"}, []byte("content"), "Formula/foo.rb", "update foo"))
+	})
+
+	t.Run("data center new file on default branch", func(t *testing.T) {
+		client, ctx := newBitbucketTestClient(t, "/rest/api/latest", func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/rest/api/latest/projects/PROJ/repos/tap/default-branch":
+				fmt.Fprint(w, `{"id":"refs/heads/master","displayId":"master"}`)
+			case r.Method == http.MethodGet && r.URL.Path == "/rest/api/latest/projects/PROJ/repos/tap/commits":
+				fmt.Fprint(w, `{"values":[]}`)
+			case r.Method == http.MethodPut:
+				require.NoError(t, r.ParseMultipartForm(1<<20))
+				require.Equal(t, "master", r.FormValue("branch"))
+				require.Empty(t, r.FormValue("sourceCommitId"))
+				fmt.Fprint(w, `{}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		})
+
+		require.NoError(t, client.CreateFile(ctx, author, Repo{Owner: "PROJ", Name: "tap"}, []byte("content"), "foo.rb", "update foo"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client, ctx := newBitbucketTestClient(t, "/2.0", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"type":"error","error":{"message":"Access denied"}}`)
+		})
+
+		err := client.CreateFile(ctx, author, Repo{Owner: "workspace", Name: "tap", Branch: "main"}, []byte("content"), "foo.rb", "update foo")
+		require.ErrorContains(t, err, `could not update "foo.rb"`)
+		require.ErrorContains(t, err, "Access denied")
+	})
+}
+
+func TestBitbucketRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foo.tar.gz")
+	require.NoError(t, os.WriteFile(path, []byte("archive"), 0o644))
+	art := &artifact.Artifact{Name: "foo.tar.gz", Path: path}
+
+	t.Run("cloud", func(t *testing.T) {
+		var uploaded string
+		client, ctx := newBitbucketTestClient(t, "/2.0", func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/2.0/repositories/workspace/repo/downloads", r.URL.Path)
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			uploaded = formFile(t, r, "files")
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		id, err := client.CreateRelease(ctx, "notes")
+		require.NoError(t, err)
+		require.Equal(t, "v1.0.0", id)
+		require.NoError(t, client.Upload(ctx, id, art))
+		require.NoError(t, client.PublishRelease(ctx, id))
+		require.Equal(t, "archive", uploaded)
+	})
+
+	t.Run("cloud error", func(t *testing.T) {
+		client, ctx := newBitbucketTestClient(t, "/2.0", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+		require.ErrorContains(t, client.Upload(ctx, "v1.0.0", art), "403 Forbidden")
+	})
+
+	t.Run("data center", func(t *testing.T) {
+		client, ctx := newBitbucketTestClient(t, "/rest/api/latest", nil)
+		id, err := client.CreateRelease(ctx, "notes")
+		require.NoError(t, err)
+		require.Equal(t, "v1.0.0", id)
+		require.ErrorIs(t, client.Upload(ctx, id, art), ErrNotImplemented)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		client, ctx := newBitbucketTestClient(t, "/2.0", nil)
+		err := client.Upload(ctx, "v1.0.0", &artifact.Artifact{Name: "nope", Path: filepath.Join(t.TempDir(), "nope")})
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestBitbucketReleaseURLTemplate(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		BitbucketURLs: config.BitbucketURLs{
+			Download: "https://bitbucket.org",
+		},
+		Release: config.Release{
+			Bitbucket: config.Repo{Owner: "workspace", Name: "repo"},
+		},
+	})
+	client, err := newBitbucket(ctx, "token")
+	require.NoError(t, err)
+
+	url, err := client.ReleaseURLTemplate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "https://bitbucket.org/workspace/repo/downloads/{{ .ArtifactName }}", url)
+
+	ctx.Config.BitbucketURLs.Download = "{{ .Nope }}"
+	_, err = client.ReleaseURLTemplate(ctx)
+	testlib.RequireTemplateError(t, err)
+
+	client.dataCenter = true
+	_, err = client.ReleaseURLTemplate(ctx)
+	require.ErrorIs(t, err, ErrNotImplemented)
+}
+
+func newBitbucketTestClient(tb testing.TB, apiPath string, handler http.HandlerFunc) (*bitbucketClient, *context.Context) {
+	tb.Helper()
+	if handler == nil {
+		handler = func(_ http.ResponseWriter, r *http.Request) {
+			tb.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+	srv := httptest.NewServer(handler)
+	tb.Cleanup(srv.Close)
+
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		BitbucketURLs: config.BitbucketURLs{
+			API: srv.URL + apiPath,
+		},
+		Release: config.Release{
+			Bitbucket: config.Repo{Owner: "workspace", Name: "repo"},
+		},
+	}, testctx.BitbucketTokenType, testctx.WithCurrentTag("v1.0.0"))
+	client, err := newBitbucket(ctx, ctx.Token)
+	require.NoError(tb, err)
+	return client, ctx
+}
+
+func formFile(tb testing.TB, r *http.Request, field string) string {
+	tb.Helper()
+	file, _, err := r.FormFile(field)
+	require.NoError(tb, err)
+	defer file.Close()
+	bts, err := io.ReadAll(file)
+	require.NoError(tb, err)
+	return string(bts)
+}
@@ -5,6 +5,7 @@ import (
 	"cmp"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
@@ -78,7 +79,8 @@ type ReleaseURLTemplater interface {
 type RepoFile struct {
 	Content    []byte
 	Path       string
-	Identifier string // for the use of the caller.
+	Identifier string      // for the use of the caller.
+	Mode       os.FileMode // only used by the git client, defaults to 0o644.
 }
 
 // FileCreator can create the given file to some code repository.
@@ -104,7 +106,12 @@ type ForkSyncer interface {
 
 // PullRequestOpener can open pull requests.
 type PullRequestOpener interface {
-	OpenPullRequest(ctx *context.Context, base, head Repo, title string, draft bool) error
+	OpenPullRequest(ctx *context.Context, base, head Repo, title, body string, draft bool) error
+}
+
+// Attester can store artifact attestations.
+type Attester interface {
+	CreateAttestation(ctx *context.Context, repo Repo, bundle []byte) error
 }
 
 // New creates a new client depending on the token type.
@@ -142,6 +149,10 @@ func newWithToken(ctx *context.Context, token string) (Client, error) {
 		return newGitLab(ctx, token)
 	case context.TokenTypeGitea:
 		return newGitea(ctx, token)
+	case context.TokenTypeForgejo:
+		return newForgejo(ctx, token)
+	case context.TokenTypeBitbucket:
+		return newBitbucket(ctx, token)
 	default:
 		return nil, fmt.Errorf("invalid client token type: %q", ctx.TokenType)
 	}
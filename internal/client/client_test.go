@@ -213,6 +213,28 @@ func TestNewWithToken(t *testing.T) {
 		require.IsType(t, &giteaClient{}, cli)
 	})
 
+	t.Run("forgejo", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env: []string{"TK=token"},
+		}, testctx.ForgejoTokenType)
+
+		cli, err := newWithToken(ctx, "{{ .Env.TK }}")
+		require.NoError(t, err)
+
+		require.IsType(t, &forgejoClient{}, cli)
+	})
+
+	t.Run("bitbucket", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env: []string{"TK=token"},
+		}, testctx.BitbucketTokenType)
+
+		cli, err := newWithToken(ctx, "{{ .Env.TK }}")
+		require.NoError(t, err)
+
+		require.IsType(t, &bitbucketClient{}, cli)
+	})
+
 	t.Run("invalid", func(t *testing.T) {
 		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 			Env: []string{"TK=token"},
@@ -1,9 +1,22 @@
 package client
 
-import "github.com/goreleaser/goreleaser/v2/pkg/config"
+import (
+	"slices"
+	"strings"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+)
 
 const prFooter = "###### Automated with [GoReleaser](https://goreleaser.com)"
 
+// prBody joins the non-empty given parts with the pull request footer.
+func prBody(parts ...string) string {
+	return strings.Join(slices.DeleteFunc(
+		append(parts, prFooter),
+		func(s string) bool { return s == "" },
+	), "\n")
+}
+
 // RepoFromRef converts a config.RepoRef into a Repo.
 func RepoFromRef(ref config.RepoRef) Repo {
 	return Repo{
@@ -0,0 +1,530 @@
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/changelog"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// DefaultForgejoAPIURL is the API URL of Codeberg, the default Forgejo instance.
+const DefaultForgejoAPIURL = "https://codeberg.org/api/v1"
+
+type forgejoClient struct {
+	client *http.Client
+	apiURL string
+	token  string
+}
+
+var (
+	_ Client            = &forgejoClient{}
+	_ PullRequestOpener = &forgejoClient{}
+)
+
+type forgejoIdentity struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type forgejoUser struct {
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+}
+
+type forgejoCommit struct {
+	SHA    string       `json:"sha"`
+	Author *forgejoUser `json:"author"`
+	Commit struct {
+		Message string `json:"message"`
+	} `json:"commit"`
+}
+
+type forgejoRelease struct {
+	ID      int64  `json:"id"`
+	TagName string `json:"tag_name"`
+	Body    string `json:"body"`
+	Draft   bool   `json:"draft"`
+	HTMLURL string `json:"html_url"`
+}
+
+type forgejoReleaseOptions struct {
+	TagName    string `json:"tag_name"`
+	Target     string `json:"target_commitish,omitempty"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+type forgejoAttachment struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type forgejoFileOptions struct {
+	Branch    string          `json:"branch,omitempty"`
+	Message   string          `json:"message"`
+	Content   string          `json:"content"`
+	SHA       string          `json:"sha,omitempty"`
+	Author    forgejoIdentity `json:"author"`
+	Committer forgejoIdentity `json:"committer"`
+}
+
+type forgejoPullRequestOptions struct {
+	Base  string `json:"base"`
+	Head  string `json:"head"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// newForgejo returns a forgejo client implementation.
+func newForgejo(ctx *context.Context, token string) (*forgejoClient, error) {
+	apiURL, err := tmpl.New(ctx).Apply(cmp.Or(ctx.Config.ForgejoURLs.API, DefaultForgejoAPIURL))
+	if err != nil {
+		return nil, fmt.Errorf("templating Forgejo API URL: %w", err)
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid Forgejo API URL: %q", apiURL)
+	}
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{
+			//nolint:gosec
+			InsecureSkipVerify: ctx.Config.ForgejoURLs.SkipTLSVerify,
+		},
+	}
+	return &forgejoClient{
+		client: &http.Client{Transport: transport},
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		token:  token,
+	}, nil
+}
+
+// Changelog fetches the changelog between two revisions.
+func (c *forgejoClient) Changelog(ctx *context.Context, repo Repo, prev, current string) ([]ChangelogItem, error) {
+	var result struct {
+		Commits []forgejoCommit `json:"commits"`
+	}
+	path := forgejoRepoPath(repo) + "/compare/" + url.PathEscape(prev+"..."+current)
+	if err := c.do(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	var log []ChangelogItem
+	for _, commit := range result.Commits {
+		item := ChangelogItem{
+			SHA:     commit.SHA,
+			Message: strings.Split(commit.Commit.Message, "\n")[0],
+		}
+		if author := commit.Author; author != nil {
+			item.Authors = append(item.Authors, Author{
+				Name:     author.FullName,
+				Email:    author.Email,
+				Username: author.Login,
+			})
+		}
+		item.Authors = append(item.Authors, changelog.ExtractCoAuthors(commit.Commit.Message)...)
+		log = append(log, fillDeprecated(item))
+	}
+	return log, nil
+}
+
+// CloseMilestone closes a given milestone.
+func (c *forgejoClient) CloseMilestone(ctx *context.Context, repo Repo, title string) error {
+	// the milestone can be identified by its name instead of its ID.
+	path := forgejoRepoPath(repo) + "/milestones/" + url.PathEscape(title)
+	err := c.do(ctx, http.MethodPatch, path, map[string]string{
+		"state": "closed",
+		"title": title,
+	}, nil)
+	if isForgejoNotFound(err) {
+		return ErrNoMilestoneFound{Title: title}
+	}
+	return err
+}
+
+func (c *forgejoClient) getDefaultBranch(ctx *context.Context, repo Repo) (string, error) {
+	var result struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := c.do(ctx, http.MethodGet, forgejoRepoPath(repo), nil, &result); err != nil {
+		log.WithField("projectID", repo.String()).
+			WithError(err).
+			Warn("error checking for default branch")
+		return "", err
+	}
+	return result.DefaultBranch, nil
+}
+
+// CreateFile creates a file in the repository at a given path
+// or updates the file if it exists.
+func (c *forgejoClient) CreateFile(
+	ctx *context.Context,
+	commitAuthor config.CommitAuthor,
+	repo Repo,
+	content []byte,
+	path,
+	message string,
+) error {
+	branch := repo.Branch
+	if branch == "" {
+		def, err := c.getDefaultBranch(ctx, repo)
+		if err != nil {
+			return err
+		}
+		branch = def
+	}
+
+	log.
+		WithField("repository", repo.String()).
+		WithField("name", repo.Name).
+		WithField("branch", branch).
+		Info("pushing")
+
+	contentsPath := forgejoRepoPath(repo) + "/contents/" + forgejoEscapePath(path)
+	var current struct {
+		SHA string `json:"sha"`
+	}
+	err := c.do(ctx, http.MethodGet, contentsPath+"?ref="+url.QueryEscape(branch), nil, &current)
+	if err != nil && !isForgejoNotFound(err) {
+		return fmt.Errorf("could not get %q: %w", path, err)
+	}
+
+	identity := forgejoIdentity{
+		Name:  commitAuthor.Name,
+		Email: commitAuthor.Email,
+	}
+	opts := forgejoFileOptions{
+		Branch:    branch,
+		Message:   message,
+		Content:   base64.StdEncoding.EncodeToString(content),
+		SHA:       current.SHA,
+		Author:    identity,
+		Committer: identity,
+	}
+
+	// file does not exist, create it
+	if current.SHA == "" {
+		if err := c.do(ctx, http.MethodPost, contentsPath, opts, nil); err != nil {
+			return fmt.Errorf("could not create %q: %w", path, err)
+		}
+		return nil
+	}
+
+	if err := c.do(ctx, http.MethodPut, contentsPath, opts, nil); err != nil {
+		return fmt.Errorf("could not update %q: %w", path, err)
+	}
+	return nil
+}
+
+// CreateRelease creates a new draft release or updates it by keeping
+// the release notes if it exists.
+func (c *forgejoClient) CreateRelease(ctx *context.Context, body string) (string, error) {
+	tpl := tmpl.New(ctx)
+	title, err := tpl.Apply(ctx.Config.Release.NameTemplate)
+	if err != nil {
+		return "", err
+	}
+	target, err := tpl.Apply(ctx.Config.Release.TargetCommitish)
+	if err != nil {
+		return "", err
+	}
+
+	repo := forgejoReleaseRepo(ctx)
+	opts := forgejoReleaseOptions{
+		TagName: ctx.Git.CurrentTag,
+		Target:  cmp.Or(target, ctx.Git.Commit),
+		Name:    title,
+		Body:    body,
+		// Always start with a draft release while uploading artifacts.
+		// PublishRelease will undraft it.
+		Draft:      true,
+		Prerelease: ctx.PreRelease,
+	}
+
+	existing, err := c.findRelease(ctx, repo, ctx.Git.CurrentTag)
+	if err != nil {
+		return "", err
+	}
+
+	var release forgejoRelease
+	if existing != nil {
+		opts.Body = getReleaseNotes(existing.Body, body, ctx.Config.Release.ReleaseNotesMode)
+		// don't turn a published release back into a draft.
+		opts.Draft = existing.Draft
+		path := forgejoRepoPath(repo) + "/releases/" + strconv.FormatInt(existing.ID, 10)
+		if err := c.do(ctx, http.MethodPatch, path, opts, &release); err != nil {
+			return "", fmt.Errorf("could not update release: %w", err)
+		}
+		log.WithField("id", release.ID).Info("Forgejo release updated")
+	} else {
+		if err := c.do(ctx, http.MethodPost, forgejoRepoPath(repo)+"/releases", opts, &release); err != nil {
+			return "", fmt.Errorf("could not create release: %w", err)
+		}
+		log.WithField("id", release.ID).Info("Forgejo release created")
+	}
+
+	return strconv.FormatInt(release.ID, 10), nil
+}
+
+// findRelease finds the release, including drafts, for the given tag.
+func (c *forgejoClient) findRelease(ctx *context.Context, repo Repo, tag string) (*forgejoRelease, error) {
+	for page := 1; ; page++ {
+		var releases []forgejoRelease
+		path := fmt.Sprintf("%s/releases?draft=true&limit=50&page=%d", forgejoRepoPath(repo), page)
+		if err := c.do(ctx, http.MethodGet, path, nil, &releases); err != nil {
+			return nil, err
+		}
+		for _, release := range releases {
+			if release.TagName == tag {
+				return &release, nil
+			}
+		}
+		if len(releases) < 50 {
+			return nil, nil
+		}
+	}
+}
+
+// PublishRelease undrafts the release, unless release.draft is set.
+func (c *forgejoClient) PublishRelease(ctx *context.Context, releaseID string) error {
+	if ctx.Config.Release.Draft {
+		return nil
+	}
+	if _, err := strconv.ParseInt(releaseID, 10, 64); err != nil {
+		return fmt.Errorf("non-numeric release ID %q: %w", releaseID, err)
+	}
+	var release forgejoRelease
+	path := forgejoRepoPath(forgejoReleaseRepo(ctx)) + "/releases/" + releaseID
+	if err := c.do(ctx, http.MethodPatch, path, map[string]bool{"draft": false}, &release); err != nil {
+		return fmt.Errorf("could not publish release: %w", err)
+	}
+	log.WithField("url", release.HTMLURL).Debug("release published")
+	return nil
+}
+
+func (c *forgejoClient) ReleaseURLTemplate(ctx *context.Context) (string, error) {
+	downloadURL, err := tmpl.New(ctx).Apply(ctx.Config.ForgejoURLs.Download)
+	if err != nil {
+		return "", fmt.Errorf("templating Forgejo download URL: %w", err)
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s/releases/download/{{ urlPathEscape .Tag }}/{{ .ArtifactName }}",
+		downloadURL,
+		ctx.Config.Release.Forgejo.Owner,
+		ctx.Config.Release.Forgejo.Name,
+	), nil
+}
+
+// Upload uploads a file into a release repository.
+func (c *forgejoClient) Upload(
+	ctx *context.Context,
+	releaseID string,
+	artifact *artifact.Artifact,
+) error {
+	if _, err := strconv.ParseInt(releaseID, 10, 64); err != nil {
+		return fmt.Errorf("non-numeric release ID %q: %w", releaseID, err)
+	}
+	path := forgejoRepoPath(forgejoReleaseRepo(ctx)) + "/releases/" + releaseID + "/assets"
+
+	// Forgejo allows multiple assets with the same name, so replacing
+	// means deleting the existing ones first.
+	if ctx.Config.Release.ReplaceExistingArtifacts {
+		var assets []forgejoAttachment
+		if err := c.do(ctx, http.MethodGet, path, nil, &assets); err != nil {
+			return err
+		}
+		for _, asset := range assets {
+			if asset.Name != artifact.Name {
+				continue
+			}
+			log.WithField("name", asset.Name).Info("delete pre-existing asset from the release")
+			if err := c.do(ctx, http.MethodDelete, path+"/"+strconv.FormatInt(asset.ID, 10), nil, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		file, err := os.Open(artifact.Path)
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		defer file.Close()
+
+		pr, pw := io.Pipe()
+		mw := multipart.NewWriter(pw)
+		go func() {
+			part, err := mw.CreateFormFile("attachment", artifact.Name)
+			if err == nil {
+				_, err = io.Copy(part, file)
+			}
+			if err == nil {
+				err = mw.Close()
+			}
+			_ = pw.CloseWithError(err)
+		}()
+		return c.send(ctx, http.MethodPost, path+"?name="+url.QueryEscape(artifact.Name), mw.FormDataContentType(), pr, nil)
+	}, retryx.IsRetriable)
+}
+
+// OpenPullRequest opens a pull request, which is marked as work in progress
+// if draft is set.
+func (c *forgejoClient) OpenPullRequest(
+	ctx *context.Context,
+	base, head Repo,
+	title, body string,
+	draft bool,
+) error {
+	base.Owner = cmp.Or(base.Owner, head.Owner)
+	base.Name = cmp.Or(base.Name, head.Name)
+	if base.Branch == "" {
+		def, err := c.getDefaultBranch(ctx, base)
+		if err != nil {
+			return err
+		}
+		base.Branch = def
+	}
+
+	// Forgejo has no draft pull requests, the WIP prefix is its equivalent.
+	if draft {
+		title = "WIP: " + title
+	}
+
+	headRef := cmp.Or(head.Branch, base.Branch)
+	if head.Owner != "" && head.Owner != base.Owner {
+		headRef = head.Owner + ":" + headRef
+	}
+
+	log := log.
+		WithField("base", headString(base, Repo{})).
+		WithField("head", headString(base, head)).
+		WithField("draft", draft)
+	log.Info("opening pull request")
+
+	var pr struct {
+		HTMLURL string `json:"html_url"`
+	}
+	err := c.do(ctx, http.MethodPost, forgejoRepoPath(base)+"/pulls", forgejoPullRequestOptions{
+		Base:  base.Branch,
+		Head:  headRef,
+		Title: title,
+		Body:  prBody(body),
+	}, &pr)
+	if err != nil {
+		if he, ok := errors.AsType[retryx.HTTPError](err); ok && he.Status == http.StatusConflict {
+			log.WithError(err).Warn("pull request already exists")
+			return nil
+		}
+		return fmt.Errorf("could not create pull request: %w", err)
+	}
+	log.WithField("url", pr.HTMLURL).Info("pull request created")
+	return nil
+}
+
+// do sends a request with the given value as its JSON body, if any, decoding
+// the response into out, if not nil.
+func (c *forgejoClient) do(ctx *context.Context, method, path string, in, out any) error {
+	var body []byte
+	if in != nil {
+		bts, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bts
+	}
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		if body == nil {
+			return c.send(ctx, method, path, "", nil, out)
+		}
+		return c.send(ctx, method, path, "application/json", bytes.NewReader(body), out)
+	}, retryx.IsRetriable)
+}
+
+func (c *forgejoClient) send(ctx *context.Context, method, path, contentType string, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, body)
+	if err != nil {
+		return retryx.Unrecoverable(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return retryx.HTTP(err, nil)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		bts, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		msg := strings.TrimSpace(string(bts))
+		if json.Unmarshal(bts, &apiErr) == nil && apiErr.Message != "" {
+			msg = apiErr.Message
+		}
+		return retryx.HTTP(fmt.Errorf("%s %s: %s: %s", method, req.URL.Path, resp.Status, msg), resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return retryx.Unrecoverable(fmt.Errorf("could not decode Forgejo response: %w", err))
+	}
+	return nil
+}
+
+func forgejoReleaseRepo(ctx *context.Context) Repo {
+	return Repo{
+		Owner: ctx.Config.Release.Forgejo.Owner,
+		Name:  ctx.Config.Release.Forgejo.Name,
+	}
+}
+
+func forgejoRepoPath(repo Repo) string {
+	return "/repos/" + url.PathEscape(repo.Owner) + "/" + url.PathEscape(repo.Name)
+}
+
+func forgejoEscapePath(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+func isForgejoNotFound(err error) bool {
+	he, ok := errors.AsType[retryx.HTTPError](err)
+	return ok && he.Status == http.StatusNotFound
+}
@@ -0,0 +1,496 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewForgejo(t *testing.T) {
+	t.Run("codeberg", func(t *testing.T) {
+		client, err := newForgejo(testctx.Wrap(t.Context()), "token")
+		require.NoError(t, err)
+		require.Equal(t, "https://codeberg.org/api/v1", client.apiURL)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env: []string{"FORGEJO_HOST=forgejo.example.com"},
+			ForgejoURLs: config.ForgejoURLs{
+				API: "https://{{ .Env.FORGEJO_HOST }}/api/v1/",
+			},
+		})
+		client, err := newForgejo(ctx, "token")
+		require.NoError(t, err)
+		require.Equal(t, "https://forgejo.example.com/api/v1", client.apiURL)
+	})
+
+	t.Run("template error", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ForgejoURLs: config.ForgejoURLs{API: "{{ .Nope }}"},
+		})
+		_, err := newForgejo(ctx, "token")
+		testlib.RequireTemplateError(t, err)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ForgejoURLs: config.ForgejoURLs{API: "codeberg.org"},
+		})
+		_, err := newForgejo(ctx, "token")
+		require.EqualError(t, err, `invalid Forgejo API URL: "codeberg.org"`)
+	})
+}
+
+func TestForgejoChangelog(t *testing.T) {
+	client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/v1/repos/owner/repo/compare/v1.0.0...v1.1.0", r.URL.Path)
+		fmt.Fprint(w, `{"commits":[{
+			"sha":"abc123",
+			"author":{"login":"jdoe","full_name":"John Doe","email":"jdoe@example.com"},
+			"commit":{"message":"feat: foo\n\nCo-authored-by: Jane <jane@example.com>"}
+		},{
+			"sha":"def456",
+			"author":null,
+			"commit":{"message":"fix: bar"}
+		}]}`)
+	})
+
+	items, err := client.Changelog(ctx, Repo{Owner: "owner", Name: "repo"}, "v1.0.0", "v1.1.0")
+	require.NoError(t, err)
+	require.Equal(t, []ChangelogItem{
+		{
+			SHA:     "abc123",
+			Message: "feat: foo",
+			Authors: []Author{
+				{Name: "John Doe", Email: "jdoe@example.com", Username: "jdoe"},
+				{Name: "Jane", Email: "jane@example.com"},
+			},
+			AuthorName:     "John Doe",
+			AuthorEmail:    "jdoe@example.com",
+			AuthorUsername: "jdoe",
+		},
+		{
+			SHA:     "def456",
+			Message: "fix: bar",
+		},
+	}, items)
+}
+
+func TestForgejoCloseMilestone(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPatch, r.Method)
+			require.Equal(t, "/api/v1/repos/owner/repo/milestones/v1.0.0", r.URL.Path)
+			require.JSONEq(t, `{"state":"closed","title":"v1.0.0"}`, readBody(t, r))
+			fmt.Fprint(w, `{"id":1}`)
+		})
+		require.NoError(t, client.CloseMilestone(ctx, Repo{Owner: "owner", Name: "repo"}, "v1.0.0"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"milestone not found"}`)
+		})
+		err := client.CloseMilestone(ctx, Repo{Owner: "owner", Name: "repo"}, "v1.0.0")
+		require.ErrorAs(t, err, &ErrNoMilestoneFound{})
+	})
+}
+
+func TestForgejoCreateFile(t *testing.T) {
+	author := config.CommitAuthor{Name: "bot", Email: "bot@example.com"}
+
+	t.Run("new file on default branch", func(t *testing.T) {
+		var created map[string]any
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/api/v1/repos/owner/tap":
+				fmt.Fprint(w, `{"default_branch":"trunk"}`)
+			case r.Method == http.MethodGet && r.URL.Path == "/api/v1/repos/owner/tap/contents/Formula/foo.rb":
+				require.Equal(t, "trunk", r.URL.Query().Get("ref"))
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"message":"not found"}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/api/v1/repos/owner/tap/contents/Formula/foo.rb":
+				require.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &created))
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		})
+
+		require.NoError(t, client.CreateFile(ctx, author, Repo{Owner: "owner", Name: "tap"}, []byte("content"), "Formula/foo.rb", "update foo"))
+		require.Equal(t, "trunk", created["branch"])
+		require.Equal(t, "update foo", created["message"])
+		require.Equal(t, base64.StdEncoding.EncodeToString([]byte("content")), created["content"])
+		require.Equal(t, map[string]any{"name": "bot", "email": "bot@example.com"}, created["author"])
+		require.NotContains(t, created, "sha")
+	})
+
+	t.Run("update existing file", func(t *testing.T) {
+		var updated map[string]any
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/v1/repos/owner/tap/contents/foo.rb", r.URL.Path)
+			switch r.Method {
+			case http.MethodGet:
+				require.Equal(t, "main", r.URL.Query().Get("ref"))
+				fmt.Fprint(w, `{"sha":"oldsha"}`)
+			case http.MethodPut:
+				require.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &updated))
+				fmt.Fprint(w, `{}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		})
+
+		require.NoError(t, client.CreateFile(ctx, author, Repo{Owner: "owner", Name: "tap", Branch: "main"}, []byte("content"), "foo.rb", "update foo"))
+		require.Equal(t, "oldsha", updated["sha"])
+		require.Equal(t, "main", updated["branch"])
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"token does not have required scope"}`)
+		})
+
+		err := client.CreateFile(ctx, author, Repo{Owner: "owner", Name: "tap", Branch: "main"}, []byte("content"), "foo.rb", "update foo")
+		require.ErrorContains(t, err, `could not get "foo.rb"`)
+		require.ErrorContains(t, err, "token does not have required scope")
+	})
+
+	t.Run("default branch error", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		err := client.CreateFile(ctx, author, Repo{Owner: "owner", Name: "tap"}, []byte("content"), "foo.rb", "update foo")
+		require.ErrorContains(t, err, "404 Not Found")
+	})
+}
+
+func TestForgejoCreateRelease(t *testing.T) {
+	t.Run("new", func(t *testing.T) {
+		var created forgejoReleaseOptions
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				require.Equal(t, "/api/v1/repos/owner/repo/releases", r.URL.Path)
+				require.Equal(t, "true", r.URL.Query().Get("draft"))
+				fmt.Fprint(w, `[{"id":1,"tag_name":"v0.9.0"}]`)
+			case http.MethodPost:
+				require.Equal(t, "/api/v1/repos/owner/repo/releases", r.URL.Path)
+				require.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &created))
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id":42}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		})
+		ctx.PreRelease = true
+
+		id, err := client.CreateRelease(ctx, "notes")
+		require.NoError(t, err)
+		require.Equal(t, "42", id)
+		require.Equal(t, forgejoReleaseOptions{
+			TagName:    "v1.0.0",
+			Target:     "deadbeef",
+			Name:       "v1.0.0",
+			Body:       "notes",
+			Draft:      true,
+			Prerelease: true,
+		}, created)
+	})
+
+	t.Run("existing", func(t *testing.T) {
+		var updated forgejoReleaseOptions
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, `[{"id":7,"tag_name":"v1.0.0","body":"existing notes","draft":false}]`)
+			case http.MethodPatch:
+				require.Equal(t, "/api/v1/repos/owner/repo/releases/7", r.URL.Path)
+				require.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &updated))
+				fmt.Fprint(w, `{"id":7}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		})
+		ctx.Config.Release.TargetCommitish = "main"
+
+		id, err := client.CreateRelease(ctx, "notes")
+		require.NoError(t, err)
+		require.Equal(t, "7", id)
+		require.Equal(t, "existing notes", updated.Body)
+		require.Equal(t, "main", updated.Target)
+		require.False(t, updated.Draft)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				fmt.Fprint(w, `[]`)
+				return
+			}
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message":"tag is protected"}`)
+		})
+
+		_, err := client.CreateRelease(ctx, "notes")
+		require.ErrorContains(t, err, "could not create release")
+		require.ErrorContains(t, err, "tag is protected")
+	})
+
+	t.Run("template error", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, nil)
+		ctx.Config.Release.NameTemplate = "{{ .Nope }}"
+		_, err := client.CreateRelease(ctx, "notes")
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestForgejoPublishRelease(t *testing.T) {
+	t.Run("publish", func(t *testing.T) {
+		var called bool
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			require.Equal(t, http.MethodPatch, r.Method)
+			require.Equal(t, "/api/v1/repos/owner/repo/releases/42", r.URL.Path)
+			require.JSONEq(t, `{"draft":false}`, readBody(t, r))
+			fmt.Fprint(w, `{"id":42}`)
+		})
+		require.NoError(t, client.PublishRelease(ctx, "42"))
+		require.True(t, called)
+	})
+
+	t.Run("draft", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, func(_ http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		})
+		ctx.Config.Release.Draft = true
+		require.NoError(t, client.PublishRelease(ctx, "42"))
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, nil)
+		require.ErrorContains(t, client.PublishRelease(ctx, "nope"), `non-numeric release ID "nope"`)
+	})
+}
+
+func TestForgejoUpload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foo.tar.gz")
+	require.NoError(t, os.WriteFile(path, []byte("archive"), 0o644))
+	art := &artifact.Artifact{Name: "foo.tar.gz", Path: path}
+
+	t.Run("upload", func(t *testing.T) {
+		var uploaded string
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/api/v1/repos/owner/repo/releases/42/assets", r.URL.Path)
+			require.Equal(t, "foo.tar.gz", r.URL.Query().Get("name"))
+			file, header, err := r.FormFile("attachment")
+			require.NoError(t, err)
+			defer file.Close()
+			require.Equal(t, "foo.tar.gz", header.Filename)
+			bts, err := io.ReadAll(file)
+			require.NoError(t, err)
+			uploaded = string(bts)
+			w.WriteHeader(http.StatusCreated)
+		})
+		require.NoError(t, client.Upload(ctx, "42", art))
+		require.Equal(t, "archive", uploaded)
+	})
+
+	t.Run("replace existing", func(t *testing.T) {
+		var deleted []string
+		var uploads int
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, `[{"id":1,"name":"foo.tar.gz"},{"id":2,"name":"checksums.txt"}]`)
+			case http.MethodDelete:
+				deleted = append(deleted, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodPost:
+				uploads++
+				w.WriteHeader(http.StatusCreated)
+			}
+		})
+		ctx.Config.Release.ReplaceExistingArtifacts = true
+		require.NoError(t, client.Upload(ctx, "42", art))
+		require.Equal(t, []string{"/api/v1/repos/owner/repo/releases/42/assets/1"}, deleted)
+		require.Equal(t, 1, uploads)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"file type is not allowed"}`)
+		})
+		require.ErrorContains(t, client.Upload(ctx, "42", art), "file type is not allowed")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, nil)
+		err := client.Upload(ctx, "42", &artifact.Artifact{Name: "nope", Path: filepath.Join(t.TempDir(), "nope")})
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestForgejoOpenPullRequest(t *testing.T) {
+	t.Run("from fork", func(t *testing.T) {
+		var opts forgejoPullRequestOptions
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/api/v1/repos/upstream/tap":
+				fmt.Fprint(w, `{"default_branch":"main"}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/api/v1/repos/upstream/tap/pulls":
+				require.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &opts))
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"html_url":"https://codeberg.org/upstream/tap/pulls/1"}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		})
+
+		require.NoError(t, client.OpenPullRequest(
+			ctx,
+			Repo{Owner: "upstream", Name: "tap"},
+			Repo{Owner: "me", Name: "tap", Branch: "foo-1.0.0"},
+			"foo 1.0.0", "body",
+			true,
+		))
+		require.Equal(t, "main", opts.Base)
+		require.Equal(t, "me:foo-1.0.0", opts.Head)
+		require.Equal(t, "WIP: foo 1.0.0", opts.Title)
+		require.Contains(t, opts.Body, "body")
+	})
+
+	t.Run("same repo", func(t *testing.T) {
+		var opts forgejoPullRequestOptions
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/v1/repos/me/tap/pulls", r.URL.Path)
+			require.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &opts))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{}`)
+		})
+
+		require.NoError(t, client.OpenPullRequest(
+			ctx,
+			Repo{Branch: "main"},
+			Repo{Owner: "me", Name: "tap", Branch: "foo-1.0.0"},
+			"foo 1.0.0", "",
+			false,
+		))
+		require.Equal(t, "foo-1.0.0", opts.Head)
+		require.Equal(t, "foo 1.0.0", opts.Title)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message":"pull request already exists for these targets"}`)
+		})
+
+		require.NoError(t, client.OpenPullRequest(
+			ctx,
+			Repo{Owner: "me", Name: "tap", Branch: "main"},
+			Repo{Owner: "me", Name: "tap", Branch: "foo-1.0.0"},
+			"foo 1.0.0", "",
+			false,
+		))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+
+		err := client.OpenPullRequest(
+			ctx,
+			Repo{Owner: "me", Name: "tap", Branch: "main"},
+			Repo{Owner: "me", Name: "tap", Branch: "foo-1.0.0"},
+			"foo 1.0.0", "",
+			false,
+		)
+		require.ErrorContains(t, err, "could not create pull request")
+	})
+}
+
+func TestForgejoReleaseURLTemplate(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Env: []string{"FORGEJO_HOST=forgejo.example.com"},
+		ForgejoURLs: config.ForgejoURLs{
+			Download: "https://{{ .Env.FORGEJO_HOST }}",
+		},
+		Release: config.Release{
+			Forgejo: config.Repo{Owner: "owner", Name: "repo"},
+		},
+	})
+	client, err := newForgejo(ctx, "token")
+	require.NoError(t, err)
+
+	url, err := client.ReleaseURLTemplate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "https://forgejo.example.com/owner/repo/releases/download/{{ urlPathEscape .Tag }}/{{ .ArtifactName }}", url)
+
+	ctx.Config.ForgejoURLs.Download = "{{ .Nope }}"
+	_, err = client.ReleaseURLTemplate(ctx)
+	testlib.RequireTemplateError(t, err)
+}
+
+func TestForgejoAuthorization(t *testing.T) {
+	client, ctx := newForgejoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "token forgejotoken", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"default_branch":"main"}`)
+	})
+	branch, err := client.getDefaultBranch(ctx, Repo{Owner: "owner", Name: "repo"})
+	require.NoError(t, err)
+	require.Equal(t, "main", branch)
+}
+
+func newForgejoTestClient(tb testing.TB, handler http.HandlerFunc) (*forgejoClient, *context.Context) {
+	tb.Helper()
+	if handler == nil {
+		handler = func(_ http.ResponseWriter, r *http.Request) {
+			tb.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+	srv := httptest.NewServer(handler)
+	tb.Cleanup(srv.Close)
+
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ForgejoURLs: config.ForgejoURLs{
+			API: srv.URL + "/api/v1",
+		},
+		Release: config.Release{
+			Forgejo:      config.Repo{Owner: "owner", Name: "repo"},
+			NameTemplate: "{{ .Tag }}",
+		},
+	}, testctx.ForgejoTokenType, testctx.WithCurrentTag("v1.0.0"), testctx.WithCommit("deadbeef"))
+	client, err := newForgejo(ctx, ctx.Token)
+	require.NoError(tb, err)
+	return client, ctx
+}
+
+func readBody(tb testing.TB, r *http.Request) string {
+	tb.Helper()
+	bts, err := io.ReadAll(r.Body)
+	require.NoError(tb, err)
+	return string(bts)
+}
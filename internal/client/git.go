@@ -137,7 +137,7 @@ func (g *gitClient) CreateFiles(
 		if err := os.MkdirAll(filepath.Dir(location), 0o755); err != nil {
 			return fmt.Errorf("failed to create parent dirs for %s: %w", file.Path, err)
 		}
-		if err := os.WriteFile(location, file.Content, 0o644); err != nil {
+		if err := os.WriteFile(location, file.Content, cmp.Or(file.Mode, 0o644)); err != nil {
 			return fmt.Errorf("failed to write %s: %w", file.Path, err)
 		}
 		log.
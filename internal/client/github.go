@@ -3,6 +3,7 @@ package client
 import (
 	"cmp"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -35,6 +36,7 @@ var (
 	_ ReleaseNotesGenerator = &githubClient{}
 	_ PullRequestOpener     = &githubClient{}
 	_ ForkSyncer            = &githubClient{}
+	_ Attester              = &githubClient{}
 )
 
 type githubClient struct {
@@ -313,7 +315,7 @@ func (c *githubClient) getPRTemplate(ctx *context.Context, repo Repo) (string, e
 func (c *githubClient) OpenPullRequest(
 	ctx *context.Context,
 	base, head Repo,
-	title string,
+	title, body string,
 	draft bool,
 ) error {
 	c.checkRateLimit(ctx)
@@ -348,7 +350,7 @@ func (c *githubClient) OpenPullRequest(
 				Title: &title,
 				Base:  &base.Branch,
 				Head:  new(headString(base, head)),
-				Body:  new(strings.Join([]string{tpl, prFooter}, "\n")),
+				Body:  new(prBody(body, tpl)),
 				Draft: &draft,
 			},
 		)
@@ -405,6 +407,33 @@ func (c *githubClient) SyncFork(ctx *context.Context, head, base Repo) error {
 	return nil
 }
 
+// CreateAttestation stores the given sigstore bundle as an artifact
+// attestation in the given repository.
+func (c *githubClient) CreateAttestation(ctx *context.Context, repo Repo, bundle []byte) error {
+	c.checkRateLimit(ctx)
+	var result struct {
+		ID int64 `json:"id"`
+	}
+	_, resp, err := githubDo(ctx, func() (any, *github.Response, error) {
+		req, err := c.client.NewRequest(
+			ctx,
+			http.MethodPost,
+			fmt.Sprintf("repos/%s/%s/attestations", repo.Owner, repo.Name),
+			map[string]json.RawMessage{"bundle": bundle},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		resp, err := c.client.Do(req, &result)
+		return nil, resp, err
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, bodyOf(resp))
+	}
+	log.WithField("id", result.ID).Debug("attestation created")
+	return nil
+}
+
 func (c *githubClient) CreateFile(
 	ctx *context.Context,
 	commitAuthor config.CommitAuthor,
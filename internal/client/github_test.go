@@ -447,7 +447,7 @@ func TestGitHubOpenPullRequestCrossRepo(t *testing.T) {
 			assert.NoError(t, json.Unmarshal(got, &pr))
 			assert.Equal(t, "main", pr.GetBase())
 			assert.Equal(t, "someoneelse:something:foo", pr.GetHead())
-			assert.Equal(t, testPRTemplate+"\n"+prFooter, pr.GetBody())
+			assert.Equal(t, "cc/ @someone\n"+testPRTemplate+"\n"+prFooter, pr.GetBody())
 			serveTestFile(t, w, "testdata/github/pull.json")
 			return
 		}
@@ -472,7 +472,7 @@ func TestGitHubOpenPullRequestCrossRepo(t *testing.T) {
 		Name:   "something",
 		Branch: "foo",
 	}
-	require.NoError(t, client.OpenPullRequest(ctx, base, head, "some title", false))
+	require.NoError(t, client.OpenPullRequest(ctx, base, head, "some title", "cc/ @someone", false))
 }
 
 func TestGitHubOpenPullRequestHappyPath(t *testing.T) {
@@ -511,7 +511,7 @@ func TestGitHubOpenPullRequestHappyPath(t *testing.T) {
 		Branch: "main",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{}, "some title", false))
+	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{}, "some title", "", false))
 }
 
 func TestGitHubOpenPullRequestNoBaseBranchDraft(t *testing.T) {
@@ -560,7 +560,7 @@ func TestGitHubOpenPullRequestNoBaseBranchDraft(t *testing.T) {
 
 	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{
 		Branch: "foo",
-	}, "some title", true))
+	}, "some title", "", true))
 }
 
 func TestGitHubOpenPullRequestPRExists(t *testing.T) {
@@ -595,7 +595,7 @@ func TestGitHubOpenPullRequestPRExists(t *testing.T) {
 		Branch: "main",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{}, "some title", false))
+	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{}, "some title", "", false))
 }
 
 func TestGitHubOpenPullRequestBaseEmpty(t *testing.T) {
@@ -635,7 +635,7 @@ func TestGitHubOpenPullRequestBaseEmpty(t *testing.T) {
 		Branch: "foo",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, Repo{}, repo, "some title", false))
+	require.NoError(t, client.OpenPullRequest(ctx, Repo{}, repo, "some title", "", false))
 }
 
 func TestGitHubOpenPullRequestHeadEmpty(t *testing.T) {
@@ -675,7 +675,7 @@ func TestGitHubOpenPullRequestHeadEmpty(t *testing.T) {
 		Branch: "main",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{}, "some title", false))
+	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{}, "some title", "", false))
 }
 
 func TestGitHubCreateFileHappyPathCreate(t *testing.T) {
@@ -1921,7 +1921,7 @@ func TestGitHubOpenPullRequestDefaultBranchError(t *testing.T) {
 		ctx,
 		Repo{Owner: "someone", Name: "something"},
 		Repo{Branch: "foo"},
-		"some title", false,
+		"some title", "", false,
 	)
 	require.Error(t, err)
 }
@@ -1954,11 +1954,60 @@ func TestGitHubOpenPullRequest422(t *testing.T) {
 		ctx,
 		Repo{Owner: "someone", Name: "something", Branch: "main"},
 		Repo{Branch: "foo"},
-		"some title", false,
+		"some title", "", false,
 	)
 	require.NoError(t, err)
 }
 
+func TestGitHubCreateAttestation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+		srv := githubTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			if r.URL.Path == "/api/v3/repos/someone/something/attestations" && r.Method == http.MethodPost {
+				bts, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"bundle":{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json"}}`, string(bts))
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id":123}`)
+				return
+			}
+			t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+		})
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			GitHubURLs: config.GitHubURLs{API: srv.URL},
+		})
+		client, err := newGitHub(ctx, "test-token")
+		require.NoError(t, err)
+		require.NoError(t, client.CreateAttestation(
+			ctx,
+			Repo{Owner: "someone", Name: "something"},
+			[]byte(`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json"}`),
+		))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		srv := githubTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message":"invalid bundle"}`)
+		})
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			GitHubURLs: config.GitHubURLs{API: srv.URL},
+		})
+		client, err := newGitHub(ctx, "test-token")
+		require.NoError(t, err)
+		require.ErrorContains(t, client.CreateAttestation(
+			ctx,
+			Repo{Owner: "someone", Name: "something"},
+			[]byte(`{}`),
+		), "invalid bundle")
+	})
+}
+
 func TestGitHubSyncForkDefaultBranchError(t *testing.T) {
 	t.Parallel()
 	srv := githubTestServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -707,7 +707,7 @@ func checkUseJobToken(ctx context.Context, token string) bool {
 func (c *gitlabClient) OpenPullRequest(
 	ctx *context.Context,
 	base, head Repo,
-	title string,
+	title, body string,
 	draft bool,
 ) error {
 	if err := c.checkIsPrivateToken(); err != nil {
@@ -755,7 +755,7 @@ func (c *gitlabClient) OpenPullRequest(
 		SourceBranch: &head.Branch,
 		TargetBranch: &base.Branch,
 		Title:        &title,
-		Description:  new(prFooter),
+		Description:  new(prBody(body)),
 	}
 
 	if targetProjectID != 0 {
@@ -813,7 +813,7 @@ func TestGitLabOpenPullRequestCrossRepo(t *testing.T) {
 			assert.Equal(t, "foo", pr.SourceBranch)
 			assert.Equal(t, "some title", pr.Title)
 			assert.EqualValues(t, 32156, pr.TargetProjectID)
-			assert.Equal(t, prFooter, pr.Description)
+			assert.Equal(t, "cc/ @someone\n"+prFooter, pr.Description)
 
 			_, err = io.Copy(w, strings.NewReader(`{"web_url": "https://gitlab.com/someoneelse/something/merge_requests/1"}`))
 			assert.NoError(t, err)
@@ -843,7 +843,7 @@ func TestGitLabOpenPullRequestCrossRepo(t *testing.T) {
 		Name:   "something",
 		Branch: "foo",
 	}
-	require.NoError(t, client.OpenPullRequest(ctx, base, head, "some title", false))
+	require.NoError(t, client.OpenPullRequest(ctx, base, head, "some title", "cc/ @someone", false))
 }
 
 func TestGitLabOpenPullRequestBaseEmpty(t *testing.T) {
@@ -898,7 +898,7 @@ func TestGitLabOpenPullRequestBaseEmpty(t *testing.T) {
 		Branch: "foo",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, Repo{}, repo, "some title", false))
+	require.NoError(t, client.OpenPullRequest(ctx, Repo{}, repo, "some title", "", false))
 }
 
 func TestGitLabOpenPullRequestDraft(t *testing.T) {
@@ -953,7 +953,7 @@ func TestGitLabOpenPullRequestDraft(t *testing.T) {
 		Branch: "main",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, Repo{}, repo, "some title", true))
+	require.NoError(t, client.OpenPullRequest(ctx, Repo{}, repo, "some title", "", true))
 }
 
 func TestGitLabOpenPullRequestBaseBranchGiven(t *testing.T) {
@@ -1002,7 +1002,7 @@ func TestGitLabOpenPullRequestBaseBranchGiven(t *testing.T) {
 		Branch: "foo",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, Repo{Branch: "main"}, repo, "some title", false))
+	require.NoError(t, client.OpenPullRequest(ctx, Repo{Branch: "main"}, repo, "some title", "", false))
 }
 
 func TestGitLabVersionEnv(t *testing.T) {
@@ -1204,7 +1204,7 @@ func TestGitLabOpenPullRequestGetProjectError(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{GitLabURLs: config.GitLabURLs{API: srv.URL}})
 	client, err := newGitLab(ctx, "test-token", gitlab.WithoutRetries())
 	require.NoError(t, err)
-	err = client.OpenPullRequest(ctx, Repo{Owner: "someone", Name: "something", Branch: "main"}, Repo{Owner: "someoneelse", Name: "something", Branch: "feature"}, "test PR", false)
+	err = client.OpenPullRequest(ctx, Repo{Owner: "someone", Name: "something", Branch: "main"}, Repo{Owner: "someoneelse", Name: "something", Branch: "feature"}, "test PR", "", false)
 	require.Error(t, err)
 }
 
@@ -1227,7 +1227,7 @@ func TestGitLabOpenPullRequestDefaultBranchError(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{GitLabURLs: config.GitLabURLs{API: srv.URL}})
 	client, err := newGitLab(ctx, "test-token", gitlab.WithoutRetries())
 	require.NoError(t, err)
-	err = client.OpenPullRequest(ctx, Repo{}, Repo{Owner: "someone", Name: "something", Branch: "feature"}, "test PR", false)
+	err = client.OpenPullRequest(ctx, Repo{}, Repo{Owner: "someone", Name: "something", Branch: "feature"}, "test PR", "", false)
 	require.Error(t, err)
 }
 
@@ -1252,7 +1252,7 @@ func TestGitLabOpenPullRequestCreateError(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{GitLabURLs: config.GitLabURLs{API: srv.URL}})
 	client, err := newGitLab(ctx, "test-token", gitlab.WithoutRetries())
 	require.NoError(t, err)
-	err = client.OpenPullRequest(ctx, Repo{Owner: "someone", Name: "something", Branch: "main"}, Repo{Owner: "someone", Name: "something", Branch: "feature"}, "test PR", false)
+	err = client.OpenPullRequest(ctx, Repo{Owner: "someone", Name: "something", Branch: "main"}, Repo{Owner: "someone", Name: "something", Branch: "feature"}, "test PR", "", false)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "could not create pull request")
 }
@@ -16,6 +16,7 @@ var (
 	_ ReleaseNotesGenerator = &Mock{}
 	_ PullRequestOpener     = &Mock{}
 	_ ForkSyncer            = &Mock{}
+	_ Attester              = &Mock{}
 )
 
 func NewMock() *Mock {
@@ -41,7 +42,16 @@ type Mock struct {
 	ReleaseNotes         string
 	ReleaseNotesParams   []string
 	OpenedPullRequest    bool
+	PullRequestBody      string
 	SyncedFork           bool
+	Attestations         [][]byte
+}
+
+func (c *Mock) CreateAttestation(_ *context.Context, _ Repo, bundle []byte) error {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	c.Attestations = append(c.Attestations, bundle)
+	return nil
 }
 
 func (c *Mock) SyncFork(_ *context.Context, _ Repo, _ Repo) error {
@@ -49,8 +59,9 @@ func (c *Mock) SyncFork(_ *context.Context, _ Repo, _ Repo) error {
 	return nil
 }
 
-func (c *Mock) OpenPullRequest(_ *context.Context, _, _ Repo, _ string, _ bool) error {
+func (c *Mock) OpenPullRequest(_ *context.Context, _, _ Repo, _, body string, _ bool) error {
 	c.OpenedPullRequest = true
+	c.PullRequestBody = body
 	return nil
 }
 
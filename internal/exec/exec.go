@@ -138,6 +138,8 @@ func filterArtifacts(ctx *context.Context, publisher config.Publisher) []*artifa
 		artifact.SBOM,
 		artifact.PySdist,
 		artifact.PyWheel,
+		artifact.DebugSymbols,
+		artifact.ArchiveManifest,
 	}
 
 	if publisher.Checksum {
@@ -0,0 +1,39 @@
+// Package execx runs external commands with the release environment.
+package execx
+
+import (
+	"os/exec"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Cmder is a special interface to execute external commands.
+//
+// The intention is to be used to wrap [Std] and provide the ability to
+// create a fake one for testing.
+type Cmder interface {
+	// Exec executes a command, returning its combined output.
+	Exec(*context.Context, string, ...string) ([]byte, error)
+}
+
+// Std executes commands with [Command].
+type Std struct{}
+
+var _ Cmder = Std{}
+
+// Exec implements [Cmder].
+func (Std) Exec(ctx *context.Context, name string, args ...string) ([]byte, error) {
+	return Command(ctx, name, args...).CombinedOutput()
+}
+
+// Command returns a command that runs name with the given arguments, and
+// with the context environment.
+func Command(ctx *context.Context, name string, args ...string) *exec.Cmd {
+	log.WithField("cmd", name).
+		WithField("args", args).
+		Debug("running")
+	c := exec.CommandContext(ctx, name, args...)
+	c.Env = append(ctx.Env.Strings(), c.Environ()...)
+	return c
+}
@@ -0,0 +1,28 @@
+package execx
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExec(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses sh")
+	ctx := testctx.Wrap(t.Context(), testctx.WithEnv(map[string]string{
+		"EXECX_TEST": "from context",
+	}))
+
+	out, err := Std{}.Exec(ctx, "sh", "-c", `echo "$EXECX_TEST"; echo err >&2`)
+	require.NoError(t, err)
+	require.Equal(t, "from context\nerr\n", string(out))
+}
+
+func TestExecError(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses sh")
+	ctx := testctx.Wrap(t.Context())
+	out, err := Std{}.Exec(ctx, "sh", "-c", "echo nope; exit 1")
+	require.EqualError(t, err, "exit status 1")
+	require.Equal(t, "nope\n", string(out))
+}
@@ -234,6 +234,15 @@ func uploadOne(ctx *context.Context, upload config.Upload, kind string, check Re
 
 func uploadWithFilter(ctx *context.Context, upload *config.Upload, filter artifact.Filter, kind string, check ResponseChecker) error {
 	var artifacts []*artifact.Artifact
+	if !upload.ExtraFilesOnly {
+		artifacts = ctx.Artifacts.Filter(filter).List()
+	}
+	return UploadArtifacts(ctx, upload, artifacts, kind, check)
+}
+
+// UploadArtifacts uploads the given artifacts, as well as the extra files of
+// the given upload.
+func UploadArtifacts(ctx *context.Context, upload *config.Upload, artifacts []*artifact.Artifact, kind string, check ResponseChecker) error {
 	extraFiles, err := extrafiles.Find(ctx, upload.ExtraFiles)
 	if err != nil {
 		return err
@@ -247,10 +256,6 @@ func uploadWithFilter(ctx *context.Context, upload *config.Upload, filter artifa
 		})
 	}
 
-	if !upload.ExtraFilesOnly {
-		artifacts = append(artifacts, ctx.Artifacts.Filter(filter).List()...)
-	}
-
 	if len(artifacts) == 0 {
 		log.Info("no artifacts found")
 	}
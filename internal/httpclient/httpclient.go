@@ -0,0 +1,46 @@
+// Package httpclient provides the HTTP client used by the announcers.
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+var errInvalidProxy = errors.New("announce: invalid proxy url")
+
+// New returns a new HTTP client using the transport returned by [Transport].
+func New(ctx *context.Context) (*http.Client, error) {
+	transport, err := Transport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// Transport returns a clone of [http.DefaultTransport] which sends requests
+// through the proxy set in 'announce.proxy'.
+// If no proxy is set, the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment
+// variables are honored instead.
+func Transport(ctx *context.Context) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	proxy, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if proxy == "" {
+		transport.Proxy = http.ProxyFromEnvironment
+		return transport, nil
+	}
+
+	// the url might contain credentials, so we don't add it to the error.
+	u, err := url.Parse(proxy)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errInvalidProxy
+	}
+	transport.Proxy = http.ProxyURL(u)
+	return transport, nil
+}
@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	require.NoError(t, err)
+
+	t.Run("proxy", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Proxy: "http://{{ .Env.PROXY_HOST }}:3128",
+			},
+		}, testctx.WithEnv(map[string]string{
+			"PROXY_HOST": "proxy.local",
+		}))
+		transport, err := Transport(ctx)
+		require.NoError(t, err)
+		u, err := transport.Proxy(req)
+		require.NoError(t, err)
+		require.Equal(t, "http://proxy.local:3128", u.String())
+	})
+
+	t.Run("env", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		transport, err := Transport(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, transport.Proxy)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Proxy: "user:secret@proxy.local",
+			},
+		})
+		_, err := Transport(ctx)
+		require.ErrorIs(t, err, errInvalidProxy)
+		require.NotContains(t, err.Error(), "secret")
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Proxy: "{{ .Foo }",
+			},
+		})
+		_, err := Transport(ctx)
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Proxy: "http://proxy.local:3128",
+		},
+	})
+	client, err := New(ctx)
+	require.NoError(t, err)
+	require.IsType(t, &http.Transport{}, client.Transport)
+
+	_, err = New(testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Proxy: "{{ .Foo }",
+		},
+	}))
+	testlib.RequireTemplateError(t, err)
+}
@@ -0,0 +1,121 @@
+// Package icns creates macOS icons (.icns files) from PNG images.
+package icns
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// types are the icon types with PNG data, and their size in pixels.
+//
+// See: https://en.wikipedia.org/wiki/Apple_Icon_Image_format
+var types = []struct {
+	name string
+	size int
+}{
+	{"icp4", 16},
+	{"icp5", 32},
+	{"ic11", 32}, // 16x16@2x
+	{"icp6", 64},
+	{"ic12", 64}, // 32x32@2x
+	{"ic07", 128},
+	{"ic08", 256},
+	{"ic13", 256}, // 128x128@2x
+	{"ic09", 512},
+	{"ic14", 512}, // 256x256@2x
+	{"ic10", 1024},
+}
+
+// Encode writes the given PNG image as an icns file to w.
+//
+// The image must be square, and it is scaled down to all the icon sizes
+// smaller than or equal to its own size.
+func Encode(w io.Writer, r io.Reader) error {
+	src, err := png.Decode(r)
+	if err != nil {
+		return fmt.Errorf("icns: could not decode png: %w", err)
+	}
+	bounds := src.Bounds()
+	if bounds.Dx() != bounds.Dy() {
+		return fmt.Errorf("icns: image must be square, got %dx%d", bounds.Dx(), bounds.Dy())
+	}
+	if bounds.Dx() < types[0].size {
+		return fmt.Errorf("icns: image must be at least %dx%d", types[0].size, types[0].size)
+	}
+
+	encoded := map[int][]byte{}
+	var body bytes.Buffer
+	for _, t := range types {
+		if t.size > bounds.Dx() {
+			break
+		}
+		data, ok := encoded[t.size]
+		if !ok {
+			var b bytes.Buffer
+			if err := png.Encode(&b, scale(src, t.size)); err != nil {
+				return fmt.Errorf("icns: could not encode png: %w", err)
+			}
+			data = b.Bytes()
+			encoded[t.size] = data
+		}
+		body.WriteString(t.name)
+		_ = binary.Write(&body, binary.BigEndian, uint32(8+len(data)))
+		body.Write(data)
+	}
+	if body.Len() == 0 {
+		return errors.New("icns: no icons")
+	}
+
+	var header bytes.Buffer
+	header.WriteString("icns")
+	_ = binary.Write(&header, binary.BigEndian, uint32(8+body.Len()))
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+	_, err = w.Write(body.Bytes())
+	return err
+}
+
+// scale scales the given square image down to size x size, averaging the
+// source pixels covered by each destination pixel.
+func scale(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	if bounds.Dx() == size {
+		return src
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	ratio := float64(bounds.Dx()) / float64(size)
+	for y := range size {
+		y0 := bounds.Min.Y + int(float64(y)*ratio)
+		y1 := max(bounds.Min.Y+int(float64(y+1)*ratio), y0+1)
+		for x := range size {
+			x0 := bounds.Min.X + int(float64(x)*ratio)
+			x1 := max(bounds.Min.X+int(float64(x+1)*ratio), x0+1)
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// RGBA returns alpha-premultiplied values.
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,95 @@
+package icns
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Encode(&out, pngOf(t, 128, 128)))
+
+	bts := out.Bytes()
+	require.Equal(t, "icns", string(bts[:4]))
+	require.Equal(t, uint32(len(bts)), binary.BigEndian.Uint32(bts[4:8]))
+
+	sizes := map[string]int{}
+	for i := 8; i < len(bts); {
+		name := string(bts[i : i+4])
+		size := int(binary.BigEndian.Uint32(bts[i+4 : i+8]))
+		img, err := png.Decode(bytes.NewReader(bts[i+8 : i+size]))
+		require.NoError(t, err)
+		require.Equal(t, img.Bounds().Dx(), img.Bounds().Dy())
+		sizes[name] = img.Bounds().Dx()
+
+		r, g, b, a := img.At(0, 0).RGBA()
+		require.Equal(t, []uint32{0xffff, 0, 0, 0xffff}, []uint32{r, g, b, a}, name)
+		i += size
+	}
+	require.Equal(t, map[string]int{
+		"icp4": 16,
+		"icp5": 32,
+		"ic11": 32,
+		"icp6": 64,
+		"ic12": 64,
+		"ic07": 128,
+	}, sizes)
+}
+
+func TestEncodeErrors(t *testing.T) {
+	for name, tt := range map[string]struct {
+		img []byte
+		err string
+	}{
+		"not a png":  {[]byte("nope"), "icns: could not decode png"},
+		"not square": {pngOf(t, 32, 16).Bytes(), "icns: image must be square, got 32x16"},
+		"too small":  {pngOf(t, 8, 8).Bytes(), "icns: image must be at least 16x16"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorContains(t, Encode(&bytes.Buffer{}, bytes.NewReader(tt.img)), tt.err)
+		})
+	}
+}
+
+func TestScale(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := range 4 {
+		for x := range 4 {
+			if x < 2 {
+				src.Set(x, y, color.White)
+			} else {
+				src.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	dst := scale(src, 2)
+	require.Equal(t, image.Rect(0, 0, 2, 2), dst.Bounds())
+	require.Equal(t, color.NRGBAModel.Convert(color.White), dst.At(0, 1))
+	require.Equal(t, color.NRGBAModel.Convert(color.Black), dst.At(1, 0))
+
+	dst = scale(src, 1)
+	r, g, b, _ := dst.At(0, 0).RGBA()
+	require.Equal(t, []uint32{0x7f7f, 0x7f7f, 0x7f7f}, []uint32{r, g, b})
+
+	require.Equal(t, src, scale(src, 4))
+}
+
+func pngOf(tb testing.TB, w, h int) *bytes.Buffer {
+	tb.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{R: 0xff, A: 0xff})
+		}
+	}
+	var b bytes.Buffer
+	require.NoError(tb, png.Encode(&b, img))
+	return &b
+}
@@ -0,0 +1,105 @@
+// Package intoto records the stages of a release, and the commands run in
+// each of them, as in-toto links.
+//
+// See https://github.com/in-toto/attestation/blob/main/spec/predicates/link.md.
+package intoto
+
+import (
+	"slices"
+	"sync"
+)
+
+const (
+	// StatementType is the type of in-toto v1 statements.
+	StatementType = "https://in-toto.io/Statement/v1"
+
+	// LinkPredicateType is the predicate type of in-toto links.
+	LinkPredicateType = "https://in-toto.io/attestation/link/v0.3"
+)
+
+// Statement is an in-toto v1 statement about its subjects.
+type Statement struct {
+	Type          string               `json:"_type"`
+	Subject       []ResourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     Link                 `json:"predicate"`
+}
+
+// ResourceDescriptor describes a material or a product of a stage.
+type ResourceDescriptor struct {
+	URI    string            `json:"uri,omitempty"`
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// Link is an in-toto link predicate.
+type Link struct {
+	Name        string               `json:"name"`
+	Command     []string             `json:"command,omitempty"`
+	Materials   []ResourceDescriptor `json:"materials,omitempty"`
+	Byproducts  map[string]any       `json:"byproducts,omitempty"`
+	Environment map[string]any       `json:"environment,omitempty"`
+}
+
+// Stage is a recorded stage of the release.
+type Stage struct {
+	Name      string
+	Materials []ResourceDescriptor
+	Products  []ResourceDescriptor
+	Commands  [][]string
+}
+
+// Recorder records the stages of a release.
+//
+// It is safe for concurrent use, and a nil Recorder records nothing.
+type Recorder struct {
+	mu     sync.Mutex
+	stages []*Stage
+}
+
+// Start starts a new stage, with the given materials.
+func (r *Recorder) Start(name string, materials []ResourceDescriptor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stages = append(r.stages, &Stage{
+		Name:      name,
+		Materials: materials,
+	})
+}
+
+// Current returns the current stage, if any.
+func (r *Recorder) Current() *Stage {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.stages) == 0 {
+		return nil
+	}
+	return r.stages[len(r.stages)-1]
+}
+
+// Record records a command run in the current stage.
+func (r *Recorder) Record(command []string) {
+	if r == nil || len(command) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.stages) == 0 {
+		return
+	}
+	stage := r.stages[len(r.stages)-1]
+	stage.Commands = append(stage.Commands, slices.Clone(command))
+}
+
+// Stages returns the recorded stages.
+func (r *Recorder) Stages() []*Stage {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.stages)
+}
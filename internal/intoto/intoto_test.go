@@ -0,0 +1,46 @@
+package intoto
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	r.Record([]string{"go", "build"})
+	require.Nil(t, r.Current())
+	require.Empty(t, r.Stages())
+}
+
+func TestRecorder(t *testing.T) {
+	r := &Recorder{}
+	r.Record([]string{"ignored"})
+	require.Nil(t, r.Current())
+
+	materials := []ResourceDescriptor{{Name: "foo", Digest: map[string]string{"sha256": "abc"}}}
+	r.Start("build", materials)
+	cmd := []string{"go", "build"}
+	r.Record(cmd)
+	r.Record(nil)
+	cmd[0] = "changed"
+
+	r.Start("archive", nil)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			r.Record([]string{"tar"})
+		})
+	}
+	wg.Wait()
+
+	stages := r.Stages()
+	require.Len(t, stages, 2)
+	require.Equal(t, "build", stages[0].Name)
+	require.Equal(t, materials, stages[0].Materials)
+	require.Equal(t, [][]string{{"go", "build"}}, stages[0].Commands)
+	require.Equal(t, "archive", stages[1].Name)
+	require.Len(t, stages[1].Commands, 10)
+	require.Same(t, stages[1], r.Current())
+}
@@ -3,17 +3,29 @@ package announce
 
 import (
 	"fmt"
+	"io"
+	"slices"
+
+	"github.com/caarlos0/log"
 
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/errhandler"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/logging"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/bluesky"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/datadog"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/discord"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/discourse"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/feed"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/googlechat"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/irc"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/linkedin"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/mastodon"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/matrix"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/mattermost"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/newrelic"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/opencollective"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/opsgenie"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/pagerduty"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/reddit"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/slack"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/smtp"
@@ -32,16 +44,30 @@ type Announcer interface {
 	Announce(ctx *context.Context) error
 }
 
+// Previewer should be implemented by announcers that can render what they
+// would announce, without announcing it.
+type Previewer interface {
+	Preview(ctx *context.Context) (string, error)
+}
+
 //nolint:gochecknoglobals
 var announcers = []Announcer{
 	// XXX: keep asc sorting
 	bluesky.New(),
+	datadog.Pipe{},
 	discord.Pipe{},
 	discourse.Pipe{},
+	feed.Pipe{},
+	googlechat.Pipe{},
+	irc.Pipe{},
 	linkedin.Pipe{},
 	mastodon.Pipe{},
+	matrix.Pipe{},
 	mattermost.Pipe{},
+	newrelic.Pipe{},
 	opencollective.Pipe{},
+	opsgenie.Pipe{},
+	pagerduty.Pipe{},
 	reddit.Pipe{},
 	slack.Pipe{},
 	smtp.Pipe{},
@@ -65,13 +91,96 @@ func (Pipe) Skip(ctx *context.Context) (bool, error) {
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
+	ordered, err := sorted(ctx.Config.Announce.Order)
+	if err != nil {
+		return err
+	}
+
 	memo := errhandler.Memo{}
-	for _, announcer := range announcers {
+	for _, announcer := range ordered {
 		if err := skip.Maybe(
 			announcer,
 			logging.PadLog(announcer.String(), errhandler.Handle(announcer.Announce)),
 		)(ctx); err != nil {
+			err = fmt.Errorf("%s: %w", announcer.String(), err)
+			if ctx.FailFast || ctx.Config.Announce.FailFast {
+				return err
+			}
+			memo.Memorize(err)
+		}
+	}
+	return memo.Error()
+}
+
+// sorted returns the announcers in the given order, followed by the ones not
+// in it, in alphabetical order.
+func sorted(order []string) ([]Announcer, error) {
+	result := make([]Announcer, 0, len(announcers))
+	seen := map[string]bool{}
+	for _, name := range order {
+		idx := slices.IndexFunc(announcers, func(a Announcer) bool {
+			return a.String() == name
+		})
+		if idx == -1 {
+			return nil, fmt.Errorf("announce: invalid order: unknown announcer %q", name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, announcers[idx])
+	}
+	for _, announcer := range announcers {
+		if !seen[announcer.String()] {
+			result = append(result, announcer)
+		}
+	}
+	return result, nil
+}
+
+// Preview writes the payload of each enabled announcer to the given writer,
+// without announcing anything.
+func Preview(ctx *context.Context, w io.Writer) error {
+	skipped, err := Pipe{}.Skip(ctx)
+	if err != nil {
+		return err
+	}
+	if skipped {
+		log.Info("announce is skipped, nothing to preview")
+		return nil
+	}
+
+	ordered, err := sorted(ctx.Config.Announce.Order)
+	if err != nil {
+		return err
+	}
+
+	memo := errhandler.Memo{}
+	for _, announcer := range ordered {
+		if skipper, ok := announcer.(skip.ErrSkipper); ok {
+			skipped, err := skipper.Skip(ctx)
+			if err != nil {
+				memo.Memorize(fmt.Errorf("%s: %w", announcer.String(), err))
+				continue
+			}
+			if skipped {
+				continue
+			}
+		}
+
+		previewer, ok := announcer.(Previewer)
+		if !ok {
+			log.Warnf("%s: preview is not supported", announcer.String())
+			continue
+		}
+
+		payload, err := previewer.Preview(ctx)
+		if err != nil {
 			memo.Memorize(fmt.Errorf("%s: %w", announcer.String(), err))
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "--- %s\n%s\n\n", announcer.String(), payload); err != nil {
+			return err
 		}
 	}
 	return memo.Error()
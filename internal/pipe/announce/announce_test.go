@@ -1,11 +1,15 @@
 package announce
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/require"
 )
@@ -34,11 +38,215 @@ func TestAnnounce(t *testing.T) {
 	require.Len(t, merr.Errors, 2)
 }
 
+type fakeAnnouncer struct {
+	name  string
+	err   error
+	calls *[]string
+}
+
+func (f fakeAnnouncer) String() string { return f.name }
+
+func (f fakeAnnouncer) Announce(*context.Context) error {
+	*f.calls = append(*f.calls, f.name)
+	return f.err
+}
+
+func fakeAnnouncers(t *testing.T, calls *[]string, failing string) {
+	t.Helper()
+	previous := announcers
+	t.Cleanup(func() { announcers = previous })
+	announcers = nil
+	for _, name := range []string{"a", "b", "c", "d"} {
+		var err error
+		if name == failing {
+			err = errors.New("fake error")
+		}
+		announcers = append(announcers, fakeAnnouncer{name: name, err: err, calls: calls})
+	}
+}
+
+func TestAnnounceOrder(t *testing.T) {
+	var calls []string
+	fakeAnnouncers(t, &calls, "")
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Order: []string{"c", "a", "c"},
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, []string{"c", "a", "b", "d"}, calls)
+}
+
+func TestAnnounceInvalidOrder(t *testing.T) {
+	var calls []string
+	fakeAnnouncers(t, &calls, "")
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Order: []string{"a", "nope"},
+		},
+	})
+	require.EqualError(t, Pipe{}.Run(ctx), `announce: invalid order: unknown announcer "nope"`)
+	require.Empty(t, calls)
+
+	var sb strings.Builder
+	require.Error(t, Preview(ctx, &sb))
+	require.Empty(t, sb.String())
+}
+
+func TestAnnounceFailFast(t *testing.T) {
+	t.Run("continue by default", func(t *testing.T) {
+		var calls []string
+		fakeAnnouncers(t, &calls, "b")
+		ctx := testctx.Wrap(t.Context())
+		require.EqualError(t, Pipe{}.Run(ctx), "1 error occurred:\n\t* b: fake error\n\n")
+		require.Equal(t, []string{"a", "b", "c", "d"}, calls)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		var calls []string
+		fakeAnnouncers(t, &calls, "b")
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				FailFast: true,
+			},
+		})
+		require.EqualError(t, Pipe{}.Run(ctx), "b: fake error")
+		require.Equal(t, []string{"a", "b"}, calls)
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		var calls []string
+		fakeAnnouncers(t, &calls, "a")
+		ctx := testctx.Wrap(t.Context())
+		ctx.FailFast = true
+		require.EqualError(t, Pipe{}.Run(ctx), "a: fake error")
+		require.Equal(t, []string{"a"}, calls)
+	})
+}
+
 func TestAnnounceAllDisabled(t *testing.T) {
 	ctx := testctx.Wrap(t.Context())
 	require.NoError(t, Pipe{}.Run(ctx))
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Announce: config.Announce{
+			Twitter: config.Twitter{
+				Enabled:         "true",
+				MessageTemplate: "{{ .ProjectName }} {{ .Tag }} is out!",
+			},
+			Mastodon: config.Mastodon{
+				Enabled:         "{{ .Env.MASTODON }}",
+				MessageTemplate: "{{ .ProjectName }} {{ .Tag }} is out on mastodon!",
+			},
+		},
+	}, testctx.WithCurrentTag("v1.2.3"), testctx.WithEnv(map[string]string{"MASTODON": "false"}))
+
+	var sb strings.Builder
+	require.NoError(t, Preview(ctx, &sb))
+	require.Equal(t, "--- twitter\nfoo v1.2.3 is out!\n\n", sb.String())
+}
+
+func TestPreviewMessageTemplates(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Announce: config.Announce{
+			MessageTemplates: map[string]string{
+				"release": "{{ .ProjectName }} {{ .Tag }} is out!",
+			},
+			Twitter: config.Twitter{
+				Enabled:         "true",
+				MessageTemplate: `{{ template "release" . }} #golang`,
+			},
+		},
+	}, testctx.WithCurrentTag("v1.2.3"))
+
+	var sb strings.Builder
+	require.NoError(t, Preview(ctx, &sb))
+	require.Equal(t, "--- twitter\nfoo v1.2.3 is out! #golang\n\n", sb.String())
+}
+
+func TestPreviewSkip(t *testing.T) {
+	cfg := config.Project{
+		Announce: config.Announce{
+			Skip: "{{ .Env.SKIP }}",
+			Twitter: config.Twitter{
+				Enabled:         "true",
+				MessageTemplate: "out!",
+			},
+		},
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), cfg, testctx.WithEnv(map[string]string{"SKIP": "true"}))
+		var sb strings.Builder
+		require.NoError(t, Preview(ctx, &sb))
+		require.Empty(t, sb.String())
+	})
+
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), cfg, testctx.WithEnv(map[string]string{"SKIP": "false"}), testctx.Skip(skips.Announce))
+		var sb strings.Builder
+		require.NoError(t, Preview(ctx, &sb))
+		require.Empty(t, sb.String())
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{Skip: "{{ .Nope }"},
+		})
+		var sb strings.Builder
+		testlib.RequireTemplateError(t, Preview(ctx, &sb))
+	})
+}
+
+func TestPreviewError(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Twitter: config.Twitter{
+				Enabled:         "true",
+				MessageTemplate: "{{ .Foo }",
+			},
+			Mastodon: config.Mastodon{
+				Enabled:         "true",
+				Server:          "https://mastodon.social",
+				MessageTemplate: "ok",
+			},
+		},
+	})
+
+	var sb strings.Builder
+	require.ErrorContains(t, Preview(ctx, &sb), "twitter: ")
+	require.Equal(t, "--- mastodon\nok\n\n", sb.String())
+}
+
+func TestPreviewSkipped(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Twitter: config.Twitter{
+				Enabled:         "true",
+				MessageTemplate: "tweet",
+			},
+			Mastodon: config.Mastodon{
+				Enabled:         "true",
+				MessageTemplate: "toot",
+			},
+		},
+	})
+
+	var sb strings.Builder
+	require.NoError(t, Preview(ctx, &sb))
+	require.Equal(t, "--- twitter\ntweet\n\n", sb.String())
+}
+
+func TestAllAnnouncersCanPreview(t *testing.T) {
+	for _, announcer := range announcers {
+		require.Implements(t, (*Previewer)(nil), announcer, announcer.String())
+	}
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		ctx := testctx.Wrap(t.Context(), testctx.Skip(skips.Announce))
@@ -0,0 +1,368 @@
+// Package appbundle implements the Pipe interface providing macOS app bundle
+// creation.
+package appbundle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/archivefiles"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/icns"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/notary"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// goos is the operating system GoReleaser is running on, as app bundles can
+// only be signed on macOS.
+var goos = runtime.GOOS
+
+// Pipe for app bundles.
+type Pipe struct{}
+
+func (Pipe) String() string { return "macOS app bundles" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.AppBundle) || len(ctx.Config.AppBundles) == 0
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("app_bundles")
+	for i := range ctx.Config.AppBundles {
+		cfg := &ctx.Config.AppBundles[i]
+		if cfg.ID == "" {
+			cfg.ID = ctx.Config.ProjectName
+		}
+		if cfg.Name == "" {
+			cfg.Name = "{{ .ProjectName }}"
+		}
+		ids.Inc(cfg.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for _, cfg := range ctx.Config.AppBundles {
+		g.Go(func() error {
+			return doRun(ctx, cfg)
+		})
+	}
+	return g.Wait()
+}
+
+func doRun(ctx *context.Context, cfg config.AppBundle) error {
+	disable, err := tmpl.New(ctx).Bool(cfg.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("disabled")
+	}
+
+	groups := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByGoos("darwin"),
+		artifact.ByTypes(
+			artifact.Binary,
+			artifact.UniversalBinary,
+		),
+		artifact.ByIDs(cfg.IDs...),
+	)).GroupByPlatform()
+	if len(groups) == 0 {
+		return pipe.Skipf("no darwin binaries found for builds %v", cfg.IDs)
+	}
+
+	for plat, binaries := range groups {
+		if len(binaries) > 1 {
+			return fmt.Errorf("app bundles can only have one binary, found %d for %s: use 'ids' to pick one", len(binaries), plat)
+		}
+	}
+
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for plat, binaries := range groups {
+		g.Go(func() error {
+			return create(ctx, cfg, plat, binaries[0])
+		})
+	}
+	return g.Wait()
+}
+
+func create(ctx *context.Context, cfg config.AppBundle, plat string, binary *artifact.Artifact) error {
+	tpl := tmpl.New(ctx).
+		WithArtifact(binary)
+
+	name := cfg.Name
+	bundle := cfg.Bundle
+	icon := cfg.Icon
+	entitlements := cfg.Entitlements
+	modTimestamp := cfg.ModTimestamp
+	if err := tpl.ApplyAll(
+		&name,
+		&bundle,
+		&icon,
+		&entitlements,
+		&modTimestamp,
+	); err != nil {
+		return err
+	}
+	if bundle == "" {
+		return errors.New("app bundle: bundle is required")
+	}
+
+	binaryName := filepath.Base(binary.Name)
+	tpl = tpl.WithExtraFields(tmpl.Fields{
+		"AppName":    name,
+		"BinaryName": binaryName,
+		"Bundle":     bundle,
+	})
+
+	app := filepath.Join(ctx.Config.Dist, "appbundle", cfg.ID, plat, name+".app")
+	log := log.WithField("bundle", filepath.Base(app))
+	log.Info("creating app bundle")
+	if err := os.RemoveAll(app); err != nil {
+		return err
+	}
+	contents := filepath.Join(app, "Contents")
+	for _, dir := range []string{"MacOS", "Resources"} {
+		if err := os.MkdirAll(filepath.Join(contents, dir), 0o755); err != nil {
+			return fmt.Errorf("failed to create app bundle: %w", err)
+		}
+	}
+
+	if err := gio.Copy(binary.Path, filepath.Join(contents, "MacOS", binaryName)); err != nil {
+		return fmt.Errorf("failed to copy binary %s: %w", binary.Name, err)
+	}
+
+	var iconFile string
+	if icon != "" {
+		var err error
+		iconFile, err = addIcon(icon, filepath.Join(contents, "Resources"))
+		if err != nil {
+			return err
+		}
+	}
+
+	plist, err := infoPlist(infoPlistData{
+		Name:       name,
+		Executable: binaryName,
+		Bundle:     bundle,
+		Version:    ctx.Version,
+		Icon:       iconFile,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(contents, "Info.plist"), plist, 0o644); err != nil {
+		return fmt.Errorf("failed to write Info.plist: %w", err)
+	}
+
+	if err := addExtraFiles(tpl, cfg, app); err != nil {
+		return err
+	}
+
+	if modTimestamp != "" {
+		if err := filepath.WalkDir(app, func(path string, _ fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			return gio.Chtimes(path, modTimestamp)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := signAndNotarize(ctx, cfg, app, entitlements); err != nil {
+		return err
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.AppBundle,
+		Name:    filepath.Base(app),
+		Path:    app,
+		Goos:    binary.Goos,
+		Goarch:  binary.Goarch,
+		Goamd64: binary.Goamd64,
+		Goarm64: binary.Goarm64,
+		Target:  binary.Target,
+		Extra: map[string]any{
+			artifact.ExtraID:     cfg.ID,
+			artifact.ExtraBinary: binaryName,
+			artifact.ExtraFormat: "appbundle",
+			artifact.ExtraExt:    ".app",
+		},
+	})
+	return nil
+}
+
+// addIcon adds the given icon to the resources directory, converting it to
+// icns if it is a png, and returns its filename.
+func addIcon(icon, resources string) (string, error) {
+	switch strings.ToLower(filepath.Ext(icon)) {
+	case ".icns":
+		if err := gio.Copy(icon, filepath.Join(resources, filepath.Base(icon))); err != nil {
+			return "", fmt.Errorf("failed to copy icon: %w", err)
+		}
+		return filepath.Base(icon), nil
+	case ".png":
+		name := strings.TrimSuffix(filepath.Base(icon), filepath.Ext(icon)) + ".icns"
+		src, err := os.Open(icon)
+		if err != nil {
+			return "", fmt.Errorf("failed to open icon: %w", err)
+		}
+		defer src.Close()
+		var b bytes.Buffer
+		if err := icns.Encode(&b, src); err != nil {
+			return "", fmt.Errorf("failed to convert icon %s: %w", icon, err)
+		}
+		if err := os.WriteFile(filepath.Join(resources, name), b.Bytes(), 0o644); err != nil {
+			return "", fmt.Errorf("failed to write icon: %w", err)
+		}
+		return name, nil
+	default:
+		return "", fmt.Errorf("icon must be a .icns or .png file, got %s", icon)
+	}
+}
+
+// addExtraFiles adds the extra files and the templated extra files to the
+// app bundle.
+// They are added after the generated files, so they can override them.
+func addExtraFiles(tpl *tmpl.Template, cfg config.AppBundle, app string) error {
+	files, err := archivefiles.Eval(tpl, cfg.ExtraFiles)
+	if err != nil {
+		return fmt.Errorf("failed to find files to add to the app bundle: %w", err)
+	}
+	for _, f := range files {
+		if !filepath.IsLocal(f.Destination) {
+			return fmt.Errorf("extra file destination must be a relative path within the app bundle, got %q", f.Destination)
+		}
+		dst := filepath.Join(app, f.Destination)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", f.Destination, err)
+		}
+		if err := gio.Copy(f.Source, dst); err != nil {
+			return fmt.Errorf("failed to copy file %s: %w", f.Source, err)
+		}
+	}
+
+	templated, err := archivefiles.EvalTemplated(tpl, cfg.TemplatedExtraFiles, app)
+	if err != nil {
+		return fmt.Errorf("failed to render templated files: %w", err)
+	}
+
+	for _, f := range append(files, templated...) {
+		dst := filepath.Join(app, f.Destination)
+		if f.Info.Mode != 0 {
+			if err := os.Chmod(dst, f.Info.Mode); err != nil {
+				return err
+			}
+		}
+		if !f.Info.ParsedMTime.IsZero() {
+			if err := os.Chtimes(dst, f.Info.ParsedMTime, f.Info.ParsedMTime); err != nil {
+				return err
+			}
+		}
+		if f.Info.Owner != "" || f.Info.Group != "" {
+			log.WithField("file", f.Destination).Warn("owner and group are not supported in app bundles")
+		}
+	}
+	return nil
+}
+
+// signAndNotarize signs the app bundle with codesign, using the notarize
+// configuration that matches its ID, if any, then notarizes it and staples
+// the ticket to it, so it can be verified offline, even when copied out of a
+// DMG.
+func signAndNotarize(ctx *context.Context, cfg config.AppBundle, app, entitlements string) error {
+	ncfg, ok, err := notary.ConfigFor(ctx, cfg.ID)
+	if err != nil || !ok {
+		return err
+	}
+	log := log.WithField("bundle", filepath.Base(app))
+	if goos != "darwin" {
+		log.Warn("app bundles can only be signed on macOS")
+		return nil
+	}
+	if entitlements == "" {
+		entitlements = ncfg.Sign.Entitlements
+	}
+	args := []string{"--options", "runtime"}
+	if entitlements != "" {
+		args = append(args, "--entitlements", entitlements)
+	}
+	log.Info("signing")
+	if err := notary.Codesign(ctx, ncfg.Sign, app, args...); err != nil {
+		return err
+	}
+
+	accepted, err := notary.Notarize(ctx, ncfg.Notarize, app)
+	if err != nil || !accepted {
+		return err
+	}
+	return notary.Staple(ctx, app)
+}
+
+type infoPlistData struct {
+	Name       string
+	Executable string
+	Bundle     string
+	Version    string
+	Icon       string
+}
+
+func infoPlist(data infoPlistData) ([]byte, error) {
+	t, err := template.New("Info.plist").Parse(infoPlistTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+const infoPlistTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleDisplayName</key>
+	<string>{{ html .Name }}</string>
+	<key>CFBundleExecutable</key>
+	<string>{{ html .Executable }}</string>
+{{- with .Icon }}
+	<key>CFBundleIconFile</key>
+	<string>{{ html . }}</string>
+{{- end }}
+	<key>CFBundleIdentifier</key>
+	<string>{{ html .Bundle }}</string>
+	<key>CFBundleInfoDictionaryVersion</key>
+	<string>6.0</string>
+	<key>CFBundleName</key>
+	<string>{{ html .Name }}</string>
+	<key>CFBundlePackageType</key>
+	<string>APPL</string>
+	<key>CFBundleShortVersionString</key>
+	<string>{{ html .Version }}</string>
+	<key>CFBundleVersion</key>
+	<string>{{ html .Version }}</string>
+	<key>NSHighResolutionCapable</key>
+	<true/>
+</dict>
+</plist>
+`
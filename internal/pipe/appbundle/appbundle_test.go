@@ -0,0 +1,254 @@
+package appbundle
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.Equal(t, "macOS app bundles", Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			AppBundles: []config.AppBundle{{}},
+		}, testctx.Skip(skips.AppBundle))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			AppBundles: []config.AppBundle{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("skip no app bundles", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "myproj",
+		AppBundles: []config.AppBundle{
+			{},
+			{ID: "custom", Name: "Custom"},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "myproj", ctx.Config.AppBundles[0].ID)
+	require.Equal(t, "{{ .ProjectName }}", ctx.Config.AppBundles[0].Name)
+	require.Equal(t, "custom", ctx.Config.AppBundles[1].ID)
+	require.Equal(t, "Custom", ctx.Config.AppBundles[1].Name)
+}
+
+func TestDefaultDuplicateID(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "myproj",
+		AppBundles:  []config.AppBundle{{}, {}},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "found 2 app_bundles with the ID 'myproj', please fix your config")
+}
+
+func TestRun(t *testing.T) {
+	ctx := makeContext(t)
+	icon := filepath.Join(t.TempDir(), "icon.png")
+	writePNG(t, icon, 64)
+	ctx.Config.AppBundles = []config.AppBundle{{
+		Name:         "My App",
+		Bundle:       "com.example.{{ .ProjectName }}",
+		Icon:         icon,
+		ModTimestamp: "{{ .CommitTimestamp }}",
+		ExtraFiles: []config.File{
+			{Source: "./testdata/README.md", Destination: "Contents/Resources/README.md"},
+		},
+		TemplatedExtraFiles: []config.TemplatedFile{
+			{Source: "./testdata/info.txt.tpl", Destination: "Contents/Resources/info.txt"},
+		},
+	}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	bundles := ctx.Artifacts.Filter(artifact.ByType(artifact.AppBundle)).List()
+	require.Len(t, bundles, 2)
+	for _, bundle := range bundles {
+		require.Equal(t, "darwin", bundle.Goos)
+		require.Equal(t, "My App.app", bundle.Name)
+		require.Equal(t, "myproj", bundle.ID())
+		require.Equal(t, "appbundle", artifact.ExtraOr(*bundle, artifact.ExtraFormat, ""))
+		require.Equal(t, filepath.Join(ctx.Config.Dist, "appbundle", "myproj", "darwin"+bundle.Goarch, "My App.app"), bundle.Path)
+
+		contents := filepath.Join(bundle.Path, "Contents")
+		require.FileExists(t, filepath.Join(contents, "MacOS", "mybin"))
+		require.FileExists(t, filepath.Join(contents, "Resources", "README.md"))
+
+		bts, err := os.ReadFile(filepath.Join(contents, "Resources", "icon.icns"))
+		require.NoError(t, err)
+		require.Equal(t, "icns", string(bts[:4]))
+		require.Equal(t, uint32(len(bts)), binary.BigEndian.Uint32(bts[4:8]))
+
+		bts, err = os.ReadFile(filepath.Join(contents, "Resources", "info.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "My App com.example.myproj mybin 1.2.3\n", string(bts))
+
+		bts, err = os.ReadFile(filepath.Join(contents, "Info.plist"))
+		require.NoError(t, err)
+		plist := string(bts)
+		require.Contains(t, plist, "<key>CFBundleExecutable</key>\n\t<string>mybin</string>")
+		require.Contains(t, plist, "<key>CFBundleIdentifier</key>\n\t<string>com.example.myproj</string>")
+		require.Contains(t, plist, "<key>CFBundleIconFile</key>\n\t<string>icon.icns</string>")
+		require.Contains(t, plist, "<key>CFBundleName</key>\n\t<string>My App</string>")
+		require.Contains(t, plist, "<key>CFBundleVersion</key>\n\t<string>1.2.3</string>")
+
+		stat, err := os.Stat(filepath.Join(contents, "Info.plist"))
+		require.NoError(t, err)
+		require.Equal(t, ctx.Git.CommitDate.Unix(), stat.ModTime().Unix())
+	}
+}
+
+func TestRunInfoPlistOverride(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.AppBundles = []config.AppBundle{{
+		Bundle: "com.example.myproj",
+		TemplatedExtraFiles: []config.TemplatedFile{
+			{Source: "./testdata/info.txt.tpl", Destination: "Contents/Info.plist"},
+		},
+	}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	for _, bundle := range ctx.Artifacts.Filter(artifact.ByType(artifact.AppBundle)).List() {
+		bts, err := os.ReadFile(filepath.Join(bundle.Path, "Contents", "Info.plist"))
+		require.NoError(t, err)
+		require.Equal(t, "myproj com.example.myproj mybin 1.2.3\n", string(bts))
+	}
+}
+
+func TestRunNotarizeOutsideMacOS(t *testing.T) {
+	testlib.Set(t, &goos, "linux")
+	ctx := makeContext(t)
+	ctx.Config.Notarize.MacOS = []config.MacOSSignNotarize{{
+		IDs:     []string{"myproj"},
+		Enabled: "true",
+	}}
+	ctx.Config.AppBundles = []config.AppBundle{{Bundle: "com.example.myproj"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.AppBundle)).List(), 2)
+}
+
+func TestRunErrors(t *testing.T) {
+	for name, tt := range map[string]struct {
+		cfg config.AppBundle
+		err string
+	}{
+		"no bundle": {
+			cfg: config.AppBundle{},
+			err: "app bundle: bundle is required",
+		},
+		"bad icon": {
+			cfg: config.AppBundle{Bundle: "com.example.myproj", Icon: "./testdata/README.md"},
+			err: "icon must be a .icns or .png file, got ./testdata/README.md",
+		},
+		"invalid png icon": {
+			cfg: config.AppBundle{Bundle: "com.example.myproj", Icon: "./testdata/invalid.png"},
+			err: "icns: could not decode png",
+		},
+		"extra file outside bundle": {
+			cfg: config.AppBundle{
+				Bundle:     "com.example.myproj",
+				ExtraFiles: []config.File{{Source: "./testdata/README.md", Destination: "../README.md"}},
+			},
+			err: "extra file destination must be a relative path within the app bundle",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := makeContext(t)
+			ctx.Config.AppBundles = []config.AppBundle{tt.cfg}
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.ErrorContains(t, Pipe{}.Run(ctx), tt.err)
+		})
+	}
+}
+
+func TestRunMultipleBinaries(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "other",
+		Path:   ctx.Artifacts.List()[0].Path,
+		Type:   artifact.Binary,
+		Goos:   "darwin",
+		Goarch: "arm64",
+		Extra: map[string]any{
+			artifact.ExtraID: "other",
+		},
+	})
+	ctx.Config.AppBundles = []config.AppBundle{{Bundle: "com.example.myproj"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Run(ctx), "app bundles can only have one binary, found 2 for darwinarm64")
+
+	ctx.Config.AppBundles[0].IDs = []string{"myproj"}
+	require.NoError(t, Pipe{}.Run(ctx))
+}
+
+func TestRunNoBinaries(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.AppBundles = []config.AppBundle{{IDs: []string{"nope"}}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.AppBundles = []config.AppBundle{{Disable: "{{ eq .Version \"1.2.3\" }}"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunBadTemplate(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.AppBundles = []config.AppBundle{{Bundle: "{{ .Nope }}"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+}
+
+func writePNG(tb testing.TB, path string, size int) {
+	tb.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		for x := range size {
+			img.Set(x, y, color.White)
+		}
+	}
+	f, err := os.Create(path)
+	require.NoError(tb, err)
+	defer f.Close()
+	require.NoError(tb, png.Encode(f, img))
+}
+
+func makeContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "myproj",
+		Dist:        tb.TempDir(),
+	}, testctx.WithVersion("1.2.3"), testctx.WithCommitDate(time.Unix(1700000000, 0)))
+	testlib.AddFakeBinaries(tb, ctx, "myproj", []string{"linux", "darwin"}, []string{"amd64", "arm64"}, "mybin")
+	return ctx
+}
@@ -0,0 +1 @@
+{{ .AppName }} {{ .Bundle }} {{ .BinaryName }} {{ .Version }}
@@ -0,0 +1,401 @@
+// Package appimage implements the Pipe interface providing Linux AppImage
+// creation.
+package appimage
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/archivefiles"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/redact"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const defaultNameTemplate = `{{ .ProjectName }}_{{ .Version }}_{{ .AppImageArch }}`
+
+// appImageArchs maps the supported GOARCHs to their AppImage architecture.
+var appImageArchs = map[string]string{
+	"amd64": "x86_64",
+	"386":   "i686",
+	"arm64": "aarch64",
+}
+
+// Pipe for AppImage packaging.
+type Pipe struct{}
+
+func (Pipe) String() string { return "appimages" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.AppImage) || len(ctx.Config.AppImages) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(*context.Context) []string { return []string{"appimagetool"} }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("appimages")
+	for i := range ctx.Config.AppImages {
+		cfg := &ctx.Config.AppImages[i]
+		if cfg.ID == "" {
+			cfg.ID = ctx.Config.ProjectName
+		}
+		if cfg.Name == "" {
+			cfg.Name = defaultNameTemplate
+		}
+		if cfg.Goamd64 == "" {
+			cfg.Goamd64 = "v1"
+		}
+		if len(cfg.Categories) == 0 {
+			cfg.Categories = []string{"Utility"}
+		}
+		ids.Inc(cfg.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for _, cfg := range ctx.Config.AppImages {
+		g.Go(func() error {
+			return doRun(ctx, cfg)
+		})
+	}
+	return g.Wait()
+}
+
+func doRun(ctx *context.Context, cfg config.AppImage) error {
+	disable, err := tmpl.New(ctx).Bool(cfg.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("disabled")
+	}
+
+	groups := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByGoos("linux"),
+		artifact.ByType(artifact.Binary),
+		artifact.ByGoarches(slices.Sorted(maps.Keys(appImageArchs))...),
+		artifact.ByIDs(cfg.IDs...),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("amd64")),
+			artifact.ByGoamd64(cfg.Goamd64),
+		),
+	)).GroupByPlatform()
+	if len(groups) == 0 {
+		return pipe.Skipf("no linux binaries found for builds %v", cfg.IDs)
+	}
+
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for plat, binaries := range groups {
+		g.Go(func() error {
+			return create(ctx, cfg, plat, binaries)
+		})
+	}
+	return g.Wait()
+}
+
+func create(ctx *context.Context, cfg config.AppImage, plat string, binaries []*artifact.Artifact) error {
+	slices.SortFunc(binaries, func(a, b *artifact.Artifact) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	binary := binaries[0]
+	arch := appImageArchs[binary.Goarch]
+	tpl := tmpl.New(ctx).
+		WithArtifact(binary).
+		WithExtraFields(tmpl.Fields{
+			"AppImageArch": arch,
+		})
+
+	name := cfg.Name
+	displayName := cfg.DisplayName
+	command := cfg.Command
+	icon := cfg.Icon
+	desktopFile := cfg.DesktopFile
+	updateInformation := cfg.UpdateInformation
+	runtimeFile := cfg.RuntimeFile
+	modTimestamp := cfg.ModTimestamp
+	if err := tpl.ApplyAll(
+		&name,
+		&displayName,
+		&command,
+		&icon,
+		&desktopFile,
+		&updateInformation,
+		&runtimeFile,
+		&modTimestamp,
+	); err != nil {
+		return err
+	}
+	if icon == "" {
+		return errors.New("appimage: icon is required")
+	}
+	ext := strings.ToLower(filepath.Ext(icon))
+	if ext != ".png" && ext != ".svg" {
+		return fmt.Errorf("appimage: icon must be a .png or .svg file, got %s", icon)
+	}
+	if displayName == "" {
+		displayName = ctx.Config.ProjectName
+	}
+	if command == "" {
+		command = filepath.Base(binary.Name)
+	}
+	if !slices.ContainsFunc(binaries, func(a *artifact.Artifact) bool {
+		return filepath.Base(a.Name) == command
+	}) {
+		return fmt.Errorf("appimage: command %q is not one of the binaries", command)
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "appimage", cfg.ID, plat)
+	appDir := filepath.Join(dir, name+".AppDir")
+	if err := os.RemoveAll(appDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(appDir, "usr", "bin"), 0o755); err != nil {
+		return fmt.Errorf("failed to create AppDir: %w", err)
+	}
+
+	for _, bin := range binaries {
+		dst := filepath.Join(appDir, "usr", "bin", filepath.Base(bin.Name))
+		if err := gio.CopyWithMode(bin.Path, dst, 0o755); err != nil {
+			return fmt.Errorf("failed to copy binary %s: %w", bin.Name, err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(appDir, "AppRun"), appRun(command), 0o755); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to write AppRun: %w", err)
+	}
+
+	var desktop []byte
+	iconName := command
+	if desktopFile != "" {
+		var err error
+		desktop, err = os.ReadFile(desktopFile)
+		if err != nil {
+			return fmt.Errorf("failed to read desktop file: %w", err)
+		}
+		iconName = desktopEntryIcon(desktop)
+		if iconName == "" {
+			return fmt.Errorf("appimage: desktop file %s has no Icon entry", desktopFile)
+		}
+	} else {
+		desktop = desktopEntry(displayName, command, iconName, cfg.Categories, cfg.Terminal)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, command+".desktop"), desktop, 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to write desktop file: %w", err)
+	}
+
+	if err := gio.Copy(icon, filepath.Join(appDir, iconName+ext)); err != nil {
+		return fmt.Errorf("failed to copy icon: %w", err)
+	}
+
+	if err := addExtraFiles(tpl, cfg, appDir); err != nil {
+		return err
+	}
+
+	if modTimestamp != "" {
+		if err := filepath.WalkDir(appDir, func(path string, _ fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			return gio.Chtimes(path, modTimestamp)
+		}); err != nil {
+			return err
+		}
+	}
+
+	filename := name + ".AppImage"
+	path := filepath.Join(ctx.Config.Dist, filename)
+	out, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	log := log.WithField("appimage", filename).WithField("dir", appDir)
+	log.Info("creating appimage")
+	for _, f := range []string{out, out + ".zsync"} {
+		if err := os.Remove(f); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	var args []string
+	if updateInformation != "" {
+		args = append(args, "--updateinformation", updateInformation)
+	}
+	if runtimeFile != "" {
+		runtimeFile, err = filepath.Abs(runtimeFile)
+		if err != nil {
+			return err
+		}
+		args = append(args, "--runtime-file", runtimeFile)
+	}
+	args = append(args, filepath.Base(appDir), out)
+	if err := run(ctx, cfg, dir, arch, args...); err != nil {
+		return err
+	}
+
+	if err := gio.Chtimes(path, modTimestamp); err != nil {
+		return err
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.AppImage,
+		Name:    filename,
+		Path:    path,
+		Goos:    binary.Goos,
+		Goarch:  binary.Goarch,
+		Goamd64: binary.Goamd64,
+		Go386:   binary.Go386,
+		Goarm64: binary.Goarm64,
+		Target:  binary.Target,
+		Extra: map[string]any{
+			artifact.ExtraID:     cfg.ID,
+			artifact.ExtraFormat: "appimage",
+			artifact.ExtraExt:    ".AppImage",
+		},
+	})
+
+	if updateInformation == "" {
+		return nil
+	}
+
+	// appimagetool only creates the zsync file if zsyncmake is available.
+	if _, err := os.Stat(path + ".zsync"); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		log.Warn("zsync file was not created, make sure zsyncmake is installed")
+		return nil
+	}
+	if err := gio.Chtimes(path+".zsync", modTimestamp); err != nil {
+		return err
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.UploadableFile,
+		Name:    filename + ".zsync",
+		Path:    path + ".zsync",
+		Goos:    binary.Goos,
+		Goarch:  binary.Goarch,
+		Goamd64: binary.Goamd64,
+		Go386:   binary.Go386,
+		Goarm64: binary.Goarm64,
+		Target:  binary.Target,
+		Extra: map[string]any{
+			artifact.ExtraID: cfg.ID,
+		},
+	})
+	return nil
+}
+
+// addExtraFiles adds the extra files to the AppDir.
+func addExtraFiles(tpl *tmpl.Template, cfg config.AppImage, appDir string) error {
+	files, err := archivefiles.Eval(tpl, cfg.ExtraFiles)
+	if err != nil {
+		return fmt.Errorf("failed to find files to add to the appimage: %w", err)
+	}
+	for _, f := range files {
+		if !filepath.IsLocal(f.Destination) {
+			return fmt.Errorf("extra file destination must be a relative path within the AppDir, got %q", f.Destination)
+		}
+		dst := filepath.Join(appDir, f.Destination)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", f.Destination, err)
+		}
+		if err := gio.Copy(f.Source, dst); err != nil {
+			return fmt.Errorf("failed to copy file %s: %w", f.Source, err)
+		}
+		if f.Info.Mode != 0 {
+			if err := os.Chmod(dst, f.Info.Mode); err != nil {
+				return err
+			}
+		}
+		if !f.Info.ParsedMTime.IsZero() {
+			if err := os.Chtimes(dst, f.Info.ParsedMTime, f.Info.ParsedMTime); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func appRun(command string) []byte {
+	return []byte(`#!/bin/sh
+HERE="$(dirname "$(readlink -f "$0")")"
+exec "$HERE/usr/bin/` + command + `" "$@"
+`)
+}
+
+func desktopEntry(name, command, icon string, categories []string, terminal bool) []byte {
+	var b bytes.Buffer
+	b.WriteString("[Desktop Entry]\n")
+	b.WriteString("Type=Application\n")
+	fmt.Fprintf(&b, "Name=%s\n", name)
+	fmt.Fprintf(&b, "Exec=%s\n", command)
+	fmt.Fprintf(&b, "Icon=%s\n", icon)
+	fmt.Fprintf(&b, "Categories=%s;\n", strings.Join(categories, ";"))
+	fmt.Fprintf(&b, "Terminal=%t\n", terminal)
+	return b.Bytes()
+}
+
+// desktopEntryIcon returns the value of the Icon key of the desktop entry.
+func desktopEntryIcon(desktop []byte) string {
+	var inEntry bool
+	s := bufio.NewScanner(bytes.NewReader(desktop))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(line, "[") {
+			inEntry = line == "[Desktop Entry]"
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if inEntry && ok && strings.TrimSpace(key) == "Icon" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func run(ctx *context.Context, cfg config.AppImage, dir, arch string, arg ...string) error {
+	cmd := exec.CommandContext(ctx, "appimagetool", arg...)
+	cmd.Dir = dir
+	cmd.Env = append(ctx.Env.Strings(), cmd.Environ()...)
+	cmd.Env = append(cmd.Env, "ARCH="+arch)
+	var b bytes.Buffer
+	w := gio.Safe(&b)
+	cmd.Stderr = redact.Writer(io.MultiWriter(logext.NewWriter(), w), cmd.Env)
+	cmd.Stdout = redact.Writer(io.MultiWriter(logext.NewWriter(), w), cmd.Env)
+	if err := cmd.Run(); err != nil {
+		return gerrors.Wrap(
+			err,
+			gerrors.WithMessage("could not create appimage"),
+			gerrors.WithDetails(
+				"args", strings.Join(cmd.Args, " "),
+				"id", cfg.ID,
+			),
+			gerrors.WithOutput(b.String()),
+		)
+	}
+	return nil
+}
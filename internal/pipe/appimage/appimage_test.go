@@ -0,0 +1,313 @@
+package appimage
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.Equal(t, "appimages", Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			AppImages: []config.AppImage{{}},
+		}, testctx.Skip(skips.AppImage))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			AppImages: []config.AppImage{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("skip no appimages", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	require.Equal(t, []string{"appimagetool"}, Pipe{}.Dependencies(nil))
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "myproj",
+		AppImages: []config.AppImage{
+			{},
+			{ID: "custom", Name: "custom", Categories: []string{"Development"}},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, config.AppImage{
+		ID:         "myproj",
+		Name:       defaultNameTemplate,
+		Goamd64:    "v1",
+		Categories: []string{"Utility"},
+	}, ctx.Config.AppImages[0])
+	require.Equal(t, "custom", ctx.Config.AppImages[1].ID)
+	require.Equal(t, "custom", ctx.Config.AppImages[1].Name)
+	require.Equal(t, []string{"Development"}, ctx.Config.AppImages[1].Categories)
+}
+
+func TestDefaultDuplicateID(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "myproj",
+		AppImages:   []config.AppImage{{}, {}},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "found 2 appimages with the ID 'myproj', please fix your config")
+}
+
+func TestRun(t *testing.T) {
+	log := fakeAppImageTool(t, 0)
+	ctx := makeContext(t)
+	ctx.Config.AppImages = []config.AppImage{{
+		DisplayName:  "My App",
+		Icon:         "./testdata/icon.svg",
+		Terminal:     true,
+		ModTimestamp: "{{ .CommitTimestamp }}",
+		ExtraFiles: []config.File{
+			{Source: "./testdata/README.md", Destination: "usr/share/doc/myproj/README.md"},
+		},
+	}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	appimages := ctx.Artifacts.Filter(artifact.ByType(artifact.AppImage)).List()
+	require.Len(t, appimages, 3)
+	for _, appimage := range appimages {
+		require.Equal(t, "linux", appimage.Goos)
+		arch := appImageArchs[appimage.Goarch]
+		require.Equal(t, "myproj_1.2.3_"+arch+".AppImage", appimage.Name)
+		require.Equal(t, filepath.Join(ctx.Config.Dist, appimage.Name), appimage.Path)
+		require.Equal(t, "myproj", appimage.ID())
+		require.Equal(t, "appimage", artifact.ExtraOr(*appimage, artifact.ExtraFormat, ""))
+
+		stat, err := os.Stat(appimage.Path)
+		require.NoError(t, err)
+		require.Equal(t, ctx.Git.CommitDate.Unix(), stat.ModTime().Unix())
+
+		appDir := filepath.Join(ctx.Config.Dist, "appimage", "myproj", "linux"+appimage.Goarch, "myproj_1.2.3_"+arch+".AppDir")
+		require.FileExists(t, filepath.Join(appDir, "usr", "bin", "mybin"))
+		require.FileExists(t, filepath.Join(appDir, "usr", "bin", "other"))
+		require.FileExists(t, filepath.Join(appDir, "usr", "share", "doc", "myproj", "README.md"))
+		require.FileExists(t, filepath.Join(appDir, "mybin.svg"))
+
+		bts, err := os.ReadFile(filepath.Join(appDir, "AppRun"))
+		require.NoError(t, err)
+		require.Contains(t, string(bts), `exec "$HERE/usr/bin/mybin" "$@"`)
+
+		bts, err = os.ReadFile(filepath.Join(appDir, "mybin.desktop"))
+		require.NoError(t, err)
+		require.Equal(t, `[Desktop Entry]
+Type=Application
+Name=My App
+Exec=mybin
+Icon=mybin
+Categories=Utility;
+Terminal=true
+`, string(bts))
+	}
+
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableFile)).List())
+
+	out, err := filepath.Abs(filepath.Join(ctx.Config.Dist, "myproj_1.2.3_x86_64.AppImage"))
+	require.NoError(t, err)
+	require.Contains(t, testlib.ReadFakeLog(t, log), "appimagetool myproj_1.2.3_x86_64.AppDir "+out)
+	bts, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "appimage x86_64\n", string(bts))
+}
+
+func TestRunUpdateInformation(t *testing.T) {
+	log := fakeAppImageTool(t, 0)
+	ctx := makeContext(t)
+	ctx.Config.AppImages = []config.AppImage{{
+		IDs:               []string{"myproj"},
+		Name:              "myproj-{{ .AppImageArch }}",
+		Icon:              "./testdata/icon.svg",
+		UpdateInformation: "gh-releases-zsync|foo|bar|latest|myproj-{{ .AppImageArch }}.AppImage.zsync",
+		RuntimeFile:       "./testdata/runtime-{{ .AppImageArch }}",
+	}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	zsyncs := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableFile)).List()
+	require.Len(t, zsyncs, 3)
+	for _, zsync := range zsyncs {
+		arch := appImageArchs[zsync.Goarch]
+		require.Equal(t, "myproj-"+arch+".AppImage.zsync", zsync.Name)
+		require.FileExists(t, zsync.Path)
+		require.Equal(t, "myproj", zsync.ID())
+	}
+
+	out, err := filepath.Abs(filepath.Join(ctx.Config.Dist, "myproj-aarch64.AppImage"))
+	require.NoError(t, err)
+	runtime, err := filepath.Abs("./testdata/runtime-aarch64")
+	require.NoError(t, err)
+	require.Contains(t, testlib.ReadFakeLog(t, log), strings.Join([]string{
+		"appimagetool",
+		"--updateinformation", "gh-releases-zsync|foo|bar|latest|myproj-aarch64.AppImage.zsync",
+		"--runtime-file", runtime,
+		"myproj-aarch64.AppDir", out,
+	}, " "))
+}
+
+func TestRunDesktopFile(t *testing.T) {
+	fakeAppImageTool(t, 0)
+	ctx := makeContext(t)
+	ctx.Config.AppImages = []config.AppImage{{
+		Command:     "other",
+		Icon:        "./testdata/icon.svg",
+		DesktopFile: "./testdata/app.desktop",
+	}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	appDir := filepath.Join(ctx.Config.Dist, "appimage", "myproj", "linuxamd64", "myproj_1.2.3_x86_64.AppDir")
+	require.FileExists(t, filepath.Join(appDir, "my-icon.svg"))
+	expected, err := os.ReadFile("./testdata/app.desktop")
+	require.NoError(t, err)
+	bts, err := os.ReadFile(filepath.Join(appDir, "other.desktop"))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(bts))
+	bts, err = os.ReadFile(filepath.Join(appDir, "AppRun"))
+	require.NoError(t, err)
+	require.Contains(t, string(bts), `exec "$HERE/usr/bin/other" "$@"`)
+}
+
+func TestRunErrors(t *testing.T) {
+	for name, tt := range map[string]struct {
+		cfg config.AppImage
+		err string
+	}{
+		"no icon": {
+			cfg: config.AppImage{},
+			err: "appimage: icon is required",
+		},
+		"bad icon": {
+			cfg: config.AppImage{Icon: "./testdata/README.md"},
+			err: "appimage: icon must be a .png or .svg file, got ./testdata/README.md",
+		},
+		"missing icon": {
+			cfg: config.AppImage{Icon: "./testdata/nope.png"},
+			err: "failed to copy icon",
+		},
+		"bad command": {
+			cfg: config.AppImage{Icon: "./testdata/icon.svg", Command: "nope"},
+			err: `appimage: command "nope" is not one of the binaries`,
+		},
+		"missing desktop file": {
+			cfg: config.AppImage{Icon: "./testdata/icon.svg", DesktopFile: "./testdata/nope.desktop"},
+			err: "failed to read desktop file",
+		},
+		"desktop file without icon": {
+			cfg: config.AppImage{Icon: "./testdata/icon.svg", DesktopFile: "./testdata/noicon.desktop"},
+			err: "appimage: desktop file ./testdata/noicon.desktop has no Icon entry",
+		},
+		"extra file outside appdir": {
+			cfg: config.AppImage{
+				Icon:       "./testdata/icon.svg",
+				ExtraFiles: []config.File{{Source: "./testdata/README.md", Destination: "../README.md"}},
+			},
+			err: "extra file destination must be a relative path within the AppDir",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fakeAppImageTool(t, 0)
+			ctx := makeContext(t)
+			ctx.Config.AppImages = []config.AppImage{tt.cfg}
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.ErrorContains(t, Pipe{}.Run(ctx), tt.err)
+		})
+	}
+}
+
+func TestRunFails(t *testing.T) {
+	fakeAppImageTool(t, 1)
+	ctx := makeContext(t)
+	ctx.Config.AppImages = []config.AppImage{{Icon: "./testdata/icon.svg"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	err := Pipe{}.Run(ctx)
+	require.Error(t, err)
+	var gerr gerrors.ErrDetailed
+	require.ErrorAs(t, err, &gerr)
+	require.Equal(t, []string{"could not create appimage"}, gerr.Messages())
+}
+
+func TestRunNoBinaries(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.AppImages = []config.AppImage{{IDs: []string{"nope"}}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.AppImages = []config.AppImage{{Disable: "{{ eq .Version \"1.2.3\" }}"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunBadTemplate(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.AppImages = []config.AppImage{{Icon: "{{ .Nope }}"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+}
+
+func TestDesktopEntryIcon(t *testing.T) {
+	for expected, desktop := range map[string]string{
+		"foo": "[Desktop Entry]\nName=Foo\nIcon = foo\n",
+		"bar": "[Desktop Action new]\nIcon=nope\n\n[Desktop Entry]\nIcon=bar\n",
+		"":    "[Desktop Entry]\nName=Foo\n\n[Desktop Action new]\nIcon=nope\n",
+	} {
+		t.Run(expected, func(t *testing.T) {
+			require.Equal(t, expected, desktopEntryIcon([]byte(desktop)))
+		})
+	}
+}
+
+// fakeAppImageTool puts a fake appimagetool in the PATH, which logs its
+// arguments, writes the ARCH environment variable to the output file (and
+// creates the zsync file, when updating information is given), and exits with
+// the given code.
+func fakeAppImageTool(tb testing.TB, exitCode int) string {
+	tb.Helper()
+	return testlib.FakeCommands(tb, map[string]string{
+		"appimagetool": `for arg in "$@"; do
+	[ "$arg" = "--updateinformation" ] && zsync=1
+	out="$arg"
+done
+echo "appimage $ARCH" > "$out"
+[ -n "$zsync" ] && echo zsync > "$out.zsync"
+exit ` + strconv.Itoa(exitCode) + `
+`,
+	})
+}
+
+func makeContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "myproj",
+		Dist:        tb.TempDir(),
+	}, testctx.WithVersion("1.2.3"), testctx.WithCommitDate(time.Unix(1700000000, 0)))
+	testlib.AddFakeBinaries(tb, ctx, "myproj", []string{"linux", "darwin"}, []string{"amd64", "386", "arm64", "riscv64"}, "other", "mybin")
+	return ctx
+}
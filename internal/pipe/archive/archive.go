@@ -6,6 +6,7 @@ package archive
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/archivefiles"
+	"github.com/goreleaser/goreleaser/v2/internal/archivemanifest"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/deprecate"
 	"github.com/goreleaser/goreleaser/v2/internal/ids"
@@ -97,11 +99,38 @@ func (Pipe) Default(ctx *context.Context) error {
 			}
 		}
 		archive.BuildsInfo.Mode = 0o755
+		warnIgnoredCompressionLevel(*archive)
+		if _, err := parseSplitSize(archive.SplitSize); err != nil {
+			return err
+		}
 		ids.Inc(archive.ID)
 	}
 	return ids.Validate()
 }
 
+// warnIgnoredCompressionLevel warns about the formats of the given archive
+// that don't support setting a compression level, if it is set.
+func warnIgnoredCompressionLevel(arch config.Archive) {
+	if arch.CompressionLevel == 0 {
+		return
+	}
+	formats := slices.Clone(arch.Formats)
+	for _, over := range arch.FormatOverrides {
+		formats = append(formats, over.Formats...)
+	}
+	var ignored []string
+	for _, format := range formats {
+		if format != "binary" && format != "none" && !archive.SupportsLevel(format) && !slices.Contains(ignored, format) {
+			ignored = append(ignored, format)
+		}
+	}
+	if len(ignored) > 0 {
+		log.WithField("id", arch.ID).
+			WithField("formats", strings.Join(ignored, ", ")).
+			Warn("compression_level is not supported by these formats, and will be ignored")
+	}
+}
+
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
 	g := semerrgroup.New(ctx.Parallelism)
@@ -122,12 +151,13 @@ func (Pipe) Run(ctx *context.Context) error {
 				artifact.Header,
 				artifact.CArchive,
 				artifact.CShared,
+				artifact.BuildFile,
 			),
 			artifact.ByIDs(archive.IDs...),
 		}
 
 		isBinary := slices.Contains(archive.Formats, "binary")
-		artifacts := ctx.Artifacts.Filter(artifact.And(filter...)).GroupByPlatform()
+		artifacts, buildFiles := splitBuildFiles(ctx.Artifacts.Filter(artifact.And(filter...)).GroupByPlatform())
 		if err := checkArtifacts(artifacts); err != nil && !isBinary && !archive.AllowDifferentBinaryCount {
 			return fmt.Errorf("invalid archive: %d: %w", i, ErrArchiveDifferentBinaryCount)
 		}
@@ -145,7 +175,7 @@ func (Pipe) Run(ctx *context.Context) error {
 					})
 				default:
 					g.Go(func() error {
-						return create(ctx, archive, artifacts, format)
+						return create(ctx, archive, artifacts, buildFiles[group], format)
 					})
 				}
 			}
@@ -154,6 +184,23 @@ func (Pipe) Run(ctx *context.Context) error {
 	return g.Wait()
 }
 
+// splitBuildFiles splits the files created by build hooks from the binaries
+// in each group, dropping the groups without binaries.
+func splitBuildFiles(groups map[string][]*artifact.Artifact) (map[string][]*artifact.Artifact, map[string][]*artifact.Artifact) {
+	binaries := map[string][]*artifact.Artifact{}
+	files := map[string][]*artifact.Artifact{}
+	for group, artifacts := range groups {
+		for _, a := range artifacts {
+			if a.Type == artifact.BuildFile {
+				files[group] = append(files[group], a)
+				continue
+			}
+			binaries[group] = append(binaries[group], a)
+		}
+	}
+	return binaries, files
+}
+
 func checkArtifacts(artifacts map[string][]*artifact.Artifact) error {
 	lens := map[int]bool{}
 	for _, v := range artifacts {
@@ -166,10 +213,10 @@ func checkArtifacts(artifacts map[string][]*artifact.Artifact) error {
 }
 
 func createMeta(ctx *context.Context, arch config.Archive, format string) error {
-	return create(ctx, arch, nil, format)
+	return create(ctx, arch, nil, nil, format)
 }
 
-func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Artifact, format string) error {
+func create(ctx *context.Context, arch config.Archive, binaries, buildFiles []*artifact.Artifact, format string) error {
 	template := tmpl.New(ctx)
 	if len(binaries) > 0 {
 		template = template.WithArtifact(binaries[0])
@@ -178,6 +225,32 @@ func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Arti
 	if err != nil {
 		return err
 	}
+	wrap, err := template.Apply(wrapFolder(arch))
+	if err != nil {
+		return err
+	}
+	if wrap != "" && !filepath.IsLocal(wrap) {
+		return fmt.Errorf("wrap_in_directory must be a relative path within the archive, got %q", wrap)
+	}
+	binDir, err := template.Apply(arch.BinariesDirectory)
+	if err != nil {
+		return err
+	}
+	if binDir != "" && !filepath.IsLocal(binDir) {
+		return fmt.Errorf("binaries_directory must be a relative path within the archive, got %q", binDir)
+	}
+	var manifest *archivemanifest.Manifest
+	if arch.Manifest {
+		manifest = &archivemanifest.Manifest{
+			Archive: folder + "." + format,
+			ID:      arch.ID,
+			Format:  format,
+		}
+		if len(binaries) > 0 {
+			manifest.Target = binaries[0].Target
+		}
+	}
+
 	archivePath := filepath.Join(ctx.Config.Dist, folder+"."+format)
 	lock.Lock()
 	if err := os.MkdirAll(filepath.Dir(archivePath), 0o755|os.ModeDir); err != nil {
@@ -194,49 +267,29 @@ func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Arti
 		return fmt.Errorf("failed to create directory %s: %w", archivePath, err)
 	}
 	lock.Unlock()
-	defer archiveFile.Close()
 
 	log := log.WithField("name", archivePath)
 	log.Info("archiving")
-
-	wrap, err := template.Apply(wrapFolder(arch))
-	if err != nil {
-		return err
+	files, bins, err := write(ctx, arch, template, archiveFile, format, wrap, binDir, manifest, binaries, buildFiles)
+	if cerr := archiveFile.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("failed to close archive: %w", cerr)
 	}
-	a, err := archive.New(archiveFile, format)
 	if err != nil {
 		return err
 	}
-	a = NewEnhancedArchive(a, wrap)
-	defer a.Close()
 
-	files, err := archivefiles.Eval(template, arch.Files)
-	if err != nil {
-		return fmt.Errorf("failed to find files to archive: %w", err)
-	}
-	if arch.Meta && len(files) == 0 {
-		return errors.New("no files found")
-	}
-	for _, f := range files {
-		if err = a.Add(f); err != nil {
-			return fmt.Errorf("failed to add: '%s' -> '%s': %w", f.Source, f.Destination, err)
-		}
-	}
-	bins := []string{}
-	for _, binary := range binaries {
-		dst := binary.Name
-		if arch.StripBinaryDirectory {
-			dst = filepath.Base(dst)
+	var parts []string
+	if arch.SplitSize != "" {
+		size, err := parseSplitSize(arch.SplitSize)
+		if err != nil {
+			return err
 		}
-		if err := a.Add(config.File{
-			Source:      binary.Path,
-			Destination: dst,
-			Info:        arch.BuildsInfo,
-		}); err != nil {
-			return fmt.Errorf("failed to add: '%s' -> '%s': %w", binary.Path, dst, err)
+		parts, err = split(archivePath, size)
+		if err != nil {
+			return err
 		}
-		bins = append(bins, binary.Name)
 	}
+
 	art := &artifact.Artifact{
 		Type: artifact.UploadableArchive,
 		Name: folder + "." + format,
@@ -270,10 +323,154 @@ func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Arti
 		}
 	}
 
-	ctx.Artifacts.Add(art)
+	if len(parts) > 0 {
+		// split archives are published as their parts, so the pipes that need
+		// the whole archive, like checksums and packagers, won't use it.
+		for _, part := range partArtifacts(art, parts) {
+			ctx.Artifacts.Add(part)
+		}
+	} else {
+		ctx.Artifacts.Add(art)
+	}
+
+	if manifest == nil {
+		return nil
+	}
+	if err := manifest.Write(archivePath + archivemanifest.Extension); err != nil {
+		return fmt.Errorf("failed to write archive manifest: %w", err)
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:   artifact.ArchiveManifest,
+		Name:   art.Name + archivemanifest.Extension,
+		Path:   archivePath + archivemanifest.Extension,
+		Goos:   art.Goos,
+		Goarch: art.Goarch,
+		Target: art.Target,
+		Extra: map[string]any{
+			artifact.ExtraID: arch.ID,
+		},
+	})
 	return nil
 }
 
+// write writes the files and binaries of the given archive config into w,
+// returning the files and binaries it added.
+func write(
+	ctx *context.Context,
+	arch config.Archive,
+	template *tmpl.Template,
+	w io.Writer,
+	format, wrap, binDir string,
+	manifest *archivemanifest.Manifest,
+	binaries, buildFiles []*artifact.Artifact,
+) (files []config.File, bins []string, err error) {
+	a, err := archive.NewWithLevel(w, format, arch.CompressionLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+	a = NewEnhancedArchive(a, wrap)
+	defer func() {
+		if cerr := a.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("failed to close archive: %w", cerr)
+		}
+	}()
+
+	var opts []archivefiles.Option
+	if arch.StrictFiles {
+		opts = append(opts, archivefiles.Strict())
+	}
+	files, err = archivefiles.Eval(template, arch.Files, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find files to archive: %w", err)
+	}
+	if len(arch.TemplatedFiles) > 0 {
+		dir, err := os.MkdirTemp("", "goreleaser-archive-templated-")
+		if err != nil {
+			return nil, nil, err
+		}
+		defer os.RemoveAll(dir)
+		templated, err := archivefiles.EvalTemplated(template, arch.TemplatedFiles, dir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to render templated files: %w", err)
+		}
+		files = append(files, templated...)
+	}
+	if arch.Meta && len(files) == 0 {
+		return nil, nil, errors.New("no files found")
+	}
+	for _, f := range buildFiles {
+		files = append(files, config.File{
+			Source:      f.Path,
+			Destination: f.Name,
+		})
+	}
+	signer := signer{
+		ctx:   ctx,
+		signs: arch.Signs,
+	}
+	if len(binaries) > 0 {
+		signer.art = binaries[0]
+	}
+	if len(arch.Signs) > 0 {
+		signer.dir, err = os.MkdirTemp("", "goreleaser-archive-sign-")
+		if err != nil {
+			return nil, nil, err
+		}
+		defer os.RemoveAll(signer.dir)
+	}
+	for _, f := range files {
+		signed, err := signer.Sign(f, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, f := range signed {
+			if err = addFile(a, manifest, wrap, f); err != nil {
+				return nil, nil, fmt.Errorf("failed to add: '%s' -> '%s': %w", f.Source, f.Destination, err)
+			}
+		}
+	}
+	bins = []string{}
+	for _, binary := range binaries {
+		dst := binary.Name
+		if arch.StripBinaryDirectory {
+			dst = filepath.Base(dst)
+		}
+		dst = filepath.Join(binDir, dst)
+		signed, err := signer.Sign(config.File{
+			Source:      binary.Path,
+			Destination: filepath.ToSlash(dst),
+			Info:        arch.BuildsInfo,
+		}, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, f := range signed {
+			if err := addFile(a, manifest, wrap, f); err != nil {
+				return nil, nil, fmt.Errorf("failed to add: '%s' -> '%s': %w", f.Source, f.Destination, err)
+			}
+		}
+		name := binary.Name
+		if binDir != "" {
+			name = filepath.ToSlash(filepath.Join(binDir, name))
+		}
+		bins = append(bins, name)
+	}
+	return files, bins, nil
+}
+
+// addFile adds the given file to the archive, and to the manifest if it is
+// not nil.
+func addFile(a archive.Archive, manifest *archivemanifest.Manifest, wrap string, f config.File) error {
+	if err := a.Add(f); err != nil {
+		return err
+	}
+	if manifest == nil {
+		return nil
+	}
+	dst := strings.ReplaceAll(filepath.Join(wrap, f.Destination), "\\", "/")
+	return manifest.Add(f.Source, dst, f.Info.Mode)
+}
+
 func listExtraFiles(files []config.File) []string {
 	result := make([]string, 0, len(files))
 	for _, f := range files {
@@ -4,6 +4,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/goreleaser/goreleaser/v2/internal/archivemanifest"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
@@ -35,6 +37,48 @@ func TestMeta(t *testing.T) {
 		)
 	})
 
+	t.Run("manifest", func(t *testing.T) {
+		dist := t.TempDir()
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					Meta:            true,
+					Manifest:        true,
+					NameTemplate:    "foo",
+					WrapInDirectory: "true",
+					Files: []config.File{
+						{
+							Source: "testdata/**/*.txt",
+							Info:   config.FileInfo{Mode: 0o644},
+						},
+					},
+				},
+			},
+		})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Run(ctx))
+
+		manifests := ctx.Artifacts.Filter(artifact.ByType(artifact.ArchiveManifest)).List()
+		require.Len(t, manifests, 1)
+		require.Equal(t, "foo.tar.gz.manifest.json", manifests[0].Name)
+		require.Equal(t, filepath.Join(dist, "foo.tar.gz.manifest.json"), manifests[0].Path)
+
+		manifest, err := archivemanifest.Read(manifests[0].Path)
+		require.NoError(t, err)
+		require.Equal(t, "foo.tar.gz", manifest.Archive)
+		require.Equal(t, "tar.gz", manifest.Format)
+		require.Len(t, manifest.Files, 3)
+		for i, path := range []string{"foo/testdata/a/a.txt", "foo/testdata/a/b/a.txt", "foo/testdata/a/b/c/d.txt"} {
+			require.Equal(t, archivemanifest.File{
+				Path:   path,
+				Mode:   0o644,
+				SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			}, manifest.Files[i])
+		}
+	})
+
 	t.Run("bad tmpl", func(t *testing.T) {
 		dist := t.TempDir()
 		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
@@ -530,6 +530,120 @@ func TestRunPipeBinary(t *testing.T) {
 	testlib.RequireNoExtraField(t, windows2, artifact.ExtraReplaces)
 }
 
+func TestRunPipeWasm(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.MkdirAll(filepath.Join(dist, "js_wasm"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dist, "js_wasm", "mybin.wasm"), []byte("fake"), 0o644))
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					ID:           "bin",
+					Formats:      []string{"binary"},
+					NameTemplate: defaultBinaryNameTemplate,
+				},
+				{
+					ID:           "tgz",
+					Formats:      []string{"tar.gz"},
+					NameTemplate: defaultNameTemplate,
+				},
+			},
+		},
+		testctx.WithVersion("0.0.1"),
+		testctx.WithCurrentTag("v0.0.1"))
+	ctx.Config.ProjectName = "foobar"
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "js",
+		Goarch: "wasm",
+		Name:   "mybin.wasm",
+		Path:   filepath.Join(dist, "js_wasm", "mybin.wasm"),
+		Type:   artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraExt:    ".wasm",
+			artifact.ExtraID:     "default",
+			artifact.ExtraWasm:   true,
+		},
+	})
+
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	binaries := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableBinary)).List()
+	require.Len(t, binaries, 1)
+	require.Equal(t, "mybin_0.0.1_js_wasm.wasm", binaries[0].Name)
+
+	archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+	require.Len(t, archives, 1)
+	require.Equal(t, "foobar_0.0.1_js_wasm.tar.gz", archives[0].Name)
+	require.Equal(t, []string{"mybin.wasm"}, testlib.LsArchive(t, archives[0].Path, "tar.gz"))
+}
+
+func TestRunPipeBuildFiles(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	for _, plat := range []string{"linux_amd64", "darwin_arm64"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dist, plat), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dist, plat, "mybin"), []byte("fake"), 0o755))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(folder, "completions"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "completions", "mybin.bash"), []byte("complete"), 0o644))
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					ID:           "tgz",
+					Formats:      []string{"tar.gz"},
+					NameTemplate: defaultNameTemplate,
+				},
+			},
+		},
+		testctx.WithVersion("0.0.1"),
+		testctx.WithCurrentTag("v0.0.1"))
+	ctx.Config.ProjectName = "foobar"
+
+	for _, plat := range []string{"linux_amd64", "darwin_arm64"} {
+		goos, goarch, _ := strings.Cut(plat, "_")
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   goos,
+			Goarch: goarch,
+			Name:   "mybin",
+			Path:   filepath.Join(dist, plat, "mybin"),
+			Type:   artifact.Binary,
+			Extra: map[string]any{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "linux",
+		Goarch: "amd64",
+		Name:   "completions/mybin.bash",
+		Path:   filepath.Join(folder, "completions", "mybin.bash"),
+		Type:   artifact.BuildFile,
+		Extra: map[string]any{
+			artifact.ExtraID: "default",
+		},
+	})
+
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+	require.Len(t, archives, 2)
+	for _, arch := range archives {
+		expected := []string{"mybin"}
+		if arch.Goos == "linux" {
+			expected = []string{"completions/mybin.bash", "mybin"}
+			require.Equal(t, []string{"completions/mybin.bash"}, artifact.MustExtra[[]string](*arch, artifact.ExtraFiles))
+		}
+		require.Equal(t, expected, testlib.LsArchive(t, arch.Path, "tar.gz"))
+	}
+}
+
 func TestRunPipeDistRemoved(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(),
 		config.Project{
@@ -599,6 +713,44 @@ func TestRunPipeInvalidGlob(t *testing.T) {
 	require.EqualError(t, Pipe{}.Run(ctx), `failed to find files to archive: globbing failed for pattern [x-]: compile glob pattern: unexpected end of input`)
 }
 
+func TestRunPipeStrictFiles(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.MkdirAll(filepath.Join(dist, "darwinamd64"), 0o755))
+	f, err := os.Create(filepath.Join(dist, "darwinamd64", "mybin"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					IDs:          []string{"default"},
+					NameTemplate: "foo",
+					Formats:      []string{"zip"},
+					StrictFiles:  true,
+					Files: []config.File{
+						{Source: "LICENSE.md"},
+					},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v0.0.1"))
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "darwin",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   filepath.Join("dist", "darwinamd64", "mybin"),
+		Type:   artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraID:     "default",
+		},
+	})
+	require.EqualError(t, Pipe{}.Run(ctx), `failed to find files to archive: no files matched the glob LICENSE.md`)
+}
+
 func TestRunPipeNameTemplateWithSpace(t *testing.T) {
 	folder := testlib.Mktmp(t)
 	dist := filepath.Join(folder, "dist")
@@ -806,6 +958,99 @@ func TestRunPipeWrap(t *testing.T) {
 	)
 }
 
+func TestRunPipeNestedLayout(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.MkdirAll(filepath.Join(dist, "darwinamd64"), 0o755))
+	f, err := os.Create(filepath.Join(dist, "darwinamd64", "mybin"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	f, err = os.Create(filepath.Join(folder, "README.md"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			ProjectName: "foo",
+			Dist:        dist,
+			Archives: []config.Archive{
+				{
+					IDs:               []string{"default"},
+					NameTemplate:      "foo",
+					WrapInDirectory:   "{{ .ProjectName }}/{{ .Version }}",
+					BinariesDirectory: "bin",
+					Formats:           []string{"tar.gz"},
+					Files: []config.File{
+						{Source: "README.*", Destination: "share/doc/foo"},
+					},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v0.0.1"),
+		testctx.WithVersion("0.0.1"))
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "darwin",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   filepath.Join("dist", "darwinamd64", "mybin"),
+		Type:   artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraID:     "default",
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+	require.Len(t, archives, 1)
+	require.Equal(t, "foo/0.0.1", artifact.MustExtra[string](*archives[0], artifact.ExtraWrappedIn))
+	require.Equal(t, []string{"bin/mybin"}, artifact.MustExtra[[]string](*archives[0], artifact.ExtraBinaries))
+
+	require.ElementsMatch(
+		t,
+		[]string{"foo/0.0.1/share/doc/foo/README.md", "foo/0.0.1/bin/mybin"},
+		testlib.LsArchive(t, filepath.Join(dist, "foo.tar.gz"), "tar.gz"),
+	)
+}
+
+func TestRunPipeInvalidLayout(t *testing.T) {
+	for name, arch := range map[string]config.Archive{
+		"wrap": {
+			WrapInDirectory: "../foo",
+		},
+		"binaries directory": {
+			BinariesDirectory: "/usr/bin",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := testlib.Mktmp(t)
+			dist := filepath.Join(folder, "dist")
+			require.NoError(t, os.Mkdir(dist, 0o755))
+			arch.IDs = []string{"default"}
+			arch.NameTemplate = "foo"
+			arch.Formats = []string{"tar.gz"}
+			ctx := testctx.WrapWithCfg(t.Context(),
+				config.Project{
+					Dist:     dist,
+					Archives: []config.Archive{arch},
+				},
+				testctx.WithCurrentTag("v0.0.1"))
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Goos:   "darwin",
+				Goarch: "amd64",
+				Name:   "mybin",
+				Path:   filepath.Join("dist", "mybin"),
+				Type:   artifact.Binary,
+				Extra: map[string]any{
+					artifact.ExtraBinary: "mybin",
+					artifact.ExtraID:     "default",
+				},
+			})
+			require.ErrorContains(t, Pipe{}.Run(ctx), "must be a relative path within the archive")
+		})
+	}
+}
+
 func TestDefault(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Archives: []config.Archive{},
@@ -902,6 +1147,157 @@ func TestFormatFor(t *testing.T) {
 	require.Equal(t, []string{"none"}, packageFormats(ctx.Config.Archives[0], "darwin"))
 }
 
+func TestRunPipeSevenZipOverride(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	for _, arch := range []string{"linuxamd64", "windowsamd64"} {
+		createFakeBinary(t, dist, arch, "mybin")
+	}
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist:        dist,
+			ProjectName: "foo",
+			Archives: []config.Archive{
+				{
+					IDs:          []string{"default"},
+					NameTemplate: "{{ .ProjectName }}_{{ .Os }}",
+					Formats:      []string{"tar.gz"},
+					FormatOverrides: []config.FormatOverride{
+						{
+							Goos:    "windows",
+							Formats: []string{"7z"},
+						},
+					},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v0.0.1"))
+	for _, goos := range []string{"linux", "windows"} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   goos,
+			Goarch: "amd64",
+			Name:   "mybin",
+			Path:   filepath.Join(dist, goos+"amd64", "mybin"),
+			Type:   artifact.Binary,
+			Extra: map[string]any{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+	require.Len(t, archives, 2)
+	for _, a := range archives {
+		switch a.Goos {
+		case "windows":
+			require.Equal(t, "foo_windows.7z", a.Name)
+			require.Equal(t, "7z", a.Format())
+		default:
+			require.Equal(t, "foo_linux.tar.gz", a.Name)
+			require.Equal(t, "tar.gz", a.Format())
+		}
+		require.FileExists(t, a.Path)
+	}
+}
+
+func TestRunPipeTemplatedFiles(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	createFakeBinary(t, dist, "linuxarm64", "mybin")
+	require.NoError(t, os.WriteFile(
+		filepath.Join(folder, "install.sh.tpl"),
+		[]byte("#!/bin/sh\ninstall {{ .ProjectName }} {{ .Version }} for {{ .Os }}/{{ .Arch }}\n"),
+		0o755,
+	))
+
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist:        dist,
+			ProjectName: "foo",
+			Archives: []config.Archive{
+				{
+					IDs:          []string{"default"},
+					NameTemplate: "foo",
+					Formats:      []string{"tar.gz"},
+					TemplatedFiles: []config.TemplatedFile{
+						{
+							Source:      "install.sh.tpl",
+							Destination: "scripts/install-{{ .Arch }}.sh",
+						},
+					},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v1.0.0"),
+		testctx.WithVersion("1.0.0"))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "linux",
+		Goarch: "arm64",
+		Name:   "mybin",
+		Path:   filepath.Join(dist, "linuxarm64", "mybin"),
+		Type:   artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraID:     "default",
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+	require.Len(t, archives, 1)
+	require.Equal(t, []string{"scripts/install-arm64.sh"}, artifact.MustExtra[[]string](*archives[0], artifact.ExtraFiles))
+	require.ElementsMatch(
+		t,
+		[]string{"mybin", "scripts/install-arm64.sh"},
+		testlib.LsArchive(t, archives[0].Path, "tar.gz"),
+	)
+	require.Equal(
+		t,
+		"#!/bin/sh\ninstall foo 1.0.0 for linux/arm64\n",
+		string(testlib.GetFileFromArchive(t, archives[0].Path, "tar.gz", "scripts/install-arm64.sh")),
+	)
+}
+
+func TestRunPipeTemplatedFilesError(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	createFakeBinary(t, dist, "linuxarm64", "mybin")
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "bad.tpl"), []byte("{{ .Nope }}"), 0o644))
+
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					IDs:          []string{"default"},
+					NameTemplate: "foo",
+					Formats:      []string{"tar.gz"},
+					TemplatedFiles: []config.TemplatedFile{
+						{Source: "bad.tpl", Destination: "bad"},
+					},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v1.0.0"))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "linux",
+		Goarch: "arm64",
+		Name:   "mybin",
+		Path:   filepath.Join(dist, "linuxarm64", "mybin"),
+		Type:   artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraID:     "default",
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+}
+
 func TestBinaryOverride(t *testing.T) {
 	folder := testlib.Mktmp(t)
 	dist := filepath.Join(folder, "dist")
@@ -1195,12 +1591,12 @@ func TestInvalidFormat(t *testing.T) {
 				ID:           "foo",
 				NameTemplate: "foo",
 				Meta:         true,
-				Formats:      []string{"7z"},
+				Formats:      []string{"rar"},
 			},
 		},
 	})
 
-	require.EqualError(t, Pipe{}.Run(ctx), "invalid archive format: 7z")
+	require.EqualError(t, Pipe{}.Run(ctx), "invalid archive format: rar")
 }
 
 func TestIssue3803(t *testing.T) {
@@ -0,0 +1,177 @@
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/redact"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	var cmds []string
+	for _, archive := range ctx.Config.Archives {
+		for _, sign := range archive.Signs {
+			cmds = append(cmds, sign.Cmd)
+		}
+	}
+	return cmds
+}
+
+// signer signs files before they are added to an archive.
+// Files are copied into dir before being signed, so the originals, which
+// might be used by other archives and packages, are left untouched.
+type signer struct {
+	ctx   *context.Context
+	art   *artifact.Artifact
+	signs []config.ArchiveSign
+	dir   string
+}
+
+func (s signer) template() *tmpl.Template {
+	t := tmpl.New(s.ctx)
+	if s.art != nil {
+		t = t.WithArtifact(s.art)
+	}
+	return t
+}
+
+// Sign signs the given file with all the matching signs, returning the files
+// to add to the archive instead of it.
+func (s signer) Sign(f config.File, binary bool) ([]config.File, error) {
+	result := []config.File{f}
+	for _, sign := range s.signs {
+		if !signMatches(sign, f.Destination, binary) {
+			continue
+		}
+		if result[0].Source == f.Source {
+			signed := filepath.Join(s.dir, f.Destination)
+			if err := os.MkdirAll(filepath.Dir(signed), 0o755); err != nil {
+				return nil, err
+			}
+			if err := gio.Copy(f.Source, signed); err != nil {
+				return nil, fmt.Errorf("could not copy %s to sign it: %w", f.Source, err)
+			}
+			result[0].Source = signed
+		}
+		sig, err := s.sign(sign, result[0])
+		if err != nil {
+			return nil, err
+		}
+		if sig.Source != "" {
+			result = append(result, sig)
+		}
+	}
+	return result, nil
+}
+
+func (s signer) sign(sign config.ArchiveSign, f config.File) (config.File, error) {
+	env := s.ctx.Env.Copy()
+	env["artifact"] = f.Source
+	env["artifactName"] = path.Base(f.Destination)
+
+	var tenv []string
+	for _, e := range sign.Env {
+		te, err := s.template().WithEnvS(tenv).Apply(e)
+		if err != nil {
+			return config.File{}, fmt.Errorf("sign failed: %s: %w", f.Destination, err)
+		}
+		tenv = append(tenv, te)
+	}
+	maps.Copy(env, context.ToEnv(tenv))
+
+	var sig config.File
+	if sign.Signature != "" {
+		name, err := s.template().WithEnv(env).Apply(expand(sign.Signature, env))
+		if err != nil {
+			return config.File{}, fmt.Errorf("sign failed: %s: %w", f.Destination, err)
+		}
+		sig = config.File{
+			Source:      filepath.Join(filepath.Dir(f.Source), name),
+			Destination: path.Join(path.Dir(f.Destination), name),
+		}
+		env["signature"] = sig.Source
+	}
+
+	args := make([]string, 0, len(sign.Args))
+	for _, a := range sign.Args {
+		arg, err := s.template().WithEnv(env).Apply(expand(a, env))
+		if err != nil {
+			return config.File{}, fmt.Errorf("sign failed: %s: %w", f.Destination, err)
+		}
+		args = append(args, arg)
+	}
+
+	output, err := s.template().WithEnv(env).Bool(sign.Output)
+	if err != nil {
+		return config.File{}, fmt.Errorf("sign failed: %s: %w", f.Destination, err)
+	}
+
+	// #nosec
+	cmd := exec.CommandContext(s.ctx, sign.Cmd, args...)
+	cmd.Env = env.Strings()
+	var b bytes.Buffer
+	w := gio.Safe(&b)
+	cmd.Stderr = redact.Writer(io.MultiWriter(logext.NewConditionalWriter(output), w), cmd.Env)
+	cmd.Stdout = redact.Writer(io.MultiWriter(logext.NewConditionalWriter(output), w), cmd.Env)
+
+	log.WithField("cmd", sign.Cmd).
+		WithField("file", f.Destination).
+		Info("signing")
+	if err := cmd.Run(); err != nil {
+		return config.File{}, gerrors.Wrap(
+			err,
+			gerrors.WithMessage("could not sign file"),
+			gerrors.WithDetails(
+				"cmd", sign.Cmd,
+				"file", f.Destination,
+			),
+			gerrors.WithOutput(b.String()),
+		)
+	}
+
+	if sig.Source != "" {
+		if _, err := os.Stat(sig.Source); err != nil {
+			return config.File{}, fmt.Errorf("sign failed: %s: signature not found: %w", f.Destination, err)
+		}
+	}
+	return sig, nil
+}
+
+// signMatches reports whether the file at dst inside the archive should be
+// signed.
+// Without files, only binaries are signed.
+func signMatches(sign config.ArchiveSign, dst string, binary bool) bool {
+	if len(sign.Files) == 0 {
+		return binary
+	}
+	for _, glob := range sign.Files {
+		if ok, _ := path.Match(glob, dst); ok {
+			return true
+		}
+		if ok, _ := path.Match(glob, path.Base(dst)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func expand(s string, env map[string]string) string {
+	return os.Expand(s, func(key string) string {
+		return env[key]
+	})
+}
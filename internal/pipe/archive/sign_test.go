@@ -0,0 +1,180 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMatches(t *testing.T) {
+	for name, tc := range map[string]struct {
+		files  []string
+		dst    string
+		binary bool
+		match  bool
+	}{
+		"binary":           {dst: "bin/foo", binary: true, match: true},
+		"not binary":       {dst: "README.md"},
+		"glob":             {files: []string{"*.exe"}, dst: "foo.exe", match: true},
+		"glob base":        {files: []string{"*.exe"}, dst: "bin/foo.exe", match: true},
+		"glob path":        {files: []string{"lib/*.dll"}, dst: "lib/foo.dll", match: true},
+		"glob no match":    {files: []string{"*.exe"}, dst: "foo", binary: true},
+		"several globs":    {files: []string{"*.exe", "*.dll"}, dst: "foo.dll", match: true},
+		"several no match": {files: []string{"*.exe", "*.dll"}, dst: "foo.so"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.match, signMatches(config.ArchiveSign{Files: tc.files}, tc.dst, tc.binary))
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Archives: []config.Archive{
+			{Signs: []config.ArchiveSign{{Cmd: "signtool"}}},
+			{},
+			{Signs: []config.ArchiveSign{{Cmd: "codesign"}}},
+		},
+	})
+	require.Equal(t, []string{"signtool", "codesign"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestRunPipeSign(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses a shell script")
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.MkdirAll(filepath.Join(dist, "windowsamd64"), 0o755))
+	bin := filepath.Join(dist, "windowsamd64", "mybin.exe")
+	require.NoError(t, os.WriteFile(bin, []byte("binary"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "README.md"), []byte("readme"), 0o644))
+
+	// fake signing tool, which signs in place and optionally writes a
+	// detached signature.
+	signer := filepath.Join(t.TempDir(), "signer")
+	require.NoError(t, os.WriteFile(signer, []byte(`#!/bin/sh
+echo " signed by $KEY" >> "$1"
+if [ -n "$2" ]; then
+	echo "signature" > "$2"
+fi
+`), 0o755))
+
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					IDs:             []string{"default"},
+					NameTemplate:    "foo",
+					WrapInDirectory: "foo",
+					Formats:         []string{"tar.gz"},
+					Files: []config.File{
+						{Source: "README.md"},
+					},
+					Signs: []config.ArchiveSign{
+						{
+							Cmd:  signer,
+							Args: []string{"${artifact}"},
+							Env:  []string{"KEY={{ .Os }}"},
+						},
+						{
+							Cmd:       signer,
+							Files:     []string{"*.exe"},
+							Signature: "${artifactName}.sig",
+							Args:      []string{"${artifact}", "${signature}"},
+							Env:       []string{"KEY=detached"},
+						},
+					},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v0.0.1"))
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "windows",
+		Goarch: "amd64",
+		Name:   "mybin.exe",
+		Path:   bin,
+		Type:   artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraExt:    ".exe",
+			artifact.ExtraID:     "default",
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	path := filepath.Join(dist, "foo.tar.gz")
+	require.ElementsMatch(
+		t,
+		[]string{"foo/README.md", "foo/mybin.exe", "foo/mybin.exe.sig"},
+		testlib.LsArchive(t, path, "tar.gz"),
+	)
+	require.Equal(
+		t,
+		"binary signed by windows\n signed by detached\n",
+		string(testlib.GetFileFromArchive(t, path, "tar.gz", "foo/mybin.exe")),
+	)
+	require.Equal(
+		t,
+		"signature\n",
+		string(testlib.GetFileFromArchive(t, path, "tar.gz", "foo/mybin.exe.sig")),
+	)
+	require.Equal(
+		t,
+		"readme",
+		string(testlib.GetFileFromArchive(t, path, "tar.gz", "foo/README.md")),
+	)
+
+	// the original binary is left untouched
+	bts, err := os.ReadFile(bin)
+	require.NoError(t, err)
+	require.Equal(t, "binary", string(bts))
+}
+
+func TestRunPipeSignFails(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.MkdirAll(filepath.Join(dist, "darwinamd64"), 0o755))
+	bin := filepath.Join(dist, "darwinamd64", "mybin")
+	require.NoError(t, os.WriteFile(bin, []byte("binary"), 0o755))
+
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					IDs:          []string{"default"},
+					NameTemplate: "foo",
+					Formats:      []string{"tar.gz"},
+					Signs: []config.ArchiveSign{
+						{Cmd: "false"},
+					},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v0.0.1"))
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "darwin",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   bin,
+		Type:   artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraID:     "default",
+		},
+	})
+	err := Pipe{}.Run(ctx)
+	require.EqualError(t, err, "exit status 1")
+	var gerr gerrors.ErrDetailed
+	require.ErrorAs(t, err, &gerr)
+	require.Equal(t, []string{"could not sign file"}, gerr.Messages())
+}
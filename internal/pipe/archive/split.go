@@ -0,0 +1,142 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/docker/go-units"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+)
+
+// partsExtension is the extension added to the archive name to form the name
+// of the file listing its parts.
+const partsExtension = ".parts"
+
+// parseSplitSize parses the given human readable size, e.g. 2GB.
+func parseSplitSize(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	size, err := units.RAMInBytes(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid split_size %q: %w", s, err)
+	}
+	if size <= 0 {
+		return 0, fmt.Errorf("invalid split_size %q: must be greater than zero", s)
+	}
+	return size, nil
+}
+
+// split splits the archive at path into parts of at most size bytes, named
+// path.001, path.002, and so on, if it is bigger than size.
+// It also writes a path.parts file with the SHA256 of each part, and of the
+// reassembled archive, which can be checked with 'sha256sum -c'.
+// It returns the paths of the parts, or nil if the archive was not split.
+func split(path string, size int64) ([]string, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 || stat.Size() <= size {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var parts []string
+	var sums strings.Builder
+	for i := 1; ; i++ {
+		part := fmt.Sprintf("%s.%03d", path, i)
+		sum, n, err := writePart(f, part, size)
+		if err != nil {
+			return nil, fmt.Errorf("could not split archive: %w", err)
+		}
+		if n == 0 {
+			if err := os.Remove(part); err != nil {
+				return nil, err
+			}
+			break
+		}
+		parts = append(parts, part)
+		fmt.Fprintf(&sums, "%s  %s\n", sum, filepath.Base(part))
+		if n < size {
+			break
+		}
+	}
+
+	sum, err := sha256sum(path)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&sums, "%s  %s\n", sum, filepath.Base(path))
+	if err := os.WriteFile(path+partsExtension, []byte(sums.String()), 0o644); err != nil {
+		return nil, err
+	}
+
+	log.WithField("archive", path).
+		WithField("parts", len(parts)).
+		Info("split archive")
+	return parts, nil
+}
+
+// writePart copies at most size bytes from r into a new file at path,
+// returning its SHA256 and how many bytes were written.
+func writePart(r io.Reader, path string, size int64) (string, int64, error) {
+	w, err := os.Create(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer w.Close()
+	h := sha256.New()
+	n, err := io.CopyN(io.MultiWriter(w, h), r, size)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", n, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, w.Close()
+}
+
+func sha256sum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// partArtifacts returns the artifacts of the parts of the given split
+// archive, and of the file listing them.
+func partArtifacts(art *artifact.Artifact, parts []string) []*artifact.Artifact {
+	paths := append(slices.Clone(parts), art.Path+partsExtension)
+	result := make([]*artifact.Artifact, 0, len(paths))
+	for _, path := range paths {
+		result = append(result, &artifact.Artifact{
+			Type:   artifact.UploadableFile,
+			Name:   filepath.Base(path),
+			Path:   path,
+			Goos:   art.Goos,
+			Goarch: art.Goarch,
+			Target: art.Target,
+			Extra: map[string]any{
+				artifact.ExtraID: art.ID(),
+			},
+		})
+	}
+	return result
+}
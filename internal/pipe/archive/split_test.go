@@ -0,0 +1,148 @@
+package archive
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSplitSize(t *testing.T) {
+	for s, expected := range map[string]int64{
+		"":      0,
+		"1024":  1024,
+		"10k":   10 * 1024,
+		"2GB":   2 * 1024 * 1024 * 1024,
+		"1.5MB": 1.5 * 1024 * 1024,
+	} {
+		t.Run(s, func(t *testing.T) {
+			size, err := parseSplitSize(s)
+			require.NoError(t, err)
+			require.Equal(t, expected, size)
+		})
+	}
+
+	for _, s := range []string{"nope", "-1", "0"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := parseSplitSize(s)
+			require.ErrorContains(t, err, "invalid split_size")
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 25)
+
+	t.Run("smaller than size", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "foo.tar.gz")
+		require.NoError(t, os.WriteFile(path, content, 0o644))
+		parts, err := split(path, 250)
+		require.NoError(t, err)
+		require.Empty(t, parts)
+		require.NoFileExists(t, path+partsExtension)
+	})
+
+	for name, size := range map[string]int64{
+		"uneven": 100,
+		"even":   125,
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "foo.tar.gz")
+			require.NoError(t, os.WriteFile(path, content, 0o644))
+			parts, err := split(path, size)
+			require.NoError(t, err)
+
+			var joined []byte
+			for i, part := range parts {
+				require.Equal(t, filepath.Join(dir, fmt.Sprintf("foo.tar.gz.%03d", i+1)), part)
+				bts, err := os.ReadFile(part)
+				require.NoError(t, err)
+				require.LessOrEqual(t, int64(len(bts)), size)
+				joined = append(joined, bts...)
+			}
+			require.Equal(t, content, joined)
+			require.Len(t, parts, (len(content)+int(size)-1)/int(size))
+
+			sums, err := os.ReadFile(path + partsExtension)
+			require.NoError(t, err)
+			lines := bytes.Split(bytes.TrimSpace(sums), []byte("\n"))
+			require.Len(t, lines, len(parts)+1)
+			require.True(t, bytes.HasSuffix(lines[0], []byte("  foo.tar.gz.001")))
+			require.True(t, bytes.HasSuffix(lines[len(lines)-1], []byte("  foo.tar.gz")))
+		})
+	}
+}
+
+func TestRunPipeSplit(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.MkdirAll(filepath.Join(dist, "darwinamd64"), 0o755))
+	bin := filepath.Join(dist, "darwinamd64", "mybin")
+	// random content, so it doesn't compress.
+	content := make([]byte, 64*1024)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(bin, content, 0o755))
+
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					IDs:          []string{"default"},
+					NameTemplate: "foo",
+					Formats:      []string{"tar.gz"},
+					SplitSize:    "1k",
+				},
+			},
+		},
+		testctx.WithCurrentTag("v0.0.1"))
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "darwin",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   bin,
+		Type:   artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraID:     "default",
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	// the whole archive is not published, only its parts.
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List())
+	parts := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableFile)).List()
+	require.Greater(t, len(parts), 2)
+	require.Equal(t, "foo.tar.gz.001", parts[0].Name)
+	require.Equal(t, filepath.Join("dist", "foo.tar.gz.001"), parts[0].Path)
+	require.Equal(t, "foo.tar.gz.parts", parts[len(parts)-1].Name)
+	for _, part := range parts {
+		require.Equal(t, "darwin", part.Goos)
+		require.Equal(t, "amd64", part.Goarch)
+		require.Equal(t, "default", part.ID())
+	}
+
+	// the whole archive is still there, and still valid.
+	require.Equal(t, []string{"mybin"}, testlib.LsArchive(t, filepath.Join(dist, "foo.tar.gz"), "tar.gz"))
+}
+
+func TestDefaultInvalidSplitSize(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Archives: []config.Archive{
+			{SplitSize: "2 potatoes"},
+		},
+	})
+	require.ErrorContains(t, Pipe{}.Default(ctx), `invalid split_size "2 potatoes"`)
+}
@@ -0,0 +1,379 @@
+// Package asdf creates asdf plugins, which can also be used by mise, and
+// commits them to a git repository.
+package asdf
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/commitauthor"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	asdfConfigExtra = "AsdfConfig"
+
+	// versionVar is the shell variable the plugin scripts hold the version
+	// being installed in.
+	versionVar = "${version}"
+)
+
+var (
+	errNoArchivesFound  = errors.New("no archives found matching goos=[darwin linux] goarch=[amd64 arm arm64 386 all]")
+	errMultipleArchives = errors.New("one asdf plugin can handle only one archive of each OS/Arch combination")
+	errNoGitURL         = errors.New("asdf: repository.git.url is required, as the plugin scripts need to be executable")
+	errSkipUpload       = pipe.Skip("asdf.skip_upload is set")
+	errSkipUploadAuto   = pipe.Skip("asdf.skip_upload is set to 'auto', and current version is a pre-release")
+)
+
+// scripts are the asdf plugin callbacks we create, by their path in the
+// plugin.
+var scripts = map[string]string{
+	"bin/list-all": listAllTemplate,
+	"bin/download": downloadTemplate,
+	"bin/install":  installTemplate,
+}
+
+// Pipe for asdf plugins.
+type Pipe struct{}
+
+func (Pipe) String() string        { return "asdf plugins" }
+func (Pipe) ContinueOnError() bool { return true }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.Asdf) || len(ctx.Config.Asdfs) == 0
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	for i := range ctx.Config.Asdfs {
+		asdf := &ctx.Config.Asdfs[i]
+
+		asdf.CommitAuthor = commitauthor.Default(asdf.CommitAuthor)
+		if asdf.CommitMessageTemplate == "" {
+			asdf.CommitMessageTemplate = "asdf plugin update for {{ .ProjectName }} version {{ .Tag }}"
+		}
+		if asdf.Name == "" {
+			asdf.Name = ctx.Config.ProjectName
+		}
+		if asdf.Goamd64 == "" {
+			asdf.Goamd64 = "v1"
+		}
+		if asdf.Goarm == "" {
+			asdf.Goarm = "6"
+		}
+	}
+	return nil
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	cli, err := client.NewReleaseClient(ctx)
+	if err != nil {
+		return err
+	}
+	return runAll(ctx, cli)
+}
+
+func runAll(ctx *context.Context, cli client.ReleaseURLTemplater) error {
+	for _, asdf := range ctx.Config.Asdfs {
+		if err := doRun(ctx, asdf, cli); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func doRun(ctx *context.Context, asdf config.Asdf, cl client.ReleaseURLTemplater) error {
+	filters := []artifact.Filter{
+		artifact.ByGooses("darwin", "linux"),
+		artifact.Or(
+			artifact.And(
+				artifact.ByGoarch("amd64"),
+				artifact.ByGoamd64(asdf.Goamd64),
+			),
+			artifact.ByGoarch("arm64"),
+			artifact.ByGoarch("386"),
+			artifact.ByGoarch("all"),
+			artifact.And(
+				artifact.ByGoarch("arm"),
+				artifact.ByGoarm(asdf.Goarm),
+			),
+		),
+		artifact.Or(
+			artifact.And(
+				artifact.ByType(artifact.UploadableArchive),
+				artifact.ByFormats("tar.gz", "tgz", "tar.xz", "txz", "tar", "zip"),
+			),
+			artifact.ByType(artifact.UploadableBinary),
+		),
+		artifact.OnlyReplacingUnibins,
+	}
+	if len(asdf.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(asdf.IDs...))
+	}
+
+	archives := ctx.Artifacts.Filter(artifact.And(filters...)).List()
+	if len(archives) == 0 {
+		return errNoArchivesFound
+	}
+
+	if err := tmpl.New(ctx).ApplyAll(
+		&asdf.Name,
+		&asdf.SourceURL,
+		&asdf.SkipUpload,
+	); err != nil {
+		return err
+	}
+
+	data, err := dataFor(ctx, asdf, cl, archives)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "asdf", asdf.Name)
+	for _, name := range slices.Sorted(maps.Keys(scripts)) {
+		content, err := doBuildScript(scripts[name], data)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		log.WithField("script", path).Info("writing")
+		if err := os.WriteFile(path, []byte(content), 0o755); err != nil { //nolint:gosec
+			return fmt.Errorf("failed to write asdf plugin: %w", err)
+		}
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: asdf.Name,
+		Path: dir,
+		Type: artifact.AsdfPlugin,
+		Extra: map[string]any{
+			asdfConfigExtra: asdf,
+		},
+	})
+	return nil
+}
+
+func doBuildScript(script string, data templateData) (string, error) {
+	t, err := template.New(data.Name).Parse(script)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func dataFor(ctx *context.Context, asdf config.Asdf, cl client.ReleaseURLTemplater, archives []*artifact.Artifact) (templateData, error) {
+	result := templateData{
+		Name:      asdf.Name,
+		SourceURL: asdf.SourceURL,
+		TagPrefix: strings.TrimSuffix(ctx.Git.CurrentTag, ctx.Version),
+	}
+
+	urlTemplate := asdf.URLTemplate
+	if urlTemplate == "" {
+		var err error
+		urlTemplate, err = cl.ReleaseURLTemplate(ctx)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, art := range archives {
+		key := art.Goos + "/" + art.Goarch
+		if seen[key] {
+			return result, errMultipleArchives
+		}
+		seen[key] = true
+
+		url, err := tmpl.New(ctx).WithArtifact(art).Apply(urlTemplate)
+		if err != nil {
+			return result, err
+		}
+		if result.SourceURL == "" {
+			result.SourceURL, err = sourceURL(url)
+			if err != nil {
+				return result, err
+			}
+		}
+
+		p := platform{
+			Case: platformCase(art),
+			URL:  versionless(ctx, url),
+			File: versionless(ctx, art.Name),
+		}
+		if art.Type == artifact.UploadableBinary {
+			p.Format = "binary"
+			p.Binaries = []string{artifact.MustExtra[string](*art, artifact.ExtraBinary)}
+		} else {
+			p.Format = "tar"
+			if art.Format() == "zip" {
+				p.Format = "zip"
+			}
+			wrap := artifact.ExtraOr(*art, artifact.ExtraWrappedIn, "")
+			for _, bin := range artifact.MustExtra[[]string](*art, artifact.ExtraBinaries) {
+				p.Binaries = append(p.Binaries, versionless(ctx, path.Join(wrap, bin)))
+			}
+		}
+		result.Platforms = append(result.Platforms, p)
+	}
+
+	slices.SortFunc(result.Platforms, func(a, b platform) int {
+		return cmp.Compare(a.Case, b.Case)
+	})
+
+	checksums, err := checksumsFile(ctx)
+	if err != nil || checksums == nil {
+		return result, err
+	}
+	url, err := tmpl.New(ctx).WithArtifact(checksums).Apply(urlTemplate)
+	if err != nil {
+		return result, err
+	}
+	result.ChecksumsURL = versionless(ctx, url)
+	return result, nil
+}
+
+// platformCase returns the shell case pattern matching the output of
+// `uname -s` and `uname -m` on the platform of the given artifact.
+func platformCase(art *artifact.Artifact) string {
+	var arches []string
+	switch art.Goarch {
+	case "amd64":
+		arches = []string{"x86_64"}
+	case "arm64":
+		arches = []string{"aarch64", "arm64"}
+	case "386":
+		arches = []string{"i386", "i686"}
+	case "arm":
+		arches = []string{"armv" + art.Goarm + "l"}
+	case "all":
+		arches = []string{"x86_64", "arm64"}
+	}
+	cases := make([]string, 0, len(arches))
+	for _, arch := range arches {
+		cases = append(cases, art.Goos+"_"+arch)
+	}
+	return strings.Join(cases, "|")
+}
+
+// checksumsFile returns the sha256 checksums file of the release, if any.
+func checksumsFile(ctx *context.Context) (*artifact.Artifact, error) {
+	cs := ctx.Config.Checksum
+	if cs.Disable || cs.Split || (cs.Algorithm != "sha256" && !slices.Contains(cs.Algorithms, "sha256")) {
+		return nil, nil
+	}
+	name, err := tmpl.New(ctx).WithExtraFields(tmpl.Fields{
+		"Algorithm": "sha256",
+	}).Apply(cs.NameTemplate)
+	if err != nil {
+		return nil, err
+	}
+	for _, art := range ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List() {
+		if art.Name == name {
+			return art, nil
+		}
+	}
+	return nil, nil
+}
+
+// sourceURL infers the URL of the git repository of the release from the URL
+// an artifact will be downloaded from.
+func sourceURL(url string) (string, error) {
+	for _, sep := range []string{"/-/releases/", "/releases/download/"} {
+		if before, _, ok := strings.Cut(url, sep); ok {
+			return before, nil
+		}
+	}
+	return "", fmt.Errorf("could not infer the repository url from %q, set 'source_url'", url)
+}
+
+// versionless replaces the current version with the shell variable holding
+// the version being installed.
+func versionless(ctx *context.Context, s string) string {
+	return strings.ReplaceAll(s, ctx.Version, versionVar)
+}
+
+// Publish the plugins.
+func (Pipe) Publish(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, plugin := range ctx.Artifacts.Filter(artifact.ByType(artifact.AsdfPlugin)).List() {
+		err := doPublish(ctx, plugin)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doPublish(ctx *context.Context, plugin *artifact.Artifact) error {
+	asdf := artifact.MustExtra[config.Asdf](*plugin, asdfConfigExtra)
+	if strings.TrimSpace(asdf.SkipUpload) == "true" {
+		return errSkipUpload
+	}
+	if strings.TrimSpace(asdf.SkipUpload) == "auto" && ctx.Semver.Prerelease != "" {
+		return errSkipUploadAuto
+	}
+
+	ref, err := client.TemplateRef(tmpl.New(ctx).Apply, asdf.Repository)
+	if err != nil {
+		return err
+	}
+	if ref.Git.URL == "" {
+		return errNoGitURL
+	}
+	repo := client.RepoFromRef(ref)
+
+	msg, err := tmpl.New(ctx).Apply(asdf.CommitMessageTemplate)
+	if err != nil {
+		return err
+	}
+
+	author, err := commitauthor.Get(ctx, asdf.CommitAuthor)
+	if err != nil {
+		return err
+	}
+
+	files := make([]client.RepoFile, 0, len(scripts))
+	for _, name := range slices.Sorted(maps.Keys(scripts)) {
+		content, err := os.ReadFile(filepath.Join(plugin.Path, filepath.FromSlash(name)))
+		if err != nil {
+			return err
+		}
+		files = append(files, client.RepoFile{
+			Content: content,
+			Path:    name,
+			Mode:    0o755,
+		})
+	}
+
+	return client.NewGitUploadClient(repo.Branch).
+		CreateFiles(ctx, author, repo, msg, files)
+}
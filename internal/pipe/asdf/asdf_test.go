@@ -0,0 +1,302 @@
+package asdf
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestContinueOnError(t *testing.T) {
+	require.True(t, Pipe{}.ContinueOnError())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Asdfs: []config.Asdf{{}},
+		}, testctx.Skip(skips.Asdf))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Asdfs: []config.Asdf{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Asdfs:       []config.Asdf{{}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	asdf := ctx.Config.Asdfs[0]
+	require.Equal(t, "foo", asdf.Name)
+	require.Equal(t, "v1", asdf.Goamd64)
+	require.Equal(t, "6", asdf.Goarm)
+	require.NotEmpty(t, asdf.CommitMessageTemplate)
+	require.NotEmpty(t, asdf.CommitAuthor.Name)
+}
+
+func TestRunPipe(t *testing.T) {
+	ctx := makeContext(t, config.Asdf{
+		SourceURL: "https://github.com/goreleaser/{{ .ProjectName }}",
+	})
+	require.NoError(t, runAll(ctx, client.NewMock()))
+
+	plugins := ctx.Artifacts.Filter(artifact.ByType(artifact.AsdfPlugin)).List()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "foo", plugins[0].Name)
+	for _, name := range []string{"list-all", "download", "install"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(plugins[0].Path, "bin", name)
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			testlib.SkipIfWindows(t, "no executable bit on windows")
+			require.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+			golden.RequireEqualExt(t, golden.RequireReadFile(t, path), ".sh")
+		})
+	}
+}
+
+func TestRunPipeNoChecksums(t *testing.T) {
+	ctx := makeContext(t, config.Asdf{
+		SourceURL: "https://github.com/goreleaser/foo",
+	})
+	ctx.Config.Checksum.Split = true
+	require.NoError(t, runAll(ctx, client.NewMock()))
+	download := golden.RequireReadFile(t, filepath.Join(ctx.Config.Dist, "asdf", "foo", "bin", "download"))
+	require.NotContains(t, string(download), "checksums")
+}
+
+func TestRunPipeSourceURL(t *testing.T) {
+	t.Run("inferred", func(t *testing.T) {
+		ctx := makeContext(t, config.Asdf{
+			URLTemplate: "https://github.com/goreleaser/foo/releases/download/{{ .Tag }}/{{ .ArtifactName }}",
+		})
+		require.NoError(t, runAll(ctx, client.NewMock()))
+		listAll := golden.RequireReadFile(t, filepath.Join(ctx.Config.Dist, "asdf", "foo", "bin", "list-all"))
+		require.Contains(t, string(listAll), `git ls-remote --tags --refs "https://github.com/goreleaser/foo"`)
+	})
+	t.Run("cannot infer", func(t *testing.T) {
+		ctx := makeContext(t, config.Asdf{})
+		require.ErrorContains(t, runAll(ctx, client.NewMock()), "set 'source_url'")
+	})
+	t.Run("bad template", func(t *testing.T) {
+		ctx := makeContext(t, config.Asdf{
+			SourceURL: "{{ .Nope }}",
+		})
+		testlib.RequireTemplateError(t, runAll(ctx, client.NewMock()))
+	})
+}
+
+func TestRunPipeErrors(t *testing.T) {
+	t.Run("no archives", func(t *testing.T) {
+		ctx := makeContext(t, config.Asdf{
+			IDs: []string{"nope"},
+		})
+		require.ErrorIs(t, runAll(ctx, client.NewMock()), errNoArchivesFound)
+	})
+	t.Run("multiple archives", func(t *testing.T) {
+		ctx := makeContext(t, config.Asdf{
+			SourceURL: "https://github.com/goreleaser/foo",
+		})
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo_1.2.3_linux_amd64.tar.xz",
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraFormat:   "tar.xz",
+				artifact.ExtraBinaries: []string{"foo"},
+			},
+		})
+		require.ErrorIs(t, runAll(ctx, client.NewMock()), errMultipleArchives)
+	})
+}
+
+func TestPublish(t *testing.T) {
+	url := testlib.GitMakeBareRepository(t)
+	ctx := makeContext(t, config.Asdf{
+		SourceURL: "https://github.com/goreleaser/foo",
+		Repository: config.RepoRef{
+			Name:   "asdf-foo",
+			Branch: "main",
+			Git: config.GitRepoRef{
+				URL:        url,
+				PrivateKey: testlib.MakeNewSSHKey(t, ""),
+			},
+		},
+	})
+	require.NoError(t, runAll(ctx, client.NewMock()))
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	for _, name := range []string{"bin/list-all", "bin/download", "bin/install"} {
+		content := testlib.CatFileFromBareRepositoryOnBranch(t, url, "main", name)
+		expected := golden.RequireReadFile(t, filepath.Join(ctx.Config.Dist, "asdf", "foo", name))
+		require.Equal(t, string(expected), string(content))
+
+		out, err := exec.CommandContext(t.Context(), "git", "-C", url, "ls-tree", "main", name).CombinedOutput()
+		require.NoError(t, err)
+		require.Contains(t, string(out), "100755")
+	}
+}
+
+func TestPublishSkip(t *testing.T) {
+	for name, tt := range map[string]struct {
+		skipUpload string
+		prerelease string
+		err        string
+	}{
+		"skip upload": {
+			skipUpload: "true",
+			err:        errSkipUpload.Error(),
+		},
+		"skip upload auto": {
+			skipUpload: "auto",
+			prerelease: "rc1",
+			err:        errSkipUploadAuto.Error(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := makeContext(t, config.Asdf{
+				SourceURL:  "https://github.com/goreleaser/foo",
+				SkipUpload: tt.skipUpload,
+			})
+			ctx.Semver.Prerelease = tt.prerelease
+			require.NoError(t, runAll(ctx, client.NewMock()))
+			err := Pipe{}.Publish(ctx)
+			testlib.AssertSkipped(t, err)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestPublishNoGitURL(t *testing.T) {
+	ctx := makeContext(t, config.Asdf{
+		SourceURL: "https://github.com/goreleaser/foo",
+		Repository: config.RepoRef{
+			Owner: "goreleaser",
+			Name:  "asdf-foo",
+		},
+	})
+	require.NoError(t, runAll(ctx, client.NewMock()))
+	require.ErrorIs(t, Pipe{}.Publish(ctx), errNoGitURL)
+}
+
+func makeContext(tb testing.TB, asdf config.Asdf) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		Dist:        tb.TempDir(),
+		ProjectName: "foo",
+		Asdfs:       []config.Asdf{asdf},
+		Checksum: config.Checksum{
+			NameTemplate: "{{ .ProjectName }}_{{ .Version }}_checksums.txt",
+			Algorithm:    "sha256",
+		},
+	}, testctx.WithVersion("1.2.3"), testctx.WithCurrentTag("v1.2.3"))
+	require.NoError(tb, Pipe{}.Default(ctx))
+
+	for _, art := range []*artifact.Artifact{
+		{
+			Name:    "foo_1.2.3_linux_amd64.tar.gz",
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraID:        "foo",
+				artifact.ExtraFormat:    "tar.gz",
+				artifact.ExtraBinaries:  []string{"foo"},
+				artifact.ExtraWrappedIn: "foo_1.2.3_linux_amd64",
+			},
+		},
+		{
+			Name:    "foo_1.2.3_linux_amd64v3.tar.gz",
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v3",
+			Type:    artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraFormat:   "tar.gz",
+				artifact.ExtraBinaries: []string{"foo"},
+			},
+		},
+		{
+			Name:   "foo_1.2.3_linux_arm64",
+			Goos:   "linux",
+			Goarch: "arm64",
+			Type:   artifact.UploadableBinary,
+			Extra: map[string]any{
+				artifact.ExtraID:     "foo",
+				artifact.ExtraFormat: "binary",
+				artifact.ExtraBinary: "foo",
+			},
+		},
+		{
+			Name:   "foo_1.2.3_linux_armv7.tar.gz",
+			Goos:   "linux",
+			Goarch: "arm",
+			Goarm:  "7",
+			Type:   artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraFormat:   "tar.gz",
+				artifact.ExtraBinaries: []string{"foo"},
+			},
+		},
+		{
+			Name:   "foo_1.2.3_darwin_all.zip",
+			Goos:   "darwin",
+			Goarch: "all",
+			Type:   artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraFormat:   "zip",
+				artifact.ExtraBinaries: []string{"foo"},
+				artifact.ExtraReplaces: true,
+			},
+		},
+		{
+			Name:    "foo_1.2.3_windows_amd64.zip",
+			Goos:    "windows",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraFormat:   "zip",
+				artifact.ExtraBinaries: []string{"foo.exe"},
+			},
+		},
+		{
+			Name: "foo_1.2.3_checksums.txt",
+			Type: artifact.Checksum,
+		},
+	} {
+		ctx.Artifacts.Add(art)
+	}
+	return ctx
+}
@@ -0,0 +1,100 @@
+package asdf
+
+type templateData struct {
+	Name         string
+	SourceURL    string
+	TagPrefix    string
+	ChecksumsURL string
+	Platforms    []platform
+}
+
+type platform struct {
+	Case     string
+	URL      string
+	File     string
+	Format   string
+	Binaries []string
+}
+
+const listAllTemplate = `#!/usr/bin/env bash
+# This file was generated by GoReleaser. DO NOT EDIT.
+set -euo pipefail
+
+prefix="{{ .TagPrefix }}"
+
+sort_versions() {
+	sed 'h; s/[+-]/./g; s/.p\([[:digit:]]\)/.z\1/; s/$/.z/; G; s/\n/ /' |
+		LC_ALL=C sort -t. -k 1,1 -k 2,2n -k 3,3n -k 4,4n -k 5,5n | awk '{print $2}'
+}
+
+git ls-remote --tags --refs "{{ .SourceURL }}" |
+	awk -F 'refs/tags/' '{ print $2 }' |
+	while read -r tag; do
+		if [[ "${tag}" == "${prefix}"* ]]; then
+			echo "${tag#"${prefix}"}"
+		fi
+	done |
+	sort_versions |
+	xargs echo
+`
+
+const downloadTemplate = `#!/usr/bin/env bash
+# This file was generated by GoReleaser. DO NOT EDIT.
+set -euo pipefail
+
+version="${ASDF_INSTALL_VERSION}"
+platform="$(uname -s | tr '[:upper:]' '[:lower:]')_$(uname -m)"
+
+case "${platform}" in
+{{- range .Platforms }}
+{{ .Case }})
+	url="{{ .URL }}"
+	file="{{ .File }}"
+	format="{{ .Format }}"
+	binaries=({{ range $i, $b := .Binaries }}{{ if $i }} {{ end }}"{{ $b }}"{{ end }})
+	;;
+{{- end }}
+*)
+	echo "{{ .Name }}: unsupported platform: ${platform}" >&2
+	exit 1
+	;;
+esac
+
+cd "${ASDF_DOWNLOAD_PATH}"
+curl -fsSL -o "${file}" "${url}"
+{{- if .ChecksumsURL }}
+
+curl -fsSL -o checksums.txt "{{ .ChecksumsURL }}"
+if command -v sha256sum >/dev/null 2>&1; then
+	awk -v file="${file}" '$2 == file' checksums.txt | sha256sum -c -
+else
+	awk -v file="${file}" '$2 == file' checksums.txt | shasum -a 256 -c -
+fi
+rm checksums.txt
+{{- end }}
+
+mkdir -p bin src
+case "${format}" in
+tar) tar -xf "${file}" -C src ;;
+zip) unzip -q "${file}" -d src ;;
+binary) mv "${file}" "src/${binaries[0]}" ;;
+esac
+for binary in "${binaries[@]}"; do
+	mv "src/${binary}" "bin/$(basename "${binary}")"
+done
+chmod +x bin/*
+rm -rf src "${file}"
+`
+
+const installTemplate = `#!/usr/bin/env bash
+# This file was generated by GoReleaser. DO NOT EDIT.
+set -euo pipefail
+
+if [ "${ASDF_INSTALL_TYPE}" != "version" ]; then
+	echo "{{ .Name }}: only version installs are supported" >&2
+	exit 1
+fi
+
+mkdir -p "${ASDF_INSTALL_PATH}"
+cp -R "${ASDF_DOWNLOAD_PATH}/." "${ASDF_INSTALL_PATH}/"
+`
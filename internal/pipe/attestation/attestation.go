@@ -0,0 +1,149 @@
+// Package attestation creates GitHub artifact attestations for the release
+// binaries and archives.
+package attestation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/slsa"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// buildType is the build type GitHub uses for provenance generated in
+// GitHub Actions workflows.
+const buildType = "https://actions.github.io/buildtypes/workflow/v1"
+
+// cmd represents a command executor.
+var cmd execx.Cmder = execx.Std{}
+
+// Pipe for GitHub artifact attestations.
+type Pipe struct{}
+
+func (Pipe) String() string        { return "github attestations" }
+func (Pipe) ContinueOnError() bool { return true }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.Sign, skips.Attestations) ||
+		ctx.Config.GitHubAttestations.Enabled == ""
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(*context.Context) []string { return []string{"cosign"} }
+
+// Publish attests the binaries and archives, and stores the attestations in
+// the repository.
+func (Pipe) Publish(ctx *context.Context) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+	return doPublish(ctx, c)
+}
+
+func doPublish(ctx *context.Context, c client.Client) error {
+	cfg := ctx.Config.GitHubAttestations
+	tpl := tmpl.New(ctx)
+	enabled, err := tpl.Bool(cfg.Enabled)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return pipe.Skip("configuration is disabled")
+	}
+	if ctx.TokenType != context.TokenTypeGitHub {
+		return pipe.Skip("attestations are only available when releasing to GitHub")
+	}
+	if ctx.Env["ACTIONS_ID_TOKEN_REQUEST_URL"] == "" {
+		return pipe.Skip("not running in GitHub Actions with the 'id-token: write' permission")
+	}
+	attester, ok := c.(client.Attester)
+	if !ok {
+		return pipe.Skip("client does not support attestations")
+	}
+	flags, err := tpl.Slice(cfg.Flags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+
+	artifacts := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByTypes(artifact.UploadableArchive, artifact.UploadableBinary),
+		artifact.ByIDs(cfg.IDs...),
+	)).List()
+	if len(artifacts) == 0 {
+		return pipe.Skip("no binaries or archives to attest")
+	}
+
+	tmp, err := os.MkdirTemp("", "goreleaser-attestation-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	bts, err := json.MarshalIndent(slsa.New(ctx, buildType, workflow(ctx)), "", "  ")
+	if err != nil {
+		return err
+	}
+	predicate := filepath.Join(tmp, "predicate.json")
+	if err := os.WriteFile(predicate, bts, 0o644); err != nil {
+		return err
+	}
+
+	repo := client.Repo{
+		Owner: ctx.Config.Release.GitHub.Owner,
+		Name:  ctx.Config.Release.GitHub.Name,
+	}
+	for i, art := range artifacts {
+		log := log.WithField("artifact", art.Name)
+		log.Info("attesting")
+		bundle := filepath.Join(tmp, strconv.Itoa(i)+".sigstore.json")
+		args := []string{
+			"attest-blob", "--yes",
+			"--new-bundle-format",
+			"--bundle", bundle,
+			"--type", "slsaprovenance1",
+			"--predicate", predicate,
+		}
+		args = append(args, flags...)
+		args = append(args, art.Path)
+		if out, err := cmd.Exec(ctx, "cosign", args...); err != nil {
+			return fmt.Errorf("attestations: failed to attest %s: %w: %s", art.Name, err, string(out))
+		}
+		bts, err := os.ReadFile(bundle)
+		if err != nil {
+			return fmt.Errorf("attestations: failed to read bundle of %s: %w", art.Name, err)
+		}
+		if err := attester.CreateAttestation(ctx, repo, bts); err != nil {
+			return fmt.Errorf("attestations: failed to store attestation of %s: %w", art.Name, err)
+		}
+	}
+	return nil
+}
+
+// workflow returns the external parameters GitHub uses to describe the
+// workflow run, e.g.:
+//
+//	{"workflow": {"ref": "refs/tags/v1.0.0", "repository": "https://github.com/foo/bar", "path": ".github/workflows/release.yml"}}
+func workflow(ctx *context.Context) map[string]any {
+	env := ctx.Env
+	repo := env["GITHUB_REPOSITORY"]
+	ref := strings.TrimPrefix(env["GITHUB_WORKFLOW_REF"], repo+"/")
+	path, ref, _ := strings.Cut(ref, "@")
+	return map[string]any{
+		"workflow": map[string]string{
+			"ref":        ref,
+			"repository": env["GITHUB_SERVER_URL"] + "/" + repo,
+			"path":       path,
+		},
+	}
+}
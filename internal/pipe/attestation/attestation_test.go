@@ -0,0 +1,235 @@
+package attestation
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/slsa"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestContinueOnError(t *testing.T) {
+	require.True(t, Pipe{}.ContinueOnError())
+}
+
+func TestDependencies(t *testing.T) {
+	require.Equal(t, []string{"cosign"}, Pipe{}.Dependencies(nil))
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	for _, key := range []skips.Key{skips.Sign, skips.Attestations} {
+		t.Run("skip "+string(key), func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				GitHubAttestations: config.GitHubAttestations{Enabled: "true"},
+			}, testctx.Skip(key))
+			require.True(t, Pipe{}.Skip(ctx))
+		})
+	}
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			GitHubAttestations: config.GitHubAttestations{Enabled: "true"},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestPublish(t *testing.T) {
+	var predicate slsa.Predicate
+	calls := fakeCosign(t, func(args []string) ([]byte, error) {
+		bts, err := os.ReadFile(args[slices.Index(args, "--predicate")+1])
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bts, &predicate))
+		return nil, writeBundle(args)
+	})
+	ctx := makeContext(t, config.GitHubAttestations{
+		Enabled: "{{ .Env.ATTEST }}",
+		Flags:   []string{"--timeout={{ .Env.TIMEOUT }}"},
+	})
+	mock := client.NewMock()
+	require.NoError(t, doPublish(ctx, mock))
+
+	require.Len(t, *calls, 2)
+	for i, name := range []string{"foo.tar.gz", "foo"} {
+		call := (*calls)[i]
+		require.Equal(t, []string{
+			"cosign", "attest-blob", "--yes",
+			"--new-bundle-format",
+			"--bundle", call[5],
+			"--type", "slsaprovenance1",
+			"--predicate", call[9],
+			"--timeout=5m",
+			filepath.Join(ctx.Config.Dist, name),
+		}, call)
+	}
+	require.Equal(t, [][]byte{
+		[]byte(`{"subject":"foo.tar.gz"}`),
+		[]byte(`{"subject":"foo"}`),
+	}, mock.Attestations)
+
+	require.Equal(t, buildType, predicate.BuildDefinition.BuildType)
+	require.Equal(t, map[string]any{
+		"workflow": map[string]any{
+			"ref":        "refs/tags/v1.2.3",
+			"repository": "https://github.com/foo/bar",
+			"path":       ".github/workflows/release.yml",
+		},
+	}, predicate.BuildDefinition.ExternalParameters)
+	require.Equal(t, "https://github.com/foo/bar/.github/workflows/release.yml@refs/tags/v1.2.3", predicate.RunDetails.Builder.ID)
+}
+
+func TestPublishIDs(t *testing.T) {
+	calls := fakeCosign(t, nil)
+	ctx := makeContext(t, config.GitHubAttestations{
+		Enabled: "true",
+		IDs:     []string{"bar"},
+	})
+	mock := client.NewMock()
+	require.NoError(t, doPublish(ctx, mock))
+	require.Len(t, *calls, 1)
+	require.Len(t, mock.Attestations, 1)
+}
+
+func TestPublishSkips(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		calls := fakeCosign(t, nil)
+		ctx := makeContext(t, config.GitHubAttestations{Enabled: "false"})
+		testlib.AssertSkipped(t, doPublish(ctx, client.NewMock()))
+		require.Empty(t, *calls)
+	})
+
+	t.Run("not github", func(t *testing.T) {
+		calls := fakeCosign(t, nil)
+		ctx := makeContext(t, config.GitHubAttestations{Enabled: "true"})
+		ctx.TokenType = context.TokenTypeGitLab
+		testlib.AssertSkipped(t, doPublish(ctx, client.NewMock()))
+		require.Empty(t, *calls)
+	})
+
+	t.Run("no oidc", func(t *testing.T) {
+		calls := fakeCosign(t, nil)
+		ctx := makeContext(t, config.GitHubAttestations{Enabled: "true"})
+		delete(ctx.Env, "ACTIONS_ID_TOKEN_REQUEST_URL")
+		testlib.AssertSkipped(t, doPublish(ctx, client.NewMock()))
+		require.Empty(t, *calls)
+	})
+
+	t.Run("no artifacts", func(t *testing.T) {
+		calls := fakeCosign(t, nil)
+		ctx := makeContext(t, config.GitHubAttestations{
+			Enabled: "true",
+			IDs:     []string{"nope"},
+		})
+		testlib.AssertSkipped(t, doPublish(ctx, client.NewMock()))
+		require.Empty(t, *calls)
+	})
+}
+
+func TestPublishErrors(t *testing.T) {
+	t.Run("bad enabled template", func(t *testing.T) {
+		fakeCosign(t, nil)
+		ctx := makeContext(t, config.GitHubAttestations{Enabled: "{{ .Nope }}"})
+		testlib.RequireTemplateError(t, doPublish(ctx, client.NewMock()))
+	})
+
+	t.Run("bad flags template", func(t *testing.T) {
+		fakeCosign(t, nil)
+		ctx := makeContext(t, config.GitHubAttestations{
+			Enabled: "true",
+			Flags:   []string{"{{ .Nope }}"},
+		})
+		testlib.RequireTemplateError(t, doPublish(ctx, client.NewMock()))
+	})
+
+	t.Run("cosign fails", func(t *testing.T) {
+		fakeCosign(t, func([]string) ([]byte, error) {
+			return []byte("no token"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.GitHubAttestations{Enabled: "true"})
+		err := doPublish(ctx, client.NewMock())
+		require.ErrorContains(t, err, "failed to attest foo.tar.gz")
+		require.ErrorContains(t, err, "no token")
+	})
+
+	t.Run("no bundle", func(t *testing.T) {
+		fakeCosign(t, func([]string) ([]byte, error) {
+			return nil, nil
+		})
+		ctx := makeContext(t, config.GitHubAttestations{Enabled: "true"})
+		require.ErrorContains(t, doPublish(ctx, client.NewMock()), "failed to read bundle of foo.tar.gz")
+	})
+}
+
+func makeContext(tb testing.TB, cfg config.GitHubAttestations) *context.Context {
+	tb.Helper()
+	dist := tb.TempDir()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		Dist: dist,
+		Release: config.Release{
+			GitHub: config.Repo{Owner: "foo", Name: "bar"},
+		},
+		GitHubAttestations: cfg,
+	},
+		testctx.GitHubTokenType,
+		testctx.WithEnv(map[string]string{
+			"ATTEST":                       "true",
+			"TIMEOUT":                      "5m",
+			"ACTIONS_ID_TOKEN_REQUEST_URL": "https://token.actions.githubusercontent.com",
+			"GITHUB_SERVER_URL":            "https://github.com",
+			"GITHUB_REPOSITORY":            "foo/bar",
+			"GITHUB_WORKFLOW_REF":          "foo/bar/.github/workflows/release.yml@refs/tags/v1.2.3",
+		}),
+	)
+	for _, a := range []*artifact.Artifact{
+		{Type: artifact.UploadableArchive, Name: "foo.tar.gz", Extra: map[string]any{artifact.ExtraID: "foo"}},
+		{Type: artifact.UploadableBinary, Name: "foo", Extra: map[string]any{artifact.ExtraID: "bar"}},
+		{Type: artifact.Checksum, Name: "checksums.txt"},
+		{Type: artifact.LinuxPackage, Name: "foo.deb"},
+	} {
+		a.Path = filepath.Join(dist, a.Name)
+		require.NoError(tb, os.WriteFile(a.Path, []byte(a.Name), 0o644))
+		ctx.Artifacts.Add(a)
+	}
+	return ctx
+}
+
+// writeBundle writes a fake bundle with the name of the subject.
+func writeBundle(args []string) error {
+	bundle := args[slices.Index(args, "--bundle")+1]
+	subject := filepath.Base(args[len(args)-1])
+	return os.WriteFile(bundle, []byte(`{"subject":"`+subject+`"}`), 0o644)
+}
+
+// fakeCosign replaces the command executor, recording the calls made to it,
+// with the command name as the first item.
+//
+// By default, it writes a fake bundle.
+func fakeCosign(tb testing.TB, fn func(args []string) ([]byte, error)) *[][]string {
+	tb.Helper()
+	if fn == nil {
+		fn = func(call []string) ([]byte, error) {
+			return nil, writeBundle(call)
+		}
+	}
+	fake := &testlib.FakeCosign{Fn: fn}
+	testlib.Set[execx.Cmder](tb, &cmd, fake)
+	return &fake.Calls
+}
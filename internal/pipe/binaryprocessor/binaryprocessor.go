@@ -0,0 +1,140 @@
+// Package binaryprocessor runs chains of commands, like strip or patchelf, on
+// the built binaries.
+package binaryprocessor
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/go-shellwords"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/debugsymbols"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const defaultDebugNameTemplate = debugsymbols.DefaultNameTemplate + ".debug"
+
+// Pipe for binary processors.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "binary processors" }
+func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.BinaryProcessors) == 0 }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("binary_processors")
+	for i := range ctx.Config.BinaryProcessors {
+		proc := &ctx.Config.BinaryProcessors[i]
+		if proc.ID == "" {
+			proc.ID = "default"
+		}
+		if proc.DebugNameTemplate == "" {
+			proc.DebugNameTemplate = defaultDebugNameTemplate
+		}
+		if len(proc.Steps) == 0 {
+			return fmt.Errorf("binary_processors: %s: at least one step is required", proc.ID)
+		}
+		ids.Inc(proc.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	g := semerrgroup.New(ctx.Parallelism)
+	skips := pipe.SkipMemento{}
+	for _, proc := range ctx.Config.BinaryProcessors {
+		disable, err := tmpl.New(ctx).Bool(proc.Disable)
+		if err != nil {
+			return err
+		}
+		if disable {
+			skips.Remember(pipe.Skipf("binary processor %s is disabled", proc.ID))
+			continue
+		}
+		for _, bin := range findBinaries(ctx, proc) {
+			g.Go(func() error {
+				return process(ctx, proc, bin)
+			})
+		}
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return skips.Evaluate()
+}
+
+// process runs all the steps of the given processor, in order, on the given
+// binary.
+func process(ctx *context.Context, proc config.BinaryProcessor, bin *artifact.Artifact) error {
+	for i, step := range proc.Steps {
+		if err := runStep(ctx, proc, step, bin); err != nil {
+			return fmt.Errorf("binary processor %s: step %d failed on %s: %w", proc.ID, i+1, bin.Path, err)
+		}
+	}
+	return nil
+}
+
+func runStep(ctx *context.Context, proc config.BinaryProcessor, step config.BinaryProcessorStep, bin *artifact.Artifact) error {
+	env := ctx.Env.Strings()
+	for _, e := range step.Env {
+		e, err := tmpl.New(ctx).WithArtifact(bin).Apply(e)
+		if err != nil {
+			return err
+		}
+		env = append(env, e)
+	}
+
+	tpl := tmpl.New(ctx).WithArtifact(bin).WithEnvS(env)
+	sh, err := tpl.Apply(step.Cmd)
+	if err != nil {
+		return err
+	}
+	debug, err := tpl.Apply(step.Debug)
+	if err != nil {
+		return err
+	}
+
+	log.WithField("cmd", sh).
+		WithField("binary", bin.Path).
+		Info("processing")
+	cmd, err := shellwords.Parse(sh)
+	if err != nil {
+		return err
+	}
+	if err := shell.Run(ctx, "", cmd, env, step.Output); err != nil {
+		return err
+	}
+
+	if debug == "" {
+		return nil
+	}
+	if _, err := os.Stat(debug); err != nil {
+		return fmt.Errorf("debug symbols were not created: %w", err)
+	}
+	name, err := tpl.Apply(proc.DebugNameTemplate)
+	if err != nil {
+		return err
+	}
+	ctx.Artifacts.Add(debugsymbols.NewArtifact(bin, name, debug))
+	return nil
+}
+
+func findBinaries(ctx *context.Context, proc config.BinaryProcessor) []*artifact.Artifact {
+	return ctx.Artifacts.Filter(artifact.And(
+		artifact.ByTypes(
+			artifact.Binary,
+			artifact.UniversalBinary,
+		),
+		artifact.ByGooses(proc.Goos...),
+		artifact.ByGoarches(proc.Goarch...),
+		artifact.ByIDs(proc.IDs...),
+	)).List()
+}
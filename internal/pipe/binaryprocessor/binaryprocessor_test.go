@@ -0,0 +1,204 @@
+package binaryprocessor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringer(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("do not skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BinaryProcessors: []config.BinaryProcessor{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BinaryProcessors: []config.BinaryProcessor{
+				{Steps: []config.BinaryProcessorStep{{Cmd: "strip {{ .ArtifactPath }}"}}},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		proc := ctx.Config.BinaryProcessors[0]
+		require.Equal(t, "default", proc.ID)
+		require.Equal(t, defaultDebugNameTemplate, proc.DebugNameTemplate)
+	})
+
+	t.Run("no steps", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BinaryProcessors: []config.BinaryProcessor{{ID: "foo"}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "binary_processors: foo: at least one step is required")
+	})
+
+	t.Run("duplicated ids", func(t *testing.T) {
+		steps := []config.BinaryProcessorStep{{Cmd: "strip {{ .ArtifactPath }}"}}
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BinaryProcessors: []config.BinaryProcessor{
+				{Steps: steps},
+				{Steps: steps},
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "found 2 binary_processors with the ID 'default', please fix your config")
+	})
+}
+
+func TestRun(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses shell commands")
+	dist := t.TempDir()
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist:        dist,
+		ProjectName: "foo",
+		BinaryProcessors: []config.BinaryProcessor{
+			{
+				Goos: []string{"linux"},
+				Steps: []config.BinaryProcessorStep{
+					{
+						Cmd:   testlib.ShC(`cp {{ .ArtifactPath }} {{ .ArtifactPath }}.debug`),
+						Debug: "{{ .ArtifactPath }}.debug",
+					},
+					{
+						Cmd: testlib.ShC(`echo $STRIPPED > {{ .ArtifactPath }}`),
+						Env: []string{"STRIPPED=stripped-{{ .Arch }}"},
+					},
+				},
+			},
+		},
+	}, testctx.WithVersion("1.2.3"))
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	for _, plat := range [][]string{{"linux", "amd64"}, {"linux", "arm64"}, {"darwin", "arm64"}} {
+		path := filepath.Join(dist, "foo_"+plat[0]+"_"+plat[1], "foo")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("binary"), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo",
+			Path:    path,
+			Goos:    plat[0],
+			Goarch:  plat[1],
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+			Extra: map[string]any{
+				artifact.ExtraBinary: "foo",
+				artifact.ExtraID:     "build1",
+			},
+		})
+	}
+
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	for _, bin := range ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List() {
+		bts, err := os.ReadFile(bin.Path)
+		require.NoError(t, err)
+		if bin.Goos == "darwin" {
+			require.Equal(t, "binary", string(bts))
+			continue
+		}
+		require.Equal(t, "stripped-"+bin.Goarch+"\n", string(bts))
+	}
+
+	debugs := ctx.Artifacts.Filter(artifact.ByType(artifact.DebugSymbols)).List()
+	require.Len(t, debugs, 2)
+	for _, debug := range debugs {
+		require.Equal(t, "linux", debug.Goos)
+		require.Equal(t, "foo_1.2.3_linux_"+debug.Goarch+".debug", debug.Name)
+		require.Equal(t, "build1", debug.ID())
+		bts, err := os.ReadFile(debug.Path)
+		require.NoError(t, err)
+		require.Equal(t, "binary", string(bts))
+	}
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		BinaryProcessors: []config.BinaryProcessor{
+			{
+				ID:      "foo",
+				Disable: "{{ .IsSnapshot }}",
+				Steps:   []config.BinaryProcessorStep{{Cmd: "exit 1"}},
+			},
+		},
+	}, testctx.Snapshot)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo",
+		Path: "foo",
+		Type: artifact.Binary,
+	})
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		step config.BinaryProcessorStep
+		err  string
+	}{
+		"failing cmd": {
+			step: config.BinaryProcessorStep{Cmd: "exit 1"},
+			err:  "binary processor default: step 1 failed",
+		},
+		"missing debug": {
+			step: config.BinaryProcessorStep{Cmd: testlib.Echo("hi"), Debug: "nope.debug"},
+			err:  "debug symbols were not created",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				BinaryProcessors: []config.BinaryProcessor{
+					{ID: "default", Steps: []config.BinaryProcessorStep{tc.step}},
+				},
+			})
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: "foo",
+				Path: "foo",
+				Type: artifact.Binary,
+			})
+			require.ErrorContains(t, Pipe{}.Run(ctx), tc.err)
+		})
+	}
+
+	for name, step := range map[string]config.BinaryProcessorStep{
+		"cmd":   {Cmd: "{{ .Nope }}"},
+		"env":   {Cmd: "true", Env: []string{"FOO={{ .Nope }}"}},
+		"debug": {Cmd: "true", Debug: "{{ .Nope }}"},
+	} {
+		t.Run("bad "+name+" template", func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				BinaryProcessors: []config.BinaryProcessor{
+					{Steps: []config.BinaryProcessorStep{step}},
+				},
+			})
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: "foo",
+				Path: "foo",
+				Type: artifact.Binary,
+			})
+			testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+		})
+	}
+
+	t.Run("bad disable template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BinaryProcessors: []config.BinaryProcessor{
+				{Disable: "{{ .Nope }}"},
+			},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
@@ -87,6 +87,15 @@ func urlFor(ctx *context.Context, conf config.Blob) (string, error) {
 // upload to destination (eg: gs://gorelease-bucket) using the given uploader
 // implementation.
 func doUpload(ctx *context.Context, conf config.Blob) error {
+	return UploadArtifacts(ctx, conf, artifactList(ctx, conf))
+}
+
+// UploadArtifacts uploads the given artifacts, as well as the extra files of
+// the given configuration, to its bucket.
+//
+// Artifact names may contain slashes, in which case they are uploaded to the
+// matching subdirectory.
+func UploadArtifacts(ctx *context.Context, conf config.Blob, artifacts []*artifact.Artifact) error {
 	dir, err := tmpl.New(ctx).Apply(conf.Directory)
 	if err != nil {
 		return err
@@ -136,7 +145,7 @@ func doUpload(ctx *context.Context, conf config.Blob) error {
 	defer up.Close()
 
 	g := semerrgroup.New(ctx.Parallelism)
-	for _, artifact := range artifactList(ctx, conf) {
+	for _, artifact := range artifacts {
 		g.Go(func() error {
 			// TODO: replace this with ?prefix=folder on the bucket url
 			dataFile := artifact.Path
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 	"time"
 
@@ -14,16 +15,23 @@ import (
 	butil "github.com/bluesky-social/indigo/util"
 	"github.com/bluesky-social/indigo/xrpc"
 	"github.com/caarlos0/env/v11"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
 const (
+	trailingPunctuation    = ".,;:!?)]}'\""
 	defaultPDSURL          = "https://bsky.social"
 	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
 )
 
+var (
+	urlRe                 = regexp.MustCompile(`https?://[^\s]+`)
+	errMissingCredentials = errors.New("bluesky: either BLUESKY_APP_PASSWORD or BLUESKY_SESSION_TOKEN must be set")
+)
+
 // Pipe announcer implementation.
 type Pipe struct {
 	pdsURL string
@@ -41,7 +49,8 @@ func (Pipe) Skip(ctx *context.Context) (bool, error) {
 }
 
 type Config struct {
-	Password string `env:"BLUESKY_APP_PASSWORD,notEmpty"`
+	Password     string `env:"BLUESKY_APP_PASSWORD"`
+	SessionToken string `env:"BLUESKY_SESSION_TOKEN"`
 }
 
 func (Pipe) Default(ctx *context.Context) error {
@@ -52,8 +61,13 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
+// Preview returns the post that would be published.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	return tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Bluesky.MessageTemplate)
+}
+
 func (p Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Bluesky.MessageTemplate)
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Bluesky.MessageTemplate)
 	if err != nil {
 		return err
 	}
@@ -62,59 +76,70 @@ func (p Pipe) Announce(ctx *context.Context) error {
 	if err = env.Parse(&cfg); err != nil {
 		return err
 	}
+	if cfg.Password == "" && cfg.SessionToken == "" {
+		return errMissingCredentials
+	}
 
 	post := bsky.FeedPost{
 		CreatedAt: time.Now().Format(time.RFC3339),
 		Text:      msg,
+		Facets:    linkFacets(msg),
 	}
 
-	// if there is a reference to the release URL, create a link to it
-	startIdx := strings.Index(msg, ctx.ReleaseURL)
-	if startIdx >= 0 {
-		post.Facets = []*bsky.RichtextFacet{
-			{
-				Index: &bsky.RichtextFacet_ByteSlice{
-					ByteStart: int64(startIdx),
-					ByteEnd:   int64(startIdx + len(ctx.ReleaseURL)),
-				},
-				Features: []*bsky.RichtextFacet_Features_Elem{
-					{
-						RichtextFacet_Link: &bsky.RichtextFacet_Link{
-							Uri: ctx.ReleaseURL,
-						},
-					},
-				},
-			},
-		}
+	pdsURL, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Bluesky.PDSURL)
+	if err != nil {
+		return err
+	}
+	if pdsURL == "" {
+		pdsURL = p.pdsURL
 	}
 
 	httpClient := butil.RobustHTTPClient()
+	if ctx.Config.Announce.Proxy != "" {
+		httpClient, err = httpclient.New(ctx)
+		if err != nil {
+			return err
+		}
+	}
 	userAgent := "goreleaser/v2"
 
 	xrpcClient := &xrpc.Client{
 		Client:    httpClient,
-		Host:      p.pdsURL,
+		Host:      pdsURL,
 		UserAgent: &userAgent,
 	}
 
-	loginInput := &atproto.ServerCreateSession_Input{
-		Identifier: ctx.Config.Announce.Bluesky.Username,
-		Password:   cfg.Password,
-	}
+	if cfg.SessionToken != "" {
+		xrpcClient.Auth = &xrpc.AuthInfo{AccessJwt: cfg.SessionToken}
+		session, err := retryx.DoWithData(ctx, ctx.Config.Retry, func() (*atproto.ServerGetSession_Output, error) {
+			r, err := atproto.ServerGetSession(ctx, xrpcClient)
+			return r, xrpcHTTP(err)
+		}, retryx.IsRetriable)
+		if err != nil {
+			return fmt.Errorf("could not get Bluesky session: %w", err)
+		}
+		xrpcClient.Auth.Handle = session.Handle
+		xrpcClient.Auth.Did = session.Did
+	} else {
+		loginInput := &atproto.ServerCreateSession_Input{
+			Identifier: ctx.Config.Announce.Bluesky.Username,
+			Password:   cfg.Password,
+		}
 
-	authResult, err := retryx.DoWithData(ctx, ctx.Config.Retry, func() (*atproto.ServerCreateSession_Output, error) {
-		r, err := atproto.ServerCreateSession(ctx, xrpcClient, loginInput)
-		return r, xrpcHTTP(err)
-	}, retryx.IsRetriable)
-	if err != nil {
-		return fmt.Errorf("could not log in to Bluesky: %w", err)
-	}
+		authResult, err := retryx.DoWithData(ctx, ctx.Config.Retry, func() (*atproto.ServerCreateSession_Output, error) {
+			r, err := atproto.ServerCreateSession(ctx, xrpcClient, loginInput)
+			return r, xrpcHTTP(err)
+		}, retryx.IsRetriable)
+		if err != nil {
+			return fmt.Errorf("could not log in to Bluesky: %w", err)
+		}
 
-	xrpcClient.Auth = &xrpc.AuthInfo{
-		AccessJwt:  authResult.AccessJwt,
-		RefreshJwt: authResult.RefreshJwt,
-		Handle:     authResult.Handle,
-		Did:        authResult.Did,
+		xrpcClient.Auth = &xrpc.AuthInfo{
+			AccessJwt:  authResult.AccessJwt,
+			RefreshJwt: authResult.RefreshJwt,
+			Handle:     authResult.Handle,
+			Did:        authResult.Did,
+		}
 	}
 
 	_, err = retryx.DoWithData(ctx, ctx.Config.Retry, func() (*atproto.RepoCreateRecord_Output, error) {
@@ -131,6 +156,31 @@ func (p Pipe) Announce(ctx *context.Context) error {
 	return err
 }
 
+// linkFacets returns a link facet for each URL in the given message, so they
+// are clickable.
+func linkFacets(msg string) []*bsky.RichtextFacet {
+	var facets []*bsky.RichtextFacet
+	for _, idx := range urlRe.FindAllStringIndex(msg, -1) {
+		start, end := idx[0], idx[1]
+		// trailing punctuation is most likely not part of the URL.
+		end = start + len(strings.TrimRight(msg[start:end], trailingPunctuation))
+		facets = append(facets, &bsky.RichtextFacet{
+			Index: &bsky.RichtextFacet_ByteSlice{
+				ByteStart: int64(start),
+				ByteEnd:   int64(end),
+			},
+			Features: []*bsky.RichtextFacet_Features_Elem{
+				{
+					RichtextFacet_Link: &bsky.RichtextFacet_Link{
+						Uri: msg[start:end],
+					},
+				},
+			},
+		})
+	}
+	return facets
+}
+
 // xrpcHTTP wraps an xrpc.Error with retryx.HTTP so that status-code-based
 // retry classification works for Bluesky SDK calls.
 func xrpcHTTP(err error) error {
@@ -42,6 +42,18 @@ func TestDefault(t *testing.T) {
 	})
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	msg, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0", msg)
+}
+
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
@@ -53,6 +65,19 @@ func TestAnnounceInvalidTemplate(t *testing.T) {
 	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
 }
 
+func TestAnnounceInvalidPDSURLTemplate(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Bluesky: config.Bluesky{
+				MessageTemplate: "test",
+				PDSURL:          "{{ .Foo }",
+			},
+		},
+	})
+	t.Setenv("BLUESKY_APP_PASSWORD", "password")
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+}
+
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
@@ -60,7 +85,34 @@ func TestAnnounceMissingEnv(t *testing.T) {
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "BLUESKY_APP_PASSWORD" should not be empty`)
+	require.ErrorIs(t, Pipe{}.Announce(ctx), errMissingCredentials)
+}
+
+func TestLinkFacets(t *testing.T) {
+	require.Empty(t, linkFacets("no links here"))
+
+	msg := "foo v1.0.0 is out! Check https://github.com/foo/foo/releases/tag/v1.0.0, or the docs (https://foo.dev/docs)."
+	facets := linkFacets(msg)
+	require.Len(t, facets, 2)
+
+	var links []string
+	for _, facet := range facets {
+		link := msg[facet.Index.ByteStart:facet.Index.ByteEnd]
+		require.Equal(t, link, facet.Features[0].RichtextFacet_Link.Uri)
+		links = append(links, link)
+	}
+	require.Equal(t, []string{
+		"https://github.com/foo/foo/releases/tag/v1.0.0",
+		"https://foo.dev/docs",
+	}, links)
+
+	t.Run("multibyte", func(t *testing.T) {
+		msg := "🚀 https://foo.dev"
+		facets := linkFacets(msg)
+		require.Len(t, facets, 1)
+		require.Equal(t, int64(len("🚀 ")), facets[0].Index.ByteStart)
+		require.Equal(t, int64(len(msg)), facets[0].Index.ByteEnd)
+	})
 }
 
 func TestSkip(t *testing.T) {
@@ -195,6 +247,50 @@ func TestAnnounceWithMockServer(t *testing.T) {
 		require.NoError(t, pipe.Announce(ctx))
 	})
 
+	t.Run("success with session token and pds url", func(t *testing.T) {
+		var record map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer test-session-token", r.Header.Get("Authorization"))
+			switch r.URL.Path {
+			case "/xrpc/com.atproto.server.getSession":
+				w.Header().Set("Content-Type", "application/json")
+				assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+					"handle": "testuser.example.com",
+					"did":    "did:plc:test123",
+				}))
+			case "/xrpc/com.atproto.repo.createRecord":
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+				w.Header().Set("Content-Type", "application/json")
+				assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+					"uri": "at://did:plc:test123/app.bsky.feed.post/test",
+					"cid": "testcid",
+				}))
+			default:
+				assert.Failf(t, "unexpected request", "unexpected request to %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "test-project",
+			Announce: config.Announce{
+				Bluesky: config.Bluesky{
+					MessageTemplate: "Release {{ .ProjectName }} at {{ .ReleaseURL }}",
+					PDSURL:          "{{ .Env.PDS_URL }}",
+				},
+			},
+		}, testctx.WithEnv(map[string]string{"PDS_URL": server.URL}))
+		ctx.ReleaseURL = "https://github.com/test/test/releases/tag/v1.0.0"
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		t.Setenv("BLUESKY_SESSION_TOKEN", "test-session-token")
+
+		require.NoError(t, New().Announce(ctx))
+		require.Equal(t, "did:plc:test123", record["repo"])
+		require.Len(t, record["record"].(map[string]any)["facets"], 1)
+	})
+
 	t.Run("login failure", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 			w.WriteHeader(http.StatusUnauthorized)
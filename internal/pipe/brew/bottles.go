@@ -0,0 +1,197 @@
+package brew
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/archive/targz"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const defaultBottlesMacOS = "big_sur"
+
+// bottleTags returns the Homebrew bottle tags for the platform of the given
+// archive.
+func bottleTags(macos string, art *artifact.Artifact) []string {
+	switch art.Goos + "/" + art.Goarch {
+	case "darwin/amd64":
+		return []string{macos}
+	case "darwin/arm64":
+		return []string{"arm64_" + macos}
+	case "darwin/all":
+		return []string{"arm64_" + macos, macos}
+	case "linux/amd64":
+		return []string{"x86_64_linux"}
+	case "linux/arm64":
+		return []string{"arm64_linux"}
+	default:
+		return nil
+	}
+}
+
+// createBottles creates a bottle for each of the given archives, containing
+// its binaries, and returns the data needed to add them to the formula.
+func createBottles(ctx *context.Context, brew config.Homebrew, cl client.ReleaseURLTemplater, archives []*artifact.Artifact) (*bottleData, error) {
+	macos, err := tmpl.New(ctx).Apply(brew.Bottles.MacOS)
+	if err != nil {
+		return nil, err
+	}
+	rootURL, err := tmpl.New(ctx).Apply(brew.Bottles.RootURL)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &bottleData{RootURL: strings.TrimSuffix(rootURL, "/")}
+	for _, archive := range archives {
+		tags := bottleTags(macos, archive)
+		if len(tags) == 0 {
+			log.WithField("archive", archive.Name).Debug("no bottle for platform")
+			continue
+		}
+		binaries := bottleBinaries(ctx, archive)
+		if len(binaries) == 0 {
+			log.WithField("archive", archive.Name).Warn("no binaries found, skipping bottle")
+			continue
+		}
+		for _, tag := range tags {
+			bottle, err := createBottle(ctx, brew.Name, tag, archive, binaries)
+			if err != nil {
+				return nil, err
+			}
+			sum, err := bottle.Checksum("sha256")
+			if err != nil {
+				return nil, err
+			}
+			if data.RootURL == "" {
+				data.RootURL, err = bottleRootURL(ctx, brew, cl, bottle)
+				if err != nil {
+					return nil, err
+				}
+			}
+			data.Tags = append(data.Tags, bottleTag{
+				Tag:    tag,
+				SHA256: sum,
+			})
+		}
+	}
+
+	if len(data.Tags) == 0 {
+		return nil, nil
+	}
+	slices.SortFunc(data.Tags, func(a, b bottleTag) int {
+		return cmp.Compare(a.Tag, b.Tag)
+	})
+	return data, nil
+}
+
+// bottleBinaries returns the binaries of the given archive, by their name.
+func bottleBinaries(ctx *context.Context, archive *artifact.Artifact) map[string]string {
+	result := map[string]string{}
+	if archive.Type == artifact.UploadableBinary {
+		result[artifact.MustExtra[string](*archive, artifact.ExtraBinary)] = archive.Path
+		return result
+	}
+
+	names := artifact.MustExtra[[]string](*archive, artifact.ExtraBinaries)
+	for _, bin := range ctx.Artifacts.Filter(artifact.And(
+		artifact.ByTypes(artifact.Binary, artifact.UniversalBinary),
+		artifact.ByGoos(archive.Goos),
+		artifact.ByGoarch(archive.Goarch),
+		artifact.ByGoarm(archive.Goarm),
+		artifact.ByGoamd64(archive.Goamd64),
+	)).List() {
+		name := path.Base(bin.Name)
+		if _, ok := result[name]; ok {
+			continue
+		}
+		if slices.ContainsFunc(names, func(s string) bool {
+			return path.Base(s) == name
+		}) {
+			result[name] = bin.Path
+		}
+	}
+	return result
+}
+
+func createBottle(ctx *context.Context, name, tag string, archive *artifact.Artifact, binaries map[string]string) (*artifact.Artifact, error) {
+	filename := fmt.Sprintf("%s-%s.%s.bottle.tar.gz", name, ctx.Version, tag)
+	path := filepath.Join(ctx.Config.Dist, "homebrew", "bottles", filename)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	log.WithField("bottle", path).Info("creating")
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bottle: %w", err)
+	}
+	defer f.Close()
+
+	a := targz.New(f)
+	for _, bin := range slices.Sorted(maps.Keys(binaries)) {
+		if err := a.Add(config.File{
+			Source:      binaries[bin],
+			Destination: strings.Join([]string{name, ctx.Version, "bin", bin}, "/"),
+			Info: config.FileInfo{
+				Mode:        0o755,
+				ParsedMTime: ctx.Git.CommitDate,
+			},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create bottle: %w", err)
+		}
+	}
+	if err := a.Close(); err != nil {
+		return nil, fmt.Errorf("failed to create bottle: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to create bottle: %w", err)
+	}
+
+	bottle := &artifact.Artifact{
+		Type:    artifact.BrewBottle,
+		Name:    filename,
+		Path:    path,
+		Goos:    archive.Goos,
+		Goarch:  archive.Goarch,
+		Goarm:   archive.Goarm,
+		Goamd64: archive.Goamd64,
+		Extra: map[string]any{
+			artifact.ExtraID: archive.ID(),
+		},
+	}
+	ctx.Artifacts.Add(bottle)
+	return bottle, nil
+}
+
+// bottleRootURL infers the root URL of the bottles from the URL the given
+// bottle will be downloaded from.
+func bottleRootURL(ctx *context.Context, brew config.Homebrew, cl client.ReleaseURLTemplater, bottle *artifact.Artifact) (string, error) {
+	urlTemplate := brew.URLTemplate
+	if urlTemplate == "" {
+		var err error
+		urlTemplate, err = cl.ReleaseURLTemplate(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+	url, err := tmpl.New(ctx).WithArtifact(bottle).Apply(urlTemplate)
+	if err != nil {
+		return "", err
+	}
+	root, ok := strings.CutSuffix(url, "/"+bottle.Name)
+	if !ok {
+		return "", fmt.Errorf("could not infer the bottles root url from %q, set 'bottles.root_url'", url)
+	}
+	return root, nil
+}
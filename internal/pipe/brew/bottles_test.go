@@ -0,0 +1,231 @@
+package brew
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFullFormulaeLivecheckBottle(t *testing.T) {
+	data := defaultTemplateData
+	data.License = "MIT"
+	data.Livecheck = []string{"url :stable", "strategy :github_latest"}
+	data.Bottle = &bottleData{
+		RootURL: "https://github.com/caarlos0/test/releases/download/v0.1.3",
+		Tags: []bottleTag{
+			{Tag: "arm64_big_sur", SHA256: "1df5fdc2bad4ed4c28fbdc77b6c542988c0dc0e2ae34e0dc912bbb1c66646c58"},
+			{Tag: "big_sur", SHA256: "1633f61598ab0791e213135923624eb342196b3494909c91899bcd0560f84c68"},
+			{Tag: "x86_64_linux", SHA256: "1633f61598ab0791e213135923624eb342196b3494909c91899bcd0560f84c67"},
+		},
+	}
+	data.Tests = []string{`system "#{bin}/test", "--version"`}
+	formulae, err := doBuildFormula(testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+	}), data)
+	require.NoError(t, err)
+
+	golden.RequireEqualRb(t, []byte(formulae))
+}
+
+func TestBottleTags(t *testing.T) {
+	for _, tt := range []struct {
+		goos, goarch string
+		expected     []string
+	}{
+		{"darwin", "amd64", []string{"big_sur"}},
+		{"darwin", "arm64", []string{"arm64_big_sur"}},
+		{"darwin", "all", []string{"arm64_big_sur", "big_sur"}},
+		{"linux", "amd64", []string{"x86_64_linux"}},
+		{"linux", "arm64", []string{"arm64_linux"}},
+		{"linux", "arm", nil},
+		{"windows", "amd64", nil},
+	} {
+		t.Run(tt.goos+"_"+tt.goarch, func(t *testing.T) {
+			require.Equal(t, tt.expected, bottleTags("big_sur", &artifact.Artifact{
+				Goos:   tt.goos,
+				Goarch: tt.goarch,
+			}))
+		})
+	}
+}
+
+func TestRunPipeBottles(t *testing.T) {
+	ctx := makeBottlesContext(t)
+	cli := client.NewMock()
+	require.NoError(t, runAll(ctx, cli))
+
+	bottles := ctx.Artifacts.Filter(artifact.ByType(artifact.BrewBottle)).List()
+	names := make([]string, 0, len(bottles))
+	for _, b := range bottles {
+		names = append(names, b.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"foo-1.0.0.arm64_big_sur.bottle.tar.gz",
+		"foo-1.0.0.big_sur.bottle.tar.gz",
+		"foo-1.0.0.x86_64_linux.bottle.tar.gz",
+	}, names)
+
+	require.Equal(t, []string{"foo/1.0.0/bin/foo"}, tarFiles(t, filepath.Join(ctx.Config.Dist, "homebrew", "bottles", "foo-1.0.0.x86_64_linux.bottle.tar.gz")))
+	require.Equal(t, []string{"foo/1.0.0/bin/foo"}, tarFiles(t, filepath.Join(ctx.Config.Dist, "homebrew", "bottles", "foo-1.0.0.big_sur.bottle.tar.gz")))
+
+	content := readFormula(t, ctx)
+	require.Contains(t, content, `root_url "https://dummyhost/download/v1.0.0"`)
+	for _, b := range bottles {
+		sum, err := b.Checksum("sha256")
+		require.NoError(t, err)
+		require.Contains(t, content, sum)
+	}
+	require.Contains(t, content, "sha256 cellar: :any_skip_relocation, arm64_big_sur:")
+	require.Contains(t, content, "sha256 cellar: :any_skip_relocation, big_sur:")
+	require.Contains(t, content, "sha256 cellar: :any_skip_relocation, x86_64_linux:")
+	require.NotContains(t, content, "arm64_linux")
+}
+
+func TestRunPipeBottlesRootURL(t *testing.T) {
+	t.Run("root url", func(t *testing.T) {
+		ctx := makeBottlesContext(t)
+		ctx.Config.Brews[0].Bottles.RootURL = "https://example.com/{{ .ProjectName }}/"
+		require.NoError(t, runAll(ctx, client.NewMock()))
+		require.Contains(t, readFormula(t, ctx), `root_url "https://example.com/foo"`)
+	})
+	t.Run("url template", func(t *testing.T) {
+		ctx := makeBottlesContext(t)
+		ctx.Config.Brews[0].URLTemplate = "https://example.com/{{ .Tag }}/{{ .ArtifactName }}"
+		require.NoError(t, runAll(ctx, client.NewMock()))
+		require.Contains(t, readFormula(t, ctx), `root_url "https://example.com/v1.0.0"`)
+	})
+	t.Run("cannot infer", func(t *testing.T) {
+		ctx := makeBottlesContext(t)
+		ctx.Config.Brews[0].URLTemplate = "https://example.com/{{ .ArtifactName }}?download=1"
+		require.ErrorContains(t, runAll(ctx, client.NewMock()), "set 'bottles.root_url'")
+	})
+	t.Run("bad template", func(t *testing.T) {
+		ctx := makeBottlesContext(t)
+		ctx.Config.Brews[0].Bottles.RootURL = "{{ .Nope }}"
+		testlib.RequireTemplateError(t, runAll(ctx, client.NewMock()))
+	})
+}
+
+func TestDefaultBottles(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Brews: []config.Homebrew{
+			{},
+			{Bottles: config.HomebrewBottles{Enabled: true}},
+			{Bottles: config.HomebrewBottles{Enabled: true, MacOS: "sonoma"}},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Empty(t, ctx.Config.Brews[0].Bottles.MacOS)
+	require.Equal(t, "big_sur", ctx.Config.Brews[1].Bottles.MacOS)
+	require.Equal(t, "sonoma", ctx.Config.Brews[2].Bottles.MacOS)
+}
+
+func readFormula(tb testing.TB, ctx *context.Context) string {
+	tb.Helper()
+	formula := ctx.Artifacts.Filter(artifact.ByType(artifact.BrewFormula)).List()
+	require.Len(tb, formula, 1)
+	bts, err := os.ReadFile(formula[0].Path)
+	require.NoError(tb, err)
+	return string(bts)
+}
+
+func tarFiles(tb testing.TB, path string) []string {
+	tb.Helper()
+	f, err := os.Open(path)
+	require.NoError(tb, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(tb, err)
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(tb, err)
+		require.Equal(tb, int64(0o755), h.Mode)
+		names = append(names, h.Name)
+	}
+	return names
+}
+
+func makeBottlesContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	folder := tb.TempDir()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		Dist:        folder,
+		ProjectName: "foo",
+		Brews: []config.Homebrew{{
+			Repository: config.RepoRef{
+				Owner: "foo",
+				Name:  "bar",
+			},
+			Bottles: config.HomebrewBottles{Enabled: true},
+		}},
+	}, testctx.WithVersion("1.0.0"), testctx.WithCurrentTag("v1.0.0"))
+	require.NoError(tb, Pipe{}.Default(ctx))
+
+	for _, p := range []struct {
+		goos, goarch, goarm string
+		typ                 artifact.Type
+	}{
+		{"darwin", "all", "", artifact.UniversalBinary},
+		{"linux", "amd64", "", artifact.Binary},
+		{"linux", "arm", "6", artifact.Binary},
+	} {
+		platform := p.goos + "_" + p.goarch
+		bin := filepath.Join(folder, platform, "foo")
+		require.NoError(tb, os.MkdirAll(filepath.Dir(bin), 0o755))
+		require.NoError(tb, os.WriteFile(bin, []byte(platform), 0o755))
+		archive := filepath.Join(folder, "foo_"+platform+".tar.gz")
+		require.NoError(tb, os.WriteFile(archive, []byte(platform), 0o644))
+
+		goamd64 := ""
+		if p.goarch == "amd64" {
+			goamd64 = "v1"
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo",
+			Path:    bin,
+			Goos:    p.goos,
+			Goarch:  p.goarch,
+			Goarm:   p.goarm,
+			Goamd64: goamd64,
+			Type:    p.typ,
+			Extra: map[string]any{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraReplaces: true,
+			},
+		})
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo_" + platform + ".tar.gz",
+			Path:    archive,
+			Goos:    p.goos,
+			Goarch:  p.goarch,
+			Goarm:   p.goarm,
+			Goamd64: goamd64,
+			Type:    artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraFormat:   "tar.gz",
+				artifact.ExtraBinaries: []string{"foo"},
+				artifact.ExtraReplaces: true,
+			},
+		})
+	}
+	return ctx
+}
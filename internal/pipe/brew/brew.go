@@ -75,6 +75,9 @@ func (Pipe) Default(ctx *context.Context) error {
 		if brew.Goamd64 == "" {
 			brew.Goamd64 = "v1"
 		}
+		if brew.Bottles.Enabled && brew.Bottles.MacOS == "" {
+			brew.Bottles.MacOS = defaultBottlesMacOS
+		}
 	}
 
 	return nil
@@ -193,7 +196,7 @@ func doPublish(ctx *context.Context, formula *artifact.Artifact, cl client.Clien
 		return errors.New("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, msg, brew.Repository.PullRequest.Draft)
+	return pcl.OpenPullRequest(ctx, base, repo, msg, brew.Repository.PullRequest.Body, brew.Repository.PullRequest.Draft)
 }
 
 func doRun(ctx *context.Context, brew config.Homebrew, cl client.ReleaseURLTemplater) error {
@@ -258,7 +261,19 @@ func doRun(ctx *context.Context, brew config.Homebrew, cl client.ReleaseURLTempl
 	}
 	brew.SkipUpload = skipUpload
 
-	content, err := buildFormula(ctx, brew, cl, archives)
+	data, err := dataFor(ctx, brew, cl, archives)
+	if err != nil {
+		return err
+	}
+
+	if brew.Bottles.Enabled {
+		data.Bottle, err = createBottles(ctx, brew, cl, archives)
+		if err != nil {
+			return fmt.Errorf("failed to create bottles: %w", err)
+		}
+	}
+
+	content, err := doBuildFormula(ctx, data)
 	if err != nil {
 		return err
 	}
@@ -290,14 +305,6 @@ func buildFormulaPath(folder, filename string) string {
 	return path.Join(folder, filename)
 }
 
-func buildFormula(ctx *context.Context, brew config.Homebrew, client client.ReleaseURLTemplater, artifacts []*artifact.Artifact) (string, error) {
-	data, err := dataFor(ctx, brew, client, artifacts)
-	if err != nil {
-		return "", err
-	}
-	return doBuildFormula(ctx, data)
-}
-
 func doBuildFormula(ctx *context.Context, data templateData) (string, error) {
 	t := template.New("formula.rb")
 	var err error
@@ -397,6 +404,7 @@ func dataFor(ctx *context.Context, cfg config.Homebrew, cl client.ReleaseURLTemp
 		Homepage:      cfg.Homepage,
 		Version:       ctx.Version,
 		License:       cfg.License,
+		Livecheck:     split(cfg.Livecheck),
 		Caveats:       split(cfg.Caveats),
 		Dependencies:  cfg.Dependencies,
 		Conflicts:     cfg.Conflicts,
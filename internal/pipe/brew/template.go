@@ -12,6 +12,8 @@ type templateData struct {
 	Homepage             string
 	Version              string
 	License              string
+	Livecheck            []string
+	Bottle               *bottleData
 	Caveats              []string
 	PostInstall          []string
 	Dependencies         []config.HomebrewDependency
@@ -35,5 +37,15 @@ type releasePackage struct {
 	Headers          []string
 }
 
+type bottleData struct {
+	RootURL string
+	Tags    []bottleTag
+}
+
+type bottleTag struct {
+	Tag    string
+	SHA256 string
+}
+
 //go:embed templates
 var formulaTemplate embed.FS
@@ -12,6 +12,23 @@ class {{ .Name }} < Formula
   {{- if .License }}
   license "{{ .License }}"
   {{- end }}
+  {{- with .Livecheck }}
+
+  livecheck do
+    {{- range . }}
+    {{ . }}
+    {{- end }}
+  end
+  {{- end }}
+  {{- with .Bottle }}
+
+  bottle do
+    root_url "{{ .RootURL }}"
+    {{- range .Tags }}
+    sha256 cellar: :any_skip_relocation, {{ .Tag }}: "{{ .SHA256 }}"
+    {{- end }}
+  end
+  {{- end }}
   {{- with .Dependencies }}
   {{ range $index, $element := . }}
   depends_on "{{ .Name }}"
@@ -3,13 +3,16 @@
 package build
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/fileglob"
 	"github.com/goreleaser/go-shellwords"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/deprecate"
 	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
 	"github.com/goreleaser/goreleaser/v2/internal/ids"
@@ -24,10 +27,14 @@ import (
 	// langs to init.
 	_ "github.com/goreleaser/goreleaser/v2/internal/builders/bun"
 	_ "github.com/goreleaser/goreleaser/v2/internal/builders/deno"
-	_ "github.com/goreleaser/goreleaser/v2/internal/builders/golang"
+	"github.com/goreleaser/goreleaser/v2/internal/builders/golang"
+	_ "github.com/goreleaser/goreleaser/v2/internal/builders/jvm"
 	_ "github.com/goreleaser/goreleaser/v2/internal/builders/node"
 	_ "github.com/goreleaser/goreleaser/v2/internal/builders/poetry"
+	_ "github.com/goreleaser/goreleaser/v2/internal/builders/prebuilt"
+	_ "github.com/goreleaser/goreleaser/v2/internal/builders/pyinstaller"
 	_ "github.com/goreleaser/goreleaser/v2/internal/builders/rust"
+	_ "github.com/goreleaser/goreleaser/v2/internal/builders/tinygo"
 	_ "github.com/goreleaser/goreleaser/v2/internal/builders/uv"
 	_ "github.com/goreleaser/goreleaser/v2/internal/builders/zig"
 )
@@ -41,6 +48,9 @@ func (Pipe) String() string {
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
+	if ctx.VerifyReproducible {
+		normalizeForReproducible(ctx)
+	}
 	g := semerrgroup.New(ctx.Parallelism)
 	for _, build := range ctx.Config.Builds {
 		skip, err := tmpl.New(ctx).Bool(build.Skip)
@@ -51,16 +61,30 @@ func (Pipe) Run(ctx *context.Context) error {
 			log.WithField("id", build.ID).Info("skip is set")
 			continue
 		}
+		if build.If != "" {
+			ok, err := tmpl.New(ctx).Bool(build.If)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				log.WithField("id", build.ID).Info("if condition is not true")
+				continue
+			}
+		}
 		log.WithField("build", build).Debug("building")
 		if err := prepare(ctx, build); err != nil {
 			return err
 		}
-		if allowParallelism(build) {
+		if err := generate(ctx, build); err != nil {
+			return err
+		}
+		limit := concurrencyFor(build)
+		if limit == 0 {
 			runPipeOnBuild(ctx, g, build)
 			continue
 		}
 		g.Go(func() error {
-			gg := semerrgroup.New(1)
+			gg := semerrgroup.New(limit)
 			runPipeOnBuild(ctx, gg, build)
 			return gg.Wait()
 		})
@@ -68,6 +92,15 @@ func (Pipe) Run(ctx *context.Context) error {
 	return g.Wait()
 }
 
+// concurrencyFor returns how many targets of the given build can be built at
+// the same time, or 0 if only the global parallelism applies.
+func concurrencyFor(build config.Build) int {
+	if !allowParallelism(build) {
+		return 1
+	}
+	return build.Concurrency
+}
+
 func allowParallelism(build config.Build) bool {
 	conc, ok := builders.For(build.Builder).(builders.ConcurrentBuilder)
 	if !ok {
@@ -115,6 +148,9 @@ func buildWithDefaults(ctx *context.Context, build config.Build) (config.Build,
 	if build.Builder == "" {
 		build.Builder = "go"
 	}
+	if build.Concurrency < 0 {
+		return build, fmt.Errorf("invalid concurrency: %d", build.Concurrency)
+	}
 	if build.Binary == "" {
 		build.Binary = ctx.Config.ProjectName
 		build.InternalDefaults.Binary = true
@@ -126,7 +162,20 @@ func buildWithDefaults(ctx *context.Context, build config.Build) (config.Build,
 	for k, v := range build.Env {
 		build.Env[k] = os.ExpandEnv(v)
 	}
-	return builders.For(build.Builder).WithDefaults(build)
+	build, err := builders.For(build.Builder).WithDefaults(build)
+	if err != nil {
+		return build, err
+	}
+	for i, gen := range build.Prebuild.Generate {
+		if gen.Cmd != "" {
+			continue
+		}
+		if build.Builder != "go" {
+			return build, fmt.Errorf("prebuild.generate.cmd is required for %s builds", build.Builder)
+		}
+		build.Prebuild.Generate[i].Cmd = build.Tool + " generate ./..."
+	}
+	return build, nil
 }
 
 func runPipeOnBuild(ctx *context.Context, g semerrgroup.Group, build config.Build) {
@@ -141,6 +190,11 @@ func runPipeOnBuild(ctx *context.Context, g semerrgroup.Group, build config.Buil
 }
 
 func buildTarget(ctx *context.Context, build config.Build, target string) error {
+	build, err := withTargetOverrides(ctx, build, target)
+	if err != nil {
+		return err
+	}
+
 	opts, err := buildOptionsForTarget(ctx, build, target)
 	if err != nil {
 		return err
@@ -150,30 +204,45 @@ func buildTarget(ctx *context.Context, build config.Build, target string) error
 		return fmt.Errorf("create target directory: %w", err)
 	}
 
+	var hookFiles []config.File
 	if !skips.Any(ctx, skips.PreBuildHooks) {
-		if err := runHook(ctx, *opts, build.Env, build.Hooks.Pre); err != nil {
+		files, err := runHook(ctx, *opts, build.Env, build.Hooks.Pre)
+		if err != nil {
 			return fmt.Errorf("pre hook failed: %w", err)
 		}
+		hookFiles = append(hookFiles, files...)
 	}
 
 	if err := doBuild(ctx, build, *opts); err != nil {
 		return fmt.Errorf("build failed: %w", err)
 	}
 
+	if ctx.VerifyReproducible {
+		if err := verifyReproducible(ctx, build, *opts); err != nil {
+			return err
+		}
+	}
+
 	if !skips.Any(ctx, skips.PostBuildHooks) {
-		if err := runHook(ctx, *opts, build.Env, build.Hooks.Post); err != nil {
+		files, err := runHook(ctx, *opts, build.Env, build.Hooks.Post)
+		if err != nil {
 			return fmt.Errorf("post hook failed: %w", err)
 		}
+		hookFiles = append(hookFiles, files...)
 	}
 
+	addHookArtifacts(ctx, build, *opts, hookFiles)
 	return nil
 }
 
-func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hooks config.Hooks) error {
+// runHook runs the given hooks, returning the files matching their artifacts
+// globs.
+func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hooks config.Hooks) ([]config.File, error) {
 	if len(hooks) == 0 {
-		return nil
+		return nil, nil
 	}
 
+	var files []config.File
 	for _, hook := range hooks {
 		var env []string
 
@@ -181,35 +250,150 @@ func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hoo
 		for _, rawEnv := range append(buildEnv, hook.Env...) {
 			e, err := tmpl.New(ctx).WithBuildOptions(opts).Apply(rawEnv)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			env = append(env, e)
 		}
 
 		dir, err := tmpl.New(ctx).WithBuildOptions(opts).Apply(hook.Dir)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		sh, err := tmpl.New(ctx).WithBuildOptions(opts).
 			WithEnvS(env).
 			Apply(hook.Cmd)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		log.WithField("hook", sh).Info("running hook")
 		cmd, err := shellwords.Parse(sh)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if err := shell.Run(ctx, dir, cmd, env, hook.Output); err != nil {
-			return err
+			return nil, err
+		}
+
+		globs, err := tmpl.New(ctx).WithBuildOptions(opts).
+			WithEnvS(env).
+			Slice(hook.Artifacts, tmpl.NonEmpty())
+		if err != nil {
+			return nil, err
+		}
+		for _, glob := range globs {
+			if dir != "" && !filepath.IsAbs(glob) {
+				glob = filepath.Join(dir, glob)
+			}
+			matches, err := fileglob.Glob(glob)
+			if err != nil {
+				return nil, fmt.Errorf("globbing failed for pattern %s: %w", glob, err)
+			}
+			if len(matches) == 0 {
+				log.WithField("glob", glob).Warn("hook artifacts glob did not match any files")
+			}
+			for _, match := range matches {
+				files = append(files, config.File{
+					Source:      match,
+					Destination: hookFileDestination(dir, match),
+				})
+			}
 		}
 	}
 
-	return nil
+	return files, nil
+}
+
+// addHookArtifacts registers the given files, created by the build hooks, as
+// artifacts for the same platform as the binary built by the given options,
+// so they can be added to its archives.
+func addHookArtifacts(ctx *context.Context, build config.Build, opts builders.Options, files []config.File) {
+	if len(files) == 0 {
+		return
+	}
+
+	bins := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByIDs(build.ID),
+		func(a *artifact.Artifact) bool {
+			return a.Type != artifact.BuildFile && a.Target == opts.Target.String()
+		},
+	)).List()
+	if len(bins) == 0 {
+		log.WithField("target", opts.Target.String()).
+			Warn("no artifacts built, ignoring hook artifacts")
+		return
+	}
+	bin := bins[0]
+
+	seen := map[string]bool{}
+	for _, file := range files {
+		if seen[file.Source] {
+			continue
+		}
+		seen[file.Source] = true
+		extra := artifact.Extras{
+			artifact.ExtraID: build.ID,
+		}
+		if abi, ok := bin.Extra["Abi"]; ok {
+			extra["Abi"] = abi
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type:      artifact.BuildFile,
+			Name:      file.Destination,
+			Path:      file.Source,
+			Goos:      bin.Goos,
+			Goarch:    bin.Goarch,
+			Goamd64:   bin.Goamd64,
+			Go386:     bin.Go386,
+			Goarm:     bin.Goarm,
+			Goarm64:   bin.Goarm64,
+			Gomips:    bin.Gomips,
+			Goppc64:   bin.Goppc64,
+			Goriscv64: bin.Goriscv64,
+			Target:    bin.Target,
+			Extra:     extra,
+		})
+	}
+}
+
+// hookFileDestination returns the path of the given file relative to the hook
+// dir, or its base name if it is outside of it.
+func hookFileDestination(dir, file string) string {
+	if dir == "" {
+		dir = "."
+	}
+	rel, err := filepath.Rel(dir, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.Base(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// withTargetOverrides returns the build with the main, binary and hooks of the
+// override matching the given target, if any.
+func withTargetOverrides(ctx *context.Context, build config.Build, target string) (config.Build, error) {
+	o, ok, err := golang.FindOverride(ctx, build, target)
+	if err != nil || !ok {
+		return build, err
+	}
+	if o.Main != "" {
+		if build.UnproxiedMain != "" {
+			return build, errors.New("overrides can't set main when gomod.proxy is enabled")
+		}
+		build.Main = o.Main
+	}
+	if o.Binary != "" {
+		build.Binary = o.Binary
+	}
+	if len(o.Hooks.Pre) > 0 {
+		build.Hooks.Pre = o.Hooks.Pre
+	}
+	if len(o.Hooks.Post) > 0 {
+		build.Hooks.Post = o.Hooks.Post
+	}
+	return build, nil
 }
 
 func doBuild(ctx *context.Context, build config.Build, opts builders.Options) error {
@@ -234,15 +418,10 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 	}
 
 	name := bin + ext
-	dir := fmt.Sprintf("%s_%s", build.ID, t)
-	noUnique, err := tmpl.New(ctx).Bool(build.NoUniqueDistDir)
+	relpath, err := outputPath(ctx, build, buildOpts, bin)
 	if err != nil {
 		return nil, err
 	}
-	if noUnique {
-		dir = ""
-	}
-	relpath := filepath.Join(ctx.Config.Dist, dir, name)
 	path, err := filepath.Abs(relpath)
 	if err != nil {
 		return nil, err
@@ -253,6 +432,34 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 	return &buildOpts, nil
 }
 
+// outputPath returns the path, within the dist directory, the given binary
+// should be built at.
+func outputPath(ctx *context.Context, build config.Build, opts builders.Options, bin string) (string, error) {
+	if build.Output != "" {
+		out, err := tmpl.New(ctx).
+			WithBuildOptions(opts).
+			WithExtraFields(tmpl.Fields{"Binary": bin}).
+			Apply(build.Output)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsLocal(out) {
+			return "", fmt.Errorf("output must be a relative path within the dist directory, got %q", out)
+		}
+		return filepath.Join(ctx.Config.Dist, out+opts.Ext), nil
+	}
+
+	dir := fmt.Sprintf("%s_%s", build.ID, opts.Target)
+	noUnique, err := tmpl.New(ctx).Bool(build.NoUniqueDistDir)
+	if err != nil {
+		return "", err
+	}
+	if noUnique {
+		dir = ""
+	}
+	return filepath.Join(ctx.Config.Dist, dir, bin+opts.Ext), nil
+}
+
 // TODO: this should probably be the responsibility of each builder.
 func extFor(target string, build config.BuildDetails) string {
 	// Configure the extensions for shared and static libraries - by default .so and .a respectively -
@@ -5,8 +5,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
@@ -46,6 +48,8 @@ func (f fakeTarget) Fields() map[string]string {
 type fakeBuilder struct {
 	fail        bool
 	failDefault bool
+	random      bool
+	full        bool
 }
 
 // Parse implements build.Builder.
@@ -60,13 +64,32 @@ func (f *fakeBuilder) WithDefaults(build config.Build) (config.Build, error) {
 	return build, nil
 }
 
-func (f *fakeBuilder) Build(ctx *context.Context, _ config.Build, options api.Options) error {
+func (f *fakeBuilder) Build(ctx *context.Context, build config.Build, options api.Options) error {
 	if f.fail {
 		return errFailedBuild
 	}
-	if err := os.WriteFile(options.Path, []byte("foo"), 0o755); err != nil {
+	content := "foo"
+	if f.random {
+		content = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	if err := os.WriteFile(options.Path, []byte(content), 0o755); err != nil {
 		return err
 	}
+	if f.full {
+		goos, goarch, _ := strings.Cut(options.Target.String(), "_")
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type:   artifact.Binary,
+			Name:   options.Name,
+			Path:   options.Path,
+			Goos:   goos,
+			Goarch: goarch,
+			Target: options.Target.String(),
+			Extra: artifact.Extras{
+				artifact.ExtraID: build.ID,
+			},
+		})
+		return nil
+	}
 	ctx.Artifacts.Add(&artifact.Artifact{
 		Name: options.Name,
 	})
@@ -81,6 +104,12 @@ func init() {
 	api.Register("fakeFailDefault", &fakeBuilder{
 		failDefault: true,
 	})
+	api.Register("fakeRandom", &fakeBuilder{
+		random: true,
+	})
+	api.Register("fakeFull", &fakeBuilder{
+		full: true,
+	})
 }
 
 func TestPipeDescription(t *testing.T) {
@@ -182,6 +211,62 @@ func TestRunFullPipe(t *testing.T) {
 	require.FileExists(t, filepath.Join(folder, "build1_linux_amd64", "testing"))
 }
 
+func TestRunPipeHookArtifacts(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	require.NoError(t, os.Mkdir(filepath.Join(folder, "completions"), 0o755))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: filepath.Join(folder, "dist"),
+		Builds: []config.Build{
+			{
+				ID:      "foo",
+				Builder: "fakeFull",
+				Binary:  "foo",
+				Hooks: config.BuildHookConfig{
+					Post: []config.Hook{
+						{
+							Dir:       "completions",
+							Cmd:       testlib.Touch("foo_{{ .Os }}.bash"),
+							Artifacts: []string{"foo_{{ .Os }}.*"},
+						},
+						{
+							Cmd:       testlib.Touch("manpage.1"),
+							Artifacts: []string{"*.1"},
+						},
+					},
+				},
+				Targets: []string{"linux_amd64", "darwin_arm64"},
+			},
+		},
+	}, testctx.WithCurrentTag("2.4.5"))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	files := ctx.Artifacts.Filter(artifact.ByType(artifact.BuildFile)).List()
+	require.Len(t, files, 4)
+	for _, f := range files {
+		require.Equal(t, "foo", f.ID())
+		require.FileExists(t, f.Path)
+		switch f.Name {
+		case "manpage.1":
+		case "foo_" + f.Goos + ".bash":
+			require.Equal(t, filepath.Join("completions", f.Name), f.Path)
+		default:
+			require.Failf(t, "unexpected build file", "%s (%s)", f.Name, f.Target)
+		}
+	}
+}
+
+func TestHookFileDestination(t *testing.T) {
+	for dir, expected := range map[string]string{
+		"":      "completions/foo.bash",
+		".":     "completions/foo.bash",
+		"other": "foo.bash",
+	} {
+		t.Run(dir, func(t *testing.T) {
+			require.Equal(t, expected, hookFileDestination(dir, filepath.Join("completions", "foo.bash")))
+		})
+	}
+}
+
 func TestRunFullPipeFail(t *testing.T) {
 	folder := testlib.Mktmp(t)
 	pre := filepath.Join(folder, "pre")
@@ -288,6 +373,49 @@ func TestDefaultFail(t *testing.T) {
 	require.Empty(t, ctx.Artifacts.List())
 }
 
+func TestDefaultInvalidConcurrency(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Builds: []config.Build{
+			{
+				Builder:     "fake",
+				Concurrency: -1,
+			},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "invalid concurrency: -1")
+}
+
+func TestConcurrencyFor(t *testing.T) {
+	require.Equal(t, 0, concurrencyFor(config.Build{Builder: "fake"}))
+	require.Equal(t, 2, concurrencyFor(config.Build{Builder: "fake", Concurrency: 2}))
+	require.Equal(t, 1, concurrencyFor(config.Build{Builder: "rust", Concurrency: 2}))
+}
+
+func TestRunPipeConcurrency(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: folder,
+		Builds: []config.Build{
+			{
+				ID:          "heavy",
+				Builder:     "fake",
+				Binary:      "heavy",
+				Concurrency: 1,
+				Targets:     []string{"linux_amd64", "darwin_arm64"},
+			},
+			{
+				ID:      "light",
+				Builder: "fake",
+				Binary:  "light",
+				Targets: []string{"linux_amd64", "darwin_arm64"},
+			},
+		},
+	}, testctx.WithCurrentTag("2.4.5"))
+
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Len(t, ctx.Artifacts.List(), 4)
+}
+
 func TestDefaultExpandEnv(t *testing.T) {
 	t.Setenv("XBAR", "FOOBAR")
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
@@ -452,6 +580,52 @@ func TestSkipBuildTmpl(t *testing.T) {
 	require.Empty(t, ctx.Artifacts.List())
 }
 
+func TestBuildIf(t *testing.T) {
+	for cond, built := range map[string]bool{
+		"{{ .IsSnapshot }}":         true,
+		"{{ not .IsSnapshot }}":     false,
+		"{{ eq .Env.FOO \"bar\" }}": true,
+		"false":                     false,
+	} {
+		t.Run(cond, func(t *testing.T) {
+			folder := testlib.Mktmp(t)
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Dist: folder,
+				Env:  []string{"FOO=bar"},
+				Builds: []config.Build{
+					{
+						ID:      "foo",
+						Builder: "fake",
+						Binary:  "foo",
+						Targets: []string{"linux_amd64"},
+						If:      cond,
+					},
+				},
+			}, testctx.Snapshot, testctx.WithCurrentTag("2.4.5"))
+			require.NoError(t, Pipe{}.Run(ctx))
+			if built {
+				require.Len(t, ctx.Artifacts.List(), 1)
+				return
+			}
+			require.Empty(t, ctx.Artifacts.List())
+		})
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		folder := testlib.Mktmp(t)
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Dist: folder,
+			Builds: []config.Build{
+				{
+					Builder: "fake",
+					If:      "{{ .Nope }}",
+				},
+			},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
+
 func TestExtDarwin(t *testing.T) {
 	require.Empty(t, extFor("darwin_amd64", config.BuildDetails{}))
 	require.Empty(t, extFor("darwin_arm64", config.BuildDetails{}))
@@ -585,6 +759,93 @@ func TestPipeOnBuild_hooksRunPerTarget(t *testing.T) {
 	require.FileExists(t, filepath.Join(tmpDir, "post-hook-windows_amd64"))
 }
 
+func TestPipeOnBuild_targetOverrides(t *testing.T) {
+	tmpDir := testlib.Mktmp(t)
+
+	build := config.Build{
+		Builder: "fake",
+		Binary:  "testing",
+		Targets: []string{
+			"linux_amd64_v1",
+			"windows_amd64_v1",
+		},
+		Hooks: config.BuildHookConfig{
+			Pre: []config.Hook{
+				{Cmd: testlib.Touch("pre-hook-{{.Os}}"), Dir: tmpDir},
+			},
+			Post: config.Hooks{
+				{Cmd: testlib.Touch("post-hook-{{.Os}}"), Dir: tmpDir},
+			},
+		},
+		BuildDetailsOverrides: []config.BuildDetailsOverride{
+			{
+				Goos:   "windows",
+				Goarch: "amd64",
+				Binary: "testing-{{.Os}}",
+				Hooks: config.BuildHookConfig{
+					Pre: []config.Hook{
+						{Cmd: testlib.Touch("pre-override-{{.Os}}"), Dir: tmpDir},
+					},
+				},
+			},
+		},
+	}
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: filepath.Join(tmpDir, "dist"),
+		Builds: []config.Build{
+			build,
+		},
+	})
+
+	g := semerrgroup.New(ctx.Parallelism)
+	runPipeOnBuild(ctx, g, build)
+	require.NoError(t, g.Wait())
+
+	names := []string{}
+	for _, a := range ctx.Artifacts.List() {
+		names = append(names, a.Name)
+	}
+	require.ElementsMatch(t, []string{"testing", "testing-windows.exe"}, names)
+
+	require.FileExists(t, filepath.Join(tmpDir, "pre-hook-linux"))
+	require.NoFileExists(t, filepath.Join(tmpDir, "pre-hook-windows"))
+	require.FileExists(t, filepath.Join(tmpDir, "pre-override-windows"))
+	require.FileExists(t, filepath.Join(tmpDir, "post-hook-linux"))
+	require.FileExists(t, filepath.Join(tmpDir, "post-hook-windows"))
+}
+
+func TestWithTargetOverrides(t *testing.T) {
+	build := config.Build{
+		Main: "./cmd/foo",
+		BuildDetailsOverrides: []config.BuildDetailsOverride{
+			{
+				Goos:   "windows",
+				Goarch: "amd64",
+				Main:   "./cmd/foo-windows",
+			},
+		},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		result, err := withTargetOverrides(testctx.Wrap(t.Context()), build, "windows_amd64_v1")
+		require.NoError(t, err)
+		require.Equal(t, "./cmd/foo-windows", result.Main)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		result, err := withTargetOverrides(testctx.Wrap(t.Context()), build, "linux_amd64_v1")
+		require.NoError(t, err)
+		require.Equal(t, "./cmd/foo", result.Main)
+	})
+
+	t.Run("gomod proxy", func(t *testing.T) {
+		build := build
+		build.UnproxiedMain = "./cmd/foo"
+		_, err := withTargetOverrides(testctx.Wrap(t.Context()), build, "windows_amd64_v1")
+		require.Error(t, err)
+	})
+}
+
 func TestPipeOnBuild_invalidBinaryTpl(t *testing.T) {
 	build := config.Build{
 		Builder: "fake",
@@ -671,6 +932,34 @@ func TestBuildOptionsForTarget(t *testing.T) {
 				Path: filepath.Join(tmpDir, "testid_linux_amd64_v1", "testbinary"),
 			},
 		},
+		{
+			name: "output",
+			build: config.Build{
+				ID:     "testid",
+				Binary: "testbinary",
+				Targets: []string{
+					"windows_arm64",
+				},
+				Output: "{{ .Os }}/{{ .Arch }}/{{ .Binary }}",
+			},
+			expectedOpts: api.Options{
+				Name: "testbinary.exe",
+				Path: filepath.Join(tmpDir, "windows", "arm64", "testbinary.exe"),
+				Ext:  ".exe",
+			},
+		},
+		{
+			name: "output outside dist",
+			build: config.Build{
+				ID:     "testid",
+				Binary: "testbinary",
+				Targets: []string{
+					"linux_amd64",
+				},
+				Output: "../{{ .Binary }}",
+			},
+			expectedErr: `output must be a relative path within the dist directory, got "../testbinary"`,
+		},
 		{
 			name: "with goarm",
 			build: config.Build{
@@ -735,6 +1024,18 @@ func TestBuildOptionsForTarget(t *testing.T) {
 	}
 }
 
+func TestBuildOptionsForTargetInvalidOutput(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Builds: []config.Build{{
+			Targets: []string{"linux_amd64"},
+			Output:  "{{ .Nope }}",
+		}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	_, err := buildOptionsForTarget(ctx, ctx.Config.Builds[0], ctx.Config.Builds[0].Targets[0])
+	testlib.RequireTemplateError(t, err)
+}
+
 func TestRunHookFailWithLogs(t *testing.T) {
 	testlib.SkipIfWindows(t, "subshells don't work in windows")
 	folder := testlib.Mktmp(t)
@@ -0,0 +1,86 @@
+package build
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// DedupPipe deduplicates the identical binaries of the builds with dedup
+// enabled.
+//
+// It runs after all the pipes that change binaries in place, like upx, binary
+// processors, or signing, so that they never change more than one binary.
+type DedupPipe struct{}
+
+func (DedupPipe) String() string { return "deduplicating binaries" }
+
+func (DedupPipe) Skip(ctx *context.Context) bool {
+	return !slices.ContainsFunc(ctx.Config.Builds, func(build config.Build) bool {
+		return build.Dedup
+	})
+}
+
+// Run the pipe.
+func (DedupPipe) Run(ctx *context.Context) error {
+	return dedup(ctx)
+}
+
+// dedup replaces the binaries of the builds with dedup enabled that are
+// byte-identical to another binary of the same build, e.g. amd64 v1 and v2,
+// with hard links to it.
+func dedup(ctx *context.Context) error {
+	for _, build := range ctx.Config.Builds {
+		if !build.Dedup {
+			continue
+		}
+		bins := ctx.Artifacts.Filter(artifact.And(
+			artifact.ByType(artifact.Binary),
+			artifact.ByIDs(build.ID),
+		)).List()
+		// targets are built concurrently, so sort them to always keep the
+		// same binary.
+		slices.SortFunc(bins, func(a, b *artifact.Artifact) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+
+		seen := map[string]string{}
+		for _, bin := range bins {
+			sum, err := bin.Checksum("sha256")
+			if err != nil {
+				return err
+			}
+			original, ok := seen[sum]
+			if !ok {
+				seen[sum] = bin.Path
+				continue
+			}
+			if err := link(original, bin.Path); err != nil {
+				return fmt.Errorf("could not deduplicate %s: %w", bin.Path, err)
+			}
+			log.WithField("binary", bin.Path).
+				WithField("original", original).
+				Info("binary is identical to another target, linked")
+		}
+	}
+	return nil
+}
+
+// link replaces path with a hard link to original.
+func link(original, path string) error {
+	tmp := path + ".dedup"
+	if err := os.Link(original, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedup(t *testing.T) {
+	dist := t.TempDir()
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: dist,
+		Builds: []config.Build{
+			{ID: "dedup", Dedup: true},
+			{ID: "nodedup"},
+		},
+	})
+
+	add := func(id, target, content string) string {
+		path := filepath.Join(dist, id+"_"+target, "foo")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:   "foo",
+			Path:   path,
+			Target: target,
+			Type:   artifact.Binary,
+			Extra: map[string]any{
+				artifact.ExtraID: id,
+			},
+		})
+		return path
+	}
+
+	v1 := add("dedup", "linux_amd64_v1", "amd64")
+	v2 := add("dedup", "linux_amd64_v2", "amd64")
+	v3 := add("dedup", "linux_amd64_v3", "amd64v3")
+	other1 := add("nodedup", "linux_amd64_v1", "amd64")
+	other2 := add("nodedup", "linux_amd64_v2", "amd64")
+
+	require.False(t, DedupPipe{}.Skip(ctx))
+	require.NoError(t, DedupPipe{}.Run(ctx))
+
+	requireSameFile(t, true, v1, v2)
+	requireSameFile(t, false, v1, v3)
+	requireSameFile(t, false, other1, other2)
+	requireSameFile(t, false, v1, other1)
+
+	bts, err := os.ReadFile(v2)
+	require.NoError(t, err)
+	require.Equal(t, "amd64", string(bts))
+	require.NoFileExists(t, v2+".dedup")
+}
+
+func TestDedupPipeSkip(t *testing.T) {
+	require.NotEmpty(t, DedupPipe{}.String())
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Builds: []config.Build{{ID: "foo"}},
+	})
+	require.True(t, DedupPipe{}.Skip(ctx))
+}
+
+func requireSameFile(tb testing.TB, same bool, a, b string) {
+	tb.Helper()
+	sa, err := os.Stat(a)
+	require.NoError(tb, err)
+	sb, err := os.Stat(b)
+	require.NoError(tb, err)
+	require.Equal(tb, same, os.SameFile(sa, sb))
+}
@@ -0,0 +1,211 @@
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/fileglob"
+	"github.com/goreleaser/go-shellwords"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// generate runs the code generators of the given build, skipping the ones
+// whose inputs did not change since their last successful run, and whose
+// outputs are still the ones it generated.
+func generate(ctx *context.Context, build config.Build) error {
+	for _, gen := range build.Prebuild.Generate {
+		if err := runGenerator(ctx, build, gen); err != nil {
+			return fmt.Errorf("generate failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func runGenerator(ctx *context.Context, build config.Build, gen config.BuildGenerator) error {
+	env := ctx.Env.Strings()
+	var genEnv []string
+	for _, e := range gen.Env {
+		e, err := tmpl.New(ctx).WithEnvS(env).Apply(e)
+		if err != nil {
+			return err
+		}
+		genEnv = append(genEnv, e)
+	}
+	env = append(env, genEnv...)
+
+	tpl := tmpl.New(ctx).WithEnvS(env)
+	sh, err := tpl.Apply(gen.Cmd)
+	if err != nil {
+		return err
+	}
+	dir, err := tpl.Apply(gen.Dir)
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		dir = build.Dir
+	}
+	inputs, err := tpl.Slice(gen.Inputs, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	outputs, err := tpl.Slice(gen.Outputs, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+
+	var stamp string
+	if len(inputs) > 0 {
+		sum, err := inputsHash(dir, inputs, sh, genEnv)
+		if err != nil {
+			return err
+		}
+		cache, err := generateCacheDir(ctx)
+		if err != nil {
+			return err
+		}
+		stamp = filepath.Join(cache, build.ID+"-"+sum)
+		if upToDate(stamp, dir, outputs) {
+			log.WithField("cmd", sh).
+				WithField("build", build.ID).
+				Info("inputs did not change, skipping generate")
+			return nil
+		}
+	}
+
+	log.WithField("cmd", sh).
+		WithField("build", build.ID).
+		Info("generating")
+	cmd, err := shellwords.Parse(sh)
+	if err != nil {
+		return err
+	}
+	if err := shell.Run(ctx, dir, cmd, env, gen.Output); err != nil {
+		return err
+	}
+
+	if stamp == "" {
+		return nil
+	}
+	sum, err := outputsHash(dir, outputs)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(stamp), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(stamp, []byte(sum+"\n"), 0o644)
+}
+
+// upToDate reports whether the given stamp exists, and the outputs still have
+// the contents recorded in it.
+func upToDate(stamp, dir string, outputs []string) bool {
+	recorded, err := os.ReadFile(stamp)
+	if err != nil {
+		return false
+	}
+	sum, err := outputsHash(dir, outputs)
+	return err == nil && strings.TrimSpace(string(recorded)) == sum
+}
+
+// generateCacheDir returns the directory in which the generate stamps are
+// kept: inside the build cache, if set, or inside the dist directory.
+func generateCacheDir(ctx *context.Context) (string, error) {
+	dir, err := tmpl.New(ctx).Apply(ctx.Config.BuildCache.Dir)
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		dir = ctx.Config.Dist
+	}
+	return filepath.Abs(filepath.Join(dir, "generate"))
+}
+
+// inputsHash hashes the command, its env, and the contents of all the files
+// matching the given globs.
+func inputsHash(dir string, globs []string, cmd string, env []string) (string, error) {
+	var files []string
+	for _, glob := range globs {
+		matches, err := globFiles(dir, glob)
+		if err != nil {
+			return "", err
+		}
+		files = append(files, matches...)
+	}
+	slices.Sort(files)
+	files = slices.Compact(files)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", cmd)
+	for _, e := range env {
+		fmt.Fprintf(h, "%s\x00", e)
+	}
+	for _, name := range files {
+		if err := hashFile(h, name); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// outputsHash hashes the contents of all the files matching the given globs,
+// failing if any of them matches nothing.
+func outputsHash(dir string, globs []string) (string, error) {
+	h := sha256.New()
+	for _, glob := range globs {
+		files, err := globFiles(dir, glob)
+		if err != nil {
+			return "", err
+		}
+		if len(files) == 0 {
+			return "", fmt.Errorf("no outputs matching %s", glob)
+		}
+		slices.Sort(files)
+		for _, name := range files {
+			if err := hashFile(h, name); err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// globFiles returns the files matching the given glob, relative to dir.
+func globFiles(dir, glob string) ([]string, error) {
+	if dir != "" && !filepath.IsAbs(glob) {
+		glob = filepath.Join(dir, glob)
+	}
+	matches, err := fileglob.Glob(glob)
+	if err != nil {
+		return nil, fmt.Errorf("globbing failed for pattern %s: %w", glob, err)
+	}
+	return matches, nil
+}
+
+func hashFile(w io.Writer, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if st.IsDir() {
+		return nil
+	}
+	fmt.Fprintf(w, "%s\x00", filepath.ToSlash(name))
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,227 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "api.proto"), []byte("v1"), 0o644))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: filepath.Join(folder, "dist"),
+		Builds: []config.Build{
+			{
+				ID:      "foo",
+				Builder: "fake",
+				Binary:  "foo",
+				Targets: []string{"linux_amd64", "darwin_arm64", "windows_amd64"},
+				Prebuild: config.BuildPrebuild{
+					Generate: []config.BuildGenerator{
+						{
+							Cmd:    testlib.Touch("generated"),
+							Inputs: []string{"*.proto"},
+						},
+					},
+				},
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+
+	generated := filepath.Join(folder, "generated")
+
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.FileExists(t, generated)
+	require.Len(t, ctx.Artifacts.List(), 3)
+
+	// inputs did not change, so it should not run again.
+	require.NoError(t, os.Remove(generated))
+	require.NoError(t, generate(ctx, ctx.Config.Builds[0]))
+	require.NoFileExists(t, generated)
+
+	// inputs changed, so it should run again.
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "api.proto"), []byte("v2"), 0o644))
+	require.NoError(t, generate(ctx, ctx.Config.Builds[0]))
+	require.FileExists(t, generated)
+}
+
+func TestGenerateOutputs(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "api.proto"), []byte("v1"), 0o644))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: filepath.Join(folder, "dist"),
+	})
+	build := config.Build{
+		ID: "foo",
+		Prebuild: config.BuildPrebuild{
+			Generate: []config.BuildGenerator{
+				{
+					Cmd:     testlib.Touch("generated"),
+					Inputs:  []string{"*.proto"},
+					Outputs: []string{"generated"},
+				},
+			},
+		},
+	}
+
+	generated := filepath.Join(folder, "generated")
+	require.NoError(t, generate(ctx, build))
+	require.FileExists(t, generated)
+
+	// outputs were removed, so it should run again.
+	require.NoError(t, os.Remove(generated))
+	require.NoError(t, generate(ctx, build))
+	require.FileExists(t, generated)
+
+	stamps, err := filepath.Glob(filepath.Join(folder, "dist", "generate", "foo-*"))
+	require.NoError(t, err)
+	require.Len(t, stamps, 1)
+	require.True(t, upToDate(stamps[0], "", []string{"generated"}))
+
+	// outputs changed, so it should run again.
+	require.NoError(t, os.WriteFile(generated, []byte("changed"), 0o644))
+	require.False(t, upToDate(stamps[0], "", []string{"generated"}))
+}
+
+func TestGenerateMissingOutputs(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "api.proto"), []byte("v1"), 0o644))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: filepath.Join(folder, "dist"),
+	})
+	build := config.Build{
+		ID: "foo",
+		Prebuild: config.BuildPrebuild{
+			Generate: []config.BuildGenerator{
+				{
+					Cmd:     testlib.Touch("generated"),
+					Inputs:  []string{"*.proto"},
+					Outputs: []string{"*.pb.go"},
+				},
+			},
+		},
+	}
+	require.ErrorContains(t, generate(ctx, build), "no outputs matching *.pb.go")
+}
+
+func TestGenerateNoInputs(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: filepath.Join(folder, "dist"),
+	})
+	build := config.Build{
+		ID: "foo",
+		Prebuild: config.BuildPrebuild{
+			Generate: []config.BuildGenerator{
+				{Cmd: testlib.Touch("generated")},
+			},
+		},
+	}
+
+	generated := filepath.Join(folder, "generated")
+	require.NoError(t, generate(ctx, build))
+	require.FileExists(t, generated)
+
+	// without inputs, it always runs.
+	require.NoError(t, os.Remove(generated))
+	require.NoError(t, generate(ctx, build))
+	require.FileExists(t, generated)
+	require.NoDirExists(t, filepath.Join(folder, "dist", "generate"))
+}
+
+func TestGenerateBuildCacheDir(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "api.proto"), []byte("v1"), 0o644))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: filepath.Join(folder, "dist"),
+		BuildCache: config.BuildCache{
+			Dir: filepath.Join(folder, "cache"),
+		},
+	})
+	build := config.Build{
+		ID: "foo",
+		Prebuild: config.BuildPrebuild{
+			Generate: []config.BuildGenerator{
+				{
+					Cmd:    testlib.Touch("generated"),
+					Inputs: []string{"*.proto"},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, generate(ctx, build))
+	entries, err := os.ReadDir(filepath.Join(folder, "cache", "generate"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestGenerateFail(t *testing.T) {
+	testlib.Mktmp(t)
+	ctx := testctx.Wrap(t.Context())
+	build := config.Build{
+		ID: "foo",
+		Prebuild: config.BuildPrebuild{
+			Generate: []config.BuildGenerator{
+				{Cmd: "exit 1"},
+			},
+		},
+	}
+	require.ErrorContains(t, generate(ctx, build), "generate failed")
+}
+
+func TestGenerateInvalidTemplate(t *testing.T) {
+	testlib.Mktmp(t)
+	ctx := testctx.Wrap(t.Context())
+	for name, gen := range map[string]config.BuildGenerator{
+		"cmd":    {Cmd: "{{ .Nope }}"},
+		"dir":    {Cmd: "true", Dir: "{{ .Nope }}"},
+		"env":    {Cmd: "true", Env: []string{"FOO={{ .Nope }}"}},
+		"inputs": {Cmd: "true", Inputs: []string{"{{ .Nope }}"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := generate(ctx, config.Build{
+				Prebuild: config.BuildPrebuild{
+					Generate: []config.BuildGenerator{gen},
+				},
+			})
+			testlib.RequireTemplateError(t, err)
+		})
+	}
+}
+
+func TestDefaultGenerate(t *testing.T) {
+	t.Run("go", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Builds: []config.Build{
+				{
+					Prebuild: config.BuildPrebuild{
+						Generate: []config.BuildGenerator{{}},
+					},
+				},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "go generate ./...", ctx.Config.Builds[0].Prebuild.Generate[0].Cmd)
+	})
+
+	t.Run("other builders", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Builds: []config.Build{
+				{
+					Builder: "fake",
+					Prebuild: config.BuildPrebuild{
+						Generate: []config.BuildGenerator{{}},
+					},
+				},
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "prebuild.generate.cmd is required for fake builds")
+	})
+}
@@ -0,0 +1,101 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	builders "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+var errNotReproducible = errors.New("build is not reproducible")
+
+// normalizeForReproducible sets the environment and mod times so both builds
+// of a target are done under the same conditions.
+func normalizeForReproducible(ctx *context.Context) {
+	if _, ok := ctx.Env["SOURCE_DATE_EPOCH"]; !ok {
+		ctx.Env["SOURCE_DATE_EPOCH"] = strconv.FormatInt(ctx.Git.CommitDate.Unix(), 10)
+	}
+	for i, build := range ctx.Config.Builds {
+		if build.ModTimestamp == "" {
+			ctx.Config.Builds[i].ModTimestamp = "{{ .CommitTimestamp }}"
+		}
+	}
+}
+
+// verifyReproducible builds the given target once more in a temporary
+// directory, and errors if the result differs from the binary at opts.Path.
+func verifyReproducible(ctx *context.Context, build config.Build, opts builders.Options) error {
+	dir, err := os.MkdirTemp("", "goreleaser-reproducible-*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	second := opts
+	second.Path = filepath.Join(dir, opts.Name)
+	if err := os.MkdirAll(filepath.Dir(second.Path), 0o755); err != nil {
+		return fmt.Errorf("create target directory: %w", err)
+	}
+
+	// the second build must not add artifacts to the actual context.
+	sctx := *ctx
+	sctx.Artifacts = artifact.New()
+
+	log.WithField("binary", opts.Name).
+		WithField("target", opts.Target.String()).
+		Info("building again to verify reproducibility")
+	if err := doBuild(&sctx, build, second); err != nil {
+		return fmt.Errorf("second build failed: %w", err)
+	}
+
+	want, err := (&artifact.Artifact{Path: opts.Path}).Checksum("sha256")
+	if err != nil {
+		return err
+	}
+	got, err := (&artifact.Artifact{Path: second.Path}).Checksum("sha256")
+	if err != nil {
+		return err
+	}
+	if want == got {
+		return nil
+	}
+
+	details := []any{"first", want, "second", got}
+	if s := suspectFlags(build); len(s) > 0 {
+		details = append(details, "suspects", strings.Join(s, ", "))
+	}
+	return gerrors.Wrap(
+		errNotReproducible,
+		gerrors.WithMessage("make sure flags, ldflags and env do not depend on the current time or build path"),
+		gerrors.WithDetails(details...),
+	)
+}
+
+// suspectFlags returns the flags of the given build that change on every
+// build, and are thus likely to break reproducibility.
+func suspectFlags(build config.Build) []string {
+	var result []string
+	for _, values := range [][]string{
+		build.Flags,
+		build.Ldflags,
+		build.Asmflags,
+		build.Gcflags,
+		build.Env,
+	} {
+		for _, v := range values {
+			if strings.Contains(v, ".Now") {
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package build
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPipeVerifyReproducible(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: folder,
+		Builds: []config.Build{
+			{
+				ID:      "foo",
+				Builder: "fake",
+				Binary:  "bin/testing",
+				Targets: []string{"linux_amd64", "darwin_arm64"},
+			},
+		},
+	}, testctx.WithGitInfo(context.GitInfo{
+		CurrentTag: "v2.4.5",
+		CommitDate: time.Unix(1700000000, 0),
+	}))
+	ctx.VerifyReproducible = true
+	delete(ctx.Env, "SOURCE_DATE_EPOCH")
+
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Len(t, ctx.Artifacts.List(), 2)
+	require.Equal(t, "1700000000", ctx.Env["SOURCE_DATE_EPOCH"])
+	require.Equal(t, "{{ .CommitTimestamp }}", ctx.Config.Builds[0].ModTimestamp)
+	require.FileExists(t, filepath.Join(folder, "foo_linux_amd64", "bin", "testing"))
+}
+
+func TestNormalizeForReproducible(t *testing.T) {
+	testlib.Mktmp(t)
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Builds: []config.Build{
+			{
+				Builder:      "fake",
+				ModTimestamp: "1",
+			},
+		},
+	}, testctx.WithEnv(map[string]string{"SOURCE_DATE_EPOCH": "10"}))
+	normalizeForReproducible(ctx)
+	require.Equal(t, "10", ctx.Env["SOURCE_DATE_EPOCH"])
+	require.Equal(t, "1", ctx.Config.Builds[0].ModTimestamp)
+}
+
+func TestRunPipeNotReproducible(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: folder,
+		Builds: []config.Build{
+			{
+				Builder: "fakeRandom",
+				Binary:  "testing",
+				BuildDetails: config.BuildDetails{
+					Flags:   []string{"-v"},
+					Ldflags: []string{"-X main.date={{ .Now.Format \"2006\" }}"},
+				},
+				Targets: []string{"linux_amd64"},
+			},
+		},
+	}, testctx.WithCurrentTag("2.4.5"))
+	ctx.VerifyReproducible = true
+
+	err := Pipe{}.Run(ctx)
+	require.ErrorIs(t, err, errNotReproducible)
+
+	var de gerrors.ErrDetailed
+	require.True(t, errors.As(err, &de))
+	details := map[string]any{}
+	for k, v := range de.Details() {
+		details[k] = v
+	}
+	require.Equal(t, "linux_amd64", details["target"])
+	require.Equal(t, `-X main.date={{ .Now.Format "2006" }}`, details["suspects"])
+	require.NotEqual(t, details["first"], details["second"])
+
+	// only the first build is registered as an artifact.
+	require.Equal(t, []*artifact.Artifact{{
+		Name: "testing",
+	}}, ctx.Artifacts.List())
+}
+
+func TestSuspectFlags(t *testing.T) {
+	require.Empty(t, suspectFlags(config.Build{
+		BuildDetails: config.BuildDetails{
+			Ldflags: []string{"-s -w -X main.date={{ .Date }}"},
+		},
+	}))
+	require.Equal(t, []string{"-X main.date={{.Now}}", "FOO={{ .Now.Unix }}"}, suspectFlags(config.Build{
+		BuildDetails: config.BuildDetails{
+			Ldflags: []string{"-s -w", "-X main.date={{.Now}}"},
+			Env:     []string{"FOO={{ .Now.Unix }}"},
+		},
+	}))
+}
@@ -0,0 +1,92 @@
+// Package buildcache sets up the Go caches shared by all builds and hooks.
+package buildcache
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Pipe that sets up the build cache.
+type Pipe struct{}
+
+func (Pipe) String() string { return "setting up build cache" }
+
+func (Pipe) Skip(ctx *context.Context) bool {
+	cache := ctx.Config.BuildCache
+	return cache.Dir == "" && len(cache.Goflags) == 0
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	cache := ctx.Config.BuildCache
+	t := tmpl.New(ctx)
+
+	goflags, err := t.Slice(cache.Goflags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	if len(goflags) > 0 {
+		if current := ctx.Env["GOFLAGS"]; current != "" {
+			goflags = append([]string{current}, goflags...)
+		}
+		ctx.Env["GOFLAGS"] = strings.Join(goflags, " ")
+	}
+
+	dir, err := t.Apply(cache.Dir)
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		return nil
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	if ctx.CleanCache {
+		log.WithField("dir", dir).Info("cleaning")
+		if err := clean(dir); err != nil {
+			return fmt.Errorf("could not clean build cache: %w", err)
+		}
+	}
+
+	ctx.Env["GOCACHE"] = filepath.Join(dir, "go-build")
+	ctx.Env["GOMODCACHE"] = filepath.Join(dir, "mod")
+	for _, d := range []string{ctx.Env["GOCACHE"], ctx.Env["GOMODCACHE"]} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return fmt.Errorf("could not create build cache: %w", err)
+		}
+	}
+
+	log.WithField("gocache", ctx.Env["GOCACHE"]).
+		WithField("gomodcache", ctx.Env["GOMODCACHE"]).
+		Info("using build cache")
+	return nil
+}
+
+// clean removes the given dir.
+// The go module cache is read-only, so it needs to be made writable first.
+func clean(dir string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.Chmod(path, 0o755)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
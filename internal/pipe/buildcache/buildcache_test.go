@@ -0,0 +1,81 @@
+package buildcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BuildCache: config.BuildCache{Goflags: []string{"-trimpath"}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestRun(t *testing.T) {
+	dir := testlib.Mktmp(t)
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		BuildCache: config.BuildCache{
+			Dir:     ".cache/{{ .ProjectName }}",
+			Goflags: []string{"-trimpath", "", "-mod={{ .Env.MOD }}"},
+		},
+	}, testctx.WithEnv(map[string]string{
+		"GOFLAGS": "-buildvcs=false",
+		"MOD":     "readonly",
+	}))
+
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "-buildvcs=false -trimpath -mod=readonly", ctx.Env["GOFLAGS"])
+	require.Equal(t, filepath.Join(dir, ".cache", "foo", "go-build"), ctx.Env["GOCACHE"])
+	require.Equal(t, filepath.Join(dir, ".cache", "foo", "mod"), ctx.Env["GOMODCACHE"])
+	require.DirExists(t, ctx.Env["GOCACHE"])
+	require.DirExists(t, ctx.Env["GOMODCACHE"])
+}
+
+func TestRunClean(t *testing.T) {
+	dir := testlib.Mktmp(t)
+	ro := filepath.Join(dir, "cache", "mod", "example.com", "foo@v1.0.0")
+	require.NoError(t, os.MkdirAll(ro, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(ro, "go.mod"), []byte("module foo"), 0o444))
+	require.NoError(t, os.Chmod(ro, 0o555))
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		BuildCache: config.BuildCache{Dir: "cache"},
+	})
+	ctx.CleanCache = true
+
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.NoDirExists(t, ro)
+	require.DirExists(t, ctx.Env["GOMODCACHE"])
+}
+
+func TestRunInvalidTemplate(t *testing.T) {
+	for name, cache := range map[string]config.BuildCache{
+		"dir":     {Dir: "{{ .Nope }}"},
+		"goflags": {Goflags: []string{"{{ .Nope }}"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				BuildCache: cache,
+			})
+			testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+		})
+	}
+}
@@ -0,0 +1,282 @@
+// Package buildpacks builds container images out of the built binaries using
+// Cloud Native Buildpacks, through the pack CLI.
+package buildpacks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const defaultBuilder = "paketobuildpacks/builder-jammy-base"
+
+var (
+	errNoImages = errors.New("buildpacks: images are required")
+
+	// digestPattern matches the digest pack prints after publishing, e.g.
+	// '*** Images (sha256:abc...):'.
+	digestPattern = regexp.MustCompile(`Images \((sha256:[a-f0-9]{64})\)`)
+)
+
+// cmd represents a command executor.
+var cmd execx.Cmder = execx.Std{}
+
+// Pipe for buildpacks images.
+type Pipe struct{}
+
+func (Pipe) String() string { return "buildpacks images" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.Buildpacks) || len(ctx.Config.Buildpacks) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(*context.Context) []string { return []string{"pack"} }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("buildpacks")
+	for i := range ctx.Config.Buildpacks {
+		bp := &ctx.Config.Buildpacks[i]
+		if len(bp.Images) == 0 {
+			return errNoImages
+		}
+		if bp.ID == "" {
+			bp.ID = ctx.Config.ProjectName
+		}
+		if bp.Goarch == "" {
+			bp.Goarch = "amd64"
+		}
+		if bp.Goamd64 == "" {
+			bp.Goamd64 = "v1"
+		}
+		if bp.Goarm == "" {
+			bp.Goarm = "7"
+		}
+		if len(bp.Tags) == 0 {
+			bp.Tags = []string{"{{ .Tag }}"}
+		}
+		if bp.Builder == "" {
+			bp.Builder = defaultBuilder
+		}
+		ids.Inc(bp.ID)
+	}
+	return ids.Validate()
+}
+
+// Run builds the images locally when running in snapshot mode.
+//
+// Otherwise, images are built and pushed in the publishing phase, as pack
+// can't push an image it built before.
+func (Pipe) Run(ctx *context.Context) error {
+	if !ctx.Snapshot {
+		return nil
+	}
+	return runAll(ctx, false)
+}
+
+// Publish builds and pushes the images.
+func (Pipe) Publish(ctx *context.Context) error {
+	return runAll(ctx, true)
+}
+
+func runAll(ctx *context.Context, publish bool) error {
+	skips := pipe.SkipMemento{}
+	for _, bp := range ctx.Config.Buildpacks {
+		err := doRun(ctx, bp, publish)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doRun(ctx *context.Context, bp config.Buildpack, publish bool) error {
+	tp := tmpl.New(ctx)
+	disable, err := tp.Bool(bp.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("configuration is disabled")
+	}
+	if publish {
+		skipPush, err := tp.Bool(bp.SkipPush)
+		if err != nil {
+			return err
+		}
+		publish = !skipPush
+	}
+
+	binaries := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.Binary),
+		artifact.ByGoos("linux"),
+		artifact.ByGoarch(bp.Goarch),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("amd64")),
+			artifact.ByGoamd64(bp.Goamd64),
+		),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("arm")),
+			artifact.ByGoarm(bp.Goarm),
+		),
+		artifact.ByIDs(bp.IDs...),
+	)).List()
+	if len(binaries) == 0 {
+		return pipe.Skipf("no linux/%s binaries found for buildpacks %q", bp.Goarch, bp.ID)
+	}
+
+	images, err := imageNames(ctx, bp)
+	if err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		return pipe.Skip("no images to build")
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "buildpacks", bp.ID)
+	if err := stage(ctx, bp, dir, binaries); err != nil {
+		return err
+	}
+
+	args, err := buildArgs(ctx, bp, dir, images, publish)
+	if err != nil {
+		return err
+	}
+
+	log.WithField("id", bp.ID).
+		WithField("images", strings.Join(images, "\n")).
+		Info("building images")
+	out, err := cmd.Exec(ctx, "pack", args...)
+	if err != nil {
+		return fmt.Errorf("buildpacks: failed to build %s: %w: %s", images[0], err, string(out))
+	}
+	if !publish {
+		return nil
+	}
+
+	match := digestPattern.FindStringSubmatch(string(out))
+	if match == nil {
+		return fmt.Errorf("buildpacks: failed to find digest in pack output: %s", string(out))
+	}
+	for _, img := range images {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: img,
+			Path: img,
+			Type: artifact.DockerImageV2,
+			Extra: map[string]any{
+				artifact.ExtraID:     bp.ID,
+				artifact.ExtraDigest: match[1],
+			},
+		})
+	}
+	return nil
+}
+
+// imageNames returns all the combinations of the given images and tags.
+func imageNames(ctx *context.Context, bp config.Buildpack) ([]string, error) {
+	tp := tmpl.New(ctx)
+	if err := tp.ApplySlice(&bp.Images, tmpl.NonEmpty()); err != nil {
+		return nil, err
+	}
+	if err := tp.ApplySlice(&bp.Tags, tmpl.NonEmpty()); err != nil {
+		return nil, err
+	}
+	var images []string
+	for _, img := range bp.Images {
+		for _, tag := range bp.Tags {
+			images = append(images, img+":"+tag)
+		}
+	}
+	return images, nil
+}
+
+// stage copies the binaries and extra files into the given directory, which
+// is used as the application directory, along with a Procfile declaring the
+// command to run.
+func stage(ctx *context.Context, bp config.Buildpack, dir string, binaries []*artifact.Artifact) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, bin := range binaries {
+		if err := gio.Copy(bin.Path, filepath.Join(dir, bin.Name)); err != nil {
+			return fmt.Errorf("buildpacks: failed to copy binary: %w", err)
+		}
+	}
+	for _, file := range bp.ExtraFiles {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), 0o755); err != nil {
+			return fmt.Errorf("buildpacks: failed to copy extra file '%s': %w", file, err)
+		}
+		if err := gio.Copy(file, filepath.Join(dir, file)); err != nil {
+			return fmt.Errorf("buildpacks: failed to copy extra file '%s': %w", file, err)
+		}
+	}
+
+	command := "./" + binaries[0].Name
+	if bp.Command != "" {
+		var err error
+		command, err = tmpl.New(ctx).Apply(bp.Command)
+		if err != nil {
+			return err
+		}
+	}
+	procfile := "web: " + command + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "Procfile"), []byte(procfile), 0o644); err != nil {
+		return fmt.Errorf("buildpacks: failed to write Procfile: %w", err)
+	}
+	return nil
+}
+
+func buildArgs(ctx *context.Context, bp config.Buildpack, dir string, images []string, publish bool) ([]string, error) {
+	tp := tmpl.New(ctx)
+	builder, err := tp.Apply(bp.Builder)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{
+		"build", images[0],
+		"--builder", builder,
+		"--path", dir,
+		"--platform", "linux/" + bp.Goarch,
+	}
+	for _, img := range images[1:] {
+		args = append(args, "--tag", img)
+	}
+	for _, buildpack := range bp.Buildpacks {
+		args = append(args, "--buildpack", buildpack)
+	}
+	if err := tp.ApplySlice(&bp.Env, tmpl.NonEmpty()); err != nil {
+		return nil, err
+	}
+	for _, env := range bp.Env {
+		args = append(args, "--env", env)
+	}
+	if err := tp.ApplySlice(&bp.Flags, tmpl.NonEmpty()); err != nil {
+		return nil, err
+	}
+	args = append(args, bp.Flags...)
+	if publish {
+		args = append(args, "--publish")
+	}
+	return args, nil
+}
@@ -0,0 +1,301 @@
+package buildpacks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeDigest = "sha256:2a6ab3b1c7a4b6f5e4b0a8a4e19b7cbb1d0e0f6f0b0e5f8b9d1a2c3d4e5f6a7b"
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestDependencies(t *testing.T) {
+	require.Equal(t, []string{"pack"}, Pipe{}.Dependencies(nil))
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Buildpacks: []config.Buildpack{{}},
+		}, testctx.Skip(skips.Buildpacks))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Buildpacks: []config.Buildpack{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			Buildpacks:  []config.Buildpack{{Images: []string{"ghcr.io/foo/bar"}}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		bp := ctx.Config.Buildpacks[0]
+		require.Equal(t, "foo", bp.ID)
+		require.Equal(t, "amd64", bp.Goarch)
+		require.Equal(t, "v1", bp.Goamd64)
+		require.Equal(t, "7", bp.Goarm)
+		require.Equal(t, []string{"{{ .Tag }}"}, bp.Tags)
+		require.Equal(t, defaultBuilder, bp.Builder)
+	})
+	t.Run("no images", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			Buildpacks:  []config.Buildpack{{}},
+		})
+		require.ErrorIs(t, Pipe{}.Default(ctx), errNoImages)
+	})
+	t.Run("duplicated ids", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			Buildpacks: []config.Buildpack{
+				{Images: []string{"foo"}},
+				{Images: []string{"bar"}},
+			},
+		})
+		require.ErrorContains(t, Pipe{}.Default(ctx), "found 2 buildpacks with the ID 'foo'")
+	})
+}
+
+func TestRunNotSnapshot(t *testing.T) {
+	fakePack(t, func([]string) ([]byte, error) {
+		t.Fatal("should not run pack")
+		return nil, nil
+	})
+	ctx := makeContext(t, config.Buildpack{Images: []string{"ghcr.io/foo/bar"}})
+	require.NoError(t, Pipe{}.Run(ctx))
+}
+
+func TestRunSnapshot(t *testing.T) {
+	var calls [][]string
+	fakePack(t, func(args []string) ([]byte, error) {
+		calls = append(calls, args)
+		return []byte("Successfully built image"), nil
+	})
+	ctx := makeContext(t, config.Buildpack{
+		Images:     []string{"ghcr.io/foo/bar"},
+		Tags:       []string{"latest"},
+		ExtraFiles: []string{"testdata/extra.txt"},
+	})
+	ctx.Snapshot = true
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	dir := filepath.Join(ctx.Config.Dist, "buildpacks", "foo")
+	require.Equal(t, [][]string{{
+		"build", "ghcr.io/foo/bar:latest",
+		"--builder", defaultBuilder,
+		"--path", dir,
+		"--platform", "linux/amd64",
+	}}, calls)
+	require.FileExists(t, filepath.Join(dir, "foo"))
+	require.FileExists(t, filepath.Join(dir, "testdata", "extra.txt"))
+	procfile, err := os.ReadFile(filepath.Join(dir, "Procfile"))
+	require.NoError(t, err)
+	require.Equal(t, "web: ./foo\n", string(procfile))
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.DockerImageV2)).List())
+}
+
+func TestPublish(t *testing.T) {
+	var calls [][]string
+	fakePack(t, func(args []string) ([]byte, error) {
+		calls = append(calls, args)
+		return []byte("*** Images (" + fakeDigest + "):\n      ghcr.io/foo/bar:v1.2.3\n"), nil
+	})
+	ctx := makeContext(t, config.Buildpack{
+		Goarch:     "arm64",
+		Images:     []string{"ghcr.io/foo/bar", "docker.io/foo/bar"},
+		Tags:       []string{"{{ .Tag }}", "{{ if .IsSnapshot }}snapshot{{ end }}", "latest"},
+		Builder:    "paketobuildpacks/builder-jammy-tiny",
+		Buildpacks: []string{"paketo-buildpacks/procfile"},
+		Env:        []string{"BP_FOO={{ .Version }}"},
+		Command:    "./{{ .ProjectName }} serve",
+		Flags:      []string{"--pull-policy=if-not-present"},
+	})
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	dir := filepath.Join(ctx.Config.Dist, "buildpacks", "foo")
+	require.Equal(t, [][]string{{
+		"build", "ghcr.io/foo/bar:v1.2.3",
+		"--builder", "paketobuildpacks/builder-jammy-tiny",
+		"--path", dir,
+		"--platform", "linux/arm64",
+		"--tag", "ghcr.io/foo/bar:latest",
+		"--tag", "docker.io/foo/bar:v1.2.3",
+		"--tag", "docker.io/foo/bar:latest",
+		"--buildpack", "paketo-buildpacks/procfile",
+		"--env", "BP_FOO=1.2.3",
+		"--pull-policy=if-not-present",
+		"--publish",
+	}}, calls)
+	procfile, err := os.ReadFile(filepath.Join(dir, "Procfile"))
+	require.NoError(t, err)
+	require.Equal(t, "web: ./foo serve\n", string(procfile))
+
+	images := ctx.Artifacts.Filter(artifact.ByType(artifact.DockerImageV2)).List()
+	require.Len(t, images, 4)
+	for _, img := range images {
+		require.Equal(t, "foo", artifact.MustExtra[string](*img, artifact.ExtraID))
+		require.Equal(t, fakeDigest, artifact.MustExtra[string](*img, artifact.ExtraDigest))
+	}
+}
+
+func TestPublishSkipPush(t *testing.T) {
+	var calls [][]string
+	fakePack(t, func(args []string) ([]byte, error) {
+		calls = append(calls, args)
+		return []byte("Successfully built image"), nil
+	})
+	ctx := makeContext(t, config.Buildpack{
+		Images:   []string{"ghcr.io/foo/bar"},
+		SkipPush: "true",
+	})
+	require.NoError(t, Pipe{}.Publish(ctx))
+	require.Len(t, calls, 1)
+	require.NotContains(t, calls[0], "--publish")
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.DockerImageV2)).List())
+}
+
+func TestPublishErrors(t *testing.T) {
+	t.Run("pack fails", func(t *testing.T) {
+		fakePack(t, func([]string) ([]byte, error) {
+			return []byte("ERROR: failed to build"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.Buildpack{Images: []string{"ghcr.io/foo/bar"}})
+		err := Pipe{}.Publish(ctx)
+		require.ErrorContains(t, err, "exit status 1")
+		require.ErrorContains(t, err, "ERROR: failed to build")
+	})
+	t.Run("no digest", func(t *testing.T) {
+		fakePack(t, func([]string) ([]byte, error) {
+			return []byte("Successfully built image"), nil
+		})
+		ctx := makeContext(t, config.Buildpack{Images: []string{"ghcr.io/foo/bar"}})
+		require.ErrorContains(t, Pipe{}.Publish(ctx), "failed to find digest")
+	})
+	t.Run("no binaries", func(t *testing.T) {
+		ctx := makeContext(t, config.Buildpack{
+			Images: []string{"ghcr.io/foo/bar"},
+			IDs:    []string{"nope"},
+		})
+		testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		ctx := makeContext(t, config.Buildpack{
+			Images:  []string{"ghcr.io/foo/bar"},
+			Disable: "true",
+		})
+		testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+	})
+	for name, bp := range map[string]config.Buildpack{
+		"disable":   {Disable: "{{ .Nope }}"},
+		"skip push": {SkipPush: "{{ .Nope }}"},
+		"images":    {Images: []string{"{{ .Nope }}"}},
+		"tags":      {Tags: []string{"{{ .Nope }}"}},
+		"command":   {Command: "{{ .Nope }}"},
+		"builder":   {Builder: "{{ .Nope }}"},
+		"env":       {Env: []string{"{{ .Nope }}"}},
+		"flags":     {Flags: []string{"{{ .Nope }}"}},
+	} {
+		t.Run("bad "+name+" template", func(t *testing.T) {
+			if len(bp.Images) == 0 {
+				bp.Images = []string{"ghcr.io/foo/bar"}
+			}
+			ctx := makeContext(t, bp)
+			testlib.RequireTemplateError(t, Pipe{}.Publish(ctx))
+		})
+	}
+}
+
+func fakePack(tb testing.TB, fn func(args []string) ([]byte, error)) {
+	tb.Helper()
+	cmd = fakeCmd{execFn: func(name string, args ...string) ([]byte, error) {
+		require.Equal(tb, "pack", name)
+		return fn(args)
+	}}
+	tb.Cleanup(func() {
+		cmd = execx.Std{}
+	})
+}
+
+func makeContext(tb testing.TB, bp config.Buildpack) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		Dist:        tb.TempDir(),
+		ProjectName: "foo",
+		Buildpacks:  []config.Buildpack{bp},
+	},
+		testctx.WithVersion("1.2.3"),
+		testctx.WithCurrentTag("v1.2.3"),
+	)
+	require.NoError(tb, Pipe{}.Default(ctx))
+
+	bin := filepath.Join(tb.TempDir(), "foo")
+	require.NoError(tb, os.WriteFile(bin, []byte("fake bin"), 0o755))
+	for _, art := range []*artifact.Artifact{
+		{
+			Name:    "foo",
+			Path:    bin,
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+		},
+		{
+			Name:    "foo",
+			Path:    bin,
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v3",
+			Type:    artifact.Binary,
+		},
+		{
+			Name:   "foo",
+			Path:   bin,
+			Goos:   "linux",
+			Goarch: "arm64",
+			Type:   artifact.Binary,
+		},
+		{
+			Name:   "foo",
+			Path:   bin,
+			Goos:   "darwin",
+			Goarch: "arm64",
+			Type:   artifact.Binary,
+		},
+	} {
+		art.Extra = map[string]any{artifact.ExtraID: "foo"}
+		ctx.Artifacts.Add(art)
+	}
+	return ctx
+}
+
+type fakeCmd struct {
+	execFn func(cmd string, args ...string) ([]byte, error)
+}
+
+var _ execx.Cmder = fakeCmd{}
+
+func (f fakeCmd) Exec(_ *context.Context, cmd string, args ...string) ([]byte, error) {
+	return f.execFn(cmd, args...)
+}
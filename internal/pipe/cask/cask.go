@@ -72,10 +72,11 @@ func (Pipe) Default(ctx *context.Context) error {
 			deprecate.Notice(ctx, "homebrew_casks.binary")
 			brew.Binaries = append(brew.Binaries, brew.Binary)
 		}
-		if len(brew.Binaries) == 0 || brew.Binaries[0] == "" {
+		// apps usually do not need binaries.
+		if brew.App == "" && (len(brew.Binaries) == 0 || brew.Binaries[0] == "") {
 			brew.Binaries = []string{brew.Name}
 		}
-		if isGenerateCompletionsConfigured(brew.GenerateCompletionsFromExecutable) && brew.GenerateCompletionsFromExecutable.Executable == "" {
+		if len(brew.Binaries) > 0 && isGenerateCompletionsConfigured(brew.GenerateCompletionsFromExecutable) && brew.GenerateCompletionsFromExecutable.Executable == "" {
 			brew.GenerateCompletionsFromExecutable.Executable = brew.Binaries[0]
 		}
 		if brew.Manpage != "" {
@@ -206,7 +207,7 @@ func doPublish(ctx *context.Context, cask *artifact.Artifact, cl client.Client)
 		return errors.New("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, msg, brew.Repository.PullRequest.Draft)
+	return pcl.OpenPullRequest(ctx, base, repo, msg, brew.Repository.PullRequest.Body, brew.Repository.PullRequest.Draft)
 }
 
 func doRun(ctx *context.Context, brew config.HomebrewCask, cl client.ReleaseURLTemplater) error {
@@ -217,13 +218,7 @@ func doRun(ctx *context.Context, brew config.HomebrewCask, cl client.ReleaseURLT
 	filters := []artifact.Filter{
 		artifact.ByGooses("darwin", "linux"),
 		artifact.ByGoarches("amd64", "arm64", "all"),
-		artifact.Or(
-			artifact.And(
-				artifact.Not(artifact.ByFormats("gz")),
-				artifact.ByType(artifact.UploadableArchive),
-			),
-			artifact.ByType(artifact.UploadableBinary),
-		),
+		packagesFilter(brew.App != ""),
 		artifact.OnlyReplacingUnibins,
 	}
 	if len(brew.IDs) > 0 {
@@ -239,6 +234,7 @@ func doRun(ctx *context.Context, brew config.HomebrewCask, cl client.ReleaseURLT
 
 	if err := tmpl.New(ctx).ApplyAll(
 		&brew.Name,
+		&brew.App,
 		&brew.SkipUpload,
 		&brew.Completions.Bash,
 		&brew.Completions.Zsh,
@@ -305,6 +301,31 @@ func doRun(ctx *context.Context, brew config.HomebrewCask, cl client.ReleaseURLT
 	return nil
 }
 
+// packagesFilter filters the artifacts to add to the cask.
+// Apps are installed from the DMGs, which replace the macOS archives.
+func packagesFilter(app bool) artifact.Filter {
+	archives := artifact.Or(
+		artifact.And(
+			artifact.Not(artifact.ByFormats("gz")),
+			artifact.ByType(artifact.UploadableArchive),
+		),
+		artifact.ByType(artifact.UploadableBinary),
+	)
+	if !app {
+		return archives
+	}
+	return artifact.Or(
+		artifact.And(
+			artifact.ByGoos("darwin"),
+			artifact.ByType(artifact.DMG),
+		),
+		artifact.And(
+			artifact.ByGoos("linux"),
+			archives,
+		),
+	)
+}
+
 func buildCaskPath(folder, filename string) string {
 	return path.Join(folder, filename)
 }
@@ -1326,6 +1326,63 @@ func TestRunPipeUniversalBinary(t *testing.T) {
 	require.Equal(t, client.Content, string(distBts))
 }
 
+func TestRunPipeDMG(t *testing.T) {
+	folder := t.TempDir()
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist:        folder,
+			ProjectName: "myapp",
+			Casks: []config.HomebrewCask{
+				{
+					Homepage:    "https://goreleaser.com",
+					Description: "Fake desc",
+					Repository: config.RepoRef{
+						Owner: "myapp",
+						Name:  "bar",
+					},
+					App: "{{ .ProjectName }}.app",
+				},
+			},
+		},
+		testctx.WithCurrentTag("v1.0.1"),
+		testctx.WithVersion("1.0.1"))
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Empty(t, ctx.Config.Casks[0].Binaries)
+
+	for _, art := range []*artifact.Artifact{
+		{
+			Name:   "MyApp_amd64.dmg",
+			Goarch: "amd64",
+			Type:   artifact.DMG,
+		},
+		{
+			Name:   "MyApp_arm64.dmg",
+			Goarch: "arm64",
+			Type:   artifact.DMG,
+		},
+		{
+			Name:   "myapp_darwin_arm64.tar.gz",
+			Goarch: "arm64",
+			Type:   artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraFormat:   "tar.gz",
+				artifact.ExtraBinaries: []string{"myapp"},
+			},
+		},
+	} {
+		art.Goos = "darwin"
+		art.Path = filepath.Join(folder, art.Name)
+		require.NoError(t, os.WriteFile(art.Path, nil, 0o644))
+		ctx.Artifacts.Add(art)
+	}
+
+	client := client.NewMock()
+	require.NoError(t, runAll(ctx, client))
+	require.NoError(t, publishAll(ctx, client))
+	require.True(t, client.CreatedFile)
+	golden.RequireEqualRb(t, []byte(client.Content))
+}
+
 func TestRunPipeUniversalBinaryNotReplacing(t *testing.T) {
 	folder := t.TempDir()
 	ctx := testctx.WrapWithCfg(t.Context(),
@@ -37,7 +37,10 @@ cask "{{ .Name }}" do
   {{ depends  . }}
   {{- end }}
 
-  {{ with and (not .HasOnlyBinaryPkgs) .Binaries }}
+  {{ with .App }}
+  app "{{ . }}"
+  {{- end }}
+  {{- with and (not .HasOnlyBinaryPkgs) .Binaries }}
   {{- range . }}
   binary "{{ . }}"
   {{- end }}
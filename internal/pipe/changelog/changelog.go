@@ -33,6 +33,8 @@ const (
 	useGit          = "git"
 	useGitHub       = "github"
 	useGitea        = "gitea"
+	useForgejo      = "forgejo"
+	useBitbucket    = "bitbucket"
 	useGitLab       = "gitlab"
 	useGitHubNative = "github-native"
 )
@@ -125,10 +127,11 @@ func title(s string, level int) string {
 }
 
 func newLineFor(ctx *context.Context) string {
-	if ctx.TokenType == context.TokenTypeGitLab || ctx.TokenType == context.TokenTypeGitea {
+	switch ctx.TokenType {
+	case context.TokenTypeGitLab, context.TokenTypeGitea, context.TokenTypeForgejo:
 		// We need two or more whitespace to let markdown interpret
 		// it as newline. See https://docs.gitlab.com/ee/user/markdown.html#newlines for details
-		log.Debug("is gitlab or gitea changelog")
+		log.Debug("is gitlab, gitea or forgejo changelog")
 		return "   \n"
 	}
 
@@ -367,7 +370,7 @@ func getChangeloger(ctx *context.Context) (changeloger, error) {
 	switch ctx.Config.Changelog.Use {
 	case useGit, "":
 		return gitChangeloger{}, nil
-	case useGitLab, useGitea, useGitHub:
+	case useGitLab, useGitea, useForgejo, useBitbucket, useGitHub:
 		if ctx.Git.PreviousTag == "" {
 			log.Warnf("there's no previous tag, using 'git' instead of '%s'", ctx.Config.Changelog.Use)
 			return gitChangeloger{}, nil
@@ -808,6 +808,54 @@ func TestGetChangeloger(t *testing.T) {
 		require.IsType(t, gitChangeloger{}, c)
 	})
 
+	t.Run(useForgejo, func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Changelog: config.Changelog{
+				Use: useForgejo,
+			},
+		}, testctx.ForgejoTokenType, testctx.WithPreviousTag("v1.2.3"))
+
+		c, err := getChangeloger(ctx)
+		require.NoError(t, err)
+		require.IsType(t, &scmChangeloger{}, c)
+	})
+
+	t.Run(useForgejo+" no previous", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Changelog: config.Changelog{
+				Use: useForgejo,
+			},
+		}, testctx.ForgejoTokenType)
+
+		c, err := getChangeloger(ctx)
+		require.NoError(t, err)
+		require.IsType(t, gitChangeloger{}, c)
+	})
+
+	t.Run(useBitbucket, func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Changelog: config.Changelog{
+				Use: useBitbucket,
+			},
+		}, testctx.BitbucketTokenType, testctx.WithPreviousTag("v1.2.3"))
+
+		c, err := getChangeloger(ctx)
+		require.NoError(t, err)
+		require.IsType(t, &scmChangeloger{}, c)
+	})
+
+	t.Run(useBitbucket+" no previous", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Changelog: config.Changelog{
+				Use: useBitbucket,
+			},
+		}, testctx.BitbucketTokenType)
+
+		c, err := getChangeloger(ctx)
+		require.NoError(t, err)
+		require.IsType(t, gitChangeloger{}, c)
+	})
+
 	t.Run("invalid", func(t *testing.T) {
 		c, err := getChangeloger(testctx.WrapWithCfg(t.Context(), config.Project{
 			Changelog: config.Changelog{
@@ -983,7 +1031,7 @@ func TestChangelogFormat(t *testing.T) {
 			}
 		}
 
-		for _, use := range []string{useGit, useGitHub, useGitLab, useGitea} {
+		for _, use := range []string{useGit, useGitHub, useGitLab, useGitea, useForgejo, useBitbucket} {
 			t.Run(use, func(t *testing.T) {
 				out, err := formatChangelog(
 					testctx.WrapWithCfg(t.Context(), makeConf(use)),
@@ -1013,7 +1061,7 @@ func TestChangelogFormat(t *testing.T) {
 			}
 		}
 
-		for _, use := range []string{useGit, useGitHub, useGitLab, useGitea} {
+		for _, use := range []string{useGit, useGitHub, useGitLab, useGitea, useForgejo, useBitbucket} {
 			t.Run(use, func(t *testing.T) {
 				out, err := formatChangelog(
 					testctx.WrapWithCfg(t.Context(), makeConf(use)),
@@ -22,6 +22,7 @@ import (
 
 const (
 	artifactChecksumExtra = "Checksum"
+	sidecarNameTemplate   = "{{ .ArtifactName }}.{{ .Algorithm }}"
 )
 
 var (
@@ -38,99 +39,186 @@ func (Pipe) Skip(ctx *context.Context) bool { return ctx.Config.Checksum.Disable
 // Default sets the pipe defaults.
 func (Pipe) Default(ctx *context.Context) error {
 	cs := &ctx.Config.Checksum
-	if cs.Algorithm == "" {
-		cs.Algorithm = "sha256"
+	if cs.Algorithm != "" {
+		// algorithm, if set, is the main one.
+		cs.Algorithms = slices.DeleteFunc(slices.Clone(cs.Algorithms), func(s string) bool {
+			return s == cs.Algorithm
+		})
+		cs.Algorithms = append([]string{cs.Algorithm}, cs.Algorithms...)
+	}
+	if len(cs.Algorithms) == 0 {
+		cs.Algorithms = []string{"sha256"}
 	}
+	cs.Algorithm = cs.Algorithms[0]
 	if cs.NameTemplate == "" {
-		if cs.Split {
-			cs.NameTemplate = "{{ .ArtifactName }}.{{ .Algorithm }}"
-		} else {
+		switch {
+		case cs.Split:
+			cs.NameTemplate = sidecarNameTemplate
+		case len(cs.Algorithms) > 1:
+			cs.NameTemplate = "{{ .ProjectName }}_{{ .Version }}_{{ .Algorithm }}_checksums.txt"
+		default:
 			cs.NameTemplate = "{{ .ProjectName }}_{{ .Version }}_checksums.txt"
 		}
 	}
+	if cs.Split && (cs.Clearsign || cs.VerifyScripts) {
+		return errors.New("checksum: clearsign and verify_scripts can't be used with split")
+	}
+	if cs.VerifyScripts {
+		return checkVerifyAlgorithm(cs.Algorithm)
+	}
 	return nil
 }
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
 	if ctx.Config.Checksum.Split {
-		return splitChecksum(ctx)
+		return splitChecksum(ctx, ctx.Config.Checksum.NameTemplate)
+	}
+
+	if err := singleChecksum(ctx); err != nil {
+		return err
 	}
+	if !ctx.Config.Checksum.Sidecars {
+		return nil
+	}
+	if err := splitChecksum(ctx, sidecarNameTemplate); err != nil && !errors.Is(err, errNoArtifacts) {
+		return err
+	}
+	return nil
+}
 
-	return singleChecksum(ctx)
+// algorithms returns the algorithms to use, the first one being the main one.
+func algorithms(ctx *context.Context) []string {
+	if algos := ctx.Config.Checksum.Algorithms; len(algos) > 0 {
+		return algos
+	}
+	return []string{ctx.Config.Checksum.Algorithm}
 }
 
-func splitChecksum(ctx *context.Context) error {
+func splitChecksum(ctx *context.Context, nameTemplate string) error {
 	artifactList, err := buildArtifactList(ctx)
 	if err != nil {
 		return err
 	}
 
-	for _, art := range artifactList {
+	seen := map[string]bool{}
+	for i, algorithm := range algorithms(ctx) {
+		// only the main algorithm is set in the artifacts extra fields.
+		main := i == 0
+		for _, art := range artifactList {
+			filename, err := tmpl.New(ctx).
+				WithArtifact(art).
+				WithExtraFields(tmpl.Fields{
+					"Algorithm": algorithm,
+				}).
+				Apply(nameTemplate)
+			if err != nil {
+				return fmt.Errorf("name template: %w", err)
+			}
+			if seen[filename] {
+				return fmt.Errorf("checksum file %s would be overwritten, make sure the name template uses .ArtifactName and .Algorithm", filename)
+			}
+			seen[filename] = true
+			filepath := filepath.Join(ctx.Config.Dist, filename)
+			if err := refreshOne(art, algorithm, main, filepath); err != nil {
+				return fmt.Errorf("%s: %w", art.Path, err)
+			}
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Type: artifact.Checksum,
+				Path: filepath,
+				Name: filename,
+				Extra: map[string]any{
+					artifact.ExtraChecksumOf: art.Path,
+					artifact.ExtraRefresh: func() error {
+						log.WithField("file", filename).Debug("refreshing checksums")
+						return refreshOne(art, algorithm, main, filepath)
+					},
+				},
+			})
+		}
+	}
+	return nil
+}
+
+func singleChecksum(ctx *context.Context) error {
+	var filenames []string
+	for _, algorithm := range algorithms(ctx) {
 		filename, err := tmpl.New(ctx).
-			WithArtifact(art).
 			WithExtraFields(tmpl.Fields{
-				"Algorithm": ctx.Config.Checksum.Algorithm,
+				"Algorithm": algorithm,
 			}).
 			Apply(ctx.Config.Checksum.NameTemplate)
 		if err != nil {
-			return fmt.Errorf("name template: %w", err)
+			return err
+		}
+		if slices.Contains(filenames, filename) {
+			return fmt.Errorf("checksums file %s would be overwritten, make sure the name template uses .Algorithm", filename)
+		}
+		filenames = append(filenames, filename)
+	}
+
+	// the verify scripts are created first, so they are in the checksums
+	// files as well.
+	if ctx.Config.Checksum.VerifyScripts {
+		if _, err := buildArtifactList(ctx); errors.Is(err, errNoArtifacts) {
+			return nil
 		}
+		if err := verifyScripts(ctx, filenames[0], algorithms(ctx)[0]); err != nil {
+			return fmt.Errorf("could not create verify scripts: %w", err)
+		}
+	}
+
+	for i, algorithm := range algorithms(ctx) {
+		filename := filenames[i]
 		filepath := filepath.Join(ctx.Config.Dist, filename)
-		if err := refreshOne(ctx, *art, filepath); err != nil {
-			return fmt.Errorf("%s: %w", art.Path, err)
+		// only the main algorithm is set in the artifacts extra fields.
+		main := i == 0
+		refresh := func() error {
+			if err := refreshAll(ctx, algorithm, main, filepath); err != nil {
+				return err
+			}
+			if ctx.Config.Checksum.Clearsign {
+				return clearsign(ctx, filepath)
+			}
+			return nil
+		}
+		if err := refresh(); err != nil {
+			if errors.Is(err, errNoArtifacts) {
+				return nil
+			}
+			return err
 		}
 		ctx.Artifacts.Add(&artifact.Artifact{
 			Type: artifact.Checksum,
 			Path: filepath,
 			Name: filename,
 			Extra: map[string]any{
-				artifact.ExtraChecksumOf: art.Path,
 				artifact.ExtraRefresh: func() error {
 					log.WithField("file", filename).Debug("refreshing checksums")
-					return refreshOne(ctx, *art, filepath)
+					return refresh()
 				},
 			},
 		})
-	}
-	return nil
-}
-
-func singleChecksum(ctx *context.Context) error {
-	filename, err := tmpl.New(ctx).Apply(ctx.Config.Checksum.NameTemplate)
-	if err != nil {
-		return err
-	}
-	filepath := filepath.Join(ctx.Config.Dist, filename)
-	if err := refreshAll(ctx, filepath); err != nil {
-		if errors.Is(err, errNoArtifacts) {
-			return nil
+		if ctx.Config.Checksum.Clearsign {
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Type: artifact.Signature,
+				Path: filepath + clearsignExtension,
+				Name: filename + clearsignExtension,
+			})
 		}
-		return err
 	}
-	ctx.Artifacts.Add(&artifact.Artifact{
-		Type: artifact.Checksum,
-		Path: filepath,
-		Name: filename,
-		Extra: map[string]any{
-			artifact.ExtraRefresh: func() error {
-				log.WithField("file", filename).Debug("refreshing checksums")
-				return refreshAll(ctx, filepath)
-			},
-		},
-	})
 	return nil
 }
 
-func refreshOne(ctx *context.Context, art artifact.Artifact, path string) error {
-	check, err := art.Checksum(ctx.Config.Checksum.Algorithm)
+func refreshOne(art *artifact.Artifact, algorithm string, main bool, path string) error {
+	check, err := sum(algorithm, main, art)
 	if err != nil {
 		return err
 	}
 	return os.WriteFile(path, []byte(check), 0o644)
 }
 
-func refreshAll(ctx *context.Context, filepath string) error {
+func refreshAll(ctx *context.Context, algorithm string, main bool, filepath string) error {
 	lock.Lock()
 	defer lock.Unlock()
 
@@ -143,7 +231,7 @@ func refreshAll(ctx *context.Context, filepath string) error {
 	sumLines := make([]string, len(artifactList))
 	for i, artifact := range artifactList {
 		g.Go(func() error {
-			sumLine, err := checksums(ctx.Config.Checksum.Algorithm, artifact)
+			sumLine, err := checksums(algorithm, main, artifact)
 			if err != nil {
 				return err
 			}
@@ -187,7 +275,7 @@ func refreshAll(ctx *context.Context, filepath string) error {
 func buildArtifactList(ctx *context.Context) ([]*artifact.Artifact, error) {
 	filter := artifact.And(
 		artifact.ByTypes(artifact.ReleaseUploadableTypes()...),
-		artifact.Not(artifact.ByTypes(artifact.Checksum, artifact.Signature, artifact.Certificate)),
+		artifact.Not(artifact.ByTypes(artifact.Checksum, artifact.Signature, artifact.Certificate, artifact.Provenance)),
 		artifact.ByIDs(ctx.Config.Checksum.IDs...),
 	)
 	artifactList := ctx.Artifacts.Filter(filter).List()
@@ -211,17 +299,20 @@ func buildArtifactList(ctx *context.Context) ([]*artifact.Artifact, error) {
 	return artifactList, nil
 }
 
-func checksums(algorithm string, a *artifact.Artifact) (string, error) {
+func checksums(algorithm string, main bool, a *artifact.Artifact) (string, error) {
 	log.WithField("file", a.Name).Debug("checksumming")
-	sha, err := a.Checksum(algorithm)
+	sha, err := sum(algorithm, main, a)
 	if err != nil {
 		return "", err
 	}
+	return fmt.Sprintf("%v  %v\n", sha, a.Name), nil
+}
 
-	if a.Extra == nil {
-		a.Extra = make(artifact.Extras)
+// sum calculates the checksum of the given artifact, setting it in its extra
+// fields only if it is the main algorithm.
+func sum(algorithm string, main bool, a *artifact.Artifact) (string, error) {
+	if main {
+		return a.Checksum(algorithm)
 	}
-	a.Extra[artifactChecksumExtra] = fmt.Sprintf("%s:%s", algorithm, sha)
-
-	return fmt.Sprintf("%v  %v\n", sha, a.Name), nil
+	return a.Sum(algorithm)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
 )
 
@@ -163,6 +164,100 @@ func TestPipeSplit(t *testing.T) {
 	}
 }
 
+const (
+	someStringSHA256 = "61d034473102d7dac305902770471fd50f4c5b26f6831a56dd90b5184b3c30fc"
+	someStringSHA512 = "14925e01a7a0cf0801aa95fe52d542b578af58ae7997ada66db3a6eae68a329d50600a5b7b442eabf4ea77ea8ef5fe40acf2ab31d47311b2a232c4f64009aac1"
+)
+
+func multiAlgorithmCtx(tb testing.TB, cs config.Checksum) *context.Context {
+	tb.Helper()
+	folder := tb.TempDir()
+	file := filepath.Join(folder, "binary")
+	require.NoError(tb, os.WriteFile(file, []byte("some string"), 0o644))
+	ctx := testctx.WrapWithCfg(tb.Context(),
+		config.Project{
+			Dist:        folder,
+			ProjectName: "foo",
+			Checksum:    cs,
+		},
+		testctx.WithVersion("1.2.3"))
+	for _, name := range []string{"binary", "binary.tar.gz"} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: name,
+			Path: file,
+			Type: artifact.UploadableArchive,
+		})
+	}
+	require.NoError(tb, Pipe{}.Default(ctx))
+	return ctx
+}
+
+func readChecksums(tb testing.TB, ctx *context.Context) map[string]string {
+	tb.Helper()
+	result := map[string]string{}
+	for _, check := range ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List() {
+		bts, err := os.ReadFile(check.Path)
+		require.NoError(tb, err)
+		result[check.Name] = string(bts)
+	}
+	return result
+}
+
+func TestPipeMultipleAlgorithms(t *testing.T) {
+	ctx := multiAlgorithmCtx(t, config.Checksum{
+		Algorithms: []string{"sha256", "sha512"},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, map[string]string{
+		"foo_1.2.3_sha256_checksums.txt": someStringSHA256 + "  binary\n" + someStringSHA256 + "  binary.tar.gz\n",
+		"foo_1.2.3_sha512_checksums.txt": someStringSHA512 + "  binary\n" + someStringSHA512 + "  binary.tar.gz\n",
+	}, readChecksums(t, ctx))
+
+	// only the main algorithm is set in the artifacts.
+	for _, a := range ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List() {
+		require.Equal(t, "sha256:"+someStringSHA256, artifact.MustExtra[string](*a, artifactChecksumExtra))
+	}
+}
+
+func TestPipeMultipleAlgorithmsSameName(t *testing.T) {
+	ctx := multiAlgorithmCtx(t, config.Checksum{
+		NameTemplate: "checksums.txt",
+		Algorithms:   []string{"sha256", "sha512"},
+	})
+	require.EqualError(t, Pipe{}.Run(ctx), "checksums file checksums.txt would be overwritten, make sure the name template uses .Algorithm")
+}
+
+func TestPipeSplitMultipleAlgorithms(t *testing.T) {
+	ctx := multiAlgorithmCtx(t, config.Checksum{
+		Split:      true,
+		Algorithms: []string{"sha256", "sha512"},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, map[string]string{
+		"binary.sha256":        someStringSHA256,
+		"binary.sha512":        someStringSHA512,
+		"binary.tar.gz.sha256": someStringSHA256,
+		"binary.tar.gz.sha512": someStringSHA512,
+	}, readChecksums(t, ctx))
+
+	// only the main algorithm is set in the artifacts.
+	for _, a := range ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List() {
+		require.Equal(t, "sha256:"+someStringSHA256, artifact.MustExtra[string](*a, artifactChecksumExtra))
+	}
+}
+
+func TestPipeSidecars(t *testing.T) {
+	ctx := multiAlgorithmCtx(t, config.Checksum{
+		Sidecars: true,
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, map[string]string{
+		"foo_1.2.3_checksums.txt": someStringSHA256 + "  binary\n" + someStringSHA256 + "  binary.tar.gz\n",
+		"binary.sha256":           someStringSHA256,
+		"binary.tar.gz.sha256":    someStringSHA256,
+	}, readChecksums(t, ctx))
+}
+
 func TestRefreshModifying(t *testing.T) {
 	const binary = "binary"
 	folder := t.TempDir()
@@ -301,6 +396,38 @@ func TestDefault(t *testing.T) {
 		ctx.Config.Checksum.NameTemplate,
 	)
 	require.Equal(t, "sha256", ctx.Config.Checksum.Algorithm)
+	require.Equal(t, []string{"sha256"}, ctx.Config.Checksum.Algorithms)
+}
+
+func TestDefaultAlgorithms(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Checksum: config.Checksum{
+				Algorithms: []string{"sha512", "blake2b"},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "sha512", ctx.Config.Checksum.Algorithm)
+		require.Equal(t, []string{"sha512", "blake2b"}, ctx.Config.Checksum.Algorithms)
+		require.Equal(
+			t,
+			"{{ .ProjectName }}_{{ .Version }}_{{ .Algorithm }}_checksums.txt",
+			ctx.Config.Checksum.NameTemplate,
+		)
+	})
+
+	t.Run("algorithm is the main one", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Checksum: config.Checksum{
+				Algorithm:  "blake2b",
+				Algorithms: []string{"sha512", "blake2b"},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "blake2b", ctx.Config.Checksum.Algorithm)
+		require.Equal(t, []string{"blake2b", "sha512"}, ctx.Config.Checksum.Algorithms)
+	})
 }
 
 func TestDefaultSPlit(t *testing.T) {
@@ -0,0 +1,250 @@
+package checksums
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	clearsignExtension = ".asc"
+	clearsignCmd       = "gpg"
+)
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	if ctx.Config.Checksum.Clearsign {
+		return []string{clearsignCmd}
+	}
+	return nil
+}
+
+// clearsign signs the given checksums file with 'gpg --clearsign', writing
+// the result to path.asc.
+func clearsign(ctx *context.Context, path string) error {
+	key, err := tmpl.New(ctx).Apply(ctx.Config.Checksum.ClearsignKey)
+	if err != nil {
+		return fmt.Errorf("clearsign key: %w", err)
+	}
+	args := []string{"--batch", "--yes"}
+	if key != "" {
+		args = append(args, "--local-user", key)
+	}
+	args = append(args, "--output", path+clearsignExtension, "--clearsign", path)
+
+	log.WithField("file", filepath.Base(path)).Info("clearsigning")
+	// #nosec
+	cmd := exec.CommandContext(ctx, clearsignCmd, args...)
+	cmd.Env = ctx.Env.Strings()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return gerrors.Wrap(
+			err,
+			gerrors.WithMessage("could not clearsign checksums file"),
+			gerrors.WithDetails("file", path),
+			gerrors.WithOutput(string(out)),
+		)
+	}
+	return nil
+}
+
+// verifyAlgorithm holds how an algorithm can be checked by the verify
+// scripts.
+type verifyAlgorithm struct {
+	// Bits is the argument to 'shasum -a'.
+	Bits string
+	// PowerShell is the 'Get-FileHash -Algorithm' name.
+	PowerShell string
+}
+
+var verifyAlgorithms = map[string]verifyAlgorithm{
+	"sha1":   {Bits: "1", PowerShell: "SHA1"},
+	"sha256": {Bits: "256", PowerShell: "SHA256"},
+	"sha384": {Bits: "384", PowerShell: "SHA384"},
+	"sha512": {Bits: "512", PowerShell: "SHA512"},
+}
+
+func checkVerifyAlgorithm(algorithm string) error {
+	if _, ok := verifyAlgorithms[algorithm]; ok {
+		return nil
+	}
+	return fmt.Errorf(
+		"verify_scripts does not support the %s algorithm, use one of: %s",
+		algorithm,
+		strings.Join(slices.Sorted(maps.Keys(verifyAlgorithms)), ", "),
+	)
+}
+
+type verifyData struct {
+	ProjectName string
+	Version     string
+	Checksums   string
+	Clearsigned bool
+	Algorithm   string
+	verifyAlgorithm
+}
+
+// verifyScripts creates the scripts that end users can run to verify their
+// downloads against the given checksums file, and adds them to the
+// artifacts.
+func verifyScripts(ctx *context.Context, checksums, algorithm string) error {
+	data := verifyData{
+		ProjectName:     ctx.Config.ProjectName,
+		Version:         ctx.Version,
+		Checksums:       checksums,
+		Clearsigned:     ctx.Config.Checksum.Clearsign,
+		Algorithm:       algorithm,
+		verifyAlgorithm: verifyAlgorithms[algorithm],
+	}
+	for name, text := range map[string]string{
+		"verify.sh":  verifyShTemplate,
+		"verify.ps1": verifyPs1Template,
+	} {
+		t, err := template.New(name).Parse(text)
+		if err != nil {
+			return err
+		}
+		var out bytes.Buffer
+		if err := t.Execute(&out, data); err != nil {
+			return err
+		}
+		path := filepath.Join(ctx.Config.Dist, name)
+		if err := os.WriteFile(path, out.Bytes(), 0o755); err != nil {
+			return err
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: artifact.UploadableFile,
+			Name: name,
+			Path: path,
+		})
+	}
+	return nil
+}
+
+const verifyShTemplate = `#!/bin/sh
+# This file was generated by GoReleaser. DO NOT EDIT.
+#
+# Verifies files downloaded from the {{ .ProjectName }} {{ .Version }} release.
+#
+# Run it from the directory with the downloaded files and {{ .Checksums }}{{ if .Clearsigned }}.asc{{ end }}:
+#
+#   sh verify.sh [FILE...]
+#
+# Without arguments, all the downloaded files listed in {{ .Checksums }} are
+# verified.
+set -eu
+
+sums="{{ .Checksums }}"
+{{- if .Clearsigned }}
+
+# gpg verifies the signature, and outputs the signed checksums.
+verified="$(mktemp)"
+trap 'rm -f "$verified"' EXIT
+if ! gpg --batch --yes --decrypt --output "$verified" "$sums.asc"; then
+	echo "could not verify the signature of $sums.asc" >&2
+	exit 1
+fi
+sums="$verified"
+{{- end }}
+
+if command -v {{ .Algorithm }}sum >/dev/null 2>&1; then
+	check() { {{ .Algorithm }}sum -c "$@"; }
+elif command -v shasum >/dev/null 2>&1; then
+	check() { shasum -a {{ .Bits }} -c "$@"; }
+else
+	echo "{{ .Algorithm }}sum or shasum is required" >&2
+	exit 1
+fi
+
+if [ "$#" -eq 0 ]; then
+	check --ignore-missing "$sums"
+	exit 0
+fi
+
+lines=""
+for file in "$@"; do
+	line="$(awk -v f="$file" 'substr($0, index($0, "  ") + 2) == f' "$sums")"
+	if [ -z "$line" ]; then
+		echo "$file: not found in {{ .Checksums }}" >&2
+		exit 1
+	fi
+	lines="$lines$line
+"
+done
+printf '%s' "$lines" | check -
+`
+
+const verifyPs1Template = `# This file was generated by GoReleaser. DO NOT EDIT.
+#
+# Verifies files downloaded from the {{ .ProjectName }} {{ .Version }} release.
+#
+# Run it from the directory with the downloaded files and {{ .Checksums }}{{ if .Clearsigned }}.asc{{ end }}:
+#
+#   .\verify.ps1 [FILE...]
+#
+# Without arguments, all the downloaded files listed in {{ .Checksums }} are
+# verified.
+param([string[]]$Files)
+$ErrorActionPreference = 'Stop'
+
+$Sums = '{{ .Checksums }}'
+{{- if .Clearsigned }}
+
+# gpg verifies the signature, and outputs the signed checksums.
+$Verified = New-TemporaryFile
+try {
+    & gpg --batch --yes --decrypt --output $Verified.FullName "$Sums.asc"
+    if ($LASTEXITCODE -ne 0) {
+        throw "could not verify the signature of $Sums.asc"
+    }
+    $Lines = Get-Content -LiteralPath $Verified.FullName
+} finally {
+    Remove-Item -LiteralPath $Verified.FullName -Force
+}
+{{- else }}
+$Lines = Get-Content -LiteralPath $Sums
+{{- end }}
+
+$Expected = @{}
+foreach ($Line in $Lines) {
+    $Index = $Line.IndexOf('  ')
+    if ($Index -gt 0) {
+        $Expected[$Line.Substring($Index + 2)] = $Line.Substring(0, $Index)
+    }
+}
+
+if (-not $Files) {
+    $Files = @($Expected.Keys | Where-Object { Test-Path -LiteralPath $_ })
+}
+
+$Failed = 0
+foreach ($File in $Files) {
+    if (-not $Expected.ContainsKey($File)) {
+        Write-Error "${File}: not found in {{ .Checksums }}" -ErrorAction Continue
+        $Failed++
+        continue
+    }
+    $Hash = (Get-FileHash -Algorithm {{ .PowerShell }} -LiteralPath $File).Hash
+    if ($Hash -ieq $Expected[$File]) {
+        Write-Output "${File}: OK"
+    } else {
+        Write-Output "${File}: FAILED"
+        $Failed++
+    }
+}
+
+if ($Failed -gt 0) {
+    exit 1
+}
+`
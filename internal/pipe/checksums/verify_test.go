@@ -0,0 +1,179 @@
+package checksums
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGPG puts a fake gpg in the PATH, which "signs" and "verifies" by
+// copying the input file to the output, and logs its arguments to the
+// returned file.
+func fakeGPG(tb testing.TB, exitCode int) string {
+	tb.Helper()
+	testlib.SkipIfWindows(tb, "uses a shell script")
+	bin := tb.TempDir()
+	log := filepath.Join(bin, "args")
+	require.NoError(tb, os.WriteFile(filepath.Join(bin, "gpg"), []byte(`#!/bin/sh
+echo "$@" >> "`+log+`"
+while [ "$#" -gt 1 ]; do
+	if [ "$1" = "--output" ]; then
+		out="$2"
+	fi
+	shift
+done
+cp "$1" "$out"
+exit `+strconv.Itoa(exitCode)+`
+`), 0o755))
+	tb.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.Empty(t, Pipe{}.Dependencies(ctx))
+	ctx.Config.Checksum.Clearsign = true
+	require.Equal(t, []string{"gpg"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestDefaultVerifyScripts(t *testing.T) {
+	t.Run("split", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Checksum: config.Checksum{
+				Split:     true,
+				Clearsign: true,
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "checksum: clearsign and verify_scripts can't be used with split")
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Checksum: config.Checksum{
+				Algorithm:     "blake2b",
+				VerifyScripts: true,
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "verify_scripts does not support the blake2b algorithm, use one of: sha1, sha256, sha384, sha512")
+	})
+}
+
+func TestPipeClearsign(t *testing.T) {
+	log := fakeGPG(t, 0)
+	ctx := multiAlgorithmCtx(t, config.Checksum{
+		NameTemplate: "SHA256SUMS",
+		Clearsign:    true,
+		ClearsignKey: "{{ .Env.KEY }}",
+	})
+	ctx.Env["KEY"] = "ABCD"
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+	require.Len(t, sigs, 1)
+	require.Equal(t, "SHA256SUMS.asc", sigs[0].Name)
+
+	sums := filepath.Join(ctx.Config.Dist, "SHA256SUMS")
+	expected := someStringSHA256 + "  binary\n" + someStringSHA256 + "  binary.tar.gz\n"
+	bts, err := os.ReadFile(sigs[0].Path)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(bts))
+
+	args, err := os.ReadFile(log)
+	require.NoError(t, err)
+	require.Equal(t, "--batch --yes --local-user ABCD --output "+sums+".asc --clearsign "+sums+"\n", string(args))
+
+	// refreshing also signs again
+	require.NoError(t, ctx.Artifacts.Refresh())
+	args, err = os.ReadFile(log)
+	require.NoError(t, err)
+	require.Len(t, args, 2*len("--batch --yes --local-user ABCD --output "+sums+".asc --clearsign "+sums+"\n"))
+}
+
+func TestPipeClearsignFails(t *testing.T) {
+	fakeGPG(t, 1)
+	ctx := multiAlgorithmCtx(t, config.Checksum{
+		Clearsign: true,
+	})
+	err := Pipe{}.Run(ctx)
+	require.Error(t, err)
+	var gerr gerrors.ErrDetailed
+	require.ErrorAs(t, err, &gerr)
+	require.Equal(t, []string{"could not clearsign checksums file"}, gerr.Messages())
+}
+
+func TestPipeVerifyScripts(t *testing.T) {
+	ctx := multiAlgorithmCtx(t, config.Checksum{
+		NameTemplate:  "SHA256SUMS",
+		VerifyScripts: true,
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	files := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableFile)).List()
+	require.Len(t, files, 2)
+
+	// the scripts are in the checksums file too.
+	sums := readChecksums(t, ctx)["SHA256SUMS"]
+	require.Contains(t, sums, "  verify.sh\n")
+	require.Contains(t, sums, "  verify.ps1\n")
+
+	ps1, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "verify.ps1"))
+	require.NoError(t, err)
+	require.Contains(t, string(ps1), "$Sums = 'SHA256SUMS'")
+	require.Contains(t, string(ps1), "Get-FileHash -Algorithm SHA256")
+	require.NotContains(t, string(ps1), "gpg")
+
+	testlib.SkipIfWindows(t, "uses a shell script")
+	run := func(args ...string) (string, error) {
+		cmd := exec.CommandContext(t.Context(), "sh", append([]string{"verify.sh"}, args...)...)
+		cmd.Dir = ctx.Config.Dist
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+
+	out, err := run()
+	require.NoError(t, err, out)
+	require.Contains(t, out, "binary: OK")
+	require.Contains(t, out, "verify.sh: OK")
+
+	out, err = run("binary")
+	require.NoError(t, err, out)
+	require.Equal(t, "binary: OK\n", out)
+
+	out, err = run("nope")
+	require.Error(t, err)
+	require.Contains(t, out, "nope: not found in SHA256SUMS")
+
+	require.NoError(t, os.WriteFile(filepath.Join(ctx.Config.Dist, "binary"), []byte("tampered"), 0o644))
+	out, err = run("binary")
+	require.Error(t, err)
+	require.Contains(t, out, "binary: FAILED")
+}
+
+func TestPipeVerifyScriptsClearsign(t *testing.T) {
+	log := fakeGPG(t, 0)
+	ctx := multiAlgorithmCtx(t, config.Checksum{
+		NameTemplate:  "SHA256SUMS",
+		Clearsign:     true,
+		VerifyScripts: true,
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	cmd := exec.CommandContext(t.Context(), "sh", "verify.sh", "binary")
+	cmd.Dir = ctx.Config.Dist
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	require.Equal(t, "binary: OK\n", string(out))
+
+	args, err := os.ReadFile(log)
+	require.NoError(t, err)
+	require.Contains(t, string(args), "--decrypt")
+}
@@ -147,6 +147,12 @@ func doRun(ctx *context.Context, cl client.ReleaseURLTemplater, choco config.Cho
 	if err != nil {
 		return err
 	}
+	if choco.InstallScript != "" {
+		script, err = buildScript(ctx, choco.InstallScript, data)
+		if err != nil {
+			return err
+		}
+	}
 
 	scriptFile := filepath.Join(toolsPath, "chocolateyinstall.ps1")
 	log.WithField("file", scriptFile).Debug("creating")
@@ -154,6 +160,18 @@ func doRun(ctx *context.Context, cl client.ReleaseURLTemplater, choco config.Cho
 		return err
 	}
 
+	if choco.UninstallScript != "" {
+		script, err := buildScript(ctx, choco.UninstallScript, data)
+		if err != nil {
+			return err
+		}
+		scriptFile := filepath.Join(toolsPath, "chocolateyuninstall.ps1")
+		log.WithField("file", scriptFile).Debug("creating")
+		if err = os.WriteFile(scriptFile, script, 0o644); err != nil {
+			return err
+		}
+	}
+
 	log.WithField("nuspec", nuspecFile).Info("packing")
 	out, err := cmd.Exec(ctx, "choco", "pack", nuspecFile, "--out", ctx.Config.Dist)
 	if err != nil {
@@ -181,13 +199,15 @@ func doRun(ctx *context.Context, cl client.ReleaseURLTemplater, choco config.Cho
 
 func doPush(ctx *context.Context, art *artifact.Artifact) error {
 	choco := artifact.MustExtra[config.Chocolatey](*art, chocoConfigExtra)
-	key, err := tmpl.New(ctx).Apply(choco.APIKey)
-	if err != nil {
+	if err := tmpl.New(ctx).ApplyAll(
+		&choco.APIKey,
+		&choco.SourceRepo,
+	); err != nil {
 		return err
 	}
 
 	log := log.WithField("name", choco.Name)
-	if key == "" {
+	if choco.APIKey == "" {
 		log.Warn("skip pushing: no api key")
 		return nil
 	}
@@ -199,7 +219,7 @@ func doPush(ctx *context.Context, art *artifact.Artifact) error {
 		"--source",
 		choco.SourceRepo,
 		"--api-key",
-		key,
+		choco.APIKey,
 		filepath.Clean(art.Path),
 	}
 
@@ -255,6 +275,9 @@ func buildNuspec(ctx *context.Context, choco config.Chocolatey) ([]byte, error)
 
 	deps := make([]Dependency, len(choco.Dependencies))
 	for i, dep := range choco.Dependencies {
+		if err := tpl.ApplyAll(&dep.ID, &dep.Version); err != nil {
+			return nil, err
+		}
 		deps[i] = Dependency{ID: dep.ID, Version: dep.Version}
 	}
 
@@ -279,6 +302,25 @@ func buildTemplate(name string, text string, data templateData) ([]byte, error)
 	return out.Bytes(), nil
 }
 
+// buildScript renders the user provided script template at the given path.
+func buildScript(ctx *context.Context, path string, data templateData) ([]byte, error) {
+	path, err := tmpl.New(ctx).Apply(path)
+	if err != nil {
+		return nil, err
+	}
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+	out, err := tmpl.New(ctx).WithExtraFields(tmpl.Fields{
+		"Packages": data.Packages,
+	}).Apply(string(bts))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
 func dataFor(ctx *context.Context, cl client.ReleaseURLTemplater, choco config.Chocolatey, artifacts []*artifact.Artifact) (templateData, error) {
 	result := templateData{}
 
@@ -191,6 +191,31 @@ func Test_buildNuspec(t *testing.T) {
 	golden.RequireEqualExt(t, out, ".nuspec")
 }
 
+func Test_buildNuspecDependencies(t *testing.T) {
+	ctx := testctx.Wrap(t.Context(), testctx.WithVersion("1.12.3"), testctx.WithEnv(map[string]string{
+		"CORE": "goreleaser-core",
+	}))
+	t.Run("templates", func(t *testing.T) {
+		out, err := buildNuspec(ctx, config.Chocolatey{
+			Name: "goreleaser",
+			Dependencies: []config.ChocolateyDependency{
+				{ID: "{{ .Env.CORE }}", Version: "[{{ .Version }}]"},
+			},
+		})
+		require.NoError(t, err)
+		require.Contains(t, string(out), `<dependency id="goreleaser-core" version="[1.12.3]" />`)
+	})
+	t.Run("bad template", func(t *testing.T) {
+		_, err := buildNuspec(ctx, config.Chocolatey{
+			Name: "goreleaser",
+			Dependencies: []config.ChocolateyDependency{
+				{ID: "foo", Version: "{{ .Nope }}"},
+			},
+		})
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
 func Test_buildTemplate(t *testing.T) {
 	folder := t.TempDir()
 	file := filepath.Join(folder, "archive")
@@ -230,6 +255,120 @@ func Test_buildTemplate(t *testing.T) {
 	golden.RequireEqualExt(t, out, ".script.ps1")
 }
 
+func TestCustomScripts(t *testing.T) {
+	cmd = fakeCmd{execFn: func(cmd string, args ...string) ([]byte, error) {
+		checkPackCmd(t, cmd, args...)
+		return []byte("success"), nil
+	}}
+	t.Cleanup(func() {
+		cmd = stdCmd{}
+	})
+
+	folder := t.TempDir()
+	file := filepath.Join(folder, "archive")
+	require.NoError(t, os.WriteFile(file, []byte("lorem ipsum"), 0o644))
+	install := filepath.Join(folder, "install.ps1")
+	require.NoError(t, os.WriteFile(install, []byte("# install {{ .Version }}\n{{ range .Packages }}{{ .Arch }} {{ .DownloadURL }} {{ .Checksum }}\n{{ end }}"), 0o644))
+	uninstall := filepath.Join(folder, "uninstall.ps1")
+	require.NoError(t, os.WriteFile(uninstall, []byte("# uninstall {{ .ProjectName }}\n"), 0o644))
+
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Dist:        folder,
+			ProjectName: "app",
+		},
+		testctx.WithCurrentTag("v1.0.1"),
+		testctx.WithVersion("1.0.1"))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:    "app_1.0.1_windows_amd64.zip",
+		Path:    file,
+		Goos:    "windows",
+		Goarch:  "amd64",
+		Goamd64: "v1",
+		Type:    artifact.UploadableArchive,
+		Extra: map[string]any{
+			artifact.ExtraID:     "app",
+			artifact.ExtraFormat: "zip",
+		},
+	})
+
+	choco := config.Chocolatey{
+		Name:            "app",
+		Goamd64:         "v1",
+		InstallScript:   install,
+		UninstallScript: uninstall,
+	}
+	require.NoError(t, doRun(ctx, client.NewMock(), choco))
+
+	tools := filepath.Join(folder, "app.choco", "tools")
+	bts, err := os.ReadFile(filepath.Join(tools, "chocolateyinstall.ps1"))
+	require.NoError(t, err)
+	require.Equal(t, "# install 1.0.1\namd64 https://dummyhost/download/v1.0.1/app_1.0.1_windows_amd64.zip 5e2bf57d3f40c4b6df69daf1936cb766f832374b4fc0259a7cbff06e2f70f269\n", string(bts))
+
+	bts, err = os.ReadFile(filepath.Join(tools, "chocolateyuninstall.ps1"))
+	require.NoError(t, err)
+	require.Equal(t, "# uninstall app\n", string(bts))
+
+	t.Run("missing script", func(t *testing.T) {
+		choco := choco
+		choco.InstallScript = filepath.Join(folder, "nope.ps1")
+		require.ErrorContains(t, doRun(ctx, client.NewMock(), choco), "failed to read script")
+	})
+	t.Run("bad template", func(t *testing.T) {
+		choco := choco
+		choco.UninstallScript = "{{ .Nope }}"
+		testlib.RequireTemplateError(t, doRun(ctx, client.NewMock(), choco))
+	})
+}
+
+func TestPublishTemplates(t *testing.T) {
+	fakenu := filepath.Join(t.TempDir(), "foo.nupkg")
+	require.NoError(t, os.WriteFile(fakenu, []byte("fake nupkg"), 0o644))
+
+	t.Run("internal feed", func(t *testing.T) {
+		cmd = fakeCmd{execFn: func(cmd string, args ...string) ([]byte, error) {
+			checkPushCmd(t, cmd, args...)
+			require.Equal(t, "https://nuget.example.com/v3/index.json", args[2])
+			require.Equal(t, "abcd", args[4])
+			return []byte("success"), nil
+		}}
+		t.Cleanup(func() {
+			cmd = stdCmd{}
+		})
+
+		ctx := testctx.Wrap(t.Context(), testctx.WithEnv(map[string]string{
+			"FEED":    "nuget.example.com",
+			"API_KEY": "abcd",
+		}))
+		require.NoError(t, doPush(ctx, &artifact.Artifact{
+			Type: artifact.PublishableChocolatey,
+			Name: "app.1.0.1.nupkg",
+			Path: fakenu,
+			Extra: map[string]any{
+				chocoConfigExtra: config.Chocolatey{
+					APIKey:     "{{ .Env.API_KEY }}",
+					SourceRepo: "https://{{ .Env.FEED }}/v3/index.json",
+				},
+			},
+		}))
+	})
+
+	t.Run("bad template", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		testlib.RequireTemplateError(t, doPush(ctx, &artifact.Artifact{
+			Type: artifact.PublishableChocolatey,
+			Name: "app.1.0.1.nupkg",
+			Path: fakenu,
+			Extra: map[string]any{
+				chocoConfigExtra: config.Chocolatey{
+					APIKey:     "abcd",
+					SourceRepo: "{{ .Nope }}",
+				},
+			},
+		}))
+	})
+}
+
 func TestPublish(t *testing.T) {
 	folder := t.TempDir()
 	file := filepath.Join(folder, "archive")
@@ -0,0 +1,165 @@
+// Package datadog records releases as Datadog events.
+package datadog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/caarlos0/log"
+
+	"github.com/goreleaser/goreleaser/v2/internal/announcement"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultAPI             = "https://api.datadoghq.com"
+	defaultService         = `{{ .ProjectName }}`
+	defaultTitleTemplate   = `{{ .ProjectName }} {{ .Tag }} was released`
+	defaultMessageTemplate = `{{ .ReleaseNotes }}`
+
+	// maxTextLength is the maximum length of the text of an event.
+	maxTextLength = 4000
+)
+
+type Pipe struct{}
+
+func (Pipe) String() string { return "datadog" }
+func (Pipe) Skip(ctx *context.Context) (bool, error) {
+	enable, err := tmpl.New(ctx).Bool(ctx.Config.Announce.Datadog.Enabled)
+	return !enable, err
+}
+
+type Config struct {
+	APIKey string `env:"DATADOG_API_KEY,notEmpty"`
+}
+
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Announce.Datadog
+	if cfg.API == "" {
+		cfg.API = defaultAPI
+	}
+	if cfg.Service == "" {
+		cfg.Service = defaultService
+	}
+	if cfg.TitleTemplate == "" {
+		cfg.TitleTemplate = defaultTitleTemplate
+	}
+	if cfg.MessageTemplate == "" {
+		cfg.MessageTemplate = defaultMessageTemplate
+	}
+	return nil
+}
+
+// Preview returns the event that would be posted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	_, event, err := newEvent(ctx)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.MarshalIndent(event, "", "  ")
+	return string(bts), err
+}
+
+func (Pipe) Announce(ctx *context.Context) error {
+	api, event, err := newEvent(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := env.ParseAs[Config]()
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(api)
+	if err != nil {
+		return fmt.Errorf("datadog: invalid api: %w", err)
+	}
+	u = u.JoinPath("api", "v1", "events")
+
+	bts, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("posting: '%s'", event.Title)
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bts))
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("DD-API-KEY", cfg.APIKey)
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return retryx.HTTP(err, resp)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusAccepted {
+			return retryx.HTTP(fmt.Errorf("%s", resp.Status), resp)
+		}
+
+		return nil
+	}, retryx.IsRetriable)
+}
+
+// newEvent returns the templated API URL and the event to post.
+func newEvent(ctx *context.Context) (string, Event, error) {
+	dcfg := ctx.Config.Announce.Datadog
+	t := tmpl.New(ctx).WithMessageTemplates()
+
+	api, service, title, msg := dcfg.API, dcfg.Service, dcfg.TitleTemplate, dcfg.MessageTemplate
+	if err := t.ApplyAll(&api, &service, &title, &msg); err != nil {
+		return "", Event{}, err
+	}
+
+	tags := []string{
+		"service:" + service,
+		"version:" + ctx.Version,
+	}
+	for _, tag := range dcfg.Tags {
+		tag, err := t.Apply(tag)
+		if err != nil {
+			return "", Event{}, err
+		}
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	event := Event{
+		Title:          title,
+		Text:           announcement.Truncate(msg, maxTextLength),
+		Tags:           tags,
+		AlertType:      "info",
+		AggregationKey: service,
+	}
+	if !ctx.Date.IsZero() {
+		event.DateHappened = ctx.Date.Unix()
+	}
+	return api, event, nil
+}
+
+// Event is a Datadog event.
+type Event struct {
+	Title          string   `json:"title"`
+	Text           string   `json:"text"`
+	Tags           []string `json:"tags,omitempty"`
+	AlertType      string   `json:"alert_type,omitempty"`
+	AggregationKey string   `json:"aggregation_key,omitempty"`
+	DateHappened   int64    `json:"date_happened,omitempty"`
+}
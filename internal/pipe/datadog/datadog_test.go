@@ -0,0 +1,173 @@
+package datadog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringer(t *testing.T) {
+	require.Equal(t, "datadog", Pipe{}.String())
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, defaultAPI, ctx.Config.Announce.Datadog.API)
+	require.Equal(t, defaultService, ctx.Config.Announce.Datadog.Service)
+	require.Equal(t, defaultTitleTemplate, ctx.Config.Announce.Datadog.TitleTemplate)
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Datadog.MessageTemplate)
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Datadog: config.Datadog{
+					Enabled: "true",
+				},
+			},
+		})
+
+		skip, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+}
+
+func TestAnnounceInvalidTemplate(t *testing.T) {
+	for name, cfg := range map[string]config.Datadog{
+		"title":   {TitleTemplate: "{{ .Foo }"},
+		"message": {MessageTemplate: "{{ .Foo }"},
+		"service": {Service: "{{ .Foo }"},
+		"api":     {API: "{{ .Foo }"},
+		"tags":    {Tags: []string{"{{ .Foo }"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{
+					Datadog: cfg,
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+		})
+	}
+}
+
+func TestAnnounceMissingEnv(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "DATADOG_API_KEY" should not be empty`)
+}
+
+func TestAnnounce(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/events" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "api-key", r.Header.Get("DD-API-KEY"))
+
+		var event Event
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, Event{
+			Title:          "honk v1.1.0 was released",
+			Text:           "- fixed the honk",
+			Tags:           []string{"service:honk-api", "version:1.1.0", "env:production"},
+			AlertType:      "info",
+			AggregationKey: "honk-api",
+			DateHappened:   1767323045,
+		}, event)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			Datadog: config.Datadog{
+				API:     ts.URL,
+				Service: "{{ .ProjectName }}-api",
+				Tags:    []string{"env:{{ .Env.DEPLOY_ENV }}", "{{ .Env.NOPE }}"},
+			},
+		},
+	},
+		testctx.WithCurrentTag("v1.1.0"),
+		testctx.WithVersion("1.1.0"),
+		testctx.WithEnv(map[string]string{"DEPLOY_ENV": "production", "NOPE": ""}),
+		testctx.WithDate(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	)
+	ctx.ReleaseNotes = "- fixed the honk"
+	t.Setenv("DATADOG_API_KEY", "api-key")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+}
+
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			Datadog: config.Datadog{
+				Service: "{{ .ProjectName }}-api",
+			},
+		},
+	},
+		testctx.WithCurrentTag("v1.1.0"),
+		testctx.WithVersion("1.1.0"),
+		testctx.WithDate(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	)
+	ctx.ReleaseNotes = "- fixed the honk"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+
+	var event Event
+	require.NoError(t, json.Unmarshal([]byte(out), &event))
+	require.Equal(t, Event{
+		Title:          "honk v1.1.0 was released",
+		Text:           "- fixed the honk",
+		Tags:           []string{"service:honk-api", "version:1.1.0"},
+		AlertType:      "info",
+		AggregationKey: "honk-api",
+		DateHappened:   1767323045,
+	}, event)
+}
+
+func TestAnnounceError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Datadog: config.Datadog{
+				API: ts.URL,
+			},
+		},
+	})
+	t.Setenv("DATADOG_API_KEY", "api-key")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Announce(ctx), "403 Forbidden")
+}
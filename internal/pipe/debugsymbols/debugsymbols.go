@@ -0,0 +1,186 @@
+// Package debugsymbols splits the debug symbols out of the built binaries.
+package debugsymbols
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/archive"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	// DefaultNameTemplate is the default name of the debug symbols of a
+	// binary, without extension.
+	DefaultNameTemplate = `{{ .Binary }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}`
+	formatBinary        = "binary"
+)
+
+// Pipe for debug symbols.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "debug symbols" }
+func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.DebugSymbols) == 0 }
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	var cmds []string
+	for _, s := range ctx.Config.DebugSymbols {
+		cmds = append(cmds, s.Objcopy)
+	}
+	return cmds
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("debug_symbols")
+	for i := range ctx.Config.DebugSymbols {
+		dbg := &ctx.Config.DebugSymbols[i]
+		if dbg.ID == "" {
+			dbg.ID = "default"
+		}
+		if dbg.Objcopy == "" {
+			dbg.Objcopy = "objcopy"
+		}
+		if dbg.NameTemplate == "" {
+			dbg.NameTemplate = DefaultNameTemplate
+		}
+		if dbg.Format == "" {
+			dbg.Format = formatBinary
+		}
+		ids.Inc(dbg.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	g := semerrgroup.New(ctx.Parallelism)
+	skips := pipe.SkipMemento{}
+	for _, dbg := range ctx.Config.DebugSymbols {
+		disable, err := tmpl.New(ctx).Bool(dbg.Disable)
+		if err != nil {
+			return err
+		}
+		if disable {
+			skips.Remember(pipe.Skipf("debug symbols %s are disabled", dbg.ID))
+			continue
+		}
+		for _, bin := range findBinaries(ctx, dbg) {
+			g.Go(func() error {
+				return split(ctx, dbg, bin)
+			})
+		}
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return skips.Evaluate()
+}
+
+// split extracts the debug symbols of the given binary into a separate file,
+// strips them from the binary, and links the binary to them.
+func split(ctx *context.Context, dbg config.DebugSymbols, bin *artifact.Artifact) error {
+	debug := bin.Path + ".debug"
+	log.WithField("binary", bin.Path).Info("splitting debug symbols")
+	for _, args := range [][]string{
+		{dbg.Objcopy, "--only-keep-debug", bin.Path, debug},
+		{dbg.Objcopy, "--strip-debug", "--add-gnu-debuglink=" + debug, bin.Path},
+	} {
+		if err := shell.Run(ctx, "", args, ctx.Env.Strings(), false); err != nil {
+			return fmt.Errorf("could not split debug symbols of %s: %w", bin.Path, err)
+		}
+	}
+
+	name, err := tmpl.New(ctx).WithArtifact(bin).Apply(dbg.NameTemplate)
+	if err != nil {
+		return err
+	}
+
+	path := debug
+	if dbg.Format == formatBinary {
+		name += ".debug"
+	} else {
+		name += "." + dbg.Format
+		path = filepath.Join(ctx.Config.Dist, name)
+		if err := archiveDebug(debug, path, dbg.Format); err != nil {
+			return err
+		}
+	}
+
+	art := NewArtifact(bin, name, path)
+	art.Extra[artifact.ExtraFormat] = dbg.Format
+	ctx.Artifacts.Add(art)
+	return nil
+}
+
+// NewArtifact returns the debug symbols artifact of the given binary.
+func NewArtifact(bin *artifact.Artifact, name, path string) *artifact.Artifact {
+	extra := map[string]any{
+		artifact.ExtraID: bin.ID(),
+	}
+	if binary, ok := bin.Extra[artifact.ExtraBinary]; ok {
+		extra[artifact.ExtraBinary] = binary
+	}
+	return &artifact.Artifact{
+		Type:      artifact.DebugSymbols,
+		Name:      name,
+		Path:      path,
+		Goos:      bin.Goos,
+		Goarch:    bin.Goarch,
+		Goamd64:   bin.Goamd64,
+		Go386:     bin.Go386,
+		Goarm:     bin.Goarm,
+		Goarm64:   bin.Goarm64,
+		Gomips:    bin.Gomips,
+		Goppc64:   bin.Goppc64,
+		Goriscv64: bin.Goriscv64,
+		Target:    bin.Target,
+		Extra:     extra,
+	}
+}
+
+// archiveDebug creates an archive with the given debug file in its root.
+func archiveDebug(debug, path, format string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	a, err := archive.New(f, format)
+	if err != nil {
+		return err
+	}
+	if err := a.Add(config.File{
+		Source:      debug,
+		Destination: filepath.Base(debug),
+	}); err != nil {
+		return err
+	}
+	if err := a.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// findBinaries returns the binaries to split.
+// Mach-O binaries are not supported by objcopy, so darwin binaries are
+// skipped.
+func findBinaries(ctx *context.Context, dbg config.DebugSymbols) []*artifact.Artifact {
+	return ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.Binary),
+		func(a *artifact.Artifact) bool { return a.Goos != "darwin" },
+		artifact.ByGooses(dbg.Goos...),
+		artifact.ByGoarches(dbg.Goarch...),
+		artifact.ByIDs(dbg.IDs...),
+	)).List()
+}
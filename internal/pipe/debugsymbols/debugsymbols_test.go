@@ -0,0 +1,202 @@
+package debugsymbols
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringer(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("do not skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DebugSymbols: []config.DebugSymbols{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DebugSymbols: []config.DebugSymbols{
+			{Objcopy: "objcopy"},
+			{Objcopy: "llvm-objcopy"},
+		},
+	})
+	require.Equal(t, []string{"objcopy", "llvm-objcopy"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DebugSymbols: []config.DebugSymbols{{}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, config.DebugSymbols{
+			ID:           "default",
+			Objcopy:      "objcopy",
+			NameTemplate: DefaultNameTemplate,
+			Format:       "binary",
+		}, ctx.Config.DebugSymbols[0])
+	})
+
+	t.Run("duplicated ids", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DebugSymbols: []config.DebugSymbols{{}, {}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "found 2 debug_symbols with the ID 'default', please fix your config")
+	})
+}
+
+// fakeObjcopy writes a script that mimics objcopy: --only-keep-debug copies
+// the binary to the debug file, and --strip-debug overwrites the binary.
+func fakeObjcopy(tb testing.TB) string {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), "objcopy")
+	script := `#!/bin/sh
+case "$1" in
+--only-keep-debug) cp "$2" "$3" ;;
+--strip-debug) echo "stripped ${2#--add-gnu-debuglink=}" > "$3" ;;
+*) exit 1 ;;
+esac
+`
+	require.NoError(tb, os.WriteFile(path, []byte(script), 0o755))
+	return path
+}
+
+func addBinaries(tb testing.TB, artifacts *artifact.Artifacts, dist string) {
+	tb.Helper()
+	for _, plat := range [][]string{{"linux", "amd64"}, {"linux", "arm64"}, {"darwin", "arm64"}} {
+		path := filepath.Join(dist, "foo_"+plat[0]+"_"+plat[1], "foo")
+		require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(tb, os.WriteFile(path, []byte("binary"), 0o755))
+		artifacts.Add(&artifact.Artifact{
+			Name:    "foo",
+			Path:    path,
+			Goos:    plat[0],
+			Goarch:  plat[1],
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+			Extra: map[string]any{
+				artifact.ExtraBinary: "foo",
+				artifact.ExtraID:     "build1",
+			},
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses a shell script")
+	dist := t.TempDir()
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist:        dist,
+		ProjectName: "foo",
+		DebugSymbols: []config.DebugSymbols{
+			{Objcopy: fakeObjcopy(t)},
+		},
+	}, testctx.WithVersion("1.2.3"))
+	require.NoError(t, Pipe{}.Default(ctx))
+	addBinaries(t, ctx.Artifacts, dist)
+
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	for _, bin := range ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List() {
+		bts, err := os.ReadFile(bin.Path)
+		require.NoError(t, err)
+		if bin.Goos == "darwin" {
+			require.Equal(t, "binary", string(bts))
+			continue
+		}
+		require.Equal(t, "stripped "+bin.Path+".debug\n", string(bts))
+	}
+
+	debugs := ctx.Artifacts.Filter(artifact.ByType(artifact.DebugSymbols)).List()
+	require.Len(t, debugs, 2)
+	for _, debug := range debugs {
+		require.Equal(t, "linux", debug.Goos)
+		require.Equal(t, "foo_1.2.3_linux_"+debug.Goarch+".debug", debug.Name)
+		require.Equal(t, "build1", debug.ID())
+		require.Equal(t, "binary", debug.Format())
+		bts, err := os.ReadFile(debug.Path)
+		require.NoError(t, err)
+		require.Equal(t, "binary", string(bts))
+	}
+}
+
+func TestRunArchive(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses a shell script")
+	dist := t.TempDir()
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist:        dist,
+		ProjectName: "foo",
+		DebugSymbols: []config.DebugSymbols{
+			{
+				Objcopy: fakeObjcopy(t),
+				Goarch:  []string{"amd64"},
+				Format:  "tar.gz",
+			},
+		},
+	}, testctx.WithVersion("1.2.3"))
+	require.NoError(t, Pipe{}.Default(ctx))
+	addBinaries(t, ctx.Artifacts, dist)
+
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	debugs := ctx.Artifacts.Filter(artifact.ByType(artifact.DebugSymbols)).List()
+	require.Len(t, debugs, 1)
+	require.Equal(t, "foo_1.2.3_linux_amd64.tar.gz", debugs[0].Name)
+	require.Equal(t, filepath.Join(dist, debugs[0].Name), debugs[0].Path)
+	require.Equal(t, "tar.gz", debugs[0].Format())
+	require.Equal(t, []string{"foo.debug"}, testlib.LsArchive(t, debugs[0].Path, "tar.gz"))
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DebugSymbols: []config.DebugSymbols{
+			{
+				ID:      "foo",
+				Objcopy: "false",
+				Disable: "{{ .IsSnapshot }}",
+			},
+		},
+	}, testctx.Snapshot)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo",
+		Path: "foo",
+		Type: artifact.Binary,
+	})
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Run("failing objcopy", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DebugSymbols: []config.DebugSymbols{{Objcopy: "false"}},
+		})
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: "foo",
+			Path: "foo",
+			Type: artifact.Binary,
+		})
+		require.ErrorContains(t, Pipe{}.Run(ctx), "could not split debug symbols of foo")
+	})
+
+	t.Run("bad disable template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DebugSymbols: []config.DebugSymbols{{Disable: "{{ .Nope }}"}},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
@@ -36,6 +36,33 @@ func (Pipe) Run(ctx *context.Context) error {
 
 		ctx.Config.GiteaURLs.Download = strings.TrimSuffix(strings.ReplaceAll(apiURL, "/api/v1", ""), "/")
 	}
+	if ctx.Config.ForgejoURLs.API == "" {
+		ctx.Config.ForgejoURLs.API = client.DefaultForgejoAPIURL
+	}
+	if ctx.Config.ForgejoURLs.Download == "" {
+		apiURL, err := tmpl.New(ctx).Apply(ctx.Config.ForgejoURLs.API)
+		if err != nil {
+			return fmt.Errorf("templating Forgejo API URL: %w", err)
+		}
+
+		ctx.Config.ForgejoURLs.Download = strings.TrimSuffix(strings.ReplaceAll(apiURL, "/api/v1", ""), "/")
+	}
+	if ctx.Config.BitbucketURLs.API == "" {
+		ctx.Config.BitbucketURLs.API = client.DefaultBitbucketAPIURL
+	}
+	if ctx.Config.BitbucketURLs.Download == "" {
+		apiURL, err := tmpl.New(ctx).Apply(ctx.Config.BitbucketURLs.API)
+		if err != nil {
+			return fmt.Errorf("templating Bitbucket API URL: %w", err)
+		}
+
+		download := client.DefaultBitbucketDownloadURL
+		if apiURL != client.DefaultBitbucketAPIURL {
+			// Data Center serves its API under /rest/api.
+			download, _, _ = strings.Cut(apiURL, "/rest/api")
+		}
+		ctx.Config.BitbucketURLs.Download = strings.TrimSuffix(download, "/")
+	}
 
 	ctx.Config.Retry.Attempts = cmp.Or(ctx.Config.Retry.Attempts, 10)
 	ctx.Config.Retry.Delay = cmp.Or(ctx.Config.Retry.Delay, 10*time.Second)
@@ -112,6 +112,64 @@ func TestFillPartial(t *testing.T) {
 	require.Equal(t, "https://gitea.com", ctx.Config.GiteaURLs.Download)
 }
 
+func TestForgejoDefaults(t *testing.T) {
+	t.Run("codeberg", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context(), testctx.ForgejoTokenType)
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "https://codeberg.org/api/v1", ctx.Config.ForgejoURLs.API)
+		require.Equal(t, "https://codeberg.org", ctx.Config.ForgejoURLs.Download)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env: []string{"FORGEJO_HOST=forgejo.example.com"},
+			ForgejoURLs: config.ForgejoURLs{
+				API: "https://{{ .Env.FORGEJO_HOST }}/api/v1/",
+			},
+		}, testctx.ForgejoTokenType)
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "https://forgejo.example.com", ctx.Config.ForgejoURLs.Download)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ForgejoURLs: config.ForgejoURLs{
+				API: "{{ .Env.NOPE }}",
+			},
+		}, testctx.ForgejoTokenType)
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
+
+func TestBitbucketDefaults(t *testing.T) {
+	t.Run("cloud", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context(), testctx.BitbucketTokenType)
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "https://api.bitbucket.org/2.0", ctx.Config.BitbucketURLs.API)
+		require.Equal(t, "https://bitbucket.org", ctx.Config.BitbucketURLs.Download)
+	})
+
+	t.Run("data center", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env: []string{"BITBUCKET_HOST=bitbucket.example.com"},
+			BitbucketURLs: config.BitbucketURLs{
+				API: "https://{{ .Env.BITBUCKET_HOST }}/rest/api/latest",
+			},
+		}, testctx.BitbucketTokenType)
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "https://bitbucket.example.com", ctx.Config.BitbucketURLs.Download)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BitbucketURLs: config.BitbucketURLs{
+				API: "{{ .Env.NOPE }}",
+			},
+		}, testctx.BitbucketTokenType)
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
+
 func TestGiteaTemplateDownloadURL(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -7,8 +7,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/goreleaser/goreleaser/v2/internal/announcement"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 
 	"github.com/caarlos0/env/v11"
@@ -22,6 +27,16 @@ const (
 	defaultColor           = "3888754"
 	defaultIcon            = "https://goreleaser.com/static/avatar.png"
 	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
+
+	componentTypeActionRow = 1
+	componentTypeButton    = 2
+	buttonStyleLink        = 5
+
+	maxFields        = 25
+	maxFieldValueLen = 1024
+	maxButtonsPerRow = 5
+	maxRows          = 5
+	ellipsis         = "…"
 )
 
 type Pipe struct{}
@@ -54,8 +69,18 @@ func (p Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
+// Preview returns the message that would be posted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	msg, err := webhookMessage(ctx)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.MarshalIndent(msg, "", "  ")
+	return string(bts), err
+}
+
 func (p Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Discord.MessageTemplate)
+	msg, err := webhookMessage(ctx)
 	if err != nil {
 		return err
 	}
@@ -65,31 +90,24 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return err
 	}
 
-	log.Infof("posting: '%s'", msg)
+	log.Infof("posting: '%s'", msg.Embeds[0].Description)
 
-	color, err := strconv.Atoi(ctx.Config.Announce.Discord.Color)
+	u, err := url.Parse(cfg.API)
 	if err != nil {
 		return err
 	}
+	u = u.JoinPath("webhooks", cfg.WebhookID, cfg.WebhookToken)
+	if len(msg.Components) > 0 {
+		// allows non application-owned webhooks to send link buttons.
+		u.RawQuery = url.Values{"with_components": []string{"true"}}.Encode()
+	}
 
-	u, err := url.Parse(cfg.API)
+	bts, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
-	u = u.JoinPath("webhooks", cfg.WebhookID, cfg.WebhookToken)
 
-	bts, err := json.Marshal(WebhookMessageCreate{
-		Embeds: []Embed{
-			{
-				Author: &EmbedAuthor{
-					Name:    ctx.Config.Announce.Discord.Author,
-					IconURL: ctx.Config.Announce.Discord.IconURL,
-				},
-				Description: msg,
-				Color:       color,
-			},
-		},
-	})
+	httpClient, err := httpclient.New(ctx)
 	if err != nil {
 		return err
 	}
@@ -101,7 +119,7 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		}
 		req.Header.Set("Content-Type", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := httpClient.Do(req)
 		if err != nil {
 			return retryx.HTTP(err, resp)
 		}
@@ -115,14 +133,186 @@ func (p Pipe) Announce(ctx *context.Context) error {
 	}, retryx.IsRetriable)
 }
 
+// webhookMessage returns the message to post.
+func webhookMessage(ctx *context.Context) (WebhookMessageCreate, error) {
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Discord.MessageTemplate)
+	if err != nil {
+		return WebhookMessageCreate{}, err
+	}
+
+	color, err := strconv.Atoi(ctx.Config.Announce.Discord.Color)
+	if err != nil {
+		return WebhookMessageCreate{}, err
+	}
+
+	embed := Embed{
+		Author: &EmbedAuthor{
+			Name:    ctx.Config.Announce.Discord.Author,
+			IconURL: ctx.Config.Announce.Discord.IconURL,
+		},
+		Description: msg,
+		Color:       color,
+	}
+	if err := enrichEmbed(ctx, &embed); err != nil {
+		return WebhookMessageCreate{}, err
+	}
+
+	components, err := buttons(ctx)
+	if err != nil {
+		return WebhookMessageCreate{}, err
+	}
+
+	return WebhookMessageCreate{
+		Embeds:     []Embed{embed},
+		Components: components,
+	}, nil
+}
+
+// enrichEmbed adds the thumbnail, the user defined fields and, optionally, the
+// artifacts fields to the given embed.
+func enrichEmbed(ctx *context.Context, embed *Embed) error {
+	t := tmpl.New(ctx).WithMessageTemplates()
+	thumbnail, err := t.Apply(ctx.Config.Announce.Discord.ThumbnailURL)
+	if err != nil {
+		return err
+	}
+	if thumbnail != "" {
+		embed.Thumbnail = &EmbedThumbnail{URL: thumbnail}
+	}
+
+	for _, field := range ctx.Config.Announce.Discord.Fields {
+		if err := t.ApplyAll(&field.Name, &field.Value); err != nil {
+			return err
+		}
+		if field.Name == "" || field.Value == "" {
+			// discord rejects empty fields.
+			continue
+		}
+		embed.Fields = append(embed.Fields, EmbedField(field))
+	}
+
+	if ctx.Config.Announce.Discord.IncludeArtifacts {
+		downloads, checksums, err := artifactsFields(ctx)
+		if err != nil {
+			return err
+		}
+		if downloads != "" {
+			embed.Fields = append(embed.Fields, EmbedField{Name: "Downloads", Value: downloads})
+		}
+		if checksums != "" {
+			embed.Fields = append(embed.Fields, EmbedField{Name: "Checksums", Value: checksums})
+		}
+	}
+
+	if len(embed.Fields) > maxFields {
+		return fmt.Errorf("discord: embeds can have at most %d fields, got %d", maxFields, len(embed.Fields))
+	}
+	return nil
+}
+
+// artifactsFields returns the values of the downloads and checksums fields.
+func artifactsFields(ctx *context.Context) (string, string, error) {
+	urlTemplate := announcement.ReleaseURLTemplate(ctx)
+	t := tmpl.New(ctx).WithMessageTemplates()
+	var downloads, checksums []string
+	for _, a := range ctx.Artifacts.Filter(artifact.And(
+		artifact.ByTypes(artifact.ReleaseUploadableTypes()...),
+		artifact.ByIDs(ctx.Config.Release.IDs...),
+	)).List() {
+		line := a.Name
+		if urlTemplate != "" {
+			link, err := t.WithArtifact(a).Apply(urlTemplate)
+			if err != nil {
+				return "", "", err
+			}
+			line = fmt.Sprintf("[%s](%s)", a.Name, link)
+		}
+		switch a.Type {
+		case artifact.Checksum:
+			checksums = append(checksums, line)
+		case artifact.Signature, artifact.Certificate, artifact.SBOM:
+			continue
+		default:
+			downloads = append(downloads, line)
+		}
+	}
+	return joinField(downloads), joinField(checksums), nil
+}
+
+// joinField joins the given lines, making sure the result fits in a field.
+func joinField(lines []string) string {
+	var sb strings.Builder
+	for i, line := range lines {
+		if sb.Len()+len(line)+1 > maxFieldValueLen-len(ellipsis) {
+			log.Warnf("discord: %d artifacts were not included because the field is too long", len(lines)-i)
+			sb.WriteString(ellipsis)
+			break
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// buttons returns the link buttons, in rows of at most 5 buttons.
+func buttons(ctx *context.Context) ([]Component, error) {
+	buttons, err := announcement.Buttons(ctx, ctx.Config.Announce.Discord.Buttons)
+	if err != nil {
+		return nil, err
+	}
+	var btns []Component
+	for _, button := range buttons {
+		btns = append(btns, Component{
+			Type:  componentTypeButton,
+			Style: buttonStyleLink,
+			Label: button.Title,
+			URL:   button.URL,
+		})
+	}
+	if len(btns) > maxButtonsPerRow*maxRows {
+		return nil, fmt.Errorf("discord: messages can have at most %d buttons, got %d", maxButtonsPerRow*maxRows, len(btns))
+	}
+
+	var rows []Component
+	for chunk := range slices.Chunk(btns, maxButtonsPerRow) {
+		rows = append(rows, Component{
+			Type:       componentTypeActionRow,
+			Components: chunk,
+		})
+	}
+	return rows, nil
+}
+
 type WebhookMessageCreate struct {
-	Embeds []Embed `json:"embeds,omitempty"`
+	Embeds     []Embed     `json:"embeds,omitempty"`
+	Components []Component `json:"components,omitempty"`
 }
 
 type Embed struct {
-	Description string       `json:"description,omitempty"`
-	Color       int          `json:"color,omitempty"`
-	Author      *EmbedAuthor `json:"author,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Color       int             `json:"color,omitempty"`
+	Author      *EmbedAuthor    `json:"author,omitempty"`
+	Thumbnail   *EmbedThumbnail `json:"thumbnail,omitempty"`
+	Fields      []EmbedField    `json:"fields,omitempty"`
+}
+
+type EmbedThumbnail struct {
+	URL string `json:"url"`
+}
+
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// Component is either an action row or a button.
+type Component struct {
+	Type       int         `json:"type"`
+	Style      int         `json:"style,omitempty"`
+	Label      string      `json:"label,omitempty"`
+	URL        string      `json:"url,omitempty"`
+	Components []Component `json:"components,omitempty"`
 }
 
 type EmbedAuthor struct {
@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
@@ -92,6 +94,186 @@ func TestAnnounce(t *testing.T) {
 	require.NoError(t, Pipe{}.Announce(ctx))
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "Honk",
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+
+	var wm WebhookMessageCreate
+	require.NoError(t, json.Unmarshal([]byte(out), &wm))
+	require.Equal(t, WebhookMessageCreate{
+		Embeds: []Embed{{
+			Description: "Honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0",
+			Color:       3888754,
+			Author: &EmbedAuthor{
+				Name:    defaultAuthor,
+				IconURL: defaultIcon,
+			},
+		}},
+	}, wm)
+}
+
+func TestAnnounceRichEmbed(t *testing.T) {
+	var wm WebhookMessageCreate
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &wm))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Release: config.Release{
+			GitHub: config.Repo{
+				Owner: "goreleaser",
+				Name:  "foo",
+			},
+		},
+		GitHubURLs: config.GitHubURLs{
+			Download: "https://github.com",
+		},
+		Announce: config.Announce{
+			Discord: config.Discord{
+				ThumbnailURL: "https://example.com/{{ .ProjectName }}.png",
+				Fields: []config.DiscordField{
+					{Name: "Version", Value: "{{ .Version }}", Inline: true},
+					{Name: "Empty", Value: "{{ .Env.NOPE }}"},
+				},
+				IncludeArtifacts: true,
+				Buttons: []config.AnnounceButton{
+					{Title: "Release", URL: "{{ .ReleaseURL }}"},
+					{Title: "Docs", URL: "https://example.com/docs"},
+				},
+			},
+		},
+	}, testctx.GitHubTokenType, testctx.WithCurrentTag("v1.0.0"), testctx.WithVersion("1.0.0"), testctx.WithEnv(map[string]string{
+		"NOPE": "",
+	}))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo_linux_amd64.tar.gz",
+		Type: artifact.UploadableArchive,
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "checksums.txt",
+		Type: artifact.Checksum,
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "checksums.txt.sig",
+		Type: artifact.Signature,
+	})
+
+	t.Setenv("DISCORD_API", ts.URL)
+	t.Setenv("DISCORD_WEBHOOK_ID", "id")
+	t.Setenv("DISCORD_WEBHOOK_TOKEN", "token")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+
+	require.Equal(t, "with_components=true", query)
+	require.Len(t, wm.Embeds, 1)
+	require.Equal(t, &EmbedThumbnail{URL: "https://example.com/foo.png"}, wm.Embeds[0].Thumbnail)
+	require.Equal(t, []EmbedField{
+		{Name: "Version", Value: "1.0.0", Inline: true},
+		{Name: "Downloads", Value: "[foo_linux_amd64.tar.gz](https://github.com/goreleaser/foo/releases/download/v1.0.0/foo_linux_amd64.tar.gz)"},
+		{Name: "Checksums", Value: "[checksums.txt](https://github.com/goreleaser/foo/releases/download/v1.0.0/checksums.txt)"},
+	}, wm.Embeds[0].Fields)
+	require.Equal(t, []Component{{
+		Type: componentTypeActionRow,
+		Components: []Component{{
+			Type:  componentTypeButton,
+			Style: buttonStyleLink,
+			Label: "Docs",
+			URL:   "https://example.com/docs",
+		}},
+	}}, wm.Components)
+}
+
+func TestButtons(t *testing.T) {
+	t.Run("rows", func(t *testing.T) {
+		var btns []config.AnnounceButton
+		for i := range 7 {
+			btns = append(btns, config.AnnounceButton{
+				Title: strconv.Itoa(i),
+				URL:   "https://example.com",
+			})
+		}
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Discord: config.Discord{Buttons: btns},
+			},
+		})
+		rows, err := buttons(ctx)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Len(t, rows[0].Components, 5)
+		require.Len(t, rows[1].Components, 2)
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		var btns []config.AnnounceButton
+		for range 26 {
+			btns = append(btns, config.AnnounceButton{
+				Title: "a",
+				URL:   "https://example.com",
+			})
+		}
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Discord: config.Discord{Buttons: btns},
+			},
+		})
+		_, err := buttons(ctx)
+		require.EqualError(t, err, "discord: messages can have at most 25 buttons, got 26")
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Discord: config.Discord{
+					Buttons: []config.AnnounceButton{{Title: "a", URL: "{{ .Foo }"}},
+				},
+			},
+		})
+		_, err := buttons(ctx)
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestEnrichEmbedInvalidTemplate(t *testing.T) {
+	for name, discord := range map[string]config.Discord{
+		"thumbnail": {ThumbnailURL: "{{ .Foo }"},
+		"field":     {Fields: []config.DiscordField{{Name: "a", Value: "{{ .Foo }"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{Discord: discord},
+			})
+			testlib.RequireTemplateError(t, enrichEmbed(ctx, &Embed{}))
+		})
+	}
+}
+
+func TestJoinField(t *testing.T) {
+	require.Empty(t, joinField(nil))
+	require.Equal(t, "a\nb", joinField([]string{"a", "b"}))
+
+	lines := make([]string, 100)
+	for i := range lines {
+		lines[i] = strings.Repeat("a", 20)
+	}
+	result := joinField(lines)
+	require.LessOrEqual(t, len(result), maxFieldValueLen)
+	require.True(t, strings.HasSuffix(result, ellipsis))
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -48,38 +49,27 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
-	title, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Discourse.TitleTemplate)
+// Preview returns the topic that would be created.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	pr, err := newPostsRequest(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
+	bts, err := json.MarshalIndent(pr, "", "  ")
+	return string(bts), err
+}
 
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Discourse.MessageTemplate)
+func (p Pipe) Announce(ctx *context.Context) error {
+	pr, err := newPostsRequest(ctx)
 	if err != nil {
 		return err
 	}
 
-	// Make 'server' a required config field.
-	if ctx.Config.Announce.Discourse.Server == "" {
-		return errors.New("'server' is a required config key")
-	}
-
-	// Make 'category_id' a required config field.
-	if ctx.Config.Announce.Discourse.CategoryID == 0 {
-		return errors.New("'category_id' is a required config key")
-	}
-
 	cfg, err := env.ParseAs[Config]()
 	if err != nil {
 		return err
 	}
 
-	pr := &postsRequest{
-		Title:    title,
-		Raw:      msg,
-		Category: ctx.Config.Announce.Discourse.CategoryID,
-	}
-
 	endpoint := ctx.Config.Announce.Discourse.Server + "/posts.json"
 
 	payload, err := json.Marshal(pr)
@@ -87,6 +77,11 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return err
 	}
 
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
 	return retryx.Do(ctx, ctx.Config.Retry, func() error {
 		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
 		if err != nil {
@@ -97,7 +92,7 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		req.Header.Set("Api-Username", ctx.Config.Announce.Discourse.Username)
 		req.Header.Set("Api-Key", cfg.APIKey)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := httpClient.Do(req)
 		if err != nil {
 			return retryx.HTTP(err, resp)
 		}
@@ -110,3 +105,31 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return nil
 	}, retryx.IsRetriable)
 }
+
+func newPostsRequest(ctx *context.Context) (*postsRequest, error) {
+	title, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Discourse.TitleTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Discourse.MessageTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make 'server' a required config field.
+	if ctx.Config.Announce.Discourse.Server == "" {
+		return nil, errors.New("'server' is a required config key")
+	}
+
+	// Make 'category_id' a required config field.
+	if ctx.Config.Announce.Discourse.CategoryID == 0 {
+		return nil, errors.New("'category_id' is a required config key")
+	}
+
+	return &postsRequest{
+		Title:    title,
+		Raw:      msg,
+		Category: ctx.Config.Announce.Discourse.CategoryID,
+	}, nil
+}
@@ -59,6 +59,38 @@ func TestAnnounce(t *testing.T) {
 	require.NoError(t, Pipe{}.Announce(ctx))
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "Honk",
+		Announce: config.Announce{
+			Discourse: config.Discourse{
+				Server:     "http://forum.example.com",
+				CategoryID: 4,
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+
+	var pr postsRequest
+	require.NoError(t, json.Unmarshal([]byte(out), &pr))
+	require.Equal(t, postsRequest{
+		Title:    "Honk v1.0.0 is out!",
+		Raw:      "Honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0",
+		Category: 4,
+	}, pr)
+}
+
+func TestPreviewMissingServer(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	_, err := Pipe{}.Preview(ctx)
+	require.EqualError(t, err, "'server' is a required config key")
+}
+
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
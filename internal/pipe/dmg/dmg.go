@@ -0,0 +1,368 @@
+// Package dmg implements the Pipe interface providing macOS disk image
+// creation.
+package dmg
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/extrafiles"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/redact"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultNameTemplate = `{{ .ProjectName }}_{{ .Arch }}`
+	backgroundDir       = ".background"
+	applicationsLink    = "Applications"
+
+	useBinary    = "binary"
+	useAppBundle = "appbundle"
+)
+
+// goos is the operating system GoReleaser is running on, which decides the
+// tool used to create the images.
+var goos = runtime.GOOS
+
+// Pipe for DMG packaging.
+type Pipe struct{}
+
+func (Pipe) String() string { return "macOS disk images" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.DMG) || len(ctx.Config.DMGs) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	if len(ctx.Config.DMGs) == 0 {
+		return nil
+	}
+	if goos == "darwin" {
+		return []string{"hdiutil"}
+	}
+	return []string{"mkisofs"}
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("dmg")
+	for i := range ctx.Config.DMGs {
+		cfg := &ctx.Config.DMGs[i]
+		if cfg.ID == "" {
+			cfg.ID = ctx.Config.ProjectName
+		}
+		if cfg.Name == "" {
+			cfg.Name = defaultNameTemplate
+		}
+		if cfg.VolumeName == "" {
+			cfg.VolumeName = "{{ .ProjectName }}"
+		}
+		if cfg.Goamd64 == "" {
+			cfg.Goamd64 = "v1"
+		}
+		switch cfg.Use {
+		case "":
+			cfg.Use = useBinary
+		case useBinary, useAppBundle:
+		default:
+			return fmt.Errorf("dmg: invalid use: %q, valid options are %q and %q", cfg.Use, useBinary, useAppBundle)
+		}
+		ids.Inc(cfg.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for _, cfg := range ctx.Config.DMGs {
+		g.Go(func() error {
+			return doRun(ctx, cfg)
+		})
+	}
+	return g.Wait()
+}
+
+func doRun(ctx *context.Context, cfg config.DMG) error {
+	disable, err := tmpl.New(ctx).Bool(cfg.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("disabled")
+	}
+
+	groups := getArtifacts(ctx, cfg)
+	if len(groups) == 0 {
+		if cfg.Use == useAppBundle {
+			return pipe.Skipf("no app bundles found for ids %v", cfg.IDs)
+		}
+		return pipe.Skipf("no darwin binaries found for builds %v", cfg.IDs)
+	}
+
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for plat, binaries := range groups {
+		g.Go(func() error {
+			return create(ctx, cfg, plat, binaries)
+		})
+	}
+	return g.Wait()
+}
+
+func create(ctx *context.Context, cfg config.DMG, plat string, binaries []*artifact.Artifact) error {
+	binary := binaries[0]
+	tpl := tmpl.New(ctx).
+		WithArtifact(binary)
+
+	name := cfg.Name
+	volumeName := cfg.VolumeName
+	background := cfg.Background
+	modTimestamp := cfg.ModTimestamp
+	if err := tpl.ApplyAll(
+		&name,
+		&volumeName,
+		&background,
+		&modTimestamp,
+	); err != nil {
+		return err
+	}
+
+	dir, err := setupContext(ctx, cfg, plat, background, binaries)
+	if err != nil {
+		return err
+	}
+
+	filename := name + ".dmg"
+	path := filepath.Join(ctx.Config.Dist, filename)
+	log := log.WithField("image", filename).WithField("dir", dir)
+	log.Info("creating disk image")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if goos == "darwin" {
+		err = createHdiutil(ctx, cfg, dir, path, volumeName, background)
+	} else {
+		if background != "" {
+			log.Warn("the background image is only shown in images created on macOS")
+		}
+		err = run(ctx, cfg, "could not create disk image", "mkisofs",
+			"-V", volumeName,
+			"-D", "-R", "-apple", "-no-pad",
+			"-o", path,
+			dir,
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := gio.Chtimes(path, modTimestamp); err != nil {
+		return err
+	}
+
+	if err := signAndNotarize(ctx, path, binaries); err != nil {
+		return err
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.DMG,
+		Name:    filename,
+		Path:    path,
+		Goos:    binary.Goos,
+		Goarch:  binary.Goarch,
+		Goamd64: binary.Goamd64,
+		Goarm64: binary.Goarm64,
+		Target:  binary.Target,
+		Extra: map[string]any{
+			artifact.ExtraID:     cfg.ID,
+			artifact.ExtraFormat: "dmg",
+			artifact.ExtraExt:    ".dmg",
+		},
+	})
+	return nil
+}
+
+// createHdiutil creates the image using hdiutil.
+//
+// If there is a background image, a writable image is created first, so
+// Finder can style its window, and then converted into a compressed one.
+func createHdiutil(ctx *context.Context, cfg config.DMG, dir, path, volumeName, background string) error {
+	if background == "" {
+		return run(ctx, cfg, "could not create disk image", "hdiutil", "create",
+			"-volname", volumeName,
+			"-srcfolder", dir,
+			"-ov",
+			"-format", "UDZO",
+			path,
+		)
+	}
+
+	rw := path + ".rw.dmg"
+	defer os.Remove(rw)
+	if err := run(ctx, cfg, "could not create disk image", "hdiutil", "create",
+		"-volname", volumeName,
+		"-srcfolder", dir,
+		"-ov",
+		"-format", "UDRW",
+		rw,
+	); err != nil {
+		return err
+	}
+
+	mountpoint := filepath.Join(filepath.Dir(dir), filepath.Base(dir)+".mnt")
+	if err := run(ctx, cfg, "could not attach disk image", "hdiutil", "attach",
+		"-readwrite", "-noverify", "-noautoopen",
+		"-mountpoint", mountpoint,
+		rw,
+	); err != nil {
+		return err
+	}
+	styleErr := run(ctx, cfg, "could not set disk image background", "osascript",
+		"-e", styleScript(volumeName, filepath.Base(background)),
+	)
+	if err := run(ctx, cfg, "could not detach disk image", "hdiutil", "detach", mountpoint); err != nil {
+		return err
+	}
+	if styleErr != nil {
+		return styleErr
+	}
+
+	return run(ctx, cfg, "could not create disk image", "hdiutil", "convert",
+		rw,
+		"-format", "UDZO",
+		"-o", path,
+	)
+}
+
+// styleScript returns the AppleScript that sets the background of the Finder
+// window of the given mounted disk.
+func styleScript(disk, background string) string {
+	return strings.Join([]string{
+		`tell application "Finder"`,
+		fmt.Sprintf(`tell disk %q`, disk),
+		`open`,
+		`set current view of container window to icon view`,
+		`set toolbar visible of container window to false`,
+		`set statusbar visible of container window to false`,
+		`set viewOptions to the icon view options of container window`,
+		`set arrangement of viewOptions to not arranged`,
+		fmt.Sprintf(`set background picture of viewOptions to file %q`, backgroundDir+":"+background),
+		`update without registering applications`,
+		`close`,
+		`end tell`,
+		`end tell`,
+	}, "\n")
+}
+
+func setupContext(
+	ctx *context.Context,
+	cfg config.DMG,
+	plat, background string,
+	binaries []*artifact.Artifact,
+) (string, error) {
+	dir := filepath.Join(ctx.Config.Dist, "dmg", cfg.ID, plat)
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	for _, binary := range binaries {
+		dst := filepath.Join(dir, binary.Name)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return "", fmt.Errorf("failed to create directory for %s: %w", binary.Name, err)
+		}
+		if err := gio.Copy(binary.Path, dst); err != nil {
+			return "", fmt.Errorf("failed to copy %s: %w", binary.Name, err)
+		}
+	}
+
+	files, err := extrafiles.Find(ctx, cfg.ExtraFiles)
+	if err != nil {
+		return "", err
+	}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err := gio.Copy(files[name], filepath.Join(dir, name)); err != nil {
+			return "", fmt.Errorf("failed to copy file %s: %w", files[name], err)
+		}
+	}
+
+	if background != "" {
+		if err := os.MkdirAll(filepath.Join(dir, backgroundDir), 0o755); err != nil {
+			return "", fmt.Errorf("failed to create directory for background: %w", err)
+		}
+		dst := filepath.Join(dir, backgroundDir, filepath.Base(background))
+		if err := gio.Copy(background, dst); err != nil {
+			return "", fmt.Errorf("failed to copy background %s: %w", background, err)
+		}
+	}
+
+	if err := os.Symlink("/Applications", filepath.Join(dir, applicationsLink)); err != nil {
+		return "", fmt.Errorf("failed to create applications link: %w", err)
+	}
+	return dir, nil
+}
+
+func run(ctx *context.Context, cfg config.DMG, msg, name string, arg ...string) error {
+	cmd := exec.CommandContext(ctx, name, arg...)
+	cmd.Env = append(ctx.Env.Strings(), cmd.Environ()...)
+	var b bytes.Buffer
+	w := gio.Safe(&b)
+	cmd.Stderr = redact.Writer(io.MultiWriter(logext.NewWriter(), w), cmd.Env)
+	cmd.Stdout = redact.Writer(io.MultiWriter(logext.NewWriter(), w), cmd.Env)
+	if err := cmd.Run(); err != nil {
+		return gerrors.Wrap(
+			err,
+			gerrors.WithMessage(msg),
+			gerrors.WithDetails(
+				"args", strings.Join(cmd.Args, " "),
+				"id", cfg.ID,
+			),
+			gerrors.WithOutput(b.String()),
+		)
+	}
+	return nil
+}
+
+func getArtifacts(ctx *context.Context, cfg config.DMG) map[string][]*artifact.Artifact {
+	types := artifact.ByTypes(
+		artifact.Binary,
+		artifact.UniversalBinary,
+	)
+	if cfg.Use == useAppBundle {
+		types = artifact.ByType(artifact.AppBundle)
+	}
+	filters := []artifact.Filter{
+		artifact.ByGoos("darwin"),
+		types,
+		artifact.ByIDs(cfg.IDs...),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("amd64")),
+			artifact.ByGoamd64(cfg.Goamd64),
+		),
+	}
+	return ctx.Artifacts.
+		Filter(artifact.And(filters...)).
+		GroupByPlatform()
+}
@@ -0,0 +1,300 @@
+package dmg
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.Equal(t, "macOS disk images", Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DMGs: []config.DMG{{}},
+		}, testctx.Skip(skips.DMG))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DMGs: []config.DMG{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("skip no dmgs", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.Empty(t, Pipe{}.Dependencies(ctx))
+
+	ctx.Config.DMGs = []config.DMG{{}}
+	testlib.Set(t, &goos, "linux")
+	require.Equal(t, []string{"mkisofs"}, Pipe{}.Dependencies(ctx))
+	testlib.Set(t, &goos, "darwin")
+	require.Equal(t, []string{"hdiutil"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "myproj",
+		DMGs: []config.DMG{
+			{},
+			{
+				ID:         "custom",
+				Name:       "custom_{{ .Arch }}",
+				VolumeName: "Custom",
+				Goamd64:    "v3",
+				Use:        "appbundle",
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	d1 := ctx.Config.DMGs[0]
+	require.Equal(t, "myproj", d1.ID)
+	require.Equal(t, defaultNameTemplate, d1.Name)
+	require.Equal(t, "{{ .ProjectName }}", d1.VolumeName)
+	require.Equal(t, "v1", d1.Goamd64)
+	require.Equal(t, "binary", d1.Use)
+
+	d2 := ctx.Config.DMGs[1]
+	require.Equal(t, "custom", d2.ID)
+	require.Equal(t, "custom_{{ .Arch }}", d2.Name)
+	require.Equal(t, "Custom", d2.VolumeName)
+	require.Equal(t, "v3", d2.Goamd64)
+	require.Equal(t, "appbundle", d2.Use)
+}
+
+func TestDefaultInvalidUse(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "myproj",
+		DMGs:        []config.DMG{{Use: "nope"}},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), `dmg: invalid use: "nope", valid options are "binary" and "appbundle"`)
+}
+
+func TestDefaultDuplicateID(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "myproj",
+		DMGs:        []config.DMG{{}, {}},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "found 2 dmg with the ID 'myproj', please fix your config")
+}
+
+func TestRunMkisofs(t *testing.T) {
+	testlib.Set(t, &goos, "linux")
+	log := fakeCommands(t, 0, "mkisofs")
+	ctx := makeContext(t)
+	ctx.Config.DMGs = []config.DMG{{
+		VolumeName: "{{ .ProjectName }} {{ .Version }}",
+		Background: "./testdata/background.png",
+		ExtraFiles: []config.ExtraFile{
+			{Glob: "./testdata/README.md"},
+		},
+	}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	images := ctx.Artifacts.Filter(artifact.ByType(artifact.DMG)).List()
+	require.Len(t, images, 2)
+	for _, image := range images {
+		require.Equal(t, "darwin", image.Goos)
+		require.Equal(t, "myproj_"+image.Goarch+".dmg", image.Name)
+		require.Equal(t, filepath.Join(ctx.Config.Dist, image.Name), image.Path)
+		require.FileExists(t, image.Path)
+		require.Equal(t, "myproj", image.ID())
+		require.Equal(t, "dmg", artifact.ExtraOr(*image, artifact.ExtraFormat, ""))
+
+		dir := filepath.Join(ctx.Config.Dist, "dmg", "myproj", "darwin"+image.Goarch)
+		require.FileExists(t, filepath.Join(dir, "mybin"))
+		require.FileExists(t, filepath.Join(dir, "README.md"))
+		require.FileExists(t, filepath.Join(dir, ".background", "background.png"))
+		link, err := os.Readlink(filepath.Join(dir, "Applications"))
+		require.NoError(t, err)
+		require.Equal(t, "/Applications", link)
+	}
+
+	cmds := testlib.ReadFakeLog(t, log)
+	require.Len(t, cmds, 2)
+	require.Contains(t, cmds, "mkisofs -V myproj 1.2.3 -D -R -apple -no-pad -o "+
+		filepath.Join(ctx.Config.Dist, "myproj_arm64.dmg")+" "+
+		filepath.Join(ctx.Config.Dist, "dmg", "myproj", "darwinarm64"))
+}
+
+func TestRunHdiutil(t *testing.T) {
+	testlib.Set(t, &goos, "darwin")
+	log := fakeCommands(t, 0, "hdiutil", "osascript")
+	ctx := makeContext(t)
+	ctx.Config.DMGs = []config.DMG{{
+		Name: "{{ .ProjectName }}",
+	}}
+	ctx.Artifacts = artifact.New()
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "mybin",
+		Path:   filepath.Join(t.TempDir(), "mybin"),
+		Type:   artifact.UniversalBinary,
+		Goos:   "darwin",
+		Goarch: "all",
+	})
+	require.NoError(t, os.WriteFile(ctx.Artifacts.List()[0].Path, []byte("bin"), 0o755))
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	images := ctx.Artifacts.Filter(artifact.ByType(artifact.DMG)).List()
+	require.Len(t, images, 1)
+	require.Equal(t, "myproj.dmg", images[0].Name)
+	require.Equal(t, "all", images[0].Goarch)
+
+	path := filepath.Join(ctx.Config.Dist, "myproj.dmg")
+	dir := filepath.Join(ctx.Config.Dist, "dmg", "myproj", "darwinall")
+	require.Equal(t, []string{
+		"hdiutil create -volname myproj -srcfolder " + dir + " -ov -format UDZO " + path,
+	}, testlib.ReadFakeLog(t, log))
+}
+
+func TestRunHdiutilBackground(t *testing.T) {
+	testlib.Set(t, &goos, "darwin")
+	log := fakeCommands(t, 0, "hdiutil", "osascript")
+	ctx := makeContext(t)
+	ctx.Config.DMGs = []config.DMG{{
+		Name:       "{{ .ProjectName }}_{{ .Arch }}",
+		Background: "./testdata/background.png",
+	}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	path := filepath.Join(ctx.Config.Dist, "myproj_arm64.dmg")
+	dir := filepath.Join(ctx.Config.Dist, "dmg", "myproj", "darwinarm64")
+	cmds := testlib.ReadFakeLog(t, log)
+	require.Contains(t, cmds, "hdiutil create -volname myproj -srcfolder "+dir+" -ov -format UDRW "+path+".rw.dmg")
+	require.Contains(t, cmds, "hdiutil attach -readwrite -noverify -noautoopen -mountpoint "+dir+".mnt "+path+".rw.dmg")
+	require.Contains(t, cmds, "hdiutil detach "+dir+".mnt")
+	require.Contains(t, cmds, "hdiutil convert "+path+".rw.dmg -format UDZO -o "+path)
+	require.Contains(t, cmds, "osascript -e "+styleScript("myproj", "background.png"))
+	require.NoFileExists(t, path+".rw.dmg")
+}
+
+func TestRunAppBundle(t *testing.T) {
+	testlib.Set(t, &goos, "linux")
+	fakeCommands(t, 0, "mkisofs")
+	ctx := makeContext(t)
+	app := filepath.Join(t.TempDir(), "MyApp.app")
+	require.NoError(t, os.MkdirAll(filepath.Join(app, "Contents", "MacOS"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(app, "Contents", "MacOS", "mybin"), []byte("bin"), 0o755))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "MyApp.app",
+		Path:   app,
+		Type:   artifact.AppBundle,
+		Goos:   "darwin",
+		Goarch: "arm64",
+		Extra: map[string]any{
+			artifact.ExtraID: "myapp",
+		},
+	})
+	ctx.Config.DMGs = []config.DMG{{Use: "appbundle"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	images := ctx.Artifacts.Filter(artifact.ByType(artifact.DMG)).List()
+	require.Len(t, images, 1)
+	require.Equal(t, "myproj_arm64.dmg", images[0].Name)
+
+	dir := filepath.Join(ctx.Config.Dist, "dmg", "myproj", "darwinarm64")
+	require.FileExists(t, filepath.Join(dir, "MyApp.app", "Contents", "MacOS", "mybin"))
+	require.NoFileExists(t, filepath.Join(dir, "mybin"))
+}
+
+func TestRunAppBundleNone(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.DMGs = []config.DMG{{Use: "appbundle"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunFails(t *testing.T) {
+	testlib.Set(t, &goos, "linux")
+	fakeCommands(t, 1, "mkisofs")
+	ctx := makeContext(t)
+	ctx.Config.DMGs = []config.DMG{{}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	err := Pipe{}.Run(ctx)
+	require.Error(t, err)
+	var gerr gerrors.ErrDetailed
+	require.ErrorAs(t, err, &gerr)
+	require.Equal(t, []string{"could not create disk image"}, gerr.Messages())
+}
+
+func TestRunNoBinaries(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.DMGs = []config.DMG{{IDs: []string{"nope"}}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.DMGs = []config.DMG{{Disable: "{{ eq .Version \"1.2.3\" }}"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunBadTemplate(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.DMGs = []config.DMG{{VolumeName: "{{ .Nope }}"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+}
+
+// fakeCommands puts fake commands in the PATH, which log their arguments,
+// create the file given to '-o' or as the last argument, and exit with the
+// given code.
+func fakeCommands(tb testing.TB, exitCode int, names ...string) string {
+	tb.Helper()
+	scripts := map[string]string{}
+	for _, name := range names {
+		scripts[name] = `out=""
+for arg in "$@"; do
+	if [ "$prev" = "-o" ]; then
+		out="$arg"
+	fi
+	prev="$arg"
+done
+case "$1" in
+	create) for arg in "$@"; do out="$arg"; done ;;
+esac
+if [ -n "$out" ]; then
+	echo dmg > "$out"
+fi
+exit ` + strconv.Itoa(exitCode) + `
+`
+	}
+	return testlib.FakeCommands(tb, scripts)
+}
+
+func makeContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "myproj",
+		Dist:        tb.TempDir(),
+	}, testctx.WithVersion("1.2.3"))
+	testlib.AddFakeBinaries(tb, ctx, "myproj", []string{"linux", "darwin"}, []string{"amd64", "arm64"}, "mybin")
+	return ctx
+}
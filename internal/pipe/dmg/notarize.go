@@ -0,0 +1,43 @@
+package dmg
+
+import (
+	"path/filepath"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/notary"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// signAndNotarize signs and notarizes the image at path using the same
+// notarize configuration used for the binaries inside it.
+func signAndNotarize(ctx *context.Context, path string, binaries []*artifact.Artifact) error {
+	ids := make([]string, 0, len(binaries))
+	for _, bin := range binaries {
+		ids = append(ids, bin.ID())
+	}
+	ncfg, ok, err := notary.ConfigFor(ctx, ids...)
+	if err != nil || !ok {
+		return err
+	}
+
+	log := log.WithField("image", filepath.Base(path))
+	if goos == "darwin" {
+		log.Info("signing")
+		if err := notary.Codesign(ctx, ncfg.Sign, path); err != nil {
+			return err
+		}
+	} else {
+		log.Warn("disk images can only be signed on macOS")
+	}
+
+	accepted, err := notary.Notarize(ctx, ncfg.Notarize, path)
+	if err != nil || !accepted {
+		return err
+	}
+	if goos != "darwin" {
+		log.Warn("notarization tickets can only be stapled on macOS")
+		return nil
+	}
+	return notary.Staple(ctx, path)
+}
@@ -0,0 +1,63 @@
+package docker
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+func init() {
+	registerImager(useNerdctl, nerdctlImager{})
+}
+
+// nerdctlDigestPattern matches the digest of the pushed index or manifest in
+// the output of nerdctl push, e.g. 'manifest-sha256:abc...: done'.
+var nerdctlDigestPattern = regexp.MustCompile(`(index|manifest)-(sha256:[a-f0-9]{64})`)
+
+type nerdctlImager struct{}
+
+func (i nerdctlImager) Push(ctx *context.Context, image string, flags []string) (string, error) {
+	args := []string{"push"}
+	args = append(args, flags...)
+	args = append(args, image)
+	bts, err := runCommandWithOutput(ctx, ".", "nerdctl", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to push %s: %w", image, err)
+	}
+	digest := nerdctlDigest(string(bts))
+	if digest == "" {
+		return "", fmt.Errorf("failed to find docker digest in nerdctl push output: %s", string(bts))
+	}
+	return digest, nil
+}
+
+func (i nerdctlImager) Build(ctx *context.Context, root string, images, flags []string) error {
+	if err := runCommand(ctx, root, "nerdctl", i.buildCommand(images, flags)...); err != nil {
+		return fmt.Errorf("failed to build %s: %w", images[0], err)
+	}
+	return nil
+}
+
+func (i nerdctlImager) buildCommand(images, flags []string) []string {
+	base := []string{"build", "."}
+	for _, image := range images {
+		base = append(base, "-t", image)
+	}
+	return append(base, flags...)
+}
+
+// nerdctlDigest returns the digest of the pushed index, if any, or of the
+// pushed manifest otherwise.
+func nerdctlDigest(out string) string {
+	var digest string
+	for _, match := range nerdctlDigestPattern.FindAllStringSubmatch(out, -1) {
+		if match[1] == "index" {
+			return match[2]
+		}
+		if digest == "" {
+			digest = match[2]
+		}
+	}
+	return digest
+}
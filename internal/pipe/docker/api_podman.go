@@ -0,0 +1,99 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+func init() {
+	registerManifester(usePodman, podmanManifester{})
+
+	registerImager(usePodman, podmanImager{})
+}
+
+type podmanManifester struct{}
+
+func (m podmanManifester) Create(ctx *context.Context, manifest string, images, flags []string) error {
+	_ = runCommand(ctx, ".", "podman", "manifest", "rm", manifest)
+
+	args := []string{"manifest", "create", manifest}
+	args = append(args, images...)
+	args = append(args, flags...)
+
+	if err := runCommand(ctx, ".", "podman", args...); err != nil {
+		return fmt.Errorf("failed to create %s: %w", manifest, err)
+	}
+	return nil
+}
+
+// Push pushes the manifest list and all the images it references.
+//
+// Unlike docker, podman does not print the digest, so we ask it to write it
+// to a file instead.
+func (m podmanManifester) Push(ctx *context.Context, manifest string, flags []string) (string, error) {
+	return pushWithDigestFile(func(digestFile string) error {
+		args := []string{"manifest", "push", "--all", "--digestfile=" + digestFile}
+		args = append(args, flags...)
+		args = append(args, manifest, "docker://"+manifest)
+		if err := runCommand(ctx, ".", "podman", args...); err != nil {
+			return fmt.Errorf("failed to push %s: %w", manifest, err)
+		}
+		return nil
+	})
+}
+
+type podmanImager struct{}
+
+func (i podmanImager) Push(ctx *context.Context, image string, flags []string) (string, error) {
+	return pushWithDigestFile(func(digestFile string) error {
+		args := []string{"push", "--digestfile=" + digestFile}
+		args = append(args, flags...)
+		args = append(args, image)
+		if err := runCommand(ctx, ".", "podman", args...); err != nil {
+			return fmt.Errorf("failed to push %s: %w", image, err)
+		}
+		return nil
+	})
+}
+
+func (i podmanImager) Build(ctx *context.Context, root string, images, flags []string) error {
+	if err := runCommand(ctx, root, "podman", i.buildCommand(images, flags)...); err != nil {
+		return fmt.Errorf("failed to build %s: %w", images[0], err)
+	}
+	return nil
+}
+
+func (i podmanImager) buildCommand(images, flags []string) []string {
+	base := []string{"build", "."}
+	for _, image := range images {
+		base = append(base, "-t", image)
+	}
+	return append(base, flags...)
+}
+
+// pushWithDigestFile runs the given push function with the path of a file
+// the digest should be written to, and returns its contents.
+func pushWithDigestFile(push func(digestFile string) error) (string, error) {
+	dir, err := os.MkdirTemp("", "goreleaser-digest-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	digestFile := filepath.Join(dir, "digest")
+	if err := push(digestFile); err != nil {
+		return "", err
+	}
+	bts, err := os.ReadFile(digestFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read digest: %w", err)
+	}
+	digest := dockerDigestPattern.FindString(strings.TrimSpace(string(bts)))
+	if digest == "" {
+		return "", fmt.Errorf("failed to find digest in %q", string(bts))
+	}
+	return digest, nil
+}
@@ -35,8 +35,10 @@ import (
 const (
 	dockerConfigExtra = "DockerConfig"
 
-	useBuildx = "buildx"
-	useDocker = "docker"
+	useBuildx  = "buildx"
+	useDocker  = "docker"
+	usePodman  = "podman"
+	useNerdctl = "nerdctl"
 )
 
 // Pipe for docker.
@@ -57,6 +59,8 @@ func (Pipe) Dependencies(ctx *context.Context) []string {
 		case useDocker, useBuildx:
 			cmds = append(cmds, "docker")
 			// TODO: how to check if buildx is installed
+		case usePodman, useNerdctl:
+			cmds = append(cmds, s.Use)
 		}
 	}
 	return cmds
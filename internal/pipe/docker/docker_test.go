@@ -1154,6 +1154,60 @@ func TestBuildCommand(t *testing.T) {
 	}
 }
 
+func TestPodmanAndNerdctlBuildCommand(t *testing.T) {
+	images := []string{"goreleaser/test_build_flag", "goreleaser/test_multiple_tags"}
+	flags := []string{"--label=foo", "--platform=linux/arm64"}
+	expect := []string{"build", ".", "-t", images[0], "-t", images[1], "--label=foo", "--platform=linux/arm64"}
+	require.Equal(t, expect, podmanImager{}.buildCommand(images, flags))
+	require.Equal(t, expect, nerdctlImager{}.buildCommand(images, flags))
+}
+
+func TestNerdctlDigest(t *testing.T) {
+	const (
+		index    = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+		manifest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+		layer    = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+	)
+	t.Run("manifest", func(t *testing.T) {
+		out := "layer-" + layer + ": done\nmanifest-" + manifest + ": done\nconfig-" + layer + ": done\n"
+		require.Equal(t, manifest, nerdctlDigest(out))
+	})
+	t.Run("index", func(t *testing.T) {
+		out := "manifest-" + manifest + ": done\nindex-" + index + ": done\n"
+		require.Equal(t, index, nerdctlDigest(out))
+	})
+	t.Run("none", func(t *testing.T) {
+		require.Empty(t, nerdctlDigest("layer-"+layer+": done"))
+	})
+}
+
+func TestPushWithDigestFile(t *testing.T) {
+	const digest = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	t.Run("success", func(t *testing.T) {
+		got, err := pushWithDigestFile(func(digestFile string) error {
+			return os.WriteFile(digestFile, []byte(digest+"\n"), 0o644)
+		})
+		require.NoError(t, err)
+		require.Equal(t, digest, got)
+	})
+	t.Run("push error", func(t *testing.T) {
+		_, err := pushWithDigestFile(func(string) error {
+			return errors.New("push failed")
+		})
+		require.EqualError(t, err, "push failed")
+	})
+	t.Run("no digest file", func(t *testing.T) {
+		_, err := pushWithDigestFile(func(string) error { return nil })
+		require.ErrorContains(t, err, "failed to read digest")
+	})
+	t.Run("invalid digest", func(t *testing.T) {
+		_, err := pushWithDigestFile(func(digestFile string) error {
+			return os.WriteFile(digestFile, []byte("nope"), 0o644)
+		})
+		require.ErrorContains(t, err, "failed to find digest")
+	})
+}
+
 func TestDescription(t *testing.T) {
 	require.NotEmpty(t, Pipe{}.String())
 }
@@ -1473,17 +1527,20 @@ func TestDependencies(t *testing.T) {
 		Dockers: []config.Docker{
 			{Use: useBuildx},
 			{Use: useDocker},
+			{Use: usePodman},
+			{Use: useNerdctl},
 			{Use: "nope"},
 		},
 		DockerManifests: []config.DockerManifest{
 			{Use: useBuildx},
 			{Use: useDocker},
+			{Use: usePodman},
 			{Use: "nope"},
 		},
 	})
 
-	require.Equal(t, []string{"docker", "docker"}, Pipe{}.Dependencies(ctx))
-	require.Equal(t, []string{"docker", "docker"}, ManifestPipe{}.Dependencies(ctx))
+	require.Equal(t, []string{"docker", "docker", "podman", "nerdctl"}, Pipe{}.Dependencies(ctx))
+	require.Equal(t, []string{"docker", "docker", "podman"}, ManifestPipe{}.Dependencies(ctx))
 }
 
 func TestIsFileNotFoundError(t *testing.T) {
@@ -1504,7 +1561,9 @@ func TestValidateImager(t *testing.T) {
 	}{
 		{use: "docker"},
 		{use: "buildx"},
-		{use: "notFound", wantError: "invalid use: notFound, valid options are [buildx docker]"},
+		{use: "podman"},
+		{use: "nerdctl"},
+		{use: "notFound", wantError: "invalid use: notFound, valid options are [buildx docker nerdctl podman]"},
 	}
 
 	for _, tt := range tests {
@@ -43,6 +43,8 @@ func (ManifestPipe) Dependencies(ctx *context.Context) []string {
 		case useDocker, useBuildx:
 			cmds = append(cmds, "docker")
 			// TODO: check buildx
+		case usePodman:
+			cmds = append(cmds, usePodman)
 		}
 	}
 	return cmds
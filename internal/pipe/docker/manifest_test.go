@@ -17,7 +17,9 @@ func TestValidateManifester(t *testing.T) {
 		wantError string
 	}{
 		{use: "docker"},
-		{use: "buildx", wantError: "docker manifest: invalid use: buildx, valid options are [docker]"},
+		{use: "podman"},
+		{use: "buildx", wantError: "docker manifest: invalid use: buildx, valid options are [docker podman]"},
+		{use: "nerdctl", wantError: "docker manifest: invalid use: nerdctl, valid options are [docker podman]"},
 	}
 
 	for _, tt := range tests {
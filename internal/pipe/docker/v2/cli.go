@@ -0,0 +1,35 @@
+package docker
+
+import (
+	"bytes"
+
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// cli represents the executor of external commands other than the build
+// itself, e.g. cosign.
+var cli cmder = stdCmd{}
+
+// cmder is a special interface to execute external commands.
+//
+// The intention is to be used to wrap the standard exec and provide the
+// ability to create a fake one for testing.
+type cmder interface {
+	// Exec executes a command, writing the given input, if any, to its
+	// stdin.
+	Exec(*context.Context, []byte, string, ...string) ([]byte, error)
+}
+
+// stdCmd uses [execx.Command].
+type stdCmd struct{}
+
+var _ cmder = &stdCmd{}
+
+func (stdCmd) Exec(ctx *context.Context, input []byte, name string, args ...string) ([]byte, error) {
+	c := execx.Command(ctx, name, args...)
+	if input != nil {
+		c.Stdin = bytes.NewReader(input)
+	}
+	return c.CombinedOutput()
+}
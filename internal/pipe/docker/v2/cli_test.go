@@ -0,0 +1,15 @@
+package docker
+
+import (
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+type fakeCmd struct {
+	execFn func(input []byte, cmd string, args ...string) ([]byte, error)
+}
+
+var _ cmder = fakeCmd{}
+
+func (f fakeCmd) Exec(_ *context.Context, input []byte, cmd string, args ...string) ([]byte, error) {
+	return f.execFn(input, cmd, args...)
+}
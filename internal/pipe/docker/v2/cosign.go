@@ -0,0 +1,155 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/slsa"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// provenanceBuildType is the SLSA build type of the provenance predicates we
+// attest images with.
+const provenanceBuildType = "https://goreleaser.com/customization/package/dockers_v2/"
+
+type cosignOptions struct {
+	key        string
+	sbom       bool
+	provenance bool
+	flags      []string
+}
+
+// cosign signs the images pushed by the given configuration, and attests them
+// with SBOMs and SLSA provenance, if enabled.
+func cosign(ctx *context.Context, d config.DockerV2) error {
+	tpl := tmpl.New(ctx)
+	enabled, err := tpl.Bool(d.Cosign.Enabled)
+	if err != nil {
+		return fmt.Errorf("dockers_v2.cosign.enabled: %w", err)
+	}
+	if !enabled {
+		return nil
+	}
+
+	var opts cosignOptions
+	if opts.key, err = tpl.Apply(d.Cosign.Key); err != nil {
+		return fmt.Errorf("dockers_v2.cosign.key: %w", err)
+	}
+	if opts.sbom, err = tpl.Bool(d.Cosign.SBOM); err != nil {
+		return fmt.Errorf("dockers_v2.cosign.sbom: %w", err)
+	}
+	if opts.provenance, err = tpl.Bool(d.Cosign.Provenance); err != nil {
+		return fmt.Errorf("dockers_v2.cosign.provenance: %w", err)
+	}
+	if opts.flags, err = tpl.Slice(d.Cosign.Flags, tmpl.NonEmpty()); err != nil {
+		return fmt.Errorf("dockers_v2.cosign.flags: %w", err)
+	}
+
+	images := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.DockerImageV2),
+		artifact.ByIDs(d.ID),
+	)).List()
+
+	// images in the same repository share the digest, and thus signatures
+	// and attestations.
+	var refs []string
+	var names []string
+	for _, img := range images {
+		names = append(names, img.Name)
+		ref := digestRef(img.Name, artifact.ExtraOr(*img, artifact.ExtraDigest, ""))
+		if !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+
+	tmp, err := os.MkdirTemp("", "goreleaser-cosign-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	var provenance string
+	if opts.provenance {
+		provenance = filepath.Join(tmp, "provenance.json")
+		predicate := slsa.New(ctx, provenanceBuildType, map[string]any{
+			"id":         d.ID,
+			"dockerfile": d.Dockerfile,
+			"platforms":  d.Platforms,
+			"images":     names,
+		})
+		bts, err := json.MarshalIndent(predicate, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(provenance, bts, 0o644); err != nil {
+			return err
+		}
+	}
+
+	for i, ref := range refs {
+		if err := cosignOne(ctx, ref, opts, tmp, i, provenance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cosignOne(ctx *context.Context, ref string, opts cosignOptions, tmp string, i int, provenance string) error {
+	log := log.WithField("image", ref)
+
+	log.Info("signing")
+	if err := runCosign(ctx, "sign", ref, opts); err != nil {
+		return err
+	}
+
+	if opts.sbom {
+		sbom := filepath.Join(tmp, fmt.Sprintf("sbom-%d.spdx.json", i))
+		log.Info("generating sbom")
+		if out, err := cli.Exec(ctx, nil, "syft", "scan", "registry:"+ref, "-o", "spdx-json="+sbom); err != nil {
+			return fmt.Errorf("failed to generate sbom for %s: %w: %s", ref, err, string(out))
+		}
+		log.Info("attesting sbom")
+		if err := runCosign(ctx, "attest", ref, opts, "--type", "spdxjson", "--predicate", sbom); err != nil {
+			return err
+		}
+	}
+
+	if provenance != "" {
+		log.Info("attesting provenance")
+		if err := runCosign(ctx, "attest", ref, opts, "--type", "slsaprovenance1", "--predicate", provenance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runCosign(ctx *context.Context, command, ref string, opts cosignOptions, args ...string) error {
+	cmdArgs := []string{command, "--yes"}
+	if opts.key != "" {
+		cmdArgs = append(cmdArgs, "--key", opts.key)
+	}
+	cmdArgs = append(cmdArgs, args...)
+	cmdArgs = append(cmdArgs, opts.flags...)
+	cmdArgs = append(cmdArgs, ref)
+	if out, err := cli.Exec(ctx, nil, "cosign", cmdArgs...); err != nil {
+		return fmt.Errorf("failed to %s %s: %w: %s", command, ref, err, string(out))
+	}
+	return nil
+}
+
+// digestRef returns the reference to the given image by digest, e.g.
+// 'ghcr.io/foo/bar@sha256:...' for 'ghcr.io/foo/bar:v1.0.0'.
+func digestRef(image, digest string) string {
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + "@" + digest
+}
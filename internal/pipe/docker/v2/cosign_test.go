@@ -0,0 +1,191 @@
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/slsa"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeDigest = "sha256:2a6ab3b1c7a4b6f5e4b0a8a4e19b7cbb1d0e0f6f0b0e5f8b9d1a2c3d4e5f6a7b"
+
+func TestDigestRef(t *testing.T) {
+	for image, expected := range map[string]string{
+		"ghcr.io/foo/bar:v1.0.0":         "ghcr.io/foo/bar@" + fakeDigest,
+		"localhost:5000/foo/bar:v1.0.0":  "localhost:5000/foo/bar@" + fakeDigest,
+		"localhost:5000/foo/bar":         "localhost:5000/foo/bar@" + fakeDigest,
+		"foo/bar":                        "foo/bar@" + fakeDigest,
+		"docker.io/library/alpine:3.2.1": "docker.io/library/alpine@" + fakeDigest,
+	} {
+		t.Run(image, func(t *testing.T) {
+			require.Equal(t, expected, digestRef(image, fakeDigest))
+		})
+	}
+}
+
+func TestCosign(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		calls := fakeCosign(t, nil)
+		ctx := makeCosignContext(t)
+		require.NoError(t, cosign(ctx, config.DockerV2{ID: "foo"}))
+		require.Empty(t, *calls)
+	})
+
+	t.Run("keyless", func(t *testing.T) {
+		calls := fakeCosign(t, nil)
+		ctx := makeCosignContext(t)
+		require.NoError(t, cosign(ctx, config.DockerV2{
+			ID: "foo",
+			Cosign: config.DockerCosign{
+				Enabled: "true",
+			},
+		}))
+		require.Equal(t, [][]string{
+			{"cosign", "sign", "--yes", "ghcr.io/foo/bar@" + fakeDigest},
+			{"cosign", "sign", "--yes", "foo/bar@" + fakeDigest},
+		}, *calls)
+	})
+
+	t.Run("key, sbom and provenance", func(t *testing.T) {
+		var predicate slsa.Predicate
+		calls := fakeCosign(t, func(args []string) ([]byte, error) {
+			if idx := slices.Index(args, "slsaprovenance1"); idx > 0 {
+				bts, err := os.ReadFile(args[idx+2])
+				require.NoError(t, err)
+				require.NoError(t, json.Unmarshal(bts, &predicate))
+			}
+			return []byte("ok"), nil
+		})
+		ctx := makeCosignContext(t)
+		require.NoError(t, cosign(ctx, config.DockerV2{
+			ID:         "foo",
+			Dockerfile: "Dockerfile",
+			Platforms:  []string{"linux/amd64"},
+			Cosign: config.DockerCosign{
+				Enabled:    "{{ not .IsSnapshot }}",
+				Key:        "{{ .Env.COSIGN_KEY }}",
+				SBOM:       "true",
+				Provenance: "true",
+				Flags:      []string{"--tlog-upload=false"},
+			},
+		}))
+
+		ref := "ghcr.io/foo/bar@" + fakeDigest
+		require.Len(t, *calls, 8)
+		require.Equal(t, []string{"cosign", "sign", "--yes", "--key", "env://COSIGN_PRIVATE_KEY", "--tlog-upload=false", ref}, (*calls)[0])
+		require.Equal(t, []string{"syft", "scan", "registry:" + ref, "-o"}, (*calls)[1][:4])
+		sbom := (*calls)[2]
+		require.Equal(t, []string{"cosign", "attest", "--yes", "--key", "env://COSIGN_PRIVATE_KEY", "--type", "spdxjson", "--predicate"}, sbom[:8])
+		require.Equal(t, []string{"--tlog-upload=false", ref}, sbom[9:])
+		require.Equal(t, []string{"cosign", "attest", "--yes", "--key", "env://COSIGN_PRIVATE_KEY", "--type", "slsaprovenance1", "--predicate"}, (*calls)[3][:8])
+
+		require.Equal(t, provenanceBuildType, predicate.BuildDefinition.BuildType)
+		require.Equal(t, "foo", predicate.BuildDefinition.ExternalParameters["id"])
+		require.Equal(t, "Dockerfile", predicate.BuildDefinition.ExternalParameters["dockerfile"])
+		require.ElementsMatch(t, []any{"ghcr.io/foo/bar:v1.0.0", "ghcr.io/foo/bar:latest", "foo/bar:v1.0.0"}, predicate.BuildDefinition.ExternalParameters["images"])
+	})
+
+	t.Run("sign fails", func(t *testing.T) {
+		fakeCosign(t, func([]string) ([]byte, error) {
+			return []byte("error signing"), errors.New("exit status 1")
+		})
+		ctx := makeCosignContext(t)
+		err := cosign(ctx, config.DockerV2{
+			ID:     "foo",
+			Cosign: config.DockerCosign{Enabled: "true"},
+		})
+		require.ErrorContains(t, err, "failed to sign ghcr.io/foo/bar@"+fakeDigest)
+		require.ErrorContains(t, err, "error signing")
+	})
+
+	t.Run("sbom fails", func(t *testing.T) {
+		fakeCosign(t, func(args []string) ([]byte, error) {
+			if args[0] == "syft" {
+				return []byte("error scanning"), errors.New("exit status 1")
+			}
+			return nil, nil
+		})
+		ctx := makeCosignContext(t)
+		err := cosign(ctx, config.DockerV2{
+			ID:     "foo",
+			Cosign: config.DockerCosign{Enabled: "true", SBOM: "true"},
+		})
+		require.ErrorContains(t, err, "failed to generate sbom")
+		require.ErrorContains(t, err, "error scanning")
+	})
+
+	for name, c := range map[string]config.DockerCosign{
+		"enabled":    {Enabled: "{{ .Nope }}"},
+		"key":        {Enabled: "true", Key: "{{ .Nope }}"},
+		"sbom":       {Enabled: "true", SBOM: "{{ .Nope }}"},
+		"provenance": {Enabled: "true", Provenance: "{{ .Nope }}"},
+		"flags":      {Enabled: "true", Flags: []string{"{{ .Nope }}"}},
+	} {
+		t.Run("bad "+name+" template", func(t *testing.T) {
+			fakeCosign(t, nil)
+			ctx := makeCosignContext(t)
+			testlib.RequireTemplateError(t, cosign(ctx, config.DockerV2{
+				ID:     "foo",
+				Cosign: c,
+			}))
+		})
+	}
+}
+
+// fakeCosign replaces the cosign command executor, recording the calls made
+// to it, with the command name as the first item.
+func fakeCosign(tb testing.TB, fn func(args []string) ([]byte, error)) *[][]string {
+	tb.Helper()
+	if fn == nil {
+		fn = func([]string) ([]byte, error) {
+			return []byte("ok"), nil
+		}
+	}
+	fake := &testlib.FakeCosign{Fn: fn}
+	testlib.Set[cmder](tb, &cli, fakeCmd{execFn: func(_ []byte, name string, args ...string) ([]byte, error) {
+		return fake.Exec(nil, name, args...)
+	}})
+	return &fake.Calls
+}
+
+func makeCosignContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	ctx := testctx.Wrap(
+		tb.Context(),
+		testctx.WithEnv(map[string]string{"COSIGN_KEY": "env://COSIGN_PRIVATE_KEY"}),
+	)
+	for _, name := range []string{
+		"ghcr.io/foo/bar:v1.0.0",
+		"ghcr.io/foo/bar:latest",
+		"foo/bar:v1.0.0",
+	} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: name,
+			Path: name,
+			Type: artifact.DockerImageV2,
+			Extra: map[string]any{
+				artifact.ExtraID:     "foo",
+				artifact.ExtraDigest: fakeDigest,
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "ghcr.io/foo/other:v1.0.0",
+		Path: "ghcr.io/foo/other:v1.0.0",
+		Type: artifact.DockerImageV2,
+		Extra: map[string]any{
+			artifact.ExtraID:     "other",
+			artifact.ExtraDigest: fakeDigest,
+		},
+	})
+	return ctx
+}
@@ -39,6 +39,8 @@ import (
 // docker image was built for (e.g. ["linux/amd64", "linux/arm64"]).
 const ExtraPlatforms = "Platforms"
 
+var errNoRegistry = errors.New("dockers_v2: registries must have a registry set")
+
 // Base v2 docker pipe.
 type Base struct{}
 
@@ -52,7 +54,20 @@ type Publish struct{ Base }
 func (p Base) String() string { return "docker images (v2)" }
 
 // Dependencies implements DependencyChecker.
-func (Base) Dependencies(*context.Context) []string { return []string{"docker buildx"} }
+func (Base) Dependencies(ctx *context.Context) []string {
+	deps := []string{"docker buildx"}
+	for _, d := range ctx.Config.DockersV2 {
+		if d.Cosign.Enabled == "" {
+			continue
+		}
+		deps = append(deps, "cosign")
+		if d.Cosign.SBOM != "" {
+			deps = append(deps, "syft")
+		}
+	}
+	slices.Sort(deps)
+	return slices.Compact(deps)
+}
 
 // Healthcheck implements Healthchecker.
 func (Base) Healthcheck(ctx *context.Context) error {
@@ -99,6 +114,11 @@ func (Base) Default(ctx *context.Context) error {
 		docker.Retry.Attempts = cmp.Or(docker.Retry.Attempts, ctx.Config.Retry.Attempts)
 		docker.Retry.Delay = cmp.Or(docker.Retry.Delay, ctx.Config.Retry.Delay)
 		docker.Retry.MaxDelay = cmp.Or(docker.Retry.MaxDelay, ctx.Config.Retry.MaxDelay)
+		for _, r := range docker.Registries {
+			if r.Registry == "" {
+				return errNoRegistry
+			}
+		}
 
 		ids.Inc(docker.ID)
 	}
@@ -149,9 +169,12 @@ func (p Publish) Publish(ctx *context.Context) error {
 		g.Go(func() error {
 			extraArgs, err := p.extraArgs(ctx, d)
 			if err != nil {
-				return fmt.Errorf("dockers_v2.sbom: %w", err)
+				return err
+			}
+			if err := buildImage(ctx, d, extraArgs...); err != nil {
+				return err
 			}
-			return buildImage(ctx, d, extraArgs...)
+			return cosign(ctx, d)
 		})
 	}
 	return g.Wait()
@@ -166,6 +189,13 @@ func (Publish) extraArgs(ctx *context.Context, d config.DockerV2) ([]string, err
 	if sbom {
 		extraArgs = append(extraArgs, "--attest=type=sbom")
 	}
+	provenance, err := tmpl.New(ctx).Apply(d.Provenance)
+	if err != nil {
+		return nil, fmt.Errorf("dockers_v2.provenance: %w", err)
+	}
+	if provenance = strings.TrimSpace(provenance); provenance != "" {
+		extraArgs = append(extraArgs, "--provenance="+provenance)
+	}
 	return extraArgs, nil
 }
 
@@ -210,6 +240,10 @@ func buildImage(ctx *context.Context, d config.DockerV2, extraArgs ...string) er
 		return fmt.Errorf("pre hook failed: %w", err)
 	}
 
+	if err := login(ctx, required(da.registries)); err != nil {
+		return err
+	}
+
 	digest, err := doBuild(ctx, d, wd, da.args)
 	if err != nil {
 		return err
@@ -225,7 +259,13 @@ func buildImage(ctx *context.Context, d config.DockerV2, extraArgs ...string) er
 		WithField("digest", digest).
 		Info("created images")
 
-	for _, img := range da.images {
+	images := da.images
+	if len(da.copies) > 0 {
+		source := digestRef(da.images[0], digest)
+		images = append(images, copyImages(ctx, da.registries, source, da.copies)...)
+	}
+
+	for _, img := range images {
 		ctx.Artifacts.Add(&artifact.Artifact{
 			Name: img,
 			Path: img,
@@ -299,6 +339,8 @@ type dockerArgs struct {
 	dockerfile string
 	args       []string
 	images     []string
+	copies     []string
+	registries []registry
 }
 
 func makeArgs(ctx *context.Context, d config.DockerV2, extraArgs []string) (dockerArgs, error) {
@@ -344,6 +386,21 @@ func makeArgs(ctx *context.Context, d config.DockerV2, extraArgs []string) (dock
 	}
 	allImages := makeImageList(images, tags)
 
+	// images in optional registries are not pushed by the build, but copied
+	// afterwards, so failing to push them doesn't fail the release.
+	var regs []registry
+	var copies []string
+	if !ctx.Snapshot && len(d.Registries) > 0 {
+		regs, err = registries(ctx, d)
+		if err != nil {
+			return dockerArgs{}, fmt.Errorf("invalid registries: %w", err)
+		}
+		allImages, copies, err = splitImages(allImages, regs)
+		if err != nil {
+			return dockerArgs{}, err
+		}
+	}
+
 	labelFlags, err := tplMapFlags(tpl, "--label", d.Labels)
 	if err != nil {
 		return dockerArgs{}, fmt.Errorf("invalid labels: %w", err)
@@ -364,6 +421,26 @@ func makeArgs(ctx *context.Context, d config.DockerV2, extraArgs []string) (dock
 		return dockerArgs{}, fmt.Errorf("invalid build args: %w", err)
 	}
 
+	cacheFromFlags, err := pathFlags(tpl, "--cache-from", d.CacheFrom, "src")
+	if err != nil {
+		return dockerArgs{}, fmt.Errorf("invalid cache_from: %w", err)
+	}
+
+	cacheToFlags, err := pathFlags(tpl, "--cache-to", d.CacheTo, "dest")
+	if err != nil {
+		return dockerArgs{}, fmt.Errorf("invalid cache_to: %w", err)
+	}
+
+	secretFlags, err := pathFlags(tpl, "--secret", d.Secrets, "src", "source")
+	if err != nil {
+		return dockerArgs{}, fmt.Errorf("invalid secrets: %w", err)
+	}
+
+	sshFlags, err := sshFlags(tpl, d.SSH)
+	if err != nil {
+		return dockerArgs{}, fmt.Errorf("invalid ssh: %w", err)
+	}
+
 	flags, err := tpl.Slice(d.Flags, tmpl.NonEmpty())
 	if err != nil {
 		return dockerArgs{}, fmt.Errorf("invalid flags: %w", err)
@@ -382,12 +459,18 @@ func makeArgs(ctx *context.Context, d config.DockerV2, extraArgs []string) (dock
 	arg = append(arg, labelFlags...)
 	arg = append(arg, annotationFlags...)
 	arg = append(arg, buildFlags...)
+	arg = append(arg, cacheFromFlags...)
+	arg = append(arg, cacheToFlags...)
+	arg = append(arg, secretFlags...)
+	arg = append(arg, sshFlags...)
 	arg = append(arg, flags...)
 	arg = append(arg, ".")
 	return dockerArgs{
 		dockerfile: dockerfile,
 		args:       arg,
 		images:     allImages,
+		copies:     copies,
+		registries: regs,
 	}, nil
 }
 
@@ -582,6 +665,59 @@ func tplMapFlags(tpl *tmpl.Template, flag string, m map[string]string) ([]string
 	return result, nil
 }
 
+// pathFlags templates the given comma-separated key=value lists, e.g.
+// 'type=local,src=./cache', into flags, making the paths in the given keys
+// absolute, as the build runs from a temporary context directory.
+func pathFlags(tpl *tmpl.Template, flag string, values []string, keys ...string) ([]string, error) {
+	values, err := tpl.Slice(values, tmpl.NonEmpty())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		fields := strings.Split(value, ",")
+		for i, field := range fields {
+			key, path, ok := strings.Cut(field, "=")
+			if !ok || !slices.Contains(keys, key) {
+				continue
+			}
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = key + "=" + abs
+		}
+		result = append(result, flag, strings.Join(fields, ","))
+	}
+	return result, nil
+}
+
+// sshFlags templates the given ssh agent sockets or keys, in the
+// 'default|<id>[=<socket>|<key>[,<key>]]' format, into flags, making their
+// paths absolute.
+func sshFlags(tpl *tmpl.Template, values []string) ([]string, error) {
+	values, err := tpl.Slice(values, tmpl.NonEmpty())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		id, paths, ok := strings.Cut(value, "=")
+		if !ok {
+			result = append(result, "--ssh", id)
+			continue
+		}
+		abs := strings.Split(paths, ",")
+		for i, path := range abs {
+			if abs[i], err = filepath.Abs(path); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, "--ssh", id+"="+strings.Join(abs, ","))
+	}
+	return result, nil
+}
+
 // IsRetriableBuild reports whether a failed docker build is worth retrying.
 //
 // Besides genuine network errors, docker builds routinely fail for transient
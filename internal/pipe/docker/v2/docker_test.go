@@ -23,7 +23,21 @@ func TestString(t *testing.T) {
 }
 
 func TestDependencies(t *testing.T) {
-	require.Equal(t, []string{"docker buildx"}, Base{}.Dependencies(nil))
+	t.Run("default", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DockersV2: []config.DockerV2{{}},
+		})
+		require.Equal(t, []string{"docker buildx"}, Base{}.Dependencies(ctx))
+	})
+	t.Run("cosign", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DockersV2: []config.DockerV2{
+				{Cosign: config.DockerCosign{Enabled: "true"}},
+				{Cosign: config.DockerCosign{Enabled: "true", SBOM: "true"}},
+			},
+		})
+		require.Equal(t, []string{"cosign", "docker buildx", "syft"}, Base{}.Dependencies(ctx))
+	})
 }
 
 func TestSkip(t *testing.T) {
@@ -71,6 +85,15 @@ func TestDefault(t *testing.T) {
 	require.Equal(t, "true", d.SBOM)
 }
 
+func TestDefaultNoRegistry(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DockersV2: []config.DockerV2{{
+			Registries: []config.DockerRegistry{{Username: "foo"}},
+		}},
+	})
+	require.ErrorIs(t, Base{}.Default(ctx), errNoRegistry)
+}
+
 func TestMakeContext(t *testing.T) {
 	t.Run("no dockerfile", func(t *testing.T) {
 		_, err := makeContext(config.DockerV2{}, nil, "  ")
@@ -122,12 +145,32 @@ func TestPublishExtraArgs(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, []string{"--push", "--attest=type=sbom"}, args)
 	})
+	t.Run("provenance", func(t *testing.T) {
+		args, err := Publish{}.extraArgs(ctx, config.DockerV2{
+			Provenance: "mode=max",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"--push", "--provenance=mode=max"}, args)
+	})
+	t.Run("provenance disabled", func(t *testing.T) {
+		args, err := Publish{}.extraArgs(ctx, config.DockerV2{
+			Provenance: "{{ .IsSnapshot }}",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"--push", "--provenance=false"}, args)
+	})
 	t.Run("tmpl err", func(t *testing.T) {
 		_, err := Publish{}.extraArgs(ctx, config.DockerV2{
 			SBOM: "{{ not .IsSn",
 		})
 		testlib.RequireTemplateError(t, err)
 	})
+	t.Run("provenance tmpl err", func(t *testing.T) {
+		_, err := Publish{}.extraArgs(ctx, config.DockerV2{
+			Provenance: "{{ .Nope }}",
+		})
+		testlib.RequireTemplateError(t, err)
+	})
 }
 
 func TestMakeArgs(t *testing.T) {
@@ -140,6 +183,10 @@ func TestMakeArgs(t *testing.T) {
 			"annotations": func(d *config.DockerV2) { d.Annotations = map[string]string{"foo": "{{.Nope}}"} },
 			"build args":  func(d *config.DockerV2) { d.BuildArgs = map[string]string{"{{.Nope}}": "bar"} },
 			"flags":       func(d *config.DockerV2) { d.Flags = []string{"{{.Nope}}"} },
+			"cache from":  func(d *config.DockerV2) { d.CacheFrom = []string{"{{.Nope}}"} },
+			"cache to":    func(d *config.DockerV2) { d.CacheTo = []string{"{{.Nope}}"} },
+			"secrets":     func(d *config.DockerV2) { d.Secrets = []string{"{{.Nope}}"} },
+			"ssh":         func(d *config.DockerV2) { d.SSH = []string{"{{.Nope}}"} },
 		} {
 			t.Run(name, func(t *testing.T) {
 				ctx := testctx.Wrap(t.Context())
@@ -246,6 +293,82 @@ func TestMakeArgs(t *testing.T) {
 	})
 }
 
+func TestMakeArgsCache(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	ctx := testctx.Wrap(t.Context())
+	da, err := makeArgs(ctx, config.DockerV2{
+		Images:    []string{"ghcr.io/foo/bar"},
+		Tags:      []string{"latest"},
+		Platforms: []string{"linux/amd64"},
+		CacheFrom: []string{
+			"type=registry,ref=ghcr.io/foo/bar:cache",
+			"type=local,src=.cache/docker",
+			"{{ if .IsSnapshot }}type=gha{{ end }}",
+		},
+		CacheTo: []string{
+			"type=local,dest=.cache/docker,mode=max",
+		},
+		Flags: []string{"--ulimit=1000"},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]string{
+			"buildx", "build",
+			"--platform", "linux/amd64",
+			"-t", "ghcr.io/foo/bar:latest",
+			"--iidfile=id.txt",
+			"--cache-from", "type=registry,ref=ghcr.io/foo/bar:cache",
+			"--cache-from", "type=local,src=" + filepath.Join(wd, ".cache/docker"),
+			"--cache-to", "type=local,dest=" + filepath.Join(wd, ".cache/docker") + ",mode=max",
+			"--ulimit=1000",
+			".",
+		},
+		da.args,
+	)
+}
+
+func TestMakeArgsSecretsSSH(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	ctx := testctx.Wrap(t.Context(), testctx.WithEnv(map[string]string{
+		"SSH_AUTH_SOCK": "/tmp/agent.sock",
+	}))
+	da, err := makeArgs(ctx, config.DockerV2{
+		Images:    []string{"ghcr.io/foo/bar"},
+		Tags:      []string{"latest"},
+		Platforms: []string{"linux/amd64"},
+		Secrets: []string{
+			"id=token,env=TOKEN",
+			"id=npmrc,src=testdata/.npmrc",
+			"{{ if .IsSnapshot }}id=snapshot,env=TOKEN{{ end }}",
+		},
+		SSH: []string{
+			"default={{ .Env.SSH_AUTH_SOCK }}",
+			"github=id_ed25519",
+			"agent",
+		},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]string{
+			"buildx", "build",
+			"--platform", "linux/amd64",
+			"-t", "ghcr.io/foo/bar:latest",
+			"--iidfile=id.txt",
+			"--secret", "id=token,env=TOKEN",
+			"--secret", "id=npmrc,src=" + filepath.Join(wd, "testdata/.npmrc"),
+			"--ssh", "default=/tmp/agent.sock",
+			"--ssh", "github=" + filepath.Join(wd, "id_ed25519"),
+			"--ssh", "agent",
+			".",
+		},
+		da.args,
+	)
+}
+
 func TestDisable(t *testing.T) {
 	t.Run("disabled", func(t *testing.T) {
 		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
@@ -0,0 +1,157 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const defaultRegistry = "docker.io"
+
+type registry struct {
+	name     string
+	username string
+	password string
+	optional bool
+}
+
+// registries applies the templates of the registries of the given
+// configuration.
+func registries(ctx *context.Context, d config.DockerV2) ([]registry, error) {
+	tpl := tmpl.New(ctx)
+	result := make([]registry, 0, len(d.Registries))
+	for _, r := range d.Registries {
+		if err := tpl.ApplyAll(&r.Registry, &r.Username, &r.Password); err != nil {
+			return nil, err
+		}
+		result = append(result, registry{
+			name:     normalizeRegistry(r.Registry),
+			username: r.Username,
+			password: r.Password,
+			optional: r.Optional,
+		})
+	}
+	return result, nil
+}
+
+// splitImages splits the given images between the ones we should build and
+// push, and the ones in optional registries, which are copied afterwards.
+func splitImages(images []string, regs []registry) ([]string, []string, error) {
+	var push, optional []string
+	for _, img := range images {
+		if isOptional(regs, registryOf(img)) {
+			optional = append(optional, img)
+			continue
+		}
+		push = append(push, img)
+	}
+	if len(push) == 0 && len(optional) > 0 {
+		return nil, nil, errors.New("all images are in optional registries, at least one image must be in a required registry")
+	}
+	return push, optional, nil
+}
+
+func isOptional(regs []registry, name string) bool {
+	return slices.ContainsFunc(regs, func(r registry) bool {
+		return r.optional && r.name == name
+	})
+}
+
+// required returns the registries that are not optional.
+func required(regs []registry) []registry {
+	return slices.DeleteFunc(slices.Clone(regs), func(r registry) bool {
+		return r.optional
+	})
+}
+
+// login logs in to the given registries, if they have credentials set.
+func login(ctx *context.Context, regs []registry) error {
+	for _, r := range regs {
+		if r.username == "" && r.password == "" {
+			continue
+		}
+		log.WithField("registry", r.name).Info("logging in")
+		if out, err := cli.Exec(
+			ctx,
+			[]byte(r.password),
+			"docker", "login", r.name,
+			"--username", r.username,
+			"--password-stdin",
+		); err != nil {
+			return fmt.Errorf("failed to login to %s: %w: %s", r.name, err, string(out))
+		}
+	}
+	return nil
+}
+
+// copyImages copies the image with the given source reference to the given
+// images in optional registries.
+//
+// Failures are only logged, and the images that were copied successfully are
+// returned.
+func copyImages(ctx *context.Context, regs []registry, source string, images []string) []string {
+	var copied []string
+	for _, r := range regs {
+		if !r.optional {
+			continue
+		}
+		var args []string
+		for _, img := range images {
+			if registryOf(img) == r.name {
+				args = append(args, "--tag", img)
+			}
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		log := log.WithField("registry", r.name)
+		if err := login(ctx, []registry{r}); err != nil {
+			log.WithError(err).Warn("skipping optional registry")
+			continue
+		}
+
+		log.WithField("source", source).Info("copying images")
+		args = append([]string{"buildx", "imagetools", "create"}, args...)
+		args = append(args, source)
+		if out, err := cli.Exec(ctx, nil, "docker", args...); err != nil {
+			log.WithError(fmt.Errorf("%w: %s", err, string(out))).
+				Warn("failed to copy images to optional registry")
+			continue
+		}
+		for _, img := range images {
+			if registryOf(img) == r.name {
+				copied = append(copied, img)
+			}
+		}
+	}
+	return copied
+}
+
+// registryOf returns the registry of the given image, e.g. 'ghcr.io' for
+// 'ghcr.io/foo/bar:v1.0.0', or 'docker.io' for 'foo/bar:v1.0.0'.
+func registryOf(image string) string {
+	first, _, ok := strings.Cut(image, "/")
+	if !ok || (!strings.ContainsAny(first, ".:") && first != "localhost") {
+		return defaultRegistry
+	}
+	return normalizeRegistry(first)
+}
+
+func normalizeRegistry(name string) string {
+	name = strings.TrimPrefix(name, "https://")
+	name = strings.TrimPrefix(name, "http://")
+	name = strings.TrimSuffix(name, "/")
+	switch name {
+	case "", "index.docker.io", "registry-1.docker.io":
+		return defaultRegistry
+	default:
+		return name
+	}
+}
@@ -0,0 +1,186 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryOf(t *testing.T) {
+	for image, expected := range map[string]string{
+		"ghcr.io/foo/bar:v1.0.0":         "ghcr.io",
+		"localhost:5000/foo/bar":         "localhost:5000",
+		"localhost/foo/bar":              "localhost",
+		"foo/bar:v1.0.0":                 "docker.io",
+		"alpine":                         "docker.io",
+		"docker.io/library/alpine:3.2.1": "docker.io",
+		"index.docker.io/foo/bar":        "docker.io",
+		"123456789012.dkr.ecr.us-east-1.amazonaws.com/foo:v1": "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+	} {
+		t.Run(image, func(t *testing.T) {
+			require.Equal(t, expected, registryOf(image))
+		})
+	}
+}
+
+func TestRegistries(t *testing.T) {
+	ctx := testctx.Wrap(t.Context(), testctx.WithEnv(map[string]string{
+		"GHCR_TOKEN": "secret",
+	}))
+	regs, err := registries(ctx, config.DockerV2{
+		Registries: []config.DockerRegistry{
+			{Registry: "ghcr.io", Username: "foo", Password: "{{ .Env.GHCR_TOKEN }}"},
+			{Registry: "https://index.docker.io/", Optional: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []registry{
+		{name: "ghcr.io", username: "foo", password: "secret"},
+		{name: "docker.io", optional: true},
+	}, regs)
+
+	_, err = registries(ctx, config.DockerV2{
+		Registries: []config.DockerRegistry{{Registry: "{{ .Nope }}"}},
+	})
+	testlib.RequireTemplateError(t, err)
+}
+
+func TestSplitImages(t *testing.T) {
+	regs := []registry{
+		{name: "ghcr.io"},
+		{name: "docker.io", optional: true},
+		{name: "quay.io", optional: true},
+	}
+
+	t.Run("split", func(t *testing.T) {
+		push, copies, err := splitImages([]string{
+			"foo/bar:v1",
+			"ghcr.io/foo/bar:v1",
+			"quay.io/foo/bar:v1",
+			"registry.example.com/foo/bar:v1",
+		}, regs)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ghcr.io/foo/bar:v1", "registry.example.com/foo/bar:v1"}, push)
+		require.Equal(t, []string{"foo/bar:v1", "quay.io/foo/bar:v1"}, copies)
+	})
+
+	t.Run("all optional", func(t *testing.T) {
+		_, _, err := splitImages([]string{"foo/bar:v1", "quay.io/foo/bar:v1"}, regs)
+		require.ErrorContains(t, err, "at least one image must be in a required registry")
+	})
+}
+
+func TestMakeArgsRegistries(t *testing.T) {
+	d := config.DockerV2{
+		Dockerfile: "Dockerfile",
+		Images:     []string{"ghcr.io/foo/bar", "foo/bar"},
+		Tags:       []string{"v1"},
+		Platforms:  []string{"linux/amd64"},
+		Registries: []config.DockerRegistry{{Registry: "docker.io", Optional: true}},
+	}
+
+	t.Run("publish", func(t *testing.T) {
+		da, err := makeArgs(testctx.Wrap(t.Context()), d, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ghcr.io/foo/bar:v1"}, da.images)
+		require.Equal(t, []string{"foo/bar:v1"}, da.copies)
+		require.NotContains(t, da.args, "foo/bar:v1")
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		da, err := makeArgs(testctx.Wrap(t.Context(), testctx.Snapshot), d, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo/bar:v1-amd64", "ghcr.io/foo/bar:v1-amd64"}, da.images)
+		require.Empty(t, da.copies)
+		require.Empty(t, da.registries)
+	})
+
+	t.Run("bad template", func(t *testing.T) {
+		d := d
+		d.Registries = []config.DockerRegistry{{Registry: "{{ .Nope }}"}}
+		_, err := makeArgs(testctx.Wrap(t.Context()), d, nil)
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestLogin(t *testing.T) {
+	t.Run("with credentials", func(t *testing.T) {
+		var input []byte
+		var calls [][]string
+		fakeCli(t, func(in []byte, args []string) ([]byte, error) {
+			input = in
+			calls = append(calls, args)
+			return []byte("Login Succeeded"), nil
+		})
+		require.NoError(t, login(testctx.Wrap(t.Context()), []registry{
+			{name: "ghcr.io", username: "foo", password: "secret"},
+			{name: "docker.io"},
+		}))
+		require.Equal(t, [][]string{
+			{"docker", "login", "ghcr.io", "--username", "foo", "--password-stdin"},
+		}, calls)
+		require.Equal(t, "secret", string(input))
+	})
+
+	t.Run("fails", func(t *testing.T) {
+		fakeCli(t, func([]byte, []string) ([]byte, error) {
+			return []byte("unauthorized"), errors.New("exit status 1")
+		})
+		err := login(testctx.Wrap(t.Context()), []registry{
+			{name: "ghcr.io", username: "foo", password: "secret"},
+		})
+		require.ErrorContains(t, err, "failed to login to ghcr.io")
+		require.ErrorContains(t, err, "unauthorized")
+	})
+}
+
+func TestCopyImages(t *testing.T) {
+	regs := []registry{
+		{name: "ghcr.io"},
+		{name: "docker.io", username: "foo", password: "secret", optional: true},
+		{name: "quay.io", username: "foo", password: "wrong", optional: true},
+		{name: "public.ecr.aws", optional: true},
+	}
+	var calls [][]string
+	fakeCli(t, func(in []byte, args []string) ([]byte, error) {
+		calls = append(calls, args)
+		if string(in) == "wrong" {
+			return []byte("unauthorized"), errors.New("exit status 1")
+		}
+		if args[len(args)-2] == "public.ecr.aws/foo/bar:v1" {
+			return []byte("denied"), errors.New("exit status 1")
+		}
+		return []byte("ok"), nil
+	})
+
+	source := "ghcr.io/foo/bar@" + fakeDigest
+	copied := copyImages(testctx.Wrap(t.Context()), regs, source, []string{
+		"foo/bar:v1",
+		"foo/bar:latest",
+		"quay.io/foo/bar:v1",
+		"public.ecr.aws/foo/bar:v1",
+	})
+	require.Equal(t, []string{"foo/bar:v1", "foo/bar:latest"}, copied)
+	require.Equal(t, [][]string{
+		{"docker", "login", "docker.io", "--username", "foo", "--password-stdin"},
+		{"docker", "buildx", "imagetools", "create", "--tag", "foo/bar:v1", "--tag", "foo/bar:latest", source},
+		{"docker", "login", "quay.io", "--username", "foo", "--password-stdin"},
+		{"docker", "buildx", "imagetools", "create", "--tag", "public.ecr.aws/foo/bar:v1", source},
+	}, calls)
+}
+
+// fakeCli replaces the command executor, with the command name as the first
+// item of the arguments given to fn.
+func fakeCli(tb testing.TB, fn func(input []byte, args []string) ([]byte, error)) {
+	tb.Helper()
+	cli = fakeCmd{execFn: func(input []byte, name string, args ...string) ([]byte, error) {
+		return fn(input, append([]string{name}, args...))
+	}}
+	tb.Cleanup(func() {
+		cli = stdCmd{}
+	})
+}
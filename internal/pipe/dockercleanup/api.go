@@ -0,0 +1,53 @@
+package dockercleanup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// request does an HTTP request to a JSON API, decoding the response into
+// out, if not nil.
+func request(ctx *context.Context, method, url, auth string, body, out any) error {
+	var r io.Reader
+	if body != nil {
+		bts, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(bts)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bts, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: got status code %d: %s", method, url, resp.StatusCode, string(bts))
+	}
+	if out == nil || len(bts) == 0 {
+		return nil
+	}
+	return json.Unmarshal(bts, out)
+}
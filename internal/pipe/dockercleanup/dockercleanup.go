@@ -0,0 +1,285 @@
+// Package dockercleanup prunes old image tags from registries.
+package dockercleanup
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/log"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+var (
+	errNoRepositories = errors.New("docker_cleanups: repositories are required")
+	errNoTags         = errors.New("docker_cleanups: tags are required")
+	errNoPolicy       = errors.New("docker_cleanups: either keep or max_age is required")
+
+	// ecrPattern matches ECR registries, capturing the account and region.
+	ecrPattern = regexp.MustCompile(`^(\d+)\.dkr\.ecr\.([a-z0-9-]+)\.amazonaws\.com$`)
+)
+
+// Pipe for docker cleanups.
+type Pipe struct{}
+
+func (Pipe) String() string        { return "docker cleanups" }
+func (Pipe) ContinueOnError() bool { return true }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.DockerCleanup) || len(ctx.Config.DockerCleanups) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	for _, c := range ctx.Config.DockerCleanups {
+		for _, repo := range c.Repositories {
+			if strings.Contains(repo, ".dkr.ecr.") {
+				return []string{"aws"}
+			}
+		}
+	}
+	return nil
+}
+
+// Default validates the configuration.
+func (Pipe) Default(ctx *context.Context) error {
+	for _, c := range ctx.Config.DockerCleanups {
+		if len(c.Repositories) == 0 {
+			return errNoRepositories
+		}
+		if len(c.Tags) == 0 {
+			return errNoTags
+		}
+		if c.Keep <= 0 && c.MaxAge <= 0 {
+			return errNoPolicy
+		}
+	}
+	return nil
+}
+
+// Publish prunes the tags.
+func (Pipe) Publish(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, c := range ctx.Config.DockerCleanups {
+		err := doCleanup(ctx, c)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doCleanup(ctx *context.Context, c config.DockerCleanup) error {
+	tpl := tmpl.New(ctx)
+	disable, err := tpl.Bool(c.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("configuration is disabled")
+	}
+
+	repos, err := tpl.Slice(c.Repositories, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	patterns, err := tpl.Slice(c.Tags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	var res []*regexp.Regexp
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("docker_cleanups: invalid tag pattern %q: %w", p, err)
+		}
+		res = append(res, re)
+	}
+	match := func(tag string) bool {
+		return slices.ContainsFunc(res, func(re *regexp.Regexp) bool {
+			return re.MatchString(tag)
+		})
+	}
+
+	for _, r := range repos {
+		repo, err := name.NewRepository(r)
+		if err != nil {
+			return fmt.Errorf("docker_cleanups: invalid repository %q: %w", r, err)
+		}
+		if err := cleanup(ctx, repo, c, match); err != nil {
+			return fmt.Errorf("docker_cleanups: %s: %w", repo.Name(), err)
+		}
+	}
+	return nil
+}
+
+func cleanup(ctx *context.Context, repo name.Repository, c config.DockerCleanup, match func(string) bool) error {
+	b, err := backendFor(ctx, repo)
+	if err != nil {
+		return err
+	}
+	tags, err := b.tags(ctx, match)
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+	if t, ok := undated(tags, match); ok {
+		return fmt.Errorf("tag %s has no creation date, so its age is unknown: images built reproducibly have it set to the epoch", t.name)
+	}
+
+	groups := plan(tags, match, c.Keep, c.MaxAge, protected(ctx, repo), time.Now())
+	if len(groups) == 0 {
+		log.WithField("repository", repo.Name()).Info("nothing to clean up")
+		return nil
+	}
+	for _, group := range groups {
+		entry := log.WithField("repository", repo.Name()).
+			WithField("digest", group[0].digest).
+			WithField("tags", tagNames(group))
+		if c.DryRun {
+			entry.Info("would delete")
+			continue
+		}
+		entry.Info("deleting")
+		if err := b.delete(ctx, group); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", strings.Join(tagNames(group), ", "), err)
+		}
+	}
+	return nil
+}
+
+// tag is an image tag in a repository.
+type tag struct {
+	name    string
+	digest  string
+	created time.Time
+
+	// id is the backend-specific identifier of the image, if any.
+	id string
+}
+
+// backend lists and deletes tags in a repository.
+type backend interface {
+	// tags lists all the tags in the repository.
+	// The creation date is only required for tags matching the given
+	// function.
+	tags(ctx *context.Context, match func(string) bool) ([]tag, error)
+
+	// delete deletes the image with the given tags, which all share the
+	// same digest.
+	delete(ctx *context.Context, tags []tag) error
+}
+
+func backendFor(ctx *context.Context, repo name.Repository) (backend, error) {
+	registry := repo.RegistryStr()
+	switch {
+	case registry == "ghcr.io":
+		return newGHCR(ctx, repo)
+	case registry == name.DefaultRegistry:
+		return newHub(ctx, repo)
+	case ecrPattern.MatchString(registry):
+		return newECR(repo), nil
+	default:
+		return newRegistry(repo), nil
+	}
+}
+
+// plan returns the images to delete, as groups of tags sharing the same
+// digest.
+//
+// Matching tags are sorted from newest to oldest, the first keep tags are
+// kept, and so are the ones newer than maxAge, if set.
+// An image is only deleted if all its tags are to be deleted.
+func plan(tags []tag, match func(string) bool, keep int, maxAge time.Duration, protected []string, now time.Time) [][]tag {
+	var candidates []tag
+	for _, t := range tags {
+		if match(t.name) {
+			candidates = append(candidates, t)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b tag) int {
+		return b.created.Compare(a.created)
+	})
+
+	deleted := map[string]bool{}
+	for i, t := range candidates {
+		if i < keep ||
+			(maxAge > 0 && now.Sub(t.created) < maxAge) ||
+			slices.Contains(protected, t.name) {
+			continue
+		}
+		deleted[t.name] = true
+	}
+
+	byDigest := map[string][]tag{}
+	var digests []string
+	for _, t := range tags {
+		if _, ok := byDigest[t.digest]; !ok {
+			digests = append(digests, t.digest)
+		}
+		byDigest[t.digest] = append(byDigest[t.digest], t)
+	}
+
+	var result [][]tag
+	for _, digest := range digests {
+		group := byDigest[digest]
+		if slices.ContainsFunc(group, func(t tag) bool { return !deleted[t.name] }) {
+			continue
+		}
+		slices.SortFunc(group, func(a, b tag) int {
+			return cmp.Compare(a.name, b.name)
+		})
+		result = append(result, group)
+	}
+	return result
+}
+
+// undated returns the first matching tag without a creation date, which
+// happens when the date comes from the image configuration, and the image was
+// built reproducibly.
+func undated(tags []tag, match func(string) bool) (tag, bool) {
+	for _, t := range tags {
+		if match(t.name) && t.created.Unix() <= 0 {
+			return t, true
+		}
+	}
+	return tag{}, false
+}
+
+// protected returns the tags of the given repository that were pushed by the
+// current release, which should never be deleted.
+func protected(ctx *context.Context, repo name.Repository) []string {
+	var result []string
+	for _, a := range ctx.Artifacts.Filter(artifact.ByTypes(
+		artifact.DockerImage,
+		artifact.DockerImageV2,
+		artifact.DockerManifest,
+	)).List() {
+		ref, err := name.NewTag(a.Name)
+		if err != nil || ref.Context().Name() != repo.Name() {
+			continue
+		}
+		result = append(result, ref.TagStr())
+	}
+	return result
+}
+
+func tagNames(tags []tag) []string {
+	result := make([]string, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, t.name)
+	}
+	return result
+}
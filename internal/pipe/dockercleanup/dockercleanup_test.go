@@ -0,0 +1,193 @@
+package dockercleanup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestContinueOnError(t *testing.T) {
+	require.True(t, Pipe{}.ContinueOnError())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("no configs", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DockerCleanups: []config.DockerCleanup{{}},
+		}, testctx.Skip(skips.DockerCleanup))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DockerCleanups: []config.DockerCleanup{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DockerCleanups: []config.DockerCleanup{{
+			Repositories: []string{"ghcr.io/foo/bar"},
+		}},
+	})
+	require.Empty(t, Pipe{}.Dependencies(ctx))
+
+	ctx.Config.DockerCleanups[0].Repositories = append(
+		ctx.Config.DockerCleanups[0].Repositories,
+		"123456789012.dkr.ecr.us-east-1.amazonaws.com/bar",
+	)
+	require.Equal(t, []string{"aws"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestDefault(t *testing.T) {
+	for expected, c := range map[error]config.DockerCleanup{
+		errNoRepositories: {Tags: []string{"^nightly-"}, Keep: 1},
+		errNoTags:         {Repositories: []string{"ghcr.io/foo/bar"}, Keep: 1},
+		errNoPolicy:       {Repositories: []string{"ghcr.io/foo/bar"}, Tags: []string{"^nightly-"}},
+	} {
+		t.Run(expected.Error(), func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				DockerCleanups: []config.DockerCleanup{c},
+			})
+			require.ErrorIs(t, Pipe{}.Default(ctx), expected)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DockerCleanups: []config.DockerCleanup{{
+				Repositories: []string{"ghcr.io/foo/bar"},
+				Tags:         []string{"^nightly-"},
+				MaxAge:       time.Hour,
+			}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+	})
+}
+
+func TestPlan(t *testing.T) {
+	now := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	tags := []tag{
+		{name: "nightly-5", digest: "d5", created: day(1)},
+		{name: "nightly-4", digest: "d4", created: day(2)},
+		{name: "nightly-3", digest: "d3", created: day(10)},
+		{name: "nightly-2", digest: "d2", created: day(20)},
+		{name: "nightly-1", digest: "d1", created: day(30)},
+		{name: "v1.0.0", digest: "d1"},
+		{name: "nightly-0", digest: "d0", created: day(40)},
+		{name: "nightly-0-amd64", digest: "d0", created: day(40)},
+	}
+	match := func(s string) bool { return strings.HasPrefix(s, "nightly-") }
+
+	t.Run("keep", func(t *testing.T) {
+		require.Equal(t, [][]tag{
+			{tags[2]},
+			{tags[3]},
+			{tags[6], tags[7]},
+		}, plan(tags, match, 2, 0, nil, now))
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		require.Equal(t, [][]tag{
+			{tags[3]},
+			{tags[6], tags[7]},
+		}, plan(tags, match, 0, 15*24*time.Hour, nil, now))
+	})
+
+	t.Run("keep and max age", func(t *testing.T) {
+		require.Equal(t, [][]tag{
+			{tags[6], tags[7]},
+		}, plan(tags, match, 4, 24*time.Hour, nil, now))
+	})
+
+	t.Run("protected", func(t *testing.T) {
+		require.Equal(t, [][]tag{
+			{tags[2]},
+			{tags[3]},
+		}, plan(tags, match, 2, 0, []string{"nightly-0-amd64"}, now))
+	})
+
+	t.Run("nothing matches", func(t *testing.T) {
+		require.Empty(t, plan(tags, func(string) bool { return false }, 1, 0, nil, now))
+	})
+}
+
+func TestProtected(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	for _, a := range []*artifact.Artifact{
+		{Name: "ghcr.io/foo/bar:nightly-1", Type: artifact.DockerImageV2},
+		{Name: "ghcr.io/foo/bar:nightly-1-amd64", Type: artifact.DockerImage},
+		{Name: "ghcr.io/foo/other:nightly-1", Type: artifact.DockerImageV2},
+		{Name: "foo/bar:nightly-1", Type: artifact.DockerManifest},
+		{Name: "ghcr.io/foo/bar:ignored", Type: artifact.UploadableArchive},
+	} {
+		ctx.Artifacts.Add(a)
+	}
+
+	repo, err := name.NewRepository("ghcr.io/foo/bar")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"nightly-1", "nightly-1-amd64"}, protected(ctx, repo))
+
+	repo, err = name.NewRepository("docker.io/foo/bar")
+	require.NoError(t, err)
+	require.Equal(t, []string{"nightly-1"}, protected(ctx, repo))
+}
+
+func TestPublishErrors(t *testing.T) {
+	for name, c := range map[string]config.DockerCleanup{
+		"disable":      {Disable: "{{ .Nope }}"},
+		"repositories": {Repositories: []string{"{{ .Nope }}"}},
+		"tags":         {Repositories: []string{"ghcr.io/foo/bar"}, Tags: []string{"{{ .Nope }}"}},
+	} {
+		t.Run("bad "+name+" template", func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				DockerCleanups: []config.DockerCleanup{c},
+			})
+			testlib.RequireTemplateError(t, Pipe{}.Publish(ctx))
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DockerCleanups: []config.DockerCleanup{{Disable: "true"}},
+		})
+		testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DockerCleanups: []config.DockerCleanup{{
+				Repositories: []string{"ghcr.io/foo/bar"},
+				Tags:         []string{"nightly-("},
+			}},
+		})
+		require.ErrorContains(t, Pipe{}.Publish(ctx), "invalid tag pattern")
+	})
+
+	t.Run("invalid repository", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DockerCleanups: []config.DockerCleanup{{
+				Repositories: []string{"ghcr.io/Foo/Bar"},
+				Tags:         []string{"^nightly-"},
+			}},
+		})
+		require.ErrorContains(t, Pipe{}.Publish(ctx), "invalid repository")
+	})
+}
@@ -0,0 +1,113 @@
+package dockercleanup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// cmd represents a command executor.
+var cmd cmder = stdCmd{}
+
+// ecrBackend uses the AWS CLI, as ECR doesn't allow deleting manifests
+// through the registry API.
+type ecrBackend struct {
+	args []string
+}
+
+func newECR(repo name.Repository) backend {
+	match := ecrPattern.FindStringSubmatch(repo.RegistryStr())
+	return ecrBackend{
+		args: []string{
+			"--registry-id", match[1],
+			"--region", match[2],
+			"--repository-name", repo.RepositoryStr(),
+			"--output", "json",
+		},
+	}
+}
+
+func (b ecrBackend) tags(ctx *context.Context, _ func(string) bool) ([]tag, error) {
+	out, err := b.exec(ctx, "describe-images", "--filter", "tagStatus=TAGGED")
+	if err != nil {
+		return nil, err
+	}
+	var images struct {
+		ImageDetails []struct {
+			ImageDigest   string    `json:"imageDigest"`
+			ImageTags     []string  `json:"imageTags"`
+			ImagePushedAt time.Time `json:"imagePushedAt"`
+		} `json:"imageDetails"`
+	}
+	if err := json.Unmarshal(out, &images); err != nil {
+		return nil, fmt.Errorf("failed to parse aws output: %w", err)
+	}
+	var result []tag
+	for _, img := range images.ImageDetails {
+		for _, t := range img.ImageTags {
+			result = append(result, tag{
+				name:    t,
+				digest:  img.ImageDigest,
+				created: img.ImagePushedAt,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (b ecrBackend) delete(ctx *context.Context, tags []tag) error {
+	out, err := b.exec(ctx, "batch-delete-image", "--image-ids", "imageDigest="+tags[0].digest)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Failures []struct {
+			FailureCode   string `json:"failureCode"`
+			FailureReason string `json:"failureReason"`
+		} `json:"failures"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return fmt.Errorf("failed to parse aws output: %w", err)
+	}
+	if len(result.Failures) > 0 {
+		return fmt.Errorf("%s: %s", result.Failures[0].FailureCode, result.Failures[0].FailureReason)
+	}
+	return nil
+}
+
+func (b ecrBackend) exec(ctx *context.Context, command string, args ...string) ([]byte, error) {
+	args = append([]string{"ecr", command}, append(args, b.args...)...)
+	out, err := cmd.Exec(ctx, "aws", args...)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			out = exitErr.Stderr
+		}
+		return nil, fmt.Errorf("aws ecr %s failed: %w: %s", command, err, string(out))
+	}
+	return out, nil
+}
+
+// cmder is a special interface to execute external commands.
+//
+// The intention is to be used to wrap the standard exec and provide the
+// ability to create a fake one for testing.
+type cmder interface {
+	// Exec executes a command, returning its standard output.
+	Exec(*context.Context, string, ...string) ([]byte, error)
+}
+
+// stdCmd uses [execx.Command].
+type stdCmd struct{}
+
+var _ cmder = &stdCmd{}
+
+func (stdCmd) Exec(ctx *context.Context, name string, args ...string) ([]byte, error) {
+	return execx.Command(ctx, name, args...).Output()
+}
@@ -0,0 +1,104 @@
+package dockercleanup
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestECR(t *testing.T) {
+	old := time.Now().AddDate(0, 0, -10).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	common := []string{
+		"--registry-id", "123456789012",
+		"--region", "us-east-1",
+		"--repository-name", "foo/bar",
+		"--output", "json",
+	}
+
+	var calls [][]string
+	cmd = fakeCmd{execFn: func(name string, args ...string) ([]byte, error) {
+		require.Equal(t, "aws", name)
+		calls = append(calls, args)
+		switch args[1] {
+		case "describe-images":
+			return fmt.Appendf(nil, `{"imageDetails": [
+				{"imageDigest": "sha256:3", "imageTags": ["nightly-3"], "imagePushedAt": %q},
+				{"imageDigest": "sha256:2", "imageTags": ["nightly-2"], "imagePushedAt": %q},
+				{"imageDigest": "sha256:1", "imageTags": ["nightly-1", "v1.0.0"], "imagePushedAt": %q}
+			]}`, recent, old, old), nil
+		case "batch-delete-image":
+			return []byte(`{"imageIds": [{"imageDigest": "sha256:2"}], "failures": []}`), nil
+		}
+		return nil, errors.New("unexpected command")
+	}}
+	t.Cleanup(func() {
+		cmd = stdCmd{}
+	})
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DockerCleanups: []config.DockerCleanup{{
+			Repositories: []string{"123456789012.dkr.ecr.us-east-1.amazonaws.com/foo/bar"},
+			Tags:         []string{"^nightly-"},
+			Keep:         1,
+		}},
+	})
+	require.NoError(t, Pipe{}.Publish(ctx))
+	require.Equal(t, [][]string{
+		append([]string{"ecr", "describe-images", "--filter", "tagStatus=TAGGED"}, common...),
+		append([]string{"ecr", "batch-delete-image", "--image-ids", "imageDigest=sha256:2"}, common...),
+	}, calls)
+}
+
+func TestECRErrors(t *testing.T) {
+	t.Cleanup(func() {
+		cmd = stdCmd{}
+	})
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DockerCleanups: []config.DockerCleanup{{
+			Repositories: []string{"123456789012.dkr.ecr.us-east-1.amazonaws.com/foo/bar"},
+			Tags:         []string{"^nightly-"},
+			Keep:         1,
+		}},
+	})
+
+	t.Run("list", func(t *testing.T) {
+		cmd = fakeCmd{execFn: func(string, ...string) ([]byte, error) {
+			return []byte("access denied"), errors.New("exit status 255")
+		}}
+		err := Pipe{}.Publish(ctx)
+		require.ErrorContains(t, err, "failed to list tags")
+		require.ErrorContains(t, err, "access denied")
+	})
+
+	t.Run("delete failures", func(t *testing.T) {
+		cmd = fakeCmd{execFn: func(_ string, args ...string) ([]byte, error) {
+			if args[1] == "describe-images" {
+				return []byte(`{"imageDetails": [
+					{"imageDigest": "sha256:2", "imageTags": ["nightly-2"], "imagePushedAt": "2024-01-02T03:04:05Z"},
+					{"imageDigest": "sha256:1", "imageTags": ["nightly-1"], "imagePushedAt": "2024-01-01T03:04:05Z"}
+				]}`), nil
+			}
+			return []byte(`{"failures": [{"failureCode": "ImageNotFound", "failureReason": "not found"}]}`), nil
+		}}
+		err := Pipe{}.Publish(ctx)
+		require.ErrorContains(t, err, "failed to delete nightly-1")
+		require.ErrorContains(t, err, "ImageNotFound: not found")
+	})
+}
+
+type fakeCmd struct {
+	execFn func(name string, args ...string) ([]byte, error)
+}
+
+var _ cmder = fakeCmd{}
+
+func (f fakeCmd) Exec(_ *context.Context, name string, args ...string) ([]byte, error) {
+	return f.execFn(name, args...)
+}
@@ -0,0 +1,108 @@
+package dockercleanup
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// githubAPI is the GitHub API used to manage GHCR packages.
+var githubAPI = "https://api.github.com"
+
+// ghcrBackend uses the GitHub packages API, as GHCR doesn't allow deleting
+// manifests through the registry API.
+type ghcrBackend struct {
+	owner   string
+	pkg     string
+	token   string
+	baseURL string
+}
+
+func newGHCR(ctx *context.Context, repo name.Repository) (backend, error) {
+	token := ctx.Env["GITHUB_TOKEN"]
+	if ctx.TokenType == context.TokenTypeGitHub && ctx.Token != "" {
+		token = ctx.Token
+	}
+	if token == "" {
+		return nil, errors.New("a GitHub token is required to clean up GHCR repositories")
+	}
+	owner, pkg, ok := strings.Cut(repo.RepositoryStr(), "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid GHCR repository: %s", repo.Name())
+	}
+
+	b := ghcrBackend{
+		owner: owner,
+		pkg:   pkg,
+		token: token,
+	}
+	var user struct {
+		Type string `json:"type"`
+	}
+	if err := request(ctx, http.MethodGet, githubAPI+"/users/"+owner, b.auth(), nil, &user); err != nil {
+		return nil, err
+	}
+	kind := "users"
+	if user.Type == "Organization" {
+		kind = "orgs"
+	}
+	b.baseURL = fmt.Sprintf(
+		"%s/%s/%s/packages/container/%s/versions",
+		githubAPI, kind, owner, url.PathEscape(pkg),
+	)
+	return b, nil
+}
+
+func (b ghcrBackend) auth() string { return "Bearer " + b.token }
+
+type ghcrVersion struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Metadata  struct {
+		Container struct {
+			Tags []string `json:"tags"`
+		} `json:"container"`
+	} `json:"metadata"`
+}
+
+func (b ghcrBackend) tags(ctx *context.Context, _ func(string) bool) ([]tag, error) {
+	var result []tag
+	for page := 1; ; page++ {
+		var versions []ghcrVersion
+		if err := request(
+			ctx,
+			http.MethodGet,
+			b.baseURL+"?per_page=100&page="+strconv.Itoa(page),
+			b.auth(),
+			nil,
+			&versions,
+		); err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			return result, nil
+		}
+		for _, v := range versions {
+			for _, t := range v.Metadata.Container.Tags {
+				result = append(result, tag{
+					name:    t,
+					digest:  v.Name,
+					created: v.CreatedAt,
+					id:      strconv.FormatInt(v.ID, 10),
+				})
+			}
+		}
+	}
+}
+
+func (b ghcrBackend) delete(ctx *context.Context, tags []tag) error {
+	return request(ctx, http.MethodDelete, b.baseURL+"/"+tags[0].id, b.auth(), nil, nil)
+}
@@ -0,0 +1,75 @@
+package dockercleanup
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHCR(t *testing.T) {
+	old := time.Now().AddDate(0, 0, -10).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	var deleted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/foo", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer gh-token", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"type": "Organization"}`)
+	})
+	mux.HandleFunc("GET /orgs/foo/packages/container/bar/versions", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprintf(w, `[
+			{"id": 3, "name": "sha256:3", "created_at": %q, "metadata": {"container": {"tags": ["nightly-3"]}}},
+			{"id": 2, "name": "sha256:2", "created_at": %q, "metadata": {"container": {"tags": ["nightly-2", "nightly-2-amd64"]}}},
+			{"id": 1, "name": "sha256:1", "created_at": %q, "metadata": {"container": {"tags": ["nightly-1", "v1.0.0"]}}},
+			{"id": 0, "name": "sha256:0", "created_at": %q, "metadata": {"container": {"tags": []}}}
+		]`, recent, old, old, old)
+	})
+	mux.HandleFunc("DELETE /orgs/foo/packages/container/bar/versions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.PathValue("id"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	githubAPI = srv.URL
+	t.Cleanup(func() {
+		githubAPI = "https://api.github.com"
+	})
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DockerCleanups: []config.DockerCleanup{{
+			Repositories: []string{"ghcr.io/foo/bar"},
+			Tags:         []string{"^nightly-"},
+			MaxAge:       24 * time.Hour,
+		}},
+	}, testctx.GitHubTokenType, testctx.WithToken("gh-token"))
+	require.NoError(t, Pipe{}.Publish(ctx))
+	require.Equal(t, []string{"2"}, deleted)
+}
+
+func TestGHCRNoToken(t *testing.T) {
+	repo, err := name.NewRepository("ghcr.io/foo/bar")
+	require.NoError(t, err)
+	_, err = newGHCR(testctx.Wrap(t.Context(), testctx.WithEnv(map[string]string{})), repo)
+	require.ErrorContains(t, err, "a GitHub token is required")
+}
+
+func TestGHCRInvalidRepository(t *testing.T) {
+	repo, err := name.NewRepository("ghcr.io/foo")
+	require.NoError(t, err)
+	_, err = newGHCR(testctx.Wrap(t.Context(), testctx.WithEnv(map[string]string{
+		"GITHUB_TOKEN": "gh-token",
+	})), repo)
+	require.ErrorContains(t, err, "invalid GHCR repository")
+}
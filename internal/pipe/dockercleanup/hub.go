@@ -0,0 +1,79 @@
+package dockercleanup
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// hubAPI is the Docker Hub API.
+var hubAPI = "https://hub.docker.com"
+
+// hubBackend uses the Docker Hub API, as Docker Hub doesn't allow deleting
+// manifests through the registry API.
+type hubBackend struct {
+	token   string
+	baseURL string
+}
+
+func newHub(ctx *context.Context, repo name.Repository) (backend, error) {
+	username, password := ctx.Env["DOCKERHUB_USERNAME"], ctx.Env["DOCKERHUB_TOKEN"]
+	if username == "" || password == "" {
+		return nil, errors.New("DOCKERHUB_USERNAME and DOCKERHUB_TOKEN are required to clean up Docker Hub repositories")
+	}
+	var login struct {
+		Token string `json:"token"`
+	}
+	if err := request(ctx, http.MethodPost, hubAPI+"/v2/users/login", "", map[string]string{
+		"username": username,
+		"password": password,
+	}, &login); err != nil {
+		return nil, err
+	}
+	return hubBackend{
+		token:   login.Token,
+		baseURL: hubAPI + "/v2/repositories/" + repo.RepositoryStr() + "/tags",
+	}, nil
+}
+
+func (b hubBackend) auth() string { return "Bearer " + b.token }
+
+func (b hubBackend) tags(ctx *context.Context, _ func(string) bool) ([]tag, error) {
+	var result []tag
+	next := b.baseURL + "?page_size=100"
+	for next != "" {
+		var page struct {
+			Next    string `json:"next"`
+			Results []struct {
+				Name          string    `json:"name"`
+				Digest        string    `json:"digest"`
+				TagLastPushed time.Time `json:"tag_last_pushed"`
+			} `json:"results"`
+		}
+		if err := request(ctx, http.MethodGet, next, b.auth(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, t := range page.Results {
+			result = append(result, tag{
+				name:    t.Name,
+				digest:  t.Digest,
+				created: t.TagLastPushed,
+			})
+		}
+		next = page.Next
+	}
+	return result, nil
+}
+
+func (b hubBackend) delete(ctx *context.Context, tags []tag) error {
+	for _, t := range tags {
+		if err := request(ctx, http.MethodDelete, b.baseURL+"/"+url.PathEscape(t.name)+"/", b.auth(), nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
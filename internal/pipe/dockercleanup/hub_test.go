@@ -0,0 +1,76 @@
+package dockercleanup
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub(t *testing.T) {
+	old := time.Now().AddDate(0, 0, -10).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	var srv *httptest.Server
+	var deleted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v2/users/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]string{"username": "foo", "password": "hub-token"}, body)
+		fmt.Fprint(w, `{"token": "jwt"}`)
+	})
+	mux.HandleFunc("GET /v2/repositories/foo/bar/tags", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer jwt", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprintf(w, `{"next": null, "results": [
+				{"name": "nightly-1", "digest": "sha256:1", "tag_last_pushed": %q},
+				{"name": "latest", "digest": "sha256:3", "tag_last_pushed": %q}
+			]}`, old, recent)
+			return
+		}
+		fmt.Fprintf(w, `{"next": %q, "results": [
+			{"name": "nightly-3", "digest": "sha256:3", "tag_last_pushed": %q},
+			{"name": "nightly-2", "digest": "sha256:2", "tag_last_pushed": %q},
+			{"name": "nightly-2-amd64", "digest": "sha256:2", "tag_last_pushed": %q}
+		]}`, srv.URL+"/v2/repositories/foo/bar/tags?page=2", recent, old, old)
+	})
+	mux.HandleFunc("DELETE /v2/repositories/foo/bar/tags/{tag}/", func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.PathValue("tag"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	hubAPI = srv.URL
+	t.Cleanup(func() {
+		hubAPI = "https://hub.docker.com"
+	})
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DockerCleanups: []config.DockerCleanup{{
+			Repositories: []string{"foo/bar"},
+			Tags:         []string{"^nightly-"},
+			Keep:         1,
+		}},
+	}, testctx.WithEnv(map[string]string{
+		"DOCKERHUB_USERNAME": "foo",
+		"DOCKERHUB_TOKEN":    "hub-token",
+	}))
+	require.NoError(t, Pipe{}.Publish(ctx))
+	require.Equal(t, []string{"nightly-2", "nightly-2-amd64", "nightly-1"}, deleted)
+}
+
+func TestHubNoCredentials(t *testing.T) {
+	repo, err := name.NewRepository("foo/bar")
+	require.NoError(t, err)
+	_, err = newHub(testctx.Wrap(t.Context(), testctx.WithEnv(map[string]string{})), repo)
+	require.ErrorContains(t, err, "DOCKERHUB_USERNAME and DOCKERHUB_TOKEN are required")
+}
@@ -0,0 +1,96 @@
+package dockercleanup
+
+import (
+	"errors"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// registryBackend uses the OCI distribution API, which works with any
+// registry that allows deleting manifests.
+type registryBackend struct {
+	repo name.Repository
+}
+
+func newRegistry(repo name.Repository) backend {
+	return registryBackend{repo: repo}
+}
+
+func (r registryBackend) options(ctx *context.Context) []remote.Option {
+	return []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+}
+
+func (r registryBackend) tags(ctx *context.Context, match func(string) bool) ([]tag, error) {
+	opts := r.options(ctx)
+	names, err := remote.List(r.repo, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]tag, 0, len(names))
+	for _, n := range names {
+		ref := r.repo.Tag(n)
+		if !match(n) {
+			desc, err := remote.Head(ref, opts...)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, tag{name: n, digest: desc.Digest.String()})
+			continue
+		}
+
+		desc, err := remote.Get(ref, opts...)
+		if err != nil {
+			return nil, err
+		}
+		img, err := image(desc)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := img.ConfigFile()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tag{
+			name:    n,
+			digest:  desc.Digest.String(),
+			created: cfg.Created.Time,
+		})
+	}
+	return result, nil
+}
+
+func (r registryBackend) delete(ctx *context.Context, tags []tag) error {
+	return remote.Delete(r.repo.Digest(tags[0].digest), r.options(ctx)...)
+}
+
+// image returns the image of the given descriptor, or the first image of it,
+// if it is an index.
+func image(desc *remote.Descriptor) (v1.Image, error) {
+	if !desc.MediaType.IsIndex() {
+		return desc.Image()
+	}
+	idx, err := desc.ImageIndex()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range manifest.Manifests {
+		// skip attestation manifests.
+		if m.Platform != nil && m.Platform.OS == "unknown" {
+			continue
+		}
+		return idx.Image(m.Digest)
+	}
+	return nil, errors.New("index has no images")
+}
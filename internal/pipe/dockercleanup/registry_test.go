@@ -0,0 +1,146 @@
+package dockercleanup
+
+import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	repo := newTestRegistry(t)
+	now := time.Now()
+	newest := pushImage(t, repo, []string{"nightly-3", "latest"}, now.Add(-time.Hour))
+	released := pushImage(t, repo, []string{"nightly-1", "v1.0.0"}, now.AddDate(0, 0, -3))
+	old := pushImage(t, repo, []string{"nightly-0"}, now.AddDate(0, 0, -4))
+
+	// multi-platform image, with an attestation manifest first.
+	idx := mutate.AppendManifests(
+		empty.Index,
+		mutate.IndexAddendum{
+			Add: newRandomImage(t, time.Time{}),
+			Descriptor: v1.Descriptor{
+				Platform: &v1.Platform{OS: "unknown", Architecture: "unknown"},
+			},
+		},
+		mutate.IndexAddendum{
+			Add: newRandomImage(t, now.AddDate(0, 0, -2)),
+			Descriptor: v1.Descriptor{
+				Platform: &v1.Platform{OS: "linux", Architecture: "amd64"},
+			},
+		},
+	)
+	require.NoError(t, remote.WriteIndex(repo.Tag("nightly-2"), idx))
+	multi, err := idx.Digest()
+	require.NoError(t, err)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DockerCleanups: []config.DockerCleanup{{
+			Repositories: []string{repo.Name()},
+			Tags:         []string{"^nightly-"},
+			Keep:         1,
+			MaxAge:       36 * time.Hour,
+		}},
+	})
+
+	tags, err := newRegistry(repo).tags(ctx, func(s string) bool {
+		return strings.HasPrefix(s, "nightly-")
+	})
+	require.NoError(t, err)
+	require.Len(t, tags, 6)
+	for _, tag := range tags {
+		if tag.name == "nightly-2" {
+			require.Equal(t, multi.String(), tag.digest)
+			require.WithinDuration(t, now.AddDate(0, 0, -2), tag.created, time.Second)
+		}
+		if tag.name == "latest" {
+			require.Equal(t, newest, tag.digest)
+			require.True(t, tag.created.IsZero())
+		}
+	}
+
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	exists := func(digest string) bool {
+		_, err := remote.Head(repo.Digest(digest))
+		return err == nil
+	}
+	require.True(t, exists(newest))
+	require.True(t, exists(released))
+	require.False(t, exists(multi.String()))
+	require.False(t, exists(old))
+}
+
+func TestRegistryUndated(t *testing.T) {
+	repo := newTestRegistry(t)
+	digest := pushImage(t, repo, []string{"nightly-1"}, time.Unix(0, 0))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DockerCleanups: []config.DockerCleanup{{
+			Repositories: []string{repo.Name()},
+			Tags:         []string{"^nightly-"},
+			MaxAge:       time.Hour,
+		}},
+	})
+	require.ErrorContains(t, Pipe{}.Publish(ctx), "tag nightly-1 has no creation date")
+	_, err := remote.Head(repo.Digest(digest))
+	require.NoError(t, err)
+}
+
+func TestRegistryDryRun(t *testing.T) {
+	repo := newTestRegistry(t)
+	digest := pushImage(t, repo, []string{"nightly-1"}, time.Now().AddDate(0, 0, -2))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		DockerCleanups: []config.DockerCleanup{{
+			Repositories: []string{repo.Name()},
+			Tags:         []string{"^nightly-"},
+			MaxAge:       time.Hour,
+			DryRun:       true,
+		}},
+	})
+	require.NoError(t, Pipe{}.Publish(ctx))
+	_, err := remote.Head(repo.Digest(digest))
+	require.NoError(t, err)
+}
+
+func newTestRegistry(tb testing.TB) name.Repository {
+	tb.Helper()
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	tb.Cleanup(srv.Close)
+	repo, err := name.NewRepository(strings.TrimPrefix(srv.URL, "http://") + "/foo/bar")
+	require.NoError(tb, err)
+	return repo
+}
+
+func newRandomImage(tb testing.TB, created time.Time) v1.Image {
+	tb.Helper()
+	img, err := random.Image(64, 1)
+	require.NoError(tb, err)
+	img, err = mutate.CreatedAt(img, v1.Time{Time: created})
+	require.NoError(tb, err)
+	return img
+}
+
+// pushImage pushes a random image with the given tags, returning its digest.
+func pushImage(tb testing.TB, repo name.Repository, tags []string, created time.Time) string {
+	tb.Helper()
+	img := newRandomImage(tb, created)
+	for _, tag := range tags {
+		require.NoError(tb, remote.Write(repo.Tag(tag), img))
+	}
+	digest, err := img.Digest()
+	require.NoError(tb, err)
+	return digest.String()
+}
@@ -19,8 +19,8 @@ import (
 	homedir "github.com/mitchellh/go-homedir"
 )
 
-// ErrMissingToken indicates an error when GITHUB_TOKEN, GITLAB_TOKEN and GITEA_TOKEN are all missing in the environment.
-var ErrMissingToken = errors.New("missing GITHUB_TOKEN, GITLAB_TOKEN and GITEA_TOKEN")
+// ErrMissingToken indicates an error when GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN, FORGEJO_TOKEN and BITBUCKET_TOKEN are all missing in the environment.
+var ErrMissingToken = errors.New("missing GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN, FORGEJO_TOKEN and BITBUCKET_TOKEN")
 
 // ErrMultipleTokens indicates that multiple tokens are defined. ATM only one of them if allowed.
 // See https://github.com/goreleaser/goreleaser/pull/809
@@ -50,6 +50,12 @@ func setDefaultTokenFiles(ctx *context.Context) {
 	if env.GiteaToken == "" {
 		env.GiteaToken = "~/.config/goreleaser/gitea_token"
 	}
+	if env.ForgejoToken == "" {
+		env.ForgejoToken = "~/.config/goreleaser/forgejo_token"
+	}
+	if env.BitbucketToken == "" {
+		env.BitbucketToken = "~/.config/goreleaser/bitbucket_token"
+	}
 }
 
 // Run the pipe.
@@ -69,6 +75,8 @@ func (Pipe) Run(ctx *context.Context) error {
 	githubToken, githubTokenErr := loadEnv("GITHUB_TOKEN", ctx.Config.EnvFiles.GitHubToken)
 	gitlabToken, gitlabTokenErr := loadEnv("GITLAB_TOKEN", ctx.Config.EnvFiles.GitLabToken)
 	giteaToken, giteaTokenErr := loadEnv("GITEA_TOKEN", ctx.Config.EnvFiles.GiteaToken)
+	forgejoToken, forgejoTokenErr := loadEnv("FORGEJO_TOKEN", ctx.Config.EnvFiles.ForgejoToken)
+	bitbucketToken, bitbucketTokenErr := loadEnv("BITBUCKET_TOKEN", ctx.Config.EnvFiles.BitbucketToken)
 
 	forceToken := ctx.Config.ForceToken
 	if forceToken == "" {
@@ -78,12 +86,28 @@ func (Pipe) Run(ctx *context.Context) error {
 	case "github":
 		gitlabToken = ""
 		giteaToken = ""
+		forgejoToken = ""
+		bitbucketToken = ""
 	case "gitlab":
 		githubToken = ""
 		giteaToken = ""
+		forgejoToken = ""
+		bitbucketToken = ""
 	case "gitea":
 		githubToken = ""
 		gitlabToken = ""
+		forgejoToken = ""
+		bitbucketToken = ""
+	case "forgejo":
+		githubToken = ""
+		gitlabToken = ""
+		giteaToken = ""
+		bitbucketToken = ""
+	case "bitbucket":
+		githubToken = ""
+		gitlabToken = ""
+		giteaToken = ""
+		forgejoToken = ""
 	default:
 		var tokens []string
 		if githubToken != "" {
@@ -95,15 +119,21 @@ func (Pipe) Run(ctx *context.Context) error {
 		if giteaToken != "" {
 			tokens = append(tokens, "GITEA_TOKEN")
 		}
+		if forgejoToken != "" {
+			tokens = append(tokens, "FORGEJO_TOKEN")
+		}
+		if bitbucketToken != "" {
+			tokens = append(tokens, "BITBUCKET_TOKEN")
+		}
 		if len(tokens) > 1 {
 			return ErrMultipleTokens{tokens}
 		}
 	}
 
-	noTokens := githubToken == "" && gitlabToken == "" && giteaToken == ""
-	noTokenErrs := githubTokenErr == nil && gitlabTokenErr == nil && giteaTokenErr == nil
+	noTokens := githubToken == "" && gitlabToken == "" && giteaToken == "" && forgejoToken == "" && bitbucketToken == ""
+	noTokenErrs := githubTokenErr == nil && gitlabTokenErr == nil && giteaTokenErr == nil && forgejoTokenErr == nil && bitbucketTokenErr == nil
 
-	if err := checkErrors(ctx, noTokens, noTokenErrs, gitlabTokenErr, githubTokenErr, giteaTokenErr); err != nil {
+	if err := checkErrors(ctx, noTokens, noTokenErrs, gitlabTokenErr, githubTokenErr, giteaTokenErr, forgejoTokenErr, bitbucketTokenErr); err != nil {
 		return err
 	}
 
@@ -119,6 +149,18 @@ func (Pipe) Run(ctx *context.Context) error {
 		ctx.Token = giteaToken
 	}
 
+	if forgejoToken != "" {
+		log.Debug("token type: forgejo")
+		ctx.TokenType = context.TokenTypeForgejo
+		ctx.Token = forgejoToken
+	}
+
+	if bitbucketToken != "" {
+		log.Debug("token type: bitbucket")
+		ctx.TokenType = context.TokenTypeBitbucket
+		ctx.Token = bitbucketToken
+	}
+
 	if githubToken != "" {
 		log.Debug("token type: github")
 		ctx.Token = githubToken
@@ -131,7 +173,7 @@ func (Pipe) Run(ctx *context.Context) error {
 	return nil
 }
 
-func checkErrors(ctx *context.Context, noTokens, noTokenErrs bool, gitlabTokenErr, githubTokenErr, giteaTokenErr error) error {
+func checkErrors(ctx *context.Context, noTokens, noTokenErrs bool, gitlabTokenErr, githubTokenErr, giteaTokenErr, forgejoTokenErr, bitbucketTokenErr error) error {
 	if ctx.SkipTokenCheck || skips.Any(ctx, skips.Publish) {
 		return nil
 	}
@@ -154,6 +196,14 @@ func checkErrors(ctx *context.Context, noTokens, noTokenErrs bool, gitlabTokenEr
 	if giteaTokenErr != nil {
 		return fmt.Errorf("failed to load gitea token: %w", giteaTokenErr)
 	}
+
+	if forgejoTokenErr != nil {
+		return fmt.Errorf("failed to load forgejo token: %w", forgejoTokenErr)
+	}
+
+	if bitbucketTokenErr != nil {
+		return fmt.Errorf("failed to load bitbucket token: %w", bitbucketTokenErr)
+	}
 	return nil
 }
 
@@ -15,7 +15,7 @@ import (
 
 func TestMain(m *testing.M) {
 	restores := map[string]string{}
-	for _, key := range []string{"GITHUB_TOKEN", "GITEA_TOKEN", "GITLAB_TOKEN"} {
+	for _, key := range []string{"GITHUB_TOKEN", "GITEA_TOKEN", "GITLAB_TOKEN", "FORGEJO_TOKEN", "BITBUCKET_TOKEN"} {
 		prevValue, ok := os.LookupEnv(key)
 		if ok {
 			_ = os.Unsetenv(key)
@@ -41,6 +41,8 @@ func TestSetDefaultTokenFiles(t *testing.T) {
 		require.Equal(t, "~/.config/goreleaser/github_token", ctx.Config.EnvFiles.GitHubToken)
 		require.Equal(t, "~/.config/goreleaser/gitlab_token", ctx.Config.EnvFiles.GitLabToken)
 		require.Equal(t, "~/.config/goreleaser/gitea_token", ctx.Config.EnvFiles.GiteaToken)
+		require.Equal(t, "~/.config/goreleaser/forgejo_token", ctx.Config.EnvFiles.ForgejoToken)
+		require.Equal(t, "~/.config/goreleaser/bitbucket_token", ctx.Config.EnvFiles.BitbucketToken)
 	})
 	t.Run("custom config config", func(t *testing.T) {
 		cfg := "what"
@@ -110,6 +112,22 @@ func TestForceToken(t *testing.T) {
 		require.NoError(t, Pipe{}.Run(ctx))
 		require.Equal(t, context.TokenTypeGitea, ctx.TokenType)
 	})
+	t.Run("forgejo", func(t *testing.T) {
+		t.Setenv("FORGEJO_TOKEN", "fake")
+		t.Setenv("GITHUB_TOKEN", "fake")
+		t.Setenv("GORELEASER_FORCE_TOKEN", "forgejo")
+		ctx := testctx.Wrap(t.Context())
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, context.TokenTypeForgejo, ctx.TokenType)
+	})
+	t.Run("bitbucket", func(t *testing.T) {
+		t.Setenv("BITBUCKET_TOKEN", "fake")
+		t.Setenv("GITHUB_TOKEN", "fake")
+		t.Setenv("GORELEASER_FORCE_TOKEN", "bitbucket")
+		ctx := testctx.Wrap(t.Context())
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, context.TokenTypeBitbucket, ctx.TokenType)
+	})
 }
 
 func TestValidGithubEnv(t *testing.T) {
@@ -136,6 +154,22 @@ func TestValidGiteaEnv(t *testing.T) {
 	require.Equal(t, context.TokenTypeGitea, ctx.TokenType)
 }
 
+func TestValidForgejoEnv(t *testing.T) {
+	t.Setenv("FORGEJO_TOKEN", "token")
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "token", ctx.Token)
+	require.Equal(t, context.TokenTypeForgejo, ctx.TokenType)
+}
+
+func TestValidBitbucketEnv(t *testing.T) {
+	t.Setenv("BITBUCKET_TOKEN", "user:token")
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "user:token", ctx.Token)
+	require.Equal(t, context.TokenTypeBitbucket, ctx.TokenType)
+}
+
 func TestInvalidEnv(t *testing.T) {
 	ctx := testctx.Wrap(t.Context())
 	require.Error(t, Pipe{}.Run(ctx))
@@ -220,6 +254,36 @@ func TestEmptyGiteaEnvFile(t *testing.T) {
 	require.ErrorContains(t, err, "failed to load gitea token")
 }
 
+func TestEmptyForgejoEnvFile(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "token")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.NoError(t, os.Chmod(f.Name(), 0o377))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		EnvFiles: config.EnvFiles{
+			ForgejoToken: f.Name(),
+		},
+	})
+
+	err = Pipe{}.Run(ctx)
+	require.ErrorContains(t, err, "failed to load forgejo token")
+}
+
+func TestEmptyBitbucketEnvFile(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "token")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.NoError(t, os.Chmod(f.Name(), 0o377))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		EnvFiles: config.EnvFiles{
+			BitbucketToken: f.Name(),
+		},
+	})
+
+	err = Pipe{}.Run(ctx)
+	require.ErrorContains(t, err, "failed to load bitbucket token")
+}
+
 func TestInvalidEnvChecksSkipped(t *testing.T) {
 	ctx := testctx.Wrap(t.Context(), testctx.Skip(skips.Publish))
 	require.NoError(t, Pipe{}.Run(ctx))
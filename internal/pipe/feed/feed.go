@@ -0,0 +1,392 @@
+// Package feed generates an Atom or RSS feed of releases.
+package feed
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"gocloud.dev/blob"
+
+	// Import the blob packages we want to be able to open.
+	_ "gocloud.dev/blob/azureblob"
+	_ "gocloud.dev/blob/gcsblob"
+	_ "gocloud.dev/blob/s3blob"
+
+	"github.com/goreleaser/goreleaser/v2/internal/announcement"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/commitauthor"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultFilename              = "feed.xml"
+	defaultTitleTemplate         = `{{ .ProjectName }} releases`
+	defaultEntryTitleTemplate    = `{{ .ProjectName }} {{ .Tag }}`
+	defaultCommitMessageTemplate = `Feed update for {{ .ProjectName }} version {{ .Tag }}`
+	defaultMaxEntries            = 50
+
+	FormatAtom = "atom"
+	FormatRSS  = "rss"
+)
+
+var errNotFound = errors.New("feed not found")
+
+type Pipe struct{}
+
+func (Pipe) String() string { return "feed" }
+func (Pipe) Skip(ctx *context.Context) (bool, error) {
+	enable, err := tmpl.New(ctx).Bool(ctx.Config.Announce.Feed.Enabled)
+	return !enable, err
+}
+
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Announce.Feed
+	if cfg.Format == "" {
+		cfg.Format = FormatAtom
+	}
+	if cfg.Filename == "" {
+		cfg.Filename = defaultFilename
+	}
+	if cfg.TitleTemplate == "" {
+		cfg.TitleTemplate = defaultTitleTemplate
+	}
+	if cfg.EntryTitleTemplate == "" {
+		cfg.EntryTitleTemplate = defaultEntryTitleTemplate
+	}
+	if cfg.MaxEntries == 0 {
+		cfg.MaxEntries = defaultMaxEntries
+	}
+	if cfg.CommitMessageTemplate == "" {
+		cfg.CommitMessageTemplate = defaultCommitMessageTemplate
+	}
+	cfg.CommitAuthor = commitauthor.Default(cfg.CommitAuthor)
+	return nil
+}
+
+// Preview returns the feed that would be written.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	_, bts, err := build(ctx)
+	return string(bts), err
+}
+
+func (Pipe) Announce(ctx *context.Context) error {
+	cfg := ctx.Config.Announce.Feed
+	filename, bts, err := build(ctx)
+	if err != nil {
+		return err
+	}
+
+	bucket := cfg.Bucket
+	if err := tmpl.New(ctx).WithMessageTemplates().ApplyAll(&bucket); err != nil {
+		return err
+	}
+
+	out := filepath.Join(ctx.Config.Dist, filename)
+	log.WithField("feed", out).Info("writing")
+	if err := os.WriteFile(out, bts, 0o644); err != nil {
+		return fmt.Errorf("feed: %w", err)
+	}
+
+	if bucket != "" {
+		if err := upload(ctx, bucket, filename, bts); err != nil {
+			return fmt.Errorf("feed: could not upload to %s: %w", bucket, err)
+		}
+	}
+
+	if cfg.Repository.Name == "" && cfg.Repository.Git.URL == "" {
+		return nil
+	}
+	return commit(ctx, filename, bts)
+}
+
+// build returns the templated filename and the rendered feed, including the
+// entries of the feed to append to, if any.
+func build(ctx *context.Context) (string, []byte, error) {
+	cfg := ctx.Config.Announce.Feed
+	if cfg.Format != FormatAtom && cfg.Format != FormatRSS {
+		return "", nil, fmt.Errorf("feed: invalid format: %q", cfg.Format)
+	}
+
+	filename, feedURL, title, entryTitle, appendTo := cfg.Filename, cfg.URL, cfg.TitleTemplate, cfg.EntryTitleTemplate, cfg.AppendTo
+	if err := tmpl.New(ctx).WithMessageTemplates().ApplyAll(&filename, &feedURL, &title, &entryTitle, &appendTo); err != nil {
+		return "", nil, err
+	}
+
+	content, err := entryContent(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var entries []entry
+	if appendTo != "" {
+		entries, err = load(ctx, appendTo)
+		if errors.Is(err, errNotFound) {
+			log.WithField("feed", appendTo).Info("feed not found, creating a new one")
+		} else if err != nil {
+			return "", nil, fmt.Errorf("feed: could not load %s: %w", appendTo, err)
+		}
+	}
+
+	latest := entry{
+		ID:      entryID(ctx),
+		Title:   entryTitle,
+		Link:    ctx.ReleaseURL,
+		Content: content,
+		Updated: ctx.Date,
+	}
+	id := feedURL
+	if id == "" {
+		id = "urn:goreleaser:" + ctx.Config.ProjectName
+	}
+	bts, err := render(cfg.Format, feed{
+		ID:      id,
+		Title:   title,
+		Link:    feedURL,
+		Updated: ctx.Date,
+		Entries: merge(latest, entries, cfg.MaxEntries),
+	})
+	return filename, bts, err
+}
+
+// entryID returns an unique id for the current release, which is used to
+// avoid duplicate entries when re-running the release.
+func entryID(ctx *context.Context) string {
+	if ctx.ReleaseURL != "" {
+		return ctx.ReleaseURL
+	}
+	return fmt.Sprintf("urn:goreleaser:%s:%s", ctx.Config.ProjectName, ctx.Git.CurrentTag)
+}
+
+// entryContent returns the release notes followed by the list of downloads.
+func entryContent(ctx *context.Context) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(strings.TrimSpace(ctx.ReleaseNotes))
+
+	urlTemplate := announcement.ReleaseURLTemplate(ctx)
+	if urlTemplate == "" {
+		return sb.String(), nil
+	}
+
+	artifacts := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByTypes(artifact.ReleaseUploadableTypes()...),
+		artifact.ByIDs(ctx.Config.Release.IDs...),
+	)).List()
+	if len(artifacts) == 0 {
+		return sb.String(), nil
+	}
+
+	if sb.Len() > 0 {
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("Downloads:\n")
+	for _, a := range artifacts {
+		url, err := tmpl.New(ctx).WithArtifact(a).Apply(urlTemplate)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "- %s: %s\n", a.Name, url)
+	}
+	return sb.String(), nil
+}
+
+// merge prepends the latest entry to the existing ones, removing any previous
+// entry with the same id, and keeps at most max entries.
+func merge(latest entry, entries []entry, maxEntries int) []entry {
+	result := []entry{latest}
+	for _, e := range entries {
+		if e.ID == latest.ID {
+			continue
+		}
+		result = append(result, e)
+	}
+	if maxEntries > 0 && len(result) > maxEntries {
+		result = result[:maxEntries]
+	}
+	return result
+}
+
+func render(format string, f feed) ([]byte, error) {
+	var v any
+	switch format {
+	case FormatRSS:
+		v = f.rss()
+	default:
+		v = f.atom()
+	}
+	bts, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("feed: %w", err)
+	}
+	return append([]byte(xml.Header), append(bts, '\n')...), nil
+}
+
+// parse reads the entries of an existing feed, either Atom or RSS.
+func parse(bts []byte) ([]entry, error) {
+	var probe struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(bts, &probe); err != nil {
+		return nil, err
+	}
+	switch probe.XMLName.Local {
+	case "feed":
+		var f atomFeed
+		if err := xml.Unmarshal(bts, &f); err != nil {
+			return nil, err
+		}
+		return f.entries(), nil
+	case "rss":
+		var f rssFeed
+		if err := xml.Unmarshal(bts, &f); err != nil {
+			return nil, err
+		}
+		return f.entries(), nil
+	default:
+		return nil, fmt.Errorf("unknown feed type: %q", probe.XMLName.Local)
+	}
+}
+
+// load reads an existing feed from a local path or an http(s) URL.
+func load(ctx *context.Context, from string) ([]entry, error) {
+	if !strings.HasPrefix(from, "http://") && !strings.HasPrefix(from, "https://") {
+		bts, err := os.ReadFile(from)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		return parse(bts)
+	}
+
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var bts []byte
+	if err := retryx.Do(ctx, ctx.Config.Retry, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, from, nil)
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return retryx.HTTP(err, resp)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusNotFound {
+			return retryx.Unrecoverable(errNotFound)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return retryx.HTTP(fmt.Errorf("%s", resp.Status), resp)
+		}
+
+		bts, err = io.ReadAll(resp.Body)
+		return err
+	}, retryx.IsRetriable); err != nil {
+		return nil, err
+	}
+	return parse(bts)
+}
+
+func upload(ctx *context.Context, bucketURL, name string, content []byte) error {
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return err
+	}
+	defer bucket.Close()
+
+	log.WithField("bucket", bucketURL).WithField("feed", name).Info("uploading")
+	return bucket.WriteAll(ctx, name, content, &blob.WriterOptions{
+		ContentType: contentType(ctx.Config.Announce.Feed.Format),
+	})
+}
+
+func commit(ctx *context.Context, filename string, content []byte) error {
+	cfg := ctx.Config.Announce.Feed
+	ref, err := client.TemplateRef(tmpl.New(ctx).WithMessageTemplates().Apply, cfg.Repository)
+	if err != nil {
+		return err
+	}
+	cfg.Repository = ref
+	repo := client.RepoFromRef(cfg.Repository)
+	gpath := path.Join(cfg.Directory, filename)
+
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(cfg.CommitMessageTemplate)
+	if err != nil {
+		return err
+	}
+
+	author, err := commitauthor.Get(ctx, cfg.CommitAuthor)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Repository.Git.URL != "" {
+		return client.NewGitUploadClient(repo.Branch).
+			CreateFile(ctx, author, repo, content, gpath, msg)
+	}
+
+	cli, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+	cli, err = client.NewIfToken(ctx, cli, cfg.Repository.Token)
+	if err != nil {
+		return err
+	}
+
+	base := client.Repo{
+		Name:   cfg.Repository.PullRequest.Base.Name,
+		Owner:  cfg.Repository.PullRequest.Base.Owner,
+		Branch: cfg.Repository.PullRequest.Base.Branch,
+	}
+
+	// try to sync branch
+	fscli, ok := cli.(client.ForkSyncer)
+	if ok && cfg.Repository.PullRequest.Enabled {
+		if err := fscli.SyncFork(ctx, repo, base); err != nil {
+			log.WithError(err).Warn("could not sync fork")
+		}
+	}
+
+	if err := cli.CreateFile(ctx, author, repo, content, gpath, msg); err != nil {
+		return err
+	}
+
+	if !cfg.Repository.PullRequest.Enabled {
+		log.Debug("feed.pull_request disabled")
+		return nil
+	}
+
+	log.Info("feed.pull_request enabled, creating a PR")
+	pcl, ok := cli.(client.PullRequestOpener)
+	if !ok {
+		return errors.New("client does not support pull requests")
+	}
+
+	return pcl.OpenPullRequest(ctx, base, repo, msg, cfg.Repository.PullRequest.Body, cfg.Repository.PullRequest.Draft)
+}
+
+func contentType(format string) string {
+	if format == FormatRSS {
+		return "application/rss+xml"
+	}
+	return "application/atom+xml"
+}
@@ -0,0 +1,292 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/fileblob"
+)
+
+func TestStringer(t *testing.T) {
+	require.Equal(t, "feed", Pipe{}.String())
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	cfg := ctx.Config.Announce.Feed
+	require.Equal(t, FormatAtom, cfg.Format)
+	require.Equal(t, defaultFilename, cfg.Filename)
+	require.Equal(t, defaultTitleTemplate, cfg.TitleTemplate)
+	require.Equal(t, defaultEntryTitleTemplate, cfg.EntryTitleTemplate)
+	require.Equal(t, defaultMaxEntries, cfg.MaxEntries)
+	require.Equal(t, defaultCommitMessageTemplate, cfg.CommitMessageTemplate)
+	require.NotEmpty(t, cfg.CommitAuthor.Name)
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Feed: config.Feed{
+					Enabled: "true",
+				},
+			},
+		})
+
+		skip, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+}
+
+func TestAnnounce(t *testing.T) {
+	for _, format := range []string{FormatAtom, FormatRSS} {
+		t.Run(format, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "honk",
+				Dist:        t.TempDir(),
+				Announce: config.Announce{
+					Feed: config.Feed{
+						Format: format,
+						URL:    "https://honk.dev/feed.xml",
+					},
+				},
+			}, testctx.WithCurrentTag("v1.0.0"))
+			ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+			ctx.ReleaseNotes = "## Changelog\n\n- honk"
+			ctx.Date = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Announce(ctx))
+
+			bts, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "feed.xml"))
+			require.NoError(t, err)
+			require.Contains(t, string(bts), "honk releases")
+			require.Contains(t, string(bts), "honk v1.0.0")
+			require.Contains(t, string(bts), "https://github.com/honk/honk/releases/tag/v1.0.0")
+			require.Contains(t, string(bts), "## Changelog")
+
+			entries, err := parse(bts)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			require.Equal(t, ctx.ReleaseURL, entries[0].ID)
+			require.True(t, ctx.Date.Equal(entries[0].Updated))
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	dist := t.TempDir()
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Dist:        dist,
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+	ctx.ReleaseNotes = "## Changelog\n\n- honk"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Contains(t, out, "honk releases")
+	require.Contains(t, out, "honk v1.0.0")
+
+	entries, err := parse([]byte(out))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// nothing is written.
+	files, err := os.ReadDir(dist)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestAnnounceAppend(t *testing.T) {
+	for _, format := range []string{FormatAtom, FormatRSS} {
+		t.Run(format, func(t *testing.T) {
+			existing, err := render(format, feed{
+				ID:      "urn:goreleaser:honk",
+				Title:   "honk releases",
+				Updated: time.Now(),
+				Entries: []entry{
+					{ID: "urn:goreleaser:honk:v1.0.0", Title: "honk v1.0.0 (old)"},
+					{ID: "urn:goreleaser:honk:v0.2.0", Title: "honk v0.2.0"},
+					{ID: "urn:goreleaser:honk:v0.1.0", Title: "honk v0.1.0"},
+				},
+			})
+			require.NoError(t, err)
+
+			appendTo := filepath.Join(t.TempDir(), "old.xml")
+			require.NoError(t, os.WriteFile(appendTo, existing, 0o644))
+
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "honk",
+				Dist:        t.TempDir(),
+				Announce: config.Announce{
+					Feed: config.Feed{
+						Format:     format,
+						AppendTo:   appendTo,
+						MaxEntries: 2,
+					},
+				},
+			}, testctx.WithCurrentTag("v1.0.0"))
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Announce(ctx))
+
+			bts, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "feed.xml"))
+			require.NoError(t, err)
+			entries, err := parse(bts)
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			require.Equal(t, "honk v1.0.0", entries[0].Title)
+			require.Equal(t, "honk v0.2.0", entries[1].Title)
+		})
+	}
+}
+
+func TestAnnounceAppendHTTP(t *testing.T) {
+	existing, err := render(FormatAtom, feed{
+		ID:    "urn:goreleaser:honk",
+		Title: "honk releases",
+		Entries: []entry{
+			{ID: "urn:goreleaser:honk:v0.1.0", Title: "honk v0.1.0"},
+		},
+	})
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/feed.xml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(existing)
+	}))
+	t.Cleanup(ts.Close)
+
+	for name, tt := range map[string]struct {
+		path    string
+		entries int
+	}{
+		"existing": {"/feed.xml", 2},
+		"missing":  {"/nope.xml", 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "honk",
+				Dist:        t.TempDir(),
+				Announce: config.Announce{
+					Feed: config.Feed{
+						AppendTo: ts.URL + tt.path,
+					},
+				},
+			}, testctx.WithCurrentTag("v1.0.0"))
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Announce(ctx))
+
+			bts, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "feed.xml"))
+			require.NoError(t, err)
+			entries, err := parse(bts)
+			require.NoError(t, err)
+			require.Len(t, entries, tt.entries)
+		})
+	}
+}
+
+func TestAnnounceAppendInvalid(t *testing.T) {
+	appendTo := filepath.Join(t.TempDir(), "old.xml")
+	require.NoError(t, os.WriteFile(appendTo, []byte("<html></html>"), 0o644))
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: t.TempDir(),
+		Announce: config.Announce{
+			Feed: config.Feed{
+				AppendTo: appendTo,
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Announce(ctx), `unknown feed type: "html"`)
+}
+
+func TestAnnounceBucket(t *testing.T) {
+	dir := t.TempDir()
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Dist:        t.TempDir(),
+		Announce: config.Announce{
+			Feed: config.Feed{
+				Bucket: "file://" + filepath.ToSlash(dir),
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+
+	bucket, err := blob.OpenBucket(t.Context(), "file://"+filepath.ToSlash(dir))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = bucket.Close() })
+
+	bts, err := bucket.ReadAll(t.Context(), "feed.xml")
+	require.NoError(t, err)
+	require.Contains(t, string(bts), "honk v1.0.0")
+}
+
+func TestAnnounceInvalidFormat(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Feed: config.Feed{
+				Format: "json",
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `feed: invalid format: "json"`)
+}
+
+func TestAnnounceInvalidTemplate(t *testing.T) {
+	for name, cfg := range map[string]config.Feed{
+		"filename":    {Filename: "{{ .Foo }"},
+		"title":       {TitleTemplate: "{{ .Foo }"},
+		"entry title": {EntryTitleTemplate: "{{ .Foo }"},
+		"append to":   {AppendTo: "{{ .Foo }"},
+		"bucket":      {Bucket: "{{ .Foo }"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Dist: t.TempDir(),
+				Announce: config.Announce{
+					Feed: cfg,
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	latest := entry{ID: "c"}
+	entries := []entry{{ID: "c"}, {ID: "b"}, {ID: "a"}}
+	require.Equal(t, []entry{{ID: "c"}, {ID: "b"}, {ID: "a"}}, merge(latest, entries, 0))
+	require.Equal(t, []entry{{ID: "c"}, {ID: "b"}}, merge(latest, entries, 2))
+	require.Equal(t, []entry{{ID: "c"}}, merge(latest, nil, 2))
+}
@@ -0,0 +1,161 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// entry is a format agnostic feed entry.
+type entry struct {
+	ID      string
+	Title   string
+	Link    string
+	Content string
+	Updated time.Time
+}
+
+// feed is a format agnostic feed.
+type feed struct {
+	ID      string
+	Title   string
+	Link    string
+	Updated time.Time
+	Entries []entry
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Links   []atomLink   `xml:"link,omitempty"`
+	Content *atomContent `xml:"content,omitempty"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+func (f feed) atom() atomFeed {
+	out := atomFeed{
+		ID:      f.ID,
+		Title:   f.Title,
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+	}
+	if f.Link != "" {
+		out.Links = []atomLink{{Href: f.Link, Rel: "alternate"}}
+	}
+	for _, e := range f.Entries {
+		ae := atomEntry{
+			ID:      e.ID,
+			Title:   e.Title,
+			Updated: e.Updated.UTC().Format(time.RFC3339),
+			Content: &atomContent{Type: "text", Body: e.Content},
+		}
+		if e.Link != "" {
+			ae.Links = []atomLink{{Href: e.Link, Rel: "alternate"}}
+		}
+		out.Entries = append(out.Entries, ae)
+	}
+	return out
+}
+
+func (f atomFeed) entries() []entry {
+	var result []entry
+	for _, ae := range f.Entries {
+		e := entry{
+			ID:    ae.ID,
+			Title: ae.Title,
+		}
+		e.Updated, _ = time.Parse(time.RFC3339, ae.Updated)
+		for _, link := range ae.Links {
+			if link.Rel == "" || link.Rel == "alternate" {
+				e.Link = link.Href
+				break
+			}
+		}
+		if ae.Content != nil {
+			e.Content = ae.Content.Body
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func (f feed) rss() rssFeed {
+	out := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Title,
+			LastBuildDate: f.Updated.UTC().Format(time.RFC1123Z),
+		},
+	}
+	for _, e := range f.Entries {
+		out.Channel.Items = append(out.Channel.Items, rssItem{
+			Title:       e.Title,
+			Link:        e.Link,
+			Description: e.Content,
+			GUID:        rssGUID{Value: e.ID},
+			PubDate:     e.Updated.UTC().Format(time.RFC1123Z),
+		})
+	}
+	return out
+}
+
+func (f rssFeed) entries() []entry {
+	var result []entry
+	for _, item := range f.Channel.Items {
+		e := entry{
+			ID:      item.GUID.Value,
+			Title:   item.Title,
+			Link:    item.Link,
+			Content: item.Description,
+		}
+		e.Updated, _ = time.Parse(time.RFC1123Z, item.PubDate)
+		result = append(result, e)
+	}
+	return result
+}
@@ -0,0 +1,255 @@
+package flatpak
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/commitauthor"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const flatpakConfigExtra = "FlatpakConfig"
+
+// ErrNoArchivesFound happens when there are no linux archives to publish to
+// Flathub.
+var ErrNoArchivesFound = errors.New("no linux archives found for flathub")
+
+func hasFlathub(fp config.Flatpak) bool {
+	return fp.Flathub.Repository.Name != "" || fp.Flathub.Repository.Git.URL != ""
+}
+
+// flathubManifest creates the manifest to be published to Flathub.
+//
+// Unlike the one used to build the bundles, it downloads the linux archives
+// from the release, so it can be built by Flathub itself.
+func flathubManifest(ctx *context.Context, fp config.Flatpak, cl client.ReleaseURLTemplater) error {
+	filters := []artifact.Filter{
+		artifact.ByGoos("linux"),
+		artifact.ByType(artifact.UploadableArchive),
+		artifact.ByGoarches("amd64", "arm64"),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("amd64")),
+			artifact.ByGoamd64(fp.Flathub.Goamd64),
+		),
+	}
+	if len(fp.Flathub.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(fp.Flathub.IDs...))
+	}
+	archives := ctx.Artifacts.Filter(artifact.And(filters...)).List()
+	if len(archives) == 0 {
+		return ErrNoArchivesFound
+	}
+	slices.SortFunc(archives, func(a, b *artifact.Artifact) int {
+		return strings.Compare(a.Goarch, b.Goarch)
+	})
+
+	urlTemplate := fp.Flathub.URLTemplate
+	if urlTemplate == "" {
+		url, err := cl.ReleaseURLTemplate(ctx)
+		if err != nil {
+			return err
+		}
+		urlTemplate = url
+	}
+
+	var sources []ManifestSource
+	for i, archive := range archives {
+		if i > 0 && archives[i-1].Goarch == archive.Goarch {
+			return fmt.Errorf("flathub: found multiple archives for linux/%s: use 'ids' to pick one", archive.Goarch)
+		}
+		url, err := tmpl.New(ctx).WithArtifact(archive).Apply(urlTemplate)
+		if err != nil {
+			return err
+		}
+		sum, err := archive.Checksum("sha256")
+		if err != nil {
+			return err
+		}
+		strip := 0
+		if artifact.ExtraOr(*archive, artifact.ExtraWrappedIn, "") != "" {
+			strip = 1
+		}
+		sources = append(sources, ManifestSource{
+			Type:            "archive",
+			URL:             url,
+			SHA256:          sum,
+			StripComponents: &strip,
+			OnlyArches:      []string{archToFlatpak[archive.Goarch]},
+		})
+	}
+
+	binaries := artifact.ExtraOr[[]string](*archives[0], artifact.ExtraBinaries, nil)
+	if len(binaries) == 0 {
+		return fmt.Errorf("flathub: archive %s has no binaries", archives[0].Name)
+	}
+	installCmds := make([]string, 0, len(binaries))
+	for _, bin := range binaries {
+		installCmds = append(installCmds, fmt.Sprintf("install -Dm755 %s /app/bin/%s", bin, path.Base(bin)))
+	}
+
+	appSources, appCmds, err := appFiles(tmpl.New(ctx), fp)
+	if err != nil {
+		return err
+	}
+
+	command := fp.Command
+	if command == "" {
+		command = path.Base(binaries[0])
+	}
+
+	manifest := Manifest{
+		ID:             fp.AppID,
+		Runtime:        fp.Runtime,
+		RuntimeVersion: fp.RuntimeVersion,
+		SDK:            fp.SDK,
+		Command:        command,
+		FinishArgs:     fp.FinishArgs,
+		Modules: []ManifestModule{
+			{
+				Name:          fp.AppID,
+				BuildSystem:   "simple",
+				BuildCommands: append(installCmds, appCmds...),
+				Sources:       append(sources, appSources...),
+			},
+		},
+	}
+	bts, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal flathub manifest: %w", err)
+	}
+
+	if err := tmpl.New(ctx).ApplyAll(&fp.Flathub.SkipUpload); err != nil {
+		return err
+	}
+
+	filename := fp.AppID + ".json"
+	manifestPath := filepath.Join(ctx.Config.Dist, "flatpak", "flathub", filename)
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0o755); err != nil {
+		return err
+	}
+	log.WithField("manifest", manifestPath).Info("writing flathub manifest")
+	if err := os.WriteFile(manifestPath, append(bts, '\n'), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to write flathub manifest: %w", err)
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: filename,
+		Path: manifestPath,
+		Type: artifact.FlatpakManifest,
+		Extra: map[string]any{
+			artifact.ExtraID:   fp.ID,
+			flatpakConfigExtra: fp,
+		},
+	})
+	return nil
+}
+
+// Publish the Flathub manifests.
+func (Pipe) Publish(ctx *context.Context) error {
+	cli, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+	return publishAll(ctx, cli)
+}
+
+func publishAll(ctx *context.Context, cli client.Client) error {
+	skips := pipe.SkipMemento{}
+	for _, manifest := range ctx.Artifacts.Filter(artifact.ByType(artifact.FlatpakManifest)).List() {
+		err := doPublish(ctx, manifest, cli)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doPublish(ctx *context.Context, manifest *artifact.Artifact, cl client.Client) error {
+	fp := artifact.MustExtra[config.Flatpak](*manifest, flatpakConfigExtra)
+	cfg := fp.Flathub
+	if strings.TrimSpace(cfg.SkipUpload) == "true" {
+		return pipe.Skip("flatpaks.flathub.skip_upload is set")
+	}
+	if strings.TrimSpace(cfg.SkipUpload) == "auto" && ctx.Semver.Prerelease != "" {
+		return pipe.Skip("prerelease detected with 'auto' upload, skipping flathub publish")
+	}
+
+	ref, err := client.TemplateRef(tmpl.New(ctx).Apply, cfg.Repository)
+	if err != nil {
+		return err
+	}
+	cfg.Repository = ref
+	repo := client.RepoFromRef(cfg.Repository)
+
+	msg, err := tmpl.New(ctx).Apply(cfg.CommitMessageTemplate)
+	if err != nil {
+		return err
+	}
+
+	author, err := commitauthor.Get(ctx, cfg.CommitAuthor)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(manifest.Path)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Repository.Git.URL != "" {
+		return client.NewGitUploadClient(repo.Branch).
+			CreateFile(ctx, author, repo, content, manifest.Name, msg)
+	}
+
+	cl, err = client.NewIfToken(ctx, cl, cfg.Repository.Token)
+	if err != nil {
+		return err
+	}
+
+	base := client.Repo{
+		Name:   cfg.Repository.PullRequest.Base.Name,
+		Owner:  cfg.Repository.PullRequest.Base.Owner,
+		Branch: cfg.Repository.PullRequest.Base.Branch,
+	}
+
+	// try to sync branch
+	fscli, ok := cl.(client.ForkSyncer)
+	if ok && cfg.Repository.PullRequest.Enabled {
+		if err := fscli.SyncFork(ctx, repo, base); err != nil {
+			log.WithError(err).Warn("could not sync fork")
+		}
+	}
+
+	if err := cl.CreateFile(ctx, author, repo, content, manifest.Name, msg); err != nil {
+		return err
+	}
+
+	if !cfg.Repository.PullRequest.Enabled {
+		log.Debug("flatpaks.flathub.pull_request disabled")
+		return nil
+	}
+
+	log.Info("flatpaks.flathub.pull_request enabled, creating a PR")
+	pcl, ok := cl.(client.PullRequestOpener)
+	if !ok {
+		return errors.New("client does not support pull requests")
+	}
+
+	return pcl.OpenPullRequest(ctx, base, repo, msg, cfg.Repository.PullRequest.Body, cfg.Repository.PullRequest.Draft)
+}
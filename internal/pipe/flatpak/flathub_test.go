@@ -0,0 +1,182 @@
+package flatpak
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlathubManifest(t *testing.T) {
+	ctx := makeFlathubContext(t)
+	fp := ctx.Config.Flatpaks[0]
+	fp.DesktopFile = "./testdata/app.desktop"
+	fp.FinishArgs = []string{"--share=network"}
+	require.NoError(t, flathubManifest(ctx, fp, client.NewMock()))
+
+	manifests := ctx.Artifacts.Filter(artifact.ByType(artifact.FlatpakManifest)).List()
+	require.Len(t, manifests, 1)
+	require.Equal(t, "org.example.App.json", manifests[0].Name)
+	require.Equal(t, filepath.Join(ctx.Config.Dist, "flatpak", "flathub", "org.example.App.json"), manifests[0].Path)
+
+	bts, err := os.ReadFile(manifests[0].Path)
+	require.NoError(t, err)
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal(bts, &manifest))
+	require.Equal(t, "org.example.App", manifest.ID)
+	require.Equal(t, "mybin", manifest.Command)
+	require.Equal(t, []string{"--share=network"}, manifest.FinishArgs)
+	require.Len(t, manifest.Modules, 1)
+
+	module := manifest.Modules[0]
+	require.Equal(t, []string{
+		"install -Dm755 mybin /app/bin/mybin",
+		"install -Dm644 org.example.App.desktop /app/share/applications/org.example.App.desktop",
+	}, module.BuildCommands)
+	require.Len(t, module.Sources, 3)
+
+	amd64 := module.Sources[0]
+	require.Equal(t, "archive", amd64.Type)
+	require.Equal(t, "https://dummyhost/download/v1.2.3/mybin_linux_amd64.tar.gz", amd64.URL)
+	require.Equal(t, []string{"x86_64"}, amd64.OnlyArches)
+	require.Equal(t, 0, *amd64.StripComponents)
+	require.Len(t, amd64.SHA256, 64)
+
+	arm64 := module.Sources[1]
+	require.Equal(t, "https://dummyhost/download/v1.2.3/mybin_linux_arm64.tar.gz", arm64.URL)
+	require.Equal(t, []string{"aarch64"}, arm64.OnlyArches)
+	require.Equal(t, 1, *arm64.StripComponents)
+
+	require.Equal(t, "inline", module.Sources[2].Type)
+}
+
+func TestFlathubManifestURLTemplate(t *testing.T) {
+	ctx := makeFlathubContext(t)
+	fp := ctx.Config.Flatpaks[0]
+	fp.Flathub.URLTemplate = "https://example.com/{{ .Tag }}/{{ .ArtifactName }}"
+	require.NoError(t, flathubManifest(ctx, fp, client.NewMock()))
+
+	manifest := ctx.Artifacts.Filter(artifact.ByType(artifact.FlatpakManifest)).List()[0]
+	bts, err := os.ReadFile(manifest.Path)
+	require.NoError(t, err)
+	require.Contains(t, string(bts), `"url": "https://example.com/v1.2.3/mybin_linux_amd64.tar.gz"`)
+}
+
+func TestFlathubManifestNoArchives(t *testing.T) {
+	ctx := makeFlathubContext(t)
+	fp := ctx.Config.Flatpaks[0]
+	fp.Flathub.IDs = []string{"nope"}
+	require.ErrorIs(t, flathubManifest(ctx, fp, client.NewMock()), ErrNoArchivesFound)
+}
+
+func TestFlathubManifestMultipleArchives(t *testing.T) {
+	ctx := makeFlathubContext(t)
+	addArchive(t, ctx, "other", "arm64", "")
+	fp := ctx.Config.Flatpaks[0]
+	require.EqualError(t, flathubManifest(ctx, fp, client.NewMock()), "flathub: found multiple archives for linux/arm64: use 'ids' to pick one")
+
+	fp.Flathub.IDs = []string{"mybin"}
+	require.NoError(t, flathubManifest(ctx, fp, client.NewMock()))
+}
+
+func TestFlathubManifestBadTemplate(t *testing.T) {
+	ctx := makeFlathubContext(t)
+	fp := ctx.Config.Flatpaks[0]
+	fp.Flathub.URLTemplate = "{{ .Nope }}"
+	testlib.RequireTemplateError(t, flathubManifest(ctx, fp, client.NewMock()))
+}
+
+func TestPublish(t *testing.T) {
+	ctx := makeFlathubContext(t)
+	ctx.Config.Flatpaks[0].Flathub.Repository.PullRequest = config.PullRequest{
+		Enabled: true,
+		Base: config.PullRequestBase{
+			Owner:  "flathub",
+			Name:   "flathub",
+			Branch: "new-pr",
+		},
+	}
+	require.NoError(t, flathubManifest(ctx, ctx.Config.Flatpaks[0], client.NewMock()))
+
+	cli := client.NewMock()
+	require.NoError(t, publishAll(ctx, cli))
+	require.True(t, cli.CreatedFile)
+	require.True(t, cli.SyncedFork)
+	require.True(t, cli.OpenedPullRequest)
+	require.Equal(t, "org.example.App.json", cli.Path)
+	require.Equal(t, []string{"Flathub manifest update for mybin version v1.2.3"}, cli.Messages)
+	require.Contains(t, cli.Content, `"id": "org.example.App"`)
+}
+
+func TestPublishSkipUpload(t *testing.T) {
+	for name, skipUpload := range map[string]string{
+		"true":          "true",
+		"auto":          "auto",
+		"template true": "{{ .IsSnapshot }}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := makeFlathubContext(t, testctx.Snapshot, testctx.WithSemver(1, 2, 3, "rc1"))
+			ctx.Config.Flatpaks[0].Flathub.SkipUpload = skipUpload
+			require.NoError(t, flathubManifest(ctx, ctx.Config.Flatpaks[0], client.NewMock()))
+
+			cli := client.NewMock()
+			testlib.AssertSkipped(t, publishAll(ctx, cli))
+			require.False(t, cli.CreatedFile)
+		})
+	}
+}
+
+func makeFlathubContext(tb testing.TB, opts ...testctx.Opt) *context.Context {
+	tb.Helper()
+	fp := validFlatpak()
+	fp.Flathub.Repository = config.RepoRef{
+		Owner: "flathub",
+		Name:  "org.example.App",
+	}
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "mybin",
+		Dist:        tb.TempDir(),
+		Flatpaks:    []config.Flatpak{fp},
+	}, append([]testctx.Opt{
+		testctx.WithCurrentTag("v1.2.3"),
+		testctx.WithVersion("1.2.3"),
+	}, opts...)...)
+	require.NoError(tb, Pipe{}.Default(ctx))
+	addArchive(tb, ctx, "mybin", "amd64", "")
+	addArchive(tb, ctx, "mybin", "arm64", "mybin_linux_arm64")
+	return ctx
+}
+
+func addArchive(tb testing.TB, ctx *context.Context, id, goarch, wrap string) {
+	tb.Helper()
+	for _, goos := range []string{"linux", "darwin"} {
+		name := id + "_" + goos + "_" + goarch + ".tar.gz"
+		path := filepath.Join(tb.TempDir(), name)
+		require.NoError(tb, os.WriteFile(path, []byte(name), 0o644))
+		a := &artifact.Artifact{
+			Name:   name,
+			Path:   path,
+			Goos:   goos,
+			Goarch: goarch,
+			Type:   artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraID:        id,
+				artifact.ExtraFormat:    "tar.gz",
+				artifact.ExtraWrappedIn: wrap,
+				artifact.ExtraBinaries:  []string{"mybin"},
+			},
+		}
+		if goarch == "amd64" {
+			a.Goamd64 = "v1"
+		}
+		ctx.Artifacts.Add(a)
+	}
+}
@@ -1,13 +1,16 @@
-// Package flatpak implements the Pipe interface providing Flatpak bindings.
+// Package flatpak implements the Pipe and Publisher interfaces providing
+// Flatpak bundles and Flathub manifests.
 //
 //nolint:tagliatelle
 package flatpak
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image/png"
 	"io"
 	"os"
 	"os/exec"
@@ -16,6 +19,8 @@ import (
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/commitauthor"
 	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
 	"github.com/goreleaser/goreleaser/v2/internal/gio"
 	"github.com/goreleaser/goreleaser/v2/internal/ids"
@@ -60,9 +65,15 @@ type ManifestModule struct {
 
 // ManifestSource is a source entry for a Flatpak module.
 type ManifestSource struct {
-	Type         string `json:"type"`
-	Path         string `json:"path"`
-	DestFilename string `json:"dest-filename,omitempty"`
+	Type            string   `json:"type"`
+	Path            string   `json:"path,omitempty"`
+	URL             string   `json:"url,omitempty"`
+	SHA256          string   `json:"sha256,omitempty"`
+	Contents        string   `json:"contents,omitempty"`
+	Base64          bool     `json:"base64,omitempty"`
+	StripComponents *int     `json:"strip-components,omitempty"`
+	OnlyArches      []string `json:"only-arches,omitempty"`
+	DestFilename    string   `json:"dest-filename,omitempty"`
 }
 
 // Pipe for Flatpak packaging.
@@ -96,6 +107,15 @@ func (Pipe) Default(ctx *context.Context) error {
 		if fp.SDK == "" {
 			return ErrNoSDK
 		}
+		if hasFlathub(*fp) {
+			fp.Flathub.CommitAuthor = commitauthor.Default(fp.Flathub.CommitAuthor)
+			if fp.Flathub.CommitMessageTemplate == "" {
+				fp.Flathub.CommitMessageTemplate = "Flathub manifest update for {{ .ProjectName }} version {{ .Tag }}"
+			}
+			if fp.Flathub.Goamd64 == "" {
+				fp.Flathub.Goamd64 = "v1"
+			}
+		}
 		ids.Inc(fp.ID)
 	}
 	return ids.Validate()
@@ -140,11 +160,23 @@ func doRun(ctx *context.Context, fp config.Flatpak) error {
 			return create(ctx, fp, arch, binaries)
 		})
 	}
-	return g.Wait()
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	if !hasFlathub(fp) {
+		return nil
+	}
+	cli, err := client.NewReleaseClient(ctx)
+	if err != nil {
+		return err
+	}
+	return flathubManifest(ctx, fp, cli)
 }
 
 func create(ctx *context.Context, fp config.Flatpak, arch string, binaries []*artifact.Artifact) error {
-	folder, err := tmpl.New(ctx).WithArtifact(binaries[0]).Apply(fp.NameTemplate)
+	tpl := tmpl.New(ctx).WithArtifact(binaries[0])
+	folder, err := tpl.Apply(fp.NameTemplate)
 	if err != nil {
 		return err
 	}
@@ -187,6 +219,13 @@ func create(ctx *context.Context, fp config.Flatpak, arch string, binaries []*ar
 		installCmds = append(installCmds, fmt.Sprintf("install -Dm755 %s /app/bin/%s", binaryName, binaryName))
 	}
 
+	appSources, appCmds, err := appFiles(tpl, fp)
+	if err != nil {
+		return err
+	}
+	sources = append(sources, appSources...)
+	installCmds = append(installCmds, appCmds...)
+
 	manifest.Modules = []ManifestModule{
 		{
 			Name:          fp.AppID,
@@ -261,6 +300,90 @@ func create(ctx *context.Context, fp config.Flatpak, arch string, binaries []*ar
 	return nil
 }
 
+// appFiles returns the sources and install commands for the desktop file,
+// icon, and metainfo of the application.
+// Their contents are inlined in the manifest, so it is self-contained.
+func appFiles(tpl *tmpl.Template, fp config.Flatpak) ([]ManifestSource, []string, error) {
+	desktop := fp.DesktopFile
+	icon := fp.Icon
+	metainfo := fp.Metainfo
+	if err := tpl.ApplyAll(&desktop, &icon, &metainfo); err != nil {
+		return nil, nil, err
+	}
+
+	var sources []ManifestSource
+	var cmds []string
+	add := func(src ManifestSource, dir string) {
+		sources = append(sources, src)
+		cmds = append(cmds, fmt.Sprintf("install -Dm644 %s %s/%s", src.DestFilename, dir, src.DestFilename))
+	}
+
+	if desktop != "" {
+		src, err := inlineSource(desktop, fp.AppID+".desktop")
+		if err != nil {
+			return nil, nil, err
+		}
+		add(src, "/app/share/applications")
+	}
+	if icon != "" {
+		src, dir, err := iconSource(icon, fp.AppID)
+		if err != nil {
+			return nil, nil, err
+		}
+		add(src, dir)
+	}
+	if metainfo != "" {
+		src, err := inlineSource(metainfo, fp.AppID+".metainfo.xml")
+		if err != nil {
+			return nil, nil, err
+		}
+		add(src, "/app/share/metainfo")
+	}
+	return sources, cmds, nil
+}
+
+func inlineSource(path, name string) (ManifestSource, error) {
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return ManifestSource{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return ManifestSource{
+		Type:         "inline",
+		Contents:     string(bts),
+		DestFilename: name,
+	}, nil
+}
+
+// iconSource returns the source for the given icon, along with the directory
+// it should be installed to, which depends on its size.
+func iconSource(icon, appID string) (ManifestSource, string, error) {
+	switch strings.ToLower(filepath.Ext(icon)) {
+	case ".svg":
+		src, err := inlineSource(icon, appID+".svg")
+		return src, "/app/share/icons/hicolor/scalable/apps", err
+	case ".png":
+		bts, err := os.ReadFile(icon)
+		if err != nil {
+			return ManifestSource{}, "", fmt.Errorf("failed to read %s: %w", icon, err)
+		}
+		cfg, err := png.DecodeConfig(bytes.NewReader(bts))
+		if err != nil {
+			return ManifestSource{}, "", fmt.Errorf("failed to decode icon %s: %w", icon, err)
+		}
+		if cfg.Width != cfg.Height {
+			return ManifestSource{}, "", fmt.Errorf("flatpak: icon must be square, got %dx%d", cfg.Width, cfg.Height)
+		}
+		return ManifestSource{
+			Type:         "inline",
+			Contents:     base64.StdEncoding.EncodeToString(bts),
+			Base64:       true,
+			DestFilename: appID + ".png",
+		}, fmt.Sprintf("/app/share/icons/hicolor/%[1]dx%[1]d/apps", cfg.Width), nil
+	default:
+		return ManifestSource{}, "", fmt.Errorf("flatpak: icon must be a .png or .svg file, got %s", icon)
+	}
+}
+
 func runCmd(ctx *context.Context, dir, errMsg, bin string, args ...string) error {
 	cmd := exec.CommandContext(ctx, bin, args...)
 	cmd.Dir = dir
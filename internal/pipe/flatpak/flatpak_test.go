@@ -1,8 +1,11 @@
 package flatpak
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"image"
+	"image/png"
 	"maps"
 	"os"
 	"path/filepath"
@@ -13,6 +16,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
@@ -50,6 +54,21 @@ func TestDefault(t *testing.T) {
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
 	require.Equal(t, defaultNameTemplate, ctx.Config.Flatpaks[0].NameTemplate)
+	require.Empty(t, ctx.Config.Flatpaks[0].Flathub.CommitMessageTemplate)
+}
+
+func TestDefaultFlathub(t *testing.T) {
+	fp := validFlatpak()
+	fp.Flathub.Repository.Name = "org.example.App"
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Flatpaks: []config.Flatpak{fp},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	flathub := ctx.Config.Flatpaks[0].Flathub
+	require.NotEmpty(t, flathub.CommitMessageTemplate)
+	require.NotEmpty(t, flathub.CommitAuthor.Name)
+	require.NotEmpty(t, flathub.CommitAuthor.Email)
+	require.Equal(t, "v1", flathub.Goamd64)
 }
 
 func TestDefaultMissingFields(t *testing.T) {
@@ -165,6 +184,114 @@ func TestRunPipe(t *testing.T) {
 	require.Equal(t, "simple", manifest.Modules[0].BuildSystem)
 }
 
+func TestAppFiles(t *testing.T) {
+	icon := filepath.Join(t.TempDir(), "icon.png")
+	writePNG(t, icon, 128, 128)
+
+	fp := validFlatpak()
+	fp.DesktopFile = "./testdata/app.desktop"
+	fp.Icon = icon
+	fp.Metainfo = "./testdata/{{ .Env.METAINFO }}"
+	ctx := testctx.Wrap(t.Context(), testctx.WithEnv(map[string]string{"METAINFO": "app.metainfo.xml"}))
+
+	sources, cmds, err := appFiles(tmpl.New(ctx), fp)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"install -Dm644 org.example.App.desktop /app/share/applications/org.example.App.desktop",
+		"install -Dm644 org.example.App.png /app/share/icons/hicolor/128x128/apps/org.example.App.png",
+		"install -Dm644 org.example.App.metainfo.xml /app/share/metainfo/org.example.App.metainfo.xml",
+	}, cmds)
+	require.Len(t, sources, 3)
+
+	desktop, err := os.ReadFile("./testdata/app.desktop")
+	require.NoError(t, err)
+	require.Equal(t, ManifestSource{
+		Type:         "inline",
+		Contents:     string(desktop),
+		DestFilename: "org.example.App.desktop",
+	}, sources[0])
+
+	require.Equal(t, "inline", sources[1].Type)
+	require.True(t, sources[1].Base64)
+	bts, err := base64.StdEncoding.DecodeString(sources[1].Contents)
+	require.NoError(t, err)
+	expected, err := os.ReadFile(icon)
+	require.NoError(t, err)
+	require.Equal(t, expected, bts)
+
+	require.Equal(t, "org.example.App.metainfo.xml", sources[2].DestFilename)
+	require.Contains(t, sources[2].Contents, "<id>org.example.App</id>")
+}
+
+func TestAppFilesSVGIcon(t *testing.T) {
+	fp := validFlatpak()
+	fp.Icon = "./testdata/icon.svg"
+	sources, cmds, err := appFiles(tmpl.New(testctx.Wrap(t.Context())), fp)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"install -Dm644 org.example.App.svg /app/share/icons/hicolor/scalable/apps/org.example.App.svg",
+	}, cmds)
+	require.Len(t, sources, 1)
+	require.False(t, sources[0].Base64)
+	require.Contains(t, sources[0].Contents, "<svg")
+}
+
+func TestAppFilesNone(t *testing.T) {
+	sources, cmds, err := appFiles(tmpl.New(testctx.Wrap(t.Context())), validFlatpak())
+	require.NoError(t, err)
+	require.Empty(t, sources)
+	require.Empty(t, cmds)
+}
+
+func TestAppFilesErrors(t *testing.T) {
+	notSquare := filepath.Join(t.TempDir(), "icon.png")
+	writePNG(t, notSquare, 64, 32)
+
+	for name, tt := range map[string]struct {
+		mod func(*config.Flatpak)
+		err string
+	}{
+		"bad icon extension": {
+			mod: func(fp *config.Flatpak) { fp.Icon = "./testdata/app.desktop" },
+			err: "flatpak: icon must be a .png or .svg file, got ./testdata/app.desktop",
+		},
+		"missing icon": {
+			mod: func(fp *config.Flatpak) { fp.Icon = "./testdata/nope.png" },
+			err: "failed to read ./testdata/nope.png",
+		},
+		"invalid png": {
+			mod: func(fp *config.Flatpak) { fp.Icon = "./testdata/invalid.png" },
+			err: "failed to decode icon ./testdata/invalid.png",
+		},
+		"icon not square": {
+			mod: func(fp *config.Flatpak) { fp.Icon = notSquare },
+			err: "flatpak: icon must be square, got 64x32",
+		},
+		"missing desktop file": {
+			mod: func(fp *config.Flatpak) { fp.DesktopFile = "./testdata/nope.desktop" },
+			err: "failed to read ./testdata/nope.desktop",
+		},
+		"missing metainfo": {
+			mod: func(fp *config.Flatpak) { fp.Metainfo = "./testdata/nope.xml" },
+			err: "failed to read ./testdata/nope.xml",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fp := validFlatpak()
+			tt.mod(&fp)
+			_, _, err := appFiles(tmpl.New(testctx.Wrap(t.Context())), fp)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	t.Run("bad template", func(t *testing.T) {
+		fp := validFlatpak()
+		fp.DesktopFile = "{{ .Nope }}"
+		_, _, err := appFiles(tmpl.New(testctx.Wrap(t.Context())), fp)
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
 func TestDependencies(t *testing.T) {
 	require.Equal(t, []string{"flatpak-builder", "flatpak"}, Pipe{}.Dependencies(nil))
 }
@@ -178,6 +305,14 @@ func validFlatpak() config.Flatpak {
 	}
 }
 
+func writePNG(tb testing.TB, path string, width, height int) {
+	tb.Helper()
+	f, err := os.Create(path)
+	require.NoError(tb, err)
+	defer f.Close()
+	require.NoError(tb, png.Encode(f, image.NewNRGBA(image.Rect(0, 0, width, height))))
+}
+
 func requireNoGerror(tb testing.TB, err error) {
 	tb.Helper()
 	if err == nil {
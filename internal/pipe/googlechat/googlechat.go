@@ -0,0 +1,247 @@
+// Package googlechat announces releases to Google Chat spaces.
+package googlechat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/caarlos0/log"
+
+	"github.com/goreleaser/goreleaser/v2/internal/announcement"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultIcon            = "https://goreleaser.com/static/avatar.png"
+	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
+	defaultTitleTemplate   = `{{ .ProjectName }} {{ .Tag }} is out!`
+	defaultButtonTitle     = "View release"
+	defaultButtonURL       = "{{ .ReleaseURL }}"
+
+	FormatText = "text"
+	FormatCard = "card"
+)
+
+type Pipe struct{}
+
+func (Pipe) String() string { return "googlechat" }
+func (Pipe) Skip(ctx *context.Context) (bool, error) {
+	enable, err := tmpl.New(ctx).Bool(ctx.Config.Announce.GoogleChat.Enabled)
+	return !enable, err
+}
+
+type Config struct {
+	Webhook string `env:"GOOGLE_CHAT_WEBHOOK,notEmpty"`
+}
+
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Announce.GoogleChat
+	if cfg.MessageTemplate == "" {
+		cfg.MessageTemplate = defaultMessageTemplate
+	}
+	if cfg.Format == "" {
+		cfg.Format = FormatText
+	}
+	if cfg.Format != FormatCard {
+		return nil
+	}
+	if cfg.TitleTemplate == "" {
+		cfg.TitleTemplate = defaultTitleTemplate
+	}
+	if cfg.IconURL == "" {
+		cfg.IconURL = defaultIcon
+	}
+	if len(cfg.Buttons) == 0 {
+		cfg.Buttons = []config.AnnounceButton{{
+			Title: defaultButtonTitle,
+			URL:   defaultButtonURL,
+		}}
+	}
+	return nil
+}
+
+// Preview returns the message that would be posted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	payload, _, err := newMessage(ctx)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.MarshalIndent(payload, "", "  ")
+	return string(bts), err
+}
+
+func (Pipe) Announce(ctx *context.Context) error {
+	payload, msg, err := newMessage(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := env.ParseAs[Config]()
+	if err != nil {
+		return err
+	}
+
+	bts, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("posting: '%s'", msg)
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.Webhook, bytes.NewReader(bts))
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return retryx.HTTP(err, resp)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return retryx.HTTP(fmt.Errorf("%s", resp.Status), resp)
+		}
+
+		return nil
+	}, retryx.IsRetriable)
+}
+
+// newMessage returns the message to post, in the configured format, and the
+// templated text.
+func newMessage(ctx *context.Context) (Message, string, error) {
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.GoogleChat.MessageTemplate)
+	if err != nil {
+		return Message{}, "", err
+	}
+
+	var payload Message
+	switch ctx.Config.Announce.GoogleChat.Format {
+	case FormatText:
+		payload.Text = msg
+	case FormatCard:
+		card, err := newCard(ctx, msg)
+		if err != nil {
+			return Message{}, "", err
+		}
+		payload.CardsV2 = []CardWithID{{
+			CardID: "release",
+			Card:   card,
+		}}
+	default:
+		return Message{}, "", fmt.Errorf("googlechat: invalid format: %q", ctx.Config.Announce.GoogleChat.Format)
+	}
+	return payload, msg, nil
+}
+
+func newCard(ctx *context.Context, msg string) (Card, error) {
+	cfg := ctx.Config.Announce.GoogleChat
+	t := tmpl.New(ctx).WithMessageTemplates()
+
+	title, subtitle, icon := cfg.TitleTemplate, cfg.SubtitleTemplate, cfg.IconURL
+	if err := t.ApplyAll(&title, &subtitle, &icon); err != nil {
+		return Card{}, err
+	}
+
+	widgets := []Widget{{
+		TextParagraph: &TextParagraph{Text: msg},
+	}}
+
+	cfgButtons, err := announcement.Buttons(ctx, cfg.Buttons)
+	if err != nil {
+		return Card{}, err
+	}
+	var buttons []Button
+	for _, button := range cfgButtons {
+		buttons = append(buttons, Button{
+			Text: button.Title,
+			OnClick: OnClick{
+				OpenLink: OpenLink{URL: button.URL},
+			},
+		})
+	}
+	if len(buttons) > 0 {
+		widgets = append(widgets, Widget{
+			ButtonList: &ButtonList{Buttons: buttons},
+		})
+	}
+
+	card := Card{
+		Sections: []Section{{Widgets: widgets}},
+	}
+	if title != "" {
+		card.Header = &CardHeader{
+			Title:     title,
+			Subtitle:  subtitle,
+			ImageURL:  icon,
+			ImageType: "CIRCLE",
+		}
+	}
+	return card, nil
+}
+
+// Message is a Google Chat message.
+type Message struct {
+	Text    string       `json:"text,omitempty"`
+	CardsV2 []CardWithID `json:"cardsV2,omitempty"`
+}
+
+type CardWithID struct {
+	CardID string `json:"cardId"`
+	Card   Card   `json:"card"`
+}
+
+type Card struct {
+	Header   *CardHeader `json:"header,omitempty"`
+	Sections []Section   `json:"sections"`
+}
+
+type CardHeader struct {
+	Title     string `json:"title"`
+	Subtitle  string `json:"subtitle,omitempty"`
+	ImageURL  string `json:"imageUrl,omitempty"`
+	ImageType string `json:"imageType,omitempty"`
+}
+
+type Section struct {
+	Widgets []Widget `json:"widgets"`
+}
+
+type Widget struct {
+	TextParagraph *TextParagraph `json:"textParagraph,omitempty"`
+	ButtonList    *ButtonList    `json:"buttonList,omitempty"`
+}
+
+type TextParagraph struct {
+	Text string `json:"text"`
+}
+
+type ButtonList struct {
+	Buttons []Button `json:"buttons"`
+}
+
+type Button struct {
+	Text    string  `json:"text"`
+	OnClick OnClick `json:"onClick"`
+}
+
+type OnClick struct {
+	OpenLink OpenLink `json:"openLink"`
+}
+
+type OpenLink struct {
+	URL string `json:"url"`
+}
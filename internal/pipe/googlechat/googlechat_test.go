@@ -0,0 +1,214 @@
+package googlechat
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringer(t *testing.T) {
+	require.Equal(t, "googlechat", Pipe{}.String())
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.GoogleChat.MessageTemplate)
+		require.Equal(t, FormatText, ctx.Config.Announce.GoogleChat.Format)
+		require.Empty(t, ctx.Config.Announce.GoogleChat.TitleTemplate)
+		require.Empty(t, ctx.Config.Announce.GoogleChat.Buttons)
+	})
+
+	t.Run("card", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				GoogleChat: config.GoogleChat{
+					Format: FormatCard,
+				},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, defaultTitleTemplate, ctx.Config.Announce.GoogleChat.TitleTemplate)
+		require.Equal(t, defaultIcon, ctx.Config.Announce.GoogleChat.IconURL)
+		require.Equal(t, []config.AnnounceButton{{
+			Title: defaultButtonTitle,
+			URL:   defaultButtonURL,
+		}}, ctx.Config.Announce.GoogleChat.Buttons)
+	})
+}
+
+func TestAnnounceInvalidTemplate(t *testing.T) {
+	for name, cfg := range map[string]config.GoogleChat{
+		"message": {MessageTemplate: "{{ .Foo }"},
+		"title":   {Format: FormatCard, TitleTemplate: "{{ .Foo }"},
+		"button": {Format: FormatCard, Buttons: []config.AnnounceButton{
+			{Title: "a", URL: "{{ .Foo }"},
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{GoogleChat: cfg},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+		})
+	}
+}
+
+func TestAnnounceInvalidFormat(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			GoogleChat: config.GoogleChat{
+				Format: "nope",
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `googlechat: invalid format: "nope"`)
+}
+
+func TestAnnounceMissingEnv(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "GOOGLE_CHAT_WEBHOOK" should not be empty`)
+}
+
+func TestAnnounce(t *testing.T) {
+	for name, tt := range map[string]struct {
+		cfg    config.GoogleChat
+		expect string
+	}{
+		"text": {
+			expect: `{"text":"foo v1.0.0 is out! Check it out at https://example.com/v1.0.0"}`,
+		},
+		"card": {
+			cfg: config.GoogleChat{
+				Format:           FormatCard,
+				SubtitleTemplate: "{{ .Version }}",
+				Buttons: []config.AnnounceButton{
+					{Title: "Release", URL: "{{ .ReleaseURL }}"},
+					{Title: "Empty", URL: "{{ .Env.NOPE }}"},
+				},
+			},
+			expect: `{"cardsV2":[{"cardId":"release","card":{
+				"header":{
+					"title":"foo v1.0.0 is out!",
+					"subtitle":"1.0.0",
+					"imageUrl":"https://goreleaser.com/static/avatar.png",
+					"imageType":"CIRCLE"
+				},
+				"sections":[{"widgets":[
+					{"textParagraph":{"text":"foo v1.0.0 is out! Check it out at https://example.com/v1.0.0"}},
+					{"buttonList":{"buttons":[
+						{"text":"Release","onClick":{"openLink":{"url":"https://example.com/v1.0.0"}}}
+					]}}
+				]}]
+			}}]}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var body []byte
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/v1/spaces/AAA/messages", r.URL.Path)
+				var err error
+				body, err = io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.True(t, json.Valid(body))
+				w.WriteHeader(http.StatusOK)
+			}))
+			t.Cleanup(srv.Close)
+			t.Setenv("GOOGLE_CHAT_WEBHOOK", srv.URL+"/v1/spaces/AAA/messages?key=k&token=t")
+
+			tt.cfg.Enabled = "true"
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "foo",
+				Announce: config.Announce{
+					GoogleChat: tt.cfg,
+				},
+			}, testctx.WithCurrentTag("v1.0.0"), testctx.WithVersion("1.0.0"), testctx.WithEnv(map[string]string{
+				"NOPE": "",
+			}))
+			ctx.ReleaseURL = "https://example.com/v1.0.0"
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Announce(ctx))
+			require.JSONEq(t, tt.expect, string(body))
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Announce: config.Announce{
+			GoogleChat: config.GoogleChat{
+				Format: FormatCard,
+				Buttons: []config.AnnounceButton{
+					{Title: "Release", URL: "{{ .ReleaseURL }}"},
+				},
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://example.com/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"cardsV2":[{"cardId":"release","card":{
+		"header":{
+			"title":"foo v1.0.0 is out!",
+			"imageUrl":"https://goreleaser.com/static/avatar.png",
+			"imageType":"CIRCLE"
+		},
+		"sections":[{"widgets":[
+			{"textParagraph":{"text":"foo v1.0.0 is out! Check it out at https://example.com/v1.0.0"}},
+			{"buttonList":{"buttons":[
+				{"text":"Release","onClick":{"openLink":{"url":"https://example.com/v1.0.0"}}}
+			]}}
+		]}]
+	}}]}`, out)
+}
+
+func TestAnnounceError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("GOOGLE_CHAT_WEBHOOK", srv.URL)
+
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Announce(ctx), "400 Bad Request")
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				GoogleChat: config.GoogleChat{
+					Enabled: "true",
+				},
+			},
+		})
+
+		skip, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+}
@@ -0,0 +1,336 @@
+// Package helm packages Helm charts, and publishes them to OCI registries or
+// chart repositories.
+package helm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultVersion  = "{{ .Version }}"
+	helmConfigExtra = "HelmChart"
+)
+
+var (
+	errNoPath = errors.New("helm_charts: path is required")
+
+	// packagedPattern matches the path helm package prints after packaging,
+	// e.g. 'Successfully packaged chart and saved it to: dist/foo-1.0.0.tgz'.
+	packagedPattern = regexp.MustCompile(`saved it to: (.+\.tgz)`)
+
+	// digestPattern matches the digest helm push prints after pushing, e.g.
+	// 'Digest: sha256:abc...'.
+	digestPattern = regexp.MustCompile(`Digest: (sha256:[a-f0-9]{64})`)
+)
+
+// cmd represents a command executor.
+var cmd cmder = stdCmd{}
+
+// Pipe for Helm charts.
+type Pipe struct{}
+
+func (Pipe) String() string        { return "helm charts" }
+func (Pipe) ContinueOnError() bool { return true }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.Helm) || len(ctx.Config.HelmCharts) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(*context.Context) []string { return []string{"helm"} }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("helm_charts")
+	for i := range ctx.Config.HelmCharts {
+		h := &ctx.Config.HelmCharts[i]
+		if h.Path == "" {
+			return errNoPath
+		}
+		if h.ID == "" {
+			h.ID = ctx.Config.ProjectName
+		}
+		if h.Version == "" {
+			h.Version = defaultVersion
+		}
+		if h.AppVersion == "" {
+			h.AppVersion = defaultVersion
+		}
+		if h.Values == nil {
+			h.Values = map[string]string{"image.tag": "{{ .Tag }}"}
+		}
+		ids.Inc(h.ID)
+	}
+	return ids.Validate()
+}
+
+// Run packages the charts.
+func (Pipe) Run(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, h := range ctx.Config.HelmCharts {
+		err := doRun(ctx, h)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doRun(ctx *context.Context, h config.HelmChart) error {
+	tpl := tmpl.New(ctx)
+	disable, err := tpl.Bool(h.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("configuration is disabled")
+	}
+	if err := tpl.ApplyAll(
+		&h.Path,
+		&h.Version,
+		&h.AppVersion,
+		&h.Sign.Key,
+		&h.Sign.Keyring,
+	); err != nil {
+		return err
+	}
+	flags, err := tpl.Slice(h.Flags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	values := map[string]string{}
+	for key, value := range h.Values {
+		value, err := tpl.Apply(value)
+		if err != nil {
+			return err
+		}
+		values[key] = value
+	}
+
+	// work on a copy of the chart, so we can change its values.
+	dir := filepath.Join(ctx.Config.Dist, "helm", h.ID)
+	chart := filepath.Join(dir, "src", filepath.Base(filepath.Clean(h.Path)))
+	if err := os.RemoveAll(chart); err != nil {
+		return err
+	}
+	if err := gio.Copy(h.Path, chart); err != nil {
+		return fmt.Errorf("helm_charts: failed to copy chart: %w", err)
+	}
+	if err := setValues(filepath.Join(chart, "values.yaml"), values); err != nil {
+		return fmt.Errorf("helm_charts: failed to set values: %w", err)
+	}
+
+	args := []string{
+		"package", chart,
+		"--destination", dir,
+		"--version", h.Version,
+		"--app-version", h.AppVersion,
+	}
+	if h.Sign.Key != "" {
+		args = append(args, "--sign", "--key", h.Sign.Key)
+		if h.Sign.Keyring != "" {
+			args = append(args, "--keyring", h.Sign.Keyring)
+		}
+	}
+	args = append(args, flags...)
+
+	log.WithField("chart", h.Path).
+		WithField("version", h.Version).
+		Info("packaging")
+	out, err := cmd.Exec(ctx, nil, "helm", args...)
+	if err != nil {
+		return fmt.Errorf("helm_charts: failed to package %s: %w: %s", h.Path, err, string(out))
+	}
+	match := packagedPattern.FindStringSubmatch(string(out))
+	if match == nil {
+		return fmt.Errorf("helm_charts: failed to find chart in helm output: %s", string(out))
+	}
+	path := strings.TrimSpace(match[1])
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.HelmChart,
+		Name: filepath.Base(path),
+		Path: path,
+		Extra: map[string]any{
+			artifact.ExtraID: h.ID,
+			helmConfigExtra:  h,
+		},
+	})
+	if h.Sign.Key != "" {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: artifact.Signature,
+			Name: filepath.Base(path) + ".prov",
+			Path: path + ".prov",
+			Extra: map[string]any{
+				artifact.ExtraID: h.ID,
+			},
+		})
+	}
+	return nil
+}
+
+// Publish pushes the charts to their repositories.
+func (Pipe) Publish(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, chart := range ctx.Artifacts.Filter(artifact.ByType(artifact.HelmChart)).List() {
+		err := doPublish(ctx, chart)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doPublish(ctx *context.Context, chart *artifact.Artifact) error {
+	h := artifact.MustExtra[config.HelmChart](*chart, helmConfigExtra)
+	if err := tmpl.New(ctx).WithArtifact(chart).ApplyAll(
+		&h.Repository,
+		&h.Username,
+		&h.Password,
+	); err != nil {
+		return err
+	}
+	if h.Repository == "" {
+		return pipe.Skipf("no repository set for helm_charts %q", h.ID)
+	}
+
+	log := log.WithField("chart", chart.Name).
+		WithField("repository", h.Repository)
+	if registry, ok := strings.CutPrefix(h.Repository, "oci://"); ok {
+		if h.Username != "" || h.Password != "" {
+			host, _, _ := strings.Cut(registry, "/")
+			log.Info("logging in")
+			if out, err := cmd.Exec(
+				ctx,
+				[]byte(h.Password),
+				"helm", "registry", "login", host,
+				"--username", h.Username,
+				"--password-stdin",
+			); err != nil {
+				return fmt.Errorf("helm_charts: failed to login to %s: %w: %s", host, err, string(out))
+			}
+		}
+
+		log.Info("pushing")
+		out, err := cmd.Exec(ctx, nil, "helm", "push", chart.Path, h.Repository)
+		if err != nil {
+			return fmt.Errorf("helm_charts: failed to push %s: %w: %s", chart.Name, err, string(out))
+		}
+		if match := digestPattern.FindStringSubmatch(string(out)); match != nil {
+			log.WithField("digest", match[1]).Info("pushed")
+		}
+		return nil
+	}
+
+	log.Info("uploading")
+	if err := upload(ctx, h, chart.Path); err != nil {
+		return fmt.Errorf("helm_charts: failed to upload %s: %w", chart.Name, err)
+	}
+	return nil
+}
+
+// upload uploads the given chart, and its provenance file, if any, to a
+// ChartMuseum compatible repository.
+func upload(ctx *context.Context, h config.HelmChart, path string) error {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	files := map[string]string{"chart": path}
+	if _, err := os.Stat(path + ".prov"); err == nil {
+		files["prov"] = path + ".prov"
+	}
+	for _, field := range slices.Sorted(maps.Keys(files)) {
+		if err := addFile(w, field, files[field]); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(h.Repository, "/") + "/api/charts"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	if h.Username != "" || h.Password != "" {
+		req.SetBasicAuth(h.Username, h.Password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		bts, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("got status code %d: %s", resp.StatusCode, string(bts))
+	}
+	return nil
+}
+
+func addFile(w *multipart.Writer, field, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	part, err := w.CreateFormFile(field, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, f)
+	return err
+}
+
+// cmder is a special interface to execute external commands.
+//
+// The intention is to be used to wrap the standard exec and provide the
+// ability to create a fake one for testing.
+type cmder interface {
+	// Exec executes a command, writing the given input, if any, to its
+	// stdin.
+	Exec(*context.Context, []byte, string, ...string) ([]byte, error)
+}
+
+// stdCmd uses [execx.Command].
+type stdCmd struct{}
+
+var _ cmder = &stdCmd{}
+
+func (stdCmd) Exec(ctx *context.Context, input []byte, name string, args ...string) ([]byte, error) {
+	c := execx.Command(ctx, name, args...)
+	if input != nil {
+		c.Stdin = bytes.NewReader(input)
+	}
+	return c.CombinedOutput()
+}
@@ -0,0 +1,391 @@
+package helm
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestContinueOnError(t *testing.T) {
+	require.True(t, Pipe{}.ContinueOnError())
+}
+
+func TestDependencies(t *testing.T) {
+	require.Equal(t, []string{"helm"}, Pipe{}.Dependencies(nil))
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			HelmCharts: []config.HelmChart{{}},
+		}, testctx.Skip(skips.Helm))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			HelmCharts: []config.HelmChart{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			HelmCharts:  []config.HelmChart{{Path: "charts/foo"}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		h := ctx.Config.HelmCharts[0]
+		require.Equal(t, "foo", h.ID)
+		require.Equal(t, defaultVersion, h.Version)
+		require.Equal(t, defaultVersion, h.AppVersion)
+		require.Equal(t, map[string]string{"image.tag": "{{ .Tag }}"}, h.Values)
+	})
+	t.Run("empty values", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			HelmCharts:  []config.HelmChart{{Path: "charts/foo", Values: map[string]string{}}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Empty(t, ctx.Config.HelmCharts[0].Values)
+	})
+	t.Run("no path", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			HelmCharts: []config.HelmChart{{}},
+		})
+		require.ErrorIs(t, Pipe{}.Default(ctx), errNoPath)
+	})
+	t.Run("duplicated ids", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			HelmCharts:  []config.HelmChart{{Path: "a"}, {Path: "b"}},
+		})
+		require.ErrorContains(t, Pipe{}.Default(ctx), "found 2 helm_charts with the ID 'foo'")
+	})
+}
+
+func TestRun(t *testing.T) {
+	calls := fakeHelm(t, nil)
+	ctx := makeContext(t, config.HelmChart{
+		Sign: config.HelmChartSign{
+			Key:     "{{ .Env.HELM_KEY }}",
+			Keyring: "/keyring.gpg",
+		},
+		Flags: []string{"--dependency-update"},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	dir := filepath.Join(ctx.Config.Dist, "helm", "foo")
+	chart := filepath.Join(dir, "src", "foo")
+	require.Equal(t, [][]string{{
+		"helm", "package", chart,
+		"--destination", dir,
+		"--version", "1.2.3",
+		"--app-version", "1.2.3",
+		"--sign", "--key", "me@example.com",
+		"--keyring", "/keyring.gpg",
+		"--dependency-update",
+	}}, *calls)
+
+	values, err := os.ReadFile(filepath.Join(chart, "values.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(values), "tag: v1.2.3")
+	require.Contains(t, string(values), "# the image repository")
+
+	// the original chart is untouched.
+	values, err = os.ReadFile(filepath.Join(ctx.Config.HelmCharts[0].Path, "values.yaml"))
+	require.NoError(t, err)
+	require.NotContains(t, string(values), "v1.2.3")
+
+	charts := ctx.Artifacts.Filter(artifact.ByType(artifact.HelmChart)).List()
+	require.Len(t, charts, 1)
+	require.Equal(t, "foo-1.2.3.tgz", charts[0].Name)
+	require.Equal(t, filepath.Join(dir, "foo-1.2.3.tgz"), charts[0].Path)
+	require.Equal(t, "foo", charts[0].ID())
+
+	provs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+	require.Len(t, provs, 1)
+	require.Equal(t, "foo-1.2.3.tgz.prov", provs[0].Name)
+}
+
+func TestRunErrors(t *testing.T) {
+	for name, h := range map[string]config.HelmChart{
+		"disable":     {Disable: "{{ .Nope }}"},
+		"path":        {Path: "{{ .Nope }}"},
+		"version":     {Version: "{{ .Nope }}"},
+		"app version": {AppVersion: "{{ .Nope }}"},
+		"sign key":    {Sign: config.HelmChartSign{Key: "{{ .Nope }}"}},
+		"flags":       {Flags: []string{"{{ .Nope }}"}},
+		"values":      {Values: map[string]string{"image.tag": "{{ .Nope }}"}},
+	} {
+		t.Run("bad "+name+" template", func(t *testing.T) {
+			fakeHelm(t, nil)
+			ctx := makeContext(t, h)
+			testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		calls := fakeHelm(t, nil)
+		ctx := makeContext(t, config.HelmChart{Disable: "true"})
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+		require.Empty(t, *calls)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		fakeHelm(t, nil)
+		ctx := makeContext(t, config.HelmChart{
+			Values: map[string]string{"image.repository.name": "foo"},
+		})
+		require.ErrorContains(t, Pipe{}.Run(ctx), "failed to set values")
+	})
+
+	t.Run("helm fails", func(t *testing.T) {
+		fakeHelm(t, func([]string) ([]byte, error) {
+			return []byte("Error: chart.metadata.name is required"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.HelmChart{})
+		err := Pipe{}.Run(ctx)
+		require.ErrorContains(t, err, "failed to package")
+		require.ErrorContains(t, err, "chart.metadata.name is required")
+	})
+
+	t.Run("unexpected output", func(t *testing.T) {
+		fakeHelm(t, func([]string) ([]byte, error) {
+			return []byte("nope"), nil
+		})
+		ctx := makeContext(t, config.HelmChart{})
+		require.ErrorContains(t, Pipe{}.Run(ctx), "failed to find chart in helm output")
+	})
+}
+
+func TestPublishOCI(t *testing.T) {
+	var input []byte
+	var calls [][]string
+	cmd = fakeCmd{execFn: func(in []byte, name string, args ...string) ([]byte, error) {
+		calls = append(calls, append([]string{name}, args...))
+		if in != nil {
+			input = in
+		}
+		if args[0] == "push" {
+			return []byte("Pushed: ghcr.io/foo/charts/foo:1.2.3\nDigest: sha256:2a6ab3b1c7a4b6f5e4b0a8a4e19b7cbb1d0e0f6f0b0e5f8b9d1a2c3d4e5f6a7b"), nil
+		}
+		return []byte("Login Succeeded"), nil
+	}}
+	t.Cleanup(func() {
+		cmd = stdCmd{}
+	})
+
+	ctx := makeContext(t, config.HelmChart{
+		Repository: "oci://ghcr.io/foo/charts",
+		Username:   "foo",
+		Password:   "{{ .Env.HELM_PASSWORD }}",
+	})
+	chart := addChart(t, ctx, ctx.Config.HelmCharts[0])
+	require.NoError(t, Pipe{}.Publish(ctx))
+	require.Equal(t, [][]string{
+		{"helm", "registry", "login", "ghcr.io", "--username", "foo", "--password-stdin"},
+		{"helm", "push", chart, "oci://ghcr.io/foo/charts"},
+	}, calls)
+	require.Equal(t, "secret", string(input))
+}
+
+func TestPublishChartMuseum(t *testing.T) {
+	var files map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/charts", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "foo", user)
+		require.Equal(t, "secret", pass)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		files = map[string]string{}
+		for field, headers := range r.MultipartForm.File {
+			f, err := headers[0].Open()
+			require.NoError(t, err)
+			bts, err := io.ReadAll(f)
+			require.NoError(t, err)
+			files[field] = headers[0].Filename + ":" + string(bts)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	calls := fakeHelm(t, nil)
+	ctx := makeContext(t, config.HelmChart{
+		Repository: srv.URL + "/",
+		Username:   "foo",
+		Password:   "{{ .Env.HELM_PASSWORD }}",
+	})
+	chart := addChart(t, ctx, ctx.Config.HelmCharts[0])
+	require.NoError(t, os.WriteFile(chart+".prov", []byte("signature"), 0o644))
+
+	require.NoError(t, Pipe{}.Publish(ctx))
+	require.Empty(t, *calls)
+	require.Equal(t, map[string]string{
+		"chart": "foo-1.2.3.tgz:chart",
+		"prov":  "foo-1.2.3.tgz.prov:signature",
+	}, files)
+}
+
+func TestPublishErrors(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		calls := fakeHelm(t, nil)
+		ctx := makeContext(t, config.HelmChart{})
+		addChart(t, ctx, ctx.Config.HelmCharts[0])
+		testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+		require.Empty(t, *calls)
+	})
+
+	t.Run("bad repository template", func(t *testing.T) {
+		fakeHelm(t, nil)
+		ctx := makeContext(t, config.HelmChart{Repository: "{{ .Nope }}"})
+		addChart(t, ctx, ctx.Config.HelmCharts[0])
+		testlib.RequireTemplateError(t, Pipe{}.Publish(ctx))
+	})
+
+	t.Run("login fails", func(t *testing.T) {
+		fakeHelm(t, func([]string) ([]byte, error) {
+			return []byte("unauthorized"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.HelmChart{
+			Repository: "oci://ghcr.io/foo/charts",
+			Username:   "foo",
+			Password:   "bar",
+		})
+		addChart(t, ctx, ctx.Config.HelmCharts[0])
+		err := Pipe{}.Publish(ctx)
+		require.ErrorContains(t, err, "failed to login to ghcr.io")
+		require.ErrorContains(t, err, "unauthorized")
+	})
+
+	t.Run("push fails", func(t *testing.T) {
+		fakeHelm(t, func([]string) ([]byte, error) {
+			return []byte("denied"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.HelmChart{Repository: "oci://ghcr.io/foo/charts"})
+		addChart(t, ctx, ctx.Config.HelmCharts[0])
+		err := Pipe{}.Publish(ctx)
+		require.ErrorContains(t, err, "failed to push foo-1.2.3.tgz")
+		require.ErrorContains(t, err, "denied")
+	})
+
+	t.Run("upload fails", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error": "file already exists"}`))
+		}))
+		t.Cleanup(srv.Close)
+		fakeHelm(t, nil)
+		ctx := makeContext(t, config.HelmChart{Repository: srv.URL})
+		addChart(t, ctx, ctx.Config.HelmCharts[0])
+		err := Pipe{}.Publish(ctx)
+		require.ErrorContains(t, err, "failed to upload foo-1.2.3.tgz")
+		require.ErrorContains(t, err, "file already exists")
+	})
+}
+
+// makeContext creates a context with a chart in a temporary directory, and
+// the given configuration, with defaults.
+func makeContext(tb testing.TB, h config.HelmChart) *context.Context {
+	tb.Helper()
+	dir := tb.TempDir()
+	chart := filepath.Join(dir, "charts", "foo")
+	require.NoError(tb, os.MkdirAll(filepath.Join(chart, "templates"), 0o755))
+	require.NoError(tb, os.WriteFile(filepath.Join(chart, "Chart.yaml"), []byte("apiVersion: v2\nname: foo\nversion: 0.0.0\n"), 0o644))
+	require.NoError(tb, os.WriteFile(filepath.Join(chart, "values.yaml"), []byte("image:\n  # the image repository\n  repository: ghcr.io/foo/foo\n  tag: \"\"\n"), 0o644))
+
+	if h.Path == "" {
+		h.Path = chart
+	}
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "foo",
+		Dist:        filepath.Join(dir, "dist"),
+		HelmCharts:  []config.HelmChart{h},
+	},
+		testctx.WithCurrentTag("v1.2.3"),
+		testctx.WithVersion("1.2.3"),
+		testctx.WithEnv(map[string]string{
+			"HELM_KEY":      "me@example.com",
+			"HELM_PASSWORD": "secret",
+		}),
+	)
+	require.NoError(tb, Pipe{}.Default(ctx))
+	return ctx
+}
+
+// addChart adds a packaged chart artifact, as the run step would.
+func addChart(tb testing.TB, ctx *context.Context, h config.HelmChart) string {
+	tb.Helper()
+	path := filepath.Join(ctx.Config.Dist, "helm", h.ID, "foo-1.2.3.tgz")
+	require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(tb, os.WriteFile(path, []byte("chart"), 0o644))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.HelmChart,
+		Name: filepath.Base(path),
+		Path: path,
+		Extra: map[string]any{
+			artifact.ExtraID: h.ID,
+			helmConfigExtra:  h,
+		},
+	})
+	return path
+}
+
+// fakeHelm replaces the command executor, recording the calls made to it,
+// with the command name as the first item.
+//
+// By default, it mimics a successful helm package.
+func fakeHelm(tb testing.TB, fn func(args []string) ([]byte, error)) *[][]string {
+	tb.Helper()
+	var calls [][]string
+	cmd = fakeCmd{execFn: func(_ []byte, name string, args ...string) ([]byte, error) {
+		call := append([]string{name}, args...)
+		calls = append(calls, call)
+		if fn != nil {
+			return fn(call)
+		}
+		idx := slices.Index(call, "--destination")
+		path := filepath.Join(call[idx+1], "foo-1.2.3.tgz")
+		if err := os.WriteFile(path, []byte("chart"), 0o644); err != nil {
+			return nil, err
+		}
+		return []byte("Successfully packaged chart and saved it to: " + path + "\n"), nil
+	}}
+	tb.Cleanup(func() {
+		cmd = stdCmd{}
+	})
+	return &calls
+}
+
+type fakeCmd struct {
+	execFn func(input []byte, name string, args ...string) ([]byte, error)
+}
+
+var _ cmder = fakeCmd{}
+
+func (f fakeCmd) Exec(_ *context.Context, input []byte, name string, args ...string) ([]byte, error) {
+	return f.execFn(input, name, args...)
+}
@@ -0,0 +1,92 @@
+package helm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// setValues sets the given values, by their dot-separated keys, e.g.
+// 'image.tag', in the given values file, keeping its comments.
+func setValues(path string, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	bts, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bts, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if err := setValue(doc.Content[0], strings.Split(key, "."), values[key]); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, out.Bytes(), 0o644) //nolint:gosec
+}
+
+func setValue(node *yaml.Node, keys []string, value string) error {
+	if node.Kind != yaml.MappingNode {
+		return errors.New("not a map")
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value != keys[0] {
+			continue
+		}
+		if len(keys) == 1 {
+			node.Content[i+1] = &yaml.Node{
+				Kind:        yaml.ScalarNode,
+				Tag:         "!!str",
+				Value:       value,
+				LineComment: node.Content[i+1].LineComment,
+			}
+			return nil
+		}
+		child := node.Content[i+1]
+		if child.Kind == yaml.ScalarNode && child.Tag == "!!null" {
+			child.Kind, child.Tag, child.Value = yaml.MappingNode, "!!map", ""
+		}
+		return setValue(child, keys[1:], value)
+	}
+
+	child := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if len(keys) > 1 {
+		child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if err := setValue(child, keys[1:], value); err != nil {
+			return err
+		}
+	}
+	node.Content = append(
+		node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keys[0]},
+		child,
+	)
+	return nil
+}
@@ -0,0 +1,66 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetValues(t *testing.T) {
+	for name, tt := range map[string]struct {
+		input    string
+		values   map[string]string
+		expected string
+	}{
+		"existing key": {
+			input:    "image:\n  # the image repository\n  repository: foo\n  tag: \"\" # overridden by goreleaser\n",
+			values:   map[string]string{"image.tag": "v1.2.3"},
+			expected: "image:\n  # the image repository\n  repository: foo\n  tag: v1.2.3 # overridden by goreleaser\n",
+		},
+		"missing keys": {
+			input:    "replicas: 1\n",
+			values:   map[string]string{"image.tag": "v1.2.3", "image.repository": "foo"},
+			expected: "replicas: 1\nimage:\n  repository: foo\n  tag: v1.2.3\n",
+		},
+		"null map": {
+			input:    "image:\n",
+			values:   map[string]string{"image.tag": "v1.2.3"},
+			expected: "image:\n  tag: v1.2.3\n",
+		},
+		"empty file": {
+			values:   map[string]string{"image.tag": "v1.2.3"},
+			expected: "image:\n  tag: v1.2.3\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "values.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.input), 0o644))
+			require.NoError(t, setValues(path, tt.values))
+			bts, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(bts))
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "values.yaml")
+		require.NoError(t, setValues(path, map[string]string{"image.tag": "v1.2.3"}))
+		bts, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, "image:\n  tag: v1.2.3\n", string(bts))
+	})
+
+	t.Run("no values", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "values.yaml")
+		require.NoError(t, setValues(path, nil))
+		require.NoFileExists(t, path)
+	})
+
+	t.Run("not a map", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "values.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("image: foo\n"), 0o644))
+		require.ErrorContains(t, setValues(path, map[string]string{"image.tag": "v1.2.3"}), "image.tag: not a map")
+	})
+}
@@ -0,0 +1,196 @@
+package irc
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineLength is the maximum length of a message text sent on a single
+// PRIVMSG, leaving room for the command, target and the prefix the server
+// adds when relaying it, as lines are limited to 512 bytes.
+const maxLineLength = 400
+
+var errSASLUnsupported = errors.New("server does not support SASL")
+
+type session struct {
+	nick     string
+	username string
+	password string
+	channels []string
+	message  string
+}
+
+type client struct {
+	conn *textproto.Conn
+}
+
+func newClient(rw io.ReadWriteCloser) *client {
+	return &client{conn: textproto.NewConn(rw)}
+}
+
+func (c *client) write(format string, args ...any) error {
+	return c.conn.PrintfLine(format, args...)
+}
+
+// send registers with the server, authenticating with SASL PLAIN if a
+// password is set, joins the channels, and posts the message to each one of
+// them.
+func (c *client) send(s session) error {
+	username := s.username
+	if username == "" {
+		username = s.nick
+	}
+
+	if s.password != "" {
+		if err := c.write("CAP REQ :sasl"); err != nil {
+			return err
+		}
+	}
+	if err := c.write("NICK %s", s.nick); err != nil {
+		return err
+	}
+	if err := c.write("USER %s 0 * :GoReleaser", username); err != nil {
+		return err
+	}
+
+	// pending channels, by their lowercase name, as servers might not keep
+	// the case.
+	pending := map[string]string{}
+	for {
+		line, err := c.conn.ReadLine()
+		if err != nil {
+			return err
+		}
+		msg := parse(line)
+
+		switch msg.command {
+		case "PING":
+			if err := c.write("PONG :%s", msg.last()); err != nil {
+				return err
+			}
+		case "ERROR":
+			return fmt.Errorf("server error: %s", msg.last())
+		case "CAP":
+			if len(msg.params) < 2 {
+				continue
+			}
+			switch msg.params[1] {
+			case "ACK":
+				if err := c.write("AUTHENTICATE PLAIN"); err != nil {
+					return err
+				}
+			case "NAK":
+				return errSASLUnsupported
+			}
+		case "AUTHENTICATE":
+			if msg.last() != "+" {
+				continue
+			}
+			creds := base64.StdEncoding.EncodeToString([]byte(username + "\x00" + username + "\x00" + s.password))
+			if err := c.write("AUTHENTICATE %s", creds); err != nil {
+				return err
+			}
+		case "903": // RPL_SASLSUCCESS
+			if err := c.write("CAP END"); err != nil {
+				return err
+			}
+		case "902", "904", "905", "906": // SASL failures
+			return fmt.Errorf("sasl authentication failed: %s", msg.last())
+		case "433": // ERR_NICKNAMEINUSE
+			return fmt.Errorf("nick %q is already in use", s.nick)
+		case "001": // RPL_WELCOME
+			for _, channel := range s.channels {
+				pending[strings.ToLower(channel)] = channel
+				if err := c.write("JOIN %s", channel); err != nil {
+					return err
+				}
+			}
+		case "403", "405", "471", "473", "474", "475", "477": // join failures
+			return fmt.Errorf("could not join %s: %s", msg.param(1), msg.last())
+		case "366": // RPL_ENDOFNAMES, sent once the channel is joined
+			key := strings.ToLower(msg.param(1))
+			channel, ok := pending[key]
+			if !ok {
+				continue
+			}
+			delete(pending, key)
+			for _, text := range split(s.message) {
+				if err := c.write("PRIVMSG %s :%s", channel, text); err != nil {
+					return err
+				}
+			}
+			if len(pending) == 0 {
+				return c.write("QUIT :%s", "Released with GoReleaser")
+			}
+		}
+	}
+}
+
+type message struct {
+	command string
+	params  []string
+}
+
+func (m message) param(i int) string {
+	if i < len(m.params) {
+		return m.params[i]
+	}
+	return ""
+}
+
+func (m message) last() string {
+	if len(m.params) == 0 {
+		return ""
+	}
+	return m.params[len(m.params)-1]
+}
+
+// parse parses a line received from the server, ignoring tags and the prefix.
+func parse(line string) message {
+	if strings.HasPrefix(line, "@") {
+		_, line, _ = strings.Cut(line, " ")
+	}
+	if strings.HasPrefix(line, ":") {
+		_, line, _ = strings.Cut(line, " ")
+	}
+	line, trailing, hasTrailing := strings.Cut(line, " :")
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return message{}
+	}
+	msg := message{
+		command: strings.ToUpper(fields[0]),
+		params:  fields[1:],
+	}
+	if hasTrailing {
+		msg.params = append(msg.params, trailing)
+	}
+	return msg
+}
+
+// split splits the message in lines, as IRC messages can't contain line
+// breaks, and then splits long lines so they fit in a single PRIVMSG.
+func split(s string) []string {
+	var result []string
+	for line := range strings.SplitSeq(s, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		for len(line) > maxLineLength {
+			i := maxLineLength
+			for i > 0 && !utf8.RuneStart(line[i]) {
+				i--
+			}
+			result = append(result, line[:i])
+			line = line[i:]
+		}
+		result = append(result, line)
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+// Package irc announces releases to IRC channels.
+package irc
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/caarlos0/log"
+
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultServer          = "irc.libera.chat"
+	defaultPort            = 6697
+	defaultPlainPort       = 6667
+	defaultNick            = "goreleaser"
+	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
+
+	timeout = time.Minute
+)
+
+var errNoChannels = errors.New("irc: at least one channel is required")
+
+type Pipe struct{}
+
+func (Pipe) String() string { return "irc" }
+func (Pipe) Skip(ctx *context.Context) (bool, error) {
+	enable, err := tmpl.New(ctx).Bool(ctx.Config.Announce.IRC.Enabled)
+	return !enable, err
+}
+
+type Config struct {
+	Password string `env:"IRC_PASSWORD"`
+}
+
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Announce.IRC
+	if cfg.Server == "" {
+		cfg.Server = defaultServer
+	}
+	if cfg.Port == 0 {
+		cfg.Port = defaultPort
+		if cfg.DisableTLS {
+			cfg.Port = defaultPlainPort
+		}
+	}
+	if cfg.Nick == "" {
+		cfg.Nick = defaultNick
+	}
+	if cfg.MessageTemplate == "" {
+		cfg.MessageTemplate = defaultMessageTemplate
+	}
+	return nil
+}
+
+// Preview returns the messages that would be sent to each channel.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	_, s, err := newSession(ctx)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, channel := range s.channels {
+		for _, line := range split(s.message) {
+			fmt.Fprintf(&sb, "%s: %s\n", channel, line)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+func (Pipe) Announce(ctx *context.Context) error {
+	icfg := ctx.Config.Announce.IRC
+	server, s, err := newSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := env.ParseAs[Config]()
+	if err != nil {
+		return err
+	}
+	s.password = cfg.Password
+
+	addr := net.JoinHostPort(server, strconv.Itoa(icfg.Port))
+	conn, err := retryx.DoWithData(ctx, ctx.Config.Retry, func() (net.Conn, error) {
+		return dial(ctx, addr, server, icfg.DisableTLS, icfg.SkipTLSVerify)
+	}, retryx.IsRetriable)
+	if err != nil {
+		return fmt.Errorf("irc: %w", err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return fmt.Errorf("irc: %w", err)
+	}
+
+	log.Infof("posting: '%s'", s.message)
+	if err := newClient(conn).send(s); err != nil {
+		return fmt.Errorf("irc: %w", err)
+	}
+	return nil
+}
+
+// newSession returns the templated server and the session to run on it,
+// without the password.
+func newSession(ctx *context.Context) (string, session, error) {
+	icfg := ctx.Config.Announce.IRC
+	t := tmpl.New(ctx).WithMessageTemplates()
+
+	server, nick, username, msg := icfg.Server, icfg.Nick, icfg.Username, icfg.MessageTemplate
+	if err := t.ApplyAll(&server, &nick, &username, &msg); err != nil {
+		return "", session{}, err
+	}
+
+	var channels []string
+	for _, channel := range icfg.Channels {
+		channel, err := t.Apply(channel)
+		if err != nil {
+			return "", session{}, err
+		}
+		if channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+	if len(channels) == 0 {
+		return "", session{}, errNoChannels
+	}
+
+	return server, session{
+		nick:     nick,
+		username: username,
+		channels: channels,
+		message:  msg,
+	}, nil
+}
+
+func dial(ctx *context.Context, addr, server string, disableTLS, skipVerify bool) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	if disableTLS {
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+	tlsDialer := &tls.Dialer{
+		NetDialer: dialer,
+		Config: &tls.Config{
+			ServerName:         server,
+			InsecureSkipVerify: skipVerify,
+		},
+	}
+	return tlsDialer.DialContext(ctx, "tcp", addr)
+}
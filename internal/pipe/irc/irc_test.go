@@ -0,0 +1,307 @@
+package irc
+
+import (
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringer(t *testing.T) {
+	require.Equal(t, "irc", Pipe{}.String())
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("tls", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, defaultServer, ctx.Config.Announce.IRC.Server)
+		require.Equal(t, defaultPort, ctx.Config.Announce.IRC.Port)
+		require.Equal(t, defaultNick, ctx.Config.Announce.IRC.Nick)
+		require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.IRC.MessageTemplate)
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				IRC: config.IRC{
+					DisableTLS: true,
+				},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, defaultPlainPort, ctx.Config.Announce.IRC.Port)
+	})
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				IRC: config.IRC{
+					Enabled: "true",
+				},
+			},
+		})
+
+		skip, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+}
+
+func TestAnnounceInvalidTemplate(t *testing.T) {
+	for name, cfg := range map[string]config.IRC{
+		"message": {MessageTemplate: "{{ .Foo }", Channels: []string{"#honk"}},
+		"server":  {Server: "{{ .Foo }", Channels: []string{"#honk"}},
+		"nick":    {Nick: "{{ .Foo }", Channels: []string{"#honk"}},
+		"channel": {Channels: []string{"{{ .Foo }"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{
+					IRC: cfg,
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+		})
+	}
+}
+
+func TestAnnounceNoChannels(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			IRC: config.IRC{
+				Channels: []string{"{{ .Env.NOPE }}"},
+			},
+		},
+	}, testctx.WithEnv(map[string]string{"NOPE": ""}))
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorIs(t, Pipe{}.Announce(ctx), errNoChannels)
+}
+
+func TestAnnounce(t *testing.T) {
+	for name, password := range map[string]string{
+		"anonymous": "",
+		"sasl":      "secret",
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := newServer(t, password)
+
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "honk",
+				Announce: config.Announce{
+					IRC: config.IRC{
+						Server:     "127.0.0.1",
+						Port:       srv.port,
+						DisableTLS: true,
+						Nick:       "honkbot",
+						Channels:   []string{"#honk", "#Honk-Dev"},
+						MessageTemplate: `{{ .ProjectName }} {{ .Tag }} is out!
+Check it out at {{ .ReleaseURL }}`,
+					},
+				},
+			}, testctx.WithCurrentTag("v1.0.0"))
+			ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+			t.Setenv("IRC_PASSWORD", password)
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Announce(ctx))
+
+			require.Equal(t, []string{
+				"PRIVMSG #honk :honk v1.0.0 is out!",
+				"PRIVMSG #honk :Check it out at https://github.com/honk/honk/releases/tag/v1.0.0",
+				"PRIVMSG #Honk-Dev :honk v1.0.0 is out!",
+				"PRIVMSG #Honk-Dev :Check it out at https://github.com/honk/honk/releases/tag/v1.0.0",
+			}, srv.messages())
+			require.Equal(t, password != "", srv.authenticated)
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			IRC: config.IRC{
+				Channels: []string{"#honk", "{{ .Env.NOPE }}", "#Honk-Dev"},
+				MessageTemplate: `{{ .ProjectName }} {{ .Tag }} is out!
+Check it out at {{ .ReleaseURL }}`,
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"), testctx.WithEnv(map[string]string{"NOPE": ""}))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, `#honk: honk v1.0.0 is out!
+#honk: Check it out at https://github.com/honk/honk/releases/tag/v1.0.0
+#Honk-Dev: honk v1.0.0 is out!
+#Honk-Dev: Check it out at https://github.com/honk/honk/releases/tag/v1.0.0`, out)
+}
+
+func TestAnnounceSASLFailed(t *testing.T) {
+	srv := newServer(t, "secret")
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			IRC: config.IRC{
+				Server:     "127.0.0.1",
+				Port:       srv.port,
+				DisableTLS: true,
+				Channels:   []string{"#honk"},
+			},
+		},
+	})
+	t.Setenv("IRC_PASSWORD", "wrong")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Announce(ctx), "irc: sasl authentication failed")
+	require.Empty(t, srv.messages())
+}
+
+func TestAnnounceJoinFailed(t *testing.T) {
+	srv := newServer(t, "")
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			IRC: config.IRC{
+				Server:     "127.0.0.1",
+				Port:       srv.port,
+				DisableTLS: true,
+				Channels:   []string{"#banned"},
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), "irc: could not join #banned: Cannot join channel (+b)")
+}
+
+func TestParse(t *testing.T) {
+	for line, expect := range map[string]message{
+		"PING :irc.example.com":                           {"PING", []string{"irc.example.com"}},
+		":irc.example.com 001 honk :Welcome to IRC":       {"001", []string{"honk", "Welcome to IRC"}},
+		":irc.example.com CAP * ACK :sasl":                {"CAP", []string{"*", "ACK", "sasl"}},
+		"@time=2026-01-01T00:00:00Z :nick!u@h JOIN #honk": {"JOIN", []string{"#honk"}},
+		":irc.example.com 366 honk #honk :End of /NAMES":  {"366", []string{"honk", "#honk", "End of /NAMES"}},
+		"AUTHENTICATE +":                                  {"AUTHENTICATE", []string{"+"}},
+		"":                                                {},
+	} {
+		t.Run(line, func(t *testing.T) {
+			require.Equal(t, expect, parse(line))
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	require.Equal(t, []string{"foo", "bar"}, split("foo\r\n\n  \nbar\n"))
+
+	long := strings.Repeat("a", maxLineLength-1) + "é" + "bc"
+	lines := split(long)
+	require.Len(t, lines, 2)
+	require.Equal(t, strings.Repeat("a", maxLineLength-1), lines[0])
+	require.Equal(t, "ébc", lines[1])
+}
+
+// server is a minimal IRC server, just enough to test the announcer.
+type server struct {
+	port          int
+	password      string
+	authenticated bool
+
+	done chan struct{}
+	msgs []string
+}
+
+func newServer(tb testing.TB, password string) *server {
+	tb.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(tb, err)
+	tb.Cleanup(func() { _ = ln.Close() })
+
+	srv := &server{
+		port:     ln.Addr().(*net.TCPAddr).Port,
+		password: password,
+		done:     make(chan struct{}),
+	}
+	go func() {
+		defer close(srv.done)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		srv.handle(textproto.NewConn(conn))
+	}()
+	return srv
+}
+
+// messages waits for the client to disconnect, and returns the messages it
+// sent.
+func (s *server) messages() []string {
+	<-s.done
+	return s.msgs
+}
+
+func (s *server) handle(conn *textproto.Conn) {
+	var nick string
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		msg := parse(line)
+		switch msg.command {
+		case "CAP":
+			if msg.param(0) == "REQ" {
+				_ = conn.PrintfLine(":irc.test CAP * ACK :sasl")
+			}
+		case "AUTHENTICATE":
+			if msg.param(0) == "PLAIN" {
+				_ = conn.PrintfLine("AUTHENTICATE +")
+				continue
+			}
+			creds, _ := base64.StdEncoding.DecodeString(msg.param(0))
+			parts := strings.Split(string(creds), "\x00")
+			if len(parts) != 3 || parts[2] != s.password {
+				_ = conn.PrintfLine(":irc.test 904 * :SASL authentication failed")
+				continue
+			}
+			s.authenticated = true
+			_ = conn.PrintfLine(":irc.test 903 * :SASL authentication successful")
+		case "NICK":
+			nick = msg.param(0)
+		case "USER":
+			_ = conn.PrintfLine(":irc.test PING :%d", s.port)
+		case "PONG":
+			_ = conn.PrintfLine(":irc.test 001 %s :Welcome", nick)
+		case "JOIN":
+			channel := msg.param(0)
+			if channel == "#banned" {
+				_ = conn.PrintfLine(":irc.test 474 %s %s :Cannot join channel (+b)", nick, channel)
+				continue
+			}
+			_ = conn.PrintfLine(":irc.test 353 %s = %s :%s", nick, channel, nick)
+			_ = conn.PrintfLine(":irc.test 366 %s %s :End of /NAMES list.", nick, strings.ToLower(channel))
+		case "PRIVMSG":
+			s.msgs = append(s.msgs, line)
+		case "QUIT":
+			return
+		}
+	}
+}
@@ -57,6 +57,7 @@ var (
 	errNoRepositories    = errors.New("ko: missing repositories: please set either the repository field or a $KO_DOCKER_REPO environment variable")
 	errInvalidMainPath   = errors.New("ko: invalid Main path: ko.main (or build.main if ko.main is not set) should be a relative path")
 	errInvalidMainGoPath = errors.New("ko: invalid Main path: your path should point to a directory instead of a .go file")
+	errTarballPlatforms  = errors.New("ko: tarball only supports a single platform, please set only one platform or remove the tarball option")
 )
 
 // Pipe that build OCI compliant images with ko.
@@ -117,6 +118,10 @@ func (Pipe) Default(ctx *context.Context) error {
 			ko.Platforms = []string{"linux/amd64"}
 		}
 
+		if ko.Tarball != "" && (len(ko.Platforms) != 1 || ko.Platforms[0] == "all") {
+			return errTarballPlatforms
+		}
+
 		if len(ko.Tags) == 0 {
 			ko.Tags = []string{"latest"}
 		}
@@ -195,6 +200,8 @@ type buildOptions struct {
 	bare                bool
 	preserveImportPaths bool
 	baseImportPaths     bool
+	tarball             string
+	skipPush            bool
 }
 
 func (o *buildOptions) makeBuilder(ctx *context.Context) (*build.Caching, error) {
@@ -309,21 +316,7 @@ func doBuild(ctx *context.Context, ko config.Ko) error {
 		Local:               ctx.Snapshot,
 		LocalDomain:         getLocalDomain(ko),
 	}
-	var p publish.Interface
-	if ctx.Snapshot {
-		p, err = publish.NewDaemon(
-			options.MakeNamer(po),
-			opts.tags,
-			publish.WithLocalDomain(po.LocalDomain),
-		)
-	} else {
-		p, err = publish.NewDefault(
-			opts.imageRepos[0],
-			publish.WithTags(opts.tags),
-			publish.WithNamer(options.MakeNamer(po)),
-			publish.WithAuthFromKeychain(keychain),
-		)
-	}
+	p, err := makePublisher(po, opts, ctx.Snapshot)
 	if err != nil {
 		return fmt.Errorf("newPublisher: %w", err)
 	}
@@ -336,6 +329,11 @@ func doBuild(ctx *context.Context, ko config.Ko) error {
 		return fmt.Errorf("close: %w", err)
 	}
 
+	if opts.skipPush && !ctx.Snapshot {
+		log.WithField("tarball", opts.tarball).Info("skipped pushing")
+		return nil
+	}
+
 	ctx.Artifacts.Add(makeArtifact(
 		ko.ID,
 		ref.Name(),
@@ -363,6 +361,52 @@ func doBuild(ctx *context.Context, ko config.Ko) error {
 	return nil
 }
 
+// makePublisher creates the publisher for the given options.
+//
+// If a tarball is set, the image is also written to it.
+// The tarball publisher goes first so the reference of the pushed image, if
+// any, is the one returned.
+func makePublisher(po *options.PublishOptions, opts *buildOptions, snapshot bool) (publish.Interface, error) {
+	var publishers []publish.Interface
+	if opts.tarball != "" {
+		publishers = append(publishers, publish.NewTarball(
+			opts.tarball,
+			opts.imageRepos[0],
+			options.MakeNamer(po),
+			opts.tags,
+		))
+	}
+
+	switch {
+	case snapshot:
+		p, err := publish.NewDaemon(
+			options.MakeNamer(po),
+			opts.tags,
+			publish.WithLocalDomain(po.LocalDomain),
+		)
+		if err != nil {
+			return nil, err
+		}
+		publishers = append(publishers, p)
+	case !opts.skipPush:
+		p, err := publish.NewDefault(
+			opts.imageRepos[0],
+			publish.WithTags(opts.tags),
+			publish.WithNamer(options.MakeNamer(po)),
+			publish.WithAuthFromKeychain(keychain),
+		)
+		if err != nil {
+			return nil, err
+		}
+		publishers = append(publishers, p)
+	}
+
+	if len(publishers) == 0 {
+		return nil, errors.New("skip_push is set, but no tarball is configured")
+	}
+	return publish.MultiPublisher(publishers...), nil
+}
+
 func findBuild(ctx *context.Context, ko config.Ko) (config.Build, error) {
 	for _, build := range ctx.Config.Builds {
 		if build.ID == ko.Build {
@@ -473,6 +517,20 @@ func buildBuildOptions(ctx *context.Context, cfg config.Ko) (*buildOptions, erro
 		}
 		opts.ldflags = ldflags
 	}
+
+	if cfg.Tarball != "" {
+		tarball, err := tmpl.New(ctx).Apply(cfg.Tarball)
+		if err != nil {
+			return nil, err
+		}
+		opts.tarball = filepath.Join(ctx.Config.Dist, tarball)
+	}
+
+	skipPush, err := tmpl.New(ctx).Bool(cfg.SkipPush)
+	if err != nil {
+		return nil, err
+	}
+	opts.skipPush = skipPush
 	return opts, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -125,6 +126,33 @@ func TestDefaultNoImage(t *testing.T) {
 	require.ErrorIs(t, Pipe{}.Default(ctx), errNoRepositories)
 }
 
+func TestDefaultTarballPlatforms(t *testing.T) {
+	for _, platforms := range [][]string{
+		{"linux/amd64", "linux/arm64"},
+		{"all"},
+	} {
+		t.Run(strings.Join(platforms, ","), func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "test",
+				Builds: []config.Build{
+					{
+						ID: "test",
+					},
+				},
+				Kos: []config.Ko{
+					{
+						Repositories: []string{"ghcr.io/foo/bar"},
+						Platforms:    platforms,
+						Tarball:      "image.tar",
+					},
+				},
+			})
+
+			require.ErrorIs(t, Pipe{}.Default(ctx), errTarballPlatforms)
+		})
+	}
+}
+
 func TestDescription(t *testing.T) {
 	require.NotEmpty(t, Pipe{}.String())
 }
@@ -643,6 +671,18 @@ func TestPublishLocalBaseImage(t *testing.T) {
 		ctx.Snapshot = true
 		require.NoError(t, doBuild(ctx, ctx.Config.Kos[0]))
 	})
+
+	t.Run("tarball only", func(t *testing.T) {
+		ctx := makeCtx()
+		ctx.Config.Dist = t.TempDir()
+		ctx.Config.Kos[0].Repositories = []string{"ghcr.io/foo/bar"}
+		ctx.Config.Kos[0].Tarball = "{{ .ProjectName }}_image.tar"
+		ctx.Config.Kos[0].SkipPush = "true"
+		ctx.Config.ProjectName = "test"
+		require.NoError(t, doBuild(ctx, ctx.Config.Kos[0]))
+		require.FileExists(t, filepath.Join(ctx.Config.Dist, "test_image.tar"))
+		require.Empty(t, ctx.Artifacts.List())
+	})
 }
 
 func TestPublishPipeError(t *testing.T) {
@@ -755,6 +795,27 @@ func TestPublishPipeError(t *testing.T) {
 		testlib.RequireTemplateError(t, Pipe{}.Publish(ctx))
 	})
 
+	t.Run("invalid tarball tmpl", func(t *testing.T) {
+		ctx := makeCtx()
+		ctx.Config.Kos[0].Tarball = "{{.Nope}}"
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Publish(ctx))
+	})
+
+	t.Run("invalid skip push tmpl", func(t *testing.T) {
+		ctx := makeCtx()
+		ctx.Config.Kos[0].SkipPush = "{{.Nope}}"
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Publish(ctx))
+	})
+
+	t.Run("skip push without tarball", func(t *testing.T) {
+		ctx := makeCtx()
+		ctx.Config.Kos[0].SkipPush = "true"
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Publish(ctx), "newPublisher: skip_push is set, but no tarball is configured")
+	})
+
 	t.Run("publish fail", func(t *testing.T) {
 		ctx := makeCtx()
 		require.NoError(t, Pipe{}.Default(ctx))
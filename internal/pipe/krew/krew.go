@@ -11,6 +11,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -32,7 +33,12 @@ const (
 	apiVersion      = "krew.googlecontainertools.github.com/v1alpha2"
 )
 
-var ErrNoArchivesFound = errors.New("no archives found")
+var (
+	ErrNoArchivesFound = errors.New("no archives found")
+
+	pluginNameRe = regexp.MustCompile(`^[\w-]+$`)
+	sha256Re     = regexp.MustCompile(`^[a-f0-9]{64}$`)
+)
 
 // Pipe for krew manifest deployment.
 type Pipe struct{}
@@ -175,6 +181,9 @@ func buildmanifest(
 	if err != nil {
 		return "", err
 	}
+	if err := validateManifest(data); err != nil {
+		return "", err
+	}
 	return doBuildManifest(data)
 }
 
@@ -220,23 +229,54 @@ func manifestFor(
 			}
 			cfg.URLTemplate = url
 		}
-		url, err := tmpl.New(ctx).WithArtifact(art).Apply(cfg.URLTemplate)
+		t := tmpl.New(ctx).WithArtifact(art)
+		url, err := t.Apply(cfg.URLTemplate)
 		if err != nil {
 			return result, err
 		}
 
+		bins := artifact.MustExtra[[]string](*art, artifact.ExtraBinaries)
+		if len(bins) != 1 {
+			return result, fmt.Errorf("only one binary per archive allowed, got %d on %q", len(bins), art.Name)
+		}
+		bin := path.Join(artifact.ExtraOr(*art, artifact.ExtraWrappedIn, ""), bins[0])
+		if cfg.Bin != "" {
+			bin, err = t.Apply(cfg.Bin)
+			if err != nil {
+				return result, err
+			}
+		}
+
+		var files []File
+		for _, f := range cfg.Files {
+			from, err := t.Apply(f.From)
+			if err != nil {
+				return result, err
+			}
+			to, err := t.Apply(f.To)
+			if err != nil {
+				return result, err
+			}
+			files = append(files, File{From: from, To: to})
+		}
+
+		var exprs []MatchExpression
+		for _, e := range cfg.Selector.MatchExpressions {
+			exprs = append(exprs, MatchExpression{
+				Key:      e.Key,
+				Operator: e.Operator,
+				Values:   e.Values,
+			})
+		}
+
 		goarch := []string{art.Goarch}
 		if art.Goarch == "all" {
 			goarch = []string{"amd64", "arm64"}
 		}
 
 		for _, arch := range goarch {
-			bins := artifact.MustExtra[[]string](*art, artifact.ExtraBinaries)
-			if len(bins) != 1 {
-				return result, fmt.Errorf("only one binary per archive allowed, got %d on %q", len(bins), art.Name)
-			}
 			result.Spec.Platforms = append(result.Spec.Platforms, Platform{
-				Bin:    bins[0],
+				Bin:    bin,
 				URI:    url,
 				Sha256: sum,
 				Selector: Selector{
@@ -244,7 +284,9 @@ func manifestFor(
 						Os:   art.Goos,
 						Arch: arch,
 					},
+					MatchExpressions: exprs,
 				},
+				Files: files,
 			})
 		}
 	}
@@ -256,6 +298,52 @@ func manifestFor(
 	return result, nil
 }
 
+// validateManifest checks the manifest against the rules krew enforces on
+// plugin manifests, so we fail before opening a pull request to the index.
+func validateManifest(m Manifest) error {
+	if !pluginNameRe.MatchString(m.Metadata.Name) {
+		return fmt.Errorf("krew: invalid plugin name: %q", m.Metadata.Name)
+	}
+	if len(m.Spec.Platforms) == 0 {
+		return errors.New("krew: manifest has no platforms")
+	}
+	for _, p := range m.Spec.Platforms {
+		platform := p.Selector.MatchLabels.Os + "/" + p.Selector.MatchLabels.Arch
+		if p.URI == "" {
+			return fmt.Errorf("krew: %s: uri is not set", platform)
+		}
+		if !sha256Re.MatchString(p.Sha256) {
+			return fmt.Errorf("krew: %s: invalid sha256: %q", platform, p.Sha256)
+		}
+		if p.Bin == "" {
+			return fmt.Errorf("krew: %s: bin is not set", platform)
+		}
+		for _, f := range p.Files {
+			if f.From == "" || f.To == "" {
+				return fmt.Errorf("krew: %s: files must have both from and to set", platform)
+			}
+		}
+		for _, e := range p.Selector.MatchExpressions {
+			if e.Key == "" {
+				return fmt.Errorf("krew: %s: match expression key is not set", platform)
+			}
+			switch e.Operator {
+			case "In", "NotIn":
+				if len(e.Values) == 0 {
+					return fmt.Errorf("krew: %s: match expression %q: operator %s requires values", platform, e.Key, e.Operator)
+				}
+			case "Exists", "DoesNotExist":
+				if len(e.Values) > 0 {
+					return fmt.Errorf("krew: %s: match expression %q: operator %s does not allow values", platform, e.Key, e.Operator)
+				}
+			default:
+				return fmt.Errorf("krew: %s: match expression %q: invalid operator: %q", platform, e.Key, e.Operator)
+			}
+		}
+	}
+	return nil
+}
+
 // Publish krew manifest.
 func (Pipe) Publish(ctx *context.Context) error {
 	cli, err := client.New(ctx)
@@ -352,7 +440,7 @@ func doPublish(ctx *context.Context, manifest *artifact.Artifact, cl client.Clie
 		return errors.New("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, msg, cfg.Repository.PullRequest.Draft)
+	return pcl.OpenPullRequest(ctx, base, repo, msg, cfg.Repository.PullRequest.Body, cfg.Repository.PullRequest.Draft)
 }
 
 func buildManifestPath(folder, filename string) string {
@@ -375,8 +463,20 @@ type MatchLabels struct {
 	Arch string `yaml:"arch,omitempty"`
 }
 
+type MatchExpression struct {
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"`
+	Values   []string `yaml:"values,omitempty"`
+}
+
 type Selector struct {
-	MatchLabels MatchLabels `yaml:"matchLabels,omitempty"`
+	MatchLabels      MatchLabels       `yaml:"matchLabels,omitempty"`
+	MatchExpressions []MatchExpression `yaml:"matchExpressions,omitempty"`
+}
+
+type File struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
 }
 
 type Platform struct {
@@ -384,6 +484,7 @@ type Platform struct {
 	URI      string   `yaml:"uri,omitempty"`
 	Sha256   string   `yaml:"sha256,omitempty"`
 	Selector Selector `yaml:"selector,omitempty"`
+	Files    []File   `yaml:"files,omitempty"`
 }
 
 type Spec struct {
@@ -159,6 +159,22 @@ func TestFullPipe(t *testing.T) {
 				}
 			},
 		},
+		"custom_bin": {
+			prepare: func(ctx *context.Context) {
+				ctx.TokenType = context.TokenTypeGitHub
+				ctx.Config.Krews[0].Repository.Owner = "test"
+				ctx.Config.Krews[0].Repository.Name = "test"
+				ctx.Config.Krews[0].Homepage = "https://github.com/goreleaser"
+				ctx.Config.Krews[0].Bin = "kubectl-{{ .ProjectName }}"
+				ctx.Config.Krews[0].Files = []config.KrewFile{
+					{From: "name", To: "kubectl-{{ .ProjectName }}"},
+					{From: "LICENSE", To: "."},
+				}
+				ctx.Config.Krews[0].Selector.MatchExpressions = []config.KrewMatchExpression{
+					{Key: "os", Operator: "In", Values: []string{"darwin", "linux"}},
+				}
+			},
+		},
 		"default_gitlab": {
 			prepare: func(ctx *context.Context) {
 				ctx.TokenType = context.TokenTypeGitLab
@@ -215,6 +231,32 @@ func TestFullPipe(t *testing.T) {
 			},
 			expectedRunErrorAs: &tmpl.Error{},
 		},
+		"invalid bin": {
+			prepare: func(ctx *context.Context) {
+				ctx.Config.Krews[0].Repository.Owner = "test"
+				ctx.Config.Krews[0].Repository.Name = "test"
+				ctx.Config.Krews[0].Bin = "{{ .Asdsa }"
+			},
+			expectedRunErrorAs: &tmpl.Error{},
+		},
+		"invalid files": {
+			prepare: func(ctx *context.Context) {
+				ctx.Config.Krews[0].Repository.Owner = "test"
+				ctx.Config.Krews[0].Repository.Name = "test"
+				ctx.Config.Krews[0].Files = []config.KrewFile{{From: "{{ .Asdsa }", To: "."}}
+			},
+			expectedRunErrorAs: &tmpl.Error{},
+		},
+		"invalid_selector": {
+			prepare: func(ctx *context.Context) {
+				ctx.Config.Krews[0].Repository.Owner = "test"
+				ctx.Config.Krews[0].Repository.Name = "test"
+				ctx.Config.Krews[0].Selector.MatchExpressions = []config.KrewMatchExpression{
+					{Key: "os", Operator: "Equals", Values: []string{"linux"}},
+				}
+			},
+			expectedRunError: `krew: darwin/amd64: match expression "os": invalid operator: "Equals"`,
+		},
 		"no short desc": {
 			prepare: func(ctx *context.Context) {
 				ctx.Config.Krews[0].Repository.Owner = "test"
@@ -959,6 +1001,110 @@ func TestRunMultipleBinaries(t *testing.T) {
 	require.EqualError(t, runAll(ctx, client), `only one binary per archive allowed, got 2 on "bin.tar.gz"`)
 }
 
+func TestRunPipeWrappedInDirectory(t *testing.T) {
+	folder := t.TempDir()
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist:        folder,
+		ProjectName: "foo",
+		Krews: []config.Krew{
+			{
+				Name:             manifestName(t),
+				Description:      "Some desc",
+				ShortDescription: "Short desc",
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.1"), testctx.WithVersion("1.0.1"))
+
+	path := filepath.Join(folder, "bin.tar.gz")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:    "bin.tar.gz",
+		Path:    path,
+		Goos:    "linux",
+		Goarch:  "amd64",
+		Goamd64: "v1",
+		Type:    artifact.UploadableArchive,
+		Extra: map[string]any{
+			artifact.ExtraID:        "foo",
+			artifact.ExtraFormat:    "tar.gz",
+			artifact.ExtraBinaries:  []string{"foo"},
+			artifact.ExtraWrappedIn: "foo_1.0.1",
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, runAll(ctx, client.NewMock()))
+	bts, err := os.ReadFile(filepath.Join(folder, "krew", manifestName(t)+".yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(bts), "- bin: foo_1.0.1/foo\n")
+}
+
+func TestValidateManifest(t *testing.T) {
+	for name, tt := range map[string]struct {
+		manifest func(m *Manifest)
+		err      string
+	}{
+		"valid": {
+			manifest: func(*Manifest) {},
+		},
+		"invalid name": {
+			manifest: func(m *Manifest) { m.Metadata.Name = "foo/bar" },
+			err:      `krew: invalid plugin name: "foo/bar"`,
+		},
+		"no platforms": {
+			manifest: func(m *Manifest) { m.Spec.Platforms = nil },
+			err:      "krew: manifest has no platforms",
+		},
+		"no uri": {
+			manifest: func(m *Manifest) { m.Spec.Platforms[0].URI = "" },
+			err:      "krew: darwin/amd64: uri is not set",
+		},
+		"invalid sha256": {
+			manifest: func(m *Manifest) { m.Spec.Platforms[0].Sha256 = "abc" },
+			err:      `krew: darwin/amd64: invalid sha256: "abc"`,
+		},
+		"no bin": {
+			manifest: func(m *Manifest) { m.Spec.Platforms[0].Bin = "" },
+			err:      "krew: darwin/amd64: bin is not set",
+		},
+		"file without to": {
+			manifest: func(m *Manifest) {
+				m.Spec.Platforms[0].Files = []File{{From: "test"}}
+			},
+			err: "krew: darwin/amd64: files must have both from and to set",
+		},
+		"expression without key": {
+			manifest: func(m *Manifest) {
+				m.Spec.Platforms[0].Selector.MatchExpressions = []MatchExpression{{Operator: "Exists"}}
+			},
+			err: "krew: darwin/amd64: match expression key is not set",
+		},
+		"in without values": {
+			manifest: func(m *Manifest) {
+				m.Spec.Platforms[0].Selector.MatchExpressions = []MatchExpression{{Key: "os", Operator: "In"}}
+			},
+			err: `krew: darwin/amd64: match expression "os": operator In requires values`,
+		},
+		"exists with values": {
+			manifest: func(m *Manifest) {
+				m.Spec.Platforms[0].Selector.MatchExpressions = []MatchExpression{{Key: "os", Operator: "Exists", Values: []string{"linux"}}}
+			},
+			err: `krew: darwin/amd64: match expression "os": operator Exists does not allow values`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := createTemplateData()
+			tt.manifest(&m)
+			err := validateManifest(m)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
 func TestDefault(t *testing.T) {
 	testlib.Mktmp(t)
 
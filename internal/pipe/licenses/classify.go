@@ -0,0 +1,153 @@
+package licenses
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+)
+
+// unknown is the license of dependencies we can't identify.
+const unknown = "Unknown"
+
+// licenseFiles are the names of the files, without extension, that may
+// contain the license of a module.
+var licenseFiles = []string{"license", "licence", "copying", "unlicense"}
+
+// rules identify licenses by phrases that are present in their texts.
+//
+// They are evaluated in order, so more specific ones should come first, e.g.:
+// the LGPL text mentions the GPL.
+var rules = []struct {
+	id      string
+	phrases []string
+}{
+	{"AGPL-3.0", []string{"gnu affero general public license", "version 3"}},
+	{"LGPL-3.0", []string{"gnu lesser general public license", "version 3"}},
+	{"LGPL-2.1", []string{"gnu lesser general public license", "version 2.1"}},
+	{"LGPL-2.0", []string{"gnu library general public license"}},
+	{"GPL-3.0", []string{"gnu general public license", "version 3"}},
+	{"GPL-2.0", []string{"gnu general public license", "version 2"}},
+	{"MPL-2.0", []string{"mozilla public license", "version 2.0"}},
+	{"EPL-2.0", []string{"eclipse public license - v 2.0"}},
+	{"Apache-2.0", []string{"apache license", "version 2.0"}},
+	{"MIT", []string{"permission is hereby granted, free of charge"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "names of its contributors"}},
+	{"BSD-2-Clause", []string{"redistribution and use in source and binary forms"}},
+	{"ISC", []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{"Unlicense", []string{"this is free and unencumbered software released into the public domain"}},
+	{"CC0-1.0", []string{"cc0 1.0 universal"}},
+	{"Zlib", []string{"this software is provided 'as-is', without any express or implied warranty"}},
+}
+
+// classify returns the SPDX identifier of the license in the given text, or
+// [unknown].
+//
+// An explicit SPDX-License-Identifier tag takes precedence over the text
+// itself.
+func classify(text string) string {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		_, id, ok := strings.Cut(scanner.Text(), "SPDX-License-Identifier:")
+		if ok && strings.TrimSpace(id) != "" {
+			return strings.TrimSpace(id)
+		}
+	}
+
+	normalized := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	for _, rule := range rules {
+		if containsAll(normalized, rule.phrases) {
+			return rule.id
+		}
+	}
+	return unknown
+}
+
+func containsAll(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if !strings.Contains(s, substr) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLicenseFile reports whether the given file name is a license file, e.g.
+// LICENSE, LICENSE.md, or COPYING.txt.
+func isLicenseFile(name string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range licenseFiles {
+		if name == prefix || strings.HasPrefix(name, prefix+".") || strings.HasPrefix(name, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// allowed reports whether the given SPDX license expression is allowed.
+//
+// In expressions with alternatives, e.g. "MIT OR Apache-2.0", one of them
+// being allowed is enough, while in conjunctions, e.g. "MIT AND Zlib", all of
+// them must be allowed.
+func allowed(expr string, allow []string) bool {
+	tokens := strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr))
+	p := &parser{tokens: tokens, allow: allow}
+	ok := p.or()
+	return ok && p.pos == len(tokens)
+}
+
+// parser evaluates SPDX license expressions against an allowlist.
+type parser struct {
+	tokens []string
+	pos    int
+	allow  []string
+}
+
+func (p *parser) next() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) or() bool {
+	ok := p.and()
+	for strings.EqualFold(p.next(), "OR") {
+		p.pos++
+		ok = p.and() || ok
+	}
+	return ok
+}
+
+func (p *parser) and() bool {
+	ok := p.license()
+	for strings.EqualFold(p.next(), "AND") {
+		p.pos++
+		ok = p.license() && ok
+	}
+	return ok
+}
+
+func (p *parser) license() bool {
+	token := p.next()
+	p.pos++
+	switch token {
+	case "(":
+		ok := p.or()
+		if p.next() != ")" {
+			return false
+		}
+		p.pos++
+		return ok
+	case "", ")":
+		return false
+	}
+	// exceptions, e.g. "GPL-2.0 WITH Classpath-exception-2.0", only relax
+	// the license.
+	if strings.EqualFold(p.next(), "WITH") {
+		p.pos += 2
+	}
+	return slices.ContainsFunc(p.allow, func(allowed string) bool {
+		return strings.EqualFold(allowed, token)
+	})
+}
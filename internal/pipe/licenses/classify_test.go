@@ -0,0 +1,91 @@
+package licenses
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	for expected, text := range map[string]string{
+		"MIT": `MIT License
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal`,
+		"Apache-2.0": `
+                                 Apache License
+                           Version 2.0, January 2004
+                        http://www.apache.org/licenses/`,
+		"BSD-3-Clause": `Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+   * Neither the name of Google Inc. nor the names of its
+contributors may be used to endorse or promote products derived from`,
+		"BSD-2-Clause": `Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:`,
+		"ISC": `Permission to use, copy, modify, and/or distribute this software for any
+purpose with or without fee is hereby granted`,
+		"MPL-2.0": `Mozilla Public License Version 2.0
+==================================`,
+		"GPL-3.0": `                    GNU GENERAL PUBLIC LICENSE
+                       Version 3, 29 June 2007`,
+		"GPL-2.0": `                    GNU GENERAL PUBLIC LICENSE
+                       Version 2, June 1991`,
+		"LGPL-3.0": `                   GNU LESSER GENERAL PUBLIC LICENSE
+                       Version 3, 29 June 2007
+  This version of the GNU Lesser General Public License incorporates
+the terms and conditions of version 3 of the GNU General Public`,
+		"AGPL-3.0": `                    GNU AFFERO GENERAL PUBLIC LICENSE
+                       Version 3, 19 November 2007`,
+		"Unlicense": `This is free and unencumbered software released into the public domain.`,
+		"Apache-2.0 OR MIT": `// SPDX-License-Identifier: Apache-2.0 OR MIT
+Some custom text.`,
+		unknown: `All rights reserved.`,
+	} {
+		t.Run(expected, func(t *testing.T) {
+			require.Equal(t, expected, classify(text))
+		})
+	}
+}
+
+func TestIsLicenseFile(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"LICENSE":         true,
+		"LICENSE.md":      true,
+		"license.txt":     true,
+		"LICENCE":         true,
+		"COPYING":         true,
+		"LICENSE-MIT":     true,
+		"UNLICENSE":       true,
+		"NOTICE":          false,
+		"README.md":       false,
+		"licenses.go":     false,
+		"licensecheck.go": false,
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, expected, isLicenseFile(name))
+		})
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	allow := []string{"MIT", "apache-2.0", "BSD-3-Clause"}
+	for expr, expected := range map[string]bool{
+		"MIT":                                  true,
+		"Apache-2.0":                           true,
+		"GPL-3.0":                              false,
+		"MIT OR GPL-3.0":                       true,
+		"MIT AND GPL-3.0":                      false,
+		"MIT AND BSD-3-Clause":                 true,
+		"(MIT OR GPL-2.0) AND Apache-2.0":      true,
+		"(MIT OR GPL-2.0) AND GPL-3.0":         false,
+		"MIT OR (GPL-2.0 AND Apache-2.0)":      true,
+		"(MIT":                                 false,
+		"GPL-2.0 WITH Classpath-exception-2.0": false,
+		"Apache-2.0 WITH LLVM-exception":       true,
+		unknown:                                false,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			require.Equal(t, expected, allowed(expr, allow))
+		})
+	}
+}
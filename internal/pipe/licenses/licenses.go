@@ -0,0 +1,347 @@
+// Package licenses creates a report of the licenses of the third-party
+// dependencies of the project.
+package licenses
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const defaultNameTemplate = "{{ .ProjectName }}_{{ .Version }}_third_party_licenses.txt"
+
+// cmd represents a command executor.
+var cmd cmder = stdCmd{}
+
+// Pipe for the licenses report.
+type Pipe struct{}
+
+func (Pipe) String() string { return "third-party licenses" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.Licenses) || ctx.Config.Licenses.Enabled == ""
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	deps := []string{cmp.Or(ctx.Config.GoMod.GoBinary, "go")}
+	if len(ctx.Config.Licenses.Images) > 0 {
+		deps = append(deps, "syft")
+	}
+	return deps
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Licenses
+	if cfg.NameTemplate == "" {
+		cfg.NameTemplate = defaultNameTemplate
+	}
+	if cfg.Dir == "" {
+		cfg.Dir = ctx.Config.GoMod.Dir
+	}
+	if len(cfg.Packages) == 0 {
+		cfg.Packages = []string{"./..."}
+	}
+	return nil
+}
+
+// dependency is a third-party dependency and its license.
+type dependency struct {
+	Name    string
+	Version string
+	License string
+	Text    string
+	Notice  string
+}
+
+// Run creates the report, and checks the licenses against the allowlist.
+func (Pipe) Run(ctx *context.Context) error {
+	cfg := ctx.Config.Licenses
+	tpl := tmpl.New(ctx)
+	enabled, err := tpl.Bool(cfg.Enabled)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return pipe.Skip("configuration is disabled")
+	}
+	if err := tpl.ApplyAll(&cfg.NameTemplate, &cfg.Dir); err != nil {
+		return err
+	}
+	images, err := tpl.Slice(cfg.Images, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+
+	modules, err := goModules(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	var report bytes.Buffer
+	writeHeader(&report, ctx)
+	writeModules(&report, modules)
+	deps := modules
+
+	for _, image := range images {
+		pkgs, err := imagePackages(ctx, image)
+		if err != nil {
+			return err
+		}
+		writeImage(&report, image, pkgs)
+		deps = append(deps, pkgs...)
+	}
+
+	path := filepath.Join(ctx.Config.Dist, cfg.NameTemplate)
+	if err := os.WriteFile(path, report.Bytes(), 0o644); err != nil {
+		return err
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.LicenseReport,
+		Name: cfg.NameTemplate,
+		Path: path,
+	})
+
+	if len(cfg.Allow) == 0 {
+		return nil
+	}
+	var forbidden []string
+	for _, dep := range deps {
+		if !allowed(dep.License, cfg.Allow) {
+			forbidden = append(forbidden, fmt.Sprintf("%s %s (%s)", dep.Name, dep.Version, dep.License))
+		}
+	}
+	if len(forbidden) > 0 {
+		return fmt.Errorf("licenses: found dependencies with licenses that are not allowed:\n  %s", strings.Join(forbidden, "\n  "))
+	}
+	return nil
+}
+
+// goModules returns the third-party modules the given packages depend on,
+// sorted by path.
+func goModules(ctx *context.Context, cfg config.Licenses) ([]dependency, error) {
+	args := []string{"list", "-deps", "-json=Module,Standard"}
+	if ctx.Config.GoMod.Mod != "" {
+		args = append(args, "-mod="+ctx.Config.GoMod.Mod)
+	}
+	args = append(args, cfg.Packages...)
+	out, err := cmd.Exec(ctx, cfg.Dir, cmp.Or(ctx.Config.GoMod.GoBinary, "go"), args...)
+	if err != nil {
+		return nil, fmt.Errorf("licenses: failed to list go dependencies: %w: %s", err, string(out))
+	}
+
+	type module struct {
+		Path    string
+		Version string
+		Dir     string
+		Main    bool
+		Replace *module
+	}
+	seen := map[string]bool{}
+	var deps []dependency
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var pkg struct {
+			Standard bool
+			Module   *module
+		}
+		err := dec.Decode(&pkg)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("licenses: failed to parse go dependencies: %w", err)
+		}
+		mod := pkg.Module
+		if pkg.Standard || mod == nil || mod.Main || seen[mod.Path] || ignored(mod.Path, cfg.Ignore) {
+			continue
+		}
+		seen[mod.Path] = true
+
+		dir, version := mod.Dir, mod.Version
+		if mod.Replace != nil {
+			dir, version = mod.Replace.Dir, cmp.Or(mod.Replace.Version, version)
+		}
+		dep, err := moduleDependency(mod.Path, version, dir)
+		if err != nil {
+			return nil, err
+		}
+		if dep.License == unknown {
+			log.WithField("module", mod.Path).Warn("could not identify license")
+		}
+		deps = append(deps, dep)
+	}
+	slices.SortFunc(deps, func(a, b dependency) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return deps, nil
+}
+
+// moduleDependency reads the license and notice files in the root of the
+// given module directory.
+func moduleDependency(path, version, dir string) (dependency, error) {
+	dep := dependency{
+		Name:    path,
+		Version: version,
+		License: unknown,
+	}
+	if dir == "" {
+		return dep, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return dep, fmt.Errorf("licenses: failed to read module %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		isNotice := strings.EqualFold(strings.TrimSuffix(name, filepath.Ext(name)), "notice")
+		if !isNotice && (dep.Text != "" || !isLicenseFile(name)) {
+			continue
+		}
+		bts, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return dep, fmt.Errorf("licenses: failed to read license of %s: %w", path, err)
+		}
+		if isNotice {
+			dep.Notice = string(bts)
+			continue
+		}
+		dep.Text = string(bts)
+		dep.License = classify(dep.Text)
+	}
+	return dep, nil
+}
+
+// imagePackages returns the packages in the given image, and their
+// licenses, as cataloged by syft.
+func imagePackages(ctx *context.Context, image string) ([]dependency, error) {
+	log.WithField("image", image).Info("cataloging image")
+	tmp, err := os.MkdirTemp("", "goreleaser-licenses-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "sbom.json")
+	out, err := cmd.Exec(ctx, "", "syft", "scan", image, "--quiet", "--output", "spdx-json="+path)
+	if err != nil {
+		return nil, fmt.Errorf("licenses: failed to catalog image %s: %w: %s", image, err, string(out))
+	}
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("licenses: failed to read sbom of image %s: %w", image, err)
+	}
+	var sbom struct {
+		Packages []struct {
+			Name             string `json:"name"`
+			VersionInfo      string `json:"versionInfo"`
+			LicenseConcluded string `json:"licenseConcluded"`
+			LicenseDeclared  string `json:"licenseDeclared"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(bts, &sbom); err != nil {
+		return nil, fmt.Errorf("licenses: failed to parse sbom of image %s: %w", image, err)
+	}
+
+	var deps []dependency
+	for _, pkg := range sbom.Packages {
+		if ignored(pkg.Name, ctx.Config.Licenses.Ignore) {
+			continue
+		}
+		license := unknown
+		for _, l := range []string{pkg.LicenseConcluded, pkg.LicenseDeclared} {
+			if l != "" && l != "NOASSERTION" && l != "NONE" {
+				license = l
+				break
+			}
+		}
+		deps = append(deps, dependency{
+			Name:    pkg.Name,
+			Version: pkg.VersionInfo,
+			License: license,
+		})
+	}
+	return deps, nil
+}
+
+// ignored reports whether the given module or package is ignored, either
+// by its exact name or by one of its parent paths.
+func ignored(name string, ignore []string) bool {
+	for _, i := range ignore {
+		if name == i || strings.HasPrefix(name, strings.TrimSuffix(i, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+const separator = "================================================================================"
+
+func writeHeader(w io.Writer, ctx *context.Context) {
+	fmt.Fprintf(w, "Third-party licenses of %s %s\n\n", ctx.Config.ProjectName, ctx.Version)
+}
+
+func writeModules(w io.Writer, deps []dependency) {
+	for _, dep := range deps {
+		fmt.Fprintf(w, "%s\n%s %s\nLicense: %s\n%s\n\n", separator, dep.Name, dep.Version, dep.License, separator)
+		if dep.Text != "" {
+			fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(dep.Text))
+		}
+		if dep.Notice != "" {
+			fmt.Fprintf(w, "NOTICE:\n\n%s\n\n", strings.TrimSpace(dep.Notice))
+		}
+	}
+}
+
+func writeImage(w io.Writer, image string, deps []dependency) {
+	fmt.Fprintf(w, "%s\nImage: %s\n%s\n\n", separator, image, separator)
+	for _, dep := range deps {
+		fmt.Fprintf(w, "%s %s: %s\n", dep.Name, dep.Version, dep.License)
+	}
+	fmt.Fprintln(w)
+}
+
+// cmder is a special interface to execute external commands.
+//
+// The intention is to be used to wrap the standard exec and provide the
+// ability to create a fake one for testing.
+type cmder interface {
+	// Exec executes a command in the given directory.
+	Exec(*context.Context, string, string, ...string) ([]byte, error)
+}
+
+// stdCmd uses [execx.Command].
+type stdCmd struct{}
+
+var _ cmder = &stdCmd{}
+
+func (stdCmd) Exec(ctx *context.Context, dir, name string, args ...string) ([]byte, error) {
+	c := execx.Command(ctx, name, args...)
+	c.Dir = dir
+	c.Env = append(c.Env, ctx.Config.GoMod.Env...)
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		return stderr.Bytes(), err
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,276 @@
+package licenses
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+const mit = `Permission is hereby granted, free of charge, to any person obtaining a copy`
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.Equal(t, []string{"go"}, Pipe{}.Dependencies(ctx))
+
+	ctx = testctx.WrapWithCfg(t.Context(), config.Project{
+		GoMod:    config.GoMod{GoBinary: "go1.26"},
+		Licenses: config.Licenses{Images: []string{"alpine"}},
+	})
+	require.Equal(t, []string{"go1.26", "syft"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Licenses: config.Licenses{Enabled: "true"},
+		}, testctx.Skip(skips.Licenses))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Licenses: config.Licenses{Enabled: "true"},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		GoMod:    config.GoMod{Dir: "./app"},
+		Licenses: config.Licenses{Enabled: "true"},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, config.Licenses{
+		Enabled:      "true",
+		NameTemplate: defaultNameTemplate,
+		Dir:          "./app",
+		Packages:     []string{"./..."},
+	}, ctx.Config.Licenses)
+}
+
+func TestRun(t *testing.T) {
+	testlib.CheckPath(t, "go")
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.22\n\n" +
+			"require (\n\texample.com/mit v1.0.0\n\texample.com/apache v1.0.0\n)\n\n" +
+			"replace example.com/mit => ./mit\n\nreplace example.com/apache => ./apache\n",
+		"main.go":           "package main\n\nimport (\n\t_ \"example.com/apache\"\n\t_ \"example.com/mit\"\n)\n\nfunc main() {}\n",
+		"LICENSE":           "Proprietary",
+		"mit/go.mod":        "module example.com/mit\n\ngo 1.22\n",
+		"mit/mit.go":        "package mit\n",
+		"mit/LICENSE":       mit,
+		"apache/go.mod":     "module example.com/apache\n\ngo 1.22\n",
+		"apache/apache.go":  "package apache\n",
+		"apache/LICENSE.md": "Apache License\nVersion 2.0, January 2004",
+		"apache/NOTICE":     "Copyright Apache folks",
+	})
+
+	ctx := makeContext(t, config.Licenses{
+		Enabled: "{{ .Env.LICENSES }}",
+		Dir:     dir,
+		Allow:   []string{"MIT", "Apache-2.0"},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	reports := ctx.Artifacts.Filter(artifact.ByType(artifact.LicenseReport)).List()
+	require.Len(t, reports, 1)
+	require.Equal(t, "proj_1.0.0_third_party_licenses.txt", reports[0].Name)
+	bts, err := os.ReadFile(reports[0].Path)
+	require.NoError(t, err)
+	require.Equal(t, `Third-party licenses of proj 1.0.0
+
+`+separator+`
+example.com/apache v1.0.0
+License: Apache-2.0
+`+separator+`
+
+Apache License
+Version 2.0, January 2004
+
+NOTICE:
+
+Copyright Apache folks
+
+`+separator+`
+example.com/mit v1.0.0
+License: MIT
+`+separator+`
+
+`+mit+`
+
+`, string(bts))
+
+	t.Run("not allowed", func(t *testing.T) {
+		ctx := makeContext(t, config.Licenses{
+			Enabled: "true",
+			Dir:     dir,
+			Allow:   []string{"MIT"},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Run(ctx), "licenses: found dependencies with licenses that are not allowed:\n  example.com/apache v1.0.0 (Apache-2.0)")
+		// the report is still created, so it can be inspected.
+		require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.LicenseReport)).List(), 1)
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		ctx := makeContext(t, config.Licenses{
+			Enabled: "true",
+			Dir:     dir,
+			Allow:   []string{"MIT"},
+			Ignore:  []string{"example.com/apache"},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Run(ctx))
+	})
+}
+
+func TestRunImages(t *testing.T) {
+	calls := fakeCmds(t, func(dir, name string, args ...string) ([]byte, error) {
+		if name == "go" {
+			return []byte(`{"Module":{"Path":"example.com/app","Main":true}}
+{"Standard":true}
+{"Module":{"Path":"example.com/dep","Version":"v1.0.0"}}`), nil
+		}
+		out := args[slices.Index(args, "--output")+1][len("spdx-json="):]
+		return nil, os.WriteFile(out, []byte(`{"packages": [
+			{"name": "musl", "versionInfo": "1.2.5", "licenseConcluded": "NOASSERTION", "licenseDeclared": "MIT"},
+			{"name": "busybox", "versionInfo": "1.37.0", "licenseDeclared": "GPL-2.0-only"},
+			{"name": "ignored", "versionInfo": "1.0.0"}
+		]}`), 0o644)
+	})
+	ctx := makeContext(t, config.Licenses{
+		Enabled: "true",
+		Images:  []string{"alpine:{{ .Env.ALPINE }}", ""},
+		Ignore:  []string{"ignored"},
+		Allow:   []string{"MIT"},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Run(ctx), "licenses: found dependencies with licenses that are not allowed:\n"+
+		"  example.com/dep v1.0.0 (Unknown)\n"+
+		"  busybox 1.37.0 (GPL-2.0-only)")
+
+	require.Len(t, *calls, 2)
+	require.Equal(t, []string{"go", "list", "-deps", "-json=Module,Standard", "./..."}, (*calls)[0][1:])
+	require.Equal(t, []string{"syft", "scan", "alpine:3.22", "--quiet", "--output"}, (*calls)[1][1:6])
+
+	bts, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "proj_1.0.0_third_party_licenses.txt"))
+	require.NoError(t, err)
+	require.Contains(t, string(bts), separator+"\nImage: alpine:3.22\n"+separator+"\n\nmusl 1.2.5: MIT\nbusybox 1.37.0: GPL-2.0-only\n")
+}
+
+func TestRunSkip(t *testing.T) {
+	ctx := makeContext(t, config.Licenses{Enabled: "false"})
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Run("bad enabled template", func(t *testing.T) {
+		ctx := makeContext(t, config.Licenses{Enabled: "{{ .Nope }}"})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+	t.Run("bad name template", func(t *testing.T) {
+		ctx := makeContext(t, config.Licenses{Enabled: "true", NameTemplate: "{{ .Nope }}"})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+	t.Run("bad images template", func(t *testing.T) {
+		ctx := makeContext(t, config.Licenses{Enabled: "true", Images: []string{"{{ .Nope }}"}})
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+	t.Run("go list fails", func(t *testing.T) {
+		fakeCmds(t, func(string, string, ...string) ([]byte, error) {
+			return []byte("no go files"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.Licenses{Enabled: "true"})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Run(ctx), "licenses: failed to list go dependencies: exit status 1: no go files")
+	})
+	t.Run("invalid go list output", func(t *testing.T) {
+		fakeCmds(t, func(string, string, ...string) ([]byte, error) {
+			return []byte("{"), nil
+		})
+		ctx := makeContext(t, config.Licenses{Enabled: "true"})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.ErrorContains(t, Pipe{}.Run(ctx), "licenses: failed to parse go dependencies")
+	})
+	t.Run("syft fails", func(t *testing.T) {
+		fakeCmds(t, func(_, name string, _ ...string) ([]byte, error) {
+			if name == "go" {
+				return nil, nil
+			}
+			return []byte("no such image"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.Licenses{Enabled: "true", Images: []string{"nope"}})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Run(ctx), "licenses: failed to catalog image nope: exit status 1: no such image")
+	})
+}
+
+func makeContext(tb testing.TB, cfg config.Licenses) *context.Context {
+	tb.Helper()
+	return testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "proj",
+		Dist:        tb.TempDir(),
+		Licenses:    cfg,
+	},
+		testctx.WithVersion("1.0.0"),
+		testctx.WithEnv(map[string]string{
+			"LICENSES": "true",
+			"ALPINE":   "3.22",
+		}),
+	)
+}
+
+func writeFiles(tb testing.TB, dir string, files map[string]string) {
+	tb.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(tb, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+// fakeCmds replaces the command executor, recording the calls made to it,
+// with the directory and the command name as the first items.
+func fakeCmds(tb testing.TB, fn func(dir, name string, args ...string) ([]byte, error)) *[][]string {
+	tb.Helper()
+	var calls [][]string
+	cmd = fakeCmd{execFn: func(dir, name string, args ...string) ([]byte, error) {
+		calls = append(calls, append([]string{dir, name}, args...))
+		return fn(dir, name, args...)
+	}}
+	tb.Cleanup(func() {
+		cmd = stdCmd{}
+	})
+	return &calls
+}
+
+type fakeCmd struct {
+	execFn func(dir, name string, args ...string) ([]byte, error)
+}
+
+var _ cmder = fakeCmd{}
+
+func (f fakeCmd) Exec(_ *context.Context, dir, name string, args ...string) ([]byte, error) {
+	return f.execFn(dir, name, args...)
+}
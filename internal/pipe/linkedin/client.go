@@ -21,6 +21,7 @@ var ErrLinkedinForbidden = errors.New("forbidden. please check your permissions"
 type oauthClientConfig struct {
 	Context     *context.Context
 	AccessToken string
+	HTTPClient  *http.Client
 }
 
 type client struct {
@@ -57,7 +58,12 @@ func createLinkedInClient(cfg oauthClientConfig) (client, error) {
 
 	config := oauth2.Config{}
 
-	c := config.Client(cfg.Context, &oauth2.Token{
+	var ctx stdctx.Context = cfg.Context
+	if cfg.HTTPClient != nil {
+		ctx = stdctx.WithValue(ctx, oauth2.HTTPClient, cfg.HTTPClient)
+	}
+
+	c := config.Client(ctx, &oauth2.Token{
 		AccessToken: cfg.AccessToken,
 	})
 
@@ -3,6 +3,7 @@ package linkedin
 import (
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -30,8 +31,13 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
+// Preview returns the message that would be shared.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	return tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.LinkedIn.MessageTemplate)
+}
+
 func (p Pipe) Announce(ctx *context.Context) error {
-	message, err := tmpl.New(ctx).Apply(ctx.Config.Announce.LinkedIn.MessageTemplate)
+	message, err := p.Preview(ctx)
 	if err != nil {
 		return err
 	}
@@ -41,9 +47,15 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return err
 	}
 
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
 	c, err := createLinkedInClient(oauthClientConfig{
 		Context:     ctx,
 		AccessToken: cfg.AccessToken,
+		HTTPClient:  httpClient,
 	})
 	if err != nil {
 		return err
@@ -25,6 +25,18 @@ func TestAnnounceDisabled(t *testing.T) {
 	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "LINKEDIN_ACCESS_TOKEN" should not be empty`)
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	msg, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0", msg)
+}
+
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
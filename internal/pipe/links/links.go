@@ -0,0 +1,250 @@
+// Package links records the stages of a release as in-toto links, and
+// writes them as a signed attestation bundle.
+package links
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/intoto"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/redact"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const defaultNameTemplate = "{{ .ProjectName }}_{{ .Version }}.links.intoto.jsonl"
+
+// cmd represents a command executor.
+var cmd execx.Cmder = execx.Std{}
+
+// Step starts recording a stage of the release, finishing the previous one.
+type Step struct {
+	Name string
+}
+
+func (s Step) String() string { return "in-toto " + s.Name + " link" }
+func (Step) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.InToto) || ctx.Config.InToto.Enabled == ""
+}
+
+// Run finishes the current stage, if any, and starts a new one, with all the
+// files created so far as its materials.
+func (s Step) Run(ctx *context.Context) error {
+	enabled, err := tmpl.New(ctx).Bool(ctx.Config.InToto.Enabled)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return pipe.Skip("configuration is disabled")
+	}
+	files, err := snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	materials := files
+	if ctx.InToto().Current() == nil {
+		materials = append(source(ctx), files...)
+	}
+	finish(ctx, files)
+	ctx.InToto().Start(s.Name, materials)
+	return nil
+}
+
+// Pipe finishes the last stage, and writes the signed links bundle.
+type Pipe struct{}
+
+func (Pipe) String() string { return "in-toto links" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.InToto) || ctx.Config.InToto.Enabled == ""
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	if ctx.Config.InToto.Enabled == "" {
+		return nil
+	}
+	return []string{"cosign"}
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	if ctx.Config.InToto.NameTemplate == "" {
+		ctx.Config.InToto.NameTemplate = defaultNameTemplate
+	}
+	return nil
+}
+
+// Run writes the links bundle, one statement per stage, and signs it, adding
+// both to the artifacts to be published.
+func (Pipe) Run(ctx *context.Context) error {
+	cfg := ctx.Config.InToto
+	tpl := tmpl.New(ctx)
+	enabled, err := tpl.Bool(cfg.Enabled)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return pipe.Skip("configuration is disabled")
+	}
+	if ctx.InToto().Current() == nil {
+		return pipe.Skip("no stages were recorded")
+	}
+	if err := tpl.ApplyAll(&cfg.NameTemplate, &cfg.Key); err != nil {
+		return err
+	}
+	flags, err := tpl.Slice(cfg.Flags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+
+	files, err := snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	finish(ctx, files)
+
+	env := ctx.Env.Strings()
+	command := redactAll(os.Args, env)
+	var lines bytes.Buffer
+	for _, stage := range ctx.InToto().Stages() {
+		subject := stage.Products
+		if len(subject) == 0 {
+			// stages that only use files, like publish, attest them.
+			subject = stage.Materials
+		}
+		link := intoto.Link{
+			Name:      stage.Name,
+			Command:   command,
+			Materials: stage.Materials,
+		}
+		if len(stage.Commands) > 0 {
+			commands := make([][]string, 0, len(stage.Commands))
+			for _, c := range stage.Commands {
+				commands = append(commands, redactAll(c, env))
+			}
+			link.Byproducts = map[string]any{"commands": commands}
+		}
+		bts, err := json.Marshal(intoto.Statement{
+			Type:          intoto.StatementType,
+			Subject:       subject,
+			PredicateType: intoto.LinkPredicateType,
+			Predicate:     link,
+		})
+		if err != nil {
+			return err
+		}
+		lines.Write(bts)
+		lines.WriteByte('\n')
+	}
+
+	path := filepath.Join(ctx.Config.Dist, cfg.NameTemplate)
+	log.WithField("file", path).Info("writing in-toto links")
+	if err := os.WriteFile(path, lines.Bytes(), 0o644); err != nil {
+		return err
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.UploadableFile,
+		Name: cfg.NameTemplate,
+		Path: path,
+	})
+
+	if skips.Any(ctx, skips.Sign) {
+		log.Warn("signing is skipped, in-toto links won't be signed")
+		return nil
+	}
+	bundle := path + ".sigstore.json"
+	args := []string{
+		"sign-blob", "--yes",
+		"--bundle", bundle,
+	}
+	if cfg.Key != "" {
+		args = append(args, "--key", cfg.Key)
+	}
+	args = append(args, flags...)
+	args = append(args, path)
+	if out, err := cmd.Exec(ctx, "cosign", args...); err != nil {
+		return fmt.Errorf("intoto: failed to sign %s: %w: %s", cfg.NameTemplate, err, string(out))
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Signature,
+		Name: filepath.Base(bundle),
+		Path: bundle,
+	})
+	return nil
+}
+
+// finish sets the products of the current stage: the given files that were
+// created or changed during it.
+func finish(ctx *context.Context, files []intoto.ResourceDescriptor) {
+	stage := ctx.InToto().Current()
+	if stage == nil {
+		return
+	}
+	materials := map[string]string{}
+	for _, m := range stage.Materials {
+		materials[m.Name] = m.Digest["sha256"]
+	}
+	stage.Products = nil
+	for _, f := range files {
+		if digest, ok := materials[f.Name]; ok && digest == f.Digest["sha256"] {
+			continue
+		}
+		stage.Products = append(stage.Products, f)
+	}
+}
+
+// snapshot returns all the artifacts that are files, with their digests.
+func snapshot(ctx *context.Context) ([]intoto.ResourceDescriptor, error) {
+	var result []intoto.ResourceDescriptor
+	seen := map[string]bool{}
+	for _, art := range ctx.Artifacts.List() {
+		name := filepath.ToSlash(art.Path)
+		if seen[name] {
+			continue
+		}
+		if st, err := os.Stat(art.Path); err != nil || !st.Mode().IsRegular() {
+			continue
+		}
+		seen[name] = true
+		sum, err := art.Checksum("sha256")
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, intoto.ResourceDescriptor{
+			Name:   name,
+			Digest: map[string]string{"sha256": sum},
+		})
+	}
+	return result, nil
+}
+
+// source returns the source repository and commit, if known.
+func source(ctx *context.Context) []intoto.ResourceDescriptor {
+	if ctx.Git.URL == "" || ctx.Git.FullCommit == "" {
+		return nil
+	}
+	uri := "git+" + ctx.Git.URL
+	if ctx.Git.CurrentTag != "" {
+		uri += "@refs/tags/" + ctx.Git.CurrentTag
+	}
+	return []intoto.ResourceDescriptor{{
+		URI:    uri,
+		Digest: map[string]string{"gitCommit": ctx.Git.FullCommit},
+	}}
+}
+
+func redactAll(args, env []string) []string {
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		result = append(result, redact.String(arg, env))
+	}
+	return result
+}
@@ -0,0 +1,277 @@
+package links
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/intoto"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+	require.Equal(t, "in-toto build link", Step{Name: "build"}.String())
+}
+
+func TestDependencies(t *testing.T) {
+	require.Empty(t, Pipe{}.Dependencies(testctx.Wrap(t.Context())))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		InToto: config.InToto{Enabled: "true"},
+	})
+	require.Equal(t, []string{"cosign"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		require.True(t, Pipe{}.Skip(ctx))
+		require.True(t, Step{}.Skip(ctx))
+	})
+	t.Run("skip intoto", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			InToto: config.InToto{Enabled: "true"},
+		}, testctx.Skip(skips.InToto))
+		require.True(t, Pipe{}.Skip(ctx))
+		require.True(t, Step{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			InToto: config.InToto{Enabled: "true"},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+		require.False(t, Step{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		InToto: config.InToto{Enabled: "true"},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, defaultNameTemplate, ctx.Config.InToto.NameTemplate)
+}
+
+func TestRun(t *testing.T) {
+	calls := fakeCosign(t, nil)
+	ctx := makeContext(t, config.InToto{
+		Key:   "{{ .Env.COSIGN_KEY }}",
+		Flags: []string{"--tlog-upload=false"},
+	})
+
+	require.NoError(t, Step{Name: "build"}.Run(ctx))
+	ctx.InToto().Record([]string{"go", "build", "-ldflags=-X main.token=supersecret"})
+	bin := addFile(t, ctx, artifact.Binary, "foo", "binary")
+
+	require.NoError(t, Step{Name: "archive"}.Run(ctx))
+	archive := addFile(t, ctx, artifact.UploadableArchive, "foo.tar.gz", "archive")
+	// modified in place, e.g. by a binary processor.
+	require.NoError(t, os.WriteFile(bin.Path, []byte("changed"), 0o644))
+
+	require.NoError(t, Step{Name: "sign"}.Run(ctx))
+	ctx.InToto().Record([]string{"gpg", "--detach-sig", archive.Path})
+	sig := addFile(t, ctx, artifact.Signature, "foo.tar.gz.sig", "signature")
+
+	require.NoError(t, Step{Name: "publish"}.Run(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	path := filepath.Join(ctx.Config.Dist, "foo_1.2.3.links.intoto.jsonl")
+	require.Equal(t, [][]string{{
+		"cosign", "sign-blob", "--yes",
+		"--bundle", path + ".sigstore.json",
+		"--key", "cosign.key",
+		"--tlog-upload=false",
+		path,
+	}}, *calls)
+
+	statements := readStatements(t, path)
+	require.Len(t, statements, 4)
+	for i, name := range []string{"build", "archive", "sign", "publish"} {
+		require.Equal(t, intoto.StatementType, statements[i].Type)
+		require.Equal(t, intoto.LinkPredicateType, statements[i].PredicateType)
+		require.Equal(t, name, statements[i].Predicate.Name)
+		require.NotEmpty(t, statements[i].Predicate.Command)
+	}
+
+	source := intoto.ResourceDescriptor{
+		URI:    "git+https://github.com/foo/bar@refs/tags/v1.2.3",
+		Digest: map[string]string{"gitCommit": "deadbeef"},
+	}
+	binary := descriptor(bin.Path, "binary")
+	changed := descriptor(bin.Path, "changed")
+
+	build := statements[0]
+	require.Equal(t, []intoto.ResourceDescriptor{source}, build.Predicate.Materials)
+	require.Equal(t, []intoto.ResourceDescriptor{binary}, build.Subject)
+	require.Equal(t, map[string]any{
+		"commands": []any{[]any{"go", "build", "-ldflags=-X main.token=$SECRET_TOKEN"}},
+	}, build.Predicate.Byproducts)
+
+	arch := statements[1]
+	require.Equal(t, []intoto.ResourceDescriptor{binary}, arch.Predicate.Materials)
+	require.Equal(t, []intoto.ResourceDescriptor{changed, descriptor(archive.Path, "archive")}, arch.Subject)
+	require.Empty(t, arch.Predicate.Byproducts)
+
+	sign := statements[2]
+	require.Equal(t, []intoto.ResourceDescriptor{changed, descriptor(archive.Path, "archive")}, sign.Predicate.Materials)
+	require.Equal(t, []intoto.ResourceDescriptor{descriptor(sig.Path, "signature")}, sign.Subject)
+	require.Equal(t, map[string]any{
+		"commands": []any{[]any{"gpg", "--detach-sig", filepath.ToSlash(archive.Path)}},
+	}, sign.Predicate.Byproducts)
+
+	// publish creates no files, so its materials are the subject.
+	publish := statements[3]
+	require.Equal(t, publish.Predicate.Materials, publish.Subject)
+	require.Len(t, publish.Subject, 3)
+
+	links := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableFile)).List()
+	require.Len(t, links, 1)
+	require.Equal(t, "foo_1.2.3.links.intoto.jsonl", links[0].Name)
+	require.Equal(t, path, links[0].Path)
+
+	bundles := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+	require.Len(t, bundles, 2)
+	require.Equal(t, "foo_1.2.3.links.intoto.jsonl.sigstore.json", bundles[1].Name)
+	require.Equal(t, path+".sigstore.json", bundles[1].Path)
+}
+
+func TestRunSkipSign(t *testing.T) {
+	calls := fakeCosign(t, nil)
+	ctx := makeContext(t, config.InToto{})
+	ctx.Skips[string(skips.Sign)] = true
+	require.NoError(t, Step{Name: "build"}.Run(ctx))
+	addFile(t, ctx, artifact.Binary, "foo", "binary")
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Empty(t, *calls)
+	require.Len(t, readStatements(t, filepath.Join(ctx.Config.Dist, "foo_1.2.3.links.intoto.jsonl")), 1)
+	require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableFile)).List(), 1)
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List())
+}
+
+func TestRunErrors(t *testing.T) {
+	for name, cfg := range map[string]config.InToto{
+		"name template": {NameTemplate: "{{ .Nope }}"},
+		"key":           {Key: "{{ .Nope }}"},
+		"flags":         {Flags: []string{"{{ .Nope }}"}},
+	} {
+		t.Run("bad "+name+" template", func(t *testing.T) {
+			fakeCosign(t, nil)
+			ctx := makeContext(t, cfg)
+			require.NoError(t, Step{Name: "build"}.Run(ctx))
+			testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+		})
+	}
+
+	t.Run("bad enabled template", func(t *testing.T) {
+		ctx := makeContext(t, config.InToto{})
+		ctx.Config.InToto.Enabled = "{{ .Nope }}"
+		testlib.RequireTemplateError(t, Step{Name: "build"}.Run(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		calls := fakeCosign(t, nil)
+		ctx := makeContext(t, config.InToto{})
+		ctx.Config.InToto.Enabled = "{{ .IsSnapshot }}"
+		testlib.AssertSkipped(t, Step{Name: "build"}.Run(ctx))
+		require.Empty(t, ctx.InToto().Stages())
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+		require.Empty(t, *calls)
+	})
+
+	t.Run("no stages", func(t *testing.T) {
+		ctx := makeContext(t, config.InToto{})
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("cosign fails", func(t *testing.T) {
+		fakeCosign(t, func([]string) ([]byte, error) {
+			return []byte("no key"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.InToto{})
+		require.NoError(t, Step{Name: "build"}.Run(ctx))
+		err := Pipe{}.Run(ctx)
+		require.ErrorContains(t, err, "failed to sign foo_1.2.3.links.intoto.jsonl")
+		require.ErrorContains(t, err, "no key")
+	})
+}
+
+func makeContext(tb testing.TB, cfg config.InToto) *context.Context {
+	tb.Helper()
+	cfg.Enabled = "true"
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "foo",
+		Dist:        tb.TempDir(),
+		InToto:      cfg,
+	},
+		testctx.WithVersion("1.2.3"),
+		testctx.WithGitInfo(context.GitInfo{
+			URL:        "https://github.com/foo/bar",
+			FullCommit: "deadbeef",
+		}),
+		testctx.WithCurrentTag("v1.2.3"),
+		testctx.WithEnv(map[string]string{
+			"COSIGN_KEY":   "cosign.key",
+			"SECRET_TOKEN": "supersecret",
+		}),
+	)
+	require.NoError(tb, Pipe{}.Default(ctx))
+	return ctx
+}
+
+func addFile(tb testing.TB, ctx *context.Context, typ artifact.Type, name, content string) *artifact.Artifact {
+	tb.Helper()
+	art := &artifact.Artifact{
+		Type: typ,
+		Name: name,
+		Path: filepath.Join(ctx.Config.Dist, name),
+	}
+	require.NoError(tb, os.WriteFile(art.Path, []byte(content), 0o644))
+	ctx.Artifacts.Add(art)
+	return art
+}
+
+func descriptor(path, content string) intoto.ResourceDescriptor {
+	sum := sha256.Sum256([]byte(content))
+	return intoto.ResourceDescriptor{
+		Name:   filepath.ToSlash(path),
+		Digest: map[string]string{"sha256": hex.EncodeToString(sum[:])},
+	}
+}
+
+func readStatements(tb testing.TB, path string) []intoto.Statement {
+	tb.Helper()
+	f, err := os.Open(path)
+	require.NoError(tb, err)
+	tb.Cleanup(func() { _ = f.Close() })
+	var result []intoto.Statement
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s intoto.Statement
+		require.NoError(tb, json.Unmarshal(scanner.Bytes(), &s))
+		result = append(result, s)
+	}
+	require.NoError(tb, scanner.Err())
+	return result
+}
+
+// fakeCosign replaces the command executor, recording the calls made to it,
+// with the command name as the first item.
+func fakeCosign(tb testing.TB, fn func(args []string) ([]byte, error)) *[][]string {
+	tb.Helper()
+	fake := &testlib.FakeCosign{Fn: fn}
+	testlib.Set[execx.Cmder](tb, &cmd, fake)
+	return &fake.Calls
+}
@@ -28,7 +28,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
-const defaultNameTemplate = `{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}.run`
+const defaultNameTemplate = `{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}{{ if eq .Os "windows" }}.exe{{ else }}.run{{ end }}`
 
 // Pipe for makeself packaging.
 type Pipe struct{}
@@ -147,6 +147,7 @@ func create(ctx *context.Context, cfg config.Makeself, plat string, binaries []*
 	license := cfg.License
 	script := cfg.Script
 	compression := cfg.Compression
+	sfxModule := cfg.SFXModule
 	extraArgs := cfg.ExtraArgs
 	keywords := cfg.Keywords
 
@@ -159,6 +160,7 @@ func create(ctx *context.Context, cfg config.Makeself, plat string, binaries []*
 		&license,
 		&script,
 		&compression,
+		&sfxModule,
 	); err != nil {
 		return err
 	}
@@ -184,6 +186,9 @@ func create(ctx *context.Context, cfg config.Makeself, plat string, binaries []*
 	if script == "" {
 		return errors.New("script is required")
 	}
+	if binary.Goos == "windows" && sfxModule == "" {
+		return errors.New("sfx_module is required to create windows installers")
+	}
 
 	dir, err := setupContext(ctx, cfg, tpl, plat, lsm, script, binaries)
 	if err != nil {
@@ -191,10 +196,86 @@ func create(ctx *context.Context, cfg config.Makeself, plat string, binaries []*
 	}
 
 	log := log.WithField("package", filename).WithField("dir", dir)
-	log.Info("creating makeself package")
+	path := filepath.Join(dir, filename)
+
+	if binary.Goos == "windows" {
+		log.Info("creating self-extracting installer")
+		if err := createSFX(ctx, cfg, dir, path, name, filepath.Base(script), sfxModule); err != nil {
+			return err
+		}
+		ctx.Artifacts.Add(makeArtifact(cfg, binary, filename, path))
+		return nil
+	}
 
+	log.Info("creating makeself package")
 	arg := makeArg(name, filename, compression, "./"+filepath.Base(script), extraArgs)
-	cmd := exec.CommandContext(ctx, "makeself", arg...)
+	if err := run(ctx, cfg, dir, "could not create makeself package", "makeself", arg...); err != nil {
+		return err
+	}
+
+	ctx.Artifacts.Add(makeArtifact(cfg, binary, filename, path))
+	return nil
+}
+
+// createSFX creates a Windows self-extracting installer, made of the given 7z
+// SFX module, its configuration, and a 7z archive with the contents of dir.
+// Once extracted, the installer runs the given script.
+func createSFX(ctx *context.Context, cfg config.Makeself, dir, path, name, script, sfxModule string) error {
+	archive := dir + ".7z"
+	if err := os.Remove(archive); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := run(ctx, cfg, dir, "could not create 7z archive", "7z", "a", "-t7z", "-y", archive, "."); err != nil {
+		return err
+	}
+	defer os.Remove(archive)
+
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create installer: %w", err)
+	}
+	defer out.Close()
+
+	if err := copyFile(out, sfxModule); err != nil {
+		return fmt.Errorf("failed to copy sfx module: %w", err)
+	}
+	if _, err := io.WriteString(out, sfxConfig(name, script)); err != nil {
+		return fmt.Errorf("failed to write sfx config: %w", err)
+	}
+	if err := copyFile(out, archive); err != nil {
+		return fmt.Errorf("failed to copy 7z archive: %w", err)
+	}
+	return out.Close()
+}
+
+// sfxConfig returns the configuration of the 7z SFX module, which runs the
+// given script after extracting the installer.
+func sfxConfig(name, script string) string {
+	program := script
+	if strings.EqualFold(filepath.Ext(script), ".ps1") {
+		program = "powershell.exe -NoProfile -ExecutionPolicy Bypass -File " + script
+	}
+	return strings.Join([]string{
+		";!@Install@!UTF-8!",
+		fmt.Sprintf("Title=%q", name),
+		fmt.Sprintf("RunProgram=%q", program),
+		";!@InstallEnd@!",
+		"",
+	}, "\n")
+}
+
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func run(ctx *context.Context, cfg config.Makeself, dir, msg, name string, arg ...string) error {
+	cmd := exec.CommandContext(ctx, name, arg...)
 	cmd.Dir = dir
 	cmd.Env = append(ctx.Env.Strings(), cmd.Environ()...)
 	var b bytes.Buffer
@@ -204,7 +285,7 @@ func create(ctx *context.Context, cfg config.Makeself, plat string, binaries []*
 	if err := cmd.Run(); err != nil {
 		return gerrors.Wrap(
 			err,
-			gerrors.WithMessage("could not create makeself package"),
+			gerrors.WithMessage(msg),
 			gerrors.WithDetails(
 				"args", strings.Join(cmd.Args, " "),
 				"id", cfg.ID,
@@ -212,9 +293,6 @@ func create(ctx *context.Context, cfg config.Makeself, plat string, binaries []*
 			gerrors.WithOutput(b.String()),
 		)
 	}
-
-	path := filepath.Join(dir, filename)
-	ctx.Artifacts.Add(makeArtifact(cfg, binary, filename, path))
 	return nil
 }
 
@@ -198,3 +198,82 @@ func requireRunMakeself(tb testing.TB, path string) {
 	require.NoError(tb, err, string(out))
 	require.Contains(tb, string(out), "hello world, from the binary")
 }
+
+func TestSFXConfig(t *testing.T) {
+	require.Equal(
+		t,
+		";!@Install@!UTF-8!\nTitle=\"myproj\"\nRunProgram=\"setup.bat\"\n;!@InstallEnd@!\n",
+		sfxConfig("myproj", "setup.bat"),
+	)
+	require.Contains(
+		t,
+		sfxConfig("myproj", "setup.ps1"),
+		"RunProgram=\"powershell.exe -NoProfile -ExecutionPolicy Bypass -File setup.ps1\"",
+	)
+}
+
+func TestRunWindows(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses a shell script")
+
+	// fake 7z, which writes the list of files it was asked to archive.
+	bin := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(bin, "7z"),
+		[]byte("#!/bin/sh\nfind . -type f | sort > \"$4\"\n"),
+		0o755,
+	))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	sfx := filepath.Join(t.TempDir(), "7zSD.sfx")
+	require.NoError(t, os.WriteFile(sfx, []byte("MZ-sfx-module\n"), 0o644))
+
+	exe := filepath.Join(t.TempDir(), "mybin.exe")
+	require.NoError(t, os.WriteFile(exe, []byte("exe"), 0o755))
+
+	ctx := makeContext(t)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "mybin.exe",
+		Path:   exe,
+		Type:   artifact.Binary,
+		Goos:   "windows",
+		Goarch: "amd64",
+	})
+	ctx.Config.Makeselfs = append(ctx.Config.Makeselfs, config.Makeself{
+		Script:    "./testdata/setup.sh",
+		Goos:      []string{"windows"},
+		SFXModule: sfx,
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	result := ctx.Artifacts.Filter(artifact.ByType(artifact.Makeself)).List()
+	require.Len(t, result, 1)
+	require.Equal(t, "myproj_1.2.3_windows_amd64.exe", result[0].Name)
+	require.Equal(t, ".exe", artifact.ExtraOr(*result[0], artifact.ExtraExt, ""))
+
+	bts, err := os.ReadFile(result[0].Path)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"MZ-sfx-module\n"+sfxConfig("myproj", "setup.sh")+"./mybin.exe\n./package.lsm\n./setup.sh\n",
+		string(bts),
+	)
+	require.NoFileExists(t, filepath.Dir(result[0].Path)+".7z")
+}
+
+func TestRunWindowsNoSFXModule(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "mybin.exe",
+		Path:   filepath.Join(t.TempDir(), "mybin.exe"),
+		Type:   artifact.Binary,
+		Goos:   "windows",
+		Goarch: "amd64",
+	})
+	ctx.Config.Makeselfs = append(ctx.Config.Makeselfs, config.Makeself{
+		Script: "./testdata/setup.sh",
+		Goos:   []string{"windows"},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Run(ctx), "sfx_module is required to create windows installers")
+}
@@ -2,15 +2,26 @@
 package mastodon
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/mattn/go-mastodon"
 )
 
-const defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
+const (
+	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
+
+	// maxMedia is the maximum number of media attachments of a status.
+	maxMedia = 4
+)
 
 type Pipe struct{}
 
@@ -34,8 +45,35 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
+// Preview returns the status that would be posted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	toot, media, err := newToot(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if toot.SpoilerText != "" {
+		fmt.Fprintf(&sb, "Content warning: %s\n", toot.SpoilerText)
+	}
+	if toot.Visibility != "" {
+		fmt.Fprintf(&sb, "Visibility: %s\n", toot.Visibility)
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(toot.Status)
+	for _, m := range media {
+		fmt.Fprintf(&sb, "\nMedia: %s", m.Path)
+		if m.Description != "" {
+			fmt.Fprintf(&sb, " (%s)", m.Description)
+		}
+	}
+	return sb.String(), nil
+}
+
 func (p Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Mastodon.MessageTemplate)
+	toot, media, err := newToot(ctx)
 	if err != nil {
 		return err
 	}
@@ -45,21 +83,88 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return err
 	}
 
+	transport, err := httpclient.Transport(ctx)
+	if err != nil {
+		return err
+	}
+
 	client := mastodon.NewClient(&mastodon.Config{
 		Server:       ctx.Config.Announce.Mastodon.Server,
 		ClientID:     cfg.ClientID,
 		ClientSecret: cfg.ClientSecret,
 		AccessToken:  cfg.AccessToken,
 	})
+	client.Transport = transport
+
+	for _, m := range media {
+		log.WithField("media", m.Path).Info("uploading")
+		attachment, err := retryx.DoWithData(ctx, ctx.Config.Retry, func() (*mastodon.Attachment, error) {
+			f, err := os.Open(m.Path)
+			if err != nil {
+				return nil, retryx.Unrecoverable(err)
+			}
+			defer f.Close()
+			return client.UploadMediaFromMedia(ctx, &mastodon.Media{
+				File:        f,
+				Description: m.Description,
+			})
+		}, retryx.IsNetworkError)
+		if err != nil {
+			return fmt.Errorf("mastodon: could not upload %s: %w", m.Path, err)
+		}
+		toot.MediaIDs = append(toot.MediaIDs, attachment.ID)
+	}
 
-	log.Infof("posting: '%s'", msg)
+	log.Infof("posting: '%s'", toot.Status)
 	if err := retryx.Do(ctx, ctx.Config.Retry, func() error {
-		_, err := client.PostStatus(ctx, &mastodon.Toot{
-			Status: msg,
-		})
+		_, err := client.PostStatus(ctx, toot)
 		return err
 	}, retryx.IsNetworkError); err != nil {
 		return err
 	}
 	return nil
 }
+
+// newToot returns the status to post and the media to attach to it.
+func newToot(ctx *context.Context) (*mastodon.Toot, []config.MastodonMedia, error) {
+	mcfg := ctx.Config.Announce.Mastodon
+	t := tmpl.New(ctx).WithMessageTemplates()
+
+	msg, spoiler := mcfg.MessageTemplate, mcfg.SpoilerText
+	if err := t.ApplyAll(&msg, &spoiler); err != nil {
+		return nil, nil, err
+	}
+
+	switch mcfg.Visibility {
+	case "",
+		mastodon.VisibilityPublic,
+		mastodon.VisibilityUnlisted,
+		mastodon.VisibilityFollowersOnly,
+		mastodon.VisibilityDirectMessage:
+	default:
+		return nil, nil, fmt.Errorf("mastodon: invalid visibility: %q", mcfg.Visibility)
+	}
+
+	var media []config.MastodonMedia
+	for _, m := range mcfg.Media {
+		if err := t.ApplyAll(&m.Path, &m.Description); err != nil {
+			return nil, nil, err
+		}
+		if m.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(m.Path); err != nil {
+			return nil, nil, fmt.Errorf("mastodon: %w", err)
+		}
+		media = append(media, m)
+	}
+	if len(media) > maxMedia {
+		return nil, nil, fmt.Errorf("mastodon: statuses can have at most %d media attachments, got %d", maxMedia, len(media))
+	}
+
+	return &mastodon.Toot{
+		Status:      msg,
+		SpoilerText: spoiler,
+		Visibility:  mcfg.Visibility,
+	}, media, nil
+}
@@ -1,11 +1,17 @@
 package mastodon
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,6 +48,121 @@ func TestAnnounceMissingEnv(t *testing.T) {
 	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "MASTODON_CLIENT_ID" should not be empty; environment variable "MASTODON_CLIENT_SECRET" should not be empty; environment variable "MASTODON_ACCESS_TOKEN" should not be empty`)
 }
 
+func TestPreview(t *testing.T) {
+	image := filepath.Join(t.TempDir(), "card.png")
+	require.NoError(t, os.WriteFile(image, []byte("png"), 0o644))
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			Mastodon: config.Mastodon{
+				MessageTemplate: "{{ .ProjectName }} {{ .Tag }} is out!",
+				SpoilerText:     "{{ .ProjectName }} release",
+				Visibility:      "unlisted",
+				Media: []config.MastodonMedia{
+					{Path: image, Description: "{{ .ProjectName }} {{ .Tag }} release card"},
+					{Path: "{{ .Env.NOPE }}"},
+				},
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"), testctx.WithEnv(map[string]string{"NOPE": ""}))
+
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Content warning: honk release\nVisibility: unlisted\n\nhonk v1.0.0 is out!\nMedia: "+image+" (honk v1.0.0 release card)", out)
+}
+
+func TestPreviewErrors(t *testing.T) {
+	image := filepath.Join(t.TempDir(), "card.png")
+	require.NoError(t, os.WriteFile(image, []byte("png"), 0o644))
+
+	for name, tt := range map[string]struct {
+		cfg    config.Mastodon
+		expect string
+	}{
+		"invalid visibility": {
+			cfg:    config.Mastodon{Visibility: "followers"},
+			expect: `mastodon: invalid visibility: "followers"`,
+		},
+		"missing media": {
+			cfg:    config.Mastodon{Media: []config.MastodonMedia{{Path: "nope.png"}}},
+			expect: "mastodon: stat nope.png: no such file or directory",
+		},
+		"too many media": {
+			cfg: config.Mastodon{Media: []config.MastodonMedia{
+				{Path: image}, {Path: image}, {Path: image}, {Path: image}, {Path: image},
+			}},
+			expect: "mastodon: statuses can have at most 4 media attachments, got 5",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{
+					Mastodon: tt.cfg,
+				},
+			})
+			_, err := Pipe{}.Preview(ctx)
+			require.EqualError(t, err, tt.expect)
+		})
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Mastodon: config.Mastodon{
+					SpoilerText: "{{ .Foo }",
+				},
+			},
+		})
+		_, err := Pipe{}.Preview(ctx)
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestAnnounce(t *testing.T) {
+	image := filepath.Join(t.TempDir(), "card.png")
+	require.NoError(t, os.WriteFile(image, []byte("png"), 0o644))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/media":
+			assert.NoError(t, r.ParseMultipartForm(1024))
+			assert.Equal(t, "release card", r.FormValue("description"))
+			fmt.Fprint(w, `{"id":"123"}`)
+		case "/api/v1/statuses":
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "honk v1.0.0 is out!", r.FormValue("status"))
+			assert.Equal(t, "new release", r.FormValue("spoiler_text"))
+			assert.Equal(t, "private", r.FormValue("visibility"))
+			assert.Equal(t, []string{"123"}, r.Form["media_ids[]"])
+			fmt.Fprint(w, `{"id":"456"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			Mastodon: config.Mastodon{
+				Server:          srv.URL,
+				MessageTemplate: "{{ .ProjectName }} {{ .Tag }} is out!",
+				SpoilerText:     "new release",
+				Visibility:      "private",
+				Media: []config.MastodonMedia{
+					{Path: image, Description: "release card"},
+				},
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	t.Setenv("MASTODON_CLIENT_ID", "id")
+	t.Setenv("MASTODON_CLIENT_SECRET", "secret")
+	t.Setenv("MASTODON_ACCESS_TOKEN", "token")
+
+	require.NoError(t, Pipe{}.Announce(ctx))
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
@@ -0,0 +1,147 @@
+// Package matrix announces releases to a Matrix room.
+package matrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/caarlos0/log"
+	"github.com/google/uuid"
+
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultHomeserver      = "https://matrix.org"
+	defaultMsgType         = "m.text"
+	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
+	htmlFormat             = "org.matrix.custom.html"
+)
+
+var errNoRoomID = errors.New("matrix: room_id is required")
+
+type Pipe struct{}
+
+func (Pipe) String() string { return "matrix" }
+func (Pipe) Skip(ctx *context.Context) (bool, error) {
+	enable, err := tmpl.New(ctx).Bool(ctx.Config.Announce.Matrix.Enabled)
+	return !enable, err
+}
+
+type Config struct {
+	AccessToken string `env:"MATRIX_ACCESS_TOKEN,notEmpty"`
+}
+
+func (Pipe) Default(ctx *context.Context) error {
+	if ctx.Config.Announce.Matrix.MessageTemplate == "" {
+		ctx.Config.Announce.Matrix.MessageTemplate = defaultMessageTemplate
+	}
+	if ctx.Config.Announce.Matrix.Homeserver == "" {
+		ctx.Config.Announce.Matrix.Homeserver = defaultHomeserver
+	}
+	if ctx.Config.Announce.Matrix.MsgType == "" {
+		ctx.Config.Announce.Matrix.MsgType = defaultMsgType
+	}
+	return nil
+}
+
+// Preview returns the message that would be posted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	_, _, message, err := newMessage(ctx)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.MarshalIndent(message, "", "  ")
+	return string(bts), err
+}
+
+func (Pipe) Announce(ctx *context.Context) error {
+	homeserver, roomID, message, err := newMessage(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := env.ParseAs[Config]()
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(homeserver)
+	if err != nil {
+		return fmt.Errorf("matrix: invalid homeserver: %w", err)
+	}
+
+	bts, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the transaction id makes retries idempotent: the homeserver will not
+	// post the same message twice.
+	u = u.JoinPath("_matrix", "client", "v3", "rooms", roomID, "send", "m.room.message", uuid.NewString())
+
+	log.Infof("posting: '%s'", message.Body)
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), bytes.NewReader(bts))
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+cfg.AccessToken)
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return retryx.HTTP(err, resp)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return retryx.HTTP(fmt.Errorf("%s", resp.Status), resp)
+		}
+
+		return nil
+	}, retryx.IsRetriable)
+}
+
+// newMessage returns the templated homeserver, room ID and message.
+func newMessage(ctx *context.Context) (string, string, Message, error) {
+	mcfg := ctx.Config.Announce.Matrix
+	homeserver, roomID, msg, html := mcfg.Homeserver, mcfg.RoomID, mcfg.MessageTemplate, mcfg.HTMLTemplate
+	if err := tmpl.New(ctx).WithMessageTemplates().ApplyAll(&homeserver, &roomID, &msg, &html); err != nil {
+		return "", "", Message{}, err
+	}
+	if roomID == "" {
+		return "", "", Message{}, errNoRoomID
+	}
+
+	message := Message{
+		MsgType: mcfg.MsgType,
+		Body:    msg,
+	}
+	if html != "" {
+		message.Format = htmlFormat
+		message.FormattedBody = html
+	}
+	return homeserver, roomID, message, nil
+}
+
+// Message is a Matrix m.room.message event content.
+type Message struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
@@ -0,0 +1,192 @@
+package matrix
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringer(t *testing.T) {
+	require.Equal(t, "matrix", Pipe{}.String())
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Matrix.MessageTemplate)
+	require.Equal(t, defaultHomeserver, ctx.Config.Announce.Matrix.Homeserver)
+	require.Equal(t, defaultMsgType, ctx.Config.Announce.Matrix.MsgType)
+}
+
+func TestAnnounceInvalidTemplate(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Matrix: config.Matrix{
+				RoomID:          "!room:matrix.org",
+				MessageTemplate: "{{ .Foo }",
+			},
+		},
+	})
+
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+}
+
+func TestAnnounceMissingRoomID(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorIs(t, Pipe{}.Announce(ctx), errNoRoomID)
+}
+
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			Matrix: config.Matrix{
+				RoomID:       "!room:matrix.org",
+				HTMLTemplate: "<b>{{ .ProjectName }}</b> {{ .Tag }} is out!",
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+
+	var msg Message
+	require.NoError(t, json.Unmarshal([]byte(out), &msg))
+	require.Equal(t, Message{
+		MsgType:       defaultMsgType,
+		Body:          "honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0",
+		Format:        htmlFormat,
+		FormattedBody: "<b>honk</b> v1.0.0 is out!",
+	}, msg)
+}
+
+func TestAnnounceMissingEnv(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Matrix: config.Matrix{
+				RoomID: "!room:matrix.org",
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "MATRIX_ACCESS_TOKEN" should not be empty`)
+}
+
+func TestAnnounce(t *testing.T) {
+	for name, tt := range map[string]struct {
+		html   string
+		expect Message
+	}{
+		"text": {
+			expect: Message{
+				MsgType: "m.notice",
+				Body:    "Honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0",
+			},
+		},
+		"html": {
+			html: `<b>{{ .ProjectName }} {{ .Tag }}</b> is out!`,
+			expect: Message{
+				MsgType:       "m.notice",
+				Body:          "Honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0",
+				Format:        htmlFormat,
+				FormattedBody: "<b>Honk v1.0.0</b> is out!",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				if !strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/!room:matrix.org/send/m.room.message/") {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+				var msg Message
+				body, _ := io.ReadAll(r.Body)
+				assert.NoError(t, json.Unmarshal(body, &msg))
+				assert.Equal(t, tt.expect, msg)
+
+				_, _ = w.Write([]byte(`{"event_id":"$abc"}`))
+			}))
+			t.Cleanup(ts.Close)
+
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "Honk",
+				Announce: config.Announce{
+					Matrix: config.Matrix{
+						Enabled:      "true",
+						Homeserver:   ts.URL,
+						RoomID:       "!room:matrix.org",
+						HTMLTemplate: tt.html,
+						MsgType:      "m.notice",
+					},
+				},
+			}, testctx.WithCurrentTag("v1.0.0"))
+			ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+			t.Setenv("MATRIX_ACCESS_TOKEN", "token")
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Announce(ctx))
+		})
+	}
+}
+
+func TestAnnounceError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Matrix: config.Matrix{
+				Homeserver: ts.URL,
+				RoomID:     "!room:matrix.org",
+			},
+		},
+	})
+	t.Setenv("MATRIX_ACCESS_TOKEN", "token")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Announce(ctx), "403 Forbidden")
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Matrix: config.Matrix{
+					Enabled: "true",
+				},
+			},
+		})
+
+		skip, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+}
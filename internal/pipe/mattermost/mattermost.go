@@ -10,6 +10,7 @@ import (
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
 
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -52,13 +53,18 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Mattermost.MessageTemplate)
+// Preview returns the webhook payload that would be posted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	wm, err := newWebhookRequest(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
+	bts, err := json.MarshalIndent(wm, "", "  ")
+	return string(bts), err
+}
 
-	title, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Mattermost.TitleTemplate)
+func (p Pipe) Announce(ctx *context.Context) error {
+	wm, err := newWebhookRequest(ctx)
 	if err != nil {
 		return err
 	}
@@ -68,9 +74,23 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return err
 	}
 
-	log.Infof("posting: %q", msg)
+	log.Infof("posting: %q", wm.Attachments[0].Text)
+
+	return postWebhook(ctx, cfg.Webhook, wm)
+}
+
+func newWebhookRequest(ctx *context.Context) (*incomingWebhookRequest, error) {
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Mattermost.MessageTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Mattermost.TitleTemplate)
+	if err != nil {
+		return nil, err
+	}
 
-	wm := &incomingWebhookRequest{
+	return &incomingWebhookRequest{
 		Username:    ctx.Config.Announce.Mattermost.Username,
 		IconEmoji:   ctx.Config.Announce.Mattermost.IconEmoji,
 		IconURL:     ctx.Config.Announce.Mattermost.IconURL,
@@ -82,9 +102,7 @@ func (p Pipe) Announce(ctx *context.Context) error {
 				Color: ctx.Config.Announce.Mattermost.Color,
 			},
 		},
-	}
-
-	return postWebhook(ctx, cfg.Webhook, wm)
+	}, nil
 }
 
 func postWebhook(ctx *context.Context, url string, msg *incomingWebhookRequest) error {
@@ -93,6 +111,11 @@ func postWebhook(ctx *context.Context, url string, msg *incomingWebhookRequest)
 		return fmt.Errorf("failed to marshal the message: %w", err)
 	}
 
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
 	return retryx.Do(ctx, ctx.Config.Retry, func() error {
 		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
 		if err != nil {
@@ -100,7 +123,7 @@ func postWebhook(ctx *context.Context, url string, msg *incomingWebhookRequest)
 		}
 		req.Header.Set("Content-Type", "application/json")
 
-		r, err := http.DefaultClient.Do(req)
+		r, err := httpClient.Do(req)
 		if err != nil {
 			return retryx.HTTP(err, r)
 		}
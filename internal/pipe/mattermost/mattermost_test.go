@@ -70,6 +70,25 @@ func TestSkip(t *testing.T) {
 	})
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "Honk",
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+
+	var rc incomingWebhookRequest
+	require.NoError(t, json.Unmarshal([]byte(out), &rc))
+	require.Equal(t, defaultUsername, rc.Username)
+	require.Len(t, rc.Attachments, 1)
+	require.Equal(t, defaultColor, rc.Attachments[0].Color)
+	require.Equal(t, "Honk v1.0.0 is out!", rc.Attachments[0].Title)
+	require.Equal(t, "Honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0", rc.Attachments[0].Text)
+}
+
 func TestPostWebhook(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		rc := &incomingWebhookRequest{}
@@ -101,6 +101,71 @@ func TestRun(t *testing.T) {
 	})
 }
 
+func TestRestore(t *testing.T) {
+	t.Run("restore", func(t *testing.T) {
+		tmp := t.TempDir()
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Dist:        tmp,
+			ProjectName: "name",
+		},
+			testctx.WithPreviousTag("v1.2.2"),
+			testctx.WithCurrentTag("v1.2.3"),
+			testctx.WithCommit("aef34a"),
+			testctx.WithVersion("1.2.3"),
+			testctx.WithDate(time.Date(2022, 0o1, 22, 10, 12, 13, 0, time.UTC)),
+		)
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:   "foo.tar.gz",
+			Path:   filepath.Join(tmp, "foo.tar.gz"),
+			Type:   artifact.UploadableArchive,
+			Goos:   "linux",
+			Goarch: "amd64",
+			Extra: map[string]any{
+				artifact.ExtraID: "default",
+			},
+		})
+		require.NoError(t, MetaPipe{}.Run(ctx))
+		require.NoError(t, ArtifactsPipe{}.Run(ctx))
+		require.NoError(t, os.WriteFile(filepath.Join(tmp, "CHANGELOG.md"), []byte("## Changelog\n"), 0o644))
+
+		restored := testctx.WrapWithCfg(t.Context(), config.Project{
+			Dist: tmp,
+		}, testctx.WithCurrentTag("v1.3.0"), testctx.WithCommit("beef"))
+		require.NoError(t, RestorePipe{}.Run(restored))
+
+		require.Equal(t, "v1.2.3", restored.Git.CurrentTag)
+		require.Equal(t, "v1.2.2", restored.Git.PreviousTag)
+		require.Equal(t, "aef34a", restored.Git.FullCommit)
+		require.Equal(t, "1.2.3", restored.Version)
+		require.Equal(t, ctx.Date, restored.Date.UTC())
+		require.Equal(t, "## Changelog\n", restored.ReleaseNotes)
+
+		archives := restored.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+		require.Len(t, archives, 1)
+		require.Equal(t, "foo.tar.gz", archives[0].Name)
+		require.Equal(t, "linux", archives[0].Goos)
+		require.Equal(t, "default", archives[0].ID())
+		require.Len(t, restored.Artifacts.Filter(artifact.ByType(artifact.Metadata)).List(), 1)
+	})
+
+	t.Run("no metadata", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Dist: t.TempDir(),
+		}, testctx.WithCurrentTag("v1.3.0"))
+		testlib.AssertSkipped(t, RestorePipe{}.Run(ctx))
+		require.Equal(t, "v1.3.0", ctx.Git.CurrentTag)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		tmp := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmp, "metadata.json"), []byte("{"), 0o644))
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Dist: tmp,
+		})
+		require.ErrorContains(t, RestorePipe{}.Run(ctx), "could not parse")
+	})
+}
+
 func requireEqualJSONFile(tb testing.TB, path string, modTime time.Time) {
 	tb.Helper()
 	golden.RequireEqualJSON(tb, golden.RequireReadFile(tb, path))
@@ -0,0 +1,83 @@
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/dist"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// RestorePipe restores the release context from the metadata.json,
+// artifacts.json and CHANGELOG.md files a previous release left in the dist
+// directory.
+type RestorePipe struct{}
+
+func (RestorePipe) String() string { return "restoring release metadata" }
+
+func (RestorePipe) Run(ctx *context.Context) error {
+	// the dist default is needed before the defaults pipe runs, as the
+	// restored tag must be parsed by the semver pipe.
+	_ = dist.Pipe{}.Default(ctx)
+
+	var meta metadata
+	if err := readJSON(filepath.Join(ctx.Config.Dist, "metadata.json"), &meta); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return pipe.Skipf("no metadata.json in %s", ctx.Config.Dist)
+		}
+		return err
+	}
+
+	var artifacts []*artifact.Artifact
+	if err := readJSON(filepath.Join(ctx.Config.Dist, "artifacts.json"), &artifacts); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		log.Warnf("no artifacts.json in %s, restoring without artifacts", ctx.Config.Dist)
+	}
+
+	if meta.Tag != ctx.Git.CurrentTag {
+		log.Warnf("released tag %s differs from current tag %s, using %s", meta.Tag, ctx.Git.CurrentTag, meta.Tag)
+	}
+	ctx.Git.CurrentTag = meta.Tag
+	ctx.Git.PreviousTag = meta.PreviousTag
+	if meta.Commit != ctx.Git.FullCommit {
+		ctx.Git.Commit = meta.Commit
+		ctx.Git.FullCommit = meta.Commit
+		ctx.Git.ShortCommit = meta.Commit[:min(len(meta.Commit), 7)]
+	}
+	ctx.Version = meta.Version
+	ctx.Date = meta.Date
+
+	for _, a := range artifacts {
+		ctx.Artifacts.Add(a)
+	}
+
+	notes, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "CHANGELOG.md"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	ctx.ReleaseNotes = string(notes)
+
+	log.WithField("tag", meta.Tag).
+		WithField("artifacts", len(artifacts)).
+		Info("restored")
+	return nil
+}
+
+func readJSON(path string, v any) error {
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bts, v); err != nil {
+		return fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	return nil
+}
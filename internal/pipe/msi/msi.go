@@ -0,0 +1,406 @@
+// Package msi implements the Pipe interface providing Windows installer
+// (MSI) creation.
+package msi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/google/uuid"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/redact"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultNameTemplate = `{{ .ProjectName }}_{{ .MsiArch }}`
+
+	schemaV3 = "v3"
+	schemaV4 = "v4"
+
+	// v4Namespace is the XML namespace of the v4 (and v5) WXS schema.
+	v4Namespace = "http://wixtoolset.org/schemas/v4/wxs"
+)
+
+// goos is the operating system GoReleaser is running on, which decides the
+// tools used to create the installers.
+var goos = runtime.GOOS
+
+// msiArchs maps the supported GOARCHs to their MSI architecture.
+var msiArchs = map[string]string{
+	"amd64": "x64",
+	"386":   "x86",
+	"arm64": "arm64",
+}
+
+// Pipe for MSI packaging.
+type Pipe struct{}
+
+func (Pipe) String() string { return "windows installers" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.MSI) || len(ctx.Config.MSI) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	if len(ctx.Config.MSI) == 0 {
+		return nil
+	}
+	if goos != "windows" {
+		return []string{"wixl"}
+	}
+	var deps []string
+	for _, cfg := range ctx.Config.MSI {
+		switch {
+		case cfg.Version == schemaV4:
+			deps = append(deps, "wix")
+		case cfg.Version == schemaV3, cfg.WXS == "":
+			deps = append(deps, "candle", "light")
+		}
+	}
+	slices.Sort(deps)
+	return slices.Compact(deps)
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("msi")
+	for i := range ctx.Config.MSI {
+		cfg := &ctx.Config.MSI[i]
+		if cfg.ID == "" {
+			cfg.ID = ctx.Config.ProjectName
+		}
+		if cfg.Name == "" {
+			cfg.Name = defaultNameTemplate
+		}
+		if cfg.Goamd64 == "" {
+			cfg.Goamd64 = "v1"
+		}
+		switch cfg.Version {
+		case "", schemaV3, schemaV4:
+		default:
+			return fmt.Errorf("msi: invalid version: %q, valid options are %q and %q", cfg.Version, schemaV3, schemaV4)
+		}
+		if cfg.Service.Name != "" && cfg.Service.Start == "" {
+			cfg.Service.Start = "auto"
+		}
+		switch cfg.Service.Start {
+		case "", "auto", "demand", "disabled":
+		default:
+			return fmt.Errorf("msi: invalid service start: %q, valid options are auto, demand, and disabled", cfg.Service.Start)
+		}
+		ids.Inc(cfg.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for _, cfg := range ctx.Config.MSI {
+		g.Go(func() error {
+			return doRun(ctx, cfg)
+		})
+	}
+	return g.Wait()
+}
+
+func doRun(ctx *context.Context, cfg config.MSI) error {
+	disable, err := tmpl.New(ctx).Bool(cfg.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("disabled")
+	}
+
+	groups := getArtifacts(ctx, cfg)
+	if len(groups) == 0 {
+		return pipe.Skipf("no windows binaries found for builds %v", cfg.IDs)
+	}
+
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for plat, binaries := range groups {
+		g.Go(func() error {
+			return create(ctx, cfg, plat, binaries)
+		})
+	}
+	return g.Wait()
+}
+
+func create(ctx *context.Context, cfg config.MSI, plat string, binaries []*artifact.Artifact) error {
+	slices.SortFunc(binaries, func(a, b *artifact.Artifact) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	binary := binaries[0]
+	arch := msiArchs[binary.Goarch]
+	tpl := tmpl.New(ctx).
+		WithArtifact(binary).
+		WithExtraFields(tmpl.Fields{
+			"MsiArch": arch,
+		})
+
+	upgradeCode, err := tpl.Apply(cfg.UpgradeCode)
+	if err != nil {
+		return err
+	}
+	if upgradeCode == "" {
+		upgradeCode = defaultUpgradeCode(ctx.Config.ProjectName, cfg.ID)
+	}
+	if _, err := uuid.Parse(upgradeCode); err != nil {
+		return fmt.Errorf("msi: invalid upgrade_code %q: %w", upgradeCode, err)
+	}
+	tpl = tpl.WithExtraFields(tmpl.Fields{
+		"UpgradeCode": upgradeCode,
+	})
+
+	name := cfg.Name
+	wxs := cfg.WXS
+	manufacturer := cfg.Manufacturer
+	modTimestamp := cfg.ModTimestamp
+	service := cfg.Service
+	extensions := cfg.Extensions
+	extraFiles := cfg.ExtraFiles
+	if err := tpl.ApplyAll(
+		&name,
+		&wxs,
+		&manufacturer,
+		&modTimestamp,
+		&service.Name,
+		&service.DisplayName,
+		&service.Description,
+		&service.Arguments,
+	); err != nil {
+		return err
+	}
+	if err := tpl.ApplySlice(&extensions); err != nil {
+		return err
+	}
+	if err := tpl.ApplySlice(&extraFiles); err != nil {
+		return err
+	}
+	extensions = slices.DeleteFunc(extensions, func(s string) bool { return s == "" })
+
+	var content string
+	if wxs == "" {
+		if manufacturer == "" {
+			manufacturer = ctx.Config.ProjectName
+		}
+		if service.DisplayName == "" {
+			service.DisplayName = service.Name
+		}
+		data := wxsData{
+			Name:         ctx.Config.ProjectName,
+			Manufacturer: manufacturer,
+			Version:      fmt.Sprintf("%d.%d.%d", ctx.Semver.Major, ctx.Semver.Minor, ctx.Semver.Patch),
+			UpgradeCode:  upgradeCode,
+			Arch:         arch,
+			Service:      wxsService(service),
+		}
+		for _, bin := range binaries {
+			data.Binaries = append(data.Binaries, wxsBinary{
+				Name:   filepath.Base(bin.Name),
+				Source: bin.Name,
+			})
+		}
+		content, err = defaultWXS(data)
+	} else {
+		content, err = renderWXS(tpl, wxs)
+	}
+	if err != nil {
+		return err
+	}
+
+	version := cfg.Version
+	if version == "" {
+		version = schemaV3
+		if strings.Contains(content, v4Namespace) {
+			version = schemaV4
+		}
+	}
+	if goos != "windows" && version != schemaV3 {
+		return fmt.Errorf("msi: msitools only supports the %s schema, got %s", schemaV3, version)
+	}
+
+	dir, err := setupContext(ctx, cfg, plat, name, content, binaries, extraFiles)
+	if err != nil {
+		return err
+	}
+
+	filename := name + ".msi"
+	path := filepath.Join(ctx.Config.Dist, filename)
+	out, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	log := log.WithField("installer", filename).WithField("dir", dir)
+	log.Info("creating installer")
+	if err := os.Remove(out); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := build(ctx, cfg, dir, out, name+".wxs", arch, version, extensions); err != nil {
+		return err
+	}
+
+	if err := gio.Chtimes(path, modTimestamp); err != nil {
+		return err
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.MSI,
+		Name:    filename,
+		Path:    path,
+		Goos:    binary.Goos,
+		Goarch:  binary.Goarch,
+		Goamd64: binary.Goamd64,
+		Go386:   binary.Go386,
+		Goarm64: binary.Goarm64,
+		Target:  binary.Target,
+		Extra: map[string]any{
+			artifact.ExtraID:     cfg.ID,
+			artifact.ExtraFormat: "msi",
+			artifact.ExtraExt:    ".msi",
+		},
+	})
+	return nil
+}
+
+func renderWXS(tpl *tmpl.Template, wxs string) (string, error) {
+	bts, err := os.ReadFile(wxs)
+	if err != nil {
+		return "", fmt.Errorf("failed to read wxs file: %w", err)
+	}
+	return tpl.Apply(string(bts))
+}
+
+// build creates the installer at out from the given wxs file inside dir.
+//
+// On Windows, it uses the WiX Toolset: 'candle' and 'light' for the v3
+// schema, and 'wix' for v4.
+// Everywhere else, it uses 'wixl', from msitools.
+func build(ctx *context.Context, cfg config.MSI, dir, out, wxs, arch, version string, extensions []string) error {
+	if goos != "windows" {
+		args := []string{"-a", arch}
+		for _, ext := range extensions {
+			args = append(args, "--ext", ext)
+		}
+		args = append(args, "-o", out, wxs)
+		return run(ctx, cfg, dir, "could not create installer", "wixl", args...)
+	}
+
+	var exts []string
+	for _, ext := range extensions {
+		exts = append(exts, "-ext", ext)
+	}
+
+	if version == schemaV4 {
+		args := append([]string{"build", "-nologo", "-arch", arch}, exts...)
+		args = append(args, "-o", out, wxs)
+		return run(ctx, cfg, dir, "could not create installer", "wix", args...)
+	}
+
+	obj := strings.TrimSuffix(wxs, ".wxs") + ".wixobj"
+	args := append([]string{"-nologo", "-arch", arch}, exts...)
+	args = append(args, "-out", obj, wxs)
+	if err := run(ctx, cfg, dir, "could not compile wxs", "candle", args...); err != nil {
+		return err
+	}
+	args = append([]string{"-nologo"}, exts...)
+	args = append(args, "-out", out, obj)
+	return run(ctx, cfg, dir, "could not create installer", "light", args...)
+}
+
+func setupContext(
+	ctx *context.Context,
+	cfg config.MSI,
+	plat, name, wxs string,
+	binaries []*artifact.Artifact,
+	extraFiles []string,
+) (string, error) {
+	dir := filepath.Join(ctx.Config.Dist, "msi", cfg.ID, plat)
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	for _, binary := range binaries {
+		dst := filepath.Join(dir, binary.Name)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return "", fmt.Errorf("failed to create directory for %s: %w", binary.Name, err)
+		}
+		if err := gio.Copy(binary.Path, dst); err != nil {
+			return "", fmt.Errorf("failed to copy binary %s: %w", binary.Name, err)
+		}
+	}
+
+	for _, file := range extraFiles {
+		if err := gio.Copy(file, filepath.Join(dir, filepath.Base(file))); err != nil {
+			return "", fmt.Errorf("failed to copy file %s: %w", file, err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name+".wxs"), []byte(wxs), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write wxs file: %w", err)
+	}
+	return dir, nil
+}
+
+func run(ctx *context.Context, cfg config.MSI, dir, msg, name string, arg ...string) error {
+	cmd := exec.CommandContext(ctx, name, arg...)
+	cmd.Dir = dir
+	cmd.Env = append(ctx.Env.Strings(), cmd.Environ()...)
+	var b bytes.Buffer
+	w := gio.Safe(&b)
+	cmd.Stderr = redact.Writer(io.MultiWriter(logext.NewWriter(), w), cmd.Env)
+	cmd.Stdout = redact.Writer(io.MultiWriter(logext.NewWriter(), w), cmd.Env)
+	if err := cmd.Run(); err != nil {
+		return gerrors.Wrap(
+			err,
+			gerrors.WithMessage(msg),
+			gerrors.WithDetails(
+				"args", strings.Join(cmd.Args, " "),
+				"id", cfg.ID,
+			),
+			gerrors.WithOutput(b.String()),
+		)
+	}
+	return nil
+}
+
+func getArtifacts(ctx *context.Context, cfg config.MSI) map[string][]*artifact.Artifact {
+	filters := []artifact.Filter{
+		artifact.ByGoos("windows"),
+		artifact.ByType(artifact.Binary),
+		artifact.ByGoarches(slices.Sorted(maps.Keys(msiArchs))...),
+		artifact.ByIDs(cfg.IDs...),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("amd64")),
+			artifact.ByGoamd64(cfg.Goamd64),
+		),
+	}
+	return ctx.Artifacts.
+		Filter(artifact.And(filters...)).
+		GroupByPlatform()
+}
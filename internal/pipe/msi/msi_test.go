@@ -0,0 +1,336 @@
+package msi
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.Equal(t, "windows installers", Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			MSI: []config.MSI{{}},
+		}, testctx.Skip(skips.MSI))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			MSI: []config.MSI{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("skip no msi", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.Empty(t, Pipe{}.Dependencies(ctx))
+
+	ctx.Config.MSI = []config.MSI{{}}
+	testlib.Set(t, &goos, "linux")
+	require.Equal(t, []string{"wixl"}, Pipe{}.Dependencies(ctx))
+
+	testlib.Set(t, &goos, "windows")
+	require.Equal(t, []string{"candle", "light"}, Pipe{}.Dependencies(ctx))
+
+	ctx.Config.MSI = []config.MSI{{Version: "v4"}, {WXS: "app.wxs"}}
+	require.Equal(t, []string{"wix"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "myproj",
+		MSI: []config.MSI{
+			{},
+			{
+				ID:      "custom",
+				Name:    "custom_{{ .MsiArch }}",
+				Goamd64: "v3",
+				Service: config.MSIService{Name: "mysvc"},
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	m1 := ctx.Config.MSI[0]
+	require.Equal(t, "myproj", m1.ID)
+	require.Equal(t, defaultNameTemplate, m1.Name)
+	require.Equal(t, "v1", m1.Goamd64)
+	require.Empty(t, m1.Service.Start)
+
+	m2 := ctx.Config.MSI[1]
+	require.Equal(t, "custom", m2.ID)
+	require.Equal(t, "custom_{{ .MsiArch }}", m2.Name)
+	require.Equal(t, "v3", m2.Goamd64)
+	require.Equal(t, "auto", m2.Service.Start)
+}
+
+func TestDefaultErrors(t *testing.T) {
+	for name, tt := range map[string]struct {
+		cfgs []config.MSI
+		err  string
+	}{
+		"duplicate id": {
+			cfgs: []config.MSI{{}, {}},
+			err:  "found 2 msi with the ID 'myproj', please fix your config",
+		},
+		"invalid version": {
+			cfgs: []config.MSI{{Version: "v5"}},
+			err:  `msi: invalid version: "v5", valid options are "v3" and "v4"`,
+		},
+		"invalid service start": {
+			cfgs: []config.MSI{{Service: config.MSIService{Name: "svc", Start: "boot"}}},
+			err:  `msi: invalid service start: "boot", valid options are auto, demand, and disabled`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "myproj",
+				MSI:         tt.cfgs,
+			})
+			require.EqualError(t, Pipe{}.Default(ctx), tt.err)
+		})
+	}
+}
+
+func TestRunWixl(t *testing.T) {
+	testlib.Set(t, &goos, "linux")
+	log := fakeCommands(t, 0, "wixl")
+	ctx := makeContext(t)
+	ctx.Config.MSI = []config.MSI{{
+		ExtraFiles: []string{"./testdata/logo.ico"},
+		Service: config.MSIService{
+			Name:      "{{ .ProjectName }}",
+			Arguments: "serve",
+		},
+	}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	installers := ctx.Artifacts.Filter(artifact.ByType(artifact.MSI)).List()
+	require.Len(t, installers, 3)
+	for _, installer := range installers {
+		require.Equal(t, "windows", installer.Goos)
+		arch := msiArchs[installer.Goarch]
+		require.Equal(t, "myproj_"+arch+".msi", installer.Name)
+		require.Equal(t, filepath.Join(ctx.Config.Dist, installer.Name), installer.Path)
+		require.FileExists(t, installer.Path)
+		require.Equal(t, "myproj", installer.ID())
+		require.Equal(t, "msi", artifact.ExtraOr(*installer, artifact.ExtraFormat, ""))
+
+		dir := filepath.Join(ctx.Config.Dist, "msi", "myproj", "windows"+installer.Goarch)
+		require.FileExists(t, filepath.Join(dir, "mybin.exe"))
+		require.FileExists(t, filepath.Join(dir, "logo.ico"))
+
+		bts, err := os.ReadFile(filepath.Join(dir, "myproj_"+arch+".wxs"))
+		require.NoError(t, err)
+		wxs := string(bts)
+		require.Contains(t, wxs, "UpgradeCode='"+defaultUpgradeCode("myproj", "myproj")+"'")
+		require.Contains(t, wxs, "Platform='"+arch+"'")
+		require.Contains(t, wxs, "Version='1.2.3'")
+		require.Contains(t, wxs, "<ServiceInstall")
+	}
+
+	out, err := filepath.Abs(filepath.Join(ctx.Config.Dist, "myproj_x64.msi"))
+	require.NoError(t, err)
+	cmds := testlib.ReadFakeLog(t, log)
+	require.Len(t, cmds, 3)
+	require.Contains(t, cmds, "wixl -a x64 -o "+out+" myproj_x64.wxs")
+}
+
+func TestRunCustomWXS(t *testing.T) {
+	testlib.Set(t, &goos, "linux")
+	log := fakeCommands(t, 0, "wixl")
+	ctx := makeContext(t)
+	ctx.Config.MSI = []config.MSI{{
+		Name:        "{{ .ProjectName }}",
+		WXS:         "./testdata/app.wxs",
+		IDs:         []string{"myproj"},
+		UpgradeCode: "ABCDDCBA-7349-453F-94F6-BCB5110BA4FD",
+		Extensions: []string{
+			`{{ if eq .Runtime.Goos "windows" }}WixUIExtension{{ end }}`,
+			"WixUtilExtension",
+		},
+	}}
+	ctx.Artifacts = ctx.Artifacts.Filter(artifact.ByGoarch("amd64"))
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	dir := filepath.Join(ctx.Config.Dist, "msi", "myproj", "windowsamd64")
+	bts, err := os.ReadFile(filepath.Join(dir, "myproj.wxs"))
+	require.NoError(t, err)
+	require.Equal(t, "myproj 1.2.3 x64 mybin ABCDDCBA-7349-453F-94F6-BCB5110BA4FD\n", string(bts))
+
+	out, err := filepath.Abs(filepath.Join(ctx.Config.Dist, "myproj.msi"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"wixl -a x64 --ext WixUtilExtension -o " + out + " myproj.wxs",
+	}, testlib.ReadFakeLog(t, log))
+}
+
+func TestRunWindows(t *testing.T) {
+	t.Run("v3", func(t *testing.T) {
+		testlib.Set(t, &goos, "windows")
+		log := fakeCommands(t, 0, "candle", "light")
+		ctx := makeContext(t)
+		ctx.Config.MSI = []config.MSI{{
+			Extensions: []string{"WixUtilExtension"},
+		}}
+		ctx.Artifacts = ctx.Artifacts.Filter(artifact.ByGoarch("386"))
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Run(ctx))
+
+		out, err := filepath.Abs(filepath.Join(ctx.Config.Dist, "myproj_x86.msi"))
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"candle -nologo -arch x86 -ext WixUtilExtension -out myproj_x86.wixobj myproj_x86.wxs",
+			"light -nologo -ext WixUtilExtension -out " + out + " myproj_x86.wixobj",
+		}, testlib.ReadFakeLog(t, log))
+	})
+
+	t.Run("v4", func(t *testing.T) {
+		testlib.Set(t, &goos, "windows")
+		log := fakeCommands(t, 0, "wix")
+		ctx := makeContext(t)
+		ctx.Config.MSI = []config.MSI{{
+			WXS:     "./testdata/app.wxs",
+			Version: "v4",
+		}}
+		ctx.Artifacts = ctx.Artifacts.Filter(artifact.ByGoarch("arm64"))
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Run(ctx))
+
+		out, err := filepath.Abs(filepath.Join(ctx.Config.Dist, "myproj_arm64.msi"))
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"wix build -nologo -arch arm64 -o " + out + " myproj_arm64.wxs",
+		}, testlib.ReadFakeLog(t, log))
+	})
+}
+
+func TestRunV4OutsideWindows(t *testing.T) {
+	testlib.Set(t, &goos, "linux")
+	ctx := makeContext(t)
+	ctx.Config.MSI = []config.MSI{{
+		WXS: "./testdata/app_v4.wxs",
+	}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Run(ctx), "msi: msitools only supports the v3 schema, got v4")
+}
+
+func TestRunInvalidUpgradeCode(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.MSI = []config.MSI{{UpgradeCode: "nope"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Run(ctx), `msi: invalid upgrade_code "nope"`)
+}
+
+func TestRunFails(t *testing.T) {
+	testlib.Set(t, &goos, "linux")
+	fakeCommands(t, 1, "wixl")
+	ctx := makeContext(t)
+	ctx.Config.MSI = []config.MSI{{}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	err := Pipe{}.Run(ctx)
+	require.Error(t, err)
+	var gerr gerrors.ErrDetailed
+	require.ErrorAs(t, err, &gerr)
+	require.Equal(t, []string{"could not create installer"}, gerr.Messages())
+}
+
+func TestRunNoBinaries(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.MSI = []config.MSI{{IDs: []string{"nope"}}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.MSI = []config.MSI{{Disable: "{{ eq .Version \"1.2.3\" }}"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunBadTemplate(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.MSI = []config.MSI{{Manufacturer: "{{ .Nope }}"}}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+}
+
+func TestDefaultWXS(t *testing.T) {
+	wxs, err := defaultWXS(wxsData{
+		Name:         "myproj",
+		Manufacturer: "Foo & Bar",
+		Version:      "1.2.3",
+		UpgradeCode:  defaultUpgradeCode("myproj", "myproj"),
+		Arch:         "x64",
+		Binaries: []wxsBinary{
+			{Name: "mybin.exe", Source: "mybin.exe"},
+			{Name: "other.exe", Source: "sub/other.exe"},
+		},
+		Service: wxsService{
+			Name:        "mysvc",
+			DisplayName: "My Service",
+			Description: "Does things",
+			Arguments:   "serve",
+			Start:       "auto",
+		},
+	})
+	require.NoError(t, err)
+	golden.RequireEqualExt(t, []byte(wxs), ".wxs")
+}
+
+func TestDefaultUpgradeCode(t *testing.T) {
+	require.Equal(t, "D8509B77-6A62-58DC-BCB8-DFF39DED61E7", defaultUpgradeCode("myproj", "myproj"))
+	require.NotEqual(t, defaultUpgradeCode("myproj", "myproj"), defaultUpgradeCode("myproj", "other"))
+}
+
+// fakeCommands puts fake commands in the PATH, which log their arguments,
+// create the file given to '-o' or '-out', and exit with the given code.
+func fakeCommands(tb testing.TB, exitCode int, names ...string) string {
+	tb.Helper()
+	scripts := map[string]string{}
+	for _, name := range names {
+		scripts[name] = `for arg in "$@"; do
+	if [ "$prev" = "-o" ] || [ "$prev" = "-out" ]; then
+		echo msi > "$arg"
+	fi
+	prev="$arg"
+done
+exit ` + strconv.Itoa(exitCode) + `
+`
+	}
+	return testlib.FakeCommands(tb, scripts)
+}
+
+func makeContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "myproj",
+		Dist:        tb.TempDir(),
+	}, testctx.WithVersion("1.2.3"), testctx.WithSemver(1, 2, 3, ""))
+	testlib.AddFakeBinaries(tb, ctx, "myproj", []string{"linux", "windows"}, []string{"amd64", "386", "arm64", "riscv64"}, "mybin.exe")
+	return ctx
+}
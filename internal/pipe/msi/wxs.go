@@ -0,0 +1,157 @@
+package msi
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/google/uuid"
+)
+
+// wxsData is the data used to render the default WXS file.
+type wxsData struct {
+	Name         string
+	Manufacturer string
+	Version      string
+	UpgradeCode  string
+	Arch         string
+	Binaries     []wxsBinary
+	Service      wxsService
+}
+
+type wxsBinary struct {
+	Name   string
+	Source string
+}
+
+type wxsService struct {
+	Name        string
+	DisplayName string
+	Description string
+	Arguments   string
+	Start       string
+}
+
+// Win64 reports whether the installer targets a 64-bit architecture.
+func (d wxsData) Win64() bool {
+	return d.Arch != "x86"
+}
+
+// GUID returns a GUID for the given component that is stable across
+// releases, as long as the upgrade code and the architecture do not change.
+func (d wxsData) GUID(component string) string {
+	ns, err := uuid.Parse(d.UpgradeCode)
+	if err != nil {
+		ns = uuid.NameSpaceOID
+	}
+	return guid(uuid.NewSHA1(ns, []byte(d.Arch+"/"+component)))
+}
+
+// defaultUpgradeCode returns an upgrade code derived from the project name
+// and the MSI ID, so it does not change between releases.
+func defaultUpgradeCode(projectName, id string) string {
+	return guid(uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://goreleaser.com/msi/"+projectName+"/"+id)))
+}
+
+func guid(u uuid.UUID) string {
+	return strings.ToUpper(u.String())
+}
+
+// defaultWXS renders the WXS file used when none is given.
+//
+// It uses the v3 schema, so it works with both msitools and the WiX Toolset
+// v3, and it:
+//   - installs the binaries into 'Program Files\<name>';
+//   - adds that directory to the system PATH;
+//   - adds a Start Menu shortcut to the first binary;
+//   - optionally installs the first binary as a Windows service;
+//   - upgrades previously installed versions.
+func defaultWXS(data wxsData) (string, error) {
+	t, err := template.New("wxs").Parse(wxsTemplate)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+const wxsTemplate = `<?xml version='1.0' encoding='windows-1252'?>
+<Wix xmlns='http://schemas.microsoft.com/wix/2006/wi'>
+  <Product
+    Id='*'
+    Name='{{ html .Name }}'
+    UpgradeCode='{{ .UpgradeCode }}'
+    Language='1033'
+    Codepage='1252'
+    Version='{{ .Version }}'
+    Manufacturer='{{ html .Manufacturer }}'>
+    <Package
+      Id='*'
+      Keywords='Installer'
+      Description='{{ html .Name }} installer'
+      Manufacturer='{{ html .Manufacturer }}'
+      InstallerVersion='{{ if eq .Arch "arm64" }}500{{ else }}200{{ end }}'
+      InstallScope='perMachine'
+      Languages='1033'
+      Compressed='yes'
+      SummaryCodepage='1252'
+      Platform='{{ .Arch }}' />
+    <MajorUpgrade DowngradeErrorMessage='A newer version of [ProductName] is already installed.' />
+    <Media Id='1' Cabinet='product.cab' EmbedCab='yes' />
+    <Directory Id='TARGETDIR' Name='SourceDir'>
+      <Directory Id='{{ if .Win64 }}ProgramFiles64Folder{{ else }}ProgramFilesFolder{{ end }}'>
+        <Directory Id='INSTALLDIR' Name='{{ html .Name }}'>
+          <Component Id='MainExecutable' Guid='{{ .GUID "MainExecutable" }}' Win64='{{ if .Win64 }}yes{{ else }}no{{ end }}'>
+{{- range $i, $bin := .Binaries }}
+            <File Id='Binary{{ $i }}' Name='{{ html $bin.Name }}' Source='{{ html $bin.Source }}' DiskId='1'{{ if eq $i 0 }} KeyPath='yes'{{ end }} />
+{{- end }}
+            <Environment Id='PATH' Name='PATH' Value='[INSTALLDIR]' Permanent='no' Part='last' Action='set' System='yes' />
+{{- with .Service }}{{ if .Name }}
+            <ServiceInstall
+              Id='ServiceInstaller'
+              Name='{{ html .Name }}'
+              DisplayName='{{ html .DisplayName }}'
+{{- with .Description }}
+              Description='{{ html . }}'
+{{- end }}
+{{- with .Arguments }}
+              Arguments='{{ html . }}'
+{{- end }}
+              Type='ownProcess'
+              Start='{{ .Start }}'
+              ErrorControl='normal' />
+            <ServiceControl
+              Id='ServiceControl'
+              Name='{{ html .Name }}'
+              Start='install'
+              Stop='both'
+              Remove='uninstall'
+              Wait='yes' />
+{{- end }}{{ end }}
+          </Component>
+        </Directory>
+      </Directory>
+      <Directory Id='ProgramMenuFolder'>
+        <Directory Id='ApplicationProgramsFolder' Name='{{ html .Name }}'>
+          <Component Id='ApplicationShortcut' Guid='{{ .GUID "ApplicationShortcut" }}'>
+            <Shortcut
+              Id='ApplicationStartMenuShortcut'
+              Name='{{ html .Name }}'
+              Target='[INSTALLDIR]{{ html (index .Binaries 0).Name }}'
+              WorkingDirectory='INSTALLDIR' />
+            <RemoveFolder Id='RemoveApplicationProgramsFolder' Directory='ApplicationProgramsFolder' On='uninstall' />
+            <RegistryValue Root='HKCU' Key='Software\{{ html .Manufacturer }}\{{ html .Name }}' Name='installed' Type='integer' Value='1' KeyPath='yes' />
+          </Component>
+        </Directory>
+      </Directory>
+    </Directory>
+    <Feature Id='Complete' Level='1'>
+      <ComponentRef Id='MainExecutable' />
+      <ComponentRef Id='ApplicationShortcut' />
+    </Feature>
+  </Product>
+</Wix>
+`
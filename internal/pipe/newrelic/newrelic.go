@@ -0,0 +1,197 @@
+// Package newrelic records releases as New Relic deployments.
+package newrelic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/caarlos0/log"
+
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultAPI                 = "https://api.newrelic.com"
+	defaultDescriptionTemplate = `{{ .ProjectName }} {{ .Tag }} was released`
+	defaultChangelogTemplate   = `{{ .ReleaseNotes }}`
+	defaultUser                = "GoReleaser"
+
+	createDeploymentMutation = `mutation($deployment: ChangeTrackingDeploymentInput!) {
+  changeTrackingCreateDeployment(deployment: $deployment) {
+    deploymentId
+  }
+}`
+)
+
+var errNoEntityGUID = errors.New("newrelic: entity_guid is required")
+
+type Pipe struct{}
+
+func (Pipe) String() string { return "newrelic" }
+func (Pipe) Skip(ctx *context.Context) (bool, error) {
+	enable, err := tmpl.New(ctx).Bool(ctx.Config.Announce.NewRelic.Enabled)
+	return !enable, err
+}
+
+type Config struct {
+	APIKey string `env:"NEW_RELIC_API_KEY,notEmpty"`
+}
+
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Announce.NewRelic
+	if cfg.API == "" {
+		cfg.API = defaultAPI
+	}
+	if cfg.DescriptionTemplate == "" {
+		cfg.DescriptionTemplate = defaultDescriptionTemplate
+	}
+	if cfg.ChangelogTemplate == "" {
+		cfg.ChangelogTemplate = defaultChangelogTemplate
+	}
+	if cfg.User == "" {
+		cfg.User = defaultUser
+	}
+	return nil
+}
+
+// Preview returns the deployment that would be created.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	_, deployment, err := newDeployment(ctx)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.MarshalIndent(deployment, "", "  ")
+	return string(bts), err
+}
+
+func (Pipe) Announce(ctx *context.Context) error {
+	api, deployment, err := newDeployment(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := env.ParseAs[Config]()
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(api)
+	if err != nil {
+		return fmt.Errorf("newrelic: invalid api: %w", err)
+	}
+	u = u.JoinPath("graphql")
+
+	bts, err := json.Marshal(Request{
+		Query: createDeploymentMutation,
+		Variables: Variables{
+			Deployment: deployment,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("posting: '%s'", deployment.Description)
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bts))
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("API-Key", cfg.APIKey)
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return retryx.HTTP(err, resp)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return retryx.HTTP(fmt.Errorf("%s", resp.Status), resp)
+		}
+
+		// GraphQL errors are reported with a 200 status code.
+		var result Response
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return retryx.Unrecoverable(fmt.Errorf("newrelic: could not decode response: %w", err))
+		}
+		if len(result.Errors) > 0 {
+			msgs := make([]string, 0, len(result.Errors))
+			for _, e := range result.Errors {
+				msgs = append(msgs, e.Message)
+			}
+			return retryx.Unrecoverable(fmt.Errorf("newrelic: %s", strings.Join(msgs, "; ")))
+		}
+
+		return nil
+	}, retryx.IsRetriable)
+}
+
+// newDeployment returns the templated API URL and the deployment to create.
+func newDeployment(ctx *context.Context) (string, Deployment, error) {
+	ncfg := ctx.Config.Announce.NewRelic
+
+	api, guid, description, changelog, user := ncfg.API, ncfg.EntityGUID, ncfg.DescriptionTemplate, ncfg.ChangelogTemplate, ncfg.User
+	if err := tmpl.New(ctx).WithMessageTemplates().ApplyAll(&api, &guid, &description, &changelog, &user); err != nil {
+		return "", Deployment{}, err
+	}
+	if guid == "" {
+		return "", Deployment{}, errNoEntityGUID
+	}
+
+	deployment := Deployment{
+		EntityGUID:  guid,
+		Version:     ctx.Version,
+		Description: description,
+		Changelog:   changelog,
+		User:        user,
+		Commit:      ctx.Git.FullCommit,
+		DeepLink:    ctx.ReleaseURL,
+	}
+	if !ctx.Date.IsZero() {
+		deployment.Timestamp = ctx.Date.UnixMilli()
+	}
+	return api, deployment, nil
+}
+
+// Request is a New Relic NerdGraph request.
+type Request struct {
+	Query     string    `json:"query"`
+	Variables Variables `json:"variables"`
+}
+
+type Variables struct {
+	Deployment Deployment `json:"deployment"`
+}
+
+// Deployment is a New Relic change tracking deployment.
+type Deployment struct {
+	EntityGUID  string `json:"entityGuid"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+	Changelog   string `json:"changelog,omitempty"`
+	User        string `json:"user,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+	DeepLink    string `json:"deepLink,omitempty"`
+	Timestamp   int64  `json:"timestamp,omitempty"`
+}
+
+type Response struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
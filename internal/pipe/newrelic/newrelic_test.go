@@ -0,0 +1,213 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringer(t *testing.T) {
+	require.Equal(t, "newrelic", Pipe{}.String())
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, defaultAPI, ctx.Config.Announce.NewRelic.API)
+	require.Equal(t, defaultDescriptionTemplate, ctx.Config.Announce.NewRelic.DescriptionTemplate)
+	require.Equal(t, defaultChangelogTemplate, ctx.Config.Announce.NewRelic.ChangelogTemplate)
+	require.Equal(t, defaultUser, ctx.Config.Announce.NewRelic.User)
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				NewRelic: config.NewRelic{
+					Enabled: "true",
+				},
+			},
+		})
+
+		skip, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+}
+
+func TestAnnounceInvalidTemplate(t *testing.T) {
+	for name, cfg := range map[string]config.NewRelic{
+		"description": {DescriptionTemplate: "{{ .Foo }", EntityGUID: "guid"},
+		"changelog":   {ChangelogTemplate: "{{ .Foo }", EntityGUID: "guid"},
+		"user":        {User: "{{ .Foo }", EntityGUID: "guid"},
+		"api":         {API: "{{ .Foo }", EntityGUID: "guid"},
+		"entity guid": {EntityGUID: "{{ .Foo }"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{
+					NewRelic: cfg,
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+		})
+	}
+}
+
+func TestAnnounceMissingEntityGUID(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorIs(t, Pipe{}.Announce(ctx), errNoEntityGUID)
+}
+
+func TestAnnounceMissingEnv(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			NewRelic: config.NewRelic{
+				EntityGUID: "guid",
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "NEW_RELIC_API_KEY" should not be empty`)
+}
+
+func TestAnnounce(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "api-key", r.Header.Get("API-Key"))
+
+		var req Request
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &req))
+		assert.Equal(t, createDeploymentMutation, req.Query)
+		assert.Equal(t, Deployment{
+			EntityGUID:  "MXxBUE18QVBQTElDQVRJT058MQ",
+			Version:     "1.1.0",
+			Description: "honk v1.1.0 was released",
+			Changelog:   "- fixed the honk",
+			User:        "GoReleaser",
+			Commit:      "abcdef",
+			DeepLink:    "https://github.com/honk/honk/releases/tag/v1.1.0",
+			Timestamp:   1767323045000,
+		}, req.Variables.Deployment)
+
+		_, _ = w.Write([]byte(`{"data":{"changeTrackingCreateDeployment":{"deploymentId":"123"}}}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			NewRelic: config.NewRelic{
+				API:        ts.URL,
+				EntityGUID: "{{ .Env.NEW_RELIC_ENTITY_GUID }}",
+			},
+		},
+	},
+		testctx.WithCurrentTag("v1.1.0"),
+		testctx.WithVersion("1.1.0"),
+		testctx.WithCommit("abcdef"),
+		testctx.WithEnv(map[string]string{"NEW_RELIC_ENTITY_GUID": "MXxBUE18QVBQTElDQVRJT058MQ"}),
+		testctx.WithDate(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	)
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.1.0"
+	ctx.ReleaseNotes = "- fixed the honk"
+	t.Setenv("NEW_RELIC_API_KEY", "api-key")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+}
+
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			NewRelic: config.NewRelic{
+				EntityGUID: "MXxBUE18QVBQTElDQVRJT058MQ",
+			},
+		},
+	},
+		testctx.WithCurrentTag("v1.1.0"),
+		testctx.WithVersion("1.1.0"),
+		testctx.WithCommit("abcdef"),
+		testctx.WithDate(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	)
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.1.0"
+	ctx.ReleaseNotes = "- fixed the honk"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+
+	var deployment Deployment
+	require.NoError(t, json.Unmarshal([]byte(out), &deployment))
+	require.Equal(t, Deployment{
+		EntityGUID:  "MXxBUE18QVBQTElDQVRJT058MQ",
+		Version:     "1.1.0",
+		Description: "honk v1.1.0 was released",
+		Changelog:   "- fixed the honk",
+		User:        "GoReleaser",
+		Commit:      "abcdef",
+		DeepLink:    "https://github.com/honk/honk/releases/tag/v1.1.0",
+		Timestamp:   1767323045000,
+	}, deployment)
+}
+
+func TestAnnounceError(t *testing.T) {
+	for name, tt := range map[string]struct {
+		status int
+		body   string
+		expect string
+	}{
+		"status": {
+			status: http.StatusUnauthorized,
+			expect: "401 Unauthorized",
+		},
+		"graphql": {
+			status: http.StatusOK,
+			body:   `{"errors":[{"message":"invalid entity"},{"message":"invalid version"}]}`,
+			expect: "newrelic: invalid entity; invalid version",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(ts.Close)
+
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{
+					NewRelic: config.NewRelic{
+						API:        ts.URL,
+						EntityGUID: "guid",
+					},
+				},
+			})
+			t.Setenv("NEW_RELIC_API_KEY", "api-key")
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.ErrorContains(t, Pipe{}.Announce(ctx), tt.expect)
+		})
+	}
+}
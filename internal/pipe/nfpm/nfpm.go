@@ -80,6 +80,11 @@ func (Pipe) Default(ctx *context.Context) error {
 			deprecate.Notice(ctx, "nfpms.builds")
 			fpm.IDs = append(fpm.IDs, fpm.Builds...)
 		}
+		for _, pkg := range fpm.Packages {
+			if pkg.PackageName == "" {
+				return fmt.Errorf("nfpm %q: package_name is required for all packages", fpm.ID)
+			}
+		}
 		ids.Inc(fpm.ID)
 	}
 
@@ -452,6 +457,11 @@ func create(ctx *context.Context, fpm config.NFPM, format string, artifacts []*a
 		}
 	}
 
+	contents, packages, err := splitPackages(t, fpm.Packages, packageName, contents)
+	if err != nil {
+		return err
+	}
+
 	log.WithField("files", destinations(contents)).Debug("all archive files")
 
 	info := &nfpm.Info{
@@ -604,6 +614,11 @@ func create(ctx *context.Context, fpm config.NFPM, format string, artifacts []*a
 		info.MSIX.Signature = nfpm.MSIXSignature{}
 	}
 
+	infos := []*nfpm.Info{info}
+	for _, pkg := range packages {
+		infos = append(infos, pkg.info(info))
+	}
+
 	packager, err := nfpm.Get(strings.Replace(format, "termux.", "", 1))
 	if err != nil {
 		return err
@@ -616,11 +631,31 @@ func create(ctx *context.Context, fpm config.NFPM, format string, artifacts []*a
 		}
 	}
 
+	for _, info := range infos {
+		if err := write(ctx, fpm, format, ext, overridden.FileNameTemplate, t, packager, info, artifacts[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write creates the package described by info, and adds it to the artifacts.
+func write(
+	ctx *context.Context,
+	fpm config.NFPM,
+	format, ext, fileNameTemplate string,
+	t *tmpl.Template,
+	packager nfpm.Packager,
+	info *nfpm.Info,
+	art *artifact.Artifact,
+) error {
+	contents := info.Contents
 	info = nfpm.WithDefaults(info)
 	packageFilename, err := t.WithExtraFields(tmpl.Fields{
+		"PackageName":           info.Name,
 		"ConventionalFileName":  packager.ConventionalFileName(info),
 		"ConventionalExtension": ext,
-	}).Apply(overridden.FileNameTemplate)
+	}).Apply(fileNameTemplate)
 	if err != nil {
 		return err
 	}
@@ -630,7 +665,10 @@ func create(ctx *context.Context, fpm config.NFPM, format string, artifacts []*a
 	}
 
 	path := filepath.Join(ctx.Config.Dist, packageFilename)
-	log.WithField("file", path).Info("creating")
+	log.WithField("package", info.Name).
+		WithField("format", format).
+		WithField("file", path).
+		Info("creating")
 	w, err := os.Create(path)
 	if err != nil {
 		return err
@@ -656,11 +694,11 @@ func create(ctx *context.Context, fpm config.NFPM, format string, artifacts []*a
 		Type:    packageType,
 		Name:    packageFilename,
 		Path:    path,
-		Goos:    artifacts[0].Goos,
-		Goarch:  artifacts[0].Goarch,
-		Goarm:   artifacts[0].Goarm,
-		Gomips:  artifacts[0].Gomips,
-		Goamd64: artifacts[0].Goamd64,
+		Goos:    art.Goos,
+		Goarch:  art.Goarch,
+		Goarm:   art.Goarm,
+		Gomips:  art.Gomips,
+		Goamd64: art.Goamd64,
 		Extra: map[string]any{
 			artifact.ExtraID:     fpm.ID,
 			artifact.ExtraFormat: format,
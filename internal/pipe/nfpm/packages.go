@@ -0,0 +1,112 @@
+package nfpm
+
+import (
+	"fmt"
+
+	"github.com/gobwas/glob"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/nfpm/v2"
+	"github.com/goreleaser/nfpm/v2/files"
+)
+
+// subPackage is an additional package created from the same nfpm config.
+type subPackage struct {
+	cfg      config.NFPMPackage
+	contents files.Contents
+}
+
+// info returns the info of the package, based on the main package's.
+//
+// Everything but the name, the description, the relationships, and the
+// contents is inherited, except for the scripts, which only belong to the
+// main package.
+func (p subPackage) info(main *nfpm.Info) *nfpm.Info {
+	info := *main
+	info.Name = p.cfg.PackageName
+	if p.cfg.Description != "" {
+		info.Description = p.cfg.Description
+	}
+	info.Depends = p.cfg.Dependencies
+	info.Recommends = p.cfg.Recommends
+	info.Suggests = p.cfg.Suggests
+	info.Conflicts = p.cfg.Conflicts
+	info.Replaces = p.cfg.Replaces
+	info.Provides = p.cfg.Provides
+	info.Contents = p.contents
+	info.Scripts = nfpm.Scripts{}
+	info.Deb.Scripts = nfpm.DebScripts{}
+	info.Deb.Triggers = nfpm.DebTriggers{}
+	info.RPM.Scripts = nfpm.RPMScripts{}
+	info.APK.Scripts = nfpm.APKScripts{}
+	info.ArchLinux.Scripts = nfpm.ArchLinuxScripts{}
+	return &info
+}
+
+// splitPackages moves the contents matching the filters of each additional
+// package out of the main package.
+// Contents are matched by their destination, and go to the first package
+// that matches them.
+func splitPackages(
+	t *tmpl.Template,
+	packages []config.NFPMPackage,
+	mainName string,
+	contents files.Contents,
+) (files.Contents, []subPackage, error) {
+	names := map[string]bool{mainName: true}
+	result := make([]subPackage, 0, len(packages))
+	for _, cfg := range packages {
+		if err := t.ApplyAll(&cfg.PackageName, &cfg.Description); err != nil {
+			return nil, nil, err
+		}
+		if names[cfg.PackageName] {
+			return nil, nil, fmt.Errorf("nfpm: duplicated package name: %q", cfg.PackageName)
+		}
+		names[cfg.PackageName] = true
+
+		for _, field := range []*[]string{
+			&cfg.Contents,
+			&cfg.Dependencies,
+			&cfg.Recommends,
+			&cfg.Suggests,
+			&cfg.Conflicts,
+			&cfg.Replaces,
+			&cfg.Provides,
+		} {
+			if err := t.ApplySlice(field, tmpl.NonEmpty()); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		globs := make([]glob.Glob, 0, len(cfg.Contents))
+		for _, pattern := range cfg.Contents {
+			g, err := glob.Compile(pattern, '/')
+			if err != nil {
+				return nil, nil, fmt.Errorf("nfpm: invalid contents filter for package %q: %w", cfg.PackageName, err)
+			}
+			globs = append(globs, g)
+		}
+
+		pkg := subPackage{cfg: cfg}
+		var rest files.Contents
+		for _, content := range contents {
+			if matchesAny(globs, content.Destination) {
+				pkg.contents = append(pkg.contents, content)
+				continue
+			}
+			rest = append(rest, content)
+		}
+		contents = rest
+		result = append(result, pkg)
+	}
+	return contents, result, nil
+}
+
+func matchesAny(globs []glob.Glob, s string) bool {
+	for _, g := range globs {
+		if g.Match(s) {
+			return true
+		}
+	}
+	return false
+}
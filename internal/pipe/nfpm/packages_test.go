@@ -0,0 +1,236 @@
+package nfpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/goreleaser/nfpm/v2"
+	"github.com/goreleaser/nfpm/v2/files"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPipePackages(t *testing.T) {
+	ctx := makePackagesContext(t, []config.NFPMPackage{
+		{
+			PackageName:  "{{ .PackageName }}-doc",
+			Description:  "Docs for {{ .PackageName }}",
+			Contents:     []string{"/usr/share/doc/**"},
+			Dependencies: []string{"{{ .PackageName }}"},
+		},
+		{
+			PackageName: "{{ .PackageName }}-completion",
+			Contents:    []string{"/usr/share/bash-completion/**", "/usr/share/zsh/**"},
+			Suggests:    []string{"bash-completion", ""},
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	packages := ctx.Artifacts.Filter(artifact.ByType(artifact.LinuxPackage)).List()
+	require.Len(t, packages, 6)
+
+	byName := map[string]*artifact.Artifact{}
+	for _, pkg := range packages {
+		require.Equal(t, "someid", pkg.ID())
+		byName[pkg.Name] = pkg
+	}
+	for _, format := range []string{"deb", "rpm"} {
+		main := byName["foo_1.0.0_linux_amd64."+format]
+		require.NotNil(t, main, format)
+		require.ElementsMatch(t, []string{
+			"/usr/bin/mybin",
+			"/etc/foo.conf",
+			"/usr/share/fish/vendor_completions.d/foo.fish",
+		}, destinations(artifact.MustExtra[files.Contents](*main, extraFiles)))
+
+		doc := byName["foo-doc_1.0.0_linux_amd64."+format]
+		require.NotNil(t, doc, format)
+		require.ElementsMatch(t, []string{
+			"/usr/share/doc/foo/README",
+		}, destinations(artifact.MustExtra[files.Contents](*doc, extraFiles)))
+
+		completion := byName["foo-completion_1.0.0_linux_amd64."+format]
+		require.NotNil(t, completion, format)
+		require.ElementsMatch(t, []string{
+			"/usr/share/bash-completion/completions/foo",
+			"/usr/share/zsh/vendor-completions/_foo",
+		}, destinations(artifact.MustExtra[files.Contents](*completion, extraFiles)))
+	}
+
+	require.Len(t, ctx.Config.NFPMs[0].Contents, 5, "should not modify the config file list")
+}
+
+func TestRunPipePackagesDuplicatedName(t *testing.T) {
+	ctx := makePackagesContext(t, []config.NFPMPackage{
+		{PackageName: "{{ .PackageName }}"},
+	})
+	require.EqualError(t, Pipe{}.Run(ctx), `nfpm: duplicated package name: "foo"`)
+}
+
+func TestRunPipePackagesInvalidFilter(t *testing.T) {
+	ctx := makePackagesContext(t, []config.NFPMPackage{
+		{PackageName: "foo-doc", Contents: []string{"/usr/share/[doc"}},
+	})
+	require.ErrorContains(t, Pipe{}.Run(ctx), `nfpm: invalid contents filter for package "foo-doc"`)
+}
+
+func TestRunPipePackagesInvalidTemplate(t *testing.T) {
+	for name, pkg := range map[string]config.NFPMPackage{
+		"package_name": {PackageName: "{{ .Nope }}"},
+		"description":  {PackageName: "foo-doc", Description: "{{ .Nope }}"},
+		"contents":     {PackageName: "foo-doc", Contents: []string{"{{ .Nope }}"}},
+		"dependencies": {PackageName: "foo-doc", Dependencies: []string{"{{ .Nope }}"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := makePackagesContext(t, []config.NFPMPackage{pkg})
+			testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+		})
+	}
+}
+
+func TestDefaultPackagesNoName(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		NFPMs: []config.NFPM{
+			{
+				ID: "someid",
+				Packages: []config.NFPMPackage{
+					{Contents: []string{"/usr/share/doc/**"}},
+				},
+			},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), `nfpm "someid": package_name is required for all packages`)
+}
+
+func TestSplitPackages(t *testing.T) {
+	contents := files.Contents{
+		{Destination: "/usr/bin/foo"},
+		{Destination: "/usr/share/doc/foo/README"},
+		{Destination: "/usr/share/doc/foo/LICENSE"},
+		{Destination: "/usr/share/man/man1/foo.1.gz"},
+	}
+	ctx := testctx.Wrap(t.Context())
+	rest, packages, err := splitPackages(tmpl.New(ctx), []config.NFPMPackage{
+		{PackageName: "foo-doc", Contents: []string{"/usr/share/**"}},
+		{PackageName: "foo-man", Contents: []string{"/usr/share/man/**"}},
+	}, "foo", contents)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/usr/bin/foo"}, destinations(rest))
+	require.Len(t, packages, 2)
+	require.Equal(t, []string{
+		"/usr/share/doc/foo/README",
+		"/usr/share/doc/foo/LICENSE",
+		"/usr/share/man/man1/foo.1.gz",
+	}, destinations(packages[0].contents), "the first matching package should win")
+	require.Empty(t, packages[1].contents)
+}
+
+func TestSubPackageInfo(t *testing.T) {
+	main := &nfpm.Info{
+		Name:        "foo",
+		Version:     "1.0.0",
+		Description: "Foo",
+		Overridables: nfpm.Overridables{
+			Depends: []string{"bar"},
+			Scripts: nfpm.Scripts{
+				PostInstall: "./testdata/post_install.sh",
+			},
+			Deb: nfpm.Deb{
+				Scripts: nfpm.DebScripts{
+					Rules: "./testdata/post_install.sh",
+				},
+			},
+		},
+	}
+	info := subPackage{
+		cfg: config.NFPMPackage{
+			PackageName:  "foo-doc",
+			Dependencies: []string{"foo"},
+		},
+		contents: files.Contents{{Destination: "/usr/share/doc/foo/README"}},
+	}.info(main)
+
+	require.Equal(t, "foo-doc", info.Name)
+	require.Equal(t, "1.0.0", info.Version)
+	require.Equal(t, "Foo", info.Description)
+	require.Equal(t, []string{"foo"}, info.Depends)
+	require.Empty(t, info.Scripts)
+	require.Empty(t, info.Deb.Scripts)
+	require.Equal(t, []string{"/usr/share/doc/foo/README"}, destinations(info.Contents))
+
+	require.Equal(t, "foo", main.Name, "should not modify the main package")
+	require.Equal(t, []string{"bar"}, main.Depends)
+	require.Equal(t, "./testdata/post_install.sh", main.Scripts.PostInstall)
+}
+
+func makePackagesContext(tb testing.TB, packages []config.NFPMPackage) *context.Context {
+	tb.Helper()
+	dist := filepath.Join(tb.TempDir(), "dist")
+	require.NoError(tb, os.MkdirAll(filepath.Join(dist, "mybin"), 0o755))
+	binPath := filepath.Join(dist, "mybin", "mybin")
+	require.NoError(tb, os.WriteFile(binPath, nil, 0o755))
+
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "mybin",
+		Dist:        dist,
+		NFPMs: []config.NFPM{
+			{
+				ID:          "someid",
+				Bindir:      "/usr/bin",
+				Formats:     []string{"deb", "rpm"},
+				Description: "Some description",
+				License:     "MIT",
+				Maintainer:  "me@me",
+				Packages:    packages,
+				NFPMOverridables: config.NFPMOverridables{
+					FileNameTemplate: defaultNameTemplate,
+					PackageName:      "foo",
+					Contents: []config.NFPMContent{
+						{
+							Source:      "./testdata/testfile.txt",
+							Destination: "/etc/foo.conf",
+							Type:        "config",
+						},
+						{
+							Source:      "./testdata/testfile.txt",
+							Destination: "/usr/share/doc/foo/README",
+						},
+						{
+							Source:      "./testdata/testfile.txt",
+							Destination: "/usr/share/bash-completion/completions/foo",
+						},
+						{
+							Source:      "./testdata/testfile.txt",
+							Destination: "/usr/share/zsh/vendor-completions/_foo",
+						},
+						{
+							Source:      "./testdata/testfile.txt",
+							Destination: "/usr/share/fish/vendor_completions.d/foo.fish",
+						},
+					},
+				},
+			},
+		},
+	}, testctx.WithVersion("1.0.0"), testctx.WithCurrentTag("v1.0.0"))
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:    "mybin",
+		Path:    binPath,
+		Goarch:  "amd64",
+		Goamd64: "v1",
+		Goos:    "linux",
+		Type:    artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraID: "default",
+		},
+	})
+	require.NoError(tb, Pipe{}.Default(ctx))
+	return ctx
+}
@@ -87,6 +87,10 @@ func (Pipe) Default(ctx *context.Context) error {
 		if nix.License != "" && !slices.Contains(validLicenses, nix.License) {
 			return fmt.Errorf("%w: %s", errInvalidLicense, nix.License)
 		}
+		if nix.Flake.Enabled {
+			nix.Flake.Path = cmp.Or(nix.Flake.Path, "flake.nix")
+			nix.Flake.Nixpkgs = cmp.Or(nix.Flake.Nixpkgs, defaultFlakeNixpkgs)
+		}
 	}
 
 	return nil
@@ -152,6 +156,8 @@ func (p Pipe) doRun(ctx *context.Context, nix config.Nix, cl client.ReleaseURLTe
 		&nix.Description,
 		&nix.Path,
 		&nix.MainProgram,
+		&nix.Flake.Path,
+		&nix.Flake.Nixpkgs,
 	)
 	if err != nil {
 		return err
@@ -171,7 +177,7 @@ func (p Pipe) doRun(ctx *context.Context, nix config.Nix, cl client.ReleaseURLTe
 		return err
 	}
 
-	content, err := preparePkg(ctx, nix, cl, p.hasher)
+	content, platforms, err := preparePkg(ctx, nix, cl, p.hasher)
 	if err != nil {
 		return err
 	}
@@ -194,6 +200,32 @@ func (p Pipe) doRun(ctx *context.Context, nix config.Nix, cl client.ReleaseURLTe
 		},
 	})
 
+	if !nix.Flake.Enabled {
+		return nil
+	}
+
+	flake, err := doBuildFlake(nix, content, platforms)
+	if err != nil {
+		return err
+	}
+
+	flakePath := filepath.Join(ctx.Config.Dist, "nix", nix.Flake.Path)
+	if err := os.MkdirAll(filepath.Dir(flakePath), 0o755); err != nil {
+		return err
+	}
+	log.WithField("flake", flakePath).Info("writing")
+	if err := os.WriteFile(flakePath, []byte(flake), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to write nix flake: %w", err)
+	}
+
+	if nix.Flake.Release {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: filepath.Base(flakePath),
+			Path: flakePath,
+			Type: artifact.UploadableFile,
+		})
+	}
+
 	return nil
 }
 
@@ -223,7 +255,7 @@ func preparePkg(
 	nix config.Nix,
 	cli client.ReleaseURLTemplater,
 	hasher fileHasher,
-) (string, error) {
+) (string, []string, error) {
 	filters := []artifact.Filter{
 		artifact.ByGooses("darwin", "linux"),
 		artifact.Or(
@@ -254,7 +286,7 @@ func preparePkg(
 
 	archives := ctx.Artifacts.Filter(artifact.And(filters...)).List()
 	if len(archives) == 0 {
-		return "", errNoArchivesFound{
+		return "", nil, errNoArchivesFound{
 			goamd64: nix.Goamd64,
 			ids:     nix.IDs,
 		}
@@ -263,19 +295,19 @@ func preparePkg(
 	if nix.URLTemplate == "" {
 		url, err := cli.ReleaseURLTemplate(ctx)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 		nix.URLTemplate = url
 	}
 
 	installs, err := installs(ctx, nix, archives[0])
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	postInstall, err := postInstall(ctx, nix, archives[0])
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	inputs := []string{"installShellFiles"}
@@ -319,11 +351,11 @@ func preparePkg(
 	for _, art := range archives {
 		sha, err := hasher.Hash(ctx, art.Path)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 		url, err := tmpl.New(ctx).WithArtifact(art).Apply(nix.URLTemplate)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 		archive := Archive{
 			URL: url,
@@ -333,14 +365,14 @@ func preparePkg(
 		for _, goarch := range expandGoarch(art.Goarch) {
 			key := art.Goos + goarch + art.Goarm
 			if _, ok := data.Archives[key]; ok {
-				return "", ErrMultipleArchivesSamePlatform
+				return "", nil, ErrMultipleArchivesSamePlatform
 			}
 			folder := cmp.Or(artifact.ExtraOr(*art, artifact.ExtraWrappedIn, ""), ".")
 			data.SourceRoots[key] = folder
 			data.Archives[key] = archive
 			plat := goosToPlatform[art.Goos+goarch+art.Goarm]
 			if plat == "" {
-				return "", errors.New("invalid platform: " + art.Goos + goarch + art.Goarm)
+				return "", nil, errors.New("invalid platform: " + art.Goos + goarch + art.Goarm)
 			}
 			platforms[plat] = true
 		}
@@ -351,7 +383,8 @@ func preparePkg(
 	}
 	data.Platforms = slices.Sorted(maps.Keys(platforms))
 
-	return doBuildPkg(ctx, data)
+	pkg, err := doBuildPkg(ctx, data)
+	return pkg, data.Platforms, err
 }
 
 func expandGoarch(goarch string) []string {
@@ -396,14 +429,29 @@ func doPublish(ctx *context.Context, hasher fileHasher, cl client.Client, pkg *a
 		return err
 	}
 
-	content, err := preparePkg(ctx, nix, cl, hasher)
+	content, platforms, err := preparePkg(ctx, nix, cl, hasher)
 	if err != nil {
 		return err
 	}
 
+	files := []client.RepoFile{{
+		Content: []byte(content),
+		Path:    gpath,
+	}}
+	if nix.Flake.Enabled {
+		flake, err := doBuildFlake(nix, content, platforms)
+		if err != nil {
+			return err
+		}
+		files = append(files, client.RepoFile{
+			Content: []byte(flake),
+			Path:    nix.Flake.Path,
+		})
+	}
+
 	if nix.Repository.Git.URL != "" {
 		return client.NewGitUploadClient(repo.Branch).
-			CreateFile(ctx, author, repo, []byte(content), gpath, msg)
+			CreateFiles(ctx, author, repo, msg, files)
 	}
 
 	cl, err = client.NewIfToken(ctx, cl, nix.Repository.Token)
@@ -425,8 +473,10 @@ func doPublish(ctx *context.Context, hasher fileHasher, cl client.Client, pkg *a
 		}
 	}
 
-	if err := cl.CreateFile(ctx, author, repo, []byte(content), gpath, msg); err != nil {
-		return err
+	for _, file := range files {
+		if err := cl.CreateFile(ctx, author, repo, file.Content, file.Path, msg); err != nil {
+			return err
+		}
 	}
 
 	if !nix.Repository.PullRequest.Enabled {
@@ -440,7 +490,7 @@ func doPublish(ctx *context.Context, hasher fileHasher, cl client.Client, pkg *a
 		return errors.New("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, msg, nix.Repository.PullRequest.Draft)
+	return pcl.OpenPullRequest(ctx, base, repo, msg, nix.Repository.PullRequest.Body, nix.Repository.PullRequest.Draft)
 }
 
 func doBuildPkg(ctx *context.Context, data templateData) (string, error) {
@@ -478,6 +528,38 @@ func doBuildPkg(ctx *context.Context, data templateData) (string, error) {
 	return out.String(), nil
 }
 
+func doBuildFlake(nix config.Nix, pkg string, platforms []string) (string, error) {
+	t, err := template.New("flake").Parse(string(flakeTmpl))
+	if err != nil {
+		return "", err
+	}
+
+	// Indent the package so it can be used as an expression inside the
+	// flake, skipping its header comments.
+	var lines []string
+	for line := range strings.SplitSeq(strings.TrimSpace(pkg), "\n") {
+		if len(lines) == 0 && strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line != "" {
+			line = "        " + line
+		}
+		lines = append(lines, line)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, flakeData{
+		Name:        nix.Name,
+		Description: nix.Description,
+		Nixpkgs:     nix.Flake.Nixpkgs,
+		Platforms:   platforms,
+		Package:     strings.Join(lines, "\n"),
+	}); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
 func postInstall(ctx *context.Context, nix config.Nix, art *artifact.Artifact) ([]string, error) {
 	applied, err := tmpl.New(ctx).WithArtifact(art).Apply(nix.PostInstall)
 	if err != nil {
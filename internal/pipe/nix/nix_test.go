@@ -796,6 +796,88 @@ func TestDynamicallyLinked(t *testing.T) {
 	golden.RequireEqual(t, content)
 }
 
+func TestFlake(t *testing.T) {
+	folder := t.TempDir()
+	ctx := testctx.WrapWithCfg(
+		t.Context(),
+		config.Project{
+			Dist:        folder,
+			ProjectName: "foo",
+			Nix: []config.Nix{{
+				IDs:         []string{"dynlink"},
+				Description: "Software to create fast and easy drum rolls.",
+				Repository: config.RepoRef{
+					Owner: "foo",
+					Name:  "bar",
+				},
+				Flake: config.NixFlake{
+					Enabled: true,
+					Nixpkgs: "github:NixOS/nixpkgs/nixos-25.05",
+					Release: true,
+				},
+			}},
+		},
+		testctx.WithVersion("1.0.0"),
+		testctx.WithCurrentTag("v1.0.0"),
+	)
+
+	for _, goos := range []string{"linux", "darwin"} {
+		for _, goarch := range []string{"amd64", "arm64"} {
+			name := "foo_" + goos + "_" + goarch + ".tar.gz"
+			path := filepath.Join(folder, "dist", name)
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name:   name,
+				Path:   path,
+				Goos:   goos,
+				Goarch: goarch,
+				Type:   artifact.UploadableArchive,
+				Extra: map[string]any{
+					artifact.ExtraID:        "dynlink",
+					artifact.ExtraFormat:    "tar.gz",
+					artifact.ExtraBinaries:  []string{"foo"},
+					artifact.ExtraWrappedIn: "",
+					artifact.ExtranDynLink:  goos == "linux",
+				},
+			})
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, nil, 0o644))
+		}
+	}
+
+	client := client.NewMock()
+	pipe := Pipe{alwaysZeroHasher{}}
+
+	require.NoError(t, pipe.Default(ctx))
+	require.Equal(t, "flake.nix", ctx.Config.Nix[0].Flake.Path)
+	require.NoError(t, pipe.runAll(ctx, client))
+
+	flakes := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableFile)).List()
+	require.Len(t, flakes, 1)
+	require.Equal(t, "flake.nix", flakes[0].Name)
+
+	content, err := os.ReadFile(flakes[0].Path)
+	require.NoError(t, err)
+	golden.RequireEqualExt(t, content, ".nix")
+
+	require.NoError(t, pipe.publishAll(ctx, client))
+	require.Len(t, client.Messages, 2)
+	require.Equal(t, "flake.nix", client.Path)
+	require.Equal(t, string(content), client.Content)
+}
+
+func TestFlakeDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Nix: []config.Nix{
+			{},
+			{Flake: config.NixFlake{Enabled: true}},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Empty(t, ctx.Config.Nix[0].Flake.Path)
+	require.Equal(t, "flake.nix", ctx.Config.Nix[1].Flake.Path)
+	require.Equal(t, defaultFlakeNixpkgs, ctx.Config.Nix[1].Flake.Nixpkgs)
+}
+
 func TestFormat(t *testing.T) {
 	testlib.SkipIfWindows(t, "nix.format won't work on Windows")
 
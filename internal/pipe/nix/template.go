@@ -5,6 +5,11 @@ import _ "embed"
 //go:embed tmpl.nix
 var pkgTmpl []byte
 
+//go:embed tmpl_flake.nix
+var flakeTmpl []byte
+
+const defaultFlakeNixpkgs = "github:NixOS/nixpkgs/nixos-unstable"
+
 type Archive struct {
 	URL, Sha string
 }
@@ -26,3 +31,11 @@ type templateData struct {
 	Dependencies      []string
 	DynamicallyLinked bool
 }
+
+type flakeData struct {
+	Name        string
+	Description string
+	Nixpkgs     string
+	Platforms   []string
+	Package     string
+}
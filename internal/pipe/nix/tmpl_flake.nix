@@ -0,0 +1,28 @@
+# This file was generated by GoReleaser. DO NOT EDIT.
+# vim: set ft=nix ts=2 sw=2 sts=2 et sta
+{
+  {{- with .Description }}
+  description = "{{ . }}";
+  {{- end }}
+
+  inputs.nixpkgs.url = "{{ .Nixpkgs }}";
+
+  outputs =
+    { self, nixpkgs }:
+    let
+      systems = [
+        {{- range .Platforms }}
+        "{{ . }}"
+        {{- end }}
+      ];
+      forAllSystems = nixpkgs.lib.genAttrs systems;
+      package =
+{{ .Package }};
+    in
+    {
+      packages = forAllSystems (system: {
+        "{{ .Name }}" = nixpkgs.legacyPackages.${system}.callPackage package { };
+        default = self.packages.${system}."{{ .Name }}";
+      });
+    };
+}
@@ -0,0 +1,135 @@
+package notary
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/redact"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/goreleaser/quill/quill/pki/load"
+)
+
+// ConfigFor returns the first enabled macOS notarize configuration that
+// applies to any of the given IDs, with its templates applied.
+//
+// This is used by pipes creating artifacts that contain the binaries, like
+// app bundles and disk images, so they can be signed and notarized with the
+// same configuration.
+func ConfigFor(ctx *context.Context, ids ...string) (config.MacOSSignNotarize, bool, error) {
+	if skips.Any(ctx, skips.Notarize) {
+		return config.MacOSSignNotarize{}, false, nil
+	}
+	for _, cfg := range ctx.Config.Notarize.MacOS {
+		enabled, err := tmpl.New(ctx).Bool(cfg.Enabled)
+		if err != nil {
+			return cfg, false, err
+		}
+		if !enabled || !slices.ContainsFunc(ids, func(id string) bool {
+			return slices.Contains(cfg.IDs, id)
+		}) {
+			continue
+		}
+		if err := tmpl.New(ctx).ApplyAll(
+			&cfg.Sign.Certificate,
+			&cfg.Sign.Password,
+			&cfg.Sign.Entitlements,
+			&cfg.Notarize.Key,
+			&cfg.Notarize.KeyID,
+			&cfg.Notarize.IssuerID,
+		); err != nil {
+			return cfg, false, err
+		}
+		return cfg, true, nil
+	}
+	return config.MacOSSignNotarize{}, false, nil
+}
+
+// Codesign signs the given path with codesign, using a temporary keychain
+// with the certificate of the given configuration.
+//
+// Unlike quill, codesign can sign app bundles and disk images, but it only
+// works on macOS.
+func Codesign(ctx *context.Context, sign config.MacOSSign, path string, args ...string) error {
+	p12, err := load.P12(sign.Certificate, sign.Password)
+	if err != nil {
+		return err
+	}
+	bts, err := load.BytesFromFileOrEnv(sign.Certificate)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp("", "goreleaser-codesign-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	certificate := filepath.Join(tmp, "certificate.p12")
+	if err := os.WriteFile(certificate, bts, 0o600); err != nil {
+		return err
+	}
+
+	keychain := filepath.Join(tmp, "goreleaser.keychain-db")
+	password := rand.Text()
+	if err := run(ctx, "could not create keychain", "security", "create-keychain", "-p", password, keychain); err != nil {
+		return err
+	}
+	defer func() {
+		_ = run(ctx, "could not delete keychain", "security", "delete-keychain", keychain)
+	}()
+
+	for _, step := range []struct {
+		msg  string
+		args []string
+	}{
+		{"could not unlock keychain", []string{"unlock-keychain", "-p", password, keychain}},
+		{"could not import certificate", []string{"import", certificate, "-k", keychain, "-P", sign.Password, "-T", "/usr/bin/codesign"}},
+		{"could not allow codesign to use the certificate", []string{"set-key-partition-list", "-S", "apple-tool:,apple:", "-s", "-k", password, keychain}},
+	} {
+		if err := run(ctx, step.msg, "security", step.args...); err != nil {
+			return err
+		}
+	}
+
+	arg := []string{
+		"--force",
+		"--timestamp",
+		"--keychain", keychain,
+		"--sign", p12.Certificate.Subject.CommonName,
+	}
+	arg = append(arg, args...)
+	return run(ctx, "could not sign "+filepath.Base(path), "codesign", append(arg, path)...)
+}
+
+// run runs the given command.
+// Its arguments are not added to the error, as they might contain passwords.
+func run(ctx *context.Context, msg, name string, arg ...string) error {
+	cmd := exec.CommandContext(ctx, name, arg...)
+	cmd.Env = append(ctx.Env.Strings(), cmd.Environ()...)
+	var b bytes.Buffer
+	w := gio.Safe(&b)
+	cmd.Stderr = redact.Writer(io.MultiWriter(logext.NewWriter(), w), cmd.Env)
+	cmd.Stdout = redact.Writer(io.MultiWriter(logext.NewWriter(), w), cmd.Env)
+	if err := cmd.Run(); err != nil {
+		return gerrors.Wrap(
+			err,
+			gerrors.WithMessage(msg),
+			gerrors.WithDetails("cmd", strings.Join(cmd.Args[:min(len(cmd.Args), 2)], " ")),
+			gerrors.WithOutput(b.String()),
+		)
+	}
+	return nil
+}
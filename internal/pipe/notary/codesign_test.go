@@ -0,0 +1,80 @@
+package notary
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFor(t *testing.T) {
+	notarize := []config.MacOSSignNotarize{
+		{IDs: []string{"bar"}, Enabled: "true"},
+		{IDs: []string{"foo"}, Enabled: "false"},
+		{
+			IDs:     []string{"foo"},
+			Enabled: "true",
+			Sign: config.MacOSSign{
+				Password: "{{ .Env.PASSWORD }}",
+			},
+		},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env:      []string{"PASSWORD=secret"},
+			Notarize: config.Notarize{MacOS: notarize},
+		})
+		cfg, ok, err := ConfigFor(ctx, "nope", "foo")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "secret", cfg.Sign.Password)
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Notarize: config.Notarize{MacOS: notarize},
+		}, testctx.Skip(skips.Notarize))
+		_, ok, err := ConfigFor(ctx, "foo")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Notarize: config.Notarize{MacOS: notarize},
+		})
+		_, ok, err := ConfigFor(ctx, "nope")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("bad enabled template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Notarize: config.Notarize{MacOS: []config.MacOSSignNotarize{
+				{IDs: []string{"foo"}, Enabled: "{{ .Nope }}"},
+			}},
+		})
+		_, _, err := ConfigFor(ctx, "foo")
+		testlib.RequireTemplateError(t, err)
+	})
+
+	t.Run("bad sign template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Notarize: config.Notarize{MacOS: []config.MacOSSignNotarize{
+				{
+					IDs:     []string{"foo"},
+					Enabled: "true",
+					Sign: config.MacOSSign{
+						Certificate: "{{ .Nope }}",
+					},
+				},
+			}},
+		})
+		_, _, err := ConfigFor(ctx, "foo")
+		testlib.RequireTemplateError(t, err)
+	})
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/goreleaser/quill/quill"
-	"github.com/goreleaser/quill/quill/notary"
 	"github.com/goreleaser/quill/quill/pki/load"
 )
 
@@ -32,6 +31,9 @@ func (MacOS) Default(ctx *context.Context) error {
 		if n.Notarize.Timeout == 0 {
 			n.Notarize.Timeout = 10 * time.Minute
 		}
+		if n.Notarize.PollInterval == 0 {
+			n.Notarize.PollInterval = 10 * time.Second
+		}
 		if len(n.IDs) == 0 {
 			n.IDs = []string{ctx.Config.ProjectName}
 		}
@@ -88,6 +90,7 @@ func signAndNotarize(ctx *context.Context, cfg config.MacOSSignNotarize) error {
 	}
 
 	for _, bin := range binaries.List() {
+		log := log.WithField("binary", bin.Path)
 		signCfg, err := quill.NewSigningConfigFromP12(bin.Path, *p12, true)
 		if err != nil {
 			return fmt.Errorf("%s: %w", bin.Path, err)
@@ -95,7 +98,7 @@ func signAndNotarize(ctx *context.Context, cfg config.MacOSSignNotarize) error {
 		signCfg = signCfg.WithTimestampServer("http://timestamp.apple.com/ts01").
 			WithEntitlements(cfg.Sign.Entitlements)
 
-		log.WithField("binary", bin.Path).Info("signing")
+		log.Info("signing")
 		if err := quill.Sign(*signCfg); err != nil {
 			return fmt.Errorf("%s: %w", bin.Path, err)
 		}
@@ -103,41 +106,21 @@ func signAndNotarize(ctx *context.Context, cfg config.MacOSSignNotarize) error {
 		if cfg.Notarize.IssuerID == "" ||
 			cfg.Notarize.KeyID == "" ||
 			cfg.Notarize.Key == "" {
-			log.WithField("binary", bin.Path).Info("will not try to notarize")
+			log.Info("will not try to notarize")
 			continue
 		}
 
-		notarizeCfg := quill.NewNotarizeConfig(
-			cfg.Notarize.IssuerID,
-			cfg.Notarize.KeyID,
-			cfg.Notarize.Key,
-		).WithStatusConfig(notary.StatusConfig{
-			Timeout: cfg.Notarize.Timeout,
-			Poll:    10,
-			Wait:    cfg.Notarize.Wait,
-		})
-
 		if cfg.Notarize.Wait {
-			log.WithField("binary", bin.Path).Info("notarizing and waiting - this might take a while")
+			log.Info("notarizing and waiting - this might take a while")
 		} else {
-			log.WithField("binary", bin.Path).Info("sending notarize request")
+			log.Info("sending notarize request")
 		}
-		status, err := quill.Notarize(bin.Path, *notarizeCfg)
+		status, err := quill.Notarize(bin.Path, *notarizeConfig(cfg.Notarize))
 		if err != nil {
 			return fmt.Errorf("%s: %w", bin.Path, err)
 		}
-
-		switch status {
-		case notary.AcceptedStatus:
-			log.WithField("binary", bin.Path).Info("notarized")
-		case notary.InvalidStatus:
-			return fmt.Errorf("%s: invalid", bin.Path)
-		case notary.RejectedStatus:
-			return fmt.Errorf("%s: rejected", bin.Path)
-		case notary.TimeoutStatus:
-			log.WithField("binary", bin.Path).Info("notarize timeout")
-		default:
-			log.WithField("binary", bin.Path).Info("notarize still pending")
+		if _, err := checkStatus(log, bin.Path, status); err != nil {
+			return err
 		}
 	}
 
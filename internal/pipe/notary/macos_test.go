@@ -52,7 +52,8 @@ func TestMacOSDefault(t *testing.T) {
 				{},
 				{
 					Notarize: config.MacOSNotarize{
-						Timeout: time.Second,
+						Timeout:      time.Second,
+						PollInterval: time.Minute,
 					},
 				},
 				{
@@ -67,19 +68,22 @@ func TestMacOSDefault(t *testing.T) {
 		{
 			IDs: []string{"foo"},
 			Notarize: config.MacOSNotarize{
-				Timeout: 10 * time.Minute,
+				Timeout:      10 * time.Minute,
+				PollInterval: 10 * time.Second,
 			},
 		},
 		{
 			IDs: []string{"foo"},
 			Notarize: config.MacOSNotarize{
-				Timeout: time.Second,
+				Timeout:      time.Second,
+				PollInterval: time.Minute,
 			},
 		},
 		{
 			IDs: []string{"hi"},
 			Notarize: config.MacOSNotarize{
-				Timeout: 10 * time.Minute,
+				Timeout:      10 * time.Minute,
+				PollInterval: 10 * time.Second,
 			},
 		},
 	}, ctx.Config.Notarize.MacOS)
@@ -0,0 +1,130 @@
+package notary
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/goreleaser/quill/quill"
+	"github.com/goreleaser/quill/quill/notary"
+)
+
+// Notarize submits the disk image, installer, or app bundle at path to
+// Apple's notary service, and reports whether it was accepted.
+//
+// quill only notarizes binaries, so the file is submitted as is.
+// App bundles are directories, so they are zipped with ditto first, which
+// only works on macOS.
+func Notarize(ctx *context.Context, cfg config.MacOSNotarize, path string) (bool, error) {
+	name := filepath.Base(path)
+	log := log.WithField("file", name)
+	if cfg.IssuerID == "" ||
+		cfg.KeyID == "" ||
+		cfg.Key == "" {
+		log.Info("will not try to notarize")
+		return false, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	payload := path
+	if info.IsDir() {
+		tmp, err := os.MkdirTemp("", "goreleaser-notarize-")
+		if err != nil {
+			return false, err
+		}
+		defer os.RemoveAll(tmp)
+		payload = filepath.Join(tmp, name+".zip")
+		if err := run(ctx, "could not zip "+name, "ditto", "-c", "-k", "--keepParent", path, payload); err != nil {
+			return false, err
+		}
+	}
+
+	if cfg.Wait {
+		log.Info("notarizing and waiting - this might take a while")
+	} else {
+		log.Info("sending notarize request")
+	}
+	status, err := submit(ctx, cfg, payload)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+	return checkStatus(log, path, status)
+}
+
+// Staple staples the notarization ticket to the given disk image, installer,
+// or app bundle, so it can be verified offline.
+//
+// It only works on macOS, and the file must have been notarized.
+func Staple(ctx *context.Context, path string) error {
+	log.WithField("file", filepath.Base(path)).Info("stapling notarization ticket")
+	return run(ctx, "could not staple notarization ticket", "xcrun", "stapler", "staple", path)
+}
+
+func submit(ctx *context.Context, cfg config.MacOSNotarize, path string) (notary.SubmissionStatus, error) {
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bts)
+
+	ncfg := notarizeConfig(cfg)
+	token, err := notary.NewSignedToken(ncfg.TokenConfig)
+	if err != nil {
+		return "", err
+	}
+	sub := notary.NewSubmission(
+		notary.NewAPIClient(token, ncfg.HTTPTimeout),
+		&notary.Payload{
+			Reader: bytes.NewReader(bts),
+			Path:   path,
+			Digest: hex.EncodeToString(sum[:]),
+		},
+	)
+	if err := sub.Start(ctx); err != nil {
+		return "", fmt.Errorf("unable to start submission: %w", err)
+	}
+	if !cfg.Wait {
+		return "", nil
+	}
+	return notary.PollStatus(ctx, sub, ncfg.StatusConfig)
+}
+
+func notarizeConfig(cfg config.MacOSNotarize) *quill.NotarizeConfig {
+	return quill.NewNotarizeConfig(
+		cfg.IssuerID,
+		cfg.KeyID,
+		cfg.Key,
+	).WithStatusConfig(notary.StatusConfig{
+		Timeout: cfg.Timeout,
+		Poll:    cfg.PollInterval,
+		Wait:    cfg.Wait,
+	})
+}
+
+// checkStatus logs the notarization status, and reports whether it was
+// accepted.
+func checkStatus(log *log.Entry, path string, status notary.SubmissionStatus) (bool, error) {
+	switch status {
+	case notary.AcceptedStatus:
+		log.Info("notarized")
+		return true, nil
+	case notary.InvalidStatus:
+		return false, fmt.Errorf("%s: invalid", path)
+	case notary.RejectedStatus:
+		return false, fmt.Errorf("%s: rejected", path)
+	case notary.TimeoutStatus:
+		log.Info("notarize timeout")
+	default:
+		log.Info("notarize still pending")
+	}
+	return false, nil
+}
@@ -0,0 +1,57 @@
+package notary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/quill/quill/notary"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotarize(t *testing.T) {
+	t.Run("no credentials", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		accepted, err := Notarize(ctx, config.MacOSNotarize{KeyID: "foo"}, "nope.dmg")
+		require.NoError(t, err)
+		require.False(t, accepted)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		_, err := Notarize(ctx, config.MacOSNotarize{
+			IssuerID: "issuer",
+			KeyID:    "key-id",
+			Key:      "key",
+		}, filepath.Join(t.TempDir(), "nope.dmg"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestCheckStatus(t *testing.T) {
+	for status, accepted := range map[notary.SubmissionStatus]bool{
+		notary.AcceptedStatus: true,
+		notary.TimeoutStatus:  false,
+		notary.PendingStatus:  false,
+		"":                    false,
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			ok, err := checkStatus(log.WithField("file", "foo"), "foo", status)
+			require.NoError(t, err)
+			require.Equal(t, accepted, ok)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := checkStatus(log.WithField("file", "foo"), "foo", notary.InvalidStatus)
+		require.EqualError(t, err, "foo: invalid")
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		_, err := checkStatus(log.WithField("file", "foo"), "foo", notary.RejectedStatus)
+		require.EqualError(t, err, "foo: rejected")
+	})
+}
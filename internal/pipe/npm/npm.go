@@ -0,0 +1,406 @@
+// Package npm creates and publishes npm packages out of the built binaries,
+// with a package per platform, and a main package depending on them as
+// optional dependencies.
+package npm
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/archive/targz"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	npmConfigExtra = "NPMConfig"
+	npmMainExtra   = "NPMMain"
+)
+
+var errNoName = errors.New("npm: name is required")
+
+// npmOS maps GOOS to the values of the os field of package.json.
+var npmOS = map[string]string{
+	"linux":   "linux",
+	"darwin":  "darwin",
+	"windows": "win32",
+	"freebsd": "freebsd",
+	"openbsd": "openbsd",
+}
+
+// npmCPU maps GOARCH to the values of the cpu field of package.json.
+var npmCPU = map[string][]string{
+	"amd64": {"x64"},
+	"arm64": {"arm64"},
+	"386":   {"ia32"},
+	"arm":   {"arm"},
+	"all":   {"x64", "arm64"},
+}
+
+// cmd represents a command executor.
+var cmd execx.Cmder = execx.Std{}
+
+// Pipe for npm packages.
+type Pipe struct{}
+
+func (Pipe) String() string        { return "npm packages" }
+func (Pipe) ContinueOnError() bool { return true }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.NPM) || len(ctx.Config.NPMs) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(*context.Context) []string { return []string{"npm"} }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("npms")
+	for i := range ctx.Config.NPMs {
+		npm := &ctx.Config.NPMs[i]
+		if npm.Name == "" {
+			return errNoName
+		}
+		if npm.ID == "" {
+			npm.ID = ctx.Config.ProjectName
+		}
+		if npm.Tag == "" {
+			npm.Tag = "latest"
+		}
+		if npm.Goamd64 == "" {
+			npm.Goamd64 = "v1"
+		}
+		if npm.Goarm == "" {
+			npm.Goarm = "7"
+		}
+		ids.Inc(npm.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, npm := range ctx.Config.NPMs {
+		err := doRun(ctx, npm)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+type packageJSON struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Description          string            `json:"description,omitempty"`
+	Homepage             string            `json:"homepage,omitempty"`
+	Keywords             []string          `json:"keywords,omitempty"`
+	License              string            `json:"license,omitempty"`
+	Author               string            `json:"author,omitempty"`
+	Repository           string            `json:"repository,omitempty"`
+	Bugs                 string            `json:"bugs,omitempty"`
+	OS                   []string          `json:"os,omitempty"`
+	CPU                  []string          `json:"cpu,omitempty"`
+	Bin                  map[string]string `json:"bin,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+}
+
+func doRun(ctx *context.Context, npm config.NPM) error {
+	disable, err := tmpl.New(ctx).Bool(npm.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("disabled")
+	}
+
+	if err := tmpl.New(ctx).ApplyAll(
+		&npm.Description,
+		&npm.License,
+		&npm.Author,
+		&npm.Repository,
+		&npm.Bugs,
+		&npm.Tag,
+	); err != nil {
+		return err
+	}
+
+	binaries := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByTypes(artifact.Binary, artifact.UniversalBinary),
+		artifact.ByGooses(slices.Sorted(maps.Keys(npmOS))...),
+		artifact.ByGoarches(slices.Sorted(maps.Keys(npmCPU))...),
+		artifact.ByIDs(npm.IDs...),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("amd64")),
+			artifact.ByGoamd64(npm.Goamd64),
+		),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("arm")),
+			artifact.ByGoarm(npm.Goarm),
+		),
+		artifact.OnlyReplacingUnibins,
+	)).List()
+	if len(binaries) == 0 {
+		return pipe.Skipf("no binaries found for builds %v", npm.IDs)
+	}
+
+	platforms := map[string][]*artifact.Artifact{}
+	for _, bin := range binaries {
+		key := bin.Goos + "-" + bin.Goarch
+		platforms[key] = append(platforms[key], bin)
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "npm", npm.ID)
+	main := packageJSON{
+		Name:                 npm.Name,
+		Version:              ctx.Version,
+		Description:          npm.Description,
+		Homepage:             npm.Homepage,
+		Keywords:             npm.Keywords,
+		License:              npm.License,
+		Author:               npm.Author,
+		Repository:           npm.Repository,
+		Bugs:                 npm.Bugs,
+		Bin:                  map[string]string{},
+		OptionalDependencies: map[string]string{},
+	}
+	launchers := map[string]*launcherData{}
+	for _, key := range slices.Sorted(maps.Keys(platforms)) {
+		bins := platforms[key]
+		first := bins[0]
+		osName := npmOS[first.Goos]
+		cpus := npmCPU[first.Goarch]
+		suffix := osName + "-" + strings.Join(cpus, "-")
+		if first.Goarch == "all" {
+			suffix = osName + "-universal"
+		}
+		pkg := packageJSON{
+			Name:        npm.Name + "-" + suffix,
+			Version:     ctx.Version,
+			Description: npm.Description,
+			Homepage:    npm.Homepage,
+			License:     npm.License,
+			Author:      npm.Author,
+			Repository:  npm.Repository,
+			Bugs:        npm.Bugs,
+			OS:          []string{osName},
+			CPU:         cpus,
+		}
+
+		files := map[string]string{}
+		for _, bin := range bins {
+			name := filepath.Base(bin.Name)
+			dst := "bin/" + name
+			if _, ok := files[dst]; ok {
+				return fmt.Errorf("npm: multiple binaries named %s for %s", name, key)
+			}
+			files[dst] = bin.Path
+
+			command := artifact.ExtraOr(*bin, artifact.ExtraBinary, strings.TrimSuffix(name, filepath.Ext(name)))
+			launcher, ok := launchers[command]
+			if !ok {
+				launcher = &launcherData{Name: command}
+				launchers[command] = launcher
+			}
+			for _, cpu := range cpus {
+				launcher.Platforms = append(launcher.Platforms, launcherPlatform{
+					Key:     osName + "-" + cpu,
+					Package: pkg.Name,
+					Path:    dst,
+				})
+			}
+		}
+
+		if err := createPackage(ctx, npm, filepath.Join(dir, suffix), pkg, nil, files); err != nil {
+			return err
+		}
+		main.OptionalDependencies[pkg.Name] = ctx.Version
+	}
+
+	files := map[string]string{}
+	for _, command := range slices.Sorted(maps.Keys(launchers)) {
+		launcher := launchers[command]
+		slices.SortFunc(launcher.Platforms, func(a, b launcherPlatform) int {
+			return cmp.Compare(a.Key, b.Key)
+		})
+		content, err := buildLauncher(*launcher)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, "launchers", command)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0o755); err != nil { //nolint:gosec
+			return fmt.Errorf("npm: failed to write launcher: %w", err)
+		}
+		main.Bin[command] = "bin/" + command
+		files["bin/"+command] = path
+	}
+
+	return createPackage(ctx, npm, filepath.Join(dir, "main"), main, npm.Extra, files)
+}
+
+func buildLauncher(data launcherData) ([]byte, error) {
+	t, err := template.New(data.Name).Parse(launcherTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// buildPackageJSON marshals the given package.json, merging the extra fields
+// into its root.
+func buildPackageJSON(pkg packageJSON, extra map[string]any) ([]byte, error) {
+	bts, err := json.MarshalIndent(pkg, "", "  ")
+	if err != nil || len(extra) == 0 {
+		return append(bts, '\n'), err
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(bts, &fields); err != nil {
+		return nil, err
+	}
+	maps.Copy(fields, extra)
+	bts, err = json.MarshalIndent(fields, "", "  ")
+	return append(bts, '\n'), err
+}
+
+// createPackage creates the npm tarball of the given package, with its
+// package.json and the given files, by their destination.
+func createPackage(ctx *context.Context, npm config.NPM, dir string, pkg packageJSON, extra map[string]any, files map[string]string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	manifest, err := buildPackageJSON(pkg, extra)
+	if err != nil {
+		return fmt.Errorf("npm: failed to create package.json: %w", err)
+	}
+	manifestPath := filepath.Join(dir, "package.json")
+	if err := os.WriteFile(manifestPath, manifest, 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("npm: failed to write package.json: %w", err)
+	}
+
+	filename := tarballName(pkg.Name, pkg.Version)
+	path := filepath.Join(filepath.Dir(dir), filename)
+	log.WithField("package", path).Info("creating")
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("npm: failed to create package: %w", err)
+	}
+	defer f.Close()
+
+	a := targz.New(f)
+	if err := a.Add(config.File{
+		Source:      manifestPath,
+		Destination: "package/package.json",
+		Info: config.FileInfo{
+			Mode:        0o644,
+			ParsedMTime: ctx.Git.CommitDate,
+		},
+	}); err != nil {
+		return fmt.Errorf("npm: failed to create package: %w", err)
+	}
+	for _, dst := range slices.Sorted(maps.Keys(files)) {
+		if err := a.Add(config.File{
+			Source:      files[dst],
+			Destination: "package/" + dst,
+			Info: config.FileInfo{
+				Mode:        0o755,
+				ParsedMTime: ctx.Git.CommitDate,
+			},
+		}); err != nil {
+			return fmt.Errorf("npm: failed to create package: %w", err)
+		}
+	}
+	if err := a.Close(); err != nil {
+		return fmt.Errorf("npm: failed to create package: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("npm: failed to create package: %w", err)
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.NPMPackage,
+		Name: filename,
+		Path: path,
+		Extra: map[string]any{
+			artifact.ExtraID: npm.ID,
+			npmConfigExtra:   npm,
+			npmMainExtra:     pkg.Name == npm.Name,
+		},
+	})
+	return nil
+}
+
+// tarballName returns the name npm pack would give to the tarball of the
+// given package, e.g. @foo/bar@1.0.0 becomes foo-bar-1.0.0.tgz.
+func tarballName(name, version string) string {
+	name = strings.ReplaceAll(strings.TrimPrefix(name, "@"), "/", "-")
+	return name + "-" + version + ".tgz"
+}
+
+// Publish the packages, the main one last, so its optional dependencies are
+// available once it is.
+func (Pipe) Publish(ctx *context.Context) error {
+	packages := ctx.Artifacts.Filter(artifact.ByType(artifact.NPMPackage)).List()
+	slices.SortStableFunc(packages, func(a, b *artifact.Artifact) int {
+		return cmp.Compare(
+			boolInt(artifact.MustExtra[bool](*a, npmMainExtra)),
+			boolInt(artifact.MustExtra[bool](*b, npmMainExtra)),
+		)
+	})
+	for _, pkg := range packages {
+		if err := doPublish(ctx, pkg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func doPublish(ctx *context.Context, pkg *artifact.Artifact) error {
+	npm := artifact.MustExtra[config.NPM](*pkg, npmConfigExtra)
+	args := []string{"publish", filepath.Clean(pkg.Path), "--tag", npm.Tag}
+	if npm.Access != "" {
+		args = append(args, "--access", npm.Access)
+	}
+	if npm.Provenance {
+		args = append(args, "--provenance")
+	}
+
+	log.WithField("package", pkg.Name).Info("publishing")
+	if out, err := cmd.Exec(ctx, "npm", args...); err != nil {
+		return fmt.Errorf("npm: failed to publish %s: %w: %s", pkg.Name, err, string(out))
+	}
+	return nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,327 @@
+package npm
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestContinueOnError(t *testing.T) {
+	require.True(t, Pipe{}.ContinueOnError())
+}
+
+func TestDependencies(t *testing.T) {
+	require.Equal(t, []string{"npm"}, Pipe{}.Dependencies(nil))
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			NPMs: []config.NPM{{}},
+		}, testctx.Skip(skips.NPM))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			NPMs: []config.NPM{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			NPMs:        []config.NPM{{Name: "foo"}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		npm := ctx.Config.NPMs[0]
+		require.Equal(t, "foo", npm.ID)
+		require.Equal(t, "latest", npm.Tag)
+		require.Equal(t, "v1", npm.Goamd64)
+		require.Equal(t, "7", npm.Goarm)
+	})
+	t.Run("no name", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			NPMs:        []config.NPM{{}},
+		})
+		require.ErrorIs(t, Pipe{}.Default(ctx), errNoName)
+	})
+	t.Run("duplicated ids", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			NPMs:        []config.NPM{{Name: "foo"}, {Name: "bar"}},
+		})
+		require.ErrorContains(t, Pipe{}.Default(ctx), "found 2 npms with the ID 'foo'")
+	})
+}
+
+func TestRun(t *testing.T) {
+	ctx := makeContext(t, config.NPM{
+		Name:        "@me/foo",
+		Description: "foo is {{ .ProjectName }}",
+		License:     "MIT",
+		Repository:  "https://github.com/goreleaser/foo",
+		Extra: map[string]any{
+			"engines": map[string]any{"node": ">=18"},
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	packages := ctx.Artifacts.Filter(artifact.ByType(artifact.NPMPackage)).List()
+	names := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		names = append(names, pkg.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"me-foo-darwin-universal-1.2.3.tgz",
+		"me-foo-linux-arm-1.2.3.tgz",
+		"me-foo-linux-x64-1.2.3.tgz",
+		"me-foo-win32-x64-1.2.3.tgz",
+		"me-foo-1.2.3.tgz",
+	}, names)
+
+	t.Run("platform", func(t *testing.T) {
+		path := filepath.Join(ctx.Config.Dist, "npm", "foo", "me-foo-win32-x64-1.2.3.tgz")
+		require.ElementsMatch(t, []string{
+			"package/package.json",
+			"package/bin/foo.exe",
+		}, testlib.LsArchive(t, path, "tar.gz"))
+
+		var pkg packageJSON
+		require.NoError(t, json.Unmarshal(testlib.GetFileFromArchive(t, path, "tar.gz", "package/package.json"), &pkg))
+		require.Equal(t, packageJSON{
+			Name:        "@me/foo-win32-x64",
+			Version:     "1.2.3",
+			Description: "foo is foo",
+			License:     "MIT",
+			Repository:  "https://github.com/goreleaser/foo",
+			OS:          []string{"win32"},
+			CPU:         []string{"x64"},
+		}, pkg)
+	})
+
+	t.Run("main", func(t *testing.T) {
+		path := filepath.Join(ctx.Config.Dist, "npm", "foo", "me-foo-1.2.3.tgz")
+		require.ElementsMatch(t, []string{
+			"package/package.json",
+			"package/bin/foo",
+		}, testlib.LsArchive(t, path, "tar.gz"))
+
+		var pkg map[string]any
+		require.NoError(t, json.Unmarshal(testlib.GetFileFromArchive(t, path, "tar.gz", "package/package.json"), &pkg))
+		require.Equal(t, "@me/foo", pkg["name"])
+		require.Equal(t, "1.2.3", pkg["version"])
+		require.Equal(t, map[string]any{"foo": "bin/foo"}, pkg["bin"])
+		require.Equal(t, map[string]any{"node": ">=18"}, pkg["engines"])
+		require.Equal(t, map[string]any{
+			"@me/foo-darwin-universal": "1.2.3",
+			"@me/foo-linux-arm":        "1.2.3",
+			"@me/foo-linux-x64":        "1.2.3",
+			"@me/foo-win32-x64":        "1.2.3",
+		}, pkg["optionalDependencies"])
+		require.NotContains(t, pkg, "os")
+
+		golden.RequireEqualExt(t, testlib.GetFileFromArchive(t, path, "tar.gz", "package/bin/foo"), ".js")
+	})
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctx := makeContext(t, config.NPM{
+		Name:    "foo",
+		Disable: "{{ .IsSnapshot }}",
+	})
+	ctx.Snapshot = true
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.NPMPackage)).List())
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Run("no binaries", func(t *testing.T) {
+		ctx := makeContext(t, config.NPM{
+			Name: "foo",
+			IDs:  []string{"nope"},
+		})
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+	})
+	t.Run("bad disable template", func(t *testing.T) {
+		ctx := makeContext(t, config.NPM{
+			Name:    "foo",
+			Disable: "{{ .Nope }}",
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+	t.Run("bad description template", func(t *testing.T) {
+		ctx := makeContext(t, config.NPM{
+			Name:        "foo",
+			Description: "{{ .Nope }}",
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
+
+func TestPublish(t *testing.T) {
+	var published [][]string
+	cmd = fakeCmd{execFn: func(name string, args ...string) ([]byte, error) {
+		require.Equal(t, "npm", name)
+		published = append(published, args)
+		return []byte("ok"), nil
+	}}
+	t.Cleanup(func() {
+		cmd = execx.Std{}
+	})
+
+	ctx := makeContext(t, config.NPM{
+		Name:       "foo",
+		Tag:        "{{ .Env.NPM_TAG }}",
+		Access:     "public",
+		Provenance: true,
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	require.Len(t, published, 5)
+	for _, args := range published {
+		require.Equal(t, "publish", args[0])
+		require.Equal(t, []string{"--tag", "next", "--access", "public", "--provenance"}, args[2:])
+	}
+	require.Equal(t, "foo-1.2.3.tgz", filepath.Base(published[len(published)-1][1]))
+}
+
+func TestPublishError(t *testing.T) {
+	cmd = fakeCmd{execFn: func(string, ...string) ([]byte, error) {
+		return []byte("E403 forbidden"), errors.New("exit status 1")
+	}}
+	t.Cleanup(func() {
+		cmd = execx.Std{}
+	})
+
+	ctx := makeContext(t, config.NPM{Name: "foo"})
+	require.NoError(t, Pipe{}.Run(ctx))
+	err := Pipe{}.Publish(ctx)
+	require.ErrorContains(t, err, "exit status 1")
+	require.ErrorContains(t, err, "E403 forbidden")
+}
+
+func TestTarballName(t *testing.T) {
+	require.Equal(t, "foo-1.0.0.tgz", tarballName("foo", "1.0.0"))
+	require.Equal(t, "me-foo-1.0.0.tgz", tarballName("@me/foo", "1.0.0"))
+}
+
+func makeContext(tb testing.TB, npm config.NPM) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		Dist:        tb.TempDir(),
+		ProjectName: "foo",
+		NPMs:        []config.NPM{npm},
+	},
+		testctx.WithVersion("1.2.3"),
+		testctx.WithCurrentTag("v1.2.3"),
+		testctx.WithEnv(map[string]string{"NPM_TAG": "next"}),
+	)
+	require.NoError(tb, Pipe{}.Default(ctx))
+
+	bin := filepath.Join(tb.TempDir(), "foo")
+	require.NoError(tb, os.WriteFile(bin, []byte("fake bin"), 0o755))
+	for _, art := range []*artifact.Artifact{
+		{
+			Name:    "foo",
+			Path:    bin,
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+		},
+		{
+			Name:    "foo",
+			Path:    bin,
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v3",
+			Type:    artifact.Binary,
+		},
+		{
+			Name:   "foo",
+			Path:   bin,
+			Goos:   "linux",
+			Goarch: "arm",
+			Goarm:  "7",
+			Type:   artifact.Binary,
+		},
+		{
+			Name:   "foo",
+			Path:   bin,
+			Goos:   "linux",
+			Goarch: "arm",
+			Goarm:  "6",
+			Type:   artifact.Binary,
+		},
+		{
+			Name:   "foo",
+			Path:   bin,
+			Goos:   "darwin",
+			Goarch: "all",
+			Type:   artifact.UniversalBinary,
+			Extra: map[string]any{
+				artifact.ExtraReplaces: true,
+			},
+		},
+		{
+			Name:    "foo.exe",
+			Path:    bin,
+			Goos:    "windows",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+			Extra: map[string]any{
+				artifact.ExtraBinary: "foo",
+				artifact.ExtraExt:    ".exe",
+			},
+		},
+		{
+			Name:   "foo",
+			Path:   bin,
+			Goos:   "js",
+			Goarch: "wasm",
+			Type:   artifact.Binary,
+		},
+	} {
+		if art.Extra == nil {
+			art.Extra = map[string]any{}
+		}
+		art.Extra[artifact.ExtraID] = "foo"
+		ctx.Artifacts.Add(art)
+	}
+	return ctx
+}
+
+type fakeCmd struct {
+	execFn func(cmd string, args ...string) ([]byte, error)
+}
+
+var _ execx.Cmder = fakeCmd{}
+
+func (f fakeCmd) Exec(_ *context.Context, cmd string, args ...string) ([]byte, error) {
+	return f.execFn(cmd, args...)
+}
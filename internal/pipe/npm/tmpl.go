@@ -0,0 +1,46 @@
+package npm
+
+type launcherData struct {
+	Name      string
+	Platforms []launcherPlatform
+}
+
+type launcherPlatform struct {
+	Key     string
+	Package string
+	Path    string
+}
+
+const launcherTemplate = `#!/usr/bin/env node
+// This file was generated by GoReleaser. DO NOT EDIT.
+"use strict";
+
+const { spawnSync } = require("child_process");
+
+const binaries = {
+{{- range .Platforms }}
+  "{{ .Key }}": ["{{ .Package }}", "{{ .Path }}"],
+{{- end }}
+};
+
+const platform = ` + "`${process.platform}-${process.arch}`" + `;
+const binary = binaries[platform];
+if (!binary) {
+  console.error(` + "`{{ .Name }}: unsupported platform: ${platform}`" + `);
+  process.exit(1);
+}
+
+let path;
+try {
+  path = require.resolve(` + "`${binary[0]}/${binary[1]}`" + `);
+} catch {
+  console.error(` + "`{{ .Name }}: could not find ${binary[0]}, make sure optional dependencies are installed`" + `);
+  process.exit(1);
+}
+
+const result = spawnSync(path, process.argv.slice(2), { stdio: "inherit" });
+if (result.error) {
+  throw result.error;
+}
+process.exit(result.status ?? 1);
+`
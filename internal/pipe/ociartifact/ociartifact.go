@@ -0,0 +1,246 @@
+// Package ociartifact pushes archives and binaries to registries as OCI
+// artifacts, using ORAS.
+package ociartifact
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultTag       = "{{ .Version }}-{{ .Os }}-{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}"
+	defaultMediaType = "application/octet-stream"
+)
+
+var (
+	errNoRepository = errors.New("oci_artifacts: repository is required")
+
+	// digestPattern matches the digest oras prints after pushing, e.g.
+	// 'Digest: sha256:abc...'.
+	digestPattern = regexp.MustCompile(`Digest: (sha256:[a-f0-9]{64})`)
+
+	// archiveMediaTypes maps archive formats to their media types.
+	archiveMediaTypes = map[string]string{
+		"tar":     "application/vnd.oci.image.layer.v1.tar",
+		"tar.gz":  "application/vnd.oci.image.layer.v1.tar+gzip",
+		"tgz":     "application/vnd.oci.image.layer.v1.tar+gzip",
+		"tar.zst": "application/vnd.oci.image.layer.v1.tar+zstd",
+		"tar.xz":  "application/x-xz",
+		"txz":     "application/x-xz",
+		"zip":     "application/zip",
+	}
+)
+
+// cmd represents a command executor.
+var cmd cmder = stdCmd{}
+
+// Pipe for OCI artifacts.
+type Pipe struct{}
+
+func (Pipe) String() string { return "oci artifacts" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.OCIArtifacts) || len(ctx.Config.OCIArtifacts) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(*context.Context) []string { return []string{"oras"} }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("oci_artifacts")
+	for i := range ctx.Config.OCIArtifacts {
+		oci := &ctx.Config.OCIArtifacts[i]
+		if oci.Repository == "" {
+			return errNoRepository
+		}
+		if oci.ID == "" {
+			oci.ID = ctx.Config.ProjectName
+		}
+		if oci.Artifacts == "" {
+			oci.Artifacts = "archive"
+		}
+		if oci.Goamd64 == "" {
+			oci.Goamd64 = "v1"
+		}
+		if oci.Goarm == "" {
+			oci.Goarm = "7"
+		}
+		if oci.Tag == "" {
+			oci.Tag = defaultTag
+		}
+		ids.Inc(oci.ID)
+	}
+	return ids.Validate()
+}
+
+// Publish pushes the artifacts.
+func (Pipe) Publish(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, oci := range ctx.Config.OCIArtifacts {
+		err := doPublish(ctx, oci)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doPublish(ctx *context.Context, oci config.OCIArtifact) error {
+	disable, err := tmpl.New(ctx).Bool(oci.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("configuration is disabled")
+	}
+
+	filters := []artifact.Filter{
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("amd64")),
+			artifact.ByGoamd64(oci.Goamd64),
+		),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("arm")),
+			artifact.ByGoarm(oci.Goarm),
+		),
+		artifact.ByIDs(oci.IDs...),
+	}
+	switch oci.Artifacts {
+	case "archive":
+		filters = append(filters, artifact.ByType(artifact.UploadableArchive))
+	case "binary":
+		filters = append(
+			filters,
+			artifact.ByTypes(artifact.Binary, artifact.UniversalBinary),
+			artifact.OnlyReplacingUnibins,
+		)
+	default:
+		return fmt.Errorf("oci_artifacts: invalid artifacts: %q", oci.Artifacts)
+	}
+
+	arts := ctx.Artifacts.Filter(artifact.And(filters...)).List()
+	if len(arts) == 0 {
+		return pipe.Skipf("no %s artifacts found for oci_artifacts %q", oci.Artifacts, oci.ID)
+	}
+
+	g := semerrgroup.New(ctx.Parallelism)
+	for _, art := range arts {
+		g.Go(func() error {
+			return push(ctx, oci, art)
+		})
+	}
+	return g.Wait()
+}
+
+func push(ctx *context.Context, oci config.OCIArtifact, art *artifact.Artifact) error {
+	tp := tmpl.New(ctx).WithArtifact(art)
+	if err := tp.ApplyAll(
+		&oci.Repository,
+		&oci.Tag,
+		&oci.ArtifactType,
+		&oci.MediaType,
+	); err != nil {
+		return err
+	}
+	if oci.MediaType == "" {
+		oci.MediaType = defaultMediaTypeFor(art)
+	}
+
+	ref := oci.Repository + ":" + oci.Tag
+	args := []string{"push", ref}
+	if oci.ArtifactType != "" {
+		args = append(args, "--artifact-type", oci.ArtifactType)
+	}
+	for _, key := range slices.Sorted(maps.Keys(oci.Annotations)) {
+		value, err := tp.Apply(oci.Annotations[key])
+		if err != nil {
+			return err
+		}
+		args = append(args, "--annotation", key+"="+value)
+	}
+	flags, err := tp.Slice(oci.Flags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+	args = append(args, flags...)
+	args = append(args, filepath.Base(art.Path)+":"+oci.MediaType)
+
+	log.WithField("artifact", art.Name).
+		WithField("ref", ref).
+		Info("pushing")
+
+	// oras uses the file path as its title, so it must be relative to the
+	// directory it runs on.
+	out, err := cmd.Exec(ctx, filepath.Dir(art.Path), "oras", args...)
+	if err != nil {
+		return fmt.Errorf("oci_artifacts: failed to push %s: %w: %s", ref, err, string(out))
+	}
+	match := digestPattern.FindStringSubmatch(string(out))
+	if match == nil {
+		return fmt.Errorf("oci_artifacts: failed to find digest in oras output: %s", string(out))
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:    ref,
+		Path:    ref,
+		Goos:    art.Goos,
+		Goarch:  art.Goarch,
+		Goamd64: art.Goamd64,
+		Goarm:   art.Goarm,
+		Type:    artifact.OCIArtifact,
+		Extra: map[string]any{
+			artifact.ExtraID:     oci.ID,
+			artifact.ExtraDigest: match[1],
+		},
+	})
+	return nil
+}
+
+func defaultMediaTypeFor(art *artifact.Artifact) string {
+	if art.Type != artifact.UploadableArchive {
+		return defaultMediaType
+	}
+	if mediaType, ok := archiveMediaTypes[art.Format()]; ok {
+		return mediaType
+	}
+	return defaultMediaType
+}
+
+// cmder is a special interface to execute external commands.
+//
+// The intention is to be used to wrap the standard exec and provide the
+// ability to create a fake one for testing.
+type cmder interface {
+	// Exec executes a command in the given directory.
+	Exec(*context.Context, string, string, ...string) ([]byte, error)
+}
+
+// stdCmd uses [execx.Command].
+type stdCmd struct{}
+
+var _ cmder = &stdCmd{}
+
+func (stdCmd) Exec(ctx *context.Context, dir, name string, args ...string) ([]byte, error) {
+	c := execx.Command(ctx, name, args...)
+	c.Dir = dir
+	return c.CombinedOutput()
+}
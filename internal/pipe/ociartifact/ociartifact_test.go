@@ -0,0 +1,349 @@
+package ociartifact
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeDigest = "sha256:2a6ab3b1c7a4b6f5e4b0a8a4e19b7cbb1d0e0f6f0b0e5f8b9d1a2c3d4e5f6a7b"
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestDependencies(t *testing.T) {
+	require.Equal(t, []string{"oras"}, Pipe{}.Dependencies(nil))
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			OCIArtifacts: []config.OCIArtifact{{}},
+		}, testctx.Skip(skips.OCIArtifacts))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			OCIArtifacts: []config.OCIArtifact{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName:  "foo",
+			OCIArtifacts: []config.OCIArtifact{{Repository: "ghcr.io/foo/bar"}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		oci := ctx.Config.OCIArtifacts[0]
+		require.Equal(t, "foo", oci.ID)
+		require.Equal(t, "archive", oci.Artifacts)
+		require.Equal(t, "v1", oci.Goamd64)
+		require.Equal(t, "7", oci.Goarm)
+		require.Equal(t, defaultTag, oci.Tag)
+	})
+	t.Run("no repository", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName:  "foo",
+			OCIArtifacts: []config.OCIArtifact{{}},
+		})
+		require.ErrorIs(t, Pipe{}.Default(ctx), errNoRepository)
+	})
+	t.Run("duplicated ids", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			OCIArtifacts: []config.OCIArtifact{
+				{Repository: "foo"},
+				{Repository: "bar"},
+			},
+		})
+		require.ErrorContains(t, Pipe{}.Default(ctx), "found 2 oci_artifacts with the ID 'foo'")
+	})
+}
+
+func TestPublishArchives(t *testing.T) {
+	calls := fakeOras(t, nil)
+	ctx := makeContext(t, config.OCIArtifact{
+		Repository:   "ghcr.io/foo/{{ .ProjectName }}",
+		ArtifactType: "application/vnd.foo.release",
+		Annotations: map[string]string{
+			"org.opencontainers.image.version": "{{ .Version }}",
+			"org.opencontainers.image.source":  "https://github.com/foo/bar",
+		},
+		Flags: []string{"--concurrency=2"},
+	})
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	dir := filepath.Dir(ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()[0].Path)
+	require.ElementsMatch(t, []fakeCall{
+		{dir: dir, args: []string{
+			"push", "ghcr.io/foo/foo:1.2.3-linux-amd64",
+			"--artifact-type", "application/vnd.foo.release",
+			"--annotation", "org.opencontainers.image.source=https://github.com/foo/bar",
+			"--annotation", "org.opencontainers.image.version=1.2.3",
+			"--concurrency=2",
+			"foo_linux_amd64.tar.gz:application/vnd.oci.image.layer.v1.tar+gzip",
+		}},
+		{dir: dir, args: []string{
+			"push", "ghcr.io/foo/foo:1.2.3-linux-armv7",
+			"--artifact-type", "application/vnd.foo.release",
+			"--annotation", "org.opencontainers.image.source=https://github.com/foo/bar",
+			"--annotation", "org.opencontainers.image.version=1.2.3",
+			"--concurrency=2",
+			"foo_linux_armv7.tar.gz:application/vnd.oci.image.layer.v1.tar+gzip",
+		}},
+		{dir: dir, args: []string{
+			"push", "ghcr.io/foo/foo:1.2.3-windows-amd64",
+			"--artifact-type", "application/vnd.foo.release",
+			"--annotation", "org.opencontainers.image.source=https://github.com/foo/bar",
+			"--annotation", "org.opencontainers.image.version=1.2.3",
+			"--concurrency=2",
+			"foo_windows_amd64.zip:application/zip",
+		}},
+	}, *calls)
+
+	arts := ctx.Artifacts.Filter(artifact.ByType(artifact.OCIArtifact)).List()
+	require.Len(t, arts, 3)
+	for _, art := range arts {
+		require.True(t, strings.HasPrefix(art.Name, "ghcr.io/foo/foo:1.2.3-"))
+		require.Equal(t, "foo", artifact.MustExtra[string](*art, artifact.ExtraID))
+		require.Equal(t, fakeDigest, artifact.MustExtra[string](*art, artifact.ExtraDigest))
+	}
+}
+
+func TestPublishBinaries(t *testing.T) {
+	calls := fakeOras(t, nil)
+	ctx := makeContext(t, config.OCIArtifact{
+		Repository: "ghcr.io/foo/bar",
+		Artifacts:  "binary",
+		Tag:        "{{ .Os }}-{{ .Arch }}",
+		MediaType:  "application/vnd.foo.binary",
+	})
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	var refs []string
+	for _, call := range *calls {
+		refs = append(refs, call.args[1])
+		require.Equal(t, "foo:application/vnd.foo.binary", call.args[len(call.args)-1])
+	}
+	slices.Sort(refs)
+	require.Equal(t, []string{
+		"ghcr.io/foo/bar:darwin-all",
+		"ghcr.io/foo/bar:linux-amd64",
+	}, refs)
+}
+
+func TestPublishErrors(t *testing.T) {
+	t.Run("oras fails", func(t *testing.T) {
+		fakeOras(t, func() ([]byte, error) {
+			return []byte("Error: unauthorized"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.OCIArtifact{Repository: "ghcr.io/foo/bar"})
+		err := Pipe{}.Publish(ctx)
+		require.ErrorContains(t, err, "exit status 1")
+		require.ErrorContains(t, err, "Error: unauthorized")
+	})
+	t.Run("no digest", func(t *testing.T) {
+		fakeOras(t, func() ([]byte, error) {
+			return []byte("Pushed"), nil
+		})
+		ctx := makeContext(t, config.OCIArtifact{Repository: "ghcr.io/foo/bar"})
+		require.ErrorContains(t, Pipe{}.Publish(ctx), "failed to find digest")
+	})
+	t.Run("invalid artifacts", func(t *testing.T) {
+		ctx := makeContext(t, config.OCIArtifact{
+			Repository: "ghcr.io/foo/bar",
+			Artifacts:  "nope",
+		})
+		require.EqualError(t, Pipe{}.Publish(ctx), `oci_artifacts: invalid artifacts: "nope"`)
+	})
+	t.Run("no artifacts", func(t *testing.T) {
+		ctx := makeContext(t, config.OCIArtifact{
+			Repository: "ghcr.io/foo/bar",
+			IDs:        []string{"nope"},
+		})
+		testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		ctx := makeContext(t, config.OCIArtifact{
+			Repository: "ghcr.io/foo/bar",
+			Disable:    "true",
+		})
+		testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+	})
+	for name, oci := range map[string]config.OCIArtifact{
+		"disable":       {Disable: "{{ .Nope }}"},
+		"repository":    {Repository: "{{ .Nope }}"},
+		"tag":           {Tag: "{{ .Nope }}"},
+		"artifact type": {ArtifactType: "{{ .Nope }}"},
+		"media type":    {MediaType: "{{ .Nope }}"},
+		"annotations":   {Annotations: map[string]string{"foo": "{{ .Nope }}"}},
+		"flags":         {Flags: []string{"{{ .Nope }}"}},
+	} {
+		t.Run("bad "+name+" template", func(t *testing.T) {
+			if oci.Repository == "" {
+				oci.Repository = "ghcr.io/foo/bar"
+			}
+			ctx := makeContext(t, oci)
+			testlib.RequireTemplateError(t, Pipe{}.Publish(ctx))
+		})
+	}
+}
+
+func TestDefaultMediaTypeFor(t *testing.T) {
+	for format, expected := range map[string]string{
+		"tar.gz": "application/vnd.oci.image.layer.v1.tar+gzip",
+		"zip":    "application/zip",
+		"gz":     defaultMediaType,
+	} {
+		t.Run(format, func(t *testing.T) {
+			require.Equal(t, expected, defaultMediaTypeFor(&artifact.Artifact{
+				Type:  artifact.UploadableArchive,
+				Extra: map[string]any{artifact.ExtraFormat: format},
+			}))
+		})
+	}
+	t.Run("binary", func(t *testing.T) {
+		require.Equal(t, defaultMediaType, defaultMediaTypeFor(&artifact.Artifact{
+			Type: artifact.Binary,
+		}))
+	})
+}
+
+type fakeCall struct {
+	dir  string
+	args []string
+}
+
+// fakeOras replaces the command executor, recording the calls made to it.
+// If fn is nil, a successful push is faked.
+func fakeOras(tb testing.TB, fn func() ([]byte, error)) *[]fakeCall {
+	tb.Helper()
+	if fn == nil {
+		fn = func() ([]byte, error) {
+			return []byte("Pushed [registry] ghcr.io/foo/bar\nDigest: " + fakeDigest + "\n"), nil
+		}
+	}
+	var mu sync.Mutex
+	var calls []fakeCall
+	cmd = fakeCmd{execFn: func(dir, name string, args ...string) ([]byte, error) {
+		require.Equal(tb, "oras", name)
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, fakeCall{dir: dir, args: args})
+		return fn()
+	}}
+	tb.Cleanup(func() {
+		cmd = stdCmd{}
+	})
+	return &calls
+}
+
+func makeContext(tb testing.TB, oci config.OCIArtifact) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		Dist:         tb.TempDir(),
+		ProjectName:  "foo",
+		OCIArtifacts: []config.OCIArtifact{oci},
+	},
+		testctx.WithVersion("1.2.3"),
+		testctx.WithCurrentTag("v1.2.3"),
+	)
+	require.NoError(tb, Pipe{}.Default(ctx))
+
+	dir := tb.TempDir()
+	path := func(name string) string {
+		p := filepath.Join(dir, name)
+		require.NoError(tb, os.WriteFile(p, []byte("fake"), 0o644))
+		return p
+	}
+	for _, art := range []*artifact.Artifact{
+		{
+			Name:    "foo_linux_amd64.tar.gz",
+			Path:    path("foo_linux_amd64.tar.gz"),
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.UploadableArchive,
+			Extra:   map[string]any{artifact.ExtraFormat: "tar.gz"},
+		},
+		{
+			Name:    "foo_linux_amd64v3.tar.gz",
+			Path:    path("foo_linux_amd64v3.tar.gz"),
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v3",
+			Type:    artifact.UploadableArchive,
+			Extra:   map[string]any{artifact.ExtraFormat: "tar.gz"},
+		},
+		{
+			Name:   "foo_linux_armv7.tar.gz",
+			Path:   path("foo_linux_armv7.tar.gz"),
+			Goos:   "linux",
+			Goarch: "arm",
+			Goarm:  "7",
+			Type:   artifact.UploadableArchive,
+			Extra:  map[string]any{artifact.ExtraFormat: "tar.gz"},
+		},
+		{
+			Name:    "foo_windows_amd64.zip",
+			Path:    path("foo_windows_amd64.zip"),
+			Goos:    "windows",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.UploadableArchive,
+			Extra:   map[string]any{artifact.ExtraFormat: "zip"},
+		},
+		{
+			Name:    "foo",
+			Path:    path("foo"),
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+		},
+		{
+			Name:   "foo",
+			Path:   path("foo"),
+			Goos:   "darwin",
+			Goarch: "all",
+			Type:   artifact.UniversalBinary,
+			Extra:  map[string]any{artifact.ExtraReplaces: true},
+		},
+	} {
+		if art.Extra == nil {
+			art.Extra = map[string]any{}
+		}
+		art.Extra[artifact.ExtraID] = "foo"
+		ctx.Artifacts.Add(art)
+	}
+	return ctx
+}
+
+type fakeCmd struct {
+	execFn func(dir, cmd string, args ...string) ([]byte, error)
+}
+
+var _ cmder = fakeCmd{}
+
+func (f fakeCmd) Exec(_ *context.Context, dir, cmd string, args ...string) ([]byte, error) {
+	return f.execFn(dir, cmd, args...)
+}
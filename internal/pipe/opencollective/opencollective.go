@@ -12,6 +12,7 @@ import (
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -46,12 +47,18 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
-	title, err := tmpl.New(ctx).Apply(ctx.Config.Announce.OpenCollective.TitleTemplate)
+// Preview returns the title and the HTML of the update that would be
+// published.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	title, html, err := update(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
-	html, err := tmpl.New(ctx).Apply(ctx.Config.Announce.OpenCollective.MessageTemplate)
+	return title + "\n\n" + html, nil
+}
+
+func (p Pipe) Announce(ctx *context.Context) error {
+	title, html, err := update(ctx)
 	if err != nil {
 		return err
 	}
@@ -76,6 +83,19 @@ func (p Pipe) Announce(ctx *context.Context) error {
 	return c.publishUpdate(ctx, id)
 }
 
+// update returns the templated title and HTML of the update.
+func update(ctx *context.Context) (string, string, error) {
+	title, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.OpenCollective.TitleTemplate)
+	if err != nil {
+		return "", "", err
+	}
+	html, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.OpenCollective.MessageTemplate)
+	if err != nil {
+		return "", "", err
+	}
+	return title, html, nil
+}
+
 type client struct {
 	endpoint string
 	token    string
@@ -191,6 +211,11 @@ func (c client) doMutation(ctx *context.Context, payload payload) ([]byte, error
 		return nil, fmt.Errorf("could not marshal payload: %w", err)
 	}
 
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return retryx.DoWithData(ctx, ctx.Config.Retry, func() ([]byte, error) {
 		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(p))
 		if err != nil {
@@ -199,7 +224,7 @@ func (c client) doMutation(ctx *context.Context, payload payload) ([]byte, error
 		req.Header.Set("Personal-Token", c.token)
 		req.Header.Set("Content-Type", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := httpClient.Do(req)
 		if err != nil {
 			return nil, retryx.HTTP(fmt.Errorf("could not send request to opencollective: %w", err), resp)
 		}
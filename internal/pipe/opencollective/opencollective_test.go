@@ -23,6 +23,20 @@ func TestDefault(t *testing.T) {
 	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.OpenCollective.MessageTemplate)
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, `v1.0.0
+
+honk v1.0.0 is out!<br/>Check it out at <a href="https://github.com/honk/honk/releases/tag/v1.0.0">https://github.com/honk/honk/releases/tag/v1.0.0</a>`, out)
+}
+
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
@@ -0,0 +1,188 @@
+// Package opsgenie records releases as Opsgenie alerts.
+package opsgenie
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/caarlos0/log"
+
+	"github.com/goreleaser/goreleaser/v2/internal/announcement"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultAPI                 = "https://api.opsgenie.com"
+	defaultMessageTemplate     = `{{ .ProjectName }} {{ .Tag }} was released`
+	defaultDescriptionTemplate = `{{ .ReleaseNotes }}`
+	defaultPriority            = "P5"
+	defaultTag                 = "release"
+)
+
+type Pipe struct{}
+
+func (Pipe) String() string { return "opsgenie" }
+func (Pipe) Skip(ctx *context.Context) (bool, error) {
+	enable, err := tmpl.New(ctx).Bool(ctx.Config.Announce.Opsgenie.Enabled)
+	return !enable, err
+}
+
+type Config struct {
+	APIKey string `env:"OPSGENIE_API_KEY,notEmpty"`
+}
+
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Announce.Opsgenie
+	if cfg.API == "" {
+		cfg.API = defaultAPI
+	}
+	if cfg.MessageTemplate == "" {
+		cfg.MessageTemplate = defaultMessageTemplate
+	}
+	if cfg.DescriptionTemplate == "" {
+		cfg.DescriptionTemplate = defaultDescriptionTemplate
+	}
+	if cfg.Priority == "" {
+		cfg.Priority = defaultPriority
+	}
+	if len(cfg.Tags) == 0 {
+		cfg.Tags = []string{defaultTag}
+	}
+	return nil
+}
+
+// Preview returns the alert that would be created.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	_, alert, err := newAlert(ctx)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.MarshalIndent(alert, "", "  ")
+	return string(bts), err
+}
+
+func (Pipe) Announce(ctx *context.Context) error {
+	api, alert, err := newAlert(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := env.ParseAs[Config]()
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(api)
+	if err != nil {
+		return fmt.Errorf("opsgenie: invalid api: %w", err)
+	}
+	u = u.JoinPath("v2", "alerts")
+
+	bts, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("posting: '%s'", alert.Message)
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bts))
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "GenieKey "+cfg.APIKey)
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return retryx.HTTP(err, resp)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusAccepted {
+			return retryx.HTTP(fmt.Errorf("%s", resp.Status), resp)
+		}
+
+		return nil
+	}, retryx.IsRetriable)
+}
+
+// newAlert returns the templated API URL and the alert to create.
+func newAlert(ctx *context.Context) (string, Alert, error) {
+	ocfg := ctx.Config.Announce.Opsgenie
+	if !slices.Contains([]string{"P1", "P2", "P3", "P4", "P5"}, ocfg.Priority) {
+		return "", Alert{}, fmt.Errorf("opsgenie: invalid priority: %q", ocfg.Priority)
+	}
+
+	t := tmpl.New(ctx).WithMessageTemplates()
+	api, msg, description := ocfg.API, ocfg.MessageTemplate, ocfg.DescriptionTemplate
+	if err := t.ApplyAll(&api, &msg, &description); err != nil {
+		return "", Alert{}, err
+	}
+
+	var tags []string
+	for _, tag := range ocfg.Tags {
+		tag, err := t.Apply(tag)
+		if err != nil {
+			return "", Alert{}, err
+		}
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	details := map[string]string{
+		"project":      ctx.Config.ProjectName,
+		"version":      ctx.Version,
+		"tag":          ctx.Git.CurrentTag,
+		"previous_tag": ctx.Git.PreviousTag,
+		"commit":       ctx.Git.FullCommit,
+		"commit_range": announcement.CommitRange(ctx),
+		"release_url":  ctx.ReleaseURL,
+	}
+	for k, v := range ocfg.Details {
+		v, err := t.Apply(v)
+		if err != nil {
+			return "", Alert{}, err
+		}
+		details[k] = v
+	}
+	for k, v := range details {
+		if v == "" {
+			delete(details, k)
+		}
+	}
+
+	return api, Alert{
+		Message:     msg,
+		Alias:       ctx.Config.ProjectName + "-" + ctx.Git.CurrentTag,
+		Description: description,
+		Tags:        tags,
+		Details:     details,
+		Source:      "GoReleaser",
+		Priority:    ocfg.Priority,
+	}, nil
+}
+
+// Alert is an Opsgenie alert.
+type Alert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Priority    string            `json:"priority,omitempty"`
+}
@@ -0,0 +1,199 @@
+package opsgenie
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringer(t *testing.T) {
+	require.Equal(t, "opsgenie", Pipe{}.String())
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, defaultAPI, ctx.Config.Announce.Opsgenie.API)
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Opsgenie.MessageTemplate)
+	require.Equal(t, defaultDescriptionTemplate, ctx.Config.Announce.Opsgenie.DescriptionTemplate)
+	require.Equal(t, defaultPriority, ctx.Config.Announce.Opsgenie.Priority)
+	require.Equal(t, []string{defaultTag}, ctx.Config.Announce.Opsgenie.Tags)
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Opsgenie: config.Opsgenie{
+					Enabled: "true",
+				},
+			},
+		})
+
+		skip, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+}
+
+func TestAnnounceInvalidTemplate(t *testing.T) {
+	for name, cfg := range map[string]config.Opsgenie{
+		"message":     {MessageTemplate: "{{ .Foo }"},
+		"description": {DescriptionTemplate: "{{ .Foo }"},
+		"api":         {API: "{{ .Foo }"},
+		"tags":        {Tags: []string{"{{ .Foo }"}},
+		"details":     {Details: map[string]string{"foo": "{{ .Foo }"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{
+					Opsgenie: cfg,
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+		})
+	}
+}
+
+func TestAnnounceInvalidPriority(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Opsgenie: config.Opsgenie{
+				Priority: "P9",
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `opsgenie: invalid priority: "P9"`)
+}
+
+func TestAnnounceMissingEnv(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "OPSGENIE_API_KEY" should not be empty`)
+}
+
+func TestAnnounce(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/alerts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "GenieKey api-key", r.Header.Get("Authorization"))
+
+		var alert Alert
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &alert))
+		assert.Equal(t, Alert{
+			Message:     "honk v1.1.0 was released",
+			Alias:       "honk-v1.1.0",
+			Description: "- fixed the honk",
+			Tags:        []string{"release", "honk"},
+			Details: map[string]string{
+				"project":      "honk",
+				"version":      "1.1.0",
+				"tag":          "v1.1.0",
+				"previous_tag": "v1.0.0",
+				"commit":       "abcdef",
+				"commit_range": "v1.0.0...v1.1.0",
+				"release_url":  "https://github.com/honk/honk/releases/tag/v1.1.0",
+				"environment":  "production",
+			},
+			Source:   "GoReleaser",
+			Priority: "P4",
+		}, alert)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			Opsgenie: config.Opsgenie{
+				API:      ts.URL,
+				Priority: "P4",
+				Tags:     []string{"release", "{{ .ProjectName }}", "{{ .Env.NOPE }}"},
+				Details: map[string]string{
+					"environment": "{{ .Env.DEPLOY_ENV }}",
+				},
+			},
+		},
+	},
+		testctx.WithCurrentTag("v1.1.0"),
+		testctx.WithPreviousTag("v1.0.0"),
+		testctx.WithVersion("1.1.0"),
+		testctx.WithCommit("abcdef"),
+		testctx.WithEnv(map[string]string{"DEPLOY_ENV": "production", "NOPE": ""}),
+	)
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.1.0"
+	ctx.ReleaseNotes = "- fixed the honk"
+	t.Setenv("OPSGENIE_API_KEY", "api-key")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+}
+
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+	},
+		testctx.WithCurrentTag("v1.1.0"),
+		testctx.WithVersion("1.1.0"),
+	)
+	ctx.ReleaseNotes = "- fixed the honk"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+
+	var alert Alert
+	require.NoError(t, json.Unmarshal([]byte(out), &alert))
+	require.Equal(t, Alert{
+		Message:     "honk v1.1.0 was released",
+		Alias:       "honk-v1.1.0",
+		Description: "- fixed the honk",
+		Tags:        []string{"release"},
+		Details: map[string]string{
+			"project": "honk",
+			"version": "1.1.0",
+			"tag":     "v1.1.0",
+		},
+		Source:   "GoReleaser",
+		Priority: "P5",
+	}, alert)
+}
+
+func TestAnnounceError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Opsgenie: config.Opsgenie{
+				API: ts.URL,
+			},
+		},
+	})
+	t.Setenv("OPSGENIE_API_KEY", "api-key")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Announce(ctx), "401 Unauthorized")
+}
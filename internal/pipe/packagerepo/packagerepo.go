@@ -0,0 +1,600 @@
+// Package packagerepo implements the Pipe interface creating apt, yum, apk,
+// and pacman repositories out of the Linux packages, and publishing them.
+package packagerepo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	h "net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/http"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/blob"
+	"github.com/goreleaser/goreleaser/v2/internal/redact"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	formatDeb       = "deb"
+	formatRPM       = "rpm"
+	formatAPK       = "apk"
+	formatArchlinux = "archlinux"
+
+	// uploadKind is used to get the upload credentials from the environment,
+	// e.g. REPOSITORY_MYNAME_SECRET.
+	uploadKind = "repository"
+)
+
+// apkArchs maps the GOARCHs (and GOARMs) to their Alpine architecture.
+var apkArchs = map[string]string{
+	"amd64":   "x86_64",
+	"386":     "x86",
+	"arm64":   "aarch64",
+	"arm6":    "armhf",
+	"arm7":    "armv7",
+	"ppc64le": "ppc64le",
+	"s390x":   "s390x",
+	"riscv64": "riscv64",
+	"loong64": "loongarch64",
+}
+
+// archlinuxArchs maps the GOARCHs (and GOARMs) to their Arch Linux
+// architecture.
+var archlinuxArchs = map[string]string{
+	"amd64": "x86_64",
+	"386":   "i686",
+	"arm64": "aarch64",
+	"arm6":  "armv6h",
+	"arm7":  "armv7h",
+}
+
+// Pipe for apt, yum, apk, and pacman repositories.
+type Pipe struct{}
+
+func (Pipe) String() string { return "package repositories" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.PackageRepo) || len(ctx.Config.PackageRepos) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	var cmds []string
+	for _, repo := range ctx.Config.PackageRepos {
+		if slices.Contains(repo.Formats, formatDeb) {
+			cmds = append(cmds, "apt-ftparchive")
+		}
+		if slices.Contains(repo.Formats, formatRPM) {
+			cmds = append(cmds, "createrepo_c")
+		}
+		if slices.Contains(repo.Formats, formatAPK) {
+			cmds = append(cmds, "apk")
+			if repo.APK.KeyFile != "" {
+				cmds = append(cmds, "abuild-sign")
+			}
+		}
+		if slices.Contains(repo.Formats, formatArchlinux) {
+			cmds = append(cmds, "repo-add")
+		}
+		if repo.Signature.KeyID != "" {
+			cmds = append(cmds, "gpg")
+		}
+	}
+	return cmds
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("package_repositories")
+	for i := range ctx.Config.PackageRepos {
+		repo := &ctx.Config.PackageRepos[i]
+		if repo.ID == "" {
+			repo.ID = "default"
+		}
+		if len(repo.Formats) == 0 {
+			repo.Formats = []string{formatDeb, formatRPM}
+		}
+		for _, format := range repo.Formats {
+			if !slices.Contains([]string{formatDeb, formatRPM, formatAPK, formatArchlinux}, format) {
+				return fmt.Errorf("package repository %q: invalid format %q, must be either deb, rpm, apk, or archlinux", repo.ID, format)
+			}
+		}
+		if repo.Deb.Distribution == "" {
+			repo.Deb.Distribution = "stable"
+		}
+		if repo.Deb.Component == "" {
+			repo.Deb.Component = "main"
+		}
+		if repo.Deb.Origin == "" {
+			repo.Deb.Origin = "{{ .ProjectName }}"
+		}
+		if repo.Deb.Label == "" {
+			repo.Deb.Label = "{{ .ProjectName }}"
+		}
+		if repo.Archlinux.Name == "" {
+			repo.Archlinux.Name = "{{ .ProjectName }}"
+		}
+		for _, b := range repo.Blobs {
+			if b.Bucket == "" || b.Provider == "" {
+				return fmt.Errorf("package repository %q: bucket or provider cannot be empty", repo.ID)
+			}
+		}
+		if err := http.Defaults(repo.Uploads); err != nil {
+			return err
+		}
+		ids.Inc(repo.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for _, repo := range ctx.Config.PackageRepos {
+		g.Go(func() error {
+			return doRun(ctx, repo)
+		})
+	}
+	return g.Wait()
+}
+
+func doRun(ctx *context.Context, repo config.PackageRepo) error {
+	disable, err := tmpl.New(ctx).Bool(repo.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("disabled")
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "repositories", repo.ID)
+	var created bool
+	for _, format := range repo.Formats {
+		packages := ctx.Artifacts.Filter(artifact.And(
+			artifact.ByType(artifact.LinuxPackage),
+			artifact.ByGoos("linux"),
+			artifact.ByFormats(format),
+			artifact.ByIDs(repo.IDs...),
+		)).List()
+		if len(packages) == 0 {
+			log.WithField("id", repo.ID).
+				WithField("format", format).
+				Warn("no packages found")
+			continue
+		}
+
+		log.WithField("id", repo.ID).
+			WithField("format", format).
+			WithField("packages", len(packages)).
+			Info("creating repository")
+		switch format {
+		case formatDeb:
+			err = createDeb(ctx, repo, filepath.Join(dir, formatDeb), packages)
+		case formatRPM:
+			err = createRPM(ctx, repo, filepath.Join(dir, formatRPM), packages)
+		case formatAPK:
+			err = createAPK(ctx, repo, filepath.Join(dir, formatAPK), packages)
+		case formatArchlinux:
+			err = createArchlinux(ctx, repo, filepath.Join(dir, formatArchlinux), packages)
+		}
+		if err != nil {
+			return err
+		}
+		created = true
+	}
+	if !created {
+		return pipe.Skipf("no %s packages found for ids %v", strings.Join(repo.Formats, " or "), repo.IDs)
+	}
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: artifact.PackageRepository,
+			Name: filepath.ToSlash(name),
+			Path: path,
+			Extra: map[string]any{
+				artifact.ExtraID: repo.ID,
+			},
+		})
+		return nil
+	})
+}
+
+// createDeb creates an apt repository with the following layout:
+//
+//	pool/<component>/<package>.deb
+//	dists/<distribution>/Release
+//	dists/<distribution>/InRelease (signed)
+//	dists/<distribution>/Release.gpg (signed)
+//	dists/<distribution>/<component>/binary-<arch>/Packages
+//	dists/<distribution>/<component>/binary-<arch>/Packages.gz
+func createDeb(ctx *context.Context, repo config.PackageRepo, dir string, packages []*artifact.Artifact) error {
+	deb := repo.Deb
+	if err := tmpl.New(ctx).ApplyAll(
+		&deb.Distribution,
+		&deb.Component,
+		&deb.Origin,
+		&deb.Label,
+		&deb.Description,
+	); err != nil {
+		return err
+	}
+
+	pool := filepath.Join("pool", deb.Component)
+	if err := copyPackages(packages, filepath.Join(dir, pool)); err != nil {
+		return err
+	}
+
+	index, err := run(ctx, repo, dir, "apt-ftparchive", "packages", filepath.ToSlash(pool))
+	if err != nil {
+		return err
+	}
+
+	dist := filepath.Join(dir, "dists", deb.Distribution)
+	indexes := splitByArch(index)
+	archs := make([]string, 0, len(indexes))
+	for arch, stanzas := range indexes {
+		archs = append(archs, arch)
+		if err := writeIndex(filepath.Join(dist, deb.Component, "binary-"+arch), stanzas); err != nil {
+			return err
+		}
+	}
+	slices.Sort(archs)
+
+	args := []string{
+		"-o", "APT::FTPArchive::Release::Origin=" + deb.Origin,
+		"-o", "APT::FTPArchive::Release::Label=" + deb.Label,
+		"-o", "APT::FTPArchive::Release::Suite=" + deb.Distribution,
+		"-o", "APT::FTPArchive::Release::Codename=" + deb.Distribution,
+		"-o", "APT::FTPArchive::Release::Architectures=" + strings.Join(archs, " "),
+		"-o", "APT::FTPArchive::Release::Components=" + deb.Component,
+	}
+	if deb.Description != "" {
+		args = append(args, "-o", "APT::FTPArchive::Release::Description="+deb.Description)
+	}
+	release, err := run(ctx, repo, dist, "apt-ftparchive", append(args, "release", ".")...)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dist, "Release"), release, 0o644); err != nil {
+		return err
+	}
+
+	return sign(ctx, repo, dist, "Release", map[string][]string{
+		"InRelease":   {"--clearsign"},
+		"Release.gpg": {"--armor", "--detach-sign"},
+	})
+}
+
+// createRPM creates a yum repository with the following layout:
+//
+//	packages/<package>.rpm
+//	repodata/repomd.xml
+//	repodata/repomd.xml.asc (signed)
+//	repodata/...
+func createRPM(ctx *context.Context, repo config.PackageRepo, dir string, packages []*artifact.Artifact) error {
+	if err := copyPackages(packages, filepath.Join(dir, "packages")); err != nil {
+		return err
+	}
+	if _, err := run(ctx, repo, dir, "createrepo_c", "."); err != nil {
+		return err
+	}
+	return sign(ctx, repo, filepath.Join(dir, "repodata"), "repomd.xml", map[string][]string{
+		"repomd.xml.asc": {"--armor", "--detach-sign"},
+	})
+}
+
+// createAPK creates an apk repository with the following layout:
+//
+//	<arch>/<package>.apk
+//	<arch>/APKINDEX.tar.gz (signed)
+func createAPK(ctx *context.Context, repo config.PackageRepo, dir string, packages []*artifact.Artifact) error {
+	key, err := tmpl.New(ctx).Apply(repo.APK.KeyFile)
+	if err != nil {
+		return err
+	}
+	if key != "" {
+		if key, err = filepath.Abs(key); err != nil {
+			return err
+		}
+	}
+	keyName, err := tmpl.New(ctx).Apply(repo.APK.KeyName)
+	if err != nil {
+		return err
+	}
+
+	for arch, pkgs := range groupByArch(packages, apkArchs) {
+		archDir := filepath.Join(dir, arch)
+		if err := copyPackages(pkgs, archDir); err != nil {
+			return err
+		}
+
+		args := []string{"index", "--allow-untrusted", "--output", "APKINDEX.tar.gz"}
+		for _, pkg := range pkgs {
+			args = append(args, pkg.Name)
+		}
+		if _, err := run(ctx, repo, archDir, "apk", args...); err != nil {
+			return err
+		}
+
+		if key == "" {
+			continue
+		}
+		args = []string{"-k", key}
+		if keyName != "" {
+			args = append(args, "-p", keyName)
+		}
+		if _, err := run(ctx, repo, archDir, "abuild-sign", append(args, "APKINDEX.tar.gz")...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createArchlinux creates a pacman repository with the following layout:
+//
+//	<arch>/<package>.pkg.tar.zst
+//	<arch>/<name>.db (link to <name>.db.tar.gz)
+//	<arch>/<name>.db.tar.gz
+//	<arch>/<name>.db.tar.gz.sig (signed)
+//	<arch>/<name>.files (link to <name>.files.tar.gz)
+//	<arch>/<name>.files.tar.gz
+//	<arch>/<name>.files.tar.gz.sig (signed)
+func createArchlinux(ctx *context.Context, repo config.PackageRepo, dir string, packages []*artifact.Artifact) error {
+	t := tmpl.New(ctx)
+	name, err := t.Apply(repo.Archlinux.Name)
+	if err != nil {
+		return err
+	}
+	key, err := t.Apply(repo.Signature.KeyID)
+	if err != nil {
+		return err
+	}
+
+	for arch, pkgs := range groupByArch(packages, archlinuxArchs) {
+		archDir := filepath.Join(dir, arch)
+		if err := copyPackages(pkgs, archDir); err != nil {
+			return err
+		}
+
+		var args []string
+		if key != "" {
+			args = append(args, "--sign", "--key", key)
+		}
+		args = append(args, name+".db.tar.gz")
+		for _, pkg := range pkgs {
+			args = append(args, pkg.Name)
+		}
+		if _, err := run(ctx, repo, archDir, "repo-add", args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupByArch groups the packages by their architecture, as named by the
+// given map.
+// Packages for all architectures are added to every group, and packages of
+// unknown architectures are ignored.
+func groupByArch(packages []*artifact.Artifact, archs map[string]string) map[string][]*artifact.Artifact {
+	result := map[string][]*artifact.Artifact{}
+	var all []*artifact.Artifact
+	for _, pkg := range packages {
+		if pkg.Goarch == "all" {
+			all = append(all, pkg)
+			continue
+		}
+		arch, ok := archs[pkg.Goarch+pkg.Goarm]
+		if !ok {
+			log.WithField("package", pkg.Name).
+				WithField("arch", pkg.Goarch+pkg.Goarm).
+				Warn("unsupported architecture, skipping")
+			continue
+		}
+		result[arch] = append(result[arch], pkg)
+	}
+	for arch := range result {
+		result[arch] = append(result[arch], all...)
+	}
+	return result
+}
+
+func copyPackages(packages []*artifact.Artifact, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, pkg := range packages {
+		if err := gio.Copy(pkg.Path, filepath.Join(dir, pkg.Name)); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", pkg.Name, err)
+		}
+	}
+	return nil
+}
+
+// splitByArch splits the given apt index by architecture.
+// Packages for the 'all' architecture are added to every other architecture.
+func splitByArch(index []byte) map[string][]string {
+	result := map[string][]string{}
+	var all []string
+	for stanza := range strings.SplitSeq(strings.TrimSpace(string(index)), "\n\n") {
+		stanza = strings.TrimSpace(stanza)
+		if stanza == "" {
+			continue
+		}
+		arch := "all"
+		for line := range strings.SplitSeq(stanza, "\n") {
+			if value, ok := strings.CutPrefix(line, "Architecture:"); ok {
+				arch = strings.TrimSpace(value)
+				break
+			}
+		}
+		if arch == "all" {
+			all = append(all, stanza)
+			continue
+		}
+		result[arch] = append(result[arch], stanza)
+	}
+	if len(all) == 0 {
+		return result
+	}
+	if len(result) == 0 {
+		result["all"] = all
+		return result
+	}
+	for arch := range result {
+		result[arch] = append(result[arch], all...)
+	}
+	return result
+}
+
+// writeIndex writes the Packages and Packages.gz files into dir.
+func writeIndex(dir string, stanzas []string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	content := []byte(strings.Join(stanzas, "\n\n") + "\n")
+	if err := os.WriteFile(filepath.Join(dir, "Packages"), content, 0o644); err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	if _, err := gz.Write(content); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "Packages.gz"), b.Bytes(), 0o644)
+}
+
+// sign signs the given file with gpg, if a key is set.
+// The outputs map the name of each signature file to the gpg flags used
+// to create it.
+func sign(ctx *context.Context, repo config.PackageRepo, dir, name string, outputs map[string][]string) error {
+	key, err := tmpl.New(ctx).Apply(repo.Signature.KeyID)
+	if err != nil {
+		return err
+	}
+	if key == "" {
+		return nil
+	}
+	for output, flags := range outputs {
+		args := append([]string{"--batch", "--yes", "--local-user", key, "--output", output}, flags...)
+		if _, err := run(ctx, repo, dir, "gpg", append(args, name)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run runs the given command in dir, returning its standard output.
+func run(ctx *context.Context, repo config.PackageRepo, dir, name string, arg ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, arg...)
+	cmd.Dir = dir
+	cmd.Env = append(ctx.Env.Strings(), cmd.Environ()...)
+	var stdout, stderr bytes.Buffer
+	w := gio.Safe(&stderr)
+	cmd.Stdout = &stdout
+	cmd.Stderr = redact.Writer(io.MultiWriter(logext.NewWriter(), w), cmd.Env)
+	if err := cmd.Run(); err != nil {
+		return nil, gerrors.Wrap(
+			err,
+			gerrors.WithMessage("could not create package repository"),
+			gerrors.WithDetails(
+				"args", strings.Join(cmd.Args, " "),
+				"id", repo.ID,
+			),
+			gerrors.WithOutput(stderr.String()),
+		)
+	}
+	return stdout.Bytes(), nil
+}
+
+// Publish the repositories.
+func (Pipe) Publish(ctx *context.Context) error {
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for _, repo := range ctx.Config.PackageRepos {
+		g.Go(func() error {
+			return doPublish(ctx, repo)
+		})
+	}
+	return g.Wait()
+}
+
+func doPublish(ctx *context.Context, repo config.PackageRepo) error {
+	if len(repo.Blobs) == 0 && len(repo.Uploads) == 0 {
+		return pipe.Skip("no blobs or uploads configured")
+	}
+	files := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.PackageRepository),
+		artifact.ByIDs(repo.ID),
+	)).List()
+	if len(files) == 0 {
+		return pipe.Skipf("no files found for package repository %q", repo.ID)
+	}
+
+	skips := &pipe.SkipMemento{}
+	for _, conf := range repo.Blobs {
+		disable, err := tmpl.New(ctx).Bool(conf.Disable)
+		if err != nil {
+			return err
+		}
+		if disable {
+			skips.Remember(pipe.Skip("blob is disabled"))
+			continue
+		}
+		if err := blob.UploadArtifacts(ctx, conf, files); err != nil {
+			return err
+		}
+	}
+	for _, upload := range repo.Uploads {
+		if err := http.CheckConfig(ctx, &upload, uploadKind); err != nil {
+			if pipe.IsSkip(err) {
+				skips.Remember(err)
+				continue
+			}
+			return err
+		}
+		skip, err := tmpl.New(ctx).Bool(upload.Skip)
+		if err != nil {
+			return err
+		}
+		if skip {
+			skips.Remember(pipe.Skip("upload is skipped"))
+			continue
+		}
+		if err := http.UploadArtifacts(ctx, &upload, files, uploadKind, func(res *h.Response) error {
+			if c := res.StatusCode; c < 200 || 299 < c {
+				return fmt.Errorf("unexpected http response status: %s", res.Status)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
@@ -0,0 +1,545 @@
+package packagerepo
+
+import (
+	"compress/gzip"
+	"io"
+	h "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			PackageRepos: []config.PackageRepo{{}},
+		}, testctx.Skip(skips.PackageRepo))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			PackageRepos: []config.PackageRepo{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		PackageRepos: []config.PackageRepo{
+			{Formats: []string{"deb"}},
+			{Formats: []string{"rpm"}, Signature: config.PackageRepoSignature{KeyID: "foo"}},
+			{Formats: []string{"apk"}, APK: config.PackageRepoAPK{KeyFile: "key.rsa"}},
+			{Formats: []string{"archlinux"}},
+		},
+	})
+	require.Equal(t, []string{"apt-ftparchive", "createrepo_c", "gpg", "apk", "abuild-sign", "repo-add"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		PackageRepos: []config.PackageRepo{{
+			Uploads: []config.Upload{{Name: "foo"}},
+		}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	repo := ctx.Config.PackageRepos[0]
+	require.Equal(t, "default", repo.ID)
+	require.Equal(t, []string{"deb", "rpm"}, repo.Formats)
+	require.Equal(t, config.PackageRepoDeb{
+		Distribution: "stable",
+		Component:    "main",
+		Origin:       "{{ .ProjectName }}",
+		Label:        "{{ .ProjectName }}",
+	}, repo.Deb)
+	require.Equal(t, "{{ .ProjectName }}", repo.Archlinux.Name)
+	require.Equal(t, "PUT", repo.Uploads[0].Method)
+}
+
+func TestDefaultInvalidFormat(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		PackageRepos: []config.PackageRepo{{Formats: []string{"pkg"}}},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), `package repository "default": invalid format "pkg", must be either deb, rpm, apk, or archlinux`)
+}
+
+func TestDefaultInvalidBlob(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		PackageRepos: []config.PackageRepo{{Blobs: []config.Blob{{Bucket: "foo"}}}},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), `package repository "default": bucket or provider cannot be empty`)
+}
+
+func TestDefaultDuplicatedID(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		PackageRepos: []config.PackageRepo{{}, {}},
+	})
+	require.Error(t, Pipe{}.Default(ctx))
+}
+
+func TestRun(t *testing.T) {
+	log := fakeTools(t, 0)
+	ctx := makeContext(t)
+	ctx.Config.PackageRepos[0].Signature.KeyID = "{{ .Env.GPG_KEY }}"
+	ctx.Config.PackageRepos[0].Deb.Description = "My {{ .ProjectName }} repo"
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	dir := filepath.Join(ctx.Config.Dist, "repositories", "default")
+	var names []string
+	for _, a := range ctx.Artifacts.Filter(artifact.ByType(artifact.PackageRepository)).List() {
+		require.Equal(t, "default", a.ID())
+		require.Equal(t, filepath.Join(dir, filepath.FromSlash(a.Name)), a.Path)
+		names = append(names, a.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"deb/pool/main/foo_1.0.0_linux_amd64.deb",
+		"deb/pool/main/foo_1.0.0_linux_arm64.deb",
+		"deb/pool/main/foo-doc_1.0.0_linux_all.deb",
+		"deb/dists/stable/Release",
+		"deb/dists/stable/InRelease",
+		"deb/dists/stable/Release.gpg",
+		"deb/dists/stable/main/binary-amd64/Packages",
+		"deb/dists/stable/main/binary-amd64/Packages.gz",
+		"deb/dists/stable/main/binary-arm64/Packages",
+		"deb/dists/stable/main/binary-arm64/Packages.gz",
+		"rpm/packages/foo-1.0.0.x86_64.rpm",
+		"rpm/repodata/repomd.xml",
+		"rpm/repodata/repomd.xml.asc",
+	}, names)
+
+	amd64 := readFile(t, filepath.Join(dir, "deb/dists/stable/main/binary-amd64/Packages"))
+	require.Contains(t, amd64, "Filename: pool/main/foo_1.0.0_linux_amd64.deb")
+	require.Contains(t, amd64, "Filename: pool/main/foo-doc_1.0.0_linux_all.deb")
+	require.NotContains(t, amd64, "arm64")
+
+	gz, err := os.Open(filepath.Join(dir, "deb/dists/stable/main/binary-amd64/Packages.gz"))
+	require.NoError(t, err)
+	defer gz.Close()
+	r, err := gzip.NewReader(gz)
+	require.NoError(t, err)
+	bts, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, amd64, string(bts))
+
+	require.Equal(t, "signed\n", readFile(t, filepath.Join(dir, "deb/dists/stable/InRelease")))
+
+	calls := testlib.ReadFakeLog(t, log)
+	require.Contains(t, calls, "apt-ftparchive packages pool/main")
+	require.Contains(t, calls, "apt-ftparchive "+strings.Join([]string{
+		"-o APT::FTPArchive::Release::Origin=myproj",
+		"-o APT::FTPArchive::Release::Label=myproj",
+		"-o APT::FTPArchive::Release::Suite=stable",
+		"-o APT::FTPArchive::Release::Codename=stable",
+		"-o APT::FTPArchive::Release::Architectures=amd64 arm64",
+		"-o APT::FTPArchive::Release::Components=main",
+		"-o APT::FTPArchive::Release::Description=My myproj repo",
+		"release .",
+	}, " "))
+	require.Contains(t, calls, "gpg --batch --yes --local-user ABCD --output InRelease --clearsign Release")
+	require.Contains(t, calls, "gpg --batch --yes --local-user ABCD --output Release.gpg --armor --detach-sign Release")
+	require.Contains(t, calls, "createrepo_c .")
+	require.Contains(t, calls, "gpg --batch --yes --local-user ABCD --output repomd.xml.asc --armor --detach-sign repomd.xml")
+}
+
+func TestRunOnlyDeb(t *testing.T) {
+	log := fakeTools(t, 0)
+	ctx := makeContext(t)
+	ctx.Config.PackageRepos[0].Formats = []string{"deb"}
+	ctx.Config.PackageRepos[0].IDs = []string{"doc"}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	dir := filepath.Join(ctx.Config.Dist, "repositories", "default", "deb")
+	require.Contains(t, readFile(t, filepath.Join(dir, "dists/stable/main/binary-all/Packages")), "foo-doc")
+	require.NoFileExists(t, filepath.Join(dir, "dists/stable/InRelease"))
+	require.NoDirExists(t, filepath.Join(dir, "..", "rpm"))
+	for _, call := range testlib.ReadFakeLog(t, log) {
+		require.True(t, strings.HasPrefix(call, "apt-ftparchive "), call)
+	}
+}
+
+func TestRunAPK(t *testing.T) {
+	log := fakeTools(t, 0)
+	ctx := makeContext(t)
+	ctx.Config.PackageRepos[0].Formats = []string{"apk"}
+	ctx.Config.PackageRepos[0].APK = config.PackageRepoAPK{
+		KeyFile: "{{ .Env.APK_KEY }}",
+		KeyName: "me.rsa.pub",
+	}
+	ctx.Env["APK_KEY"] = "me.rsa"
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	var names []string
+	for _, a := range ctx.Artifacts.Filter(artifact.ByType(artifact.PackageRepository)).List() {
+		names = append(names, a.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"apk/x86_64/foo_1.0.0_linux_amd64.apk",
+		"apk/x86_64/APKINDEX.tar.gz",
+		"apk/armv7/foo_1.0.0_linux_armv7.apk",
+		"apk/armv7/APKINDEX.tar.gz",
+	}, names)
+
+	key, err := filepath.Abs("me.rsa")
+	require.NoError(t, err)
+	calls := testlib.ReadFakeLog(t, log)
+	require.ElementsMatch(t, []string{
+		"apk index --allow-untrusted --output APKINDEX.tar.gz foo_1.0.0_linux_amd64.apk",
+		"apk index --allow-untrusted --output APKINDEX.tar.gz foo_1.0.0_linux_armv7.apk",
+		"abuild-sign -k " + key + " -p me.rsa.pub APKINDEX.tar.gz",
+		"abuild-sign -k " + key + " -p me.rsa.pub APKINDEX.tar.gz",
+	}, calls)
+}
+
+func TestRunAPKNoKey(t *testing.T) {
+	log := fakeTools(t, 0)
+	ctx := makeContext(t)
+	ctx.Config.PackageRepos[0].Formats = []string{"apk"}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+	for _, call := range testlib.ReadFakeLog(t, log) {
+		require.True(t, strings.HasPrefix(call, "apk index "), call)
+	}
+}
+
+func TestRunArchlinux(t *testing.T) {
+	log := fakeTools(t, 0)
+	ctx := makeContext(t)
+	ctx.Config.PackageRepos[0].Formats = []string{"archlinux"}
+	ctx.Config.PackageRepos[0].Signature.KeyID = "{{ .Env.GPG_KEY }}"
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	var names []string
+	for _, a := range ctx.Artifacts.Filter(artifact.ByType(artifact.PackageRepository)).List() {
+		names = append(names, a.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"archlinux/x86_64/foo-1.0.0-1-x86_64.pkg.tar.zst",
+		"archlinux/x86_64/foo-doc-1.0.0-1-any.pkg.tar.zst",
+		"archlinux/x86_64/myproj.db",
+		"archlinux/x86_64/myproj.db.tar.gz",
+		"archlinux/aarch64/foo-1.0.0-1-aarch64.pkg.tar.zst",
+		"archlinux/aarch64/foo-doc-1.0.0-1-any.pkg.tar.zst",
+		"archlinux/aarch64/myproj.db",
+		"archlinux/aarch64/myproj.db.tar.gz",
+	}, names)
+
+	require.Equal(t, "db\n", readFile(t, filepath.Join(ctx.Config.Dist, "repositories", "default", "archlinux", "x86_64", "myproj.db")))
+	require.ElementsMatch(t, []string{
+		"repo-add --sign --key ABCD myproj.db.tar.gz foo-1.0.0-1-x86_64.pkg.tar.zst foo-doc-1.0.0-1-any.pkg.tar.zst",
+		"repo-add --sign --key ABCD myproj.db.tar.gz foo-1.0.0-1-aarch64.pkg.tar.zst foo-doc-1.0.0-1-any.pkg.tar.zst",
+	}, testlib.ReadFakeLog(t, log))
+}
+
+func TestRunNoPackages(t *testing.T) {
+	fakeTools(t, 0)
+	ctx := makeContext(t)
+	ctx.Config.PackageRepos[0].IDs = []string{"nope"}
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.PackageRepository)).List())
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctx := makeContext(t)
+	ctx.Config.PackageRepos[0].Disable = "true"
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+}
+
+func TestRunBadTemplate(t *testing.T) {
+	fakeTools(t, 0)
+	for name, fn := range map[string]func(*config.PackageRepo){
+		"disable":      func(r *config.PackageRepo) { r.Disable = "{{ .Nope }}" },
+		"distribution": func(r *config.PackageRepo) { r.Deb.Distribution = "{{ .Nope }}" },
+		"key_id":       func(r *config.PackageRepo) { r.Signature.KeyID = "{{ .Nope }}" },
+		"archlinux name": func(r *config.PackageRepo) {
+			r.Formats = []string{"archlinux"}
+			r.Archlinux.Name = "{{ .Nope }}"
+		},
+		"apk key_file": func(r *config.PackageRepo) {
+			r.Formats = []string{"apk"}
+			r.APK.KeyFile = "{{ .Nope }}"
+		},
+		"apk key_name": func(r *config.PackageRepo) {
+			r.Formats = []string{"apk"}
+			r.APK.KeyName = "{{ .Nope }}"
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := makeContext(t)
+			fn(&ctx.Config.PackageRepos[0])
+			require.NoError(t, Pipe{}.Default(ctx))
+			testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+		})
+	}
+}
+
+func TestRunToolFails(t *testing.T) {
+	fakeTools(t, 1)
+	ctx := makeContext(t)
+	require.NoError(t, Pipe{}.Default(ctx))
+	err := Pipe{}.Run(ctx)
+	var gerr gerrors.ErrDetailed
+	require.ErrorAs(t, err, &gerr)
+	require.Equal(t, []string{"could not create package repository"}, gerr.Messages())
+}
+
+func TestSplitByArch(t *testing.T) {
+	t.Run("mixed", func(t *testing.T) {
+		require.Equal(t, map[string][]string{
+			"amd64": {"Package: a\nArchitecture: amd64", "Package: c\nArchitecture: all"},
+			"arm64": {"Package: b\nArchitecture: arm64", "Package: c\nArchitecture: all"},
+		}, splitByArch([]byte("Package: a\nArchitecture: amd64\n\nPackage: b\nArchitecture: arm64\n\nPackage: c\nArchitecture: all\n\n")))
+	})
+	t.Run("only all", func(t *testing.T) {
+		require.Equal(t, map[string][]string{
+			"all": {"Package: c\nArchitecture: all"},
+		}, splitByArch([]byte("Package: c\nArchitecture: all\n")))
+	})
+	t.Run("empty", func(t *testing.T) {
+		require.Empty(t, splitByArch(nil))
+	})
+}
+
+func TestPublish(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(h.HandlerFunc(func(w h.ResponseWriter, r *h.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		user, pass, _ := r.BasicAuth()
+		paths = append(paths, r.Method+" "+user+":"+pass+" "+r.URL.Path)
+		w.WriteHeader(h.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "myproj",
+		PackageRepos: []config.PackageRepo{{
+			Uploads: []config.Upload{{
+				Name:     "production",
+				Target:   srv.URL + "/repo/",
+				Username: "user",
+			}},
+		}},
+	}, testctx.WithEnv(map[string]string{
+		"REPOSITORY_PRODUCTION_SECRET": "secret",
+	}))
+	require.NoError(t, Pipe{}.Default(ctx))
+	addRepoFiles(t, ctx, "default", "deb/dists/stable/Release", "rpm/repodata/repomd.xml")
+	addRepoFiles(t, ctx, "other", "deb/dists/stable/Release")
+
+	require.NoError(t, Pipe{}.Publish(ctx))
+	slices.Sort(paths)
+	require.Equal(t, []string{
+		"PUT user:secret /repo/deb/dists/stable/Release",
+		"PUT user:secret /repo/rpm/repodata/repomd.xml",
+	}, paths)
+}
+
+func TestPublishUploadError(t *testing.T) {
+	srv := httptest.NewServer(h.HandlerFunc(func(w h.ResponseWriter, _ *h.Request) {
+		w.WriteHeader(h.StatusForbidden)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		PackageRepos: []config.PackageRepo{{
+			Uploads: []config.Upload{{
+				Name:   "production",
+				Target: srv.URL,
+			}},
+		}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	addRepoFiles(t, ctx, "default", "deb/dists/stable/Release")
+	require.ErrorContains(t, Pipe{}.Publish(ctx), "unexpected http response status: 403 Forbidden")
+}
+
+func TestPublishSkip(t *testing.T) {
+	t.Run("nothing configured", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			PackageRepos: []config.PackageRepo{{}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+	})
+	t.Run("no files", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			PackageRepos: []config.PackageRepo{{
+				Uploads: []config.Upload{{Name: "production", Target: "http://nope"}},
+			}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			PackageRepos: []config.PackageRepo{{
+				Blobs: []config.Blob{{
+					Bucket:   "foo",
+					Provider: "s3",
+					Disable:  "true",
+				}},
+				Uploads: []config.Upload{{
+					Name:   "production",
+					Target: "http://nope",
+					Skip:   "true",
+				}},
+			}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		addRepoFiles(t, ctx, "default", "deb/dists/stable/Release")
+		testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+	})
+}
+
+func addRepoFiles(tb testing.TB, ctx *context.Context, id string, names ...string) {
+	tb.Helper()
+	dir := tb.TempDir()
+	for _, name := range names {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(tb, os.WriteFile(path, []byte(name), 0o644))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: artifact.PackageRepository,
+			Name: name,
+			Path: path,
+			Extra: map[string]any{
+				artifact.ExtraID: id,
+			},
+		})
+	}
+}
+
+// fakeTools puts fake apt-ftparchive, createrepo_c, gpg, apk, abuild-sign,
+// and repo-add commands in the PATH, logging their arguments.
+func fakeTools(tb testing.TB, exitCode int) string {
+	tb.Helper()
+	scripts := map[string]string{
+		"apt-ftparchive": `
+if [ "$1" = "packages" ]; then
+	for f in "$2"/*.deb; do
+		case "$f" in
+		*amd64*) arch=amd64 ;;
+		*arm64*) arch=arm64 ;;
+		*) arch=all ;;
+		esac
+		printf 'Package: %s\nArchitecture: %s\nFilename: %s\n\n' "$(basename "$f")" "$arch" "$f"
+	done
+else
+	echo "Origin: fake"
+fi
+`,
+		"createrepo_c": `
+mkdir -p repodata
+echo '<repomd/>' > repodata/repomd.xml
+`,
+		"apk": `
+while [ $# -gt 0 ]; do
+	[ "$1" = "--output" ] && out="$2"
+	shift
+done
+echo index > "$out"
+`,
+		"abuild-sign": "",
+		"repo-add": `
+for arg in "$@"; do
+	case "$arg" in
+	*.db.tar.gz) db="$arg" ;;
+	esac
+done
+echo db > "$db"
+ln -sf "$db" "${db%.tar.gz}"
+`,
+		"gpg": `
+while [ $# -gt 0 ]; do
+	[ "$1" = "--output" ] && out="$2"
+	shift
+done
+echo signed > "$out"
+`,
+	}
+	if exitCode != 0 {
+		for name := range scripts {
+			scripts[name] = "exit " + strconv.Itoa(exitCode)
+		}
+	}
+	log := testlib.FakeCommands(tb, scripts)
+	tb.Setenv("GPG_KEY", "ABCD")
+	return log
+}
+
+func readFile(tb testing.TB, path string) string {
+	tb.Helper()
+	bts, err := os.ReadFile(path)
+	require.NoError(tb, err)
+	return string(bts)
+}
+
+func makeContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName:  "myproj",
+		Dist:         tb.TempDir(),
+		PackageRepos: []config.PackageRepo{{}},
+	}, testctx.WithVersion("1.0.0"))
+
+	tmp := tb.TempDir()
+	for _, pkg := range []struct {
+		id, name, goarch, goarm, format string
+	}{
+		{"default", "foo_1.0.0_linux_amd64.deb", "amd64", "", "deb"},
+		{"default", "foo_1.0.0_linux_arm64.deb", "arm64", "", "deb"},
+		{"doc", "foo-doc_1.0.0_linux_all.deb", "all", "", "deb"},
+		{"default", "foo-1.0.0.x86_64.rpm", "amd64", "", "rpm"},
+		{"default", "foo_1.0.0_linux_amd64.apk", "amd64", "", "apk"},
+		{"default", "foo_1.0.0_linux_armv7.apk", "arm", "7", "apk"},
+		{"default", "foo_1.0.0_linux_mips.apk", "mips", "", "apk"},
+		{"default", "foo_1.0.0_linux_arm64.termux.deb", "arm64", "", "termux.deb"},
+		{"default", "foo-1.0.0-1-x86_64.pkg.tar.zst", "amd64", "", "archlinux"},
+		{"default", "foo-1.0.0-1-aarch64.pkg.tar.zst", "arm64", "", "archlinux"},
+		{"doc", "foo-doc-1.0.0-1-any.pkg.tar.zst", "all", "", "archlinux"},
+	} {
+		path := filepath.Join(tmp, pkg.name)
+		require.NoError(tb, os.WriteFile(path, []byte(pkg.name), 0o644))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:   pkg.name,
+			Path:   path,
+			Goos:   "linux",
+			Goarch: pkg.goarch,
+			Goarm:  pkg.goarm,
+			Type:   artifact.LinuxPackage,
+			Extra: map[string]any{
+				artifact.ExtraID:     pkg.id,
+				artifact.ExtraFormat: pkg.format,
+			},
+		})
+	}
+	return ctx
+}
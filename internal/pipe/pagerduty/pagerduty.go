@@ -0,0 +1,185 @@
+// Package pagerduty records releases as PagerDuty change events.
+package pagerduty
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/caarlos0/log"
+
+	"github.com/goreleaser/goreleaser/v2/internal/announcement"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/retryx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultAPI             = "https://events.pagerduty.com"
+	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} was released`
+	defaultSource          = `{{ .ProjectName }}`
+
+	// maxSummaryLength is the maximum length PagerDuty accepts in the
+	// summary of an event.
+	maxSummaryLength = 1024
+)
+
+type Pipe struct{}
+
+func (Pipe) String() string { return "pagerduty" }
+func (Pipe) Skip(ctx *context.Context) (bool, error) {
+	enable, err := tmpl.New(ctx).Bool(ctx.Config.Announce.PagerDuty.Enabled)
+	return !enable, err
+}
+
+type Config struct {
+	RoutingKey string `env:"PAGERDUTY_ROUTING_KEY,notEmpty"`
+}
+
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Announce.PagerDuty
+	if cfg.API == "" {
+		cfg.API = defaultAPI
+	}
+	if cfg.MessageTemplate == "" {
+		cfg.MessageTemplate = defaultMessageTemplate
+	}
+	if cfg.Source == "" {
+		cfg.Source = defaultSource
+	}
+	return nil
+}
+
+// Preview returns the change event that would be sent, without the routing
+// key.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	_, event, err := newChangeEvent(ctx)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.MarshalIndent(event, "", "  ")
+	return string(bts), err
+}
+
+func (Pipe) Announce(ctx *context.Context) error {
+	api, event, err := newChangeEvent(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := env.ParseAs[Config]()
+	if err != nil {
+		return err
+	}
+	event.RoutingKey = cfg.RoutingKey
+
+	u, err := url.Parse(api)
+	if err != nil {
+		return fmt.Errorf("pagerduty: invalid api: %w", err)
+	}
+	u = u.JoinPath("v2", "change", "enqueue")
+
+	bts, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("posting: '%s'", event.Payload.Summary)
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bts))
+		if err != nil {
+			return retryx.Unrecoverable(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return retryx.HTTP(err, resp)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusAccepted {
+			return retryx.HTTP(fmt.Errorf("%s", resp.Status), resp)
+		}
+
+		return nil
+	}, retryx.IsRetriable)
+}
+
+// newChangeEvent returns the templated API URL and the change event to send.
+func newChangeEvent(ctx *context.Context) (string, ChangeEvent, error) {
+	pcfg := ctx.Config.Announce.PagerDuty
+	t := tmpl.New(ctx).WithMessageTemplates()
+
+	api, msg, source := pcfg.API, pcfg.MessageTemplate, pcfg.Source
+	if err := t.ApplyAll(&api, &msg, &source); err != nil {
+		return "", ChangeEvent{}, err
+	}
+
+	details := map[string]string{
+		"project":      ctx.Config.ProjectName,
+		"version":      ctx.Version,
+		"tag":          ctx.Git.CurrentTag,
+		"previous_tag": ctx.Git.PreviousTag,
+		"commit":       ctx.Git.FullCommit,
+		"commit_range": announcement.CommitRange(ctx),
+		"release_url":  ctx.ReleaseURL,
+	}
+	for k, v := range pcfg.CustomDetails {
+		v, err := t.Apply(v)
+		if err != nil {
+			return "", ChangeEvent{}, err
+		}
+		details[k] = v
+	}
+	for k, v := range details {
+		if v == "" {
+			delete(details, k)
+		}
+	}
+
+	event := ChangeEvent{
+		Payload: Payload{
+			Summary:       announcement.Truncate(msg, maxSummaryLength),
+			Source:        source,
+			Timestamp:     ctx.Date.UTC().Format(time.RFC3339),
+			CustomDetails: details,
+		},
+	}
+	if ctx.ReleaseURL != "" {
+		event.Links = []Link{{
+			Href: ctx.ReleaseURL,
+			Text: "Release " + ctx.Git.CurrentTag,
+		}}
+	}
+	return api, event, nil
+}
+
+// ChangeEvent is a PagerDuty change event.
+type ChangeEvent struct {
+	RoutingKey string  `json:"routing_key"`
+	Payload    Payload `json:"payload"`
+	Links      []Link  `json:"links,omitempty"`
+}
+
+type Payload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source,omitempty"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type Link struct {
+	Href string `json:"href"`
+	Text string `json:"text,omitempty"`
+}
@@ -0,0 +1,185 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringer(t *testing.T) {
+	require.Equal(t, "pagerduty", Pipe{}.String())
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, defaultAPI, ctx.Config.Announce.PagerDuty.API)
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.PagerDuty.MessageTemplate)
+	require.Equal(t, defaultSource, ctx.Config.Announce.PagerDuty.Source)
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				PagerDuty: config.PagerDuty{
+					Enabled: "true",
+				},
+			},
+		})
+
+		skip, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+}
+
+func TestAnnounceInvalidTemplate(t *testing.T) {
+	for name, cfg := range map[string]config.PagerDuty{
+		"message": {MessageTemplate: "{{ .Foo }"},
+		"source":  {Source: "{{ .Foo }"},
+		"api":     {API: "{{ .Foo }"},
+		"details": {CustomDetails: map[string]string{"foo": "{{ .Foo }"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{
+					PagerDuty: cfg,
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+		})
+	}
+}
+
+func TestAnnounceMissingEnv(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "PAGERDUTY_ROUTING_KEY" should not be empty`)
+}
+
+func TestAnnounce(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/change/enqueue" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var event ChangeEvent
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, ChangeEvent{
+			RoutingKey: "routing-key",
+			Payload: Payload{
+				Summary:   "honk v1.1.0 was released",
+				Source:    "honk",
+				Timestamp: "2026-01-02T03:04:05Z",
+				CustomDetails: map[string]string{
+					"project":      "honk",
+					"version":      "1.1.0",
+					"tag":          "v1.1.0",
+					"previous_tag": "v1.0.0",
+					"commit":       "abcdef",
+					"commit_range": "v1.0.0...v1.1.0",
+					"release_url":  "https://github.com/honk/honk/releases/tag/v1.1.0",
+					"environment":  "production",
+				},
+			},
+			Links: []Link{{
+				Href: "https://github.com/honk/honk/releases/tag/v1.1.0",
+				Text: "Release v1.1.0",
+			}},
+		}, event)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			PagerDuty: config.PagerDuty{
+				API: ts.URL,
+				CustomDetails: map[string]string{
+					"environment": "{{ .Env.DEPLOY_ENV }}",
+				},
+			},
+		},
+	},
+		testctx.WithCurrentTag("v1.1.0"),
+		testctx.WithPreviousTag("v1.0.0"),
+		testctx.WithVersion("1.1.0"),
+		testctx.WithCommit("abcdef"),
+		testctx.WithEnv(map[string]string{"DEPLOY_ENV": "production"}),
+		testctx.WithDate(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	)
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.1.0"
+	t.Setenv("PAGERDUTY_ROUTING_KEY", "routing-key")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+}
+
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+	},
+		testctx.WithCurrentTag("v1.1.0"),
+		testctx.WithVersion("1.1.0"),
+		testctx.WithDate(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	)
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+
+	var event ChangeEvent
+	require.NoError(t, json.Unmarshal([]byte(out), &event))
+	require.Equal(t, ChangeEvent{
+		Payload: Payload{
+			Summary:   "honk v1.1.0 was released",
+			Source:    "honk",
+			Timestamp: "2026-01-02T03:04:05Z",
+			CustomDetails: map[string]string{
+				"project": "honk",
+				"version": "1.1.0",
+				"tag":     "v1.1.0",
+			},
+		},
+	}, event)
+}
+
+func TestAnnounceError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			PagerDuty: config.PagerDuty{
+				API: ts.URL,
+			},
+		},
+	})
+	t.Setenv("PAGERDUTY_ROUTING_KEY", "routing-key")
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorContains(t, Pipe{}.Announce(ctx), "400 Bad Request")
+}
@@ -29,6 +29,8 @@ func (Pipe) Default(ctx *context.Context) error {
 		ctx.Config.Release.GitHub.Name,
 		ctx.Config.Release.GitLab.Name,
 		ctx.Config.Release.Gitea.Name,
+		ctx.Config.Release.Forgejo.Name,
+		ctx.Config.Release.Bitbucket.Name,
 		moduleName(ctx),
 		gitRemote(ctx),
 	} {
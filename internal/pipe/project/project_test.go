@@ -73,6 +73,36 @@ func TestEmptyProjectName_DefaultsToGiteaRelease(t *testing.T) {
 	require.Equal(t, "bar", ctx.Config.ProjectName)
 }
 
+func TestEmptyProjectName_DefaultsToForgejoRelease(t *testing.T) {
+	_ = testlib.Mktmp(t)
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Release: config.Release{
+			Forgejo: config.Repo{
+				Owner: "bar",
+				Name:  "bar",
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "bar", ctx.Config.ProjectName)
+}
+
+func TestEmptyProjectName_DefaultsToBitbucketRelease(t *testing.T) {
+	_ = testlib.Mktmp(t)
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Release: config.Release{
+			Bitbucket: config.Repo{
+				Owner: "bar",
+				Name:  "bar",
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "bar", ctx.Config.ProjectName)
+}
+
 func TestEmptyProjectName_DefaultsToGoModPath(t *testing.T) {
 	_ = testlib.Mktmp(t)
 	ctx := testctx.Wrap(t.Context())
@@ -0,0 +1,235 @@
+// Package provenance generates signed SLSA provenance for the release
+// artifacts.
+package provenance
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/slsa"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	// buildType is the SLSA build type of the provenance predicates we
+	// generate.
+	buildType = "https://goreleaser.com/customization/sign/provenance/"
+
+	defaultNameTemplate = "{{ .ProjectName }}_{{ .Version }}.intoto.jsonl"
+)
+
+// cmd represents a command executor.
+var cmd execx.Cmder = execx.Std{}
+
+// Pipe for SLSA provenance.
+type Pipe struct{}
+
+func (Pipe) String() string { return "slsa provenance" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.Sign, skips.Provenance) || len(ctx.Config.Provenances) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(*context.Context) []string { return []string{"cosign"} }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("provenances")
+	for i := range ctx.Config.Provenances {
+		p := &ctx.Config.Provenances[i]
+		if p.ID == "" {
+			p.ID = "default"
+		}
+		if p.Artifacts == "" {
+			p.Artifacts = "all"
+		}
+		if p.NameTemplate == "" {
+			p.NameTemplate = defaultNameTemplate
+		}
+		ids.Inc(p.ID)
+	}
+	return ids.Validate()
+}
+
+// Run generates and signs the provenance statements.
+func (Pipe) Run(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, p := range ctx.Config.Provenances {
+		err := doRun(ctx, p)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doRun(ctx *context.Context, p config.Provenance) error {
+	tpl := tmpl.New(ctx)
+	disable, err := tpl.Bool(p.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("configuration is disabled")
+	}
+	if err := tpl.ApplyAll(&p.NameTemplate, &p.Key); err != nil {
+		return err
+	}
+	flags, err := tpl.Slice(p.Flags, tmpl.NonEmpty())
+	if err != nil {
+		return err
+	}
+
+	filter, err := filterFor(p)
+	if err != nil {
+		return err
+	}
+	artifacts := ctx.Artifacts.Filter(filter).List()
+	if len(artifacts) == 0 {
+		return pipe.Skipf("no artifacts matching the provenance %q filters found", p.ID)
+	}
+
+	tmp, err := os.MkdirTemp("", "goreleaser-provenance-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	predicate, err := newPredicate(ctx, p)
+	if err != nil {
+		return err
+	}
+	bts, err := json.MarshalIndent(predicate, "", "  ")
+	if err != nil {
+		return err
+	}
+	predicatePath := filepath.Join(tmp, "predicate.json")
+	if err := os.WriteFile(predicatePath, bts, 0o644); err != nil {
+		return err
+	}
+
+	// each line is a signed statement, with one of the artifacts as its
+	// subject.
+	var lines bytes.Buffer
+	for i, art := range artifacts {
+		log.WithField("artifact", art.Name).Info("attesting provenance")
+		output := filepath.Join(tmp, strconv.Itoa(i)+".intoto.json")
+		args := []string{
+			"attest-blob", "--yes",
+			"--type", "slsaprovenance1",
+			"--predicate", predicatePath,
+			"--output-attestation", output,
+		}
+		if p.Key != "" {
+			args = append(args, "--key", p.Key)
+		}
+		args = append(args, flags...)
+		args = append(args, art.Path)
+		if out, err := cmd.Exec(ctx, "cosign", args...); err != nil {
+			return fmt.Errorf("provenance: failed to attest %s: %w: %s", art.Name, err, string(out))
+		}
+		bts, err := os.ReadFile(output)
+		if err != nil {
+			return fmt.Errorf("provenance: failed to read attestation of %s: %w", art.Name, err)
+		}
+		lines.Write(bytes.TrimSpace(bts))
+		lines.WriteByte('\n')
+	}
+
+	path := filepath.Join(ctx.Config.Dist, p.NameTemplate)
+	if err := os.WriteFile(path, lines.Bytes(), 0o644); err != nil {
+		return err
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Provenance,
+		Name: p.NameTemplate,
+		Path: path,
+		Extra: map[string]any{
+			artifact.ExtraID: p.ID,
+		},
+	})
+	return nil
+}
+
+func filterFor(p config.Provenance) (artifact.Filter, error) {
+	var filter artifact.Filter
+	switch p.Artifacts {
+	case "all":
+		filter = artifact.And(
+			artifact.ByTypes(artifact.ReleaseUploadableTypes()...),
+			artifact.Not(artifact.ByTypes(
+				artifact.Signature,
+				artifact.Certificate,
+				artifact.Provenance,
+			)),
+		)
+	case "checksum":
+		return artifact.ByType(artifact.Checksum), nil
+	case "source":
+		return artifact.ByType(artifact.UploadableSourceArchive), nil
+	case "archive":
+		filter = artifact.ByType(artifact.UploadableArchive)
+	case "binary":
+		filter = artifact.ByType(artifact.UploadableBinary)
+	case "sbom":
+		filter = artifact.ByType(artifact.SBOM)
+	case "package":
+		filter = artifact.ByType(artifact.LinuxPackage)
+	case "installer":
+		filter = artifact.ByType(artifact.MSI)
+	default:
+		return nil, fmt.Errorf("provenance: invalid list of artifacts: %s", p.Artifacts)
+	}
+	return artifact.And(filter, artifact.ByIDs(p.IDs...)), nil
+}
+
+// newPredicate creates the SLSA predicate for the given configuration.
+//
+// Besides the source repository, the effective configuration is added as a
+// resolved dependency, so its digest is part of the provenance.
+func newPredicate(ctx *context.Context, p config.Provenance) (slsa.Predicate, error) {
+	predicate := slsa.New(ctx, buildType, map[string]any{
+		"id":        p.ID,
+		"artifacts": p.Artifacts,
+		"ids":       p.IDs,
+		"version":   ctx.Version,
+		"snapshot":  ctx.Snapshot,
+	})
+
+	bts, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "config.yaml"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return predicate, nil
+	}
+	if err != nil {
+		return predicate, err
+	}
+	sum := sha256.Sum256(bts)
+	predicate.BuildDefinition.ResolvedDependencies = append(
+		predicate.BuildDefinition.ResolvedDependencies,
+		slsa.ResourceDescriptor{
+			Name:   "config.yaml",
+			Digest: map[string]string{"sha256": hex.EncodeToString(sum[:])},
+		},
+	)
+	return predicate, nil
+}
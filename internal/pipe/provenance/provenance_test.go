@@ -0,0 +1,258 @@
+package provenance
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/slsa"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestDependencies(t *testing.T) {
+	require.Equal(t, []string{"cosign"}, Pipe{}.Dependencies(nil))
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	for _, key := range []skips.Key{skips.Sign, skips.Provenance} {
+		t.Run("skip "+string(key), func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Provenances: []config.Provenance{{}},
+			}, testctx.Skip(key))
+			require.True(t, Pipe{}.Skip(ctx))
+		})
+	}
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Provenances: []config.Provenance{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Provenances: []config.Provenance{{}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, config.Provenance{
+			ID:           "default",
+			Artifacts:    "all",
+			NameTemplate: defaultNameTemplate,
+		}, ctx.Config.Provenances[0])
+	})
+	t.Run("duplicated ids", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Provenances: []config.Provenance{{}, {}},
+		})
+		require.ErrorContains(t, Pipe{}.Default(ctx), "found 2 provenances with the ID 'default'")
+	})
+}
+
+func TestRun(t *testing.T) {
+	calls := fakeCosign(t, nil)
+	ctx := makeContext(t, config.Provenance{
+		Key:   "{{ .Env.COSIGN_KEY }}",
+		Flags: []string{"--tlog-upload=false"},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	require.Len(t, *calls, 3)
+	for i, name := range []string{"foo.tar.gz", "foo", "checksums.txt"} {
+		call := (*calls)[i]
+		require.Equal(t, "cosign", call[0])
+		require.Equal(t, []string{"attest-blob", "--yes", "--type", "slsaprovenance1"}, call[1:5])
+		require.Equal(t, []string{"--key", "cosign.key", "--tlog-upload=false"}, call[9:12])
+		require.Equal(t, filepath.Join(ctx.Config.Dist, name), call[len(call)-1])
+	}
+
+	provs := ctx.Artifacts.Filter(artifact.ByType(artifact.Provenance)).List()
+	require.Len(t, provs, 1)
+	require.Equal(t, "foo_1.2.3.intoto.jsonl", provs[0].Name)
+	require.Equal(t, "default", provs[0].ID())
+
+	bts, err := os.ReadFile(provs[0].Path)
+	require.NoError(t, err)
+	require.Equal(t, `{"subject":"foo.tar.gz"}
+{"subject":"foo"}
+{"subject":"checksums.txt"}
+`, string(bts))
+}
+
+func TestRunFilters(t *testing.T) {
+	for artifacts, expected := range map[string][]string{
+		"archive":  {"foo.tar.gz"},
+		"binary":   {"foo"},
+		"checksum": {"checksums.txt"},
+	} {
+		t.Run(artifacts, func(t *testing.T) {
+			calls := fakeCosign(t, nil)
+			ctx := makeContext(t, config.Provenance{Artifacts: artifacts})
+			require.NoError(t, Pipe{}.Run(ctx))
+			var got []string
+			for _, call := range *calls {
+				got = append(got, filepath.Base(call[len(call)-1]))
+			}
+			require.Equal(t, expected, got)
+		})
+	}
+
+	t.Run("ids", func(t *testing.T) {
+		calls := fakeCosign(t, nil)
+		ctx := makeContext(t, config.Provenance{
+			Artifacts: "archive",
+			IDs:       []string{"bar"},
+		})
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+		require.Empty(t, *calls)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		fakeCosign(t, nil)
+		ctx := makeContext(t, config.Provenance{Artifacts: "nope"})
+		require.ErrorContains(t, Pipe{}.Run(ctx), "invalid list of artifacts: nope")
+	})
+}
+
+func TestRunErrors(t *testing.T) {
+	for name, p := range map[string]config.Provenance{
+		"disable":       {Disable: "{{ .Nope }}"},
+		"name template": {NameTemplate: "{{ .Nope }}"},
+		"key":           {Key: "{{ .Nope }}"},
+		"flags":         {Flags: []string{"{{ .Nope }}"}},
+	} {
+		t.Run("bad "+name+" template", func(t *testing.T) {
+			fakeCosign(t, nil)
+			ctx := makeContext(t, p)
+			testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		calls := fakeCosign(t, nil)
+		ctx := makeContext(t, config.Provenance{Disable: "true"})
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+		require.Empty(t, *calls)
+	})
+
+	t.Run("cosign fails", func(t *testing.T) {
+		fakeCosign(t, func([]string) ([]byte, error) {
+			return []byte("no key"), errors.New("exit status 1")
+		})
+		ctx := makeContext(t, config.Provenance{})
+		err := Pipe{}.Run(ctx)
+		require.ErrorContains(t, err, "failed to attest foo.tar.gz")
+		require.ErrorContains(t, err, "no key")
+	})
+
+	t.Run("no attestation", func(t *testing.T) {
+		fakeCosign(t, func([]string) ([]byte, error) {
+			return nil, nil
+		})
+		ctx := makeContext(t, config.Provenance{})
+		require.ErrorContains(t, Pipe{}.Run(ctx), "failed to read attestation of foo.tar.gz")
+	})
+}
+
+func TestPredicate(t *testing.T) {
+	var predicate slsa.Predicate
+	fakeCosign(t, func(args []string) ([]byte, error) {
+		bts, err := os.ReadFile(args[slices.Index(args, "--predicate")+1])
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bts, &predicate))
+		output := args[slices.Index(args, "--output-attestation")+1]
+		return nil, os.WriteFile(output, []byte("{}"), 0o644)
+	})
+	ctx := makeContext(t, config.Provenance{Artifacts: "binary"})
+	require.NoError(t, os.WriteFile(filepath.Join(ctx.Config.Dist, "config.yaml"), []byte("project_name: foo\n"), 0o644))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	require.Equal(t, buildType, predicate.BuildDefinition.BuildType)
+	require.Equal(t, map[string]any{
+		"id":        "default",
+		"artifacts": "binary",
+		"ids":       nil,
+		"version":   "1.2.3",
+		"snapshot":  false,
+	}, predicate.BuildDefinition.ExternalParameters)
+	require.Equal(t, []slsa.ResourceDescriptor{
+		{
+			URI:    "git+https://github.com/foo/bar@refs/tags/v1.2.3",
+			Digest: map[string]string{"gitCommit": "deadbeef"},
+		},
+		{
+			Name: "config.yaml",
+			// sha256 of 'project_name: foo\n'.
+			Digest: map[string]string{"sha256": "f15fa631668fd0bfbec577e56f9ca8657a0a2d4d005f5bafc2b3073a74ca8ccc"},
+		},
+	}, predicate.BuildDefinition.ResolvedDependencies)
+	require.Equal(t, "https://goreleaser.com", predicate.RunDetails.Builder.ID)
+}
+
+func makeContext(tb testing.TB, p config.Provenance) *context.Context {
+	tb.Helper()
+	dist := tb.TempDir()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "foo",
+		Dist:        dist,
+		Provenances: []config.Provenance{p},
+	},
+		testctx.WithVersion("1.2.3"),
+		testctx.WithGitInfo(context.GitInfo{
+			URL:        "https://github.com/foo/bar",
+			FullCommit: "deadbeef",
+		}),
+		testctx.WithCurrentTag("v1.2.3"),
+		testctx.WithEnv(map[string]string{"COSIGN_KEY": "cosign.key"}),
+	)
+	for _, a := range []*artifact.Artifact{
+		{Type: artifact.UploadableArchive, Name: "foo.tar.gz"},
+		{Type: artifact.UploadableBinary, Name: "foo"},
+		{Type: artifact.Checksum, Name: "checksums.txt"},
+		{Type: artifact.Signature, Name: "checksums.txt.sig"},
+		{Type: artifact.Binary, Name: "foo-internal"},
+	} {
+		a.Path = filepath.Join(dist, a.Name)
+		a.Extra = map[string]any{artifact.ExtraID: "foo"}
+		require.NoError(tb, os.WriteFile(a.Path, []byte(a.Name), 0o644))
+		ctx.Artifacts.Add(a)
+	}
+	require.NoError(tb, Pipe{}.Default(ctx))
+	return ctx
+}
+
+// fakeCosign replaces the command executor, recording the calls made to it,
+// with the command name as the first item.
+//
+// By default, it writes an attestation with the name of the subject.
+func fakeCosign(tb testing.TB, fn func(args []string) ([]byte, error)) *[][]string {
+	tb.Helper()
+	if fn == nil {
+		fn = func(call []string) ([]byte, error) {
+			output := call[slices.Index(call, "--output-attestation")+1]
+			subject := filepath.Base(call[len(call)-1])
+			return nil, os.WriteFile(output, []byte(`{"subject":"`+subject+`"}`+"\n"), 0o644)
+		}
+	}
+	fake := &testlib.FakeCosign{Fn: fn}
+	testlib.Set[execx.Cmder](tb, &cmd, fake)
+	return &fake.Calls
+}
@@ -8,21 +8,31 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/logging"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/artifactory"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/asdf"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/attestation"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/aur"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/aursources"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/blob"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/brew"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/buildpacks"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/cask"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/chocolatey"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/custompublishers"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/docker"
 	dockerv2 "github.com/goreleaser/goreleaser/v2/internal/pipe/docker/v2"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/dockercleanup"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/dockerdigest"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/flatpak"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/helm"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/ko"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/krew"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/mcp"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/milestone"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nix"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/npm"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/ociartifact"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/packagerepo"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/pypi"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/release"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/scoop"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sign"
@@ -48,15 +58,22 @@ func New() Pipe {
 			blob.Pipe{},
 			upload.Pipe{},
 			artifactory.Pipe{},
+			packagerepo.Pipe{},
+			npm.Pipe{},
+			pypi.Pipe{},
 			docker.Pipe{},
 			docker.ManifestPipe{},
 			dockerv2.Publish{},
 			dockerdigest.Pipe{},
 			ko.Pipe{},
+			buildpacks.Pipe{},
+			ociartifact.Pipe{},
+			helm.Pipe{},
 			sign.DockerPipe{},
 			snapcraft.Pipe{},
 			// This should be one of the last steps
 			release.Pipe{},
+			attestation.Pipe{},
 			// brew et al use the release URL, so, they should be last
 			nix.New(),
 			winget.Pipe{},
@@ -65,11 +82,15 @@ func New() Pipe {
 			aur.Pipe{},
 			aursources.Pipe{},
 			krew.Pipe{},
+			asdf.Pipe{},
+			flatpak.Pipe{},
 			scoop.Pipe{},
 			chocolatey.Pipe{},
 			mcp.New(),
 			milestone.Pipe{},
 			custompublishers.Pipe{},
+			// prune old tags only after everything else was published
+			dockercleanup.Pipe{},
 		},
 	}
 }
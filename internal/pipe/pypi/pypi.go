@@ -0,0 +1,451 @@
+// Package pypi creates Python wheels wrapping the built binaries, and
+// publishes them to PyPI.
+package pypi
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/archive/zip"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const pypiConfigExtra = "PyPIConfig"
+
+var (
+	errNoName         = errors.New("pypi: name is required")
+	errSkipUpload     = pipe.Skip("pypi.skip_upload is set")
+	errSkipUploadAuto = pipe.Skip("pypi.skip_upload is set to 'auto', and current version is a pre-release")
+
+	// nonAlphanumeric matches the characters that are normalized away in
+	// distribution names and python identifiers.
+	nonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+// platformTags are the wheel platform tags for each GOOS and GOARCH.
+//
+// Go binaries are statically linked by default, so the linux wheels are
+// tagged as compatible with both glibc and musl based distributions.
+var platformTags = map[string]map[string][]string{
+	"linux": {
+		"amd64": linuxTags("x86_64"),
+		"arm64": linuxTags("aarch64"),
+		"386":   linuxTags("i686"),
+		"arm":   linuxTags("armv7l"),
+	},
+	"darwin": {
+		"amd64": {"macosx_11_0_x86_64"},
+		"arm64": {"macosx_11_0_arm64"},
+		"all":   {"macosx_11_0_universal2"},
+	},
+	"windows": {
+		"amd64": {"win_amd64"},
+		"arm64": {"win_arm64"},
+		"386":   {"win32"},
+	},
+}
+
+func linuxTags(arch string) []string {
+	return []string{
+		"manylinux_2_17_" + arch,
+		"manylinux2014_" + arch,
+		"musllinux_1_1_" + arch,
+	}
+}
+
+// cmd represents a command executor.
+var cmd execx.Cmder = execx.Std{}
+
+// Pipe for PyPI wheels.
+type Pipe struct{}
+
+func (Pipe) String() string        { return "pypi wheels" }
+func (Pipe) ContinueOnError() bool { return true }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.PyPI) || len(ctx.Config.PyPIs) == 0
+}
+
+// Dependencies implements healthcheck.DependencyChecker.
+func (Pipe) Dependencies(*context.Context) []string { return []string{"uv"} }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("pypis")
+	for i := range ctx.Config.PyPIs {
+		pypi := &ctx.Config.PyPIs[i]
+		if pypi.Name == "" {
+			return errNoName
+		}
+		if pypi.ID == "" {
+			pypi.ID = ctx.Config.ProjectName
+		}
+		if pypi.Version == "" {
+			pypi.Version = "{{ .Version }}"
+		}
+		if pypi.Goamd64 == "" {
+			pypi.Goamd64 = "v1"
+		}
+		if pypi.Goarm == "" {
+			pypi.Goarm = "7"
+		}
+		ids.Inc(pypi.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, pypi := range ctx.Config.PyPIs {
+		err := doRun(ctx, pypi)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doRun(ctx *context.Context, pypi config.PyPI) error {
+	disable, err := tmpl.New(ctx).Bool(pypi.Disable)
+	if err != nil {
+		return err
+	}
+	if disable {
+		return pipe.Skip("disabled")
+	}
+
+	if err := tmpl.New(ctx).ApplyAll(
+		&pypi.Version,
+		&pypi.Description,
+		&pypi.License,
+		&pypi.Author,
+		&pypi.Repository,
+		&pypi.PublishURL,
+		&pypi.SkipUpload,
+	); err != nil {
+		return err
+	}
+
+	binaries := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByTypes(artifact.Binary, artifact.UniversalBinary),
+		artifact.ByGooses(slices.Sorted(maps.Keys(platformTags))...),
+		artifact.ByIDs(pypi.IDs...),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("amd64")),
+			artifact.ByGoamd64(pypi.Goamd64),
+		),
+		artifact.Or(
+			artifact.Not(artifact.ByGoarch("arm")),
+			artifact.ByGoarm(pypi.Goarm),
+		),
+		artifact.OnlyReplacingUnibins,
+		func(a *artifact.Artifact) bool {
+			_, ok := platformTags[a.Goos][a.Goarch]
+			return ok
+		},
+	)).List()
+	if len(binaries) == 0 {
+		return pipe.Skipf("no binaries found for builds %v", pypi.IDs)
+	}
+
+	platforms := map[string][]*artifact.Artifact{}
+	for _, bin := range binaries {
+		key := bin.Goos + "_" + bin.Goarch
+		platforms[key] = append(platforms[key], bin)
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "pypi", pypi.ID)
+	for _, key := range slices.Sorted(maps.Keys(platforms)) {
+		if err := createWheel(ctx, pypi, dir, platforms[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createWheel creates the wheel of the given binaries, which must be all of
+// the same platform.
+func createWheel(ctx *context.Context, pypi config.PyPI, dir string, binaries []*artifact.Artifact) error {
+	first := binaries[0]
+	tags := platformTags[first.Goos][first.Goarch]
+	distName := normalize(pypi.Name)
+	module := distName
+	version := strings.ReplaceAll(pypi.Version, "-", "_")
+	distInfo := distName + "-" + version + ".dist-info"
+	filename := fmt.Sprintf("%s-%s-py3-none-%s.whl", distName, version, strings.Join(tags, "."))
+
+	data := templateData{Module: module}
+	files := map[string][]byte{}
+	sources := map[string]string{}
+	for _, bin := range binaries {
+		binary := filepath.Base(bin.Name)
+		name := artifact.ExtraOr(*bin, artifact.ExtraBinary, strings.TrimSuffix(binary, filepath.Ext(binary)))
+		dst := module + "/bin/" + binary
+		if _, ok := sources[dst]; ok {
+			return fmt.Errorf("pypi: multiple binaries named %s for %s/%s", binary, first.Goos, first.Goarch)
+		}
+		sources[dst] = bin.Path
+		data.Commands = append(data.Commands, command{
+			Name:   name,
+			Func:   "run_" + normalize(name),
+			Binary: binary,
+		})
+	}
+	slices.SortFunc(data.Commands, func(a, b command) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	for path, tpl := range map[string]string{
+		module + "/__init__.py":        initTemplate,
+		module + "/__main__.py":        mainTemplate,
+		distInfo + "/entry_points.txt": entryPointsTemplate,
+	} {
+		content, err := applyTemplate(tpl, data)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+	}
+	files[distInfo+"/METADATA"] = buildMetadata(pypi)
+	files[distInfo+"/WHEEL"] = buildWheel(tags)
+
+	record, err := buildRecord(distInfo+"/RECORD", files, sources)
+	if err != nil {
+		return err
+	}
+	files[distInfo+"/RECORD"] = record
+
+	staging := filepath.Join(dir, strings.TrimSuffix(filename, ".whl"))
+	for path, content := range files {
+		dst := filepath.Join(staging, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, content, 0o644); err != nil { //nolint:gosec
+			return fmt.Errorf("pypi: failed to write %s: %w", path, err)
+		}
+		sources[path] = dst
+	}
+
+	path := filepath.Join(dir, filename)
+	log.WithField("wheel", path).Info("creating")
+	if err := writeWheel(ctx, path, sources, distInfo+"/RECORD"); err != nil {
+		return fmt.Errorf("pypi: failed to create wheel: %w", err)
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.PyWheel,
+		Name:    filename,
+		Path:    path,
+		Goos:    first.Goos,
+		Goarch:  first.Goarch,
+		Goamd64: first.Goamd64,
+		Goarm:   first.Goarm,
+		Extra: map[string]any{
+			artifact.ExtraID:  pypi.ID,
+			artifact.ExtraExt: ".whl",
+			pypiConfigExtra:   pypi,
+		},
+	})
+	return nil
+}
+
+// writeWheel zips the given files into path, with the RECORD file last, as
+// recommended by the wheel specification.
+func writeWheel(ctx *context.Context, path string, sources map[string]string, record string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	names := slices.Sorted(maps.Keys(sources))
+	names = slices.DeleteFunc(names, func(name string) bool { return name == record })
+	names = append(names, record)
+
+	a := zip.New(f)
+	for _, name := range names {
+		mode := os.FileMode(0o644)
+		if strings.Contains(name, "/bin/") {
+			mode = 0o755
+		}
+		if err := a.Add(config.File{
+			Source:      sources[name],
+			Destination: name,
+			Info: config.FileInfo{
+				Mode:        mode,
+				ParsedMTime: ctx.Git.CommitDate,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	if err := a.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func applyTemplate(tpl string, data templateData) ([]byte, error) {
+	t, err := template.New(data.Module).Parse(tpl)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func buildMetadata(pypi config.PyPI) []byte {
+	var sb strings.Builder
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "%s: %s\n", name, value)
+		}
+	}
+	field("Metadata-Version", "2.1")
+	field("Name", pypi.Name)
+	field("Version", pypi.Version)
+	field("Summary", pypi.Description)
+	field("Home-page", pypi.Homepage)
+	field("Author", pypi.Author)
+	field("License", pypi.License)
+	field("Keywords", strings.Join(pypi.Keywords, ","))
+	if pypi.Repository != "" {
+		field("Project-URL", "Repository, "+pypi.Repository)
+	}
+	return []byte(sb.String())
+}
+
+func buildWheel(tags []string) []byte {
+	var sb strings.Builder
+	sb.WriteString("Wheel-Version: 1.0\n")
+	sb.WriteString("Generator: goreleaser\n")
+	sb.WriteString("Root-Is-Purelib: false\n")
+	for _, tag := range tags {
+		sb.WriteString("Tag: py3-none-" + tag + "\n")
+	}
+	return []byte(sb.String())
+}
+
+// buildRecord creates the RECORD file, with the hashes and sizes of all the
+// files in the wheel.
+func buildRecord(record string, files map[string][]byte, sources map[string]string) ([]byte, error) {
+	lines := make([]string, 0, len(files)+len(sources)+1)
+	for name, content := range files {
+		sum := sha256.Sum256(content)
+		lines = append(lines, recordLine(name, sum[:], int64(len(content))))
+	}
+	for name, path := range sources {
+		sum, size, err := hashFile(path)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, recordLine(name, sum, size))
+	}
+	slices.Sort(lines)
+	lines = append(lines, record+",,")
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+func recordLine(name string, sum []byte, size int64) string {
+	return fmt.Sprintf("%s,sha256=%s,%d", name, base64.RawURLEncoding.EncodeToString(sum), size)
+}
+
+func hashFile(path string) ([]byte, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.Sum(nil), size, nil
+}
+
+// normalize normalizes the given name the way distribution names are
+// normalized in wheel file names, which also makes it a valid python
+// identifier.
+func normalize(name string) string {
+	return strings.ToLower(nonAlphanumeric.ReplaceAllString(name, "_"))
+}
+
+// Publish the wheels.
+func (Pipe) Publish(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, pypi := range ctx.Config.PyPIs {
+		wheels := ctx.Artifacts.Filter(artifact.And(
+			artifact.ByType(artifact.PyWheel),
+			artifact.ByID(pypi.ID),
+			func(a *artifact.Artifact) bool {
+				_, ok := a.Extra[pypiConfigExtra]
+				return ok
+			},
+		)).List()
+		if len(wheels) == 0 {
+			continue
+		}
+		err := doPublish(ctx, wheels)
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doPublish(ctx *context.Context, wheels []*artifact.Artifact) error {
+	pypi := artifact.MustExtra[config.PyPI](*wheels[0], pypiConfigExtra)
+	if strings.TrimSpace(pypi.SkipUpload) == "true" {
+		return errSkipUpload
+	}
+	if strings.TrimSpace(pypi.SkipUpload) == "auto" && ctx.Semver.Prerelease != "" {
+		return errSkipUploadAuto
+	}
+
+	args := []string{"publish"}
+	if pypi.PublishURL != "" {
+		args = append(args, "--publish-url", pypi.PublishURL)
+	}
+	for _, wheel := range wheels {
+		args = append(args, filepath.Clean(wheel.Path))
+	}
+
+	log.WithField("package", pypi.Name).Info("publishing")
+	if out, err := cmd.Exec(ctx, "uv", args...); err != nil {
+		return fmt.Errorf("pypi: failed to publish %s: %w: %s", pypi.Name, err, string(out))
+	}
+	return nil
+}
@@ -0,0 +1,391 @@
+package pypi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/execx"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestContinueOnError(t *testing.T) {
+	require.True(t, Pipe{}.ContinueOnError())
+}
+
+func TestDependencies(t *testing.T) {
+	require.Equal(t, []string{"uv"}, Pipe{}.Dependencies(nil))
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.Wrap(t.Context())))
+	})
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			PyPIs: []config.PyPI{{}},
+		}, testctx.Skip(skips.PyPI))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			PyPIs: []config.PyPI{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			PyPIs:       []config.PyPI{{Name: "foo"}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		pypi := ctx.Config.PyPIs[0]
+		require.Equal(t, "foo", pypi.ID)
+		require.Equal(t, "{{ .Version }}", pypi.Version)
+		require.Equal(t, "v1", pypi.Goamd64)
+		require.Equal(t, "7", pypi.Goarm)
+	})
+	t.Run("no name", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			PyPIs:       []config.PyPI{{}},
+		})
+		require.ErrorIs(t, Pipe{}.Default(ctx), errNoName)
+	})
+	t.Run("duplicated ids", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			PyPIs:       []config.PyPI{{Name: "foo"}, {Name: "bar"}},
+		})
+		require.ErrorContains(t, Pipe{}.Default(ctx), "found 2 pypis with the ID 'foo'")
+	})
+}
+
+func TestRun(t *testing.T) {
+	ctx := makeContext(t, config.PyPI{
+		Name:        "My-Foo",
+		Description: "foo is {{ .ProjectName }}",
+		License:     "MIT",
+		Repository:  "https://github.com/goreleaser/foo",
+		Keywords:    []string{"cli", "go"},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	wheels := ctx.Artifacts.Filter(artifact.ByType(artifact.PyWheel)).List()
+	names := make([]string, 0, len(wheels))
+	for _, wheel := range wheels {
+		names = append(names, wheel.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"my_foo-1.2.3-py3-none-macosx_11_0_universal2.whl",
+		"my_foo-1.2.3-py3-none-manylinux_2_17_armv7l.manylinux2014_armv7l.musllinux_1_1_armv7l.whl",
+		"my_foo-1.2.3-py3-none-manylinux_2_17_x86_64.manylinux2014_x86_64.musllinux_1_1_x86_64.whl",
+		"my_foo-1.2.3-py3-none-win_amd64.whl",
+	}, names)
+
+	t.Run("linux", func(t *testing.T) {
+		path := filepath.Join(ctx.Config.Dist, "pypi", "foo", "my_foo-1.2.3-py3-none-manylinux_2_17_x86_64.manylinux2014_x86_64.musllinux_1_1_x86_64.whl")
+		require.Equal(t, []string{
+			"my_foo-1.2.3.dist-info/METADATA",
+			"my_foo-1.2.3.dist-info/WHEEL",
+			"my_foo-1.2.3.dist-info/entry_points.txt",
+			"my_foo/__init__.py",
+			"my_foo/__main__.py",
+			"my_foo/bin/foo",
+			"my_foo/bin/foo-cli",
+			"my_foo-1.2.3.dist-info/RECORD",
+		}, testlib.LsArchive(t, path, "zip"))
+
+		require.Equal(t, strings.Join([]string{
+			"Metadata-Version: 2.1",
+			"Name: My-Foo",
+			"Version: 1.2.3",
+			"Summary: foo is foo",
+			"License: MIT",
+			"Keywords: cli,go",
+			"Project-URL: Repository, https://github.com/goreleaser/foo",
+			"",
+		}, "\n"), string(testlib.GetFileFromArchive(t, path, "zip", "my_foo-1.2.3.dist-info/METADATA")))
+
+		require.Equal(t, strings.Join([]string{
+			"Wheel-Version: 1.0",
+			"Generator: goreleaser",
+			"Root-Is-Purelib: false",
+			"Tag: py3-none-manylinux_2_17_x86_64",
+			"Tag: py3-none-manylinux2014_x86_64",
+			"Tag: py3-none-musllinux_1_1_x86_64",
+			"",
+		}, "\n"), string(testlib.GetFileFromArchive(t, path, "zip", "my_foo-1.2.3.dist-info/WHEEL")))
+
+		record := string(testlib.GetFileFromArchive(t, path, "zip", "my_foo-1.2.3.dist-info/RECORD"))
+		require.Contains(t, record, "my_foo/bin/foo,sha256=-EytZSkGMcFe6NiFKkAtKIOEgKvm2jMT6z1kpgJAqvY,8\n")
+		require.True(t, strings.HasSuffix(record, "my_foo-1.2.3.dist-info/RECORD,,\n"))
+
+		for _, name := range []string{
+			"my_foo/__init__.py",
+			"my_foo/__main__.py",
+			"my_foo-1.2.3.dist-info/entry_points.txt",
+		} {
+			golden.RequireEqualExt(t, testlib.GetFileFromArchive(t, path, "zip", name), "."+filepath.Base(name))
+		}
+	})
+
+	t.Run("windows", func(t *testing.T) {
+		path := filepath.Join(ctx.Config.Dist, "pypi", "foo", "my_foo-1.2.3-py3-none-win_amd64.whl")
+		require.Contains(t, testlib.LsArchive(t, path, "zip"), "my_foo/bin/foo.exe")
+		entrypoints := testlib.GetFileFromArchive(t, path, "zip", "my_foo-1.2.3.dist-info/entry_points.txt")
+		require.Equal(t, "[console_scripts]\nfoo = my_foo:run_foo\n", string(entrypoints))
+	})
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctx := makeContext(t, config.PyPI{
+		Name:    "foo",
+		Disable: "{{ .IsSnapshot }}",
+	})
+	ctx.Snapshot = true
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.PyWheel)).List())
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Run("no binaries", func(t *testing.T) {
+		ctx := makeContext(t, config.PyPI{
+			Name: "foo",
+			IDs:  []string{"nope"},
+		})
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+	})
+	t.Run("bad disable template", func(t *testing.T) {
+		ctx := makeContext(t, config.PyPI{
+			Name:    "foo",
+			Disable: "{{ .Nope }}",
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+	t.Run("bad version template", func(t *testing.T) {
+		ctx := makeContext(t, config.PyPI{
+			Name:    "foo",
+			Version: "{{ .Nope }}",
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
+
+func TestPublish(t *testing.T) {
+	var calls [][]string
+	cmd = fakeCmd{execFn: func(name string, args ...string) ([]byte, error) {
+		require.Equal(t, "uv", name)
+		calls = append(calls, args)
+		return []byte("ok"), nil
+	}}
+	t.Cleanup(func() {
+		cmd = execx.Std{}
+	})
+
+	ctx := makeContext(t, config.PyPI{
+		Name:       "foo",
+		PublishURL: "https://test.pypi.org/legacy/",
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo-1.2.3-py3-none-any.whl",
+		Path: "foo-1.2.3-py3-none-any.whl",
+		Type: artifact.PyWheel,
+		Extra: map[string]any{
+			artifact.ExtraID: "foo",
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	require.Len(t, calls, 1)
+	require.Equal(t, []string{"publish", "--publish-url", "https://test.pypi.org/legacy/"}, calls[0][:3])
+	require.Len(t, calls[0][3:], 4)
+	for _, arg := range calls[0][3:] {
+		require.Equal(t, ".whl", filepath.Ext(arg))
+		require.NotEqual(t, "foo-1.2.3-py3-none-any.whl", arg)
+	}
+}
+
+func TestPublishEnv(t *testing.T) {
+	token := filepath.Join(t.TempDir(), "token")
+	testlib.FakeCommands(t, map[string]string{
+		"uv": `printf '%s' "$UV_PUBLISH_TOKEN" > "` + token + `"`,
+	})
+
+	ctx := makeContext(t, config.PyPI{Name: "foo"})
+	ctx.Env["UV_PUBLISH_TOKEN"] = "pypi-token"
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	bts, err := os.ReadFile(token)
+	require.NoError(t, err)
+	require.Equal(t, "pypi-token", string(bts))
+}
+
+func TestPublishSkip(t *testing.T) {
+	for name, tt := range map[string]struct {
+		skipUpload string
+		prerelease string
+		err        string
+	}{
+		"skip upload": {
+			skipUpload: "true",
+			err:        errSkipUpload.Error(),
+		},
+		"skip upload auto": {
+			skipUpload: "auto",
+			prerelease: "rc1",
+			err:        errSkipUploadAuto.Error(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := makeContext(t, config.PyPI{
+				Name:       "foo",
+				SkipUpload: tt.skipUpload,
+			})
+			ctx.Semver.Prerelease = tt.prerelease
+			require.NoError(t, Pipe{}.Run(ctx))
+			err := Pipe{}.Publish(ctx)
+			testlib.AssertSkipped(t, err)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestPublishError(t *testing.T) {
+	cmd = fakeCmd{execFn: func(string, ...string) ([]byte, error) {
+		return []byte("403 Forbidden"), errors.New("exit status 2")
+	}}
+	t.Cleanup(func() {
+		cmd = execx.Std{}
+	})
+
+	ctx := makeContext(t, config.PyPI{Name: "foo"})
+	require.NoError(t, Pipe{}.Run(ctx))
+	err := Pipe{}.Publish(ctx)
+	require.ErrorContains(t, err, "exit status 2")
+	require.ErrorContains(t, err, "403 Forbidden")
+}
+
+func TestNormalize(t *testing.T) {
+	for name, expected := range map[string]string{
+		"foo":         "foo",
+		"My-Foo":      "my_foo",
+		"foo.bar_baz": "foo_bar_baz",
+		"foo--bar":    "foo_bar",
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, expected, normalize(name))
+		})
+	}
+}
+
+func makeContext(tb testing.TB, pypi config.PyPI) *context.Context {
+	tb.Helper()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		Dist:        tb.TempDir(),
+		ProjectName: "foo",
+		PyPIs:       []config.PyPI{pypi},
+	}, testctx.WithVersion("1.2.3"), testctx.WithCurrentTag("v1.2.3"))
+	require.NoError(tb, Pipe{}.Default(ctx))
+
+	bin := filepath.Join(tb.TempDir(), "foo")
+	require.NoError(tb, os.WriteFile(bin, []byte("fake bin"), 0o755))
+	for _, art := range []*artifact.Artifact{
+		{
+			Name:    "foo",
+			Path:    bin,
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+		},
+		{
+			Name:    "foo-cli",
+			Path:    bin,
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+		},
+		{
+			Name:    "foo",
+			Path:    bin,
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v3",
+			Type:    artifact.Binary,
+		},
+		{
+			Name:   "foo",
+			Path:   bin,
+			Goos:   "linux",
+			Goarch: "arm",
+			Goarm:  "7",
+			Type:   artifact.Binary,
+		},
+		{
+			Name:   "foo",
+			Path:   bin,
+			Goos:   "linux",
+			Goarch: "mips",
+			Type:   artifact.Binary,
+		},
+		{
+			Name:   "foo",
+			Path:   bin,
+			Goos:   "darwin",
+			Goarch: "all",
+			Type:   artifact.UniversalBinary,
+			Extra: map[string]any{
+				artifact.ExtraReplaces: true,
+			},
+		},
+		{
+			Name:    "foo.exe",
+			Path:    bin,
+			Goos:    "windows",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+			Extra: map[string]any{
+				artifact.ExtraBinary: "foo",
+				artifact.ExtraExt:    ".exe",
+			},
+		},
+	} {
+		if art.Extra == nil {
+			art.Extra = map[string]any{}
+		}
+		art.Extra[artifact.ExtraID] = "foo"
+		ctx.Artifacts.Add(art)
+	}
+	return ctx
+}
+
+type fakeCmd struct {
+	execFn func(cmd string, args ...string) ([]byte, error)
+}
+
+var _ execx.Cmder = fakeCmd{}
+
+func (f fakeCmd) Exec(_ *context.Context, cmd string, args ...string) ([]byte, error) {
+	return f.execFn(cmd, args...)
+}
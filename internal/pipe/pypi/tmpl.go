@@ -0,0 +1,44 @@
+package pypi
+
+type templateData struct {
+	Module   string
+	Commands []command
+}
+
+type command struct {
+	Name   string
+	Func   string
+	Binary string
+}
+
+const initTemplate = `# This file was generated by GoReleaser. DO NOT EDIT.
+import os
+import subprocess
+import sys
+
+
+def _run(binary):
+    path = os.path.join(os.path.dirname(os.path.abspath(__file__)), "bin", binary)
+    args = [path, *sys.argv[1:]]
+    if sys.platform == "win32":
+        sys.exit(subprocess.call(args))
+    os.execv(path, args)
+{{- range .Commands }}
+
+
+def {{ .Func }}():
+    _run("{{ .Binary }}")
+{{- end }}
+`
+
+const mainTemplate = `# This file was generated by GoReleaser. DO NOT EDIT.
+from {{ .Module }} import {{ (index .Commands 0).Func }}
+
+{{ (index .Commands 0).Func }}()
+`
+
+const entryPointsTemplate = `[console_scripts]
+{{- range .Commands }}
+{{ .Name }} = {{ $.Module }}:{{ .Func }}
+{{- end }}
+`
@@ -2,9 +2,12 @@
 package reddit
 
 import (
+	"fmt"
+
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/go-reddit/v3/reddit"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -40,21 +43,37 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
-	title, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Reddit.TitleTemplate)
+// Preview returns the link that would be submitted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	req, err := newLinkRequest(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
+	return fmt.Sprintf("r/%s: %s\n%s", req.Subreddit, req.Title, req.URL), nil
+}
 
-	url, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Reddit.URLTemplate)
+func newLinkRequest(ctx *context.Context) (reddit.SubmitLinkRequest, error) {
+	title, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Reddit.TitleTemplate)
 	if err != nil {
-		return err
+		return reddit.SubmitLinkRequest{}, err
 	}
 
-	linkRequest := reddit.SubmitLinkRequest{
+	url, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Reddit.URLTemplate)
+	if err != nil {
+		return reddit.SubmitLinkRequest{}, err
+	}
+
+	return reddit.SubmitLinkRequest{
 		Subreddit: ctx.Config.Announce.Reddit.Sub,
 		Title:     title,
 		URL:       url,
+	}, nil
+}
+
+func (p Pipe) Announce(ctx *context.Context) error {
+	linkRequest, err := newLinkRequest(ctx)
+	if err != nil {
+		return err
 	}
 
 	cfg, err := env.ParseAs[Config]()
@@ -63,7 +82,12 @@ func (p Pipe) Announce(ctx *context.Context) error {
 	}
 
 	credentials := reddit.Credentials{ID: ctx.Config.Announce.Reddit.ApplicationID, Secret: cfg.Secret, Username: ctx.Config.Announce.Reddit.Username, Password: cfg.Password}
-	client, err := reddit.NewClient(credentials)
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := reddit.NewClient(credentials, reddit.WithHTTPClient(httpClient))
 	if err != nil {
 		return err
 	}
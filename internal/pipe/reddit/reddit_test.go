@@ -19,6 +19,23 @@ func TestDefault(t *testing.T) {
 	require.Equal(t, defaultTitleTemplate, ctx.Config.Announce.Reddit.TitleTemplate)
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			Reddit: config.Reddit{
+				Sub: "goreleaser",
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "r/goreleaser: honk v1.0.0 is out!\nhttps://github.com/honk/honk/releases/tag/v1.0.0", out)
+}
+
 func TestAnnounceInvalidURLTemplate(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
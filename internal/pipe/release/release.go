@@ -46,6 +46,12 @@ func (p Pipe) Default(ctx *context.Context) error {
 	if ctx.Config.Release.Gitea.String() != "" {
 		numOfReleases++
 	}
+	if ctx.Config.Release.Forgejo.String() != "" {
+		numOfReleases++
+	}
+	if ctx.Config.Release.Bitbucket.String() != "" {
+		numOfReleases++
+	}
 	if numOfReleases > 1 {
 		return ErrMultipleReleases
 	}
@@ -63,6 +69,14 @@ func (p Pipe) Default(ctx *context.Context) error {
 		if err := setupGitea(ctx); err != nil {
 			return err
 		}
+	case context.TokenTypeForgejo:
+		if err := setupForgejo(ctx); err != nil {
+			return err
+		}
+	case context.TokenTypeBitbucket:
+		if err := setupBitbucket(ctx); err != nil {
+			return err
+		}
 	default:
 		// We keep github as default for now
 		if err := setupGitHub(ctx); err != nil {
@@ -125,6 +139,10 @@ func releaseRepo(ctx *context.Context) config.Repo {
 		return ctx.Config.Release.GitLab
 	case context.TokenTypeGitea:
 		return ctx.Config.Release.Gitea
+	case context.TokenTypeForgejo:
+		return ctx.Config.Release.Forgejo
+	case context.TokenTypeBitbucket:
+		return ctx.Config.Release.Bitbucket
 	default:
 		return ctx.Config.Release.GitHub
 	}
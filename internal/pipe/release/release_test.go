@@ -44,6 +44,22 @@ func TestReleaseRepo(t *testing.T) {
 		}, testctx.GiteaTokenType)
 		require.Equal(t, "gt-owner/gt-repo", releaseRepo(ctx).String())
 	})
+	t.Run("forgejo", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Release: config.Release{
+				Forgejo: config.Repo{Owner: "fj-owner", Name: "fj-repo"},
+			},
+		}, testctx.ForgejoTokenType)
+		require.Equal(t, "fj-owner/fj-repo", releaseRepo(ctx).String())
+	})
+	t.Run("bitbucket", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Release: config.Release{
+				Bitbucket: config.Repo{Owner: "bb-owner", Name: "bb-repo"},
+			},
+		}, testctx.BitbucketTokenType)
+		require.Equal(t, "bb-owner/bb-repo", releaseRepo(ctx).String())
+	})
 }
 
 func TestReleaseClient(t *testing.T) {
@@ -501,6 +517,67 @@ func TestDefaultWithGiteaInvalidURL(t *testing.T) {
 	require.Error(t, Pipe{}.Default(ctx))
 }
 
+func TestDefaultWithForgejo(t *testing.T) {
+	testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitRemoteAdd(t, "git@codeberg.org:forgejoowner/forgejorepo.git")
+
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			ForgejoURLs: config.ForgejoURLs{
+				Download: "https://codeberg.org",
+			},
+		},
+		testctx.ForgejoTokenType,
+		testctx.WithCurrentTag("v1.0.0"))
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "forgejorepo", ctx.Config.Release.Forgejo.Name)
+	require.Equal(t, "forgejoowner", ctx.Config.Release.Forgejo.Owner)
+	require.Equal(t, "https://codeberg.org/forgejoowner/forgejorepo/releases/tag/v1.0.0", ctx.ReleaseURL)
+}
+
+func TestDefaultMultipleReleasesWithForgejo(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Release: config.Release{
+			Gitea:   config.Repo{Owner: "foo", Name: "bar"},
+			Forgejo: config.Repo{Owner: "foo", Name: "bar"},
+		},
+	}, testctx.ForgejoTokenType)
+	require.ErrorIs(t, Pipe{}.Default(ctx), ErrMultipleReleases)
+}
+
+func TestDefaultWithBitbucket(t *testing.T) {
+	testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitRemoteAdd(t, "git@bitbucket.org:bitbucketowner/bitbucketrepo.git")
+
+	ctx := testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			BitbucketURLs: config.BitbucketURLs{
+				API:      "https://api.bitbucket.org/2.0",
+				Download: "https://bitbucket.org",
+			},
+		},
+		testctx.BitbucketTokenType,
+		testctx.WithCurrentTag("v1.0.0"))
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "bitbucketrepo", ctx.Config.Release.Bitbucket.Name)
+	require.Equal(t, "bitbucketowner", ctx.Config.Release.Bitbucket.Owner)
+	require.Equal(t, "https://bitbucket.org/bitbucketowner/bitbucketrepo/downloads/", ctx.ReleaseURL)
+}
+
+func TestDefaultMultipleReleasesWithBitbucket(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Release: config.Release{
+			GitHub:    config.Repo{Owner: "foo", Name: "bar"},
+			Bitbucket: config.Repo{Owner: "foo", Name: "bar"},
+		},
+	}, testctx.BitbucketTokenType)
+	require.ErrorIs(t, Pipe{}.Default(ctx), ErrMultipleReleases)
+}
+
 func TestDefaultPreRelease(t *testing.T) {
 	testlib.Mktmp(t)
 	testlib.GitInit(t)
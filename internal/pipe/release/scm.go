@@ -2,6 +2,7 @@ package release
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -87,3 +88,78 @@ func setupGitea(ctx *context.Context) error {
 	ctx.ReleaseURL = url
 	return err
 }
+
+func setupForgejo(ctx *context.Context) error {
+	if ctx.Config.Release.Forgejo.Name == "" {
+		repo, err := getRepository(ctx)
+		if err != nil {
+			return err
+		}
+		ctx.Config.Release.Forgejo = repo
+	}
+
+	if err := tmpl.New(ctx).ApplyAll(
+		&ctx.Config.Release.Forgejo.Name,
+		&ctx.Config.Release.Forgejo.Owner,
+	); err != nil {
+		return err
+	}
+
+	url, err := tmpl.New(ctx).Apply(fmt.Sprintf(
+		"%s/%s/%s/releases/tag/%s",
+		ctx.Config.ForgejoURLs.Download,
+		ctx.Config.Release.Forgejo.Owner,
+		ctx.Config.Release.Forgejo.Name,
+		ctx.Git.CurrentTag,
+	))
+	ctx.ReleaseURL = url
+	return err
+}
+
+func setupBitbucket(ctx *context.Context) error {
+	if ctx.Config.Release.Bitbucket.Name == "" {
+		repo, err := getRepository(ctx)
+		if err != nil {
+			return err
+		}
+		ctx.Config.Release.Bitbucket = repo
+	}
+
+	tpl := tmpl.New(ctx)
+	if err := tpl.ApplyAll(
+		&ctx.Config.Release.Bitbucket.Name,
+		&ctx.Config.Release.Bitbucket.Owner,
+	); err != nil {
+		return err
+	}
+
+	apiURL, err := tpl.Apply(ctx.Config.BitbucketURLs.API)
+	if err != nil {
+		return err
+	}
+
+	// Bitbucket has no releases: link to the downloads section on Cloud, and
+	// to the tag on Data Center, which serves its API under /rest/api.
+	releaseURL := fmt.Sprintf(
+		"%s/%s/%s/downloads/",
+		ctx.Config.BitbucketURLs.Download,
+		ctx.Config.Release.Bitbucket.Owner,
+		ctx.Config.Release.Bitbucket.Name,
+	)
+	if strings.Contains(apiURL, "/rest/api/") {
+		// remotes are usually cloned from /scm/PROJECT/repo.
+		owner := ctx.Config.Release.Bitbucket.Owner
+		ctx.Config.Release.Bitbucket.Owner = owner[strings.LastIndex(owner, "/")+1:]
+		releaseURL = fmt.Sprintf(
+			"%s/projects/%s/repos/%s/browse?at=refs%%2Ftags%%2F%s",
+			ctx.Config.BitbucketURLs.Download,
+			ctx.Config.Release.Bitbucket.Owner,
+			ctx.Config.Release.Bitbucket.Name,
+			ctx.Git.CurrentTag,
+		)
+	}
+
+	url, err := tpl.Apply(releaseURL)
+	ctx.ReleaseURL = url
+	return err
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/goreleaser/goreleaser/v2/internal/git"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/stretchr/testify/require"
 )
@@ -122,6 +123,47 @@ func TestSetupGitea(t *testing.T) {
 	})
 }
 
+func TestSetupForgejo(t *testing.T) {
+	t.Run("no repo", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		require.NoError(t, setupForgejo(ctx))
+		require.Equal(t, "goreleaser", ctx.Config.Release.Forgejo.Name)
+	})
+
+	t.Run("with templates", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env: []string{"NAME=foo", "OWNER=bar"},
+			ForgejoURLs: config.ForgejoURLs{
+				Download: "https://{{ .Env.OWNER }}/download",
+			},
+			Release: config.Release{
+				Forgejo: config.Repo{
+					Owner: "{{.Env.OWNER}}",
+					Name:  "{{.Env.NAME}}",
+				},
+			},
+		})
+
+		require.NoError(t, setupForgejo(ctx))
+		require.Equal(t, "bar", ctx.Config.Release.Forgejo.Owner)
+		require.Equal(t, "foo", ctx.Config.Release.Forgejo.Name)
+		require.Equal(t, "https://bar/download/bar/foo/releases/tag/", ctx.ReleaseURL)
+	})
+
+	t.Run("with invalid templates", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Release: config.Release{
+				Forgejo: config.Repo{
+					Name:  "foo",
+					Owner: "{{.Env.NOPE}}",
+				},
+			},
+		})
+
+		require.Error(t, setupForgejo(ctx))
+	})
+}
+
 func TestSetupGitHub(t *testing.T) {
 	t.Run("no repo", func(t *testing.T) {
 		ctx := testctx.Wrap(t.Context())
@@ -176,3 +218,82 @@ func TestSetupGitHub(t *testing.T) {
 		})
 	})
 }
+
+func TestSetupBitbucket(t *testing.T) {
+	t.Run("no repo", func(t *testing.T) {
+		ctx := testctx.Wrap(t.Context())
+		require.NoError(t, setupBitbucket(ctx))
+		require.Equal(t, "goreleaser", ctx.Config.Release.Bitbucket.Name)
+	})
+
+	t.Run("cloud", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env: []string{"NAME=foo", "OWNER=bar"},
+			BitbucketURLs: config.BitbucketURLs{
+				API:      "https://api.bitbucket.org/2.0",
+				Download: "https://bitbucket.org",
+			},
+			Release: config.Release{
+				Bitbucket: config.Repo{
+					Owner: "{{.Env.OWNER}}",
+					Name:  "{{.Env.NAME}}",
+				},
+			},
+		})
+
+		require.NoError(t, setupBitbucket(ctx))
+		require.Equal(t, "bar", ctx.Config.Release.Bitbucket.Owner)
+		require.Equal(t, "foo", ctx.Config.Release.Bitbucket.Name)
+		require.Equal(t, "https://bitbucket.org/bar/foo/downloads/", ctx.ReleaseURL)
+	})
+
+	t.Run("data center", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Env: []string{"HOST=bitbucket.example.com"},
+			BitbucketURLs: config.BitbucketURLs{
+				API:      "https://{{ .Env.HOST }}/rest/api/latest",
+				Download: "https://{{ .Env.HOST }}",
+			},
+			Release: config.Release{
+				Bitbucket: config.Repo{
+					Owner: "scm/PROJ",
+					Name:  "foo",
+				},
+			},
+		}, testctx.WithCurrentTag("v1.0.0"))
+
+		require.NoError(t, setupBitbucket(ctx))
+		require.Equal(t, "PROJ", ctx.Config.Release.Bitbucket.Owner)
+		require.Equal(t, "foo", ctx.Config.Release.Bitbucket.Name)
+		require.Equal(t, "https://bitbucket.example.com/projects/PROJ/repos/foo/browse?at=refs%2Ftags%2Fv1.0.0", ctx.ReleaseURL)
+	})
+
+	t.Run("with invalid templates", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Release: config.Release{
+				Bitbucket: config.Repo{
+					Name:  "foo",
+					Owner: "{{.Env.NOPE}}",
+				},
+			},
+		})
+
+		require.Error(t, setupBitbucket(ctx))
+	})
+
+	t.Run("with invalid api url template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			BitbucketURLs: config.BitbucketURLs{
+				API: "{{.Env.NOPE}}",
+			},
+			Release: config.Release{
+				Bitbucket: config.Repo{
+					Name:  "foo",
+					Owner: "bar",
+				},
+			},
+		})
+
+		testlib.RequireTemplateError(t, setupBitbucket(ctx))
+	})
+}
@@ -0,0 +1,334 @@
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// formats are the SBOM formats we can create natively, by name, with the
+// syft output used to create them, and the extension of their documents.
+//
+// syft can't create SPDX 3.0 documents, so we create SPDX 2.3 ones and
+// convert them.
+var formats = map[string]struct {
+	syft string
+	ext  string
+}{
+	"spdx-json":      {syft: "spdx-json", ext: ".sbom.json"},
+	"spdx3-json":     {syft: "spdx-json", ext: ".spdx3.json"},
+	"cyclonedx-json": {syft: "cyclonedx-json", ext: ".cdx.json"},
+}
+
+// spdx3Context is the JSON-LD context of SPDX 3.0 documents.
+const spdx3Context = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+
+// creationInfoID is the blank node ID of the creation info of the SPDX 3.0
+// documents we create.
+const creationInfoID = "_:creationinfo"
+
+// spdx3Relationships maps SPDX 2 relationship types to SPDX 3.0 ones, and
+// whether their direction is reversed.
+var spdx3Relationships = map[string]struct {
+	typ     string
+	reverse bool
+}{
+	"CONTAINS":       {typ: "contains"},
+	"CONTAINED_BY":   {typ: "contains", reverse: true},
+	"DEPENDS_ON":     {typ: "dependsOn"},
+	"DEPENDENCY_OF":  {typ: "dependsOn", reverse: true},
+	"DESCRIBES":      {typ: "describes"},
+	"DESCRIBED_BY":   {typ: "describes", reverse: true},
+	"GENERATES":      {typ: "generates"},
+	"GENERATED_FROM": {typ: "generates", reverse: true},
+	"ANCESTOR_OF":    {typ: "ancestorOf"},
+	"DESCENDANT_OF":  {typ: "descendantOf"},
+	"OTHER":          {typ: "other"},
+}
+
+// detect returns the format of the given SBOM document, or an empty string
+// if it is not known.
+func detect(doc map[string]any) string {
+	switch {
+	case doc["bomFormat"] == "CycloneDX":
+		return "cyclonedx-json"
+	case doc["spdxVersion"] != nil:
+		return "spdx-json"
+	case doc["@graph"] != nil:
+		return "spdx3-json"
+	default:
+		return ""
+	}
+}
+
+func readDocument(path string) (map[string]any, error) {
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(bts, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func writeDocument(path string, doc map[string]any) error {
+	bts, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bts, 0o644)
+}
+
+// convertToSPDX3 converts the SPDX 2 document in the given path to SPDX 3.0,
+// in place.
+func convertToSPDX3(path string) error {
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+	switch detect(doc) {
+	case "spdx3-json":
+		return nil
+	case "spdx-json":
+		return writeDocument(path, toSPDX3(doc))
+	default:
+		return errors.New("not a SPDX 2 document")
+	}
+}
+
+// toSPDX3 converts the given SPDX 2 document to SPDX 3.0.
+//
+// Packages, files, their checksums, licenses, and relationships are kept.
+// Elements are identified by the document namespace, followed by their SPDX
+// 2 ID.
+func toSPDX3(doc map[string]any) map[string]any {
+	ns := strings.TrimSuffix(str(doc["documentNamespace"]), "#")
+	id := func(spdxID string) string { return ns + "#" + spdxID }
+
+	var graph []any
+	var elements []string
+	add := func(e map[string]any) {
+		e["creationInfo"] = creationInfoID
+		graph = append(graph, e)
+		elements = append(elements, e["spdxId"].(string))
+	}
+
+	creation := map[string]any{
+		"type":        "CreationInfo",
+		"@id":         creationInfoID,
+		"specVersion": "3.0.1",
+	}
+	info, _ := doc["creationInfo"].(map[string]any)
+	setIf(creation, "created", str(info["created"]))
+	var createdBy, createdUsing []string
+	for _, creator := range list(info["creators"]) {
+		typ, name, ok := strings.Cut(str(creator), ": ")
+		if !ok {
+			continue
+		}
+		e := map[string]any{
+			"type":   typ,
+			"spdxId": id(typ + "-" + slug(name)),
+			"name":   name,
+		}
+		add(e)
+		if typ == "Tool" {
+			createdUsing = append(createdUsing, e["spdxId"].(string))
+			continue
+		}
+		createdBy = append(createdBy, e["spdxId"].(string))
+	}
+	if len(createdBy) == 0 {
+		e := map[string]any{
+			"type":   "Agent",
+			"spdxId": id("Agent-NOASSERTION"),
+			"name":   "NOASSERTION",
+		}
+		add(e)
+		createdBy = append(createdBy, e["spdxId"].(string))
+	}
+	creation["createdBy"] = createdBy
+	if len(createdUsing) > 0 {
+		creation["createdUsing"] = createdUsing
+	}
+
+	relationship := func(from, typ string, to ...string) {
+		add(map[string]any{
+			"type":             "Relationship",
+			"spdxId":           id("Relationship-" + strconv.Itoa(len(graph))),
+			"from":             from,
+			"to":               to,
+			"relationshipType": typ,
+		})
+	}
+
+	licenses := map[string]string{}
+	license := func(expr string) string {
+		if lid, ok := licenses[expr]; ok {
+			return lid
+		}
+		lid := id("LicenseExpression-" + strconv.Itoa(len(licenses)))
+		licenses[expr] = lid
+		add(map[string]any{
+			"type":                              "simplelicensing_LicenseExpression",
+			"spdxId":                            lid,
+			"simplelicensing_licenseExpression": expr,
+		})
+		return lid
+	}
+
+	for _, item := range list(doc["packages"]) {
+		p, _ := item.(map[string]any)
+		e := map[string]any{
+			"type":   "software_Package",
+			"spdxId": id(str(p["SPDXID"])),
+			"name":   str(p["name"]),
+		}
+		setIf(e, "software_packageVersion", str(p["versionInfo"]))
+		setIf(e, "software_downloadLocation", assertion(p["downloadLocation"]))
+		setIf(e, "software_homePage", assertion(p["homepage"]))
+		setIf(e, "software_copyrightText", assertion(p["copyrightText"]))
+		setIf(e, "description", str(p["description"]))
+		setHashes(e, p["checksums"])
+		var identifiers []any
+		for _, ref := range list(p["externalRefs"]) {
+			ref, _ := ref.(map[string]any)
+			locator := str(ref["referenceLocator"])
+			switch ref["referenceType"] {
+			case "purl":
+				setIf(e, "software_packageUrl", locator)
+			case "cpe23Type", "cpe22Type":
+				identifiers = append(identifiers, map[string]any{
+					"type":                   "ExternalIdentifier",
+					"externalIdentifierType": strings.TrimSuffix(str(ref["referenceType"]), "Type"),
+					"identifier":             locator,
+				})
+			}
+		}
+		if len(identifiers) > 0 {
+			e["externalIdentifier"] = identifiers
+		}
+		add(e)
+
+		for _, l := range []struct{ field, typ string }{
+			{"licenseConcluded", "hasConcludedLicense"},
+			{"licenseDeclared", "hasDeclaredLicense"},
+		} {
+			if expr := assertion(p[l.field]); expr != "" && expr != "NONE" {
+				relationship(e["spdxId"].(string), l.typ, license(expr))
+			}
+		}
+	}
+
+	for _, item := range list(doc["files"]) {
+		f, _ := item.(map[string]any)
+		e := map[string]any{
+			"type":   "software_File",
+			"spdxId": id(str(f["SPDXID"])),
+			"name":   str(f["fileName"]),
+		}
+		setHashes(e, f["checksums"])
+		add(e)
+	}
+
+	var roots []string
+	for _, item := range list(doc["documentDescribes"]) {
+		roots = append(roots, id(str(item)))
+	}
+	docID := str(doc["SPDXID"])
+	for _, item := range list(doc["relationships"]) {
+		r, _ := item.(map[string]any)
+		from, to, typ := str(r["spdxElementId"]), str(r["relatedSpdxElement"]), str(r["relationshipType"])
+		if from == docID && typ == "DESCRIBES" {
+			if !slices.Contains(roots, id(to)) {
+				roots = append(roots, id(to))
+			}
+			continue
+		}
+		if from == docID || to == docID || to == "NONE" || to == "NOASSERTION" {
+			continue
+		}
+		rel, ok := spdx3Relationships[typ]
+		if !ok {
+			rel.typ = "other"
+		}
+		if rel.reverse {
+			from, to = to, from
+		}
+		relationship(id(from), rel.typ, id(to))
+	}
+
+	document := map[string]any{
+		"type":               "SpdxDocument",
+		"spdxId":             id(docID),
+		"creationInfo":       creationInfoID,
+		"name":               str(doc["name"]),
+		"profileConformance": []string{"core", "software", "simpleLicensing"},
+		"element":            elements,
+	}
+	if len(roots) > 0 {
+		document["rootElement"] = roots
+	}
+
+	return map[string]any{
+		"@context": spdx3Context,
+		"@graph":   append([]any{creation, document}, graph...),
+	}
+}
+
+func setHashes(e map[string]any, checksums any) {
+	var hashes []any
+	for _, item := range list(checksums) {
+		c, _ := item.(map[string]any)
+		hashes = append(hashes, map[string]any{
+			"type":      "Hash",
+			"algorithm": strings.ReplaceAll(strings.ToLower(str(c["algorithm"])), "-", "_"),
+			"hashValue": str(c["checksumValue"]),
+		})
+	}
+	if len(hashes) > 0 {
+		e["verifiedUsing"] = hashes
+	}
+}
+
+// assertion returns the given SPDX 2 value, or an empty string if it is
+// NOASSERTION.
+func assertion(v any) string {
+	if s := str(v); s != "NOASSERTION" {
+		return s
+	}
+	return ""
+}
+
+func setIf(m map[string]any, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func list(v any) []any {
+	l, _ := v.([]any)
+	return l
+}
+
+// slug makes the given name safe to use in an element ID.
+func slug(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+}
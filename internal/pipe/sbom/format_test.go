@@ -0,0 +1,180 @@
+package sbom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const spdx2Document = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "foo",
+  "documentNamespace": "https://anchore.com/syft/file/foo-1234",
+  "creationInfo": {
+    "created": "2025-01-01T00:00:00Z",
+    "creators": ["Organization: Anchore, Inc", "Tool: syft-1.0.0"]
+  },
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Package-foo",
+      "name": "foo",
+      "versionInfo": "v1.0.0",
+      "downloadLocation": "NOASSERTION",
+      "licenseConcluded": "MIT",
+      "licenseDeclared": "NOASSERTION",
+      "checksums": [{"algorithm": "SHA256", "checksumValue": "abc"}],
+      "externalRefs": [
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/foo@v1.0.0"},
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:foo:foo:v1.0.0:*:*:*:*:*:*:*"}
+      ]
+    },
+    {
+      "SPDXID": "SPDXRef-Package-bar",
+      "name": "bar",
+      "versionInfo": "v2.0.0",
+      "licenseConcluded": "MIT"
+    }
+  ],
+  "files": [
+    {
+      "SPDXID": "SPDXRef-File-foo",
+      "fileName": "/foo",
+      "checksums": [{"algorithm": "SHA-256", "checksumValue": "def"}]
+    }
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-foo"},
+    {"spdxElementId": "SPDXRef-Package-foo", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-File-foo"},
+    {"spdxElementId": "SPDXRef-Package-bar", "relationshipType": "DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-Package-foo"}
+  ]
+}`
+
+func TestDetect(t *testing.T) {
+	for expected, doc := range map[string]map[string]any{
+		"cyclonedx-json": {"bomFormat": "CycloneDX"},
+		"spdx-json":      {"spdxVersion": "SPDX-2.3"},
+		"spdx3-json":     {"@graph": []any{}},
+		"":               {"foo": "bar"},
+	} {
+		t.Run(expected, func(t *testing.T) {
+			require.Equal(t, expected, detect(doc))
+		})
+	}
+}
+
+func TestConvertToSPDX3(t *testing.T) {
+	path := writeTestDocument(t, "foo.spdx3.json", spdx2Document)
+	require.NoError(t, convertToSPDX3(path))
+
+	doc, err := readDocument(path)
+	require.NoError(t, err)
+	require.Equal(t, "spdx3-json", detect(doc))
+	require.Equal(t, spdx3Context, doc["@context"])
+
+	const ns = "https://anchore.com/syft/file/foo-1234#"
+	elements := map[string]map[string]any{}
+	var relationships []map[string]any
+	for _, item := range list(doc["@graph"]) {
+		e := item.(map[string]any)
+		if e["type"] == "Relationship" {
+			relationships = append(relationships, e)
+			continue
+		}
+		elements[str(e["type"])+"/"+str(e["spdxId"])+str(e["@id"])] = e
+	}
+
+	require.Equal(t, map[string]any{
+		"type":         "CreationInfo",
+		"@id":          creationInfoID,
+		"specVersion":  "3.0.1",
+		"created":      "2025-01-01T00:00:00Z",
+		"createdBy":    []any{ns + "Organization-Anchore--Inc"},
+		"createdUsing": []any{ns + "Tool-syft-1.0.0"},
+	}, elements["CreationInfo/"+creationInfoID])
+
+	document := elements["SpdxDocument/"+ns+"SPDXRef-DOCUMENT"]
+	require.NotNil(t, document)
+	require.Equal(t, "foo", document["name"])
+	require.Equal(t, []any{ns + "SPDXRef-Package-foo"}, document["rootElement"])
+	require.Len(t, document["element"], len(list(doc["@graph"]))-2)
+
+	require.Equal(t, map[string]any{
+		"type":                    "software_Package",
+		"spdxId":                  ns + "SPDXRef-Package-foo",
+		"creationInfo":            creationInfoID,
+		"name":                    "foo",
+		"software_packageVersion": "v1.0.0",
+		"software_packageUrl":     "pkg:golang/foo@v1.0.0",
+		"verifiedUsing": []any{
+			map[string]any{"type": "Hash", "algorithm": "sha256", "hashValue": "abc"},
+		},
+		"externalIdentifier": []any{
+			map[string]any{
+				"type":                   "ExternalIdentifier",
+				"externalIdentifierType": "cpe23",
+				"identifier":             "cpe:2.3:a:foo:foo:v1.0.0:*:*:*:*:*:*:*",
+			},
+		},
+	}, elements["software_Package/"+ns+"SPDXRef-Package-foo"])
+
+	require.Equal(t, map[string]any{
+		"type":         "software_File",
+		"spdxId":       ns + "SPDXRef-File-foo",
+		"creationInfo": creationInfoID,
+		"name":         "/foo",
+		"verifiedUsing": []any{
+			map[string]any{"type": "Hash", "algorithm": "sha_256", "hashValue": "def"},
+		},
+	}, elements["software_File/"+ns+"SPDXRef-File-foo"])
+
+	// both packages share the same license expression.
+	license := ns + "LicenseExpression-0"
+	require.Equal(t, "MIT", elements["simplelicensing_LicenseExpression/"+license]["simplelicensing_licenseExpression"])
+
+	var rels []string
+	for _, r := range relationships {
+		for _, to := range list(r["to"]) {
+			rels = append(rels, str(r["from"])+" "+str(r["relationshipType"])+" "+str(to))
+		}
+	}
+	require.ElementsMatch(t, []string{
+		ns + "SPDXRef-Package-foo hasConcludedLicense " + license,
+		ns + "SPDXRef-Package-bar hasConcludedLicense " + license,
+		ns + "SPDXRef-Package-foo contains " + ns + "SPDXRef-File-foo",
+		ns + "SPDXRef-Package-foo dependsOn " + ns + "SPDXRef-Package-bar",
+	}, rels)
+}
+
+func TestConvertToSPDX3AlreadyConverted(t *testing.T) {
+	content := `{"@context":"` + spdx3Context + `","@graph":[]}`
+	path := writeTestDocument(t, "foo.spdx3.json", content)
+	require.NoError(t, convertToSPDX3(path))
+	bts, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, content, string(bts))
+}
+
+func TestConvertToSPDX3Errors(t *testing.T) {
+	t.Run("not spdx", func(t *testing.T) {
+		path := writeTestDocument(t, "foo.cdx.json", `{"bomFormat":"CycloneDX"}`)
+		require.EqualError(t, convertToSPDX3(path), "not a SPDX 2 document")
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		path := writeTestDocument(t, "foo.spdx3.json", `{`)
+		require.Error(t, convertToSPDX3(path))
+	})
+	t.Run("missing", func(t *testing.T) {
+		require.ErrorIs(t, convertToSPDX3(filepath.Join(t.TempDir(), "nope.json")), os.ErrNotExist)
+	})
+}
+
+func writeTestDocument(tb testing.TB, name, content string) string {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), name)
+	require.NoError(tb, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
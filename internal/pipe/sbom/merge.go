@@ -0,0 +1,341 @@
+package sbom
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/caarlos0/log"
+	"github.com/google/uuid"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const defaultMergeNameTemplate = "{{ .ProjectName }}_{{ .Version }}"
+
+// mergeTask merges the given SBOMs into a single release SBOM per format.
+func mergeTask(ctx *context.Context, cfg config.SBOM, sboms []*artifact.Artifact) error {
+	tpl := tmpl.New(ctx)
+	enabled, err := tpl.Bool(cfg.Merge.Enabled)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+	name, err := tpl.Apply(cfg.Merge.NameTemplate)
+	if err != nil {
+		return err
+	}
+
+	docs := map[string][]map[string]any{}
+	for _, sbom := range sboms {
+		doc, err := readDocument(sbom.Path)
+		if err != nil {
+			return fmt.Errorf("merging sboms: %s: %w", sbom.Name, err)
+		}
+		format := detect(doc)
+		if format == "" {
+			log.WithField("sbom", sbom.Name).Warn("unknown sbom format, not merging it")
+			continue
+		}
+		docs[format] = append(docs[format], doc)
+	}
+
+	m := merger{
+		name:      ctx.Config.ProjectName + "-" + ctx.Version,
+		project:   ctx.Config.ProjectName,
+		version:   ctx.Version,
+		created:   cmp.Or(ctx.Date, time.Now()).UTC().Format(time.RFC3339),
+		namespace: "https://goreleaser.com/spdxdocs/" + ctx.Config.ProjectName + "-" + ctx.Version,
+	}
+	m.uuid = uuid.NewSHA1(uuid.NameSpaceURL, []byte(m.namespace+"@"+ctx.Git.FullCommit)).String()
+	m.namespace += "-" + m.uuid
+
+	for _, format := range slices.Sorted(maps.Keys(docs)) {
+		var merged map[string]any
+		switch format {
+		case "cyclonedx-json":
+			merged = m.cycloneDX(docs[format])
+		case "spdx-json":
+			merged = m.spdx(docs[format])
+		case "spdx3-json":
+			merged = m.spdx3(docs[format])
+		}
+		filename := name + formats[format].ext
+		path := filepath.Join(ctx.Config.Dist, filename)
+		log.WithField("sbom", filename).Info("merging")
+		if err := writeDocument(path, merged); err != nil {
+			return fmt.Errorf("merging sboms: %w", err)
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: artifact.SBOM,
+			Name: filename,
+			Path: path,
+			Extra: map[string]any{
+				artifact.ExtraID: cfg.ID,
+			},
+		})
+	}
+	return nil
+}
+
+type merger struct {
+	name      string
+	project   string
+	version   string
+	created   string
+	namespace string
+	uuid      string
+}
+
+// cycloneDX merges CycloneDX documents.
+//
+// The component each document describes becomes a dependency of the project,
+// and all components and dependencies are deduplicated by their reference.
+func (m merger) cycloneDX(docs []map[string]any) map[string]any {
+	root := map[string]any{
+		"type":    "application",
+		"bom-ref": m.name,
+		"name":    m.project,
+		"version": m.version,
+	}
+	var components []any
+	seen := map[string]bool{}
+	addComponent := func(c map[string]any) string {
+		ref := cmp.Or(str(c["bom-ref"]), str(c["purl"]), str(c["name"])+"@"+str(c["version"]))
+		if !seen[ref] {
+			seen[ref] = true
+			components = append(components, c)
+		}
+		return ref
+	}
+
+	var refs []string
+	dependencies := map[string][]string{}
+	depend := func(ref string, deps ...string) {
+		if _, ok := dependencies[ref]; !ok {
+			refs = append(refs, ref)
+			dependencies[ref] = []string{}
+		}
+		for _, dep := range deps {
+			if !slices.Contains(dependencies[ref], dep) {
+				dependencies[ref] = append(dependencies[ref], dep)
+			}
+		}
+	}
+	depend(m.name)
+
+	specVersion := "1.6"
+	for i, doc := range docs {
+		if i == 0 {
+			specVersion = cmp.Or(str(doc["specVersion"]), specVersion)
+		}
+		if metadata, ok := doc["metadata"].(map[string]any); ok {
+			if c, ok := metadata["component"].(map[string]any); ok {
+				depend(m.name, addComponent(c))
+			}
+		}
+		for _, item := range list(doc["components"]) {
+			if c, ok := item.(map[string]any); ok {
+				addComponent(c)
+			}
+		}
+		for _, item := range list(doc["dependencies"]) {
+			d, _ := item.(map[string]any)
+			var deps []string
+			for _, dep := range list(d["dependsOn"]) {
+				deps = append(deps, str(dep))
+			}
+			depend(str(d["ref"]), deps...)
+		}
+	}
+
+	var deps []any
+	for _, ref := range refs {
+		dep := map[string]any{"ref": ref}
+		if len(dependencies[ref]) > 0 {
+			dep["dependsOn"] = dependencies[ref]
+		}
+		deps = append(deps, dep)
+	}
+
+	return map[string]any{
+		"bomFormat":    "CycloneDX",
+		"specVersion":  specVersion,
+		"serialNumber": "urn:uuid:" + m.uuid,
+		"version":      1,
+		"metadata": map[string]any{
+			"timestamp": m.created,
+			"tools": map[string]any{
+				"components": []any{
+					map[string]any{"type": "application", "name": "goreleaser"},
+				},
+			},
+			"component": root,
+		},
+		"components":   components,
+		"dependencies": deps,
+	}
+}
+
+// spdx merges SPDX 2 documents.
+//
+// Packages, files, and extracted licenses are deduplicated by their IDs, and
+// relationships to each document become relationships to the merged one.
+func (m merger) spdx(docs []map[string]any) map[string]any {
+	const docID = "SPDXRef-DOCUMENT"
+	lists := map[string][]any{}
+	seen := map[string]bool{}
+	add := func(field, key string, item any) {
+		if seen[field+"/"+key] {
+			return
+		}
+		seen[field+"/"+key] = true
+		lists[field] = append(lists[field], item)
+	}
+
+	spdxVersion := "SPDX-2.3"
+	for i, doc := range docs {
+		if i == 0 {
+			spdxVersion = cmp.Or(str(doc["spdxVersion"]), spdxVersion)
+		}
+		for _, field := range []string{"packages", "files"} {
+			for _, item := range list(doc[field]) {
+				e, _ := item.(map[string]any)
+				add(field, str(e["SPDXID"]), e)
+			}
+		}
+		for _, item := range list(doc["hasExtractedLicensingInfos"]) {
+			e, _ := item.(map[string]any)
+			add("hasExtractedLicensingInfos", str(e["licenseId"]), e)
+		}
+		for _, item := range list(doc["documentDescribes"]) {
+			add("relationships", docID+"/DESCRIBES/"+str(item), map[string]any{
+				"spdxElementId":      docID,
+				"relationshipType":   "DESCRIBES",
+				"relatedSpdxElement": str(item),
+			})
+		}
+		source := str(doc["SPDXID"])
+		for _, item := range list(doc["relationships"]) {
+			r, _ := item.(map[string]any)
+			for _, field := range []string{"spdxElementId", "relatedSpdxElement"} {
+				if r[field] == source {
+					r[field] = docID
+				}
+			}
+			key := str(r["spdxElementId"]) + "/" + str(r["relationshipType"]) + "/" + str(r["relatedSpdxElement"])
+			add("relationships", key, r)
+		}
+	}
+
+	merged := map[string]any{
+		"spdxVersion":       spdxVersion,
+		"dataLicense":       "CC0-1.0",
+		"SPDXID":            docID,
+		"name":              m.name,
+		"documentNamespace": m.namespace,
+		"creationInfo": map[string]any{
+			"created":  m.created,
+			"creators": []string{"Tool: goreleaser"},
+		},
+	}
+	for field, items := range lists {
+		merged[field] = items
+	}
+	return merged
+}
+
+// spdx3 merges SPDX 3.0 documents.
+//
+// Elements are deduplicated by their IDs, all of them share the creation
+// info of the merged document, and the root elements of each document become
+// the root elements of the merged one.
+func (m merger) spdx3(docs []map[string]any) map[string]any {
+	id := m.namespace + "#SPDXRef-DOCUMENT"
+	creation := map[string]any{
+		"type":        "CreationInfo",
+		"@id":         creationInfoID,
+		"specVersion": "3.0.1",
+		"created":     m.created,
+	}
+
+	var graph []any
+	var elements, roots, createdBy, createdUsing, profiles []string
+	seen := map[string]bool{}
+	appendNew := func(s []string, items ...any) []string {
+		for _, item := range items {
+			if v := str(item); v != "" && !slices.Contains(s, v) {
+				s = append(s, v)
+			}
+		}
+		return s
+	}
+
+	for _, doc := range docs {
+		for _, item := range list(doc["@graph"]) {
+			e, _ := item.(map[string]any)
+			switch e["type"] {
+			case "CreationInfo":
+				createdBy = appendNew(createdBy, list(e["createdBy"])...)
+				createdUsing = appendNew(createdUsing, list(e["createdUsing"])...)
+				continue
+			case "SpdxDocument":
+				roots = appendNew(roots, list(e["rootElement"])...)
+				profiles = appendNew(profiles, list(e["profileConformance"])...)
+				continue
+			}
+			// inline creation infos are merged as well.
+			if info, ok := e["creationInfo"].(map[string]any); ok {
+				createdBy = appendNew(createdBy, list(info["createdBy"])...)
+				createdUsing = appendNew(createdUsing, list(info["createdUsing"])...)
+			}
+			spdxID := str(e["spdxId"])
+			if spdxID != "" && seen[spdxID] {
+				continue
+			}
+			seen[spdxID] = true
+			e["creationInfo"] = creationInfoID
+			graph = append(graph, e)
+			if spdxID != "" {
+				elements = append(elements, spdxID)
+			}
+		}
+	}
+
+	if len(createdBy) > 0 {
+		creation["createdBy"] = createdBy
+	}
+	if len(createdUsing) > 0 {
+		creation["createdUsing"] = createdUsing
+	}
+	document := map[string]any{
+		"type":               "SpdxDocument",
+		"spdxId":             id,
+		"creationInfo":       creationInfoID,
+		"name":               m.name,
+		"profileConformance": appendNew([]string{"core", "software"}, anys(profiles)...),
+		"element":            elements,
+	}
+	if len(roots) > 0 {
+		document["rootElement"] = roots
+	}
+	return map[string]any{
+		"@context": spdx3Context,
+		"@graph":   append([]any{creation, document}, graph...),
+	}
+}
+
+func anys(s []string) []any {
+	result := make([]any, 0, len(s))
+	for _, v := range s {
+		result = append(result, v)
+	}
+	return result
+}
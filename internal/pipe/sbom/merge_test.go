@@ -0,0 +1,206 @@
+package sbom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	ctx := makeMergeContext(t)
+	for name, content := range map[string]string{
+		"foo.cdx.json": `{
+			"bomFormat": "CycloneDX",
+			"specVersion": "1.6",
+			"metadata": {"component": {"bom-ref": "foo", "type": "file", "name": "foo"}},
+			"components": [{"bom-ref": "pkg:golang/dep@v1", "name": "dep", "version": "v1"}],
+			"dependencies": [{"ref": "foo", "dependsOn": ["pkg:golang/dep@v1"]}]
+		}`,
+		"bar.cdx.json": `{
+			"bomFormat": "CycloneDX",
+			"specVersion": "1.6",
+			"metadata": {"component": {"bom-ref": "bar", "type": "file", "name": "bar"}},
+			"components": [{"bom-ref": "pkg:golang/dep@v1", "name": "dep", "version": "v1"}],
+			"dependencies": [{"ref": "bar", "dependsOn": ["pkg:golang/dep@v1"]}]
+		}`,
+		"foo.sbom.json": `{
+			"spdxVersion": "SPDX-2.3",
+			"SPDXID": "SPDXRef-DOCUMENT",
+			"packages": [{"SPDXID": "SPDXRef-Package-foo", "name": "foo"}, {"SPDXID": "SPDXRef-Package-dep", "name": "dep"}],
+			"relationships": [
+				{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-foo"},
+				{"spdxElementId": "SPDXRef-Package-foo", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-dep"}
+			]
+		}`,
+		"bar.sbom.json": `{
+			"spdxVersion": "SPDX-2.3",
+			"SPDXID": "SPDXRef-DOCUMENT",
+			"documentDescribes": ["SPDXRef-Package-bar"],
+			"packages": [{"SPDXID": "SPDXRef-Package-bar", "name": "bar"}, {"SPDXID": "SPDXRef-Package-dep", "name": "dep"}]
+		}`,
+		"foo.spdx3.json": `{
+			"@context": "` + spdx3Context + `",
+			"@graph": [
+				{"type": "CreationInfo", "@id": "_:creationinfo", "createdBy": ["urn:agent"]},
+				{"type": "SpdxDocument", "spdxId": "urn:foo#doc", "rootElement": ["urn:foo"], "profileConformance": ["core", "software", "simpleLicensing"]},
+				{"type": "software_Package", "spdxId": "urn:foo", "creationInfo": "_:creationinfo", "name": "foo"},
+				{"type": "software_Package", "spdxId": "urn:dep", "creationInfo": "_:creationinfo", "name": "dep"}
+			]
+		}`,
+		"bar.spdx3.json": `{
+			"@context": "` + spdx3Context + `",
+			"@graph": [
+				{"type": "SpdxDocument", "spdxId": "urn:bar#doc", "rootElement": ["urn:bar"]},
+				{"type": "software_Package", "spdxId": "urn:bar", "creationInfo": {"type": "CreationInfo", "createdBy": ["urn:other-agent"]}, "name": "bar"},
+				{"type": "software_Package", "spdxId": "urn:dep", "creationInfo": "_:creationinfo", "name": "dep"}
+			]
+		}`,
+		"foo.txt": `{"unknown": true}`,
+	} {
+		path := filepath.Join(ctx.Config.Dist, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	var sboms []*artifact.Artifact
+	for _, name := range []string{
+		"foo.cdx.json", "bar.cdx.json",
+		"foo.sbom.json", "bar.sbom.json",
+		"foo.spdx3.json", "bar.spdx3.json",
+		"foo.txt",
+	} {
+		sboms = append(sboms, &artifact.Artifact{
+			Type: artifact.SBOM,
+			Name: name,
+			Path: filepath.Join(ctx.Config.Dist, name),
+		})
+	}
+
+	cfg := config.SBOM{
+		ID: "merged",
+		Merge: config.SBOMMerge{
+			Enabled:      "{{ .Env.MERGE }}",
+			NameTemplate: "{{ .ProjectName }}_{{ .Version }}_release",
+		},
+	}
+	require.NoError(t, mergeTask(ctx, cfg, sboms))
+
+	merged := ctx.Artifacts.Filter(artifact.ByType(artifact.SBOM)).List()
+	var names []string
+	for _, a := range merged {
+		require.Equal(t, "merged", a.ID())
+		names = append(names, a.Name)
+	}
+	require.Equal(t, []string{
+		"proj_1.0.0_release.cdx.json",
+		"proj_1.0.0_release.sbom.json",
+		"proj_1.0.0_release.spdx3.json",
+	}, names)
+
+	t.Run("cyclonedx", func(t *testing.T) {
+		doc, err := readDocument(merged[0].Path)
+		require.NoError(t, err)
+		require.Equal(t, "cyclonedx-json", detect(doc))
+		metadata := doc["metadata"].(map[string]any)
+		require.Equal(t, "2025-01-02T03:04:05Z", metadata["timestamp"])
+		require.Equal(t, map[string]any{
+			"type":    "application",
+			"bom-ref": "proj-1.0.0",
+			"name":    "proj",
+			"version": "1.0.0",
+		}, metadata["component"])
+		require.Len(t, doc["components"], 3)
+		require.ElementsMatch(t, []any{
+			map[string]any{"ref": "proj-1.0.0", "dependsOn": []any{"foo", "bar"}},
+			map[string]any{"ref": "foo", "dependsOn": []any{"pkg:golang/dep@v1"}},
+			map[string]any{"ref": "bar", "dependsOn": []any{"pkg:golang/dep@v1"}},
+		}, doc["dependencies"])
+	})
+
+	t.Run("spdx", func(t *testing.T) {
+		doc, err := readDocument(merged[1].Path)
+		require.NoError(t, err)
+		require.Equal(t, "spdx-json", detect(doc))
+		require.Equal(t, "proj-1.0.0", doc["name"])
+		require.Contains(t, doc["documentNamespace"], "https://goreleaser.com/spdxdocs/proj-1.0.0-")
+		require.Len(t, doc["packages"], 3)
+		var rels []string
+		for _, item := range list(doc["relationships"]) {
+			r := item.(map[string]any)
+			rels = append(rels, str(r["spdxElementId"])+" "+str(r["relationshipType"])+" "+str(r["relatedSpdxElement"]))
+		}
+		require.ElementsMatch(t, []string{
+			"SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-foo",
+			"SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-bar",
+			"SPDXRef-Package-foo DEPENDS_ON SPDXRef-Package-dep",
+		}, rels)
+	})
+
+	t.Run("spdx3", func(t *testing.T) {
+		doc, err := readDocument(merged[2].Path)
+		require.NoError(t, err)
+		require.Equal(t, "spdx3-json", detect(doc))
+		graph := list(doc["@graph"])
+		require.Len(t, graph, 5)
+		creation := graph[0].(map[string]any)
+		require.Equal(t, []any{"urn:agent", "urn:other-agent"}, creation["createdBy"])
+		document := graph[1].(map[string]any)
+		require.Equal(t, "SpdxDocument", document["type"])
+		require.Equal(t, []any{"urn:foo", "urn:bar"}, document["rootElement"])
+		require.Equal(t, []any{"urn:foo", "urn:dep", "urn:bar"}, document["element"])
+		require.Equal(t, []any{"core", "software", "simpleLicensing"}, document["profileConformance"])
+		for _, item := range graph[2:] {
+			require.Equal(t, creationInfoID, item.(map[string]any)["creationInfo"])
+		}
+	})
+}
+
+func TestMergeDisabled(t *testing.T) {
+	ctx := makeMergeContext(t)
+	for _, enabled := range []string{"", "false"} {
+		cfg := config.SBOM{Merge: config.SBOMMerge{Enabled: enabled}}
+		require.NoError(t, mergeTask(ctx, cfg, []*artifact.Artifact{{Path: "nope.json"}}))
+	}
+	require.Empty(t, ctx.Artifacts.List())
+}
+
+func TestMergeErrors(t *testing.T) {
+	t.Run("bad enabled template", func(t *testing.T) {
+		ctx := makeMergeContext(t)
+		cfg := config.SBOM{Merge: config.SBOMMerge{Enabled: "{{ .Nope }}"}}
+		testlib.RequireTemplateError(t, mergeTask(ctx, cfg, nil))
+	})
+	t.Run("bad name template", func(t *testing.T) {
+		ctx := makeMergeContext(t)
+		cfg := config.SBOM{Merge: config.SBOMMerge{Enabled: "true", NameTemplate: "{{ .Nope }}"}}
+		testlib.RequireTemplateError(t, mergeTask(ctx, cfg, nil))
+	})
+	t.Run("invalid document", func(t *testing.T) {
+		ctx := makeMergeContext(t)
+		path := filepath.Join(ctx.Config.Dist, "foo.sbom.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+		cfg := config.SBOM{Merge: config.SBOMMerge{Enabled: "true", NameTemplate: "merged"}}
+		require.ErrorContains(t, mergeTask(ctx, cfg, []*artifact.Artifact{
+			{Name: "foo.sbom.json", Path: path},
+		}), "merging sboms: foo.sbom.json")
+	})
+}
+
+func makeMergeContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	return testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "proj",
+		Dist:        tb.TempDir(),
+	},
+		testctx.WithVersion("1.0.0"),
+		testctx.WithDate(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		testctx.WithEnv(map[string]string{"MERGE": "true"}),
+	)
+}
@@ -48,12 +48,23 @@ func (Pipe) Dependencies(ctx *context.Context) []string {
 // Default sets the Pipes defaults.
 func (Pipe) Default(ctx *context.Context) error {
 	ids := ids.New("sboms")
+	merged := map[string]string{}
 	for i := range ctx.Config.SBOMs {
 		cfg := &ctx.Config.SBOMs[i]
 		if err := setConfigDefaults(cfg); err != nil {
 			return err
 		}
 		ids.Inc(cfg.ID)
+		if cfg.Merge.Enabled == "" {
+			continue
+		}
+		if other, ok := merged[cfg.Merge.NameTemplate]; ok {
+			return fmt.Errorf(
+				"sboms %q and %q have the same merge.name_template %q, their merged SBOMs would overwrite each other",
+				other, cfg.ID, cfg.Merge.NameTemplate,
+			)
+		}
+		merged[cfg.Merge.NameTemplate] = cfg.ID
 	}
 	return ids.Validate()
 }
@@ -65,20 +76,41 @@ func setConfigDefaults(cfg *config.SBOM) error {
 	if cfg.Artifacts == "" {
 		cfg.Artifacts = "archive"
 	}
+	for _, format := range cfg.Formats {
+		if _, ok := formats[format]; !ok {
+			return fmt.Errorf("invalid sbom format: %s", format)
+		}
+	}
 	if len(cfg.Documents) == 0 {
+		var name string
 		switch cfg.Artifacts {
 		case "binary":
-			cfg.Documents = []string{"{{ .Binary }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}.sbom.json"}
+			name = "{{ .Binary }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}"
 		case "any":
 			cfg.Documents = []string{}
 		default:
-			cfg.Documents = []string{"{{ .ArtifactName }}.sbom.json"}
+			name = "{{ .ArtifactName }}"
+		}
+		if name != "" && len(cfg.Formats) == 0 {
+			cfg.Documents = []string{name + ".sbom.json"}
+		}
+		for _, format := range cfg.Formats {
+			if name != "" {
+				cfg.Documents = append(cfg.Documents, name+formats[format].ext)
+			}
 		}
 	}
 	if cfg.Cmd == "syft" {
-		if len(cfg.Args) == 0 {
+		if len(cfg.Args) == 0 && len(cfg.Formats) == 0 {
 			cfg.Args = []string{"$artifact", "--output", "spdx-json=$document", "--enrich", "all"}
 		}
+		if len(cfg.Args) == 0 {
+			cfg.Args = []string{"$artifact"}
+			for i, format := range cfg.Formats {
+				cfg.Args = append(cfg.Args, "--output", fmt.Sprintf("%s=$document%d", formats[format].syft, i))
+			}
+			cfg.Args = append(cfg.Args, "--enrich", "all")
+		}
 		if len(cfg.Env) == 0 && (cfg.Artifacts == "source" || cfg.Artifacts == "archive") {
 			cfg.Env = []string{
 				"SYFT_FILE_METADATA_CATALOGER_ENABLED=true",
@@ -88,9 +120,15 @@ func setConfigDefaults(cfg *config.SBOM) error {
 	if cfg.ID == "" {
 		cfg.ID = "default"
 	}
-	if cfg.Artifacts != "any" && len(cfg.Documents) > 1 {
+	if len(cfg.Formats) > 0 && cfg.Artifacts != "any" && len(cfg.Documents) != len(cfg.Formats) {
+		return fmt.Errorf("sboms: got %d documents for %d formats, they must match", len(cfg.Documents), len(cfg.Formats))
+	}
+	if cfg.Artifacts != "any" && len(cfg.Documents) > 1 && len(cfg.Formats) == 0 {
 		return fmt.Errorf("multiple SBOM outputs when artifacts=%q is unsupported", cfg.Artifacts)
 	}
+	if cfg.Merge.Enabled != "" && cfg.Merge.NameTemplate == "" {
+		cfg.Merge.NameTemplate = defaultMergeNameTemplate
+	}
 	return nil
 }
 
@@ -132,7 +170,7 @@ func catalogTask(ctx *context.Context, cfg config.SBOM) error {
 		for _, newArtifact := range newArtifacts {
 			ctx.Artifacts.Add(newArtifact)
 		}
-		return nil
+		return mergeTask(ctx, cfg, newArtifacts)
 	default:
 		return fmt.Errorf("invalid list of artifacts to catalog: %s", cfg.Artifacts)
 	}
@@ -144,20 +182,26 @@ func catalogTask(ctx *context.Context, cfg config.SBOM) error {
 	if len(artifacts) == 0 {
 		log.Warn("no artifacts matching current filters")
 	}
-	return catalog(ctx, cfg, artifacts)
+	sboms, err := catalog(ctx, cfg, artifacts)
+	if err != nil {
+		return err
+	}
+	return mergeTask(ctx, cfg, sboms)
 }
 
-func catalog(ctx *context.Context, cfg config.SBOM, artifacts []*artifact.Artifact) error {
+func catalog(ctx *context.Context, cfg config.SBOM, artifacts []*artifact.Artifact) ([]*artifact.Artifact, error) {
+	var sboms []*artifact.Artifact
 	for _, a := range artifacts {
 		newArtifacts, err := catalogArtifact(ctx, cfg, a)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		for _, newArtifact := range newArtifacts {
 			ctx.Artifacts.Add(newArtifact)
 		}
+		sboms = append(sboms, newArtifacts...)
 	}
-	return nil
+	return sboms, nil
 }
 
 func subprocessDistPath(distDir string, path string) (string, error) {
@@ -237,7 +281,7 @@ func catalogArtifact(ctx *context.Context, cfg config.SBOM, a *artifact.Artifact
 
 	var artifacts []*artifact.Artifact
 
-	for _, path := range paths {
+	for idx, path := range paths {
 		if !filepath.IsAbs(path) {
 			path = filepath.Join(ctx.Config.Dist, path)
 		}
@@ -247,6 +291,11 @@ func catalogArtifact(ctx *context.Context, cfg config.SBOM, a *artifact.Artifact
 			return nil, fmt.Errorf("cataloging artifacts: failed to find SBOM artifact %q: %w", path, err)
 		}
 		for _, match := range matches {
+			if idx < len(cfg.Formats) && cfg.Formats[idx] == "spdx3-json" {
+				if err := convertToSPDX3(match); err != nil {
+					return nil, fmt.Errorf("cataloging artifacts: failed to convert %q to SPDX 3.0: %w", match, err)
+				}
+			}
 			artifacts = append(artifacts, &artifact.Artifact{
 				Type: artifact.SBOM,
 				Name: filepath.Base(match),
@@ -132,6 +132,46 @@ func TestSBOMCatalogDefault(t *testing.T) {
 				"SYFT_FILE_METADATA_CATALOGER_ENABLED=true",
 			},
 		},
+		{
+			configs: []config.SBOM{
+				{
+					Artifacts: "binary",
+					Formats:   []string{"cyclonedx-json", "spdx3-json"},
+				},
+			},
+			artifact: "binary",
+			cmd:      defaultCmd,
+			sboms: []string{
+				"{{ .Binary }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}.cdx.json",
+				"{{ .Binary }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}.spdx3.json",
+			},
+			args: []string{
+				"$artifact",
+				"--output", "cyclonedx-json=$document0",
+				"--output", "spdx-json=$document1",
+				"--enrich", "all",
+			},
+		},
+		{
+			// formats and documents must match
+			configs: []config.SBOM{
+				{
+					Artifacts: "binary",
+					Formats:   []string{"cyclonedx-json", "spdx-json"},
+					Documents: []string{"doc1"},
+				},
+			},
+			err: true,
+		},
+		{
+			configs: []config.SBOM{
+				{
+					Artifacts: "binary",
+					Formats:   []string{"spdx-tag-value"},
+				},
+			},
+			err: true,
+		},
 		{
 			// multiple documents are not allowed when artifacts != "any"
 			configs: []config.SBOM{
@@ -168,6 +208,31 @@ func TestSBOMCatalogDefault(t *testing.T) {
 	}
 }
 
+func TestSBOMMergeDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		SBOMs: []config.SBOM{
+			{},
+			{ID: "merged", Merge: config.SBOMMerge{Enabled: "true"}},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Empty(t, ctx.Config.SBOMs[0].Merge.NameTemplate)
+	require.Equal(t, defaultMergeNameTemplate, ctx.Config.SBOMs[1].Merge.NameTemplate)
+}
+
+func TestSBOMMergeDuplicateName(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		SBOMs: []config.SBOM{
+			{ID: "archives", Merge: config.SBOMMerge{Enabled: "true"}},
+			{ID: "binaries", Artifacts: "binary", Merge: config.SBOMMerge{Enabled: "true"}},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), `sboms "archives" and "binaries" have the same merge.name_template "{{ .ProjectName }}_{{ .Version }}", their merged SBOMs would overwrite each other`)
+
+	ctx.Config.SBOMs[1].Merge.NameTemplate = "{{ .ProjectName }}_{{ .Version }}_binaries"
+	require.NoError(t, Pipe{}.Default(ctx))
+}
+
 func TestSBOMCatalogInvalidArtifacts(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		SBOMs: []config.SBOM{{Artifacts: "foo"}},
@@ -551,6 +616,58 @@ func TestSBOMCatalogRealSyft(t *testing.T) {
 	require.Equal(t, "fake_bin_1.0.0_linux_amd64.sbom.json", sboms[0].Name)
 }
 
+func TestSBOMCatalogFormats(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses a bash script")
+	fakesyft, err := filepath.Abs("./testdata/fakesyftformats")
+	require.NoError(t, err)
+
+	tmp := t.TempDir()
+	bin := filepath.Join(tmp, "fake_bin")
+	require.NoError(t, os.WriteFile(bin, []byte("fake"), 0o644))
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "fake",
+		Dist:        tmp,
+		SBOMs: []config.SBOM{
+			{
+				Artifacts: "binary",
+				Formats:   []string{"cyclonedx-json", "spdx3-json"},
+				Merge:     config.SBOMMerge{Enabled: "true"},
+			},
+		},
+	}, testctx.WithVersion("1.0.0"))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "fake_bin",
+		Path:   bin,
+		Goos:   "linux",
+		Goarch: "amd64",
+		Type:   artifact.UploadableBinary,
+		Extra: map[string]any{
+			artifact.ExtraID:     "test",
+			artifact.ExtraBinary: "fake_bin",
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	ctx.Config.SBOMs[0].Cmd = fakesyft
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	var names []string
+	for _, sbom := range ctx.Artifacts.Filter(artifact.ByType(artifact.SBOM)).List() {
+		names = append(names, sbom.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"fake_bin_1.0.0_linux_amd64.cdx.json",
+		"fake_bin_1.0.0_linux_amd64.spdx3.json",
+		"fake_1.0.0.cdx.json",
+		"fake_1.0.0.spdx3.json",
+	}, names)
+
+	doc, err := readDocument(filepath.Join(tmp, "fake_bin_1.0.0_linux_amd64.spdx3.json"))
+	require.NoError(t, err)
+	require.Equal(t, "spdx3-json", detect(doc))
+}
+
 func testSBOMCataloging(
 	tb testing.TB,
 	ctx *context.Context,
@@ -3,6 +3,7 @@ package scoop
 
 import (
 	"bytes"
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -149,6 +150,8 @@ func doRun(ctx *context.Context, scoop config.Scoop, cl client.ReleaseURLTemplat
 		&scoop.Description,
 		&scoop.Homepage,
 		&scoop.SkipUpload,
+		&scoop.Autoupdate.GitHub,
+		&scoop.Autoupdate.URL,
 	); err != nil {
 		return err
 	}
@@ -274,7 +277,7 @@ func doPublish(ctx *context.Context, manifest *artifact.Artifact, cl client.Clie
 		return errors.New("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, commitMessage, scoop.Repository.PullRequest.Draft)
+	return pcl.OpenPullRequest(ctx, base, repo, commitMessage, scoop.Repository.PullRequest.Body, scoop.Repository.PullRequest.Draft)
 }
 
 // Manifest represents a scoop.sh App Manifest.
@@ -290,6 +293,32 @@ type Manifest struct {
 	PostInstall  []string            `json:"post_install,omitempty"` // An array of strings, of the commands to be executed after an application is installed.
 	Depends      []string            `json:"depends,omitempty"`      // A string or an array of strings.
 	Shortcuts    [][]string          `json:"shortcuts,omitempty"`    // A two-dimensional array of string, specifies the shortcut values to make available in the startmenu.
+	Checkver     *Checkver           `json:"checkver,omitempty"`     // How to check for new versions of the app.
+	Autoupdate   *Autoupdate         `json:"autoupdate,omitempty"`   // How to update the manifest to a new version of the app.
+}
+
+// Checkver represents how scoop checks for new versions of an app.
+type Checkver struct {
+	GitHub string `json:"github,omitempty"` // URL of the GitHub repository
+	URL    string `json:"url,omitempty"`    // URL of a page containing the version
+	Regex  string `json:"regex,omitempty"`  // regex to extract the version from the page
+}
+
+// Autoupdate represents how scoop updates the manifest to a new version.
+type Autoupdate struct {
+	Architecture map[string]AutoupdateResource `json:"architecture"`
+}
+
+// AutoupdateResource represents the url of an architecture, with $version
+// placeholders.
+type AutoupdateResource struct {
+	URL  string          `json:"url"`            // URL to the archive
+	Hash *AutoupdateHash `json:"hash,omitempty"` // where to get the archive checksum from
+}
+
+// AutoupdateHash represents where to get the checksum of an archive from.
+type AutoupdateHash struct {
+	URL string `json:"url"` // URL to the checksums file
 }
 
 // Resource represents a combination of a url and a binary name for an architecture.
@@ -323,6 +352,17 @@ func dataFor(ctx *context.Context, scoop config.Scoop, cl client.ReleaseURLTempl
 		Shortcuts:    scoop.Shortcuts,
 	}
 
+	if scoop.Autoupdate.Enabled {
+		checkver, err := checkverFor(ctx, scoop.Autoupdate)
+		if err != nil {
+			return manifest, err
+		}
+		manifest.Checkver = checkver
+		manifest.Autoupdate = &Autoupdate{
+			Architecture: map[string]AutoupdateResource{},
+		}
+	}
+
 	if scoop.URLTemplate == "" {
 		url, err := cl.ReleaseURLTemplate(ctx)
 		if err != nil {
@@ -370,11 +410,73 @@ func dataFor(ctx *context.Context, scoop config.Scoop, cl client.ReleaseURLTempl
 			Bin:  binaries(*artifact),
 			Hash: sum,
 		}
+
+		if manifest.Autoupdate != nil {
+			resource := AutoupdateResource{
+				URL: withVersionPlaceholder(ctx, url),
+			}
+			if checksum := checksumFor(ctx, artifact); checksum != nil {
+				sumURL, err := tmpl.New(ctx).WithArtifact(checksum).Apply(scoop.URLTemplate)
+				if err != nil {
+					return manifest, err
+				}
+				resource.Hash = &AutoupdateHash{
+					URL: withVersionPlaceholder(ctx, sumURL),
+				}
+			}
+			manifest.Autoupdate.Architecture[arch] = resource
+		}
 	}
 
 	return manifest, nil
 }
 
+// checkverFor returns how to check for new versions, defaulting to the GitHub
+// repository being released to.
+func checkverFor(ctx *context.Context, cfg config.ScoopAutoupdate) (*Checkver, error) {
+	if cfg.GitHub == "" && cfg.URL == "" {
+		if ctx.TokenType != context.TokenTypeGitHub {
+			return nil, errors.New("scoop: autoupdate requires either github or url to be set when not releasing to GitHub")
+		}
+		downloadURL, err := tmpl.New(ctx).Apply(ctx.Config.GitHubURLs.Download)
+		if err != nil {
+			return nil, err
+		}
+		cfg.GitHub = fmt.Sprintf(
+			"%s/%s/%s",
+			strings.TrimSuffix(cmp.Or(downloadURL, "https://github.com"), "/"),
+			ctx.Config.Release.GitHub.Owner,
+			ctx.Config.Release.GitHub.Name,
+		)
+	}
+	return &Checkver{
+		GitHub: cfg.GitHub,
+		URL:    cfg.URL,
+		Regex:  cfg.Regex,
+	}, nil
+}
+
+// checksumFor returns the checksums file containing the checksum of the given
+// archive, if any.
+func checksumFor(ctx *context.Context, archive *artifact.Artifact) *artifact.Artifact {
+	for _, checksum := range ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List() {
+		of := artifact.ExtraOr(*checksum, artifact.ExtraChecksumOf, "")
+		if of == "" || of == archive.Path {
+			return checksum
+		}
+	}
+	return nil
+}
+
+// withVersionPlaceholder replaces the current version with scoop's $version
+// placeholder.
+func withVersionPlaceholder(ctx *context.Context, s string) string {
+	if ctx.Version == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, ctx.Version, "$version")
+}
+
 func binaries(a artifact.Artifact) []string {
 	//nolint:prealloc
 	var result []string
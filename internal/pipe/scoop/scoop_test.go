@@ -1,6 +1,7 @@
 package scoop
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -922,6 +923,135 @@ func TestRunPipePullRequest(t *testing.T) {
 	golden.RequireEqualJSON(t, []byte(client.Content))
 }
 
+func TestRunPipeAutoupdate(t *testing.T) {
+	ctx := makeAutoupdateContext(t, testctx.GitHubTokenType)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo_1.2.1_checksums.txt",
+		Path: filepath.Join(ctx.Config.Dist, "foo_1.2.1_checksums.txt"),
+		Type: artifact.Checksum,
+	})
+
+	client := client.NewMock()
+	require.NoError(t, runAll(ctx, client))
+	require.NoError(t, publishAll(ctx, client))
+	golden.RequireEqualJSON(t, []byte(client.Content))
+}
+
+func TestRunPipeAutoupdateCustom(t *testing.T) {
+	t.Run("url", func(t *testing.T) {
+		ctx := makeAutoupdateContext(t)
+		ctx.Config.Scoops[0].Autoupdate.URL = "https://{{ .ProjectName }}.com/version"
+		ctx.Config.Scoops[0].Autoupdate.Regex = `v([\d.]+)`
+		require.NoError(t, runAll(ctx, client.NewMock()))
+
+		manifest := readManifest(t, ctx)
+		require.Equal(t, &Checkver{
+			URL:   "https://foo.com/version",
+			Regex: `v([\d.]+)`,
+		}, manifest.Checkver)
+		require.Equal(t, AutoupdateResource{
+			URL: "https://dummyhost/download/v$version/foo_$version_windows_amd64.zip",
+		}, manifest.Autoupdate.Architecture["64bit"])
+	})
+	t.Run("github", func(t *testing.T) {
+		ctx := makeAutoupdateContext(t)
+		ctx.Config.Scoops[0].Autoupdate.GitHub = "https://github.com/{{ .ProjectName }}/{{ .ProjectName }}"
+		require.NoError(t, runAll(ctx, client.NewMock()))
+		require.Equal(t, &Checkver{
+			GitHub: "https://github.com/foo/foo",
+		}, readManifest(t, ctx).Checkver)
+	})
+	t.Run("split checksums", func(t *testing.T) {
+		ctx := makeAutoupdateContext(t, testctx.GitHubTokenType)
+		for _, archive := range ctx.Artifacts.List() {
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: archive.Name + ".sha256",
+				Path: archive.Path + ".sha256",
+				Type: artifact.Checksum,
+				Extra: map[string]any{
+					artifact.ExtraChecksumOf: archive.Path,
+				},
+			})
+		}
+		require.NoError(t, runAll(ctx, client.NewMock()))
+		require.Equal(t, &AutoupdateHash{
+			URL: "https://dummyhost/download/v$version/foo_$version_windows_arm64.zip.sha256",
+		}, readManifest(t, ctx).Autoupdate.Architecture["arm64"].Hash)
+	})
+	t.Run("not github", func(t *testing.T) {
+		ctx := makeAutoupdateContext(t, testctx.GitLabTokenType)
+		require.EqualError(t, runAll(ctx, client.NewMock()), "scoop: autoupdate requires either github or url to be set when not releasing to GitHub")
+	})
+	t.Run("bad template", func(t *testing.T) {
+		ctx := makeAutoupdateContext(t)
+		ctx.Config.Scoops[0].Autoupdate.URL = "{{ .Nope }}"
+		testlib.RequireTemplateError(t, runAll(ctx, client.NewMock()))
+	})
+}
+
+func readManifest(tb testing.TB, ctx *context.Context) Manifest {
+	tb.Helper()
+	manifests := ctx.Artifacts.Filter(artifact.ByType(artifact.ScoopManifest)).List()
+	require.Len(tb, manifests, 1)
+	bts, err := os.ReadFile(manifests[0].Path)
+	require.NoError(tb, err)
+	var manifest Manifest
+	require.NoError(tb, json.Unmarshal(bts, &manifest))
+	return manifest
+}
+
+func makeAutoupdateContext(tb testing.TB, opts ...testctx.Opt) *context.Context {
+	tb.Helper()
+	directory := tb.TempDir()
+	ctx := testctx.WrapWithCfg(tb.Context(),
+		config.Project{
+			Dist:        directory,
+			ProjectName: "foo",
+			Release: config.Release{
+				GitHub: config.Repo{
+					Owner: "foo",
+					Name:  "bar",
+				},
+			},
+			Scoops: []config.Scoop{{
+				Homepage:    "https://foo.com",
+				Description: "Fake desc",
+				Repository: config.RepoRef{
+					Owner: "foo",
+					Name:  "scoop-bucket",
+				},
+				Autoupdate: config.ScoopAutoupdate{
+					Enabled: true,
+				},
+			}},
+		},
+		append([]testctx.Opt{
+			testctx.WithVersion("1.2.1"),
+			testctx.WithCurrentTag("v1.2.1"),
+		}, opts...)...)
+	require.NoError(tb, Pipe{}.Default(ctx))
+
+	for _, goarch := range []string{"amd64", "arm64"} {
+		name := "foo_1.2.1_windows_" + goarch + ".zip"
+		path := filepath.Join(directory, name)
+		require.NoError(tb, os.WriteFile(path, nil, 0o644))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    name,
+			Path:    path,
+			Goos:    "windows",
+			Goarch:  goarch,
+			Goamd64: "v1",
+			Type:    artifact.UploadableArchive,
+			Extra: map[string]any{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraFormat:   "zip",
+				artifact.ExtraBinaries: []string{"foo.exe"},
+			},
+		})
+	}
+	return ctx
+}
+
 func Test_buildManifest(t *testing.T) {
 	directory := t.TempDir()
 	file := filepath.Join(directory, "archive")
@@ -40,6 +40,9 @@ func (Pipe) Skip(ctx *context.Context) bool {
 func (Pipe) Dependencies(ctx *context.Context) []string {
 	var cmds []string
 	for _, s := range ctx.Config.Signs {
+		if isNative(s) {
+			continue
+		}
 		cmds = append(cmds, s.Cmd)
 	}
 	return cmds
@@ -61,14 +64,17 @@ func (Pipe) Default(ctx *context.Context) error {
 	ids := ids.New("signs")
 	for i := range ctx.Config.Signs {
 		cfg := &ctx.Config.Signs[i]
-		if cfg.Cmd == "" {
+		if err := validateNative(cfg, "${artifact}"); err != nil {
+			return err
+		}
+		if cfg.Cmd == "" && !isNative(*cfg) {
 			// gpgPath is either "gpg" (default) or the user's git config gpg.program value
 			cfg.Cmd = gpgPath()
 		}
 		if cfg.Signature == "" {
 			cfg.Signature = "${artifact}.sig"
 		}
-		if len(cfg.Args) == 0 {
+		if len(cfg.Args) == 0 && !isNative(*cfg) {
 			cfg.Args = []string{"--output", "$signature", "--detach-sig", "$artifact"}
 		}
 		if cfg.Artifacts == "" {
@@ -116,6 +122,8 @@ func (Pipe) Run(ctx *context.Context) error {
 				filters = append(filters, artifact.ByType(artifact.SBOM))
 			case "package":
 				filters = append(filters, artifact.ByType(artifact.LinuxPackage))
+			case "installer":
+				filters = append(filters, artifact.ByType(artifact.MSI))
 			case "none": // TODO(caarlos0): this is not very useful, lets remove it.
 				return pipe.ErrSkipSignEnabled
 			default:
@@ -138,11 +146,13 @@ func sign(ctx *context.Context, cfg config.Sign, artifacts []*artifact.Artifact)
 		log.Warn("no artifacts matching the given filters found")
 		return nil
 	}
+	signers := kmsSigners{}
+	defer signers.Close()
 	for _, a := range artifacts {
 		if err := a.Refresh(); err != nil {
 			return err
 		}
-		artifacts, err := signone(ctx, cfg, a)
+		artifacts, err := signone(ctx, cfg, signers, a)
 		if err != nil {
 			return err
 		}
@@ -176,7 +186,7 @@ func tmplPath(ctx *context.Context, env map[string]string, a *artifact.Artifact,
 	return relativeToDist(ctx.Config.Dist, result)
 }
 
-func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*artifact.Artifact, error) {
+func signone(ctx *context.Context, cfg config.Sign, signers kmsSigners, art *artifact.Artifact) ([]*artifact.Artifact, error) {
 	env := ctx.Env.Copy()
 	env["artifactName"] = art.Name // shouldn't be used
 	env["artifact"] = art.Path
@@ -196,6 +206,16 @@ func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*
 	}
 	env["signature"] = name
 
+	if isNative(cfg) {
+		log.WithField("artifact", art.Name).
+			WithField("signature", name).
+			Info("signing")
+		if err := signNative(ctx, cfg, signers, env, art, name); err != nil {
+			return nil, fmt.Errorf("sign failed: %s: %w", art.Name, err)
+		}
+		return signatureArtifacts(ctx, cfg, art, env)
+	}
+
 	cert, err := tmplPath(ctx, env, art, cfg.Certificate)
 	if err != nil {
 		return nil, fmt.Errorf("sign failed: %s: %w", art.Name, err)
@@ -248,6 +268,7 @@ func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*
 	// #nosec
 	cmd := exec.CommandContext(ctx, cfg.Cmd, args...)
 	cmd.Env = env.Strings()
+	ctx.InToto().Record(cmd.Args)
 
 	var b bytes.Buffer
 	w := gio.Safe(&b)
@@ -269,15 +290,21 @@ func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*
 		)
 	}
 
+	return signatureArtifacts(ctx, cfg, art, env)
+}
+
+// signatureArtifacts returns the signature and certificate artifacts created
+// by signing the given artifact.
+func signatureArtifacts(ctx *context.Context, cfg config.Sign, art *artifact.Artifact, env context.Env) ([]*artifact.Artifact, error) {
 	var result []*artifact.Artifact
 
 	// re-execute template results, using artifact desc as artifact so they eval to the actual needed file desc.
 	env["artifact"] = art.Name
-	name, err = tmpl.New(ctx).WithArtifact(art).WithEnv(env).Apply(expand(cfg.Signature, env))
+	name, err := tmpl.New(ctx).WithArtifact(art).WithEnv(env).Apply(expand(cfg.Signature, env))
 	if err != nil {
 		return nil, fmt.Errorf("sign failed: %s: %w", art.Name, err)
 	}
-	cert, err = tmpl.New(ctx).WithArtifact(art).WithEnv(env).Apply(expand(cfg.Certificate, env))
+	cert, err := tmpl.New(ctx).WithArtifact(art).WithEnv(env).Apply(expand(cfg.Certificate, env))
 	if err != nil {
 		return nil, fmt.Errorf("sign failed: %s: %w", art.Name, err)
 	}
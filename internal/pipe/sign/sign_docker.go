@@ -1,6 +1,7 @@
 package sign
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
@@ -33,6 +34,9 @@ func (DockerPipe) Default(ctx *context.Context) error {
 	ids := ids.New("docker_signs")
 	for i := range ctx.Config.DockerSigns {
 		cfg := &ctx.Config.DockerSigns[i]
+		if isNative(*cfg) {
+			return errors.New("docker_signs: minisign, signify and kms can't sign docker images")
+		}
 		if cfg.Cmd == "" {
 			cfg.Cmd = "cosign"
 		}
@@ -0,0 +1,488 @@
+package sign
+
+import (
+	"bytes"
+	stdctx "context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	gcpkms "cloud.google.com/go/kms/apiv1"
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azkeys"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// kmsSigner signs digests with a key that never leaves the KMS.
+type kmsSigner interface {
+	crypto.Signer
+	io.Closer
+}
+
+// kmsProviders maps the key URI schemes to their signers.
+var kmsProviders = map[string]func(ctx stdctx.Context, key string) (kmsSigner, error){
+	"awskms":   newAWSKMS,
+	"gcpkms":   newGCPKMS,
+	"azurekms": newAzureKMS,
+}
+
+// kmsSigners caches the signers of a sign configuration by key, so each key
+// is loaded from its KMS once instead of once per artifact.
+type kmsSigners map[string]kmsSigner
+
+func (s kmsSigners) get(ctx stdctx.Context, key string) (kmsSigner, error) {
+	if signer, ok := s[key]; ok {
+		return signer, nil
+	}
+	scheme, rest, _ := strings.Cut(key, "://")
+	newSigner, ok := kmsProviders[scheme]
+	if !ok {
+		return nil, fmt.Errorf("invalid key %q: must start with awskms://, gcpkms:// or azurekms://", key)
+	}
+	signer, err := newSigner(ctx, rest)
+	if err != nil {
+		return nil, err
+	}
+	s[key] = signer
+	return signer, nil
+}
+
+// Close closes all the cached signers.
+func (s kmsSigners) Close() error {
+	var errs []error
+	for _, signer := range s {
+		errs = append(errs, signer.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// signKMS creates an OpenPGP detached signature of the artifact, as
+// `gpg --detach-sig` does, using a key kept in a cloud KMS.
+func signKMS(ctx *context.Context, cfg config.SignKMS, signers kmsSigners, art *artifact.Artifact, signature string) error {
+	if cfg.Key == "" {
+		return errors.New("kms: key is required")
+	}
+	created, err := kmsCreated(cfg.Created)
+	if err != nil {
+		return fmt.Errorf("kms: %w", err)
+	}
+
+	signer, err := signers.get(ctx, cfg.Key)
+	if err != nil {
+		return fmt.Errorf("kms: %w", err)
+	}
+	hash, err := kmsHash(cfg.Hash, signer.Public())
+	if err != nil {
+		return fmt.Errorf("kms: %w", err)
+	}
+	pub, err := kmsPublicKey(created, signer.Public())
+	if err != nil {
+		return fmt.Errorf("kms: %w", err)
+	}
+
+	f, err := os.Open(art.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := hash.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	priv := &packet.PrivateKey{PublicKey: *pub, PrivateKey: signer}
+	sig := &packet.Signature{
+		SigType:      packet.SigTypeBinary,
+		PubKeyAlgo:   priv.PubKeyAlgo,
+		Hash:         hash,
+		CreationTime: ctx.Date,
+		IssuerKeyId:  &priv.KeyId,
+	}
+	if err := sig.Sign(h, priv, nil); err != nil {
+		return fmt.Errorf("kms: %w", err)
+	}
+
+	out, err := os.Create(signature)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if !cfg.Armor {
+		if err := sig.Serialize(out); err != nil {
+			return err
+		}
+		return out.Close()
+	}
+	w, err := armor.Encode(out, "PGP SIGNATURE", nil)
+	if err != nil {
+		return err
+	}
+	if err := sig.Serialize(w); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// ecdsaCurveOIDs are the OpenPGP OIDs of the NIST curves, see RFC 9580,
+// section 9.2.
+var ecdsaCurveOIDs = map[elliptic.Curve][]byte{
+	elliptic.P256(): {0x2a, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07},
+	elliptic.P384(): {0x2b, 0x81, 0x04, 0x00, 0x22},
+	elliptic.P521(): {0x2b, 0x81, 0x04, 0x00, 0x23},
+}
+
+// kmsPublicKey returns the OpenPGP v4 public key of the KMS key, created at
+// the given time.
+func kmsPublicKey(created time.Time, pub crypto.PublicKey) (*packet.PublicKey, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return packet.NewRSAPublicKey(created, pub), nil
+	case *ecdsa.PublicKey:
+		// go-crypto only builds ECDSA keys from its own key type, so we
+		// serialize the public key packet (RFC 9580, section 5.5.2) and parse
+		// it back.
+		oid, ok := ecdsaCurveOIDs[pub.Curve]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve: %s", pub.Curve.Params().Name)
+		}
+		point, err := pub.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		var body bytes.Buffer
+		body.WriteByte(4)
+		_ = binary.Write(&body, binary.BigEndian, uint32(created.Unix()))
+		body.WriteByte(byte(packet.PubKeyAlgoECDSA))
+		body.WriteByte(byte(len(oid)))
+		body.Write(oid)
+		// the point is uncompressed, so it starts with 0x04, which has 3
+		// significant bits.
+		_ = binary.Write(&body, binary.BigEndian, uint16((len(point)-1)*8+3))
+		body.Write(point)
+
+		var b bytes.Buffer
+		b.WriteByte(0x99) // old format public key packet, 2 bytes length.
+		_ = binary.Write(&b, binary.BigEndian, uint16(body.Len()))
+		b.Write(body.Bytes())
+		p, err := packet.Read(&b)
+		if err != nil {
+			return nil, err
+		}
+		pk, ok := p.(*packet.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("invalid public key packet: %T", p)
+		}
+		return pk, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %T", pub)
+	}
+}
+
+// kmsCreated parses the creation time of the OpenPGP key, which is part of
+// its fingerprint, either as a unix timestamp or as RFC 3339.
+func kmsCreated(s string) (time.Time, error) {
+	if s == "" {
+		return time.Unix(0, 0), nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(n, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid created: %q: must be a unix timestamp or RFC 3339", s)
+	}
+	return t, nil
+}
+
+// kmsHash returns the hash to sign with, defaulting to the one that matches
+// the key.
+func kmsHash(name string, pub crypto.PublicKey) (crypto.Hash, error) {
+	var hash crypto.Hash
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		hash = crypto.SHA256
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			hash = crypto.SHA256
+		case elliptic.P384():
+			hash = crypto.SHA384
+		case elliptic.P521():
+			hash = crypto.SHA512
+		default:
+			return 0, fmt.Errorf("unsupported curve: %s", pub.Curve.Params().Name)
+		}
+	default:
+		return 0, fmt.Errorf("unsupported key type: %T", pub)
+	}
+	switch name {
+	case "":
+		return hash, nil
+	case "sha256":
+		return crypto.SHA256, nil
+	case "sha384":
+		return crypto.SHA384, nil
+	case "sha512":
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported hash: %q", name)
+	}
+}
+
+// hashBits returns the size in bits of the given SHA-2 hash, as used in
+// the KMS algorithm names.
+func hashBits(hash crypto.Hash) (string, error) {
+	switch hash {
+	case crypto.SHA256, crypto.SHA384, crypto.SHA512:
+		return strconv.Itoa(hash.Size() * 8), nil
+	default:
+		return "", fmt.Errorf("unsupported hash: %s", hash)
+	}
+}
+
+// awsKMS signs with an AWS KMS asymmetric key.
+//
+// The key is given as awskms:///<key id, key arn, alias name or alias arn>.
+type awsKMS struct {
+	ctx    stdctx.Context
+	client *kms.Client
+	key    string
+	pub    crypto.PublicKey
+}
+
+func newAWSKMS(ctx stdctx.Context, key string) (kmsSigner, error) {
+	key = strings.TrimPrefix(key, "/")
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := kms.NewFromConfig(cfg)
+	out, err := client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(key)})
+	if err != nil {
+		return nil, err
+	}
+	pub, err := x509.ParsePKIXPublicKey(out.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &awsKMS{ctx: ctx, client: client, key: key, pub: pub}, nil
+}
+
+func (k *awsKMS) Public() crypto.PublicKey { return k.pub }
+
+func (k *awsKMS) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	bits, err := hashBits(opts.HashFunc())
+	if err != nil {
+		return nil, err
+	}
+	alg := types.SigningAlgorithmSpec("ECDSA_SHA_" + bits)
+	if _, ok := k.pub.(*rsa.PublicKey); ok {
+		alg = types.SigningAlgorithmSpec("RSASSA_PKCS1_V1_5_SHA_" + bits)
+	}
+	out, err := k.client.Sign(k.ctx, &kms.SignInput{
+		KeyId:            aws.String(k.key),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: alg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Signature, nil
+}
+
+func (k *awsKMS) Close() error { return nil }
+
+// gcpKMS signs with a Google Cloud KMS asymmetric key version.
+//
+// The key is given as
+// gcpkms://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>.
+type gcpKMS struct {
+	ctx    stdctx.Context
+	client *gcpkms.KeyManagementClient
+	name   string
+	pub    crypto.PublicKey
+}
+
+func newGCPKMS(ctx stdctx.Context, name string) (kmsSigner, error) {
+	client, err := gcpkms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: name})
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(resp.GetPem()))
+	if block == nil {
+		_ = client.Close()
+		return nil, errors.New("invalid public key")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return &gcpKMS{ctx: ctx, client: client, name: name, pub: pub}, nil
+}
+
+func (k *gcpKMS) Public() crypto.PublicKey { return k.pub }
+
+func (k *gcpKMS) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	d := &kmspb.Digest{}
+	switch opts.HashFunc() {
+	case crypto.SHA256:
+		d.Digest = &kmspb.Digest_Sha256{Sha256: digest}
+	case crypto.SHA384:
+		d.Digest = &kmspb.Digest_Sha384{Sha384: digest}
+	case crypto.SHA512:
+		d.Digest = &kmspb.Digest_Sha512{Sha512: digest}
+	default:
+		return nil, fmt.Errorf("unsupported hash: %s", opts.HashFunc())
+	}
+	resp, err := k.client.AsymmetricSign(k.ctx, &kmspb.AsymmetricSignRequest{
+		Name:   k.name,
+		Digest: d,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetSignature(), nil
+}
+
+func (k *gcpKMS) Close() error { return k.client.Close() }
+
+// azureKMS signs with an Azure Key Vault key.
+//
+// The key is given as azurekms://<vault host>/<key name>[/<version>].
+type azureKMS struct {
+	ctx     stdctx.Context
+	client  *azkeys.Client
+	name    string
+	version string
+	pub     crypto.PublicKey
+}
+
+func newAzureKMS(ctx stdctx.Context, key string) (kmsSigner, error) {
+	host, path, _ := strings.Cut(key, "/")
+	name, version, _ := strings.Cut(path, "/")
+	if host == "" || name == "" {
+		return nil, fmt.Errorf("invalid key: %q: must be azurekms://<vault host>/<key name>[/<version>]", key)
+	}
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, err
+	}
+	client, err := azkeys.NewClient("https://"+host, cred, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.GetKey(ctx, name, version, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Key == nil {
+		return nil, errors.New("invalid public key")
+	}
+	pub, err := azurePublicKey(resp.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &azureKMS{ctx: ctx, client: client, name: name, version: version, pub: pub}, nil
+}
+
+// azurePublicKey converts the JSON web key of an RSA or EC key to a public
+// key.
+func azurePublicKey(key *azkeys.JSONWebKey) (crypto.PublicKey, error) {
+	if key.Kty == nil {
+		return nil, errors.New("invalid public key")
+	}
+	switch *key.Kty {
+	case azkeys.KeyTypeRSA, azkeys.KeyTypeRSAHSM:
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(key.N),
+			E: int(new(big.Int).SetBytes(key.E).Int64()),
+		}, nil
+	case azkeys.KeyTypeEC, azkeys.KeyTypeECHSM:
+		if key.Crv == nil {
+			return nil, errors.New("invalid public key")
+		}
+		var curve elliptic.Curve
+		switch *key.Crv {
+		case azkeys.CurveNameP256:
+			curve = elliptic.P256()
+		case azkeys.CurveNameP384:
+			curve = elliptic.P384()
+		case azkeys.CurveNameP521:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", *key.Crv)
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(key.X),
+			Y:     new(big.Int).SetBytes(key.Y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", *key.Kty)
+	}
+}
+
+func (k *azureKMS) Public() crypto.PublicKey { return k.pub }
+
+func (k *azureKMS) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	bits, err := hashBits(opts.HashFunc())
+	if err != nil {
+		return nil, err
+	}
+	_, isRSA := k.pub.(*rsa.PublicKey)
+	alg := azkeys.SignatureAlgorithm("ES" + bits)
+	if isRSA {
+		alg = azkeys.SignatureAlgorithm("RS" + bits)
+	}
+	resp, err := k.client.Sign(k.ctx, k.name, k.version, azkeys.SignParameters{
+		Algorithm: &alg,
+		Value:     digest,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if isRSA {
+		return resp.Result, nil
+	}
+	// Key Vault returns ECDSA signatures as r||s, while crypto.Signer
+	// returns them ASN.1 encoded.
+	n := len(resp.Result) / 2
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		new(big.Int).SetBytes(resp.Result[:n]),
+		new(big.Int).SetBytes(resp.Result[n:]),
+	})
+}
+
+func (k *azureKMS) Close() error { return nil }
@@ -0,0 +1,234 @@
+package sign
+
+import (
+	stdctx "context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+// testKMS signs with a local key, as a KMS would.
+type testKMS struct {
+	crypto.Signer
+	loaded int
+	closed bool
+}
+
+func (k *testKMS) Close() error {
+	k.closed = true
+	return nil
+}
+
+func registerTestKMS(tb testing.TB, signer crypto.Signer) *testKMS {
+	tb.Helper()
+	k := &testKMS{Signer: signer}
+	kmsProviders["testkms"] = func(_ stdctx.Context, key string) (kmsSigner, error) {
+		require.Equal(tb, "my-key", key)
+		k.loaded++
+		return k, nil
+	}
+	tb.Cleanup(func() { delete(kmsProviders, "testkms") })
+	return k
+}
+
+func TestKMSDefault(t *testing.T) {
+	ctx := makeNativeContext(t, config.Sign{
+		KMS: &config.SignKMS{Key: "awskms:///alias/release"},
+	}, "")
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "${artifact}.sig", ctx.Config.Signs[0].Signature)
+	require.Empty(t, ctx.Config.Signs[0].Cmd)
+	require.Empty(t, ctx.Config.Signs[0].Args)
+	require.Empty(t, Pipe{}.Dependencies(ctx))
+}
+
+func TestKMS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		signer crypto.Signer
+		hash   string
+		expect crypto.Hash
+	}{
+		"rsa":             {rsaKey, "", crypto.SHA256},
+		"rsa sha512":      {rsaKey, "sha512", crypto.SHA512},
+		"ecdsa p256":      {p256, "", crypto.SHA256},
+		"ecdsa p384":      {p384, "", crypto.SHA384},
+		"ecdsa p384 s512": {p384, "sha512", crypto.SHA512},
+	} {
+		t.Run(name, func(t *testing.T) {
+			k := registerTestKMS(t, tt.signer)
+			ctx := makeNativeContext(t, config.Sign{
+				Artifacts: "checksum",
+				KMS: &config.SignKMS{
+					Key:     "testkms://my-key",
+					Created: "{{ .Env.CREATED }}",
+					Hash:    tt.hash,
+				},
+			}, "")
+			ctx.Env["CREATED"] = "2024-01-02T03:04:05Z"
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+			require.True(t, k.closed)
+
+			sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+			require.Len(t, sigs, 1)
+			require.Equal(t, "checksums.txt.sig", sigs[0].Name)
+
+			f, err := os.Open(sigs[0].Path)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = f.Close() })
+			sig := readSignature(t, f)
+			require.Equal(t, tt.expect, sig.Hash)
+			require.Equal(t, ctx.Date.Unix(), sig.CreationTime.Unix())
+			verifyKMSSignature(t, tt.signer.Public(), time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), sig)
+		})
+	}
+}
+
+func TestKMSArmor(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	registerTestKMS(t, key)
+	ctx := makeNativeContext(t, config.Sign{
+		Artifacts: "checksum",
+		Signature: "${artifact}.asc",
+		KMS: &config.SignKMS{
+			Key:     "testkms://my-key",
+			Created: "1700000000",
+			Armor:   true,
+		},
+	}, "")
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+	require.Len(t, sigs, 1)
+	require.Equal(t, "checksums.txt.asc", sigs[0].Name)
+
+	f, err := os.Open(sigs[0].Path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+	block, err := armor.Decode(f)
+	require.NoError(t, err)
+	require.Equal(t, "PGP SIGNATURE", block.Type)
+	verifyKMSSignature(t, key.Public(), time.Unix(1700000000, 0), readSignature(t, block.Body))
+}
+
+func TestKMSSignerLoadedOnce(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	k := registerTestKMS(t, key)
+	ctx := makeNativeContext(t, config.Sign{
+		Artifacts: "all",
+		KMS:       &config.SignKMS{Key: "testkms://my-key"},
+	}, "")
+	archive := &artifact.Artifact{
+		Type: artifact.UploadableArchive,
+		Name: "proj.tar.gz",
+		Path: filepath.Join(ctx.Config.Dist, "proj.tar.gz"),
+	}
+	require.NoError(t, os.WriteFile(archive.Path, []byte("archive"), 0o644))
+	ctx.Artifacts.Add(archive)
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List(), 2)
+	require.Equal(t, 1, k.loaded)
+	require.True(t, k.closed)
+}
+
+func TestKMSErrors(t *testing.T) {
+	ed, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		cfg    config.SignKMS
+		signer crypto.Signer
+		err    string
+	}{
+		"no key": {
+			err: "kms: key is required",
+		},
+		"invalid scheme": {
+			cfg: config.SignKMS{Key: "vault://my-key"},
+			err: `kms: invalid key "vault://my-key": must start with awskms://, gcpkms:// or azurekms://`,
+		},
+		"invalid created": {
+			cfg: config.SignKMS{Key: "testkms://my-key", Created: "yesterday"},
+			err: `kms: invalid created: "yesterday": must be a unix timestamp or RFC 3339`,
+		},
+		"invalid hash": {
+			cfg:    config.SignKMS{Key: "testkms://my-key", Hash: "md5"},
+			signer: rsaKey,
+			err:    `kms: unsupported hash: "md5"`,
+		},
+		"unsupported key type": {
+			cfg:    config.SignKMS{Key: "testkms://my-key"},
+			signer: edSigner{ed},
+			err:    "kms: unsupported key type: ed25519.PublicKey",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			registerTestKMS(t, tt.signer)
+			ctx := makeNativeContext(t, config.Sign{
+				Artifacts: "checksum",
+				KMS:       &tt.cfg,
+			}, "")
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.ErrorContains(t, Pipe{}.Run(ctx), tt.err)
+		})
+	}
+}
+
+// edSigner exposes only an ed25519 public key, which KMS signing doesn't
+// support.
+type edSigner struct{ pub ed25519.PublicKey }
+
+func (s edSigner) Public() crypto.PublicKey { return s.pub }
+
+func (edSigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	panic("unreachable")
+}
+
+func readSignature(tb testing.TB, r io.Reader) *packet.Signature {
+	tb.Helper()
+	p, err := packet.Read(r)
+	require.NoError(tb, err)
+	sig, ok := p.(*packet.Signature)
+	require.True(tb, ok, "expected a signature packet, got %T", p)
+	require.Equal(tb, packet.SigTypeBinary, sig.SigType)
+	return sig
+}
+
+func verifyKMSSignature(tb testing.TB, pub crypto.PublicKey, created time.Time, sig *packet.Signature) {
+	tb.Helper()
+	pk, err := kmsPublicKey(created, pub)
+	require.NoError(tb, err)
+	require.NotNil(tb, sig.IssuerKeyId)
+	require.Equal(tb, pk.KeyId, *sig.IssuerKeyId)
+	h := sig.Hash.New()
+	_, _ = h.Write([]byte("checksums"))
+	require.NoError(tb, pk.VerifySignature(h, sig))
+}
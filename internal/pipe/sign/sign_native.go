@@ -0,0 +1,349 @@
+package sign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blowfish"
+	"golang.org/x/crypto/scrypt"
+)
+
+// isNative reports whether the given configuration uses one of the native
+// signing backends instead of running a command.
+func isNative(cfg config.Sign) bool {
+	return cfg.Minisign != nil || cfg.Signify != nil || cfg.KMS != nil
+}
+
+// validateNative checks that at most one native backend is configured, and
+// sets its defaults.
+func validateNative(cfg *config.Sign, signature string) error {
+	if !isNative(*cfg) {
+		return nil
+	}
+	var n int
+	for _, set := range []bool{cfg.Minisign != nil, cfg.Signify != nil, cfg.KMS != nil} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
+		return errors.New("signs: only one of minisign, signify and kms can be used")
+	}
+	if cfg.Signature == "" {
+		cfg.Signature = signature
+		if cfg.Minisign != nil {
+			cfg.Signature += ".minisig"
+		} else {
+			cfg.Signature += ".sig"
+		}
+	}
+	return nil
+}
+
+// signNative signs the artifact with the configured native backend,
+// writing the signature to the given path.
+func signNative(ctx *context.Context, cfg config.Sign, signers kmsSigners, env context.Env, art *artifact.Artifact, signature string) error {
+	tpl := tmpl.New(ctx).WithArtifact(art).WithEnv(env)
+	if cfg.Minisign != nil {
+		m := *cfg.Minisign
+		if err := tpl.ApplyAll(&m.Key, &m.KeyFile, &m.Password, &m.TrustedComment, &m.UntrustedComment); err != nil {
+			return err
+		}
+		return minisign(ctx, m, art, signature)
+	}
+	if cfg.KMS != nil {
+		k := *cfg.KMS
+		if err := tpl.ApplyAll(&k.Key, &k.Created); err != nil {
+			return err
+		}
+		return signKMS(ctx, k, signers, art, signature)
+	}
+	s := *cfg.Signify
+	if err := tpl.ApplyAll(&s.Key, &s.KeyFile, &s.Password, &s.Comment); err != nil {
+		return err
+	}
+	return signify(s, art, signature)
+}
+
+// readSecretKey reads the base64-encoded secret key from the given key, or
+// key file, skipping the untrusted comment line.
+func readSecretKey(key, keyFile string) ([]byte, error) {
+	if keyFile != "" {
+		bts, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		key = string(bts)
+	}
+	for line := range strings.SplitSeq(key, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "untrusted comment:") {
+			continue
+		}
+		bts, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret key: %w", err)
+		}
+		return bts, nil
+	}
+	return nil, errors.New("key or key_file is required")
+}
+
+const (
+	minisignSecretKeySize = 158
+	minisignKeyNumSize    = 104
+)
+
+// minisign creates a prehashed signature of the artifact, as `minisign -S`
+// does.
+//
+// See https://jedisct1.github.io/minisign/#secret-key-format.
+func minisign(ctx *context.Context, cfg config.SignMinisign, art *artifact.Artifact, signature string) error {
+	keyID, key, err := minisignSecretKey(cfg)
+	if err != nil {
+		return fmt.Errorf("minisign: %w", err)
+	}
+
+	f, err := os.Open(art.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h, err := blake2b.New512(nil)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	sig := ed25519.Sign(key, h.Sum(nil))
+
+	trusted := cfg.TrustedComment
+	if trusted == "" {
+		trusted = "timestamp:" + strconv.FormatInt(ctx.Date.Unix(), 10) + "\tfile:" + art.Name + "\thashed"
+	}
+	if strings.ContainsAny(trusted, "\r\n") {
+		return errors.New("minisign: trusted comment must be a single line")
+	}
+	global := ed25519.Sign(key, append(bytes.Clone(sig), trusted...))
+
+	untrusted := cfg.UntrustedComment
+	if untrusted == "" {
+		untrusted = "signature from minisign secret key"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "untrusted comment: %s\n", untrusted)
+	fmt.Fprintln(&b, base64.StdEncoding.EncodeToString(slices.Concat([]byte("ED"), keyID, sig)))
+	fmt.Fprintf(&b, "trusted comment: %s\n", trusted)
+	fmt.Fprintln(&b, base64.StdEncoding.EncodeToString(global))
+	return os.WriteFile(signature, b.Bytes(), 0o644)
+}
+
+// minisignSecretKey reads, and decrypts if needed, the minisign secret key,
+// returning its ID and the ed25519 key.
+func minisignSecretKey(cfg config.SignMinisign) ([]byte, ed25519.PrivateKey, error) {
+	bts, err := readSecretKey(cfg.Key, cfg.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bts) != minisignSecretKeySize || string(bts[:2]) != "Ed" || string(bts[4:6]) != "B2" {
+		return nil, nil, errors.New("invalid secret key")
+	}
+	salt := bts[6:38]
+	keyNum := bytes.Clone(bts[54:])
+
+	switch kdf := bts[2:4]; string(kdf) {
+	case "Sc":
+		if cfg.Password == "" {
+			return nil, nil, errors.New("secret key is encrypted, but no password was given")
+		}
+		n, r, p := scryptParams(binary.LittleEndian.Uint64(bts[38:46]), binary.LittleEndian.Uint64(bts[46:54]))
+		stream, err := scrypt.Key([]byte(cfg.Password), salt, n, r, p, minisignKeyNumSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		subtle.XORBytes(keyNum, keyNum, stream)
+	case "\x00\x00":
+	default:
+		return nil, nil, fmt.Errorf("unsupported key derivation function: %q", kdf)
+	}
+
+	keyID, key, checksum := keyNum[:8], keyNum[8:72], keyNum[72:]
+	expected := blake2b.Sum256(slices.Concat(bts[:2], keyID, key))
+	if subtle.ConstantTimeCompare(checksum, expected[:]) != 1 {
+		return nil, nil, errors.New("wrong password or corrupted secret key")
+	}
+	return keyID, ed25519.PrivateKey(key), nil
+}
+
+// scryptParams converts libsodium's scryptsalsa208sha256 limits to scrypt
+// parameters, as crypto_pwhash_scryptsalsa208sha256 does.
+func scryptParams(opsLimit, memLimit uint64) (n, r, p int) {
+	opsLimit = max(opsLimit, 32768)
+	r = 8
+	var maxN uint64
+	if opsLimit < memLimit/32 {
+		p = 1
+		maxN = opsLimit / (uint64(r) * 4)
+	} else {
+		maxN = memLimit / (uint64(r) * 128)
+	}
+	logN := 1
+	for ; logN < 63; logN++ {
+		if uint64(1)<<logN > maxN/2 {
+			break
+		}
+	}
+	if p == 0 {
+		maxRP := min((opsLimit/4)/(uint64(1)<<logN), 0x3fffffff)
+		p = int(maxRP / uint64(r))
+	}
+	return 1 << logN, r, p
+}
+
+const signifySecretKeySize = 104
+
+// signify creates a signature of the artifact, as `signify -S` does.
+//
+// See https://man.openbsd.org/signify.
+func signify(cfg config.SignSignify, art *artifact.Artifact, signature string) error {
+	keyNum, key, err := signifySecretKey(cfg)
+	if err != nil {
+		return fmt.Errorf("signify: %w", err)
+	}
+	msg, err := os.ReadFile(art.Path)
+	if err != nil {
+		return err
+	}
+	sig := ed25519.Sign(key, msg)
+
+	comment := cfg.Comment
+	if comment == "" {
+		comment = "signature from signify secret key"
+		if strings.HasSuffix(cfg.KeyFile, ".sec") {
+			comment = "verify with " + strings.TrimSuffix(filepath.Base(cfg.KeyFile), ".sec") + ".pub"
+		}
+	}
+	content := fmt.Sprintf(
+		"untrusted comment: %s\n%s\n",
+		comment,
+		base64.StdEncoding.EncodeToString(slices.Concat([]byte("Ed"), keyNum, sig)),
+	)
+	return os.WriteFile(signature, []byte(content), 0o644)
+}
+
+// signifySecretKey reads, and decrypts if needed, the signify secret key,
+// returning its number and the ed25519 key.
+func signifySecretKey(cfg config.SignSignify) ([]byte, ed25519.PrivateKey, error) {
+	bts, err := readSecretKey(cfg.Key, cfg.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bts) != signifySecretKeySize || string(bts[:2]) != "Ed" || string(bts[2:4]) != "BK" {
+		return nil, nil, errors.New("invalid secret key")
+	}
+	rounds := binary.BigEndian.Uint32(bts[4:8])
+	salt, checksum, keyNum := bts[8:24], bts[24:32], bts[32:40]
+	key := bytes.Clone(bts[40:])
+	if rounds > 0 {
+		if cfg.Password == "" {
+			return nil, nil, errors.New("secret key is encrypted, but no password was given")
+		}
+		xor, err := bcryptPBKDF([]byte(cfg.Password), salt, int(rounds), len(key))
+		if err != nil {
+			return nil, nil, err
+		}
+		subtle.XORBytes(key, key, xor)
+	}
+	sum := sha512.Sum512(key)
+	if subtle.ConstantTimeCompare(checksum, sum[:8]) != 1 {
+		return nil, nil, errors.New("wrong password or corrupted secret key")
+	}
+	return keyNum, ed25519.PrivateKey(key), nil
+}
+
+// bcryptPBKDF implements bcrypt_pbkdf(3) from OpenBSD, which signify uses to
+// encrypt secret keys.
+//
+// Adapted from golang.org/x/crypto/ssh/internal/bcrypt_pbkdf, which can't be
+// imported.
+func bcryptPBKDF(password, salt []byte, rounds, keyLen int) ([]byte, error) {
+	const blockSize = 32
+	if len(password) == 0 || len(salt) == 0 || keyLen > 1024 {
+		return nil, errors.New("bcrypt_pbkdf: invalid parameters")
+	}
+
+	numBlocks := (keyLen + blockSize - 1) / blockSize
+	key := make([]byte, numBlocks*blockSize)
+
+	h := sha512.New()
+	h.Write(password)
+	shapass := h.Sum(nil)
+
+	shasalt := make([]byte, 0, sha512.Size)
+	cnt, tmp := make([]byte, 4), make([]byte, blockSize)
+	for block := 1; block <= numBlocks; block++ {
+		h.Reset()
+		h.Write(salt)
+		binary.BigEndian.PutUint32(cnt, uint32(block))
+		h.Write(cnt)
+		if err := bcryptHash(tmp, shapass, h.Sum(shasalt)); err != nil {
+			return nil, err
+		}
+
+		out := bytes.Clone(tmp)
+		for i := 2; i <= rounds; i++ {
+			h.Reset()
+			h.Write(tmp)
+			if err := bcryptHash(tmp, shapass, h.Sum(shasalt)); err != nil {
+				return nil, err
+			}
+			subtle.XORBytes(out, out, tmp)
+		}
+
+		for i, v := range out {
+			key[i*numBlocks+(block-1)] = v
+		}
+	}
+	return key[:keyLen], nil
+}
+
+func bcryptHash(out, shapass, shasalt []byte) error {
+	c, err := blowfish.NewSaltedCipher(shapass, shasalt)
+	if err != nil {
+		return err
+	}
+	for range 64 {
+		blowfish.ExpandKey(shasalt, c)
+		blowfish.ExpandKey(shapass, c)
+	}
+	copy(out, "OxychromaticBlowfishSwatDynamite")
+	for i := 0; i < 32; i += 8 {
+		for range 64 {
+			c.Encrypt(out[i:i+8], out[i:i+8])
+		}
+	}
+	// swap bytes due to different endianness.
+	for i := 0; i < 32; i += 4 {
+		out[i+3], out[i+2], out[i+1], out[i] = out[i], out[i+1], out[i+2], out[i+3]
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+package sign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/scrypt"
+)
+
+func TestNativeDefault(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Signs: []config.Sign{
+			{ID: "minisign", Minisign: &config.SignMinisign{}},
+			{ID: "signify", Signify: &config.SignSignify{}},
+		},
+		BinarySigns: []config.BinarySign{
+			{Minisign: &config.SignMinisign{}},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, BinaryPipe{}.Default(ctx))
+
+	require.Equal(t, "${artifact}.minisig", ctx.Config.Signs[0].Signature)
+	require.Equal(t, "${artifact}.sig", ctx.Config.Signs[1].Signature)
+	require.Equal(t, defaultSignatureName+".minisig", ctx.Config.BinarySigns[0].Signature)
+	for _, cfg := range ctx.Config.Signs {
+		require.Empty(t, cfg.Cmd)
+		require.Empty(t, cfg.Args)
+	}
+	require.Empty(t, ctx.Config.BinarySigns[0].Cmd)
+	require.Empty(t, Pipe{}.Dependencies(ctx))
+	require.Empty(t, BinaryPipe{}.Dependencies(ctx))
+}
+
+func TestNativeDefaultErrors(t *testing.T) {
+	t.Run("both", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Signs: []config.Sign{{
+				Minisign: &config.SignMinisign{},
+				Signify:  &config.SignSignify{},
+			}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "signs: only one of minisign, signify and kms can be used")
+	})
+	t.Run("docker", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			DockerSigns: []config.Sign{{Signify: &config.SignSignify{}}},
+		})
+		require.EqualError(t, DockerPipe{}.Default(ctx), "docker_signs: minisign, signify and kms can't sign docker images")
+	})
+}
+
+func TestMinisign(t *testing.T) {
+	for name, password := range map[string]string{
+		"encrypted":   "secret",
+		"unencrypted": "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, keyID := newMinisignKey(t, password)
+			keyFile := filepath.Join(t.TempDir(), "minisign.key")
+			require.NoError(t, os.WriteFile(keyFile, key, 0o600))
+
+			ctx := makeNativeContext(t, config.Sign{
+				Artifacts: "checksum",
+				Minisign: &config.SignMinisign{
+					KeyFile:  keyFile,
+					Password: "{{ .Env.PASSWORD }}",
+				},
+			}, password)
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+			require.Len(t, sigs, 1)
+			require.Equal(t, "checksums.txt.minisig", sigs[0].Name)
+
+			bts, err := os.ReadFile(sigs[0].Path)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(bts)), "\n")
+			require.Len(t, lines, 4)
+			require.Equal(t, "untrusted comment: signature from minisign secret key", lines[0])
+			require.Equal(t, "trusted comment: timestamp:1735787045\tfile:checksums.txt\thashed", lines[2])
+
+			pub := minisignPublicKey(t, key, password)
+			sig := decode(t, lines[1])
+			require.Equal(t, []byte("ED"), sig[:2])
+			require.Equal(t, keyID, sig[2:10])
+			hash := blake2b.Sum512([]byte("checksums"))
+			require.True(t, ed25519.Verify(pub, hash[:], sig[10:]))
+			trusted := strings.TrimPrefix(lines[2], "trusted comment: ")
+			require.True(t, ed25519.Verify(pub, append(sig[10:], trusted...), decode(t, lines[3])))
+		})
+	}
+}
+
+func TestMinisignComments(t *testing.T) {
+	key, _ := newMinisignKey(t, "")
+	ctx := makeNativeContext(t, config.Sign{
+		Artifacts: "checksum",
+		Minisign: &config.SignMinisign{
+			Key:              "{{ .Env.KEY }}",
+			TrustedComment:   "{{ .ProjectName }} {{ .Version }}",
+			UntrustedComment: "verify with minisign.pub",
+		},
+	}, "")
+	ctx.Env["KEY"] = string(key)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	bts, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "checksums.txt.minisig"))
+	require.NoError(t, err)
+	lines := strings.Split(string(bts), "\n")
+	require.Equal(t, "untrusted comment: verify with minisign.pub", lines[0])
+	require.Equal(t, "trusted comment: proj 1.0.0", lines[2])
+}
+
+func TestSignify(t *testing.T) {
+	for name, password := range map[string]string{
+		"encrypted":   "secret",
+		"unencrypted": "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, keyNum, pub := newSignifyKey(t, password)
+			keyFile := filepath.Join(t.TempDir(), "release.sec")
+			require.NoError(t, os.WriteFile(keyFile, key, 0o600))
+
+			ctx := makeNativeContext(t, config.Sign{
+				Artifacts: "checksum",
+				Signify: &config.SignSignify{
+					KeyFile:  keyFile,
+					Password: "{{ .Env.PASSWORD }}",
+				},
+			}, password)
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+			require.Len(t, sigs, 1)
+			require.Equal(t, "checksums.txt.sig", sigs[0].Name)
+
+			bts, err := os.ReadFile(sigs[0].Path)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(bts)), "\n")
+			require.Len(t, lines, 2)
+			require.Equal(t, "untrusted comment: verify with release.pub", lines[0])
+			sig := decode(t, lines[1])
+			require.Equal(t, []byte("Ed"), sig[:2])
+			require.Equal(t, keyNum, sig[2:10])
+			require.True(t, ed25519.Verify(pub, []byte("checksums"), sig[10:]))
+		})
+	}
+}
+
+func TestNativeErrors(t *testing.T) {
+	minisignKey, _ := newMinisignKey(t, "secret")
+	signifyKey, _, _ := newSignifyKey(t, "secret")
+	for name, tc := range map[string]struct {
+		cfg config.Sign
+		err string
+	}{
+		"minisign no key": {
+			cfg: config.Sign{Minisign: &config.SignMinisign{}},
+			err: "minisign: key or key_file is required",
+		},
+		"minisign missing key file": {
+			cfg: config.Sign{Minisign: &config.SignMinisign{KeyFile: "nope.key"}},
+			err: "minisign: open nope.key",
+		},
+		"minisign invalid key": {
+			cfg: config.Sign{Minisign: &config.SignMinisign{Key: "Zm9v"}},
+			err: "minisign: invalid secret key",
+		},
+		"minisign no password": {
+			cfg: config.Sign{Minisign: &config.SignMinisign{Key: string(minisignKey)}},
+			err: "minisign: secret key is encrypted, but no password was given",
+		},
+		"minisign wrong password": {
+			cfg: config.Sign{Minisign: &config.SignMinisign{Key: string(minisignKey), Password: "nope"}},
+			err: "minisign: wrong password or corrupted secret key",
+		},
+		"minisign multiline trusted comment": {
+			cfg: config.Sign{Minisign: &config.SignMinisign{Key: string(minisignKey), Password: "secret", TrustedComment: "a\nb"}},
+			err: "minisign: trusted comment must be a single line",
+		},
+		"minisign bad template": {
+			cfg: config.Sign{Minisign: &config.SignMinisign{Key: "{{ .Nope }}"}},
+			err: `template: failed to apply "{{ .Nope }}"`,
+		},
+		"signify no password": {
+			cfg: config.Sign{Signify: &config.SignSignify{Key: string(signifyKey)}},
+			err: "signify: secret key is encrypted, but no password was given",
+		},
+		"signify wrong password": {
+			cfg: config.Sign{Signify: &config.SignSignify{Key: string(signifyKey), Password: "nope"}},
+			err: "signify: wrong password or corrupted secret key",
+		},
+		"signify invalid key": {
+			cfg: config.Sign{Signify: &config.SignSignify{Key: "untrusted comment: foo\n!!"}},
+			err: "signify: invalid secret key",
+		},
+		"signify bad template": {
+			cfg: config.Sign{Signify: &config.SignSignify{Password: "{{ .Nope }}"}},
+			err: `template: failed to apply "{{ .Nope }}"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.cfg.Artifacts = "checksum"
+			ctx := makeNativeContext(t, tc.cfg, "")
+			require.NoError(t, Pipe{}.Default(ctx))
+			err := Pipe{}.Run(ctx)
+			require.ErrorContains(t, err, "sign failed: checksums.txt")
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestScryptParams(t *testing.T) {
+	// minisign defaults: crypto_pwhash_scryptsalsa208sha256 sensitive limits.
+	n, r, p := scryptParams(33554432, 1073741824)
+	require.Equal(t, []int{1 << 20, 8, 1}, []int{n, r, p})
+
+	// interactive limits.
+	n, r, p = scryptParams(524288, 16777216)
+	require.Equal(t, []int{1 << 14, 8, 1}, []int{n, r, p})
+}
+
+func TestBcryptPBKDF(t *testing.T) {
+	// golden values from golang.org/x/crypto/ssh/internal/bcrypt_pbkdf.
+	key, err := bcryptPBKDF([]byte("password"), []byte("salt"), 12, 32)
+	require.NoError(t, err)
+	require.Equal(t, "1ae42c05d487bc02f64921a4ebe4ea93bcacfe135fda99974c06b7b01fae149a", hex.EncodeToString(key))
+
+	key, err = bcryptPBKDF([]byte("passwordy\x00PASSWORD\x00"), []byte("salty\x00SALT\x00"), 3, 32)
+	require.NoError(t, err)
+	require.Equal(t, "7f310bd3e78c3280c59ce4595211a2928e8d4ec744c1ed2efc9f764e3388e0ad", hex.EncodeToString(key))
+
+	_, err = bcryptPBKDF(nil, []byte("salt"), 1, 32)
+	require.Error(t, err)
+}
+
+func makeNativeContext(tb testing.TB, cfg config.Sign, password string) *context.Context {
+	tb.Helper()
+	dist := tb.TempDir()
+	ctx := testctx.WrapWithCfg(tb.Context(), config.Project{
+		ProjectName: "proj",
+		Dist:        dist,
+		Signs:       []config.Sign{cfg},
+	},
+		testctx.WithVersion("1.0.0"),
+		testctx.WithDate(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		testctx.WithEnv(map[string]string{"PASSWORD": password}),
+	)
+	art := &artifact.Artifact{
+		Type: artifact.Checksum,
+		Name: "checksums.txt",
+		Path: filepath.Join(dist, "checksums.txt"),
+	}
+	require.NoError(tb, os.WriteFile(art.Path, []byte("checksums"), 0o644))
+	ctx.Artifacts.Add(art)
+	return ctx
+}
+
+// newMinisignKey creates a minisign secret key, encrypted with the given
+// password, if any, using cheap scrypt limits.
+func newMinisignKey(tb testing.TB, password string) ([]byte, []byte) {
+	tb.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(tb, err)
+	keyID := make([]byte, 8)
+	salt := make([]byte, 32)
+	_, _ = rand.Read(keyID)
+	_, _ = rand.Read(salt)
+
+	checksum := blake2b.Sum256(slices.Concat([]byte("Ed"), keyID, priv))
+	keyNum := slices.Concat(keyID, priv, checksum[:])
+	kdf := []byte{0, 0}
+	limits := make([]byte, 16)
+	if password != "" {
+		kdf = []byte("Sc")
+		binary.LittleEndian.PutUint64(limits[:8], 524288)
+		binary.LittleEndian.PutUint64(limits[8:], 16777216)
+		n, r, p := scryptParams(524288, 16777216)
+		stream, err := scrypt.Key([]byte(password), salt, n, r, p, len(keyNum))
+		require.NoError(tb, err)
+		subtle.XORBytes(keyNum, keyNum, stream)
+	}
+	bts := slices.Concat([]byte("Ed"), kdf, []byte("B2"), salt, limits, keyNum)
+	return []byte("untrusted comment: minisign encrypted secret key\n" + base64.StdEncoding.EncodeToString(bts) + "\n"), keyID
+}
+
+// minisignPublicKey returns the public key of the given minisign secret key.
+func minisignPublicKey(tb testing.TB, key []byte, password string) ed25519.PublicKey {
+	tb.Helper()
+	_, priv, err := minisignSecretKey(config.SignMinisign{Key: string(key), Password: password})
+	require.NoError(tb, err)
+	return priv.Public().(ed25519.PublicKey)
+}
+
+// newSignifyKey creates a signify secret key, encrypted with the given
+// password, if any.
+func newSignifyKey(tb testing.TB, password string) ([]byte, []byte, ed25519.PublicKey) {
+	tb.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(tb, err)
+	keyNum := make([]byte, 8)
+	salt := make([]byte, 16)
+	_, _ = rand.Read(keyNum)
+	_, _ = rand.Read(salt)
+
+	checksum := sha512.Sum512(priv)
+	key := bytes.Clone(priv)
+	rounds := make([]byte, 4)
+	if password != "" {
+		binary.BigEndian.PutUint32(rounds, 2)
+		xor, err := bcryptPBKDF([]byte(password), salt, 2, len(key))
+		require.NoError(tb, err)
+		subtle.XORBytes(key, key, xor)
+	}
+	bts := slices.Concat([]byte("EdBK"), rounds, salt, checksum[:8], keyNum, key)
+	return []byte("untrusted comment: signify secret key\n" + base64.StdEncoding.EncodeToString(bts) + "\n"), keyNum, pub
+}
+
+func decode(tb testing.TB, s string) []byte {
+	tb.Helper()
+	bts, err := base64.StdEncoding.DecodeString(s)
+	require.NoError(tb, err)
+	return bts
+}
@@ -3,11 +3,16 @@ package slack
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/announcement"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -15,10 +20,13 @@ import (
 )
 
 const (
+	releaseNotesFilename   = "release-notes.md"
 	defaultUsername        = `GoReleaser`
 	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
 )
 
+var errChannelRequired = errors.New("slack: channel is required to upload the release notes or to thread the artifacts")
+
 type Pipe struct{}
 
 func (Pipe) String() string { return "slack" }
@@ -31,6 +39,13 @@ type Config struct {
 	Webhook string `env:"SLACK_WEBHOOK,notEmpty"`
 }
 
+// BotConfig is used instead of [Config] when the announcement needs the Web
+// API, i.e. when uploading the release notes or replying in a thread.
+type BotConfig struct {
+	API   string `env:"SLACK_API" envDefault:"https://slack.com/api/"`
+	Token string `env:"SLACK_BOT_TOKEN,notEmpty"`
+}
+
 func (Pipe) Default(ctx *context.Context) error {
 	if ctx.Config.Announce.Slack.MessageTemplate == "" {
 		ctx.Config.Announce.Slack.MessageTemplate = defaultMessageTemplate
@@ -41,26 +56,57 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
+// Preview returns the message that would be posted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	wm, err := webhookMessage(ctx)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.MarshalIndent(wm, "", "  ")
+	return string(bts), err
+}
+
 func (p Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Slack.MessageTemplate)
+	wm, err := webhookMessage(ctx)
 	if err != nil {
 		return err
 	}
 
+	log.Infof("posting: '%s'", wm.Text)
+
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Config.Announce.Slack.UploadReleaseNotes || ctx.Config.Announce.Slack.ThreadArtifacts {
+		return announceWithBot(ctx, httpClient, wm.Text, wm.Blocks, wm.Attachments)
+	}
+
 	cfg, err := env.ParseAs[Config]()
 	if err != nil {
 		return err
 	}
 
-	log.Infof("posting: '%s'", msg)
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		return slack.PostWebhookCustomHTTPContext(ctx, cfg.Webhook, httpClient, wm)
+	}, retryx.IsNetworkError)
+}
+
+// webhookMessage returns the message to post.
+func webhookMessage(ctx *context.Context) (*slack.WebhookMessage, error) {
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Slack.MessageTemplate)
+	if err != nil {
+		return nil, err
+	}
 
 	// optional processing of advanced formatting options
 	blocks, attachments, err := parseAdvancedFormatting(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	wm := &slack.WebhookMessage{
+	return &slack.WebhookMessage{
 		Username:  ctx.Config.Announce.Slack.Username,
 		IconEmoji: ctx.Config.Announce.Slack.IconEmoji,
 		IconURL:   ctx.Config.Announce.Slack.IconURL,
@@ -70,11 +116,122 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		// optional enrichments
 		Blocks:      blocks,
 		Attachments: attachments,
+	}, nil
+}
+
+// announceWithBot posts the announcement using the Web API, uploads the
+// release notes and posts the artifacts as replies to it.
+func announceWithBot(
+	ctx *context.Context,
+	httpClient *http.Client,
+	msg string,
+	blocks *slack.Blocks,
+	attachments []slack.Attachment,
+) error {
+	if ctx.Config.Announce.Slack.Channel == "" {
+		return errChannelRequired
 	}
 
-	return retryx.Do(ctx, ctx.Config.Retry, func() error {
-		return slack.PostWebhook(cfg.Webhook, wm)
-	}, retryx.IsNetworkError)
+	cfg, err := env.ParseAs[BotConfig]()
+	if err != nil {
+		return err
+	}
+
+	api := slack.New(
+		cfg.Token,
+		slack.OptionHTTPClient(httpClient),
+		slack.OptionAPIURL(cfg.API),
+	)
+
+	identity := []slack.MsgOption{
+		slack.MsgOptionUsername(ctx.Config.Announce.Slack.Username),
+	}
+	if emoji := ctx.Config.Announce.Slack.IconEmoji; emoji != "" {
+		identity = append(identity, slack.MsgOptionIconEmoji(emoji))
+	}
+	if url := ctx.Config.Announce.Slack.IconURL; url != "" {
+		identity = append(identity, slack.MsgOptionIconURL(url))
+	}
+
+	opts := append([]slack.MsgOption{
+		slack.MsgOptionText(msg, false),
+		slack.MsgOptionAttachments(attachments...),
+	}, identity...)
+	if blocks != nil {
+		opts = append(opts, slack.MsgOptionBlocks(blocks.BlockSet...))
+	}
+
+	var channel, ts string
+	if err := retryx.Do(ctx, ctx.Config.Retry, func() error {
+		channel, ts, err = api.PostMessageContext(ctx, ctx.Config.Announce.Slack.Channel, opts...)
+		return err
+	}, retryx.IsNetworkError); err != nil {
+		return fmt.Errorf("slack: could not post message: %w", err)
+	}
+
+	if ctx.Config.Announce.Slack.UploadReleaseNotes && ctx.ReleaseNotes != "" {
+		log.WithField("thread", ts).Info("uploading release notes")
+		if err := retryx.Do(ctx, ctx.Config.Retry, func() error {
+			_, err := api.UploadFileContext(ctx, slack.UploadFileParameters{
+				Channel:         channel,
+				ThreadTimestamp: ts,
+				Content:         ctx.ReleaseNotes,
+				FileSize:        len(ctx.ReleaseNotes),
+				Filename:        releaseNotesFilename,
+				Title:           ctx.Config.ProjectName + " " + ctx.Git.CurrentTag,
+				SnippetType:     "markdown",
+			})
+			return err
+		}, retryx.IsNetworkError); err != nil {
+			return fmt.Errorf("slack: could not upload release notes: %w", err)
+		}
+	}
+
+	if !ctx.Config.Announce.Slack.ThreadArtifacts {
+		return nil
+	}
+
+	reply, err := artifactsMessage(ctx)
+	if err != nil {
+		return err
+	}
+	if reply == "" {
+		return nil
+	}
+
+	log.WithField("thread", ts).Info("posting artifacts")
+	if err := retryx.Do(ctx, ctx.Config.Retry, func() error {
+		_, _, err := api.PostMessageContext(ctx, channel, append([]slack.MsgOption{
+			slack.MsgOptionText(reply, false),
+			slack.MsgOptionTS(ts),
+		}, identity...)...)
+		return err
+	}, retryx.IsNetworkError); err != nil {
+		return fmt.Errorf("slack: could not post artifacts: %w", err)
+	}
+	return nil
+}
+
+// artifactsMessage returns a message listing the released artifacts, linking
+// to them if possible.
+func artifactsMessage(ctx *context.Context) (string, error) {
+	urlTemplate := announcement.ReleaseURLTemplate(ctx)
+	var sb strings.Builder
+	for _, a := range ctx.Artifacts.Filter(artifact.And(
+		artifact.ByTypes(artifact.ReleaseUploadableTypes()...),
+		artifact.ByIDs(ctx.Config.Release.IDs...),
+	)).List() {
+		if urlTemplate == "" {
+			fmt.Fprintf(&sb, "• %s\n", a.Name)
+			continue
+		}
+		url, err := tmpl.New(ctx).WithArtifact(a).Apply(urlTemplate)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "• <%s|%s>\n", url, a.Name)
+	}
+	return sb.String(), nil
 }
 
 func parseAdvancedFormatting(ctx *context.Context) (*slack.Blocks, []slack.Attachment, error) {
@@ -109,7 +266,7 @@ func unmarshal(ctx *context.Context, in any, target any) error {
 	// ensure that double quotes that are inside the string get un-escaped so they can be interpreted for templates
 	body = strings.ReplaceAll(body, "\\\"", "\"")
 
-	tplApplied, err := tmpl.New(ctx).Apply(body)
+	tplApplied, err := tmpl.New(ctx).WithMessageTemplates().Apply(body)
 	if err != nil {
 		return fmt.Errorf("failed to evaluate template: %w", err)
 	}
@@ -3,10 +3,13 @@ package slack
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/internal/yaml"
@@ -79,6 +82,28 @@ func TestAnnounceWithQuotes(t *testing.T) {
 	})
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			Slack: config.Slack{
+				Channel: "#releases",
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+
+	var wm slack.WebhookMessage
+	require.NoError(t, json.Unmarshal([]byte(out), &wm))
+	require.Equal(t, defaultUsername, wm.Username)
+	require.Equal(t, "#releases", wm.Channel)
+	require.Equal(t, "honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0", wm.Text)
+}
+
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
@@ -90,6 +115,144 @@ func TestAnnounceMissingEnv(t *testing.T) {
 	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "SLACK_WEBHOOK" should not be empty`)
 }
 
+func TestAnnounceWithBot(t *testing.T) {
+	var calls []string
+	var uploaded, reply string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "token", r.Form.Get("token"))
+			assert.Equal(t, "C123", r.Form.Get("channel"))
+			if ts := r.Form.Get("thread_ts"); ts != "" {
+				assert.Equal(t, "1.2", ts)
+				reply = r.Form.Get("text")
+			} else {
+				assert.Equal(t, "foo v1.0.0 is out!", r.Form.Get("text"))
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"C123","ts":"1.2"}`))
+		case "/files.getUploadURLExternal":
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, releaseNotesFilename, r.Form.Get("filename"))
+			_, _ = fmt.Fprintf(w, `{"ok":true,"upload_url":"%s/upload","file_id":"F1"}`, srv.URL)
+		case "/upload":
+			assert.NoError(t, r.ParseMultipartForm(1<<20))
+			f, header, err := r.FormFile("file")
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer f.Close()
+			assert.Equal(t, releaseNotesFilename, header.Filename)
+			bts, err := io.ReadAll(f)
+			assert.NoError(t, err)
+			uploaded = string(bts)
+		case "/files.completeUploadExternal":
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "C123", r.Form.Get("channel_id"))
+			assert.Equal(t, "1.2", r.Form.Get("thread_ts"))
+			_, _ = w.Write([]byte(`{"ok":true,"files":[{"id":"F1"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("SLACK_API", srv.URL+"/")
+	t.Setenv("SLACK_BOT_TOKEN", "token")
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Release: config.Release{
+			GitHub: config.Repo{
+				Owner: "goreleaser",
+				Name:  "foo",
+			},
+		},
+		GitHubURLs: config.GitHubURLs{
+			Download: "https://github.com",
+		},
+		Announce: config.Announce{
+			Slack: config.Slack{
+				MessageTemplate:    "{{ .ProjectName }} {{ .Tag }} is out!",
+				Channel:            "C123",
+				UploadReleaseNotes: true,
+				ThreadArtifacts:    true,
+			},
+		},
+	}, testctx.GitHubTokenType, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseNotes = "## Changelog\n\n- foo"
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo_linux_amd64.tar.gz",
+		Path: "dist/foo_linux_amd64.tar.gz",
+		Type: artifact.UploadableArchive,
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo",
+		Path: "dist/foo_linux_amd64_v1/foo",
+		Type: artifact.Binary,
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+	require.Equal(t, []string{
+		"/chat.postMessage",
+		"/files.getUploadURLExternal",
+		"/upload",
+		"/files.completeUploadExternal",
+		"/chat.postMessage",
+	}, calls)
+	require.Equal(t, ctx.ReleaseNotes, uploaded)
+	require.Equal(t, "• <https://github.com/goreleaser/foo/releases/download/v1.0.0/foo_linux_amd64.tar.gz|foo_linux_amd64.tar.gz>\n", reply)
+}
+
+func TestAnnounceWithBotErrors(t *testing.T) {
+	t.Run("no channel", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Slack: config.Slack{
+					UploadReleaseNotes: true,
+				},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.ErrorIs(t, Pipe{}.Announce(ctx), errChannelRequired)
+	})
+
+	t.Run("missing env", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Slack: config.Slack{
+					Channel:         "C123",
+					ThreadArtifacts: true,
+				},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "SLACK_BOT_TOKEN" should not be empty`)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+		}))
+		t.Cleanup(srv.Close)
+		t.Setenv("SLACK_API", srv.URL+"/")
+		t.Setenv("SLACK_BOT_TOKEN", "token")
+
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Slack: config.Slack{
+					Channel:         "C123",
+					ThreadArtifacts: true,
+				},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Announce(ctx), "slack: could not post message: channel_not_found")
+	})
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
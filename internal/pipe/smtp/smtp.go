@@ -5,9 +5,11 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -46,32 +48,25 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
-	subject, err := tmpl.New(ctx).Apply(ctx.Config.Announce.SMTP.SubjectTemplate)
+// Preview returns the email that would be sent, MIME encoded.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	m, err := newMessage(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
+	var sb strings.Builder
+	if _, err := m.WriteTo(&sb); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
 
-	body, err := tmpl.New(ctx).Apply(ctx.Config.Announce.SMTP.BodyTemplate)
+func (p Pipe) Announce(ctx *context.Context) error {
+	m, err := newMessage(ctx)
 	if err != nil {
 		return err
 	}
 
-	m := gomail.NewMessage()
-
-	// Set E-Mail sender
-	m.SetHeader("From", ctx.Config.Announce.SMTP.From)
-
-	// Set E-Mail receivers
-	receivers := ctx.Config.Announce.SMTP.To
-	m.SetHeader("To", receivers...)
-
-	// Set E-Mail subject
-	m.SetHeader("Subject", subject)
-
-	// Set E-Mail body. You can set plain text or html with text/html
-	m.SetBody("text/plain", body)
-
 	cfg, err := getConfig(ctx.Config.Announce.SMTP)
 	if err != nil {
 		return err
@@ -89,15 +84,93 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return err
 	}
 
-	log.Infof("The mail has been send from %s to %s\n", ctx.Config.Announce.SMTP.From, receivers)
+	log.Infof("The mail has been send from %s to %s\n", m.GetHeader("From"), m.GetHeader("To"))
 
 	return nil
 }
 
+func newMessage(ctx *context.Context) (*gomail.Message, error) {
+	smtp := ctx.Config.Announce.SMTP
+	t := tmpl.New(ctx).WithMessageTemplates()
+
+	subject, body, html, from := smtp.SubjectTemplate, smtp.BodyTemplate, smtp.HTMLBodyTemplate, smtp.From
+	if err := t.ApplyAll(&subject, &body, &html, &from); err != nil {
+		return nil, err
+	}
+
+	to, err := recipients(t, smtp.To)
+	if err != nil {
+		return nil, err
+	}
+	if len(to) == 0 {
+		return nil, errNoRecipients
+	}
+	cc, err := recipients(t, smtp.Cc)
+	if err != nil {
+		return nil, err
+	}
+	bcc, err := recipients(t, smtp.Bcc)
+	if err != nil {
+		return nil, err
+	}
+
+	m := gomail.NewMessage()
+
+	// Set E-Mail sender
+	m.SetHeader("From", from)
+
+	// Set E-Mail receivers
+	m.SetHeader("To", to...)
+	if len(cc) > 0 {
+		m.SetHeader("Cc", cc...)
+	}
+	if len(bcc) > 0 {
+		m.SetHeader("Bcc", bcc...)
+	}
+
+	// Set E-Mail subject
+	m.SetHeader("Subject", subject)
+
+	// Set E-Mail body, with an optional HTML alternative.
+	m.SetBody("text/plain", body)
+	if html != "" {
+		m.AddAlternative("text/html", html)
+	}
+
+	if smtp.AttachChecksums {
+		for _, checksum := range ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List() {
+			m.Attach(checksum.Path, gomail.Rename(checksum.Name))
+		}
+	}
+
+	return m, nil
+}
+
+// recipients applies the templates of the given addresses, splitting the
+// results by commas, so a single entry can expand to many recipients, e.g.
+// '{{ .Env.MAIL_TO }}'.
+func recipients(t *tmpl.Template, addresses []string) ([]string, error) {
+	var result []string
+	for _, address := range addresses {
+		applied, err := t.Apply(address)
+		if err != nil {
+			return nil, err
+		}
+		for s := range strings.SplitSeq(applied, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				result = append(result, s)
+			}
+		}
+	}
+	return result, nil
+}
+
 var (
 	errNoPort     = errors.New("SMTP: missing smtp.port or $SMTP_PORT")
 	errNoUsername = errors.New("SMTP: missing smtp.username or $SMTP_USERNAME")
 	errNoHost     = errors.New("SMTP: missing smtp.host or $SMTP_HOST")
+
+	errNoRecipients = errors.New("SMTP: no recipients")
 )
 
 func getConfig(smtp config.SMTP) (Config, error) {
@@ -1,10 +1,15 @@
 package smtp
 
 import (
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/stretchr/testify/require"
 )
@@ -136,3 +141,106 @@ func TestGetConfig(t *testing.T) {
 		require.EqualError(t, err, "SMTP: env: environment variable \"SMTP_PASSWORD\" should not be empty")
 	})
 }
+
+func TestNewMessage(t *testing.T) {
+	checksums := filepath.Join(t.TempDir(), "checksums.txt")
+	require.NoError(t, os.WriteFile(checksums, []byte("abc123  foo.tar.gz\n"), 0o644))
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			SMTP: config.SMTP{
+				From:             "{{ .Env.MAIL_FROM }}",
+				To:               []string{"{{ .Env.MAIL_TO }}", "", "carlos@example.com"},
+				Cc:               []string{"cc@example.com"},
+				Bcc:              []string{"{{ .Env.MAIL_BCC }}"},
+				HTMLBodyTemplate: "<pre>{{ html .ReleaseNotes }}</pre>",
+				AttachChecksums:  true,
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"), testctx.WithEnv(map[string]string{
+		"MAIL_FROM": "releases@example.com",
+		"MAIL_TO":   "a@example.com, b@example.com",
+		"MAIL_BCC":  "",
+	}))
+	ctx.ReleaseNotes = "- fix <foo> & bar"
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "checksums.txt",
+		Path: checksums,
+		Type: artifact.Checksum,
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	m, err := newMessage(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"releases@example.com"}, m.GetHeader("From"))
+	require.Equal(t, []string{"a@example.com", "b@example.com", "carlos@example.com"}, m.GetHeader("To"))
+	require.Equal(t, []string{"cc@example.com"}, m.GetHeader("Cc"))
+	require.Empty(t, m.GetHeader("Bcc"))
+	require.Equal(t, []string{"honk v1.0.0 is out!"}, m.GetHeader("Subject"))
+
+	var sb strings.Builder
+	_, err = m.WriteTo(&sb)
+	require.NoError(t, err)
+	out := sb.String()
+	require.Contains(t, out, "multipart/alternative")
+	require.Contains(t, out, "Content-Type: text/html")
+	require.Contains(t, out, "&lt;foo&gt; &amp; bar")
+	require.Contains(t, out, `filename="checksums.txt"`)
+}
+
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+		Announce: config.Announce{
+			SMTP: config.SMTP{
+				From: "releases@example.com",
+				To:   []string{"carlos@example.com"},
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Contains(t, out, "From: releases@example.com")
+	require.Contains(t, out, "To: carlos@example.com")
+	require.Contains(t, out, "Subject: honk v1.0.0 is out!")
+}
+
+func TestNewMessageErrors(t *testing.T) {
+	t.Run("no recipients", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				SMTP: config.SMTP{
+					To: []string{"{{ .Env.MAIL_TO }}"},
+				},
+			},
+		}, testctx.WithEnv(map[string]string{"MAIL_TO": ""}))
+		require.NoError(t, Pipe{}.Default(ctx))
+		_, err := newMessage(ctx)
+		require.ErrorIs(t, err, errNoRecipients)
+	})
+
+	for name, cfg := range map[string]config.SMTP{
+		"subject": {SubjectTemplate: "{{ .Foo }", To: []string{"a@example.com"}},
+		"body":    {BodyTemplate: "{{ .Foo }", To: []string{"a@example.com"}},
+		"html":    {HTMLBodyTemplate: "{{ .Foo }", To: []string{"a@example.com"}},
+		"from":    {From: "{{ .Foo }", To: []string{"a@example.com"}},
+		"to":      {To: []string{"{{ .Foo }"}},
+		"cc":      {Cc: []string{"{{ .Foo }"}, To: []string{"a@example.com"}},
+		"bcc":     {Bcc: []string{"{{ .Foo }"}, To: []string{"a@example.com"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{
+					SMTP: cfg,
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			_, err := newMessage(ctx)
+			testlib.RequireTemplateError(t, err)
+		})
+	}
+}
@@ -57,6 +57,7 @@ type Metadata struct {
 	Apps          map[string]AppMetadata
 	Hooks         map[string]any `yaml:",omitempty"`
 	Plugs         map[string]any `yaml:",omitempty"`
+	Slots         map[string]any `yaml:",omitempty"`
 }
 
 // AppMetadata for the binaries that will be in the snap package.
@@ -217,7 +218,7 @@ func doRun(ctx *context.Context, snap config.Snapcraft) error {
 
 func isValidArch(arch string) bool {
 	// https://snapcraft.io/docs/architectures
-	return slices.Contains([]string{"s390x", "ppc64el", "arm64", "armhf", "i386", "amd64"}, arch)
+	return slices.Contains([]string{"s390x", "ppc64el", "arm64", "armhf", "i386", "amd64", "riscv64"}, arch)
 }
 
 // Publish packages.
@@ -278,8 +279,12 @@ func create(ctx *context.Context, snap config.Snapcraft, arch string, binaries [
 		Grade:         snap.Grade,
 		Confinement:   snap.Confinement,
 		Architectures: []string{arch},
+		Assumes:       snap.Assumes,
 		Layout:        map[string]LayoutMetadata{},
 		Apps:          map[string]AppMetadata{},
+		Hooks:         snap.Hooks,
+		Plugs:         snap.Plugs,
+		Slots:         snap.Slots,
 	}
 
 	if snap.Title != "" {
@@ -398,9 +403,6 @@ func create(ctx *context.Context, snap config.Snapcraft, arch string, binaries [
 		}
 
 		metadata.Apps[name] = appMetadata
-		metadata.Assumes = snap.Assumes
-		metadata.Hooks = snap.Hooks
-		metadata.Plugs = snap.Plugs
 	}
 
 	out, err := yaml.Marshal(metadata)
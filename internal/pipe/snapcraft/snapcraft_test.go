@@ -270,6 +270,13 @@ func TestRunPipeMetadata(t *testing.T) {
 						"read": []string{"$HOME/test"},
 					},
 				},
+				Slots: map[string]any{
+					"dbus-svc": map[string]any{
+						"interface": "dbus",
+						"bus":       "session",
+						"name":      "org.example.foo",
+					},
+				},
 				Builds:           []string{"foo"},
 				ChannelTemplates: []string{"stable"},
 			},
@@ -336,9 +343,57 @@ func TestRunPipeMetadata(t *testing.T) {
 		},
 	}, metadata.Apps)
 	require.Equal(t, map[string]any{"read": []any{"$HOME/test"}}, metadata.Plugs["personal-files"])
+	require.Equal(t, map[string]any{
+		"interface": "dbus",
+		"bus":       "session",
+		"name":      "org.example.foo",
+	}, metadata.Slots["dbus-svc"])
 	require.Equal(t, "$SNAP_DATA/etc", metadata.Layout["/etc/testprojectname"].Bind)
 }
 
+func TestRunPipeMetadataNoApps(t *testing.T) {
+	testlib.SkipIfWindows(t, "snap doesn't work in windows")
+	testlib.CheckPath(t, "snapcraft")
+	folder := t.TempDir()
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.Mkdir(dist, 0o755))
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "testprojectname",
+		Dist:        dist,
+		Snapcrafts: []config.Snapcraft{
+			{
+				NameTemplate: "foo_{{.Arch}}",
+				Summary:      "test summary",
+				Description:  "test description",
+				Assumes:      []string{"snapd2.38"},
+				Hooks: map[string]any{
+					"install": map[string]any{
+						"plugs": []string{"network"},
+					},
+				},
+				Plugs: map[string]any{
+					"dot-foo": map[string]any{
+						"interface": "personal-files",
+						"read":      []string{"$HOME/.foo"},
+					},
+				},
+				Builds:           []string{"foo"},
+				ChannelTemplates: []string{"stable"},
+			},
+		},
+	}, testctx.WithCurrentTag("v1.2.3"), testctx.WithVersion("1.2.3"))
+
+	addBinaries(t, ctx, "foo", dist)
+	require.NoError(t, Pipe{}.Run(ctx))
+	yamlFile, err := os.ReadFile(filepath.Join(dist, "foo_amd64", "prime", "meta", "snap.yaml"))
+	require.NoError(t, err)
+	var metadata Metadata
+	require.NoError(t, yaml.Unmarshal(yamlFile, &metadata))
+	require.Equal(t, []string{"snapd2.38"}, metadata.Assumes)
+	require.Contains(t, metadata.Hooks, "install")
+	require.Contains(t, metadata.Plugs, "dot-foo")
+}
+
 func TestNoSnapcraftInPath(t *testing.T) {
 	t.Setenv("PATH", "")
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
@@ -740,6 +795,7 @@ func Test_isValidArch(t *testing.T) {
 		{"arm64", true},
 		{"armhf", true},
 		{"i386", true},
+		{"riscv64", true},
 		{"mips", false},
 		{"armel", false},
 	}
@@ -2,10 +2,16 @@
 package sourcearchive
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/archivefiles"
@@ -14,6 +20,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/git"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/archive"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -56,13 +63,43 @@ func (Pipe) Run(ctx *context.Context) error {
 		args = append(args, "--prefix", prefix)
 	}
 	args = append(args, ctx.Git.FullCommit)
+	if len(ctx.Config.Source.Exclude) > 0 {
+		args = append(args, "--", ".")
+		args = append(args, excludePathspecs(ctx.Config.Source.Exclude)...)
+	}
 
 	if _, err := git.Clean(git.Run(ctx, args...)); err != nil {
 		return err
 	}
 
-	if len(ctx.Config.Source.Files) > 0 {
-		if err := appendExtraFilesToArchive(ctx, prefix, path, format); err != nil {
+	files, err := archivefiles.Eval(tmpl.New(ctx), ctx.Config.Source.Files)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Config.Source.Submodules {
+		subFiles, err := submoduleFiles(ctx)
+		if err != nil {
+			return err
+		}
+		files = append(files, subFiles...)
+	}
+
+	if ctx.Config.Source.Vendor {
+		tmp, err := os.MkdirTemp("", "goreleaser-vendor-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		vendorFiles, err := vendor(ctx, filepath.Join(tmp, "vendor"))
+		if err != nil {
+			return err
+		}
+		files = append(files, vendorFiles...)
+	}
+
+	if len(files) > 0 {
+		if err := appendExtraFilesToArchive(prefix, path, format, files); err != nil {
 			return err
 		}
 	}
@@ -78,7 +115,97 @@ func (Pipe) Run(ctx *context.Context) error {
 	return err
 }
 
-func appendExtraFilesToArchive(ctx *context.Context, prefix, name, format string) error {
+// excludePathspecs returns the git pathspecs excluding the given globs.
+func excludePathspecs(globs []string) []string {
+	result := make([]string, 0, len(globs))
+	for _, glob := range globs {
+		result = append(result, ":(exclude,glob)"+glob)
+	}
+	return result
+}
+
+// submoduleFiles returns the files tracked by the git submodules, which
+// git-archive does not include.
+func submoduleFiles(ctx *context.Context) ([]config.File, error) {
+	out, err := git.Run(ctx, "submodule", "status", "--recursive")
+	if err != nil {
+		return nil, fmt.Errorf("could not list submodules: %w", err)
+	}
+	var submodules []string
+	for line := range strings.SplitSeq(out, "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line[1:])
+		if len(fields) < 2 {
+			continue
+		}
+		switch line[0] {
+		case '-':
+			return nil, fmt.Errorf("submodule %s is not initialized", fields[1])
+		case '+', 'U':
+			return nil, fmt.Errorf("submodule %s is not at the commit recorded in the repository", fields[1])
+		}
+		submodules = append(submodules, fields[1]+"/")
+	}
+	if len(submodules) == 0 {
+		return nil, nil
+	}
+
+	args := []string{"ls-files", "-z", "--recurse-submodules", "--", "."}
+	args = append(args, excludePathspecs(ctx.Config.Source.Exclude)...)
+	out, err = git.Run(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not list submodule files: %w", err)
+	}
+	var result []config.File
+	for name := range strings.SplitSeq(out, "\x00") {
+		if !slices.ContainsFunc(submodules, func(sub string) bool {
+			return strings.HasPrefix(name, sub)
+		}) {
+			continue
+		}
+		result = append(result, config.File{
+			Source:      name,
+			Destination: name,
+		})
+	}
+	return result, nil
+}
+
+// vendor runs 'go mod vendor' into the given directory, and returns its
+// files to be added to the archive under 'vendor'.
+func vendor(ctx *context.Context, dir string) ([]config.File, error) {
+	gobin := cmp.Or(ctx.Config.GoMod.GoBinary, "go")
+	cmd := exec.CommandContext(ctx, gobin, "mod", "vendor", "-o", dir)
+	cmd.Env = append(ctx.Env.Strings(), ctx.Config.GoMod.Env...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to vendor dependencies: %w: %s", err, string(out))
+	}
+
+	var result []config.File
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == dir {
+			// no dependencies to vendor
+			return filepath.SkipDir
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		result = append(result, config.File{
+			Source:      path,
+			Destination: "vendor/" + filepath.ToSlash(rel),
+		})
+		return nil
+	})
+	return result, err
+}
+
+func appendExtraFilesToArchive(prefix, name, format string, files []config.File) error {
 	oldPath := name + ".bkp"
 	if err := gio.Copy(name, oldPath); err != nil {
 		return fmt.Errorf("failed make a backup of %q: %w", name, err)
@@ -103,10 +230,6 @@ func appendExtraFilesToArchive(ctx *context.Context, prefix, name, format string
 		return err
 	}
 
-	files, err := archivefiles.Eval(tmpl.New(ctx), ctx.Config.Source.Files)
-	if err != nil {
-		return err
-	}
 	for _, f := range files {
 		f.Destination = path.Join(prefix, f.Destination)
 		if err := arch.Add(f); err != nil {
@@ -2,6 +2,7 @@ package sourcearchive
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
@@ -150,6 +151,150 @@ func doVerifyTestArchive(tb testing.TB, ctx *context.Context, tmp, format string
 	require.Equal(tb, " (HEAD -> main, tag: v1.0.0)", string(version))
 }
 
+// runGit runs git with the given arguments in the given directory.
+func runGit(tb testing.TB, dir string, args ...string) {
+	tb.Helper()
+	args = append([]string{
+		"-C", dir,
+		"-c", "user.name=GoReleaser",
+		"-c", "user.email=test@goreleaser.github.com",
+		"-c", "commit.gpgSign=false",
+		"-c", "protocol.file.allow=always",
+	}, args...)
+	out, err := exec.CommandContext(tb.Context(), "git", args...).CombinedOutput()
+	require.NoError(tb, err, string(out))
+}
+
+func sourceCtx(tb testing.TB, source config.Source) *context.Context {
+	tb.Helper()
+	source.Enabled = true
+	source.Format = "tar.gz"
+	source.PrefixTemplate = "{{ .ProjectName }}-{{ .Version }}/"
+	ctx := testctx.WrapWithCfg(tb.Context(),
+		config.Project{
+			ProjectName: "foo",
+			Dist:        "dist",
+			Source:      source,
+		},
+		testctx.WithCommit("HEAD"),
+		testctx.WithVersion("1.0.0"),
+		testctx.WithCurrentTag("v1.0.0"))
+	require.NoError(tb, Pipe{}.Default(ctx))
+	return ctx
+}
+
+func TestArchiveExclude(t *testing.T) {
+	testlib.Mktmp(t)
+	require.NoError(t, os.Mkdir("dist", 0o744))
+	testlib.GitInit(t)
+	require.NoError(t, os.MkdirAll("docs/img", 0o755))
+	require.NoError(t, os.MkdirAll("pkg/testdata", 0o755))
+	require.NoError(t, os.WriteFile("main.go", []byte("package main"), 0o644))
+	require.NoError(t, os.WriteFile("docs/index.md", []byte("# docs"), 0o644))
+	require.NoError(t, os.WriteFile("docs/img/logo.png", []byte("png"), 0o644))
+	require.NoError(t, os.WriteFile("pkg/pkg.go", []byte("package pkg"), 0o644))
+	require.NoError(t, os.WriteFile("pkg/testdata/big.bin", []byte("big"), 0o644))
+	testlib.GitAdd(t)
+	testlib.GitCommit(t, "feat: first")
+
+	ctx := sourceCtx(t, config.Source{
+		Exclude: []string{"docs/**/*.png", "**/testdata/**"},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.ElementsMatch(t, []string{
+		"foo-1.0.0/",
+		"foo-1.0.0/docs/",
+		"foo-1.0.0/docs/index.md",
+		"foo-1.0.0/main.go",
+		"foo-1.0.0/pkg/",
+		"foo-1.0.0/pkg/pkg.go",
+	}, testlib.LsArchive(t, filepath.Join("dist", "foo-1.0.0.tar.gz"), "tar.gz"))
+}
+
+func TestArchiveSubmodules(t *testing.T) {
+	sub := t.TempDir()
+	runGit(t, sub, "init", "-q")
+	require.NoError(t, os.MkdirAll(filepath.Join(sub, "testdata"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sub, "lib.go"), []byte("package lib"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(sub, "testdata", "big.bin"), []byte("big"), 0o644))
+	runGit(t, sub, "add", "-A")
+	runGit(t, sub, "commit", "-q", "-m", "lib")
+
+	testlib.Mktmp(t)
+	require.NoError(t, os.Mkdir("dist", 0o744))
+	testlib.GitInit(t)
+	require.NoError(t, os.WriteFile("main.go", []byte("package main"), 0o644))
+	runGit(t, ".", "submodule", "add", "-q", sub, "third_party/lib")
+	testlib.GitAdd(t)
+	testlib.GitCommit(t, "feat: first")
+
+	t.Run("without submodules", func(t *testing.T) {
+		ctx := sourceCtx(t, config.Source{})
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.NotContains(
+			t,
+			testlib.LsArchive(t, filepath.Join("dist", "foo-1.0.0.tar.gz"), "tar.gz"),
+			"foo-1.0.0/third_party/lib/lib.go",
+		)
+		require.NoError(t, os.RemoveAll(filepath.Join("dist", "foo-1.0.0.tar.gz")))
+	})
+
+	t.Run("with submodules", func(t *testing.T) {
+		ctx := sourceCtx(t, config.Source{
+			Submodules: true,
+			Exclude:    []string{"**/testdata/**"},
+		})
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.ElementsMatch(t, []string{
+			"foo-1.0.0/",
+			"foo-1.0.0/.gitmodules",
+			"foo-1.0.0/main.go",
+			"foo-1.0.0/third_party/",
+			"foo-1.0.0/third_party/lib/",
+			"foo-1.0.0/third_party/lib/lib.go",
+		}, testlib.LsArchive(t, filepath.Join("dist", "foo-1.0.0.tar.gz"), "tar.gz"))
+		require.NoError(t, os.RemoveAll(filepath.Join("dist", "foo-1.0.0.tar.gz")))
+	})
+
+	t.Run("not initialized", func(t *testing.T) {
+		runGit(t, ".", "submodule", "deinit", "-q", "--all")
+		ctx := sourceCtx(t, config.Source{
+			Submodules: true,
+		})
+		require.EqualError(t, Pipe{}.Run(ctx), "submodule third_party/lib is not initialized")
+	})
+}
+
+func TestArchiveVendor(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses a shell script")
+	testlib.Mktmp(t)
+	require.NoError(t, os.Mkdir("dist", 0o744))
+	testlib.GitInit(t)
+	require.NoError(t, os.WriteFile("main.go", []byte("package main"), 0o644))
+	testlib.GitAdd(t)
+	testlib.GitCommit(t, "feat: first")
+
+	// fake go binary that writes a vendored module into the -o directory.
+	gobin := filepath.Join(t.TempDir(), "go")
+	require.NoError(t, os.WriteFile(gobin, []byte(`#!/bin/sh
+mkdir -p "$4/example.com/dep"
+echo "# example.com/dep v1.0.0" > "$4/modules.txt"
+echo "package dep" > "$4/example.com/dep/dep.go"
+`), 0o755))
+
+	ctx := sourceCtx(t, config.Source{
+		Vendor: true,
+	})
+	ctx.Config.GoMod.GoBinary = gobin
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.ElementsMatch(t, []string{
+		"foo-1.0.0/",
+		"foo-1.0.0/main.go",
+		"foo-1.0.0/vendor/modules.txt",
+		"foo-1.0.0/vendor/example.com/dep/dep.go",
+	}, testlib.LsArchive(t, filepath.Join("dist", "foo-1.0.0.tar.gz"), "tar.gz"))
+}
+
 func TestInvalidFormat(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Dist:        t.TempDir(),
@@ -2,12 +2,19 @@
 package teams
 
 import (
+	"encoding/json"
+	"fmt"
+
 	goteamsnotify "github.com/atc0005/go-teams-notify/v2"
+	"github.com/atc0005/go-teams-notify/v2/adaptivecard"
 	"github.com/atc0005/go-teams-notify/v2/messagecard"
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/announcement"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -16,6 +23,11 @@ const (
 	defaultIcon            = "https://goreleaser.com/static/avatar.png"
 	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
 	defaultMessageTitle    = `{{ .ProjectName }} {{ .Tag }} is out!`
+	defaultButtonTitle     = "View release"
+	defaultButtonURL       = "{{ .ReleaseURL }}"
+
+	FormatMessageCard  = "messagecard"
+	FormatAdaptiveCard = "adaptivecard"
 )
 
 type Pipe struct{}
@@ -43,16 +55,27 @@ func (p Pipe) Default(ctx *context.Context) error {
 	if ctx.Config.Announce.Teams.Color == "" {
 		ctx.Config.Announce.Teams.Color = defaultColor
 	}
+	if ctx.Config.Announce.Teams.Format == FormatAdaptiveCard && len(ctx.Config.Announce.Teams.Buttons) == 0 {
+		ctx.Config.Announce.Teams.Buttons = []config.AnnounceButton{{
+			Title: defaultButtonTitle,
+			URL:   defaultButtonURL,
+		}}
+	}
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
-	title, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Teams.TitleTemplate)
+// Preview returns the card that would be posted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	card, _, err := newCard(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
+	bts, err := json.MarshalIndent(card, "", "  ")
+	return string(bts), err
+}
 
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Teams.MessageTemplate)
+func (p Pipe) Announce(ctx *context.Context) error {
+	card, msg, err := newCard(ctx)
 	if err != nil {
 		return err
 	}
@@ -64,7 +87,47 @@ func (p Pipe) Announce(ctx *context.Context) error {
 
 	log.Infof("posting: '%s'", msg)
 
-	client := goteamsnotify.NewTeamsClient()
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	client := goteamsnotify.NewTeamsClient().SetHTTPClient(httpClient)
+
+	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+		return client.Send(cfg.Webhook, card)
+	}, retryx.IsNetworkError)
+}
+
+// newCard returns the card to post, in the configured format, and the
+// templated message.
+func newCard(ctx *context.Context) (goteamsnotify.TeamsMessage, string, error) {
+	title, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Teams.TitleTemplate)
+	if err != nil {
+		return nil, "", err
+	}
+
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Teams.MessageTemplate)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var card goteamsnotify.TeamsMessage
+	switch ctx.Config.Announce.Teams.Format {
+	case "", FormatMessageCard:
+		card, err = messageCard(ctx, title, msg)
+	case FormatAdaptiveCard:
+		card, err = adaptiveCard(ctx, title, msg)
+	default:
+		err = fmt.Errorf("teams: invalid format: %q", ctx.Config.Announce.Teams.Format)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return card, msg, nil
+}
+
+func messageCard(ctx *context.Context, title, msg string) (*messagecard.MessageCard, error) {
 	msgCard := messagecard.NewMessageCard()
 	msgCard.Summary = title
 	msgCard.ThemeColor = ctx.Config.Announce.Teams.Color
@@ -74,11 +137,59 @@ func (p Pipe) Announce(ctx *context.Context) error {
 	messageCardSection.ActivityText = msg
 	messageCardSection.Markdown = true
 	messageCardSection.ActivityImage = ctx.Config.Announce.Teams.IconURL
-	err = msgCard.AddSection(messageCardSection)
+	if err := msgCard.AddSection(messageCardSection); err != nil {
+		return nil, err
+	}
+	return msgCard, nil
+}
+
+func adaptiveCard(ctx *context.Context, title, msg string) (*adaptivecard.Message, error) {
+	t := tmpl.New(ctx).WithMessageTemplates()
+	card := adaptivecard.NewCard()
+	card.FallbackText = title
+	if err := card.AddElement(
+		false,
+		adaptivecard.NewTitleTextBlock(title, true),
+		adaptivecard.NewTextBlock(msg, true),
+	); err != nil {
+		return nil, err
+	}
+
+	var facts []adaptivecard.Fact
+	for _, fact := range ctx.Config.Announce.Teams.Facts {
+		fact := adaptivecard.Fact(fact)
+		if err := t.ApplyAll(&fact.Title, &fact.Value); err != nil {
+			return nil, err
+		}
+		facts = append(facts, fact)
+	}
+	if len(facts) > 0 {
+		factSet := adaptivecard.NewFactSet()
+		if err := factSet.AddFact(facts...); err != nil {
+			return nil, err
+		}
+		if err := card.AddFactSet(false, factSet); err != nil {
+			return nil, err
+		}
+	}
+
+	buttons, err := announcement.Buttons(ctx, ctx.Config.Announce.Teams.Buttons)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return retryx.Do(ctx, ctx.Config.Retry, func() error {
-		return client.Send(cfg.Webhook, msgCard)
-	}, retryx.IsNetworkError)
+	var actions []adaptivecard.Action
+	for _, button := range buttons {
+		action, err := adaptivecard.NewActionOpenURL(button.URL, button.Title)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	if len(actions) > 0 {
+		if err := card.AddAction(false, actions...); err != nil {
+			return nil, err
+		}
+	}
+
+	return adaptivecard.NewMessageFromCard(card)
 }
@@ -19,6 +19,21 @@ func TestDefault(t *testing.T) {
 	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Teams.MessageTemplate)
 }
 
+func TestDefaultAdaptiveCard(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Teams: config.Teams{
+				Format: FormatAdaptiveCard,
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, []config.AnnounceButton{{
+		Title: defaultButtonTitle,
+		URL:   defaultButtonURL,
+	}}, ctx.Config.Announce.Teams.Buttons)
+}
+
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
@@ -45,6 +60,123 @@ func TestAnnounceMissingEnv(t *testing.T) {
 	require.EqualError(t, Pipe{}.Announce(ctx), `env: environment variable "TEAMS_WEBHOOK" should not be empty`)
 }
 
+func TestAnnounceInvalidFormat(t *testing.T) {
+	t.Setenv("TEAMS_WEBHOOK", "https://example.webhook.office.com/foo")
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Teams: config.Teams{
+				Enabled: "true",
+				Format:  "nope",
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx), `teams: invalid format: "nope"`)
+}
+
+func TestPreview(t *testing.T) {
+	for _, format := range []string{FormatMessageCard, FormatAdaptiveCard} {
+		t.Run(format, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "foo",
+				Announce: config.Announce{
+					Teams: config.Teams{
+						Format: format,
+					},
+				},
+			}, testctx.WithCurrentTag("v1.2.3"))
+			ctx.ReleaseURL = "https://github.com/foo/bar/releases/tag/v1.2.3"
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			out, err := Pipe{}.Preview(ctx)
+			require.NoError(t, err)
+			require.Contains(t, out, "foo v1.2.3 is out!")
+			require.Contains(t, out, "foo v1.2.3 is out! Check it out at https://github.com/foo/bar/releases/tag/v1.2.3")
+		})
+	}
+}
+
+func TestAdaptiveCard(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Announce: config.Announce{
+			Teams: config.Teams{
+				Format: FormatAdaptiveCard,
+				Facts: []config.TeamsFact{
+					{Title: "Version", Value: "{{ .Version }}"},
+				},
+				Buttons: []config.AnnounceButton{
+					{Title: "Changelog", URL: "https://example.com/{{ .Tag }}"},
+				},
+			},
+		},
+	}, testctx.WithCurrentTag("v1.2.3"), testctx.WithVersion("1.2.3"))
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	msg, err := adaptiveCard(ctx, "title", "message")
+	require.NoError(t, err)
+	require.NoError(t, msg.Validate())
+	require.Len(t, msg.Attachments, 1)
+
+	card := msg.Attachments[0].Content
+	require.Equal(t, "title", card.FallbackText)
+	require.Len(t, card.Body, 3)
+	require.Equal(t, "title", card.Body[0].Text)
+	require.Equal(t, "message", card.Body[1].Text)
+	require.Len(t, card.Body[2].Facts, 1)
+	require.Equal(t, "Version", card.Body[2].Facts[0].Title)
+	require.Equal(t, "1.2.3", card.Body[2].Facts[0].Value)
+	require.Len(t, card.Actions, 1)
+	require.Equal(t, "Changelog", card.Actions[0].Title)
+	require.Equal(t, "https://example.com/v1.2.3", card.Actions[0].URL)
+}
+
+func TestAdaptiveCardDefaultButton(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Teams: config.Teams{
+				Format: FormatAdaptiveCard,
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	t.Run("no release url", func(t *testing.T) {
+		msg, err := adaptiveCard(ctx, "title", "message")
+		require.NoError(t, err)
+		require.Empty(t, msg.Attachments[0].Content.Actions)
+	})
+
+	t.Run("release url", func(t *testing.T) {
+		ctx.ReleaseURL = "https://github.com/foo/bar/releases/tag/v1.0.0"
+		msg, err := adaptiveCard(ctx, "title", "message")
+		require.NoError(t, err)
+		require.Len(t, msg.Attachments[0].Content.Actions, 1)
+		require.Equal(t, defaultButtonTitle, msg.Attachments[0].Content.Actions[0].Title)
+		require.Equal(t, ctx.ReleaseURL, msg.Attachments[0].Content.Actions[0].URL)
+	})
+}
+
+func TestAdaptiveCardInvalidTemplate(t *testing.T) {
+	for name, teams := range map[string]config.Teams{
+		"fact": {
+			Facts: []config.TeamsFact{{Title: "a", Value: "{{ .Foo }"}},
+		},
+		"button": {
+			Buttons: []config.AnnounceButton{{Title: "a", URL: "{{ .Foo }"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				Announce: config.Announce{Teams: teams},
+			})
+			_, err := adaptiveCard(ctx, "title", "message")
+			testlib.RequireTemplateError(t, err)
+		})
+	}
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 
 	"github.com/caarlos0/env/v11"
@@ -52,6 +53,16 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
+// Preview returns the message that would be sent.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	args, err := getMessageDetails(ctx)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.MarshalIndent(args, "", "  ")
+	return string(bts), err
+}
+
 func (Pipe) Announce(ctx *context.Context) error {
 	args, err := getMessageDetails(ctx)
 	if err != nil {
@@ -69,6 +80,11 @@ func (Pipe) Announce(ctx *context.Context) error {
 	}
 
 	log.Infof("posting: '%s'", args["text"])
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
 	return retryx.Do(ctx, ctx.Config.Retry, func() error {
 		request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", cfg.ConsumerToken), bytes.NewReader(payload))
 		if err != nil {
@@ -76,7 +92,7 @@ func (Pipe) Announce(ctx *context.Context) error {
 		}
 		request.Header.Set("Content-Type", "application/json")
 
-		resp, err := http.DefaultClient.Do(request)
+		resp, err := httpClient.Do(request)
 		if err != nil {
 			return retryx.HTTP(err, resp)
 		}
@@ -105,19 +121,19 @@ func getMessageDetails(ctx *context.Context) (map[string]any, error) {
 	if ctx.Config.Announce.Telegram.ParseMode != "" {
 		m["parse_mode"] = ctx.Config.Announce.Telegram.ParseMode
 	}
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Telegram.MessageTemplate)
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Telegram.MessageTemplate)
 	if err != nil {
 		return nil, err
 	}
 	m["text"] = msg
 
-	chatID, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Telegram.ChatID)
+	chatID, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Telegram.ChatID)
 	if err != nil {
 		return nil, err
 	}
 	m["chat_id"] = chatID
 
-	messageThreadIDStr, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Telegram.MessageThreadID)
+	messageThreadIDStr, err := tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Telegram.MessageThreadID)
 	if err != nil {
 		return nil, err
 	}
@@ -130,5 +146,12 @@ func getMessageDetails(ctx *context.Context) (map[string]any, error) {
 		m["message_thread_id"] = messageThreadID
 	}
 
+	if ctx.Config.Announce.Telegram.DisableNotification {
+		m["disable_notification"] = true
+	}
+	if ctx.Config.Announce.Telegram.DisableWebPagePreview {
+		m["link_preview_options"] = map[string]any{"is_disabled": true}
+	}
+
 	return m, nil
 }
@@ -1,6 +1,7 @@
 package telegram
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
@@ -112,6 +113,26 @@ func TestSkip(t *testing.T) {
 	})
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Announce: config.Announce{
+			Telegram: config.Telegram{
+				ChatID: "1230212",
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"chat_id": "1230212",
+		"parse_mode": "MarkdownV2",
+		"text": "foo v1\\.0\\.0 is out\\! Check it out at "
+	}`, out)
+}
+
 func TestGetMessageDetails(t *testing.T) {
 	t.Run("default message template", func(t *testing.T) {
 		ctx := testctx.WrapWithCfg(t.Context(),
@@ -130,5 +151,48 @@ func TestGetMessageDetails(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "1230212", args["chat_id"])
 		require.Equal(t, "foo v1\\.0\\.0 is out\\! Check it out at ", args["text"])
+		require.NotContains(t, args, "message_thread_id")
+		require.NotContains(t, args, "disable_notification")
+		require.NotContains(t, args, "link_preview_options")
+	})
+
+	t.Run("topic and silent", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(),
+			config.Project{
+				ProjectName: "foo",
+				Announce: config.Announce{
+					Telegram: config.Telegram{
+						ChatID:                "1230212",
+						MessageThreadID:       "{{ .Env.THREAD }}",
+						DisableNotification:   true,
+						DisableWebPagePreview: true,
+					},
+				},
+			},
+			testctx.WithCurrentTag("v1.0.0"),
+			testctx.WithEnv(map[string]string{"THREAD": "42"}))
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		args, err := getMessageDetails(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(42), args["message_thread_id"])
+		require.Equal(t, true, args["disable_notification"])
+		require.Equal(t, map[string]any{"is_disabled": true}, args["link_preview_options"])
+	})
+
+	t.Run("invalid thread id", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(),
+			config.Project{
+				Announce: config.Announce{
+					Telegram: config.Telegram{
+						ChatID:          "1230212",
+						MessageThreadID: "general",
+					},
+				},
+			})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		_, err := getMessageDetails(ctx)
+		require.ErrorIs(t, err, strconv.ErrSyntax)
 	})
 }
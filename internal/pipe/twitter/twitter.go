@@ -2,10 +2,13 @@
 package twitter
 
 import (
+	stdctx "context"
+
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
 	"github.com/dghubble/go-twitter/twitter"
 	"github.com/dghubble/oauth1"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -35,8 +38,13 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 
+// Preview returns the tweet that would be posted.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	return tmpl.New(ctx).WithMessageTemplates().Apply(ctx.Config.Announce.Twitter.MessageTemplate)
+}
+
 func (p Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Twitter.MessageTemplate)
+	msg, err := p.Preview(ctx)
 	if err != nil {
 		return err
 	}
@@ -46,10 +54,15 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return err
 	}
 
+	httpClient, err := httpclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
 	log.Infof("posting: '%s'", msg)
 	config := oauth1.NewConfig(cfg.ConsumerKey, cfg.ConsumerSecret)
 	token := oauth1.NewToken(cfg.AccessToken, cfg.AccessSecret)
-	client := twitter.NewClient(config.Client(oauth1.NoContext, token))
+	client := twitter.NewClient(config.Client(stdctx.WithValue(oauth1.NoContext, oauth1.HTTPClient, httpClient), token))
 	if err := retryx.Do(ctx, ctx.Config.Retry, func() error {
 		_, _, err := client.Statuses.Update(msg, nil)
 		return err
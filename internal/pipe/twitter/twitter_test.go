@@ -31,6 +31,18 @@ func TestAnnounceInvalidTemplate(t *testing.T) {
 	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "honk",
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/honk/honk/releases/tag/v1.0.0"
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	msg, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "honk v1.0.0 is out! Check it out at https://github.com/honk/honk/releases/tag/v1.0.0", msg)
+}
+
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
@@ -2,13 +2,15 @@ package universalbinary
 
 import "github.com/goreleaser/goreleaser/v2/internal/tmpl"
 
-type unitarget struct{}
+type unitarget struct {
+	goos string
+}
 
-func (unitarget) String() string { return "darwin_all" }
+func (t unitarget) String() string { return t.goos + "_all" }
 
-func (unitarget) Fields() map[string]string {
+func (t unitarget) Fields() map[string]string {
 	return map[string]string{
-		tmpl.KeyOS:   "darwin",
+		tmpl.KeyOS:   t.goos,
 		tmpl.KeyArch: "all",
 	}
 }
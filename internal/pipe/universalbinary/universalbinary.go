@@ -1,4 +1,5 @@
-// Package universalbinary can join multiple darwin binaries into a single universal binary.
+// Package universalbinary can join multiple binaries of the same OS into a
+// single universal binary.
 package universalbinary
 
 import (
@@ -45,6 +46,9 @@ func (Pipe) Default(ctx *context.Context) error {
 		if unibin.NameTemplate == "" {
 			unibin.NameTemplate = "{{ .ProjectName }}"
 		}
+		if goosFor(*unibin) != "darwin" && unibin.Cmd == "" {
+			return fmt.Errorf("universal_binaries: %s: cmd is required to create %s universal binaries", unibin.ID, unibin.Goos)
+		}
 		ids.Inc(unibin.ID)
 	}
 	return ids.Validate()
@@ -56,7 +60,7 @@ func (Pipe) Run(ctx *context.Context) error {
 	for _, unibin := range ctx.Config.UniversalBinaries {
 		g.Go(func() error {
 			opts := build.Options{
-				Target: unitarget{},
+				Target: unitarget{goos: goosFor(unibin)},
 			}
 			if !skips.Any(ctx, skips.PreBuildHooks) {
 				if err := runHook(ctx, &opts, unibin.Hooks.Pre); err != nil {
@@ -137,7 +141,6 @@ const (
 	align     = 1 << alignBits
 )
 
-// heavily based on https://github.com/randall77/makefat
 func makeUniversalBinary(ctx *context.Context, opts *build.Options, unibin config.UniversalBinary) error {
 	if err := tmpl.New(ctx).ApplyAll(
 		&unibin.NameTemplate,
@@ -148,7 +151,8 @@ func makeUniversalBinary(ctx *context.Context, opts *build.Options, unibin confi
 	name := unibin.NameTemplate
 	opts.Name = name
 
-	path := filepath.Join(ctx.Config.Dist, unibin.ID+"_darwin_all", name)
+	goos := goosFor(unibin)
+	path := filepath.Join(ctx.Config.Dist, unibin.ID+"_"+goos+"_all", name)
 	opts.Path = path
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
@@ -156,13 +160,70 @@ func makeUniversalBinary(ctx *context.Context, opts *build.Options, unibin confi
 
 	binaries := ctx.Artifacts.Filter(filterFor(unibin)).List()
 	if len(binaries) == 0 {
-		return pipe.Skipf("no darwin binaries found with ids: %s", strings.Join(unibin.IDs, ", "))
+		return pipe.Skipf("no %s binaries found with ids: %s", goos, strings.Join(unibin.IDs, ", "))
 	}
 
 	log.WithField("id", unibin.ID).
 		WithField("binary", path).
 		Infof("creating from %d binaries", len(binaries))
 
+	if unibin.Cmd != "" {
+		if err := runCmd(ctx, opts, unibin, binaries); err != nil {
+			return err
+		}
+	} else if err := makeFat(path, binaries); err != nil {
+		return err
+	}
+
+	if err := gio.Chtimes(path, unibin.ModTimestamp); err != nil {
+		return err
+	}
+
+	extra := map[string]any{}
+	maps.Copy(extra, binaries[0].Extra)
+	extra[artifact.ExtraReplaces] = unibin.Replace
+	extra[artifact.ExtraID] = unibin.ID
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:   artifact.UniversalBinary,
+		Name:   name,
+		Path:   path,
+		Goos:   goos,
+		Goarch: "all",
+		Extra:  extra,
+	})
+
+	return nil
+}
+
+// runCmd creates the universal binary using the configured command, with the
+// paths of all the binaries appended to it.
+func runCmd(ctx *context.Context, opts *build.Options, unibin config.UniversalBinary, binaries []*artifact.Artifact) error {
+	sh, err := tmpl.New(ctx).WithBuildOptions(*opts).Apply(unibin.Cmd)
+	if err != nil {
+		return err
+	}
+	cmd, err := shellwords.Parse(sh)
+	if err != nil {
+		return err
+	}
+	for _, bin := range binaries {
+		cmd = append(cmd, bin.Path)
+	}
+	log.WithField("cmd", cmd).Debug("running")
+	if err := shell.Run(ctx, "", cmd, ctx.Env.Strings(), false); err != nil {
+		return err
+	}
+	if _, err := os.Stat(opts.Path); err != nil {
+		return fmt.Errorf("universal binary was not created by %q: %w", sh, err)
+	}
+	return nil
+}
+
+// makeFat creates a macOS universal binary from the given binaries.
+//
+// heavily based on https://github.com/randall77/makefat
+func makeFat(path string, binaries []*artifact.Artifact) error {
 	var inputs []input
 	offset := int64(align)
 	for _, f := range binaries {
@@ -228,32 +289,21 @@ func makeUniversalBinary(ctx *context.Context, opts *build.Options, unibin confi
 	if err := out.Close(); err != nil {
 		return fmt.Errorf("failed to close file: %w", err)
 	}
+	return nil
+}
 
-	if err := gio.Chtimes(path, unibin.ModTimestamp); err != nil {
-		return err
+// goosFor returns the OS of the binaries to join, darwin by default.
+func goosFor(unibin config.UniversalBinary) string {
+	if unibin.Goos == "" {
+		return "darwin"
 	}
-
-	extra := map[string]any{}
-	maps.Copy(extra, binaries[0].Extra)
-	extra[artifact.ExtraReplaces] = unibin.Replace
-	extra[artifact.ExtraID] = unibin.ID
-
-	ctx.Artifacts.Add(&artifact.Artifact{
-		Type:   artifact.UniversalBinary,
-		Name:   name,
-		Path:   path,
-		Goos:   "darwin",
-		Goarch: "all",
-		Extra:  extra,
-	})
-
-	return nil
+	return unibin.Goos
 }
 
 func filterFor(unibin config.UniversalBinary) artifact.Filter {
 	return artifact.And(
 		artifact.ByType(artifact.Binary),
-		artifact.ByGoos("darwin"),
+		artifact.ByGoos(goosFor(unibin)),
 		artifact.ByIDs(unibin.IDs...),
 	)
 }
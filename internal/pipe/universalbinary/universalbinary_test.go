@@ -99,6 +99,28 @@ func TestDefault(t *testing.T) {
 
 		require.EqualError(t, Pipe{}.Default(ctx), `found 2 universal_binaries with the ID 'foo', please fix your config`)
 	})
+
+	t.Run("other os without cmd", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "proj",
+			UniversalBinaries: []config.UniversalBinary{
+				{Goos: "freebsd"},
+			},
+		})
+
+		require.EqualError(t, Pipe{}.Default(ctx), `universal_binaries: proj: cmd is required to create freebsd universal binaries`)
+	})
+
+	t.Run("other os with cmd", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "proj",
+			UniversalBinaries: []config.UniversalBinary{
+				{Goos: "freebsd", Cmd: "fatten -o {{ .Path }}"},
+			},
+		})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+	})
 }
 
 func TestSkip(t *testing.T) {
@@ -415,6 +437,81 @@ func TestRun(t *testing.T) {
 	})
 }
 
+func TestRunCmd(t *testing.T) {
+	testlib.SkipIfWindows(t, "uses a shell script")
+	dist := t.TempDir()
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Dist: dist,
+		UniversalBinaries: []config.UniversalBinary{
+			{
+				ID:           "foo",
+				IDs:          []string{"foo"},
+				NameTemplate: "foo",
+				Goos:         "freebsd",
+				Cmd:          `sh -c 'cat "$@" > {{ .Path }}' --`,
+				Hooks: config.BuildHookConfig{
+					Post: []config.Hook{
+						{Cmd: testlib.ShC(`echo "{{ .Os }} {{ .Arch }} {{ .Target }}" > {{ .Path }}.post`)},
+					},
+				},
+			},
+		},
+	})
+	for _, arch := range []string{"amd64", "arm64"} {
+		path := filepath.Join(dist, "foo_freebsd_"+arch, "foo")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(arch+"\n"), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:   "foo",
+			Path:   path,
+			Goos:   "freebsd",
+			Goarch: arch,
+			Type:   artifact.Binary,
+			Extra: map[string]any{
+				artifact.ExtraBinary: "foo",
+				artifact.ExtraID:     "foo",
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "foo",
+		Path:   filepath.Join(dist, "foo_darwin_arm64", "foo"),
+		Goos:   "darwin",
+		Goarch: "arm64",
+		Type:   artifact.Binary,
+		Extra: map[string]any{
+			artifact.ExtraBinary: "foo",
+			artifact.ExtraID:     "foo",
+		},
+	})
+
+	require.NoError(t, Pipe{}.Run(ctx))
+	unis := ctx.Artifacts.Filter(artifact.ByType(artifact.UniversalBinary)).List()
+	require.Len(t, unis, 1)
+	require.Equal(t, "freebsd", unis[0].Goos)
+	require.Equal(t, "all", unis[0].Goarch)
+	require.Equal(t, filepath.Join(dist, "foo_freebsd_all", "foo"), unis[0].Path)
+
+	bts, err := os.ReadFile(unis[0].Path)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"amd64", "arm64"}, strings.Fields(string(bts)))
+
+	bts, err = os.ReadFile(unis[0].Path + ".post")
+	require.NoError(t, err)
+	require.Equal(t, "freebsd all freebsd_all\n", string(bts))
+
+	t.Run("no output", func(t *testing.T) {
+		ctx.Config.UniversalBinaries[0].Cmd = "true"
+		ctx.Config.UniversalBinaries[0].NameTemplate = "bar"
+		require.ErrorContains(t, Pipe{}.Run(ctx), "universal binary was not created")
+	})
+
+	t.Run("bad cmd tmpl", func(t *testing.T) {
+		ctx.Config.UniversalBinaries[0].Cmd = "{{ .Nope }}"
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
+
 func checkUniversalBinary(tb testing.TB, unibin *artifact.Artifact) {
 	tb.Helper()
 
@@ -2,20 +2,35 @@
 package webhook
 
 import (
+	"cmp"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/google/uuid"
+	"github.com/goreleaser/goreleaser/v2/internal/announcement"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/gerrors"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/retryx"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -26,6 +41,14 @@ const (
 	UserAgentHeaderValue   = "goreleaser"
 	AuthorizationHeaderKey = "Authorization"
 	DefaultContentType     = "application/json; charset=utf-8"
+	DefaultSignatureHeader = "X-Hub-Signature-256"
+	DefaultSignaturePrefix = "sha256="
+
+	FormatRaw               = "raw"
+	FormatCloudEvents       = "cloudevents"
+	CloudEventsContentType  = "application/cloudevents+json; charset=utf-8"
+	defaultCloudEventSource = "{{ .ProjectName }}"
+	defaultCloudEventType   = "com.goreleaser.release.published"
 )
 
 var defaultExpectedStatusCodes = []int{
@@ -36,8 +59,8 @@ type Pipe struct{}
 
 func (Pipe) String() string { return "webhook" }
 func (Pipe) Skip(ctx *context.Context) (bool, error) {
-	enable, err := tmpl.New(ctx).Bool(ctx.Config.Announce.Webhook.Enabled)
-	return !enable, err
+	webhooks, err := enabledWebhooks(ctx)
+	return len(webhooks) == 0, err
 }
 
 type Config struct {
@@ -46,62 +69,144 @@ type Config struct {
 }
 
 func (p Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Webhook.MessageTemplate == "" {
-		ctx.Config.Announce.Webhook.MessageTemplate = defaultMessageTemplate
+	setDefaults(&ctx.Config.Announce.Webhook)
+	for i := range ctx.Config.Announce.Webhooks {
+		setDefaults(&ctx.Config.Announce.Webhooks[i])
 	}
-	if ctx.Config.Announce.Webhook.ContentType == "" {
-		ctx.Config.Announce.Webhook.ContentType = DefaultContentType
+	return nil
+}
+
+func setDefaults(webhook *config.Webhook) {
+	if webhook.MessageTemplate == "" {
+		webhook.MessageTemplate = defaultMessageTemplate
 	}
-	if len(ctx.Config.Announce.Webhook.ExpectedStatusCodes) == 0 {
-		ctx.Config.Announce.Webhook.ExpectedStatusCodes = defaultExpectedStatusCodes
+	if webhook.ContentType == "" {
+		webhook.ContentType = DefaultContentType
+	}
+	if len(webhook.ExpectedStatusCodes) == 0 {
+		webhook.ExpectedStatusCodes = defaultExpectedStatusCodes
+	}
+	if webhook.Format == FormatCloudEvents {
+		if webhook.CloudEvents.Source == "" {
+			webhook.CloudEvents.Source = defaultCloudEventSource
+		}
+		if webhook.CloudEvents.Type == "" {
+			webhook.CloudEvents.Type = defaultCloudEventType
+		}
+	}
+	if sig := &webhook.Signature; sig.Secret != "" {
+		if sig.Header == "" {
+			sig.Header = DefaultSignatureHeader
+		}
+		if sig.Encoding == "" {
+			sig.Encoding = "hex"
+		}
+		if sig.Prefix == nil {
+			sig.Prefix = new(DefaultSignaturePrefix)
+		}
 	}
-	return nil
+}
+
+// enabledWebhooks returns both the single webhook and the webhooks list
+// entries that are enabled.
+func enabledWebhooks(ctx *context.Context) ([]config.Webhook, error) {
+	var result []config.Webhook
+	all := append([]config.Webhook{ctx.Config.Announce.Webhook}, ctx.Config.Announce.Webhooks...)
+	for _, webhook := range all {
+		enable, err := tmpl.New(ctx).Bool(webhook.Enabled)
+		if err != nil {
+			return nil, err
+		}
+		if enable {
+			result = append(result, webhook)
+		}
+	}
+	return result, nil
 }
 
 func (p Pipe) Announce(ctx *context.Context) error {
-	cfg, err := env.ParseAs[Config]()
+	webhooks, err := enabledWebhooks(ctx)
 	if err != nil {
 		return err
 	}
 
-	endpointURLConfig, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Webhook.EndpointURL)
+	var errs []error
+	for _, webhook := range webhooks {
+		if err := announce(ctx, webhook); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Preview returns the requests that would be sent to each enabled webhook.
+func (Pipe) Preview(ctx *context.Context) (string, error) {
+	webhooks, err := enabledWebhooks(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
-	if len(endpointURLConfig) == 0 {
-		return errors.New("no endpoint url")
+
+	var sb strings.Builder
+	for i, webhook := range webhooks {
+		endpointURL, contentType, msg, err := request(ctx, webhook)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		fmt.Fprintf(&sb, "POST %s\n%s: %s\n\n%s", endpointURL, ContentTypeHeaderKey, contentType, msg)
 	}
+	return sb.String(), nil
+}
 
-	if _, err := url.ParseRequestURI(endpointURLConfig); err != nil {
+func announce(ctx *context.Context, webhook config.Webhook) error {
+	cfg, err := env.ParseAs[Config]()
+	if err != nil {
 		return err
 	}
-	endpointURL, err := url.Parse(endpointURLConfig)
+
+	endpointURL, contentType, msg, err := request(ctx, webhook)
 	if err != nil {
 		return err
 	}
 
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Webhook.MessageTemplate)
+	signature, err := sign(ctx, webhook.Signature, msg)
 	if err != nil {
 		return err
 	}
 
-	log.Infof("posting: '%s'", msg)
-	customTransport := http.DefaultTransport.(*http.Transport).Clone()
+	headers := make(map[string]string, len(webhook.Headers))
+	for key, value := range webhook.Headers {
+		headers[key], err = tmpl.New(ctx).WithMessageTemplates().Apply(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	tlsCfg, err := tlsConfig(ctx, webhook)
+	if err != nil {
+		return err
+	}
 
-	customTransport.TLSClientConfig = &tls.Config{
-		InsecureSkipVerify: ctx.Config.Announce.Webhook.SkipTLSVerify,
+	customTransport, err := httpclient.Transport(ctx)
+	if err != nil {
+		return err
 	}
+	customTransport.TLSClientConfig = tlsCfg
+
+	log.Infof("posting: '%s'", msg)
 
 	client := &http.Client{
 		Transport: customTransport,
 	}
 
-	return retryx.Do(ctx, ctx.Config.Retry, func() error {
+	return retryx.Do(ctx, retryConfig(ctx, webhook), func() error {
 		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL.String(), strings.NewReader(msg))
 		if err != nil {
 			return retryx.Unrecoverable(err)
 		}
-		req.Header.Add(ContentTypeHeaderKey, ctx.Config.Announce.Webhook.ContentType)
+		req.Header.Add(ContentTypeHeaderKey, contentType)
 		req.Header.Add(UserAgentHeaderKey, UserAgentHeaderValue)
 
 		if cfg.BasicAuthHeader != "" {
@@ -112,7 +217,12 @@ func (p Pipe) Announce(ctx *context.Context) error {
 			req.Header.Add(AuthorizationHeaderKey, cfg.BearerTokenHeader)
 		}
 
-		for key, value := range ctx.Config.Announce.Webhook.Headers {
+		if signature != "" {
+			log.Debugf("set signature header")
+			req.Header.Set(webhook.Signature.Header, signature)
+		}
+
+		for key, value := range headers {
 			log.Debugf("custom header set: %s", key)
 			req.Header.Add(key, value)
 		}
@@ -123,7 +233,7 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		}
 		defer resp.Body.Close()
 
-		if !slices.Contains(ctx.Config.Announce.Webhook.ExpectedStatusCodes, resp.StatusCode) {
+		if !slices.Contains(webhook.ExpectedStatusCodes, resp.StatusCode) {
 			body, _ := io.ReadAll(resp.Body)
 			return retryx.HTTP(gerrors.Wrap(
 				fmt.Errorf("request failed with status %v", resp.Status),
@@ -135,5 +245,236 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		log.Infof("Post OK: '%v'", resp.StatusCode)
 		log.Infof("Response : %v\n", string(body))
 		return nil
-	}, retryx.IsRetriable)
+	}, retryIf(webhook.RetryOnStatusCodes))
+}
+
+// request returns the endpoint, the content type and the body of the request
+// to send to the given webhook.
+func request(ctx *context.Context, webhook config.Webhook) (*url.URL, string, string, error) {
+	endpointURLConfig, err := tmpl.New(ctx).WithMessageTemplates().Apply(webhook.EndpointURL)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if len(endpointURLConfig) == 0 {
+		return nil, "", "", errors.New("no endpoint url")
+	}
+
+	if _, err := url.ParseRequestURI(endpointURLConfig); err != nil {
+		return nil, "", "", err
+	}
+	endpointURL, err := url.Parse(endpointURLConfig)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	msg, err := tmpl.New(ctx).WithMessageTemplates().Apply(webhook.MessageTemplate)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if webhook.IncludeArtifacts {
+		msg, err = withArtifacts(ctx, msg)
+		if err != nil {
+			return nil, "", "", err
+		}
+	}
+
+	contentType := webhook.ContentType
+	switch webhook.Format {
+	case "", FormatRaw:
+	case FormatCloudEvents:
+		msg, err = cloudEvent(ctx, webhook, msg)
+		if err != nil {
+			return nil, "", "", err
+		}
+		contentType = CloudEventsContentType
+	default:
+		return nil, "", "", fmt.Errorf("invalid format: %q", webhook.Format)
+	}
+	return endpointURL, contentType, msg, nil
+}
+
+// tlsConfig returns the TLS configuration for the given webhook, loading the
+// client certificate and custom CA, if any.
+func tlsConfig(ctx *context.Context, webhook config.Webhook) (*tls.Config, error) {
+	cfg := &tls.Config{
+		InsecureSkipVerify: webhook.SkipTLSVerify,
+	}
+
+	certFile, keyFile, caFile := webhook.ClientCert, webhook.ClientKey, webhook.CAFile
+	if err := tmpl.New(ctx).WithMessageTemplates().ApplyAll(&certFile, &keyFile, &caFile); err != nil {
+		return nil, err
+	}
+
+	if certFile != "" && keyFile == "" {
+		return nil, errors.New("'client_key' must be set when 'client_cert' is set")
+	}
+	if keyFile != "" && certFile == "" {
+		return nil, errors.New("'client_cert' must be set when 'client_key' is set")
+	}
+	if certFile != "" && keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		bts, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bts) {
+			return nil, fmt.Errorf("no certificates could be added from %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
+
+// retryConfig returns the retry configuration of the given webhook, falling
+// back to the global one.
+func retryConfig(ctx *context.Context, webhook config.Webhook) config.Retry {
+	return config.Retry{
+		Attempts: cmp.Or(webhook.Retry.Attempts, ctx.Config.Retry.Attempts),
+		Delay:    cmp.Or(webhook.Retry.Delay, ctx.Config.Retry.Delay),
+		MaxDelay: cmp.Or(webhook.Retry.MaxDelay, ctx.Config.Retry.MaxDelay),
+	}
+}
+
+// retryIf returns the retry condition for the given status codes.
+// If no status codes are given, the default retry condition is used.
+// Network errors are always retried.
+func retryIf(codes []int) func(error) bool {
+	if len(codes) == 0 {
+		return retryx.IsRetriable
+	}
+	return func(err error) bool {
+		if he, ok := errors.AsType[retryx.HTTPError](err); ok && he.Status != 0 {
+			return slices.Contains(codes, he.Status)
+		}
+		return retryx.IsRetriable(err)
+	}
+}
+
+type artifactPayload struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Type        string `json:"type"`
+	Goos        string `json:"goos,omitempty"`
+	Goarch      string `json:"goarch,omitempty"`
+	Checksum    string `json:"checksum,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// withArtifacts adds the list of released artifacts to the given message,
+// which must be a JSON object, under the "artifacts" key.
+func withArtifacts(ctx *context.Context, msg string) (string, error) {
+	var payload map[string]any
+	dec := json.NewDecoder(strings.NewReader(msg))
+	dec.UseNumber()
+	if err := dec.Decode(&payload); err != nil {
+		return "", fmt.Errorf("include_artifacts requires the message to be a JSON object: %w", err)
+	}
+	if payload == nil {
+		return "", errors.New("include_artifacts requires the message to be a JSON object")
+	}
+
+	urlTemplate := announcement.ReleaseURLTemplate(ctx)
+	artifacts := []artifactPayload{}
+	for _, a := range ctx.Artifacts.Filter(artifact.And(
+		artifact.ByTypes(artifact.ReleaseUploadableTypes()...),
+		artifact.ByIDs(ctx.Config.Release.IDs...),
+	)).List() {
+		item := artifactPayload{
+			Name:     a.Name,
+			Path:     filepath.ToSlash(a.Path),
+			Type:     a.Type.String(),
+			Goos:     a.Goos,
+			Goarch:   a.Goarch,
+			Checksum: artifact.ExtraOr(*a, artifact.ExtraChecksum, ""),
+		}
+		if urlTemplate != "" {
+			downloadURL, err := tmpl.New(ctx).WithArtifact(a).Apply(urlTemplate)
+			if err != nil {
+				return "", err
+			}
+			item.DownloadURL = downloadURL
+		}
+		artifacts = append(artifacts, item)
+	}
+	payload["artifacts"] = artifacts
+
+	bts, err := json.Marshal(payload)
+	return string(bts), err
+}
+
+// cloudEvent wraps the given message in a CloudEvents 1.0 envelope, using
+// the structured content mode.
+// If the message is valid JSON, it is embedded as is, otherwise it is sent as
+// a string.
+func cloudEvent(ctx *context.Context, webhook config.Webhook, msg string) (string, error) {
+	source, typ, subject := webhook.CloudEvents.Source, webhook.CloudEvents.Type, webhook.CloudEvents.Subject
+	if err := tmpl.New(ctx).WithMessageTemplates().ApplyAll(&source, &typ, &subject); err != nil {
+		return "", err
+	}
+	if source == "" {
+		return "", errors.New("cloudevents: source is required")
+	}
+	if typ == "" {
+		return "", errors.New("cloudevents: type is required")
+	}
+
+	event := map[string]any{
+		"specversion":     "1.0",
+		"id":              uuid.NewString(),
+		"source":          source,
+		"type":            typ,
+		"time":            time.Now().UTC().Format(time.RFC3339),
+		"datacontenttype": webhook.ContentType,
+	}
+	if subject != "" {
+		event["subject"] = subject
+	}
+	if json.Valid([]byte(msg)) {
+		event["data"] = json.RawMessage(msg)
+	} else {
+		event["data"] = msg
+	}
+
+	bts, err := json.Marshal(event)
+	return string(bts), err
+}
+
+// sign returns the value of the signature header for the given body, or an
+// empty string if signing is not configured.
+func sign(ctx *context.Context, cfg config.WebhookSignature, body string) (string, error) {
+	secret, err := tmpl.New(ctx).WithMessageTemplates().Apply(cfg.Secret)
+	if err != nil {
+		return "", err
+	}
+	if secret == "" {
+		return "", nil
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(body))
+	sum := mac.Sum(nil)
+
+	var prefix string
+	if cfg.Prefix != nil {
+		prefix = *cfg.Prefix
+	}
+
+	switch cfg.Encoding {
+	case "", "hex":
+		return prefix + hex.EncodeToString(sum), nil
+	case "base64":
+		return prefix + base64.StdEncoding.EncodeToString(sum), nil
+	default:
+		return "", fmt.Errorf("invalid signature encoding: %q", cfg.Encoding)
+	}
 }
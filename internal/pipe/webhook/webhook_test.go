@@ -1,15 +1,31 @@
 package webhook
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
@@ -24,7 +40,9 @@ func TestStringer(t *testing.T) {
 func TestNoEndpoint(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
-			Webhook: config.Webhook{},
+			Webhook: config.Webhook{
+				Enabled: "true",
+			},
 		},
 	})
 
@@ -36,6 +54,7 @@ func TestMalformedEndpoint(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
 			Webhook: config.Webhook{
+				Enabled:     "true",
 				EndpointURL: "httxxx://example.com",
 			},
 		},
@@ -48,6 +67,7 @@ func TestAnnounceInvalidMessageTemplate(t *testing.T) {
 	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
 		Announce: config.Announce{
 			Webhook: config.Webhook{
+				Enabled:         "true",
 				EndpointURL:     "https://example.com/webhook",
 				MessageTemplate: "{{ .Foo }",
 			},
@@ -87,6 +107,7 @@ func TestAnnounceWebhook(t *testing.T) {
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
 			Webhook: config.Webhook{
+				Enabled:         "true",
 				EndpointURL:     srv.URL,
 				MessageTemplate: "{{ .ProjectName }}",
 			},
@@ -97,6 +118,57 @@ func TestAnnounceWebhook(t *testing.T) {
 	require.NoError(t, Pipe{}.Announce(ctx))
 }
 
+func TestPreview(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "webhook-test",
+		Announce: config.Announce{
+			Webhook: config.Webhook{
+				Enabled:         "true",
+				EndpointURL:     "https://example.com/{{ .ProjectName }}",
+				MessageTemplate: `{"project":"{{ .ProjectName }}"}`,
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, `POST https://example.com/webhook-test
+Content-Type: application/json; charset=utf-8
+
+{"project":"webhook-test"}`, out)
+}
+
+func TestDisabledWebhook(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Webhook: config.Webhook{
+				EndpointURL:     "https://example.com/disabled",
+				MessageTemplate: "disabled",
+			},
+			Webhooks: []config.Webhook{{
+				Enabled:         "true",
+				EndpointURL:     srv.URL,
+				MessageTemplate: "enabled",
+			}},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	out, err := Pipe{}.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "POST "+srv.URL+"\nContent-Type: application/json; charset=utf-8\n\nenabled", out)
+	require.NoError(t, Pipe{}.Announce(ctx))
+	require.Equal(t, int32(1), calls.Load())
+}
+
 func TestAnnounceTLSWebhook(t *testing.T) {
 	responseServer := WebHookServerMockMessage{
 		Response: "Thanks for the announcement!",
@@ -119,6 +191,7 @@ func TestAnnounceTLSWebhook(t *testing.T) {
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
 			Webhook: config.Webhook{
+				Enabled:         "true",
 				EndpointURL:     srv.URL,
 				MessageTemplate: "{{ .ProjectName }}",
 				SkipTLSVerify:   true,
@@ -149,6 +222,7 @@ func TestAnnounceTLSCheckCertWebhook(t *testing.T) {
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
 			Webhook: config.Webhook{
+				Enabled:       "true",
 				EndpointURL:   srv.URL,
 				SkipTLSVerify: false,
 			},
@@ -159,6 +233,124 @@ func TestAnnounceTLSCheckCertWebhook(t *testing.T) {
 	require.Error(t, Pipe{}.Announce(ctx))
 }
 
+func TestAnnounceMutualTLSWebhook(t *testing.T) {
+	dir := t.TempDir()
+	clientCert, clientKey, cert := generateClientCert(t, dir)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		assert.Len(t, r.TLS.PeerCertificates, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	}), 0o644))
+
+	webhook := config.Webhook{
+		Enabled:     "true",
+		EndpointURL: srv.URL,
+		ClientCert:  clientCert,
+		ClientKey:   clientKey,
+		CAFile:      caFile,
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{Webhook: webhook},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Announce(ctx))
+	})
+
+	t.Run("no client cert", func(t *testing.T) {
+		webhook := webhook
+		webhook.ClientCert = ""
+		webhook.ClientKey = ""
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{Webhook: webhook},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Error(t, Pipe{}.Announce(ctx))
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		webhook := webhook
+		webhook.ClientKey = ""
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{Webhook: webhook},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Announce(ctx), "'client_key' must be set when 'client_cert' is set")
+	})
+
+	t.Run("no cert", func(t *testing.T) {
+		webhook := webhook
+		webhook.ClientCert = ""
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{Webhook: webhook},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Announce(ctx), "'client_cert' must be set when 'client_key' is set")
+	})
+
+	t.Run("invalid ca file", func(t *testing.T) {
+		webhook := webhook
+		webhook.CAFile = clientKey
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{Webhook: webhook},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.ErrorContains(t, Pipe{}.Announce(ctx), "no certificates could be added from")
+	})
+
+	t.Run("missing ca file", func(t *testing.T) {
+		webhook := webhook
+		webhook.CAFile = filepath.Join(dir, "nope.pem")
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{Webhook: webhook},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.ErrorIs(t, Pipe{}.Announce(ctx), os.ErrNotExist)
+	})
+}
+
+func generateClientCert(tb testing.TB, dir string) (string, string, *x509.Certificate) {
+	tb.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(tb, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "goreleaser"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(tb, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(tb, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(tb, err)
+
+	certPath := filepath.Join(dir, "client.pem")
+	keyPath := filepath.Join(dir, "client.key")
+	require.NoError(tb, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644))
+	require.NoError(tb, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return certPath, keyPath, cert
+}
+
 func TestAnnounceBasicAuthWebhook(t *testing.T) {
 	responseServer := WebHookServerMockMessage{
 		Response: "Thanks for the announcement!",
@@ -187,6 +379,7 @@ func TestAnnounceBasicAuthWebhook(t *testing.T) {
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
 			Webhook: config.Webhook{
+				Enabled:         "true",
 				EndpointURL:     srv.URL,
 				MessageTemplate: "{{ .ProjectName }}",
 			},
@@ -225,6 +418,7 @@ func TestAnnounceAdditionalHeadersWebhook(t *testing.T) {
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
 			Webhook: config.Webhook{
+				Enabled:         "true",
 				EndpointURL:     srv.URL,
 				MessageTemplate: "{{ .ProjectName }}",
 				Headers: map[string]string{
@@ -238,6 +432,49 @@ func TestAnnounceAdditionalHeadersWebhook(t *testing.T) {
 	require.NoError(t, Pipe{}.Announce(ctx))
 }
 
+func TestAnnounceTemplatedHeadersWebhook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		assert.Equal(t, "v1.2.3", r.Header.Get("X-Release"))
+		assert.Equal(t, "Bearer secret-token", r.Header.Get("X-Auth"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Webhook: config.Webhook{
+				Enabled:     "true",
+				EndpointURL: srv.URL,
+				Headers: map[string]string{
+					"X-Release": "{{ .Tag }}",
+					"X-Auth":    "Bearer {{ .Env.WEBHOOK_TOKEN }}",
+				},
+			},
+		},
+	}, testctx.WithCurrentTag("v1.2.3"), testctx.WithEnv(map[string]string{"WEBHOOK_TOKEN": "secret-token"}))
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Webhook: config.Webhook{
+					Enabled:     "true",
+					EndpointURL: srv.URL,
+					Headers: map[string]string{
+						"X-Release": "{{ .Foo }",
+					},
+				},
+			},
+		})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	})
+}
+
 func TestAnnounceExpectedStatusCodesWebhook(t *testing.T) {
 	responseServer := WebHookServerMockMessage{
 		Response: "Thanks for the announcement!",
@@ -262,6 +499,7 @@ func TestAnnounceExpectedStatusCodesWebhook(t *testing.T) {
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
 			Webhook: config.Webhook{
+				Enabled:             "true",
 				EndpointURL:         srv.URL,
 				MessageTemplate:     "{{ .ProjectName }}",
 				ExpectedStatusCodes: []int{418},
@@ -273,6 +511,510 @@ func TestAnnounceExpectedStatusCodesWebhook(t *testing.T) {
 	require.NoError(t, Pipe{}.Announce(ctx))
 }
 
+func TestAnnounceSignedWebhook(t *testing.T) {
+	const secret = "s3cr3t"
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("webhook-test"))
+	sum := mac.Sum(nil)
+
+	for name, tc := range map[string]struct {
+		signature config.WebhookSignature
+		header    string
+		expected  string
+	}{
+		"default": {
+			signature: config.WebhookSignature{
+				Secret: "{{ .Env.WEBHOOK_SECRET }}",
+			},
+			header:   "X-Hub-Signature-256",
+			expected: "sha256=" + hex.EncodeToString(sum),
+		},
+		"base64": {
+			signature: config.WebhookSignature{
+				Secret:   secret,
+				Header:   "X-Signature",
+				Encoding: "base64",
+				Prefix:   new(""),
+			},
+			header:   "X-Signature",
+			expected: base64.StdEncoding.EncodeToString(sum),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				assert.Equal(t, tc.expected, r.Header.Get(tc.header))
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "webhook-test",
+				Announce: config.Announce{
+					Webhook: config.Webhook{
+						Enabled:         "true",
+						EndpointURL:     srv.URL,
+						MessageTemplate: "{{ .ProjectName }}",
+						Signature:       tc.signature,
+					},
+				},
+			}, testctx.WithEnv(map[string]string{"WEBHOOK_SECRET": secret}))
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Announce(ctx))
+		})
+	}
+
+	t.Run("invalid encoding", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Webhook: config.Webhook{
+					Enabled:     "true",
+					EndpointURL: "https://example.com/webhook",
+					Signature: config.WebhookSignature{
+						Secret:   secret,
+						Encoding: "base32",
+					},
+				},
+			},
+		})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Announce(ctx), `invalid signature encoding: "base32"`)
+	})
+
+	t.Run("invalid secret template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Webhook: config.Webhook{
+					Enabled:     "true",
+					EndpointURL: "https://example.com/webhook",
+					Signature: config.WebhookSignature{
+						Secret: "{{ .Foo }",
+					},
+				},
+			},
+		})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	})
+}
+
+func TestAnnounceRetryOnStatusCodesWebhook(t *testing.T) {
+	for name, tc := range map[string]struct {
+		codes    []int
+		status   int
+		attempts int32
+		fail     bool
+	}{
+		"default retries 502": {
+			status:   http.StatusBadGateway,
+			attempts: 2,
+		},
+		"default does not retry 409": {
+			status:   http.StatusConflict,
+			attempts: 1,
+			fail:     true,
+		},
+		"retries listed code": {
+			codes:    []int{http.StatusConflict},
+			status:   http.StatusConflict,
+			attempts: 2,
+		},
+		"does not retry unlisted code": {
+			codes:    []int{http.StatusConflict},
+			status:   http.StatusBadGateway,
+			attempts: 1,
+			fail:     true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if calls.Add(1) == 1 {
+					w.WriteHeader(tc.status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+				ProjectName: "webhook-test",
+				Retry: config.Retry{
+					Attempts: 3,
+					Delay:    time.Millisecond,
+				},
+				Announce: config.Announce{
+					Webhook: config.Webhook{
+						Enabled:            "true",
+						EndpointURL:        srv.URL,
+						RetryOnStatusCodes: tc.codes,
+					},
+				},
+			})
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			err := Pipe{}.Announce(ctx)
+			if tc.fail {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.attempts, calls.Load())
+		})
+	}
+}
+
+func TestAnnounceRetryWebhook(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Retry: config.Retry{
+			Attempts: 10,
+			Delay:    time.Hour,
+		},
+		Announce: config.Announce{
+			Webhook: config.Webhook{
+				Enabled:     "true",
+				EndpointURL: srv.URL,
+				Retry: config.Retry{
+					Attempts: 2,
+					Delay:    time.Millisecond,
+				},
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Error(t, Pipe{}.Announce(ctx))
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryConfig(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Retry: config.Retry{
+			Attempts: 10,
+			Delay:    time.Second,
+			MaxDelay: time.Minute,
+		},
+	})
+	require.Equal(t, ctx.Config.Retry, retryConfig(ctx, config.Webhook{}))
+	require.Equal(t, config.Retry{
+		Attempts: 3,
+		Delay:    time.Second,
+		MaxDelay: time.Hour,
+	}, retryConfig(ctx, config.Webhook{
+		Retry: config.Retry{Attempts: 3, MaxDelay: time.Hour},
+	}))
+}
+
+func TestAnnounceMultipleWebhooks(t *testing.T) {
+	var calls atomic.Int32
+	newServer := func(expected string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			calls.Add(1)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, string(body))
+			assert.Equal(t, expected, r.Header.Get("X-Name"))
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+	srv1 := newServer("first")
+	defer srv1.Close()
+	srv2 := newServer("second")
+	defer srv2.Close()
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Webhook: config.Webhook{
+				Enabled:         "true",
+				EndpointURL:     srv1.URL,
+				MessageTemplate: "first",
+				Headers:         map[string]string{"X-Name": "first"},
+			},
+			Webhooks: []config.Webhook{
+				{
+					Enabled:         "true",
+					EndpointURL:     srv2.URL,
+					MessageTemplate: "second",
+					Headers:         map[string]string{"X-Name": "second"},
+				},
+				{
+					Enabled:     "false",
+					EndpointURL: "https://example.com/disabled",
+				},
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestAnnounceMultipleWebhooksErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		Announce: config.Announce{
+			Webhooks: []config.Webhook{
+				{Enabled: "true"},
+				{Enabled: "true", EndpointURL: srv.URL},
+				{Enabled: "true", EndpointURL: "httxxx://example.com"},
+			},
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	err := Pipe{}.Announce(ctx)
+	require.ErrorContains(t, err, "no endpoint url")
+	require.ErrorContains(t, err, `unsupported protocol scheme "httxxx"`)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestAnnounceIncludeArtifactsWebhook(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		var err error
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Release: config.Release{
+			GitHub: config.Repo{
+				Owner: "goreleaser",
+				Name:  "foo",
+			},
+		},
+		GitHubURLs: config.GitHubURLs{
+			Download: "https://github.com",
+		},
+		Announce: config.Announce{
+			Webhook: config.Webhook{
+				Enabled:          "true",
+				EndpointURL:      srv.URL,
+				MessageTemplate:  `{"message": "{{ .ProjectName }} {{ .Tag }}", "count": 10}`,
+				IncludeArtifacts: true,
+			},
+		},
+	}, testctx.GitHubTokenType, testctx.WithCurrentTag("v1.0.0"))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "foo_linux_amd64.tar.gz",
+		Path:   "dist/foo_linux_amd64.tar.gz",
+		Goos:   "linux",
+		Goarch: "amd64",
+		Type:   artifact.UploadableArchive,
+		Extra: map[string]any{
+			artifact.ExtraChecksum: "sha256:abc",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "checksums.txt",
+		Path: "dist/checksums.txt",
+		Type: artifact.Checksum,
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "foo",
+		Path:   "dist/foo_linux_amd64_v1/foo",
+		Goos:   "linux",
+		Goarch: "amd64",
+		Type:   artifact.Binary,
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx))
+	require.JSONEq(t, `{
+		"message": "foo v1.0.0",
+		"count": 10,
+		"artifacts": [
+			{
+				"name": "foo_linux_amd64.tar.gz",
+				"path": "dist/foo_linux_amd64.tar.gz",
+				"type": "Archive",
+				"goos": "linux",
+				"goarch": "amd64",
+				"checksum": "sha256:abc",
+				"download_url": "https://github.com/goreleaser/foo/releases/download/v1.0.0/foo_linux_amd64.tar.gz"
+			},
+			{
+				"name": "checksums.txt",
+				"path": "dist/checksums.txt",
+				"type": "Checksum",
+				"download_url": "https://github.com/goreleaser/foo/releases/download/v1.0.0/checksums.txt"
+			}
+		]
+	}`, string(body))
+
+	t.Run("release disabled", func(t *testing.T) {
+		ctx.Config.Release.Disable = "true"
+		require.NoError(t, Pipe{}.Announce(ctx))
+		require.NotContains(t, string(body), "download_url")
+		require.Contains(t, string(body), "foo_linux_amd64.tar.gz")
+	})
+
+	t.Run("not a json object", func(t *testing.T) {
+		for _, tpl := range []string{"foo", "[1, 2]", "null"} {
+			ctx.Config.Announce.Webhook.MessageTemplate = tpl
+			require.ErrorContains(t, Pipe{}.Announce(ctx), "include_artifacts requires the message to be a JSON object")
+		}
+	})
+}
+
+func TestAnnounceCloudEventsWebhook(t *testing.T) {
+	var body []byte
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		var err error
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		contentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	type event struct {
+		SpecVersion     string          `json:"specversion"`
+		ID              string          `json:"id"`
+		Source          string          `json:"source"`
+		Type            string          `json:"type"`
+		Subject         string          `json:"subject"`
+		Time            time.Time       `json:"time"`
+		DataContentType string          `json:"datacontenttype"`
+		Data            json.RawMessage `json:"data"`
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			Announce: config.Announce{
+				Webhook: config.Webhook{
+					Enabled:         "true",
+					EndpointURL:     srv.URL,
+					MessageTemplate: `{"message": "{{ .ProjectName }} {{ .Tag }}"}`,
+					Format:          "cloudevents",
+				},
+			},
+		}, testctx.WithCurrentTag("v1.0.0"))
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Announce(ctx))
+		require.Equal(t, "application/cloudevents+json; charset=utf-8", contentType)
+
+		var ev event
+		require.NoError(t, json.Unmarshal(body, &ev))
+		require.Equal(t, "1.0", ev.SpecVersion)
+		require.NotEmpty(t, ev.ID)
+		require.Equal(t, "foo", ev.Source)
+		require.Equal(t, "com.goreleaser.release.published", ev.Type)
+		require.Empty(t, ev.Subject)
+		require.WithinDuration(t, time.Now(), ev.Time, time.Minute)
+		require.Equal(t, "application/json; charset=utf-8", ev.DataContentType)
+		require.JSONEq(t, `{"message": "foo v1.0.0"}`, string(ev.Data))
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			Announce: config.Announce{
+				Webhook: config.Webhook{
+					Enabled:         "true",
+					EndpointURL:     srv.URL,
+					MessageTemplate: `{{ .ProjectName }} {{ .Tag }} is out`,
+					ContentType:     "text/plain",
+					Format:          "cloudevents",
+					CloudEvents: config.CloudEvents{
+						Source:  "https://example.com/{{ .ProjectName }}",
+						Type:    "com.example.release",
+						Subject: "{{ .Tag }}",
+					},
+				},
+			},
+		}, testctx.WithCurrentTag("v1.0.0"))
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Announce(ctx))
+
+		var ev event
+		require.NoError(t, json.Unmarshal(body, &ev))
+		require.Equal(t, "https://example.com/foo", ev.Source)
+		require.Equal(t, "com.example.release", ev.Type)
+		require.Equal(t, "v1.0.0", ev.Subject)
+		require.Equal(t, "text/plain", ev.DataContentType)
+		require.JSONEq(t, `"foo v1.0.0 is out"`, string(ev.Data))
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Webhook: config.Webhook{
+					Enabled:     "true",
+					EndpointURL: srv.URL,
+					Format:      "cloudevents",
+					CloudEvents: config.CloudEvents{
+						Source: "{{ .Foo }",
+					},
+				},
+			},
+		})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	})
+
+	t.Run("empty source", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Webhook: config.Webhook{
+					Enabled:     "true",
+					EndpointURL: srv.URL,
+					Format:      "cloudevents",
+				},
+			},
+		})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Announce(ctx), "cloudevents: source is required")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Webhook: config.Webhook{
+					Enabled:     "true",
+					EndpointURL: srv.URL,
+					Format:      "jsonrpc",
+				},
+			},
+		})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Announce(ctx), `invalid format: "jsonrpc"`)
+	})
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		skip, err := Pipe{}.Skip(testctx.Wrap(t.Context()))
@@ -293,6 +1035,34 @@ func TestSkip(t *testing.T) {
 		require.NoError(t, err)
 		require.False(t, skip)
 	})
+
+	t.Run("dont skip list", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Webhooks: []config.Webhook{
+					{Enabled: "false"},
+					{Enabled: "true"},
+				},
+			},
+		})
+
+		skip, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Webhooks: []config.Webhook{
+					{Enabled: "{{ .Foo }"},
+				},
+			},
+		})
+
+		_, err := Pipe{}.Skip(ctx)
+		testlib.RequireTemplateError(t, err)
+	})
 }
 
 func TestDefault(t *testing.T) {
@@ -309,6 +1079,21 @@ func TestDefault(t *testing.T) {
 		require.NotEmpty(t, actual.ContentType)
 		require.NotEmpty(t, actual.ExpectedStatusCodes)
 	})
+	t.Run("list", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				Webhooks: []config.Webhook{{}, {ContentType: "text"}},
+			},
+		})
+
+		require.NoError(t, Pipe{}.Default(ctx))
+		for _, actual := range ctx.Config.Announce.Webhooks {
+			require.NotEmpty(t, actual.MessageTemplate)
+			require.NotEmpty(t, actual.ContentType)
+			require.NotEmpty(t, actual.ExpectedStatusCodes)
+		}
+		require.Equal(t, "text", ctx.Config.Announce.Webhooks[1].ContentType)
+	})
 	t.Run("not empty", func(t *testing.T) {
 		expected := config.Webhook{
 			MessageTemplate:     "foo",
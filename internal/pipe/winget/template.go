@@ -74,25 +74,39 @@ type InstallerItemFile struct {
 //nolint:tagliatelle
 type InstallerItem struct {
 	Architecture         string              `yaml:"Architecture,omitempty"`
+	InstallerType        string              `yaml:"InstallerType,omitempty"`
 	NestedInstallerType  string              `yaml:"NestedInstallerType,omitempty"`
 	NestedInstallerFiles []InstallerItemFile `yaml:"NestedInstallerFiles,omitempty"`
 	InstallerURL         string              `yaml:"InstallerUrl,omitempty"`
 	InstallerSha256      string              `yaml:"InstallerSha256,omitempty"`
+	ProductCode          string              `yaml:"ProductCode,omitempty"`
 	UpgradeBehavior      string              `yaml:"UpgradeBehavior,omitempty"`
 }
 
+//nolint:tagliatelle
+type InstallerSwitches struct {
+	Silent             string `yaml:"Silent,omitempty"`
+	SilentWithProgress string `yaml:"SilentWithProgress,omitempty"`
+	Interactive        string `yaml:"Interactive,omitempty"`
+	InstallLocation    string `yaml:"InstallLocation,omitempty"`
+	Log                string `yaml:"Log,omitempty"`
+	Upgrade            string `yaml:"Upgrade,omitempty"`
+	Custom             string `yaml:"Custom,omitempty"`
+}
+
 //nolint:tagliatelle
 type Installer struct {
-	PackageIdentifier string          `yaml:"PackageIdentifier,omitempty"`
-	PackageVersion    string          `yaml:"PackageVersion,omitempty"`
-	InstallerLocale   string          `yaml:"InstallerLocale,omitempty"`
-	InstallerType     string          `yaml:"InstallerType,omitempty"`
-	Commands          []string        `yaml:"Commands,omitempty"`
-	ReleaseDate       string          `yaml:"ReleaseDate,omitempty"`
-	Installers        []InstallerItem `yaml:"Installers,omitempty"`
-	ManifestType      string          `yaml:"ManifestType,omitempty"`
-	ManifestVersion   string          `yaml:"ManifestVersion,omitempty"`
-	Dependencies      Dependencies    `yaml:"Dependencies,omitempty"`
+	PackageIdentifier string            `yaml:"PackageIdentifier,omitempty"`
+	PackageVersion    string            `yaml:"PackageVersion,omitempty"`
+	InstallerLocale   string            `yaml:"InstallerLocale,omitempty"`
+	InstallerType     string            `yaml:"InstallerType,omitempty"`
+	InstallerSwitches InstallerSwitches `yaml:"InstallerSwitches,omitempty"`
+	Commands          []string          `yaml:"Commands,omitempty"`
+	ReleaseDate       string            `yaml:"ReleaseDate,omitempty"`
+	Installers        []InstallerItem   `yaml:"Installers,omitempty"`
+	ManifestType      string            `yaml:"ManifestType,omitempty"`
+	ManifestVersion   string            `yaml:"ManifestVersion,omitempty"`
+	Dependencies      Dependencies      `yaml:"Dependencies,omitempty"`
 }
 
 //nolint:tagliatelle
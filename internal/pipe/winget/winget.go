@@ -133,6 +133,14 @@ func (p Pipe) doRun(ctx *context.Context, winget config.Winget, cl client.Releas
 		&winget.License,
 		&winget.LicenseURL,
 		&winget.DefaultLocale,
+		&winget.ProductCode,
+		&winget.InstallerSwitches.Silent,
+		&winget.InstallerSwitches.SilentWithProgress,
+		&winget.InstallerSwitches.Interactive,
+		&winget.InstallerSwitches.InstallLocation,
+		&winget.InstallerSwitches.Log,
+		&winget.InstallerSwitches.Upgrade,
+		&winget.InstallerSwitches.Custom,
 	)
 	if err != nil {
 		return err
@@ -186,15 +194,14 @@ func (p Pipe) doRun(ctx *context.Context, winget config.Winget, cl client.Releas
 		}
 	}
 
+	formatsFilter, err := formatsFilterFor(winget.Formats)
+	if err != nil {
+		return err
+	}
+
 	filters := []artifact.Filter{
 		artifact.ByGoos("windows"),
-		artifact.Or(
-			artifact.And(
-				artifact.ByFormats("zip"),
-				artifact.ByType(artifact.UploadableArchive),
-			),
-			artifact.ByType(artifact.UploadableBinary),
-		),
+		formatsFilter,
 		artifact.Or(
 			artifact.ByGoarch("386"),
 			artifact.ByGoarch("arm64"),
@@ -365,7 +372,7 @@ func doPublish(ctx *context.Context, cl client.Client, wingets []*artifact.Artif
 		return errors.New("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, msg, winget.Repository.PullRequest.Draft)
+	return pcl.OpenPullRequest(ctx, base, repo, msg, winget.Repository.PullRequest.Body, winget.Repository.PullRequest.Draft)
 }
 
 func langserverLineFor(tp artifact.Type) string {
@@ -436,68 +443,115 @@ func makeInstaller(ctx *context.Context, winget config.Winget, archives []*artif
 		PackageIdentifier: winget.PackageIdentifier,
 		PackageVersion:    ctx.Version,
 		InstallerLocale:   winget.DefaultLocale,
-		InstallerType:     "zip",
 		Commands:          []string{},
 		ReleaseDate:       ctx.Date.Format(time.DateOnly),
 		Installers:        []InstallerItem{},
 		ManifestType:      "installer",
 		ManifestVersion:   manifestVersion,
+		InstallerSwitches: InstallerSwitches{
+			Silent:             winget.InstallerSwitches.Silent,
+			SilentWithProgress: winget.InstallerSwitches.SilentWithProgress,
+			Interactive:        winget.InstallerSwitches.Interactive,
+			InstallLocation:    winget.InstallerSwitches.InstallLocation,
+			Log:                winget.InstallerSwitches.Log,
+			Upgrade:            winget.InstallerSwitches.Upgrade,
+			Custom:             winget.InstallerSwitches.Custom,
+		},
 		Dependencies: Dependencies{
 			PackageDependencies: deps,
 		},
 	}
 
-	var amd64Count, i386count, zipCount, binaryCount int
+	seen := map[string]bool{}
 	for _, archive := range archives {
-		sha256, err := archive.Checksum("sha256")
-		if err != nil {
-			return Installer{}, err
-		}
-		url, err := tmpl.New(ctx).WithArtifact(archive).Apply(winget.URLTemplate)
-		if err != nil {
-			return Installer{}, err
-		}
 		item := InstallerItem{
-			Architecture:    fromGoArch[archive.Goarch],
-			InstallerURL:    url,
-			InstallerSha256: sha256,
-			UpgradeBehavior: "uninstallPrevious",
+			Architecture: fromGoArch[archive.Goarch],
 		}
 		switch archive.Type {
 		case artifact.UploadableArchive:
-			if archive.Format() != "zip" {
-				continue
-			}
-			zipCount++
-			installer.InstallerType = "zip"
+			item.InstallerType = "zip"
 			item.NestedInstallerType = "portable"
 			item.NestedInstallerFiles = installerItemFilesFor(*archive)
+			item.UpgradeBehavior = "uninstallPrevious"
 		case artifact.UploadableBinary:
-			binaryCount++
-			installer.InstallerType = "portable"
+			item.InstallerType = "portable"
+			item.UpgradeBehavior = "uninstallPrevious"
 			cmd := artifact.MustExtra[string](*archive, artifact.ExtraBinary)
 			installer.Commands = []string{cmd}
+		case artifact.MSI:
+			item.InstallerType = "msi"
+			item.ProductCode = winget.ProductCode
+		case artifact.MSIX:
+			item.InstallerType = "msix"
 		}
-		installer.Installers = append(installer.Installers, item)
-		switch archive.Goarch {
-		case "386":
-			i386count++
-		case "amd64":
-			amd64Count++
+
+		key := item.Architecture + "/" + item.InstallerType
+		if seen[key] {
+			return Installer{}, errMultipleArchives
+		}
+		seen[key] = true
+
+		sha256, err := archive.Checksum("sha256")
+		if err != nil {
+			return Installer{}, err
+		}
+		url, err := tmpl.New(ctx).WithArtifact(archive).Apply(winget.URLTemplate)
+		if err != nil {
+			return Installer{}, err
 		}
+		item.InstallerURL = url
+		item.InstallerSha256 = sha256
+		installer.Installers = append(installer.Installers, item)
+	}
+
+	types := map[string]bool{}
+	for _, item := range installer.Installers {
+		types[item.InstallerType] = true
 	}
 
-	if binaryCount > 0 && zipCount > 0 {
+	if len(winget.Formats) == 0 && types["zip"] && types["portable"] {
 		return Installer{}, errMixedFormats
 	}
 
-	if i386count > 1 || amd64Count > 1 {
-		return Installer{}, errMultipleArchives
+	// if all installers are of the same type, set it only once.
+	if len(types) == 1 {
+		installer.InstallerType = installer.Installers[0].InstallerType
+		for i := range installer.Installers {
+			installer.Installers[i].InstallerType = ""
+		}
 	}
 
 	return installer, nil
 }
 
+// formatsFilterFor returns a filter matching the artifacts of the given
+// winget formats.
+// If no formats are given, zip archives and binaries are matched.
+func formatsFilterFor(formats []string) (artifact.Filter, error) {
+	if len(formats) == 0 {
+		formats = []string{"zip", "binary"}
+	}
+	var filters []artifact.Filter
+	for _, format := range formats {
+		switch format {
+		case "zip":
+			filters = append(filters, artifact.And(
+				artifact.ByFormats("zip"),
+				artifact.ByType(artifact.UploadableArchive),
+			))
+		case "binary":
+			filters = append(filters, artifact.ByType(artifact.UploadableBinary))
+		case "msi":
+			filters = append(filters, artifact.ByType(artifact.MSI))
+		case "msix":
+			filters = append(filters, artifact.ByType(artifact.MSIX))
+		default:
+			return nil, fmt.Errorf("invalid winget format: %q", format)
+		}
+	}
+	return artifact.Or(filters...), nil
+}
+
 func fixTags(in []string) []string {
 	for i := range in {
 		in[i] = strings.ReplaceAll(strings.ToLower(in[i]), " ", "-")
@@ -14,7 +14,9 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/golden"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
 )
 
@@ -184,6 +186,7 @@ func TestRunPipe(t *testing.T) {
 							Owner: "ms",
 							Name:  "winget",
 						},
+						Body: "cc/ @foo",
 					},
 				},
 			},
@@ -750,7 +753,8 @@ func TestRunPipe(t *testing.T) {
 				Goarch: goarch,
 				Type:   artifact.UploadableBinary,
 				Extra: map[string]any{
-					artifact.ExtraID: "bar",
+					artifact.ExtraID:     "bar",
+					artifact.ExtraBinary: "bar",
 				},
 			})
 			createFakeArtifact("bar", goos, goarch, "v1", "", nil)
@@ -826,6 +830,7 @@ func TestRunPipe(t *testing.T) {
 			if tt.winget.Repository.PullRequest.Enabled {
 				require.True(t, client.SyncedFork)
 				require.True(t, client.OpenedPullRequest)
+				require.Equal(t, tt.winget.Repository.PullRequest.Body, client.PullRequestBody)
 			}
 		})
 	}
@@ -919,3 +924,113 @@ func TestFormatBinary(t *testing.T) {
 	require.NoError(t, pipe.publishAll(ctx, client))
 	require.True(t, client.CreatedFile)
 }
+
+func TestMultipleInstallers(t *testing.T) {
+	ctx := makeMultipleInstallersContext(t)
+
+	client := client.NewMock()
+	pipe := Pipe{}
+
+	require.NoError(t, pipe.runAll(ctx, client))
+	for _, winget := range ctx.Artifacts.Filter(artifact.ByTypes(
+		artifact.WingetInstaller,
+		artifact.WingetVersion,
+		artifact.WingetDefaultLocale,
+	)).List() {
+		bts, err := os.ReadFile(winget.Path)
+		require.NoError(t, err)
+		cfg := artifact.MustExtra[config.Winget](*winget, wingetConfigExtra)
+		golden.RequireEqualExtSubfolder(t, bts, extFor(winget.Type, cfg.DefaultLocale))
+	}
+}
+
+func TestMultipleInstallersErrors(t *testing.T) {
+	t.Run("invalid format", func(t *testing.T) {
+		ctx := makeMultipleInstallersContext(t)
+		ctx.Config.Winget[0].Formats = []string{"zip", "exe"}
+		require.EqualError(t, Pipe{}.runAll(ctx, client.NewMock()), `invalid winget format: "exe"`)
+	})
+	t.Run("multiple msis", func(t *testing.T) {
+		ctx := makeMultipleInstallersContext(t)
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "bar_windows_amd64v1.msi",
+			Path:    "doesnt-matter",
+			Goos:    "windows",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.MSI,
+			Extra: map[string]any{
+				artifact.ExtraID: "foo",
+			},
+		})
+		require.ErrorIs(t, Pipe{}.runAll(ctx, client.NewMock()), errMultipleArchives)
+	})
+	t.Run("bad switches template", func(t *testing.T) {
+		ctx := makeMultipleInstallersContext(t)
+		ctx.Config.Winget[0].InstallerSwitches.Log = "{{ .Nope }}"
+		testlib.RequireTemplateError(t, Pipe{}.runAll(ctx, client.NewMock()))
+	})
+}
+
+func makeMultipleInstallersContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	folder := tb.TempDir()
+	ctx := testctx.WrapWithCfg(tb.Context(),
+		config.Project{
+			Dist:        folder,
+			ProjectName: "foo",
+			Winget: []config.Winget{{
+				Name:             "foo",
+				Publisher:        "goreleaser",
+				License:          "MIT",
+				ShortDescription: "foo bar zaz",
+				IDs:              []string{"foo"},
+				Formats:          []string{"zip", "msi", "msix"},
+				ProductCode:      "{A1B2C3D4-0000-1111-2222-333344445555}",
+				InstallerSwitches: config.WingetInstallerSwitches{
+					Silent:             "/quiet",
+					SilentWithProgress: "/passive",
+					Custom:             "/norestart",
+				},
+				Repository: config.RepoRef{
+					Owner: "foo",
+					Name:  "bar",
+				},
+			}},
+		},
+		testctx.WithVersion("1.2.1"),
+		testctx.WithCurrentTag("v1.2.1"),
+		testctx.WithDate(time.Date(2023, 6, 12, 20, 32, 10, 12, time.Local)))
+	require.NoError(tb, Pipe{}.Default(ctx))
+
+	for _, p := range []struct {
+		goarch, goamd64, ext string
+		typ                  artifact.Type
+	}{
+		{"amd64", "v1", "zip", artifact.UploadableArchive},
+		{"arm64", "", "zip", artifact.UploadableArchive},
+		{"amd64", "v1", "msi", artifact.MSI},
+		{"arm64", "", "msi", artifact.MSI},
+		{"amd64", "v1", "msix", artifact.MSIX},
+		{"amd64", "v1", "tar.gz", artifact.UploadableArchive},
+	} {
+		name := "foo_windows_" + p.goarch + p.goamd64 + "." + p.ext
+		path := filepath.Join(folder, "dist", name)
+		require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(tb, os.WriteFile(path, nil, 0o644))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    name,
+			Path:    path,
+			Goos:    "windows",
+			Goarch:  p.goarch,
+			Goamd64: p.goamd64,
+			Type:    p.typ,
+			Extra: map[string]any{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraFormat:   p.ext,
+				artifact.ExtraBinaries: []string{"foo.exe"},
+			},
+		})
+	}
+	return ctx
+}
@@ -5,18 +5,26 @@ import (
 	"fmt"
 
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/announce"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/appbundle"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/appimage"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/archive"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/asdf"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/aur"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/aursources"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/before"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/binaryprocessor"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/brew"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/build"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/buildcache"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/buildpacks"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/cask"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/changelog"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/checksums"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/chocolatey"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/debugsymbols"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/defaults"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/dist"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/dmg"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/docker"
 	dockerv2 "github.com/goreleaser/goreleaser/v2/internal/pipe/docker/v2"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/effectiveconfig"
@@ -24,16 +32,24 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/flatpak"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/git"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/gomod"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/helm"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/ko"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/krew"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/licenses"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/links"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/makeself"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/metadata"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/msi"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nfpm"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nix"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/notary"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/npm"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/packagerepo"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/partial"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/prebuild"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/provenance"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/publish"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/pypi"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/reportsizes"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sbom"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/scoop"
@@ -75,6 +91,10 @@ var BuildPipeline = []Piper{
 	partial.Pipe{},
 	// snapshot version handling
 	snapshot.Pipe{},
+	// setup the shared build cache
+	buildcache.Pipe{},
+	// start recording the build in-toto link
+	links.Step{Name: "build"},
 	// run global hooks before build
 	before.Pipe{},
 	// ensure ./dist exists and is empty
@@ -97,12 +117,18 @@ var BuildPipeline = []Piper{
 	build.Pipe{},
 	// universal binary handling
 	universalbinary.Pipe{},
+	// split debug symbols out of the binaries
+	debugsymbols.Pipe{},
+	// binary processors, like strip and patchelf
+	binaryprocessor.Pipe{},
 	// upx
 	upx.Pipe{},
 	// sign binaries
 	sign.BinaryPipe{},
 	// notarize macos apps
 	notary.MacOS{},
+	// link identical binaries, after everything that changes them
+	build.DedupPipe{},
 }
 
 // BuildCmdPipeline is the pipeline run by goreleaser build.
@@ -114,11 +140,30 @@ var BuildCmdPipeline = append(
 	metadata.ArtifactsPipe{},
 )
 
+// AnnouncePipeline is the pipeline run by goreleaser announce, before
+// announcing.
+//
+//nolint:gochecknoglobals
+var AnnouncePipeline = []Piper{
+	// load and validate environment variables
+	env.Pipe{},
+	// get and validate git repo state
+	git.Pipe{},
+	// restore the context of the release from the dist directory
+	metadata.RestorePipe{},
+	// parse current tag to a semver
+	semver.Pipe{},
+	// load default configs
+	defaults.Pipe{},
+}
+
 // Pipeline contains all pipe implementations in order.
 //
 //nolint:gochecknoglobals
 var Pipeline = append(
 	BuildPipeline,
+	// start recording the archive in-toto link
+	links.Step{Name: "archive"},
 	// builds the release changelog
 	changelog.Pipe{},
 	// archive in tar.gz, zip or binary (which does no archiving at all)
@@ -131,16 +176,40 @@ var Pipeline = append(
 	srpm.Pipe{},
 	// create makeself self-extracting archives
 	makeself.Pipe{},
+	// create macOS app bundles
+	appbundle.Pipe{},
+	// create macOS disk images
+	dmg.Pipe{},
+	// create windows installers
+	msi.Pipe{},
 	// archive via snapcraft (snap)
 	snapcraft.Pipe{},
 	// create flatpak bundles
 	flatpak.Pipe{},
+	// create appimages
+	appimage.Pipe{},
+	// create apt and yum repositories
+	packagerepo.Pipe{},
+	// create npm packages
+	npm.Pipe{},
+	// create python wheels
+	pypi.Pipe{},
+	// package helm charts
+	helm.Pipe{},
 	// create SBOMs of artifacts
 	sbom.Pipe{},
+	// create the third-party licenses report
+	licenses.Pipe{},
 	// checksums of the files
 	checksums.Pipe{},
+	// start recording the sign in-toto link
+	links.Step{Name: "sign"},
 	// sign artifacts
 	sign.Pipe{},
+	// generate signed slsa provenance
+	provenance.Pipe{},
+	// start recording the publish in-toto link
+	links.Step{Name: "publish"},
 	// create arch linux aur pkgbuild
 	aur.Pipe{},
 	// create arch linux aur pkgbuild (sources)
@@ -155,6 +224,8 @@ var Pipeline = append(
 	cask.Pipe{},
 	// krew plugins
 	krew.Pipe{},
+	// asdf plugins
+	asdf.Pipe{},
 	// create scoop buckets
 	scoop.Pipe{},
 	// create chocolatey pkg and publish
@@ -166,6 +237,10 @@ var Pipeline = append(
 	dockerv2.Snapshot{},
 	// create and push docker images using ko
 	ko.Pipe{},
+	// create docker images using buildpacks
+	buildpacks.Pipe{},
+	// write the signed in-toto links
+	links.Pipe{},
 	// publishes artifacts
 	publish.New(),
 	// creates a artifacts.json files in the dist directory
@@ -26,6 +26,7 @@ func Run(ctx *context.Context, dir string, command, env []string, output bool) e
 	/* #nosec */
 	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
 	cmd.Env = env
+	ctx.InToto().Record(command)
 
 	var b bytes.Buffer
 	w := gio.Safe(&b)
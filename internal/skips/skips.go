@@ -38,6 +38,22 @@ const (
 	Archive        Key = "archive"
 	MCP            Key = "mcp"
 	SRPM           Key = "srpm"
+	DMG            Key = "dmg"
+	AppBundle      Key = "appbundle"
+	MSI            Key = "msi"
+	AppImage       Key = "appimage"
+	PackageRepo    Key = "package-repository"
+	Asdf           Key = "asdf"
+	NPM            Key = "npm"
+	PyPI           Key = "pypi"
+	Buildpacks     Key = "buildpacks"
+	OCIArtifacts   Key = "oci-artifacts"
+	DockerCleanup  Key = "docker-cleanup"
+	Helm           Key = "helm"
+	Provenance     Key = "provenance"
+	Attestations   Key = "attestations"
+	Licenses       Key = "licenses"
+	InToto         Key = "intoto"
 )
 
 func String(ctx *context.Context) string {
@@ -118,18 +134,34 @@ var Release = Keys{
 	SBOM,
 	Ko,
 	Docker,
+	Buildpacks,
+	OCIArtifacts,
+	DockerCleanup,
+	Helm,
+	Provenance,
+	Attestations,
+	Licenses,
+	InToto,
 	Winget,
 	Chocolatey,
 	Snapcraft,
 	Scoop,
 	Homebrew,
 	Nix,
+	Asdf,
+	NPM,
+	PyPI,
 	AUR,
 	AURSource,
 	NFPM,
 	Makeself,
 	Flatpak,
 	SRPM,
+	DMG,
+	AppBundle,
+	MSI,
+	AppImage,
+	PackageRepo,
 	Before,
 	Notarize,
 	Archive,
@@ -56,7 +56,7 @@ func TestSetAllowed(t *testing.T) {
 func TestComplete(t *testing.T) {
 	require.Equal(
 		t,
-		[]string{"announce", "archive", "aur", "aur-source"},
+		[]string{"announce", "appbundle", "appimage", "archive", "asdf", "attestations", "aur", "aur-source"},
 		skips.Release.Complete("a"),
 	)
 }
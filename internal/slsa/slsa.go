@@ -0,0 +1,114 @@
+// Package slsa builds SLSA v1 provenance predicates.
+//
+// See https://slsa.dev/spec/v1.0/provenance.
+package slsa
+
+import (
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// PredicateType is the in-toto predicate type of SLSA v1 provenance.
+const PredicateType = "https://slsa.dev/provenance/v1"
+
+// defaultBuilderID is used when we can't tell which CI system is running
+// GoReleaser.
+const defaultBuilderID = "https://goreleaser.com"
+
+// Predicate is a SLSA v1 provenance predicate.
+type Predicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+// BuildDefinition describes the inputs of the build.
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   map[string]any       `json:"externalParameters"`
+	InternalParameters   map[string]any       `json:"internalParameters,omitempty"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+// ResourceDescriptor describes a resource used by the build.
+type ResourceDescriptor struct {
+	URI    string            `json:"uri,omitempty"`
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// RunDetails describes who ran the build, and when.
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Builder identifies the build platform.
+type Builder struct {
+	ID string `json:"id"`
+}
+
+// Metadata of a build run.
+type Metadata struct {
+	InvocationID string     `json:"invocationId,omitempty"`
+	StartedOn    *time.Time `json:"startedOn,omitempty"`
+}
+
+// New creates a predicate for the current build, of the given type and with
+// the given external parameters.
+//
+// The source repository is added as a resolved dependency, and the builder
+// is identified from the environment.
+func New(ctx *context.Context, buildType string, params map[string]any) Predicate {
+	builderID, invocationID := builder(ctx)
+	p := Predicate{
+		BuildDefinition: BuildDefinition{
+			BuildType:          buildType,
+			ExternalParameters: params,
+		},
+		RunDetails: RunDetails{
+			Builder: Builder{ID: builderID},
+			Metadata: Metadata{
+				InvocationID: invocationID,
+			},
+		},
+	}
+	if !ctx.Date.IsZero() {
+		date := ctx.Date.UTC()
+		p.RunDetails.Metadata.StartedOn = &date
+	}
+	if ctx.Git.URL != "" && ctx.Git.FullCommit != "" {
+		uri := "git+" + ctx.Git.URL
+		if ctx.Git.CurrentTag != "" {
+			uri += "@refs/tags/" + ctx.Git.CurrentTag
+		}
+		p.BuildDefinition.ResolvedDependencies = append(
+			p.BuildDefinition.ResolvedDependencies,
+			ResourceDescriptor{
+				URI:    uri,
+				Digest: map[string]string{"gitCommit": ctx.Git.FullCommit},
+			},
+		)
+	}
+	return p
+}
+
+// builder returns the builder and invocation IDs from the CI environment, if
+// any.
+func builder(ctx *context.Context) (string, string) {
+	env := ctx.Env
+	if server, ref := env["GITHUB_SERVER_URL"], env["GITHUB_WORKFLOW_REF"]; server != "" && ref != "" {
+		var invocation string
+		if repo, run := env["GITHUB_REPOSITORY"], env["GITHUB_RUN_ID"]; repo != "" && run != "" {
+			invocation = server + "/" + repo + "/actions/runs/" + run
+			if attempt := env["GITHUB_RUN_ATTEMPT"]; attempt != "" {
+				invocation += "/attempts/" + attempt
+			}
+		}
+		return server + "/" + ref, invocation
+	}
+	if server, project := env["CI_SERVER_URL"], env["CI_PROJECT_PATH"]; server != "" && project != "" {
+		return server + "/" + project, env["CI_JOB_URL"]
+	}
+	return defaultBuilderID, ""
+}
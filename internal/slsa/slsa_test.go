@@ -0,0 +1,105 @@
+package slsa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	date := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{},
+		testctx.WithGitInfo(context.GitInfo{
+			URL:        "https://github.com/foo/bar",
+			FullCommit: "deadbeef",
+		}),
+		testctx.WithCurrentTag("v1.2.3"),
+		testctx.WithDate(date),
+		testctx.WithEnv(map[string]string{}),
+	)
+
+	p := New(ctx, "https://goreleaser.com/test/v1", map[string]any{"foo": "bar"})
+	require.Equal(t, "https://goreleaser.com/test/v1", p.BuildDefinition.BuildType)
+	require.Equal(t, map[string]any{"foo": "bar"}, p.BuildDefinition.ExternalParameters)
+	require.Equal(t, []ResourceDescriptor{{
+		URI:    "git+https://github.com/foo/bar@refs/tags/v1.2.3",
+		Digest: map[string]string{"gitCommit": "deadbeef"},
+	}}, p.BuildDefinition.ResolvedDependencies)
+	require.Equal(t, defaultBuilderID, p.RunDetails.Builder.ID)
+	require.Empty(t, p.RunDetails.Metadata.InvocationID)
+	require.Equal(t, &date, p.RunDetails.Metadata.StartedOn)
+
+	bts, err := json.Marshal(p)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"buildDefinition": {
+			"buildType": "https://goreleaser.com/test/v1",
+			"externalParameters": {"foo": "bar"},
+			"resolvedDependencies": [{
+				"uri": "git+https://github.com/foo/bar@refs/tags/v1.2.3",
+				"digest": {"gitCommit": "deadbeef"}
+			}]
+		},
+		"runDetails": {
+			"builder": {"id": "https://goreleaser.com"},
+			"metadata": {"startedOn": "2025-01-02T03:04:05Z"}
+		}
+	}`, string(bts))
+}
+
+func TestNewNoGit(t *testing.T) {
+	ctx := testctx.Wrap(t.Context())
+	p := New(ctx, "foo", nil)
+	require.Empty(t, p.BuildDefinition.ResolvedDependencies)
+}
+
+func TestBuilder(t *testing.T) {
+	for name, tc := range map[string]struct {
+		env        map[string]string
+		builder    string
+		invocation string
+	}{
+		"none": {
+			builder: defaultBuilderID,
+		},
+		"github": {
+			env: map[string]string{
+				"GITHUB_SERVER_URL":   "https://github.com",
+				"GITHUB_WORKFLOW_REF": "foo/bar/.github/workflows/release.yml@refs/tags/v1.2.3",
+				"GITHUB_REPOSITORY":   "foo/bar",
+				"GITHUB_RUN_ID":       "123",
+				"GITHUB_RUN_ATTEMPT":  "2",
+			},
+			builder:    "https://github.com/foo/bar/.github/workflows/release.yml@refs/tags/v1.2.3",
+			invocation: "https://github.com/foo/bar/actions/runs/123/attempts/2",
+		},
+		"github without run": {
+			env: map[string]string{
+				"GITHUB_SERVER_URL":   "https://github.com",
+				"GITHUB_WORKFLOW_REF": "foo/bar/.github/workflows/release.yml@refs/heads/main",
+			},
+			builder: "https://github.com/foo/bar/.github/workflows/release.yml@refs/heads/main",
+		},
+		"gitlab": {
+			env: map[string]string{
+				"CI_SERVER_URL":   "https://gitlab.com",
+				"CI_PROJECT_PATH": "foo/bar",
+				"CI_JOB_URL":      "https://gitlab.com/foo/bar/-/jobs/123",
+			},
+			builder:    "https://gitlab.com/foo/bar",
+			invocation: "https://gitlab.com/foo/bar/-/jobs/123",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.Wrap(t.Context(), testctx.WithEnv(tc.env))
+			builder, invocation := builder(ctx)
+			require.Equal(t, tc.builder, builder)
+			require.Equal(t, tc.invocation, invocation)
+		})
+	}
+}
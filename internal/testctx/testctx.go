@@ -29,6 +29,16 @@ func GiteaTokenType(ctx *context.Context) {
 	WithToken("giteatoken")(ctx)
 }
 
+func ForgejoTokenType(ctx *context.Context) {
+	WithTokenType(context.TokenTypeForgejo)(ctx)
+	WithToken("forgejotoken")(ctx)
+}
+
+func BitbucketTokenType(ctx *context.Context) {
+	WithTokenType(context.TokenTypeBitbucket)(ctx)
+	WithToken("bitbuckettoken")(ctx)
+}
+
 func WithTokenType(t context.TokenType) Opt {
 	return func(ctx *context.Context) {
 		ctx.TokenType = t
@@ -0,0 +1,98 @@
+package testlib
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+// Set sets the given variable to value, restoring it when the test finishes.
+func Set[T any](tb testing.TB, v *T, value T) {
+	tb.Helper()
+	previous := *v
+	*v = value
+	tb.Cleanup(func() { *v = previous })
+}
+
+// FakeCommands puts fake commands in the PATH, which log their name and
+// arguments to the returned file, and then run the given shell script.
+//
+// The calls can be read with [ReadFakeLog].
+func FakeCommands(tb testing.TB, scripts map[string]string) string {
+	tb.Helper()
+	SkipIfWindows(tb, "uses shell scripts")
+	bin := tb.TempDir()
+	log := filepath.Join(bin, "log")
+	for name, script := range scripts {
+		require.NoError(tb, os.WriteFile(filepath.Join(bin, name), []byte(`#!/bin/sh
+printf '%s\0' "`+name+` $*" >> "`+log+`"
+`+script), 0o755))
+	}
+	tb.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+// ReadFakeLog returns the calls logged by the commands created by
+// [FakeCommands], in order.
+func ReadFakeLog(tb testing.TB, path string) []string {
+	tb.Helper()
+	bts, err := os.ReadFile(path)
+	require.NoError(tb, err)
+	return strings.Split(strings.TrimSuffix(string(bts), "\x00"), "\x00")
+}
+
+// AddFakeBinaries adds a fake binary with each of the given names, for each
+// of the given goos and goarch, to the context.
+func AddFakeBinaries(tb testing.TB, ctx *context.Context, id string, goos, goarch []string, names ...string) {
+	tb.Helper()
+	tmp := tb.TempDir()
+	for _, name := range names {
+		require.NoError(tb, os.WriteFile(filepath.Join(tmp, name), []byte("bin"), 0o755))
+	}
+	for _, o := range goos {
+		for _, a := range goarch {
+			for _, name := range names {
+				ctx.Artifacts.Add(&artifact.Artifact{
+					Name:    name,
+					Path:    filepath.Join(tmp, name),
+					Type:    artifact.Binary,
+					Goos:    o,
+					Goarch:  a,
+					Goamd64: "v1",
+					Extra: map[string]any{
+						artifact.ExtraID:     id,
+						artifact.ExtraBinary: strings.TrimSuffix(name, ".exe"),
+					},
+				})
+			}
+		}
+	}
+}
+
+// FakeCosign is a fake cosign command, which records its calls.
+type FakeCosign struct {
+	// Calls has the name and arguments of each call.
+	Calls [][]string
+	// Fn answers each call, if set.
+	Fn func(args []string) ([]byte, error)
+
+	lock sync.Mutex
+}
+
+// Exec records the call, and answers it with Fn, if set.
+func (f *FakeCosign) Exec(_ *context.Context, name string, args ...string) ([]byte, error) {
+	call := append([]string{name}, args...)
+	f.lock.Lock()
+	f.Calls = append(f.Calls, call)
+	f.lock.Unlock()
+	if f.Fn == nil {
+		return nil, nil
+	}
+	return f.Fn(call)
+}
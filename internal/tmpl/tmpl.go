@@ -26,6 +26,11 @@ import (
 // Template holds data that can be applied to a template string.
 type Template struct {
 	fields Fields
+
+	// messageTemplates are only made available by WithMessageTemplates.
+	messageTemplates map[string]string
+	named            *template.Template
+	namedErr         error
 }
 
 // Fields that will be available to the template engine.
@@ -89,6 +94,9 @@ const (
 	artifactName = "ArtifactName"
 	artifactExt  = "ArtifactExt"
 	artifactPath = "ArtifactPath"
+	jvmGroup     = "JvmGroup"
+	jvmArtifact  = "JvmArtifact"
+	jvmVersion   = "JvmVersion"
 )
 
 // build keys.
@@ -148,7 +156,8 @@ func New(ctx *context.Context) *Template {
 	})
 
 	return &Template{
-		fields: fields,
+		fields:           fields,
+		messageTemplates: ctx.Config.Announce.MessageTemplates,
 	}
 }
 
@@ -212,6 +221,9 @@ func (t *Template) WithArtifact(a *artifact.Artifact) *Template {
 		artifactName: a.Name,
 		artifactExt:  a.Ext(),
 		artifactPath: a.Path,
+		jvmGroup:     artifact.ExtraOr(*a, artifact.ExtraJvmGroup, ""),
+		jvmArtifact:  artifact.ExtraOr(*a, artifact.ExtraJvmArtifact, ""),
+		jvmVersion:   artifact.ExtraOr(*a, artifact.ExtraJvmVersion, ""),
 	})
 }
 
@@ -268,62 +280,111 @@ func WithPrefix(prefix string) SliceOpt {
 	}
 }
 
+// rootName is the name of the template applied by Apply.
+const rootName = "tmpl"
+
+// WithMessageTemplates makes the announce message templates available with
+// {{ template "name" . }}.
+//
+// They are parsed only once, here, and a {{ define "name" }} in the applied
+// string takes precedence over them.
+func (t *Template) WithMessageTemplates() *Template {
+	tt := t.copying()
+	tt.named, tt.namedErr = t.parseMessageTemplates()
+	return tt
+}
+
+func (t *Template) parseMessageTemplates() (*template.Template, error) {
+	root := t.root()
+	for _, name := range slices.Sorted(maps.Keys(t.messageTemplates)) {
+		if name == rootName {
+			return nil, fmt.Errorf("invalid message template name %q: it is reserved", name)
+		}
+		if _, err := root.New(name).Parse(t.messageTemplates[name]); err != nil {
+			return nil, newTmplError(t.messageTemplates[name], err)
+		}
+	}
+	return root, nil
+}
+
+// root returns the template in which the string to apply is parsed.
+func (t *Template) root() *template.Template {
+	return template.New(rootName).
+		Option("missingkey=error").
+		Funcs(t.funcs())
+}
+
+func (t *Template) funcs() template.FuncMap {
+	return template.FuncMap{
+		"replace": strings.ReplaceAll,
+		"split":   strings.Split,
+		"time": func(s string) string {
+			return time.Now().UTC().Format(s)
+		},
+		"contains":       strings.Contains,
+		"tolower":        strings.ToLower,
+		"toupper":        strings.ToUpper,
+		"trim":           strings.TrimSpace,
+		"trimprefix":     strings.TrimPrefix,
+		"trimsuffix":     strings.TrimSuffix,
+		"title":          cases.Title(language.English).String,
+		"dir":            filepath.Dir,
+		"base":           filepath.Base,
+		"abs":            filepath.Abs,
+		"incmajor":       incMajor,
+		"incminor":       incMinor,
+		"incpatch":       incPatch,
+		"filter":         filter(false),
+		"reverseFilter":  filter(true),
+		"mdv2escape":     mdv2Escape,
+		"envOrDefault":   t.envOrDefault,
+		"isEnvSet":       t.isEnvSet,
+		"map":            makemap,
+		"indexOrDefault": indexOrDefault,
+		"urlPathEscape":  url.PathEscape,
+		"blake2b":        checksum("blake2b"),
+		"blake2s":        checksum("blake2s"),
+		"blake3":         checksum("blake3"),
+		"crc32":          checksum("crc32"),
+		"md5":            checksum("md5"),
+		"sha224":         checksum("sha224"),
+		"sha384":         checksum("sha384"),
+		"sha256":         checksum("sha256"),
+		"sha1":           checksum("sha1"),
+		"sha512":         checksum("sha512"),
+		"sha3_224":       checksum("sha3-224"),
+		"sha3_384":       checksum("sha3-384"),
+		"sha3_256":       checksum("sha3-256"),
+		"sha3_512":       checksum("sha3-512"),
+		"readFile":       readFile,
+		"mustReadFile":   mustReadFile,
+		"englishJoin":    englishJoin,
+		"list":           makeList,
+	}
+}
+
 // Apply applies the given string against the Fields stored in the template.
 func (t *Template) Apply(s string) (string, error) {
-	var out bytes.Buffer
-	tmpl, err := template.New("tmpl").
-		Option("missingkey=error").
-		Funcs(template.FuncMap{
-			"replace": strings.ReplaceAll,
-			"split":   strings.Split,
-			"time": func(s string) string {
-				return time.Now().UTC().Format(s)
-			},
-			"contains":       strings.Contains,
-			"tolower":        strings.ToLower,
-			"toupper":        strings.ToUpper,
-			"trim":           strings.TrimSpace,
-			"trimprefix":     strings.TrimPrefix,
-			"trimsuffix":     strings.TrimSuffix,
-			"title":          cases.Title(language.English).String,
-			"dir":            filepath.Dir,
-			"base":           filepath.Base,
-			"abs":            filepath.Abs,
-			"incmajor":       incMajor,
-			"incminor":       incMinor,
-			"incpatch":       incPatch,
-			"filter":         filter(false),
-			"reverseFilter":  filter(true),
-			"mdv2escape":     mdv2Escape,
-			"envOrDefault":   t.envOrDefault,
-			"isEnvSet":       t.isEnvSet,
-			"map":            makemap,
-			"indexOrDefault": indexOrDefault,
-			"urlPathEscape":  url.PathEscape,
-			"blake2b":        checksum("blake2b"),
-			"blake2s":        checksum("blake2s"),
-			"blake3":         checksum("blake3"),
-			"crc32":          checksum("crc32"),
-			"md5":            checksum("md5"),
-			"sha224":         checksum("sha224"),
-			"sha384":         checksum("sha384"),
-			"sha256":         checksum("sha256"),
-			"sha1":           checksum("sha1"),
-			"sha512":         checksum("sha512"),
-			"sha3_224":       checksum("sha3-224"),
-			"sha3_384":       checksum("sha3-384"),
-			"sha3_256":       checksum("sha3-256"),
-			"sha3_512":       checksum("sha3-512"),
-			"readFile":       readFile,
-			"mustReadFile":   mustReadFile,
-			"englishJoin":    englishJoin,
-			"list":           makeList,
-		}).
-		Parse(s)
+	if t.namedErr != nil {
+		return "", t.namedErr
+	}
+
+	tmpl := t.root()
+	if t.named != nil {
+		named, err := t.named.Clone()
+		if err != nil {
+			return "", err
+		}
+		// the functions need to be bound to this template's fields.
+		tmpl = named.Funcs(t.funcs())
+	}
+
+	tmpl, err := tmpl.Parse(s)
 	if err != nil {
 		return "", newTmplError(s, err)
 	}
 
+	var out bytes.Buffer
 	err = tmpl.Execute(&out, t.fields)
 	return out.String(), newTmplError(s, err)
 }
@@ -395,7 +456,10 @@ func (t *Template) envOrDefault(name, value string) string {
 
 func (t *Template) copying() *Template {
 	tpl := &Template{
-		fields: Fields{},
+		fields:           Fields{},
+		messageTemplates: t.messageTemplates,
+		named:            t.named,
+		namedErr:         t.namedErr,
 	}
 	maps.Copy(tpl.fields, t.fields)
 	return tpl
@@ -105,6 +105,7 @@ func TestWithArtifact(t *testing.T) {
 		"env foo: bar":                        `env foo: {{ envOrDefault "FOO" "barrrrr" }}`,
 		"env foo is set: true":                `env foo is set: {{ isEnvSet "FOO" }}`,
 		"/foo%2Fbar":                          `/{{ urlPathEscape .Env.WITH_SLASHES}}`,
+		"jvm: com.example:foo:1.0.0":          `jvm: {{ .JvmGroup }}:{{ .JvmArtifact }}:{{ .JvmVersion }}`,
 
 		"artifact dir: " + filepath.FromSlash("/tmp"): "artifact dir: {{ dir .ArtifactPath }}",
 
@@ -136,8 +137,11 @@ func TestWithArtifact(t *testing.T) {
 					Goriscv64: "rva22u64",
 					Target:    "a_fake_target",
 					Extra: map[string]any{
-						artifact.ExtraBinary: "binary",
-						artifact.ExtraExt:    ".exe",
+						artifact.ExtraBinary:      "binary",
+						artifact.ExtraExt:         ".exe",
+						artifact.ExtraJvmGroup:    "com.example",
+						artifact.ExtraJvmArtifact: "foo",
+						artifact.ExtraJvmVersion:  "1.0.0",
 					},
 				},
 			).Apply(tmpl)
@@ -611,6 +615,86 @@ func TestWithExtraFields(t *testing.T) {
 	require.Equal(t, "foo", out)
 }
 
+func TestMessageTemplates(t *testing.T) {
+	ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+		ProjectName: "foo",
+		Announce: config.Announce{
+			MessageTemplates: map[string]string{
+				"release": `{{ .ProjectName }} {{ .Tag }} is out!`,
+				"full":    `{{ template "release" . }} {{ .ReleaseURL }}`,
+			},
+		},
+	}, testctx.WithCurrentTag("v1.2.3"))
+	ctx.ReleaseURL = "https://example.com/v1.2.3"
+
+	t.Run("simple", func(t *testing.T) {
+		out, err := New(ctx).WithMessageTemplates().Apply(`{{ template "release" . }}`)
+		require.NoError(t, err)
+		require.Equal(t, "foo v1.2.3 is out!", out)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		out, err := New(ctx).WithMessageTemplates().WithExtraFields(Fields{"Extra": "yay"}).Apply(`{{ template "full" . }} {{ .Extra }}`)
+		require.NoError(t, err)
+		require.Equal(t, "foo v1.2.3 is out! https://example.com/v1.2.3 yay", out)
+	})
+
+	t.Run("applied many times", func(t *testing.T) {
+		tpl := New(ctx).WithMessageTemplates()
+		a, b := `{{ template "release" . }}`, `{{ template "full" . }}`
+		require.NoError(t, tpl.ApplyAll(&a, &b))
+		require.Equal(t, "foo v1.2.3 is out!", a)
+		require.Equal(t, "foo v1.2.3 is out! https://example.com/v1.2.3", b)
+	})
+
+	t.Run("define takes precedence", func(t *testing.T) {
+		out, err := New(ctx).WithMessageTemplates().Apply(`{{ define "release" }}overridden{{ end }}{{ template "full" . }}`)
+		require.NoError(t, err)
+		require.Equal(t, "overridden https://example.com/v1.2.3", out)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := New(ctx).WithMessageTemplates().Apply(`{{ template "nope" . }}`)
+		require.ErrorContains(t, err, `template "nope" not defined`)
+	})
+
+	t.Run("not opted in", func(t *testing.T) {
+		_, err := New(ctx).Apply(`{{ template "release" . }}`)
+		require.ErrorContains(t, err, `template "release" not defined`)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			ProjectName: "foo",
+			Announce: config.Announce{
+				MessageTemplates: map[string]string{
+					"bad": `{{ .Foo }`,
+				},
+			},
+		})
+		_, err := New(ctx).WithMessageTemplates().Apply(`{{ .ProjectName }}`)
+		require.ErrorAs(t, err, &Error{})
+		require.ErrorContains(t, err, `failed to apply "{{ .Foo }"`)
+
+		// other templates are not affected.
+		out, err := New(ctx).Apply(`{{ .ProjectName }}`)
+		require.NoError(t, err)
+		require.Equal(t, "foo", out)
+	})
+
+	t.Run("reserved name", func(t *testing.T) {
+		ctx := testctx.WrapWithCfg(t.Context(), config.Project{
+			Announce: config.Announce{
+				MessageTemplates: map[string]string{
+					"tmpl": `nope`,
+				},
+			},
+		})
+		_, err := New(ctx).WithMessageTemplates().Apply(`{{ .ProjectName }}`)
+		require.EqualError(t, err, `invalid message template name "tmpl": it is reserved`)
+	})
+}
+
 func TestBool(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
 		for _, v := range []string{
@@ -7,8 +7,10 @@ import (
 	"os"
 
 	"github.com/goreleaser/goreleaser/v2/pkg/archive/gzip"
+	"github.com/goreleaser/goreleaser/v2/pkg/archive/sevenzip"
 	"github.com/goreleaser/goreleaser/v2/pkg/archive/tar"
 	"github.com/goreleaser/goreleaser/v2/pkg/archive/targz"
+	"github.com/goreleaser/goreleaser/v2/pkg/archive/tarlz4"
 	"github.com/goreleaser/goreleaser/v2/pkg/archive/tarxz"
 	"github.com/goreleaser/goreleaser/v2/pkg/archive/tarzst"
 	"github.com/goreleaser/goreleaser/v2/pkg/archive/xz"
@@ -24,25 +26,45 @@ type Archive interface {
 
 // New archive.
 func New(w io.Writer, format string) (Archive, error) {
+	return NewWithLevel(w, format, 0)
+}
+
+// NewWithLevel creates a new archive with the given compression level.
+// The level is ignored by the formats that don't support it (see
+// [SupportsLevel]), and 0 means the format's default.
+func NewWithLevel(w io.Writer, format string, level int) (Archive, error) {
 	switch format {
 	case "tar.gz", "tgz":
-		return targz.New(w), nil
+		return targz.NewWithLevel(w, level), nil
 	case "tar":
 		return tar.New(w), nil
 	case "gz":
-		return gzip.New(w), nil
+		return gzip.NewWithLevel(w, level), nil
 	case "tar.xz", "txz":
 		return tarxz.New(w), nil
 	case "tar.zst", "tzst":
-		return tarzst.New(w), nil
+		return tarzst.NewWithLevel(w, level), nil
+	case "tar.lz4", "tlz4":
+		return tarlz4.NewWithLevel(w, level), nil
 	case "zip":
-		return zip.New(w), nil
+		return zip.NewWithLevel(w, level), nil
 	case "xz":
 		return xz.New(w), nil
+	case "7z":
+		return sevenzip.New(w), nil
 	}
 	return nil, fmt.Errorf("invalid archive format: %s", format)
 }
 
+// SupportsLevel reports whether the given format can use a compression level.
+func SupportsLevel(format string) bool {
+	switch format {
+	case "tar.gz", "tgz", "gz", "tar.zst", "tzst", "tar.lz4", "tlz4", "zip":
+		return true
+	}
+	return false
+}
+
 // Copy copies the source archive into a new one, which can be appended at.
 // Source needs to be in the specified format.
 func Copy(r *os.File, w io.Writer, format string) (Archive, error) {
@@ -18,7 +18,7 @@ func TestArchive(t *testing.T) {
 	require.NoError(t, empty.Close())
 	require.NoError(t, os.Mkdir(folder+"/folder-inside", 0o755))
 
-	for _, format := range []string{"tar.gz", "zip", "gz", "tar.xz", "tar", "tgz", "txz", "tar.zst", "tzst", "xz"} {
+	for _, format := range []string{"tar.gz", "zip", "gz", "tar.xz", "tar", "tgz", "txz", "tar.zst", "tzst", "tar.lz4", "tlz4", "xz", "7z"} {
 		t.Run(format, func(t *testing.T) {
 			f1, err := os.Create(filepath.Join(t.TempDir(), "1.tar"))
 			require.NoError(t, err)
@@ -36,7 +36,7 @@ func TestArchive(t *testing.T) {
 			require.NoError(t, archive.Close())
 			require.NoError(t, f1.Close())
 
-			if format == "tar.xz" || format == "txz" || format == "gz" || format == "tar.zst" || format == "tzst" || format == "xz" {
+			if format == "tar.xz" || format == "txz" || format == "gz" || format == "tar.zst" || format == "tzst" || format == "tar.lz4" || format == "tlz4" || format == "xz" || format == "7z" {
 				_, err := Copy(f1, io.Discard, format)
 				require.Error(t, err)
 				return
@@ -71,8 +71,35 @@ func TestArchive(t *testing.T) {
 	}
 
 	// unsupported format...
-	t.Run("7z", func(t *testing.T) {
-		_, err := New(io.Discard, "7z")
-		require.EqualError(t, err, "invalid archive format: 7z")
+	t.Run("rar", func(t *testing.T) {
+		_, err := New(io.Discard, "rar")
+		require.EqualError(t, err, "invalid archive format: rar")
 	})
 }
+
+func TestArchiveWithLevel(t *testing.T) {
+	for _, format := range []string{"tar.gz", "gz", "zip"} {
+		t.Run(format, func(t *testing.T) {
+			require.True(t, SupportsLevel(format))
+			for _, level := range []int{0, 1, 9, 30} {
+				f, err := os.Create(filepath.Join(t.TempDir(), "test."+format))
+				require.NoError(t, err)
+				a, err := NewWithLevel(f, format, level)
+				require.NoError(t, err)
+				require.NoError(t, a.Add(config.File{
+					Source:      "./testdata/foo.txt",
+					Destination: "foo.txt",
+				}))
+				require.NoError(t, a.Close())
+				require.NoError(t, f.Close())
+				require.Equal(t, []string{"foo.txt"}, testlib.LsArchive(t, f.Name(), format))
+			}
+		})
+	}
+
+	for _, format := range []string{"tar", "tar.xz", "xz"} {
+		t.Run(format, func(t *testing.T) {
+			require.False(t, SupportsLevel(format))
+		})
+	}
+}
@@ -18,8 +18,18 @@ type Archive struct {
 
 // New gz archive.
 func New(target io.Writer) Archive {
+	return NewWithLevel(target, 0)
+}
+
+// NewWithLevel creates a new gz archive with the given compression level,
+// from 1 (fastest) to 9 (best compression).
+// Level 0 uses the best compression.
+func NewWithLevel(target io.Writer, level int) Archive {
+	if level <= 0 {
+		level = gzip.BestCompression
+	}
 	// the error will be nil since the compression level is valid
-	gw, _ := gzip.NewWriterLevel(target, gzip.BestCompression)
+	gw, _ := gzip.NewWriterLevel(target, min(level, gzip.BestCompression))
 	return Archive{
 		gw: gw,
 	}
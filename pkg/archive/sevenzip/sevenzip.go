@@ -0,0 +1,374 @@
+// Package sevenzip implements the Archive interface providing 7z archiving
+// and LZMA2 compression.
+package sevenzip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+	"unicode/utf16"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/ulikunitz/xz/lzma"
+)
+
+const dictCap = 16 * 1024 * 1024
+
+var signature = []byte{'7', 'z', 0xBC, 0xAF, 0x27, 0x1C}
+
+// property ids, as defined in the 7z format specification.
+const (
+	idEnd             = 0x00
+	idHeader          = 0x01
+	idMainStreamsInfo = 0x04
+	idFilesInfo       = 0x05
+	idPackInfo        = 0x06
+	idUnpackInfo      = 0x07
+	idSubStreamsInfo  = 0x08
+	idSize            = 0x09
+	idCRC             = 0x0A
+	idFolder          = 0x0B
+	idCodersUnpack    = 0x0C
+	idNumUnpackStream = 0x0D
+	idEmptyStream     = 0x0E
+	idEmptyFile       = 0x0F
+	idName            = 0x11
+	idMTime           = 0x14
+	idAttributes      = 0x15
+)
+
+// lzma2 is the id of the LZMA2 coder.
+const lzma2 = 0x21
+
+// Windows file attributes.
+// The unix extension bit means the higher 16 bits hold the unix file mode.
+const (
+	attrDirectory     = 0x10
+	attrUnixExtension = 0x8000
+)
+
+type entry struct {
+	name  string
+	size  uint64
+	crc   uint32
+	mtime time.Time
+	mode  fs.FileMode
+}
+
+// Archive as 7z.
+//
+// All files are compressed together into a single LZMA2 stream, which is
+// buffered into a temporary file, as the 7z header can only be written once
+// all files were added.
+type Archive struct {
+	target  io.Writer
+	tmp     *os.File
+	lw      *lzma.Writer2
+	entries []entry
+	files   map[string]bool
+	closed  bool
+}
+
+// New 7z archive.
+func New(target io.Writer) *Archive {
+	return &Archive{
+		target: target,
+		files:  map[string]bool{},
+	}
+}
+
+// Add file to the archive.
+func (a *Archive) Add(f config.File) error {
+	if a.closed {
+		return errors.New("7z: archive already closed")
+	}
+	if _, ok := a.files[f.Destination]; ok {
+		return &fs.PathError{Err: fs.ErrExist, Path: f.Destination, Op: "add"}
+	}
+	a.files[f.Destination] = true
+	info, err := os.Lstat(f.Source) // #nosec
+	if err != nil {
+		return err
+	}
+	e := entry{
+		name:  filepath.ToSlash(f.Destination),
+		mtime: info.ModTime(),
+		mode:  info.Mode(),
+	}
+	if !f.Info.ParsedMTime.IsZero() {
+		e.mtime = f.Info.ParsedMTime
+	}
+	if f.Info.Mode != 0 {
+		e.mode = e.mode.Type() | f.Info.Mode
+	}
+	if info.IsDir() {
+		a.entries = append(a.entries, e)
+		return nil
+	}
+
+	var r io.Reader
+	if info.Mode()&os.ModeSymlink != 0 {
+		link, err := os.Readlink(f.Source) // #nosec
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Source, err)
+		}
+		r = bytes.NewReader([]byte(filepath.ToSlash(link)))
+	} else {
+		file, err := os.Open(f.Source) // #nosec
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	if a.lw == nil {
+		if err := a.init(); err != nil {
+			return err
+		}
+	}
+	h := crc32.NewIEEE()
+	n, err := io.Copy(io.MultiWriter(a.lw, h), r)
+	if err != nil {
+		return err
+	}
+	e.size = uint64(n)
+	e.crc = h.Sum32()
+	a.entries = append(a.entries, e)
+	return nil
+}
+
+func (a *Archive) init() error {
+	tmp, err := os.CreateTemp("", "goreleaser-7z-*")
+	if err != nil {
+		return err
+	}
+	lw, err := lzma.Writer2Config{DictCap: dictCap}.NewWriter2(tmp)
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	a.tmp = tmp
+	a.lw = lw
+	return nil
+}
+
+// Close writes the archive to the target.
+func (a *Archive) Close() error {
+	if a.closed {
+		return nil
+	}
+	a.closed = true
+
+	var packSize uint64
+	if a.tmp != nil {
+		defer os.Remove(a.tmp.Name())
+		defer a.tmp.Close()
+		if err := a.lw.Close(); err != nil {
+			return err
+		}
+		size, err := a.tmp.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		if _, err := a.tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		packSize = uint64(size)
+	}
+
+	// empty archives have no header at all.
+	var header []byte
+	if len(a.entries) > 0 {
+		header = a.header(packSize)
+	}
+
+	start := make([]byte, 20)
+	binary.LittleEndian.PutUint64(start[0:], packSize)
+	binary.LittleEndian.PutUint64(start[8:], uint64(len(header)))
+	binary.LittleEndian.PutUint32(start[16:], crc32.ChecksumIEEE(header))
+
+	var sh bytes.Buffer
+	sh.Write(signature)
+	sh.Write([]byte{0, 4})
+	_ = binary.Write(&sh, binary.LittleEndian, crc32.ChecksumIEEE(start))
+	sh.Write(start)
+	if _, err := a.target.Write(sh.Bytes()); err != nil {
+		return err
+	}
+	if a.tmp != nil {
+		if _, err := io.Copy(a.target, a.tmp); err != nil {
+			return err
+		}
+	}
+	_, err := a.target.Write(header)
+	return err
+}
+
+func (a *Archive) header(packSize uint64) []byte {
+	var streams []entry
+	var unpackSize uint64
+	for _, e := range a.entries {
+		if e.size > 0 {
+			streams = append(streams, e)
+			unpackSize += e.size
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteByte(idHeader)
+
+	if len(streams) > 0 {
+		b.WriteByte(idMainStreamsInfo)
+
+		b.WriteByte(idPackInfo)
+		writeNumber(&b, 0) // pack position
+		writeNumber(&b, 1) // number of pack streams
+		b.WriteByte(idSize)
+		writeNumber(&b, packSize)
+		b.WriteByte(idEnd)
+
+		b.WriteByte(idUnpackInfo)
+		b.WriteByte(idFolder)
+		writeNumber(&b, 1)    // number of folders
+		b.WriteByte(0)        // not external
+		writeNumber(&b, 1)    // number of coders
+		b.WriteByte(0x20 | 1) // has properties, 1 byte id
+		b.WriteByte(lzma2)
+		writeNumber(&b, 1)
+		b.WriteByte(lzma.EncodeDictCap(dictCap))
+		b.WriteByte(idCodersUnpack)
+		writeNumber(&b, unpackSize)
+		b.WriteByte(idEnd)
+
+		b.WriteByte(idSubStreamsInfo)
+		b.WriteByte(idNumUnpackStream)
+		writeNumber(&b, uint64(len(streams)))
+		if len(streams) > 1 {
+			b.WriteByte(idSize)
+			for _, e := range streams[:len(streams)-1] {
+				writeNumber(&b, e.size)
+			}
+		}
+		b.WriteByte(idCRC)
+		b.WriteByte(1) // all defined
+		for _, e := range streams {
+			_ = binary.Write(&b, binary.LittleEndian, e.crc)
+		}
+		b.WriteByte(idEnd)
+
+		b.WriteByte(idEnd)
+	}
+
+	b.WriteByte(idFilesInfo)
+	writeNumber(&b, uint64(len(a.entries)))
+
+	if len(streams) < len(a.entries) {
+		emptyStream := make([]bool, 0, len(a.entries))
+		var emptyFile []bool
+		for _, e := range a.entries {
+			emptyStream = append(emptyStream, e.size == 0)
+			if e.size == 0 {
+				emptyFile = append(emptyFile, !e.mode.IsDir())
+			}
+		}
+		writeProperty(&b, idEmptyStream, bitVector(emptyStream))
+		writeProperty(&b, idEmptyFile, bitVector(emptyFile))
+	}
+
+	var names bytes.Buffer
+	names.WriteByte(0) // not external
+	for _, e := range a.entries {
+		for _, c := range utf16.Encode([]rune(e.name)) {
+			_ = binary.Write(&names, binary.LittleEndian, c)
+		}
+		_ = binary.Write(&names, binary.LittleEndian, uint16(0))
+	}
+	writeProperty(&b, idName, names.Bytes())
+
+	var mtimes bytes.Buffer
+	mtimes.Write([]byte{1, 0}) // all defined, not external
+	for _, e := range a.entries {
+		_ = binary.Write(&mtimes, binary.LittleEndian, filetime(e.mtime))
+	}
+	writeProperty(&b, idMTime, mtimes.Bytes())
+
+	var attrs bytes.Buffer
+	attrs.Write([]byte{1, 0}) // all defined, not external
+	for _, e := range a.entries {
+		_ = binary.Write(&attrs, binary.LittleEndian, attributes(e.mode))
+	}
+	writeProperty(&b, idAttributes, attrs.Bytes())
+
+	b.WriteByte(idEnd)
+
+	b.WriteByte(idEnd)
+	return b.Bytes()
+}
+
+func writeProperty(b *bytes.Buffer, id byte, data []byte) {
+	b.WriteByte(id)
+	writeNumber(b, uint64(len(data)))
+	b.Write(data)
+}
+
+// writeNumber writes v using the 7z variable length encoding, in which the
+// number of leading 1 bits of the first byte is the number of extra bytes.
+func writeNumber(b *bytes.Buffer, v uint64) {
+	var first byte
+	mask := byte(0x80)
+	i := 0
+	for ; i < 8; i++ {
+		if v < 1<<(7*(i+1)) {
+			first |= byte(v >> (8 * i))
+			break
+		}
+		first |= mask
+		mask >>= 1
+	}
+	b.WriteByte(first)
+	for ; i > 0; i-- {
+		b.WriteByte(byte(v))
+		v >>= 8
+	}
+}
+
+func bitVector(bits []bool) []byte {
+	out := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		if bit {
+			out[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return out
+}
+
+// filetime converts t into a Windows FILETIME, the number of 100ns intervals
+// since 1601-01-01.
+func filetime(t time.Time) uint64 {
+	const epochDiff = 116444736000000000
+	return uint64(t.UnixNano()/100 + epochDiff)
+}
+
+func attributes(mode fs.FileMode) uint32 {
+	unix := uint32(mode.Perm())
+	var attr uint32
+	switch {
+	case mode.IsDir():
+		attr |= attrDirectory
+		unix |= 0o040000
+	case mode&fs.ModeSymlink != 0:
+		unix |= 0o120000
+	default:
+		unix |= 0o100000
+	}
+	return attr | attrUnixExtension | unix<<16
+}
@@ -0,0 +1,185 @@
+package sevenzip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz/lzma"
+)
+
+func TestSevenZipFile(t *testing.T) {
+	tmp := t.TempDir()
+	f, err := os.Create(filepath.Join(tmp, "test.7z"))
+	require.NoError(t, err)
+	defer f.Close()
+	archive := New(f)
+	defer archive.Close()
+
+	require.Error(t, archive.Add(config.File{
+		Source:      "../testdata/nope.txt",
+		Destination: "nope.txt",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/foo.txt",
+		Destination: "foo.txt",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/sub1",
+		Destination: "sub1",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/sub1/bar.txt",
+		Destination: "sub1/bar.txt",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/sub1/executable",
+		Destination: "sub1/executable",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/sub1/sub2",
+		Destination: "sub1/sub2",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/sub1/sub2/subfoo.txt",
+		Destination: "sub1/sub2/subfoo.txt",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/regular.txt",
+		Destination: "regular.txt",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/link.txt",
+		Destination: "link.txt",
+	}))
+
+	require.ErrorIs(t, archive.Add(config.File{
+		Source:      "../testdata/regular.txt",
+		Destination: "link.txt",
+	}), fs.ErrExist)
+
+	require.NoError(t, archive.Close())
+	require.Error(t, archive.Add(config.File{
+		Source:      "sevenzip.go",
+		Destination: "sevenzip.go",
+	}))
+	require.NoError(t, f.Close())
+
+	bts, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	packed, header := readArchive(t, bts)
+
+	r, err := lzma.Reader2Config{DictCap: dictCap}.NewReader2(bytes.NewReader(packed))
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "foo\nbar\nsub\nregular file\nregular.txt", string(content))
+
+	for _, name := range []string{
+		"foo.txt",
+		"sub1",
+		"sub1/bar.txt",
+		"sub1/executable",
+		"sub1/sub2",
+		"sub1/sub2/subfoo.txt",
+		"regular.txt",
+		"link.txt",
+	} {
+		require.True(t, bytes.Contains(header, utf16le(name)), name)
+	}
+
+	if _, err := exec.LookPath("bsdtar"); err == nil {
+		out, err := exec.CommandContext(t.Context(), "bsdtar", "-tvf", f.Name()).CombinedOutput()
+		require.NoError(t, err, string(out))
+		require.Contains(t, string(out), "sub1/sub2/subfoo.txt")
+		require.Contains(t, string(out), "link.txt -> regular.txt")
+		require.Contains(t, string(out), "-rwxrwxr-x")
+	}
+}
+
+func TestSevenZipFileInfo(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "test.7z"))
+	require.NoError(t, err)
+	defer f.Close()
+	archive := New(f)
+	defer archive.Close()
+
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/foo.txt",
+		Destination: "nope.txt",
+		Info: config.FileInfo{
+			Mode: 0o755,
+		},
+	}))
+	require.NoError(t, archive.Close())
+	require.NoError(t, f.Close())
+
+	bts, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	_, header := readArchive(t, bts)
+
+	attrs := make([]byte, 4)
+	binary.LittleEndian.PutUint32(attrs, attrUnixExtension|(0o100755<<16))
+	require.True(t, bytes.Contains(header, attrs))
+}
+
+func TestSevenZipEmpty(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, New(&b).Close())
+	require.Len(t, b.Bytes(), 32)
+	packed, header := readArchive(t, b.Bytes())
+	require.Empty(t, packed)
+	require.Empty(t, header)
+}
+
+func TestWriteNumber(t *testing.T) {
+	for v, expected := range map[uint64][]byte{
+		0:       {0x00},
+		0x7f:    {0x7f},
+		0x80:    {0x80, 0x80},
+		0x3fff:  {0xbf, 0xff},
+		0x4000:  {0xc0, 0x00, 0x40},
+		1 << 56: {0xff, 0, 0, 0, 0, 0, 0, 0, 0x01},
+	} {
+		var b bytes.Buffer
+		writeNumber(&b, v)
+		require.Equal(t, expected, b.Bytes(), v)
+	}
+}
+
+func TestBitVector(t *testing.T) {
+	require.Equal(t, []byte{0b10100000}, bitVector([]bool{true, false, true}))
+	require.Equal(t, []byte{0xff, 0b10000000}, bitVector([]bool{true, true, true, true, true, true, true, true, true}))
+}
+
+// readArchive checks the signature header of a 7z archive, returning its
+// packed streams and its header.
+func readArchive(tb testing.TB, bts []byte) ([]byte, []byte) {
+	tb.Helper()
+	require.GreaterOrEqual(tb, len(bts), 32)
+	require.Equal(tb, signature, bts[:6])
+	require.Equal(tb, crc32.ChecksumIEEE(bts[12:32]), binary.LittleEndian.Uint32(bts[8:12]))
+	offset := binary.LittleEndian.Uint64(bts[12:20])
+	size := binary.LittleEndian.Uint64(bts[20:28])
+	header := bts[32+offset : 32+offset+size]
+	require.Equal(tb, crc32.ChecksumIEEE(header), binary.LittleEndian.Uint32(bts[28:32]))
+	require.Len(tb, bts, int(32+offset+size))
+	return bts[32 : 32+offset], header
+}
+
+func utf16le(s string) []byte {
+	var b bytes.Buffer
+	for _, c := range utf16.Encode([]rune(s)) {
+		_ = binary.Write(&b, binary.LittleEndian, c)
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,61 @@
+// Package tarlz4 implements the Archive interface providing tar.lz4 archiving
+// and compression.
+package tarlz4
+
+import (
+	"io"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/archive/tar"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/pierrec/lz4/v4"
+)
+
+// Archive as tar.lz4.
+type Archive struct {
+	lz4w *lz4.Writer
+	tw   *tar.Archive
+}
+
+// New tar.lz4 archive.
+func New(target io.Writer) Archive {
+	return NewWithLevel(target, 0)
+}
+
+// NewWithLevel creates a new tar.lz4 archive with the given compression level,
+// from 1 (fastest) to 9 (best compression).
+// Level 0 uses the fast compression.
+func NewWithLevel(target io.Writer, level int) Archive {
+	lz4w := lz4.NewWriter(target)
+	if level > 0 {
+		// the error will be nil since the compression level is valid
+		_ = lz4w.Apply(lz4.CompressionLevelOption(compressionLevel(level)))
+	}
+	tw := tar.New(lz4w)
+	return Archive{
+		lz4w: lz4w,
+		tw:   &tw,
+	}
+}
+
+// compressionLevel maps the 1-9 level to lz4's levels.
+func compressionLevel(level int) lz4.CompressionLevel {
+	levels := []lz4.CompressionLevel{
+		lz4.Level1, lz4.Level2, lz4.Level3,
+		lz4.Level4, lz4.Level5, lz4.Level6,
+		lz4.Level7, lz4.Level8, lz4.Level9,
+	}
+	return levels[min(level, len(levels))-1]
+}
+
+// Close all closeables.
+func (a Archive) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.lz4w.Close()
+}
+
+// Add file to the archive.
+func (a Archive) Add(f config.File) error {
+	return a.tw.Add(f)
+}
@@ -18,7 +18,18 @@ type Archive struct {
 
 // New tar.zst archive.
 func New(target io.Writer) Archive {
-	zstw, _ := zstd.NewWriter(target)
+	return NewWithLevel(target, 0)
+}
+
+// NewWithLevel creates a new tar.zst archive with the given zstd compression
+// level, from 1 (fastest) to 22 (best compression).
+// Level 0 uses the default compression.
+func NewWithLevel(target io.Writer, level int) Archive {
+	var opts []zstd.EOption
+	if level > 0 {
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+	zstw, _ := zstd.NewWriter(target, opts...)
 	tw := tar.New(zstw)
 	return Archive{
 		zstw: zstw,
@@ -22,9 +22,20 @@ type Archive struct {
 
 // New zip archive.
 func New(target io.Writer) Archive {
+	return NewWithLevel(target, 0)
+}
+
+// NewWithLevel creates a new zip archive with the given deflate compression
+// level, from 1 (fastest) to 9 (best compression).
+// Level 0 uses the best compression.
+func NewWithLevel(target io.Writer, level int) Archive {
+	if level <= 0 {
+		level = flate.BestCompression
+	}
+	level = min(level, flate.BestCompression)
 	compressor := zip.NewWriter(target)
 	compressor.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
-		return flate.NewWriter(out, flate.BestCompression)
+		return flate.NewWriter(out, level)
 	})
 	return Archive{
 		z:     compressor,
@@ -2,6 +2,7 @@
 package build
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
@@ -36,11 +37,17 @@ func For(name string) Builder {
 func Dependencies(ctx *context.Context) []string {
 	var result []string
 	for _, build := range ctx.Config.Builds {
-		dep, ok := For(build.Builder).(DependingBuilder)
-		if !ok {
-			continue
+		if dep, ok := For(build.Builder).(DependingBuilder); ok {
+			result = append(result, dep.Dependencies()...)
+		}
+		for _, tc := range build.Toolchains {
+			for _, cmd := range []string{tc.CC, tc.CXX} {
+				// templated commands can only be known at build time.
+				if fields := strings.Fields(cmd); len(fields) > 0 && !strings.Contains(cmd, "{{") {
+					result = append(result, fields[0])
+				}
+			}
 		}
-		result = append(result, dep.Dependencies()...)
 	}
 	return result
 }
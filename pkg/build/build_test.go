@@ -71,3 +71,19 @@ func TestDependencies(t *testing.T) {
 			},
 		})))
 }
+
+func TestDependenciesToolchains(t *testing.T) {
+	require.Equal(t, []string{"fake", "zig", "o64-clang", "o64-clang++"}, Dependencies(testctx.WrapWithCfg(t.Context(),
+		config.Project{
+			Builds: []config.Build{
+				{
+					Builder: "completedummy",
+					Toolchains: []config.BuildToolchain{
+						{Goos: "linux", CC: "zig cc -target x86_64-linux-musl"},
+						{Goos: "darwin", CC: "o64-clang", CXX: "o64-clang++"},
+						{Goos: "windows", CC: "{{ .Env.WINDOWS_CC }}"},
+					},
+				},
+			},
+		})))
+}
@@ -45,6 +45,20 @@ type GiteaURLs struct {
 	SkipTLSVerify bool   `yaml:"skip_tls_verify,omitempty" json:"skip_tls_verify,omitempty"`
 }
 
+// ForgejoURLs holds the URLs to be used when using forgejo.
+type ForgejoURLs struct {
+	API           string `yaml:"api,omitempty" json:"api,omitempty"`
+	Download      string `yaml:"download,omitempty" json:"download,omitempty"`
+	SkipTLSVerify bool   `yaml:"skip_tls_verify,omitempty" json:"skip_tls_verify,omitempty"`
+}
+
+// BitbucketURLs holds the URLs to be used when using bitbucket.
+type BitbucketURLs struct {
+	API           string `yaml:"api,omitempty" json:"api,omitempty"`
+	Download      string `yaml:"download,omitempty" json:"download,omitempty"`
+	SkipTLSVerify bool   `yaml:"skip_tls_verify,omitempty" json:"skip_tls_verify,omitempty"`
+}
+
 // Repo represents any kind of repo (github, gitlab, etc).
 // to upload releases into.
 type Repo struct {
@@ -108,6 +122,9 @@ type PullRequest struct {
 	Enabled bool            `yaml:"enabled,omitempty" json:"enabled,omitempty"`
 	Base    PullRequestBase `yaml:"base,omitempty" json:"base,omitempty"`
 	Draft   bool            `yaml:"draft,omitempty" json:"draft,omitempty"`
+
+	// v2.18+
+	Body string `yaml:"body,omitempty" json:"body,omitempty"`
 }
 
 // HomebrewDependency represents Homebrew dependency.
@@ -211,6 +228,17 @@ type Homebrew struct {
 	Goarm                 string               `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
 	Goamd64               string               `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
 	Service               string               `yaml:"service,omitempty" json:"service,omitempty"`
+
+	// v2.18+
+	Livecheck string          `yaml:"livecheck,omitempty" json:"livecheck,omitempty"`
+	Bottles   HomebrewBottles `yaml:"bottles,omitempty" json:"bottles,omitempty"`
+}
+
+// HomebrewBottles configures the creation of Homebrew bottles.
+type HomebrewBottles struct {
+	Enabled bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	RootURL string `yaml:"root_url,omitempty" json:"root_url,omitempty"`
+	MacOS   string `yaml:"macos,omitempty" json:"macos,omitempty"`
 }
 
 // HomebrewCask contains the homebrew_casks section.
@@ -240,6 +268,9 @@ type HomebrewCask struct {
 	// v2.15+
 	GenerateCompletionsFromExecutable HomebrewCaskGeneratedCompletions `yaml:"generate_completions_from_executable,omitempty" json:"generate_completions_from_executable,omitempty"`
 
+	// v2.18+
+	App string `yaml:"app,omitempty" json:"app,omitempty"`
+
 	// XXX: casks don't yet support it, it has no effect.
 	License string `yaml:"license,omitempty" json:"license,omitempty"`
 
@@ -333,6 +364,16 @@ type Nix struct {
 
 	// v2.16+
 	MainProgram string `yaml:"main_program,omitempty" json:"main_program,omitempty"`
+
+	// v2.18+
+	Flake NixFlake `yaml:"flake,omitempty" json:"flake,omitempty"`
+}
+
+type NixFlake struct {
+	Enabled bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Path    string `yaml:"path,omitempty" json:"path,omitempty"`
+	Nixpkgs string `yaml:"nixpkgs,omitempty" json:"nixpkgs,omitempty"`
+	Release bool   `yaml:"release,omitempty" json:"release,omitempty"`
 }
 
 type NixDependency struct {
@@ -370,6 +411,21 @@ type Winget struct {
 	InstallationNotes     string             `yaml:"installation_notes,omitempty" json:"installation_notes,omitempty"`
 	Tags                  []string           `yaml:"tags,omitempty" json:"tags,omitempty"`
 	Dependencies          []WingetDependency `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
+
+	// v2.18+
+	Formats           []string                `yaml:"formats,omitempty" json:"formats,omitempty" jsonschema:"enum=zip,enum=binary,enum=msi,enum=msix"`
+	ProductCode       string                  `yaml:"product_code,omitempty" json:"product_code,omitempty"`
+	InstallerSwitches WingetInstallerSwitches `yaml:"installer_switches,omitempty" json:"installer_switches,omitempty"`
+}
+
+type WingetInstallerSwitches struct {
+	Silent             string `yaml:"silent,omitempty" json:"silent,omitempty"`
+	SilentWithProgress string `yaml:"silent_with_progress,omitempty" json:"silent_with_progress,omitempty"`
+	Interactive        string `yaml:"interactive,omitempty" json:"interactive,omitempty"`
+	InstallLocation    string `yaml:"install_location,omitempty" json:"install_location,omitempty"`
+	Log                string `yaml:"log,omitempty" json:"log,omitempty"`
+	Upgrade            string `yaml:"upgrade,omitempty" json:"upgrade,omitempty"`
+	Custom             string `yaml:"custom,omitempty" json:"custom,omitempty"`
 }
 
 type WingetDependency struct {
@@ -392,6 +448,44 @@ type Krew struct {
 	Goarm                 string       `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
 	Goamd64               string       `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
 	SkipUpload            string       `yaml:"skip_upload,omitempty" json:"skip_upload,omitempty" jsonschema:"oneof_type=string;boolean"`
+
+	// v2.18+
+	Bin      string       `yaml:"bin,omitempty" json:"bin,omitempty"`
+	Files    []KrewFile   `yaml:"files,omitempty" json:"files,omitempty"`
+	Selector KrewSelector `yaml:"selector,omitempty" json:"selector,omitempty"`
+}
+
+// KrewFile is a file mapping in a krew plugin manifest.
+type KrewFile struct {
+	From string `yaml:"from" json:"from"`
+	To   string `yaml:"to" json:"to"`
+}
+
+// KrewSelector allows to add extra constraints to the platforms of a krew
+// plugin manifest.
+type KrewSelector struct {
+	MatchExpressions []KrewMatchExpression `yaml:"match_expressions,omitempty" json:"match_expressions,omitempty"`
+}
+
+// KrewMatchExpression is a label selector requirement.
+type KrewMatchExpression struct {
+	Key      string   `yaml:"key" json:"key"`
+	Operator string   `yaml:"operator" json:"operator" jsonschema:"enum=In,enum=NotIn,enum=Exists,enum=DoesNotExist"`
+	Values   []string `yaml:"values,omitempty" json:"values,omitempty"`
+}
+
+// Asdf contains the configuration of an asdf (and mise) plugin.
+type Asdf struct {
+	Name                  string       `yaml:"name,omitempty" json:"name,omitempty"`
+	IDs                   []string     `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Repository            RepoRef      `yaml:"repository,omitempty" json:"repository,omitempty"`
+	CommitAuthor          CommitAuthor `yaml:"commit_author,omitempty" json:"commit_author,omitempty"`
+	CommitMessageTemplate string       `yaml:"commit_msg_template,omitempty" json:"commit_msg_template,omitempty"`
+	URLTemplate           string       `yaml:"url_template,omitempty" json:"url_template,omitempty"`
+	SourceURL             string       `yaml:"source_url,omitempty" json:"source_url,omitempty"`
+	Goarm                 string       `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
+	Goamd64               string       `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	SkipUpload            string       `yaml:"skip_upload,omitempty" json:"skip_upload,omitempty" jsonschema:"oneof_type=string;boolean"`
 }
 
 // Ko contains the ko section
@@ -422,6 +516,10 @@ type Ko struct {
 	// v2.7+
 	Disable string `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
 
+	// v2.18+
+	Tarball  string `yaml:"tarball,omitempty" json:"tarball,omitempty"`
+	SkipPush string `yaml:"skip_push,omitempty" json:"skip_push,omitempty" jsonschema:"oneof_type=string;boolean"`
+
 	// Deprecated: use [Repositories].
 	Repository string `yaml:"repository,omitempty" json:"repository,omitempty" jsonschema:"deprecated=true"`
 }
@@ -445,6 +543,18 @@ type Scoop struct {
 	Depends               []string     `yaml:"depends,omitempty" json:"depends,omitempty"`
 	Shortcuts             [][]string   `yaml:"shortcuts,omitempty" json:"shortcuts,omitempty"`
 	Goamd64               string       `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+
+	// v2.18+
+	Autoupdate ScoopAutoupdate `yaml:"autoupdate,omitempty" json:"autoupdate,omitempty"`
+}
+
+// ScoopAutoupdate configures the checkver and autoupdate properties of a
+// scoop manifest.
+type ScoopAutoupdate struct {
+	Enabled bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	GitHub  string `yaml:"github,omitempty" json:"github,omitempty"`
+	URL     string `yaml:"url,omitempty" json:"url,omitempty"`
+	Regex   string `yaml:"regex,omitempty" json:"regex,omitempty"`
 }
 
 // CommitAuthor is the author of a Git commit.
@@ -499,7 +609,7 @@ type Build struct {
 	Main            string          `yaml:"main,omitempty" json:"main,omitempty"`
 	Binary          string          `yaml:"binary,omitempty" json:"binary,omitempty"`
 	Hooks           BuildHookConfig `yaml:"hooks,omitempty" json:"hooks,omitempty"`
-	Builder         string          `yaml:"builder,omitempty" json:"builder,omitempty" jsonschema:"enum=,enum=go,enum=rust,enum=zig,enum=bun,enum=deno,enum=node,enum=uv,enum=poetry"`
+	Builder         string          `yaml:"builder,omitempty" json:"builder,omitempty" jsonschema:"enum=,enum=go,enum=rust,enum=zig,enum=bun,enum=deno,enum=node,enum=uv,enum=poetry,enum=prebuilt,enum=jvm,enum=pyinstaller,enum=tinygo"`
 	ModTimestamp    string          `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
 	Skip            string          `yaml:"skip,omitempty" json:"skip,omitempty" jsonschema:"oneof_type=string;boolean"`
 	Tool            string          `yaml:"tool,omitempty" json:"tool,omitempty"`
@@ -509,6 +619,15 @@ type Build struct {
 	UnproxiedMain   string          `yaml:"-" json:"-"` // used by gomod.proxy
 	UnproxiedDir    string          `yaml:"-" json:"-"` // used by gomod.proxy
 
+	// v2.18+
+	Prebuilt    PrebuiltBuildOptions `yaml:"prebuilt,omitempty" json:"prebuilt,omitempty"`
+	Toolchains  []BuildToolchain     `yaml:"toolchains,omitempty" json:"toolchains,omitempty"`
+	Concurrency int                  `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+	Prebuild    BuildPrebuild        `yaml:"prebuild,omitempty" json:"prebuild,omitempty"`
+	If          string               `yaml:"if,omitempty" json:"if,omitempty"`
+	Output      string               `yaml:"output,omitempty" json:"output,omitempty"`
+	Dedup       bool                 `yaml:"dedup,omitempty" json:"dedup,omitempty"`
+
 	BuildDetails          `yaml:",inline" json:",inline"`
 	BuildDetailsOverrides []BuildDetailsOverride `yaml:"overrides,omitempty" json:"overrides,omitempty"`
 
@@ -519,6 +638,36 @@ type Build struct {
 	GoBinary string `yaml:"gobinary,omitempty" json:"gobinary,omitempty" jsonschema:"deprecated=true"`
 }
 
+// BuildPrebuild configures the steps that run once per build, before any of
+// its targets is built.
+type BuildPrebuild struct {
+	Generate []BuildGenerator `yaml:"generate,omitempty" json:"generate,omitempty"`
+}
+
+// BuildGenerator is a code generation step.
+type BuildGenerator struct {
+	Cmd     string   `yaml:"cmd,omitempty" json:"cmd,omitempty"`
+	Dir     string   `yaml:"dir,omitempty" json:"dir,omitempty"`
+	Env     []string `yaml:"env,omitempty" json:"env,omitempty"`
+	Inputs  []string `yaml:"inputs,omitempty" json:"inputs,omitempty"`
+	Outputs []string `yaml:"outputs,omitempty" json:"outputs,omitempty"`
+	Output  bool     `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// PrebuiltBuildOptions configures the prebuilt builder.
+type PrebuiltBuildOptions struct {
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+}
+
+// BuildToolchain is the C/C++ toolchain used to build the matching targets
+// with CGO.
+type BuildToolchain struct {
+	Goos   string `yaml:"goos,omitempty" json:"goos,omitempty"`
+	Goarch string `yaml:"goarch,omitempty" json:"goarch,omitempty"`
+	CC     string `yaml:"cc,omitempty" json:"cc,omitempty"`
+	CXX    string `yaml:"cxx,omitempty" json:"cxx,omitempty"`
+}
+
 type BuildInternalDefaults struct {
 	// whether the pipe set the current binary.
 	// this is true when the user didn't set a binary name.
@@ -540,6 +689,11 @@ type BuildDetailsOverride struct {
 	Goppc64      string `yaml:"goppc64,omitempty" json:"goppc64,omitempty"`
 	Goriscv64    string `yaml:"goriscv64,omitempty" json:"goriscv64,omitempty"`
 	BuildDetails `yaml:",inline" json:",inline"`
+
+	// v2.18+
+	Main   string          `yaml:"main,omitempty" json:"main,omitempty"`
+	Binary string          `yaml:"binary,omitempty" json:"binary,omitempty"`
+	Hooks  BuildHookConfig `yaml:"hooks,omitempty" json:"hooks,omitempty"`
 }
 
 type BuildDetails struct {
@@ -550,6 +704,9 @@ type BuildDetails struct {
 	Asmflags  StringArray `yaml:"asmflags,omitempty" json:"asmflags,omitempty"`
 	Gcflags   StringArray `yaml:"gcflags,omitempty" json:"gcflags,omitempty"`
 	Env       []string    `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// v2.18+
+	Pgo string `yaml:"pgo,omitempty" json:"pgo,omitempty"`
 }
 
 type BuildHookConfig struct {
@@ -564,15 +721,18 @@ type Hook struct {
 	Cmd    string   `yaml:"cmd,omitempty" json:"cmd,omitempty"`
 	Env    []string `yaml:"env,omitempty" json:"env,omitempty"`
 	Output bool     `yaml:"output,omitempty" json:"output,omitempty"`
+
+	// v2.18+
+	Artifacts []string `yaml:"artifacts,omitempty" json:"artifacts,omitempty"`
 }
 
 // FormatOverride is used to specify a custom format for a specific GOOS.
 type FormatOverride struct {
 	Goos    string      `yaml:"goos,omitempty" json:"goos,omitempty"`
-	Formats StringArray `yaml:"formats,omitempty" json:"formats,omitempty" jsonschema:"enum=tar,enum=tgz,enum=tar.gz,enum=zip,enum=gz,enum=tar.xz,enum=txz,enum=binary,enum=none,default=tar.gz"`
+	Formats StringArray `yaml:"formats,omitempty" json:"formats,omitempty" jsonschema:"enum=tar,enum=tgz,enum=tar.gz,enum=zip,enum=gz,enum=tar.xz,enum=txz,enum=tar.zst,enum=tzst,enum=tar.lz4,enum=tlz4,enum=7z,enum=binary,enum=none,default=tar.gz"`
 
 	// Deprecated: use [Formats] instead.
-	Format string `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=tar,enum=tgz,enum=tar.gz,enum=zip,enum=gz,enum=tar.xz,enum=txz,enum=binary,enum=none,default=tar.gz,deprecated=true"`
+	Format string `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=tar,enum=tgz,enum=tar.gz,enum=zip,enum=gz,enum=tar.xz,enum=txz,enum=tar.zst,enum=tzst,enum=tar.lz4,enum=tlz4,enum=7z,enum=binary,enum=none,default=tar.gz,deprecated=true"`
 }
 
 // File is a file inside an archive.
@@ -584,6 +744,14 @@ type File struct {
 	Default     bool     `yaml:"-" json:"-"`
 }
 
+// TemplatedFile is a file which contents are rendered through the template
+// engine before being added to an archive.
+type TemplatedFile struct {
+	Source      string   `yaml:"src,omitempty" json:"src,omitempty"`
+	Destination string   `yaml:"dst,omitempty" json:"dst,omitempty"`
+	Info        FileInfo `yaml:"info,omitempty" json:"info,omitempty"`
+}
+
 // FileInfo is the file info of a file.
 type FileInfo struct {
 	Owner       string      `yaml:"owner,omitempty" json:"owner,omitempty"`
@@ -593,7 +761,7 @@ type FileInfo struct {
 	ParsedMTime time.Time   `yaml:"-" json:"-"`
 }
 
-// UniversalBinary setups macos universal binaries.
+// UniversalBinary setups universal binaries, macos ones by default.
 type UniversalBinary struct {
 	ID           string          `yaml:"id,omitempty" json:"id,omitempty"`
 	IDs          []string        `yaml:"ids,omitempty" json:"ids,omitempty"`
@@ -601,6 +769,10 @@ type UniversalBinary struct {
 	Replace      bool            `yaml:"replace,omitempty" json:"replace,omitempty"`
 	Hooks        BuildHookConfig `yaml:"hooks,omitempty" json:"hooks,omitempty"`
 	ModTimestamp string          `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
+
+	// v2.18+
+	Goos string `yaml:"goos,omitempty" json:"goos,omitempty"`
+	Cmd  string `yaml:"cmd,omitempty" json:"cmd,omitempty"`
 }
 
 // UPX allows to compress binaries with `upx`.
@@ -617,13 +789,44 @@ type UPX struct {
 	Brute    bool     `yaml:"brute,omitempty" json:"brute,omitempty"`
 }
 
+// BinaryProcessor runs a chain of commands on the built binaries.
+type BinaryProcessor struct {
+	ID                string                `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs               []string              `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Goos              []string              `yaml:"goos,omitempty" json:"goos,omitempty"`
+	Goarch            []string              `yaml:"goarch,omitempty" json:"goarch,omitempty"`
+	Disable           string                `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+	DebugNameTemplate string                `yaml:"debug_name_template,omitempty" json:"debug_name_template,omitempty"`
+	Steps             []BinaryProcessorStep `yaml:"steps,omitempty" json:"steps,omitempty"`
+}
+
+// BinaryProcessorStep is a command run on each binary.
+type BinaryProcessorStep struct {
+	Cmd    string   `yaml:"cmd,omitempty" json:"cmd,omitempty"`
+	Env    []string `yaml:"env,omitempty" json:"env,omitempty"`
+	Output bool     `yaml:"output,omitempty" json:"output,omitempty"`
+	Debug  string   `yaml:"debug,omitempty" json:"debug,omitempty"`
+}
+
+// DebugSymbols splits the debug symbols out of the built binaries.
+type DebugSymbols struct {
+	ID           string   `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs          []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Goos         []string `yaml:"goos,omitempty" json:"goos,omitempty"`
+	Goarch       []string `yaml:"goarch,omitempty" json:"goarch,omitempty"`
+	Disable      string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Objcopy      string   `yaml:"objcopy,omitempty" json:"objcopy,omitempty"`
+	NameTemplate string   `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	Format       string   `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=binary,enum=tar.gz,enum=tgz,enum=tar.xz,enum=txz,enum=tar.zst,enum=tzst,enum=zip,default=binary"`
+}
+
 // Archive config used for the archive.
 type Archive struct {
 	ID                        string           `yaml:"id,omitempty" json:"id,omitempty"`
 	IDs                       []string         `yaml:"ids,omitempty" json:"ids,omitempty"`
 	BuildsInfo                FileInfo         `yaml:"builds_info,omitempty" json:"builds_info,omitempty"`
 	NameTemplate              string           `yaml:"name_template,omitempty" json:"name_template,omitempty"`
-	Formats                   StringArray      `yaml:"formats,omitempty" json:"formats,omitempty" jsonschema:"enum=tar,enum=tgz,enum=tar.gz,enum=zip,enum=gz,enum=tar.xz,enum=txz,enum=binary,default=tar.gz"`
+	Formats                   StringArray      `yaml:"formats,omitempty" json:"formats,omitempty" jsonschema:"enum=tar,enum=tgz,enum=tar.gz,enum=zip,enum=gz,enum=tar.xz,enum=txz,enum=tar.zst,enum=tzst,enum=tar.lz4,enum=tlz4,enum=7z,enum=binary,default=tar.gz"`
 	FormatOverrides           []FormatOverride `yaml:"format_overrides,omitempty" json:"format_overrides,omitempty"`
 	WrapInDirectory           string           `yaml:"wrap_in_directory,omitempty" json:"wrap_in_directory,omitempty" jsonschema:"oneof_type=string;boolean"`
 	StripBinaryDirectory      bool             `yaml:"strip_binary_directory,omitempty" json:"strip_binary_directory,omitempty"`
@@ -631,8 +834,17 @@ type Archive struct {
 	Meta                      bool             `yaml:"meta,omitempty" json:"meta,omitempty"`
 	AllowDifferentBinaryCount bool             `yaml:"allow_different_binary_count,omitempty" json:"allow_different_binary_count,omitempty"`
 
+	// v2.18+
+	CompressionLevel  int             `yaml:"compression_level,omitempty" json:"compression_level,omitempty"`
+	StrictFiles       bool            `yaml:"strict_files,omitempty" json:"strict_files,omitempty"`
+	Manifest          bool            `yaml:"manifest,omitempty" json:"manifest,omitempty"`
+	BinariesDirectory string          `yaml:"binaries_directory,omitempty" json:"binaries_directory,omitempty"`
+	Signs             []ArchiveSign   `yaml:"signs,omitempty" json:"signs,omitempty"`
+	SplitSize         string          `yaml:"split_size,omitempty" json:"split_size,omitempty"`
+	TemplatedFiles    []TemplatedFile `yaml:"templated_files,omitempty" json:"templated_files,omitempty"`
+
 	// Deprecated: use [Formats] instead.
-	Format string `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=tar,enum=tgz,enum=tar.gz,enum=zip,enum=gz,enum=tar.xz,enum=txz,enum=binary,default=tar.gz,deprecated=true"`
+	Format string `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=tar,enum=tgz,enum=tar.gz,enum=zip,enum=gz,enum=tar.xz,enum=txz,enum=tar.zst,enum=tzst,enum=tar.lz4,enum=tlz4,enum=7z,enum=binary,default=tar.gz,deprecated=true"`
 
 	// Deprecated: use [IDs] instead.
 	Builds []string `yaml:"builds,omitempty" json:"builds,omitempty" jsonschema:"deprecated=true"`
@@ -652,6 +864,8 @@ type Release struct {
 	GitHub                 Repo        `yaml:"github,omitempty" json:"github,omitempty"`
 	GitLab                 Repo        `yaml:"gitlab,omitempty" json:"gitlab,omitempty"`
 	Gitea                  Repo        `yaml:"gitea,omitempty" json:"gitea,omitempty"`
+	Forgejo                Repo        `yaml:"forgejo,omitempty" json:"forgejo,omitempty"`
+	Bitbucket              Repo        `yaml:"bitbucket,omitempty" json:"bitbucket,omitempty"`
 	Draft                  bool        `yaml:"draft,omitempty" json:"draft,omitempty"`
 	ReplaceExistingDraft   bool        `yaml:"replace_existing_draft,omitempty" json:"replace_existing_draft,omitempty"`
 	UseExistingDraft       bool        `yaml:"use_existing_draft,omitempty" json:"use_existing_draft,omitempty"`
@@ -712,10 +926,27 @@ type NFPM struct {
 	// v2.14+
 	GoAmd64 []string `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
 
+	// v2.18+
+	Packages []NFPMPackage `yaml:"packages,omitempty" json:"packages,omitempty"`
+
 	// Deprecated: use [IDs] instead.
 	Builds []string `yaml:"builds,omitempty" json:"builds,omitempty" jsonschema:"deprecated=true"`
 }
 
+// NFPMPackage is an additional package created from the same nfpm config,
+// taking the contents matching its filters out of the main package.
+type NFPMPackage struct {
+	PackageName  string   `yaml:"package_name" json:"package_name"`
+	Description  string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Contents     []string `yaml:"contents,omitempty" json:"contents,omitempty"`
+	Dependencies []string `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
+	Recommends   []string `yaml:"recommends,omitempty" json:"recommends,omitempty"`
+	Suggests     []string `yaml:"suggests,omitempty" json:"suggests,omitempty"`
+	Conflicts    []string `yaml:"conflicts,omitempty" json:"conflicts,omitempty"`
+	Replaces     []string `yaml:"replaces,omitempty" json:"replaces,omitempty"`
+	Provides     []string `yaml:"provides,omitempty" json:"provides,omitempty"`
+}
+
 type Libdirs struct {
 	Header   string `yaml:"header,omitempty" json:"header,omitempty"`
 	CArchive string `yaml:"carchive,omitempty" json:"carchive,omitempty"`
@@ -978,6 +1209,17 @@ type SBOM struct {
 
 	// v2.10+
 	Disable string `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+
+	// v2.18+
+	Formats []string  `yaml:"formats,omitempty" json:"formats,omitempty" jsonschema:"enum=spdx-json,enum=spdx3-json,enum=cyclonedx-json"`
+	Merge   SBOMMerge `yaml:"merge,omitempty" json:"merge,omitempty"`
+}
+
+// SBOMMerge configures merging the SBOMs of a configuration into a single
+// release SBOM.
+type SBOMMerge struct {
+	Enabled      string `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	NameTemplate string `yaml:"name_template,omitempty" json:"name_template,omitempty"`
 }
 
 // Sign config.
@@ -986,13 +1228,56 @@ type Sign struct {
 	Cmd         string   `yaml:"cmd,omitempty" json:"cmd,omitempty"`
 	Args        []string `yaml:"args,omitempty" json:"args,omitempty"`
 	Signature   string   `yaml:"signature,omitempty" json:"signature,omitempty"`
-	Artifacts   string   `yaml:"artifacts,omitempty" json:"artifacts,omitempty" jsonschema:"enum=all,enum=manifests,enum=images,enum=checksum,enum=source,enum=package,enum=archive,enum=binary,enum=sbom"`
+	Artifacts   string   `yaml:"artifacts,omitempty" json:"artifacts,omitempty" jsonschema:"enum=all,enum=manifests,enum=images,enum=checksum,enum=source,enum=package,enum=installer,enum=archive,enum=binary,enum=sbom"`
 	IDs         []string `yaml:"ids,omitempty" json:"ids,omitempty"`
 	Stdin       *string  `yaml:"stdin,omitempty" json:"stdin,omitempty"`
 	StdinFile   string   `yaml:"stdin_file,omitempty" json:"stdin_file,omitempty"`
 	Env         []string `yaml:"env,omitempty" json:"env,omitempty"`
 	Certificate string   `yaml:"certificate,omitempty" json:"certificate,omitempty"`
 	Output      string   `yaml:"output,omitempty" json:"output,omitempty" jsonschema:"oneof_type=string;boolean"`
+
+	// v2.18+
+	Minisign *SignMinisign `yaml:"minisign,omitempty" json:"minisign,omitempty"`
+	Signify  *SignSignify  `yaml:"signify,omitempty" json:"signify,omitempty"`
+	KMS      *SignKMS      `yaml:"kms,omitempty" json:"kms,omitempty"`
+}
+
+// SignMinisign configures signing with a minisign secret key, without
+// running an external command.
+type SignMinisign struct {
+	Key              string `yaml:"key,omitempty" json:"key,omitempty"`
+	KeyFile          string `yaml:"key_file,omitempty" json:"key_file,omitempty"`
+	Password         string `yaml:"password,omitempty" json:"password,omitempty"`
+	TrustedComment   string `yaml:"trusted_comment,omitempty" json:"trusted_comment,omitempty"`
+	UntrustedComment string `yaml:"untrusted_comment,omitempty" json:"untrusted_comment,omitempty"`
+}
+
+// SignSignify configures signing with an OpenBSD signify secret key, without
+// running an external command.
+type SignSignify struct {
+	Key      string `yaml:"key,omitempty" json:"key,omitempty"`
+	KeyFile  string `yaml:"key_file,omitempty" json:"key_file,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+	Comment  string `yaml:"comment,omitempty" json:"comment,omitempty"`
+}
+
+// SignKMS configures OpenPGP signing with a key kept in a cloud KMS, without
+// exporting it or running an external command.
+type SignKMS struct {
+	Key     string `yaml:"key,omitempty" json:"key,omitempty"`
+	Created string `yaml:"created,omitempty" json:"created,omitempty"`
+	Hash    string `yaml:"hash,omitempty" json:"hash,omitempty" jsonschema:"enum=sha256,enum=sha384,enum=sha512"`
+	Armor   bool   `yaml:"armor,omitempty" json:"armor,omitempty"`
+}
+
+// ArchiveSign signs files before they are added to an archive.
+type ArchiveSign struct {
+	Cmd       string   `yaml:"cmd" json:"cmd"`
+	Args      []string `yaml:"args,omitempty" json:"args,omitempty"`
+	Files     []string `yaml:"files,omitempty" json:"files,omitempty"`
+	Signature string   `yaml:"signature,omitempty" json:"signature,omitempty"`
+	Env       []string `yaml:"env,omitempty" json:"env,omitempty"`
+	Output    string   `yaml:"output,omitempty" json:"output,omitempty" jsonschema:"oneof_type=string;boolean"`
 }
 
 // BinarySign config.
@@ -1008,6 +1293,11 @@ type BinarySign struct {
 	Env         []string `yaml:"env,omitempty" json:"env,omitempty"`
 	Certificate string   `yaml:"certificate,omitempty" json:"certificate,omitempty"`
 	Output      string   `yaml:"output,omitempty" json:"output,omitempty" jsonschema:"oneof_type=string;boolean"`
+
+	// v2.18+
+	Minisign *SignMinisign `yaml:"minisign,omitempty" json:"minisign,omitempty"`
+	Signify  *SignSignify  `yaml:"signify,omitempty" json:"signify,omitempty"`
+	KMS      *SignKMS      `yaml:"kms,omitempty" json:"kms,omitempty"`
 }
 
 type Notarize struct {
@@ -1027,6 +1317,9 @@ type MacOSNotarize struct {
 	KeyID    string        `yaml:"key_id" json:"key_id"`
 	Timeout  time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty" jsonschema:"type=string"`
 	Wait     bool          `yaml:"wait,omitempty" json:"wait,omitempty"`
+
+	// v2.18+
+	PollInterval time.Duration `yaml:"poll_interval,omitempty" json:"poll_interval,omitempty" jsonschema:"type=string"`
 }
 
 type MacOSSign struct {
@@ -1107,6 +1400,9 @@ type Snapcraft struct {
 
 	// Deprecated: use IDs.
 	Builds []string `yaml:"builds,omitempty" json:"builds,omitempty" jsonschema:"deprecated=true"`
+
+	// v2.18+
+	Slots map[string]any `yaml:"slots,omitempty" json:"slots,omitempty"`
 }
 
 // SnapcraftExtraFiles config.
@@ -1140,9 +1436,34 @@ type Flatpak struct {
 	// Sandbox permissions.
 	FinishArgs []string `yaml:"finish_args,omitempty" json:"finish_args,omitempty"`
 
+	// Desktop entry file, installed as <app_id>.desktop.
+	DesktopFile string `yaml:"desktop_file,omitempty" json:"desktop_file,omitempty"`
+
+	// Application icon, either a square PNG or a SVG, installed as <app_id>.png
+	// or <app_id>.svg.
+	Icon string `yaml:"icon,omitempty" json:"icon,omitempty"`
+
+	// AppStream metainfo file, installed as <app_id>.metainfo.xml.
+	Metainfo string `yaml:"metainfo,omitempty" json:"metainfo,omitempty"`
+
+	// Flathub publishing.
+	Flathub Flathub `yaml:"flathub,omitempty" json:"flathub,omitempty"`
+
 	Disable string `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
 }
 
+// Flathub contains the configuration to publish a Flatpak manifest to a
+// Flathub packaging repository.
+type Flathub struct {
+	IDs                   []string     `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Goamd64               string       `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	Repository            RepoRef      `yaml:"repository,omitempty" json:"repository,omitempty"`
+	CommitAuthor          CommitAuthor `yaml:"commit_author,omitempty" json:"commit_author,omitempty"`
+	CommitMessageTemplate string       `yaml:"commit_msg_template,omitempty" json:"commit_msg_template,omitempty"`
+	URLTemplate           string       `yaml:"url_template,omitempty" json:"url_template,omitempty"`
+	SkipUpload            string       `yaml:"skip_upload,omitempty" json:"skip_upload,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
 // Snapshot config.
 type Snapshot struct {
 	// Deprecated: use VersionTemplate.
@@ -1158,6 +1479,13 @@ type Checksum struct {
 	IDs          []string    `yaml:"ids,omitempty" json:"ids,omitempty"`
 	Disable      bool        `yaml:"disable,omitempty" json:"disable,omitempty"`
 	ExtraFiles   []ExtraFile `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+
+	// v2.18+
+	Algorithms    []string `yaml:"algorithms,omitempty" json:"algorithms,omitempty"`
+	Sidecars      bool     `yaml:"sidecars,omitempty" json:"sidecars,omitempty"`
+	Clearsign     bool     `yaml:"clearsign,omitempty" json:"clearsign,omitempty"`
+	ClearsignKey  string   `yaml:"clearsign_key,omitempty" json:"clearsign_key,omitempty"`
+	VerifyScripts bool     `yaml:"verify_scripts,omitempty" json:"verify_scripts,omitempty"`
 }
 
 // Retry config for operations that support retries.
@@ -1184,7 +1512,7 @@ type Docker struct {
 	Files              []string `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
 	BuildFlagTemplates []string `yaml:"build_flag_templates,omitempty" json:"build_flag_templates,omitempty"`
 	PushFlags          []string `yaml:"push_flags,omitempty" json:"push_flags,omitempty"`
-	Use                string   `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=docker,enum=buildx,default=docker"`
+	Use                string   `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=docker,enum=buildx,enum=podman,enum=nerdctl,default=docker"`
 
 	Retry Retry `yaml:"retry,omitempty" json:"retry,omitempty"` // Deprecated: use [Project.Retry] instead.
 }
@@ -1199,7 +1527,7 @@ type DockerManifest struct {
 	ImageTemplates []string `yaml:"image_templates,omitempty" json:"image_templates,omitempty"`
 	CreateFlags    []string `yaml:"create_flags,omitempty" json:"create_flags,omitempty"`
 	PushFlags      []string `yaml:"push_flags,omitempty" json:"push_flags,omitempty"`
-	Use            string   `yaml:"use,omitempty" json:"use,omitempty"`
+	Use            string   `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=docker,enum=podman,default=docker"`
 
 	Retry Retry `yaml:"retry,omitempty" json:"retry,omitempty"` // Deprecated: use [Project.Retry] instead.
 }
@@ -1221,9 +1549,141 @@ type DockerV2 struct {
 	SBOM        string            `yaml:"sbom,omitempty" json:"sbom,omitempty" jsonschema:"oneof_type=string;boolean"`
 	Hooks       BuildHookConfig   `yaml:"hooks,omitempty" json:"hooks,omitempty"`
 
+	// v2.18+
+	CacheFrom  []string         `yaml:"cache_from,omitempty" json:"cache_from,omitempty"`
+	CacheTo    []string         `yaml:"cache_to,omitempty" json:"cache_to,omitempty"`
+	Provenance string           `yaml:"provenance,omitempty" json:"provenance,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Cosign     DockerCosign     `yaml:"cosign,omitempty" json:"cosign,omitempty"`
+	Registries []DockerRegistry `yaml:"registries,omitempty" json:"registries,omitempty"`
+	Secrets    []string         `yaml:"secrets,omitempty" json:"secrets,omitempty"`
+	SSH        []string         `yaml:"ssh,omitempty" json:"ssh,omitempty"`
+
 	Retry Retry `yaml:"retry,omitempty" json:"retry,omitempty"` // Deprecated: use [Project.Retry] instead.
 }
 
+// DockerCosign configures signing and attesting the pushed images with cosign.
+type DockerCosign struct {
+	Enabled    string   `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Key        string   `yaml:"key,omitempty" json:"key,omitempty"`
+	SBOM       string   `yaml:"sbom,omitempty" json:"sbom,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Provenance string   `yaml:"provenance,omitempty" json:"provenance,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Flags      []string `yaml:"flags,omitempty" json:"flags,omitempty"`
+}
+
+// DockerRegistry configures the credentials of a registry dockers_v2 images
+// are pushed to, and whether failing to push to it should fail the release.
+type DockerRegistry struct {
+	Registry string `yaml:"registry" json:"registry"`
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+	Optional bool   `yaml:"optional,omitempty" json:"optional,omitempty"`
+}
+
+// Buildpack configures container images built with Cloud Native Buildpacks.
+type Buildpack struct {
+	ID         string   `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs        []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Goarch     string   `yaml:"goarch,omitempty" json:"goarch,omitempty"`
+	Goamd64    string   `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	Goarm      string   `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
+	Images     []string `yaml:"images,omitempty" json:"images,omitempty"`
+	Tags       []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Builder    string   `yaml:"builder,omitempty" json:"builder,omitempty"`
+	Buildpacks []string `yaml:"buildpacks,omitempty" json:"buildpacks,omitempty"`
+	Env        []string `yaml:"env,omitempty" json:"env,omitempty"`
+	Command    string   `yaml:"command,omitempty" json:"command,omitempty"`
+	ExtraFiles []string `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+	Flags      []string `yaml:"flags,omitempty" json:"flags,omitempty"`
+	SkipPush   string   `yaml:"skip_push,omitempty" json:"skip_push,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Disable    string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// OCIArtifact configures pushing files to a registry as OCI artifacts.
+type OCIArtifact struct {
+	ID           string            `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs          []string          `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Artifacts    string            `yaml:"artifacts,omitempty" json:"artifacts,omitempty" jsonschema:"enum=archive,enum=binary,default=archive"`
+	Goamd64      string            `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	Goarm        string            `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
+	Repository   string            `yaml:"repository,omitempty" json:"repository,omitempty"`
+	Tag          string            `yaml:"tag,omitempty" json:"tag,omitempty"`
+	ArtifactType string            `yaml:"artifact_type,omitempty" json:"artifact_type,omitempty"`
+	MediaType    string            `yaml:"media_type,omitempty" json:"media_type,omitempty"`
+	Annotations  map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+	Flags        []string          `yaml:"flags,omitempty" json:"flags,omitempty"`
+	Disable      string            `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// HelmChart configures packaging and publishing a Helm chart.
+type HelmChart struct {
+	ID         string            `yaml:"id,omitempty" json:"id,omitempty"`
+	Path       string            `yaml:"path,omitempty" json:"path,omitempty"`
+	Version    string            `yaml:"version,omitempty" json:"version,omitempty"`
+	AppVersion string            `yaml:"app_version,omitempty" json:"app_version,omitempty"`
+	Values     map[string]string `yaml:"values,omitempty" json:"values,omitempty"`
+	Sign       HelmChartSign     `yaml:"sign,omitempty" json:"sign,omitempty"`
+	Flags      []string          `yaml:"flags,omitempty" json:"flags,omitempty"`
+	Repository string            `yaml:"repository,omitempty" json:"repository,omitempty"`
+	Username   string            `yaml:"username,omitempty" json:"username,omitempty"`
+	Password   string            `yaml:"password,omitempty" json:"password,omitempty"`
+	Disable    string            `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// HelmChartSign configures signing a Helm chart, creating its provenance
+// file.
+type HelmChartSign struct {
+	Key     string `yaml:"key,omitempty" json:"key,omitempty"`
+	Keyring string `yaml:"keyring,omitempty" json:"keyring,omitempty"`
+}
+
+// DockerCleanup configures pruning old image tags from registries.
+type DockerCleanup struct {
+	Repositories []string      `yaml:"repositories,omitempty" json:"repositories,omitempty"`
+	Tags         []string      `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Keep         int           `yaml:"keep,omitempty" json:"keep,omitempty"`
+	MaxAge       time.Duration `yaml:"max_age,omitempty" json:"max_age,omitempty"`
+	DryRun       bool          `yaml:"dry_run,omitempty" json:"dry_run,omitempty"`
+	Disable      string        `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// Provenance configures generating signed SLSA provenance for the release
+// artifacts.
+type Provenance struct {
+	ID           string   `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs          []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Artifacts    string   `yaml:"artifacts,omitempty" json:"artifacts,omitempty" jsonschema:"enum=all,enum=checksum,enum=source,enum=package,enum=installer,enum=archive,enum=binary,enum=sbom"`
+	NameTemplate string   `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	Key          string   `yaml:"key,omitempty" json:"key,omitempty"`
+	Flags        []string `yaml:"flags,omitempty" json:"flags,omitempty"`
+	Disable      string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// GitHubAttestations configures creating GitHub artifact attestations.
+type GitHubAttestations struct {
+	Enabled string   `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	IDs     []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Flags   []string `yaml:"flags,omitempty" json:"flags,omitempty"`
+}
+
+// InToto configures recording the release stages as signed in-toto links.
+type InToto struct {
+	Enabled      string   `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	NameTemplate string   `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	Key          string   `yaml:"key,omitempty" json:"key,omitempty"`
+	Flags        []string `yaml:"flags,omitempty" json:"flags,omitempty"`
+}
+
+// Licenses configures the third-party licenses report.
+type Licenses struct {
+	Enabled      string   `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	NameTemplate string   `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	Dir          string   `yaml:"dir,omitempty" json:"dir,omitempty"`
+	Packages     []string `yaml:"packages,omitempty" json:"packages,omitempty"`
+	Images       []string `yaml:"images,omitempty" json:"images,omitempty"`
+	Allow        []string `yaml:"allow,omitempty" json:"allow,omitempty"`
+	Ignore       []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
+}
+
 // DockerDigest config.
 type DockerDigest struct {
 	Disable      string `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
@@ -1241,7 +1701,7 @@ type Changelog struct {
 	Filters Filters          `yaml:"filters,omitempty" json:"filters,omitempty"`
 	Sort    string           `yaml:"sort,omitempty" json:"sort,omitempty" jsonschema:"enum=asc,enum=desc,enum=,default="`
 	Disable string           `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
-	Use     string           `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=git,enum=github,enum=github-native,enum=gitlab,default=git"`
+	Use     string           `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=git,enum=github,enum=github-native,enum=gitlab,enum=gitea,enum=forgejo,enum=bitbucket,default=git"`
 	Format  string           `yaml:"format,omitempty" json:"format,omitempty"`
 	Groups  []ChangelogGroup `yaml:"groups,omitempty" json:"groups,omitempty"`
 	Abbrev  int              `yaml:"abbrev,omitempty" json:"abbrev,omitempty"`
@@ -1257,9 +1717,11 @@ type ChangelogGroup struct {
 // EnvFiles holds paths to files that contains environment variables
 // values like the github token for example.
 type EnvFiles struct {
-	GitHubToken string `yaml:"github_token,omitempty" json:"github_token,omitempty"`
-	GitLabToken string `yaml:"gitlab_token,omitempty" json:"gitlab_token,omitempty"`
-	GiteaToken  string `yaml:"gitea_token,omitempty" json:"gitea_token,omitempty"`
+	GitHubToken    string `yaml:"github_token,omitempty" json:"github_token,omitempty"`
+	GitLabToken    string `yaml:"gitlab_token,omitempty" json:"gitlab_token,omitempty"`
+	GiteaToken     string `yaml:"gitea_token,omitempty" json:"gitea_token,omitempty"`
+	ForgejoToken   string `yaml:"forgejo_token,omitempty" json:"forgejo_token,omitempty"`
+	BitbucketToken string `yaml:"bitbucket_token,omitempty" json:"bitbucket_token,omitempty"`
 }
 
 // Before config.
@@ -1334,6 +1796,11 @@ type Source struct {
 	Enabled        bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
 	PrefixTemplate string `yaml:"prefix_template,omitempty" json:"prefix_template,omitempty"`
 	Files          []File `yaml:"files,omitempty" json:"files,omitempty"`
+
+	// v2.18+
+	Submodules bool     `yaml:"submodules,omitempty" json:"submodules,omitempty"`
+	Vendor     bool     `yaml:"vendor,omitempty" json:"vendor,omitempty"`
+	Exclude    []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
 }
 
 // Project includes all project configuration.
@@ -1388,8 +1855,29 @@ type Project struct {
 	MCP               MCP               `yaml:"mcp,omitempty" json:"mcp,omitempty"`
 	Retry             Retry             `yaml:"retry,omitempty" json:"retry,omitempty"`
 
+	// v2.18+
+	BuildCache         BuildCache         `yaml:"build_cache,omitempty" json:"build_cache,omitempty"`
+	BinaryProcessors   []BinaryProcessor  `yaml:"binary_processors,omitempty" json:"binary_processors,omitempty"`
+	DebugSymbols       []DebugSymbols     `yaml:"debug_symbols,omitempty" json:"debug_symbols,omitempty"`
+	DMGs               []DMG              `yaml:"dmg,omitempty" json:"dmg,omitempty"`
+	AppBundles         []AppBundle        `yaml:"app_bundles,omitempty" json:"app_bundles,omitempty"`
+	MSI                []MSI              `yaml:"msi,omitempty" json:"msi,omitempty"`
+	AppImages          []AppImage         `yaml:"appimages,omitempty" json:"appimages,omitempty"`
+	PackageRepos       []PackageRepo      `yaml:"package_repositories,omitempty" json:"package_repositories,omitempty"`
+	Asdfs              []Asdf             `yaml:"asdfs,omitempty" json:"asdfs,omitempty"`
+	NPMs               []NPM              `yaml:"npms,omitempty" json:"npms,omitempty"`
+	PyPIs              []PyPI             `yaml:"pypis,omitempty" json:"pypis,omitempty"`
+	Buildpacks         []Buildpack        `yaml:"buildpacks,omitempty" json:"buildpacks,omitempty"`
+	OCIArtifacts       []OCIArtifact      `yaml:"oci_artifacts,omitempty" json:"oci_artifacts,omitempty"`
+	DockerCleanups     []DockerCleanup    `yaml:"docker_cleanups,omitempty" json:"docker_cleanups,omitempty"`
+	HelmCharts         []HelmChart        `yaml:"helm_charts,omitempty" json:"helm_charts,omitempty"`
+	Provenances        []Provenance       `yaml:"provenances,omitempty" json:"provenances,omitempty"`
+	GitHubAttestations GitHubAttestations `yaml:"github_attestations,omitempty" json:"github_attestations,omitempty"`
+	Licenses           Licenses           `yaml:"licenses,omitempty" json:"licenses,omitempty"`
+	InToto             InToto             `yaml:"intoto,omitempty" json:"intoto,omitempty"`
+
 	// force the SCM token to use when multiple are set
-	ForceToken string `yaml:"force_token,omitempty" json:"force_token,omitempty" jsonschema:"enum=github,enum=gitlab,enum=gitea,enum=,default="`
+	ForceToken string `yaml:"force_token,omitempty" json:"force_token,omitempty" jsonschema:"enum=github,enum=gitlab,enum=gitea,enum=forgejo,enum=bitbucket,enum=,default="`
 
 	// should be set if using github enterprise
 	GitHubURLs GitHubURLs `yaml:"github_urls,omitempty" json:"github_urls,omitempty"`
@@ -1400,6 +1888,12 @@ type Project struct {
 	// should be set if using Gitea
 	GiteaURLs GiteaURLs `yaml:"gitea_urls,omitempty" json:"gitea_urls,omitempty"`
 
+	// should be set if using a Forgejo instance other than Codeberg
+	ForgejoURLs ForgejoURLs `yaml:"forgejo_urls,omitempty" json:"forgejo_urls,omitempty"`
+
+	// should be set if using Bitbucket Data Center
+	BitbucketURLs BitbucketURLs `yaml:"bitbucket_urls,omitempty" json:"bitbucket_urls,omitempty"`
+
 	// Deprecated: use [Project.Casks] instead.
 	Brews []Homebrew `yaml:"brews,omitempty" json:"brews,omitempty" jsonschema:"deprecated=true"`
 
@@ -1410,6 +1904,12 @@ type Project struct {
 	DockerManifests []DockerManifest `yaml:"docker_manifests,omitempty" json:"docker_manifests,omitempty" jsonschema:"deprecated=true"`
 }
 
+// BuildCache configures the Go caches shared by all builds and hooks.
+type BuildCache struct {
+	Dir     string   `yaml:"dir,omitempty" json:"dir,omitempty"`
+	Goflags []string `yaml:"goflags,omitempty" json:"goflags,omitempty"`
+}
+
 type ProjectMetadata struct {
 	ModTimestamp string `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
 }
@@ -1424,6 +1924,7 @@ type GoMod struct {
 
 type Announce struct {
 	Skip           string         `yaml:"skip,omitempty" json:"skip,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Proxy          string         `yaml:"proxy,omitempty" json:"proxy,omitempty"`
 	Twitter        Twitter        `yaml:"twitter,omitempty" json:"twitter,omitempty"`
 	Mastodon       Mastodon       `yaml:"mastodon,omitempty" json:"mastodon,omitempty"`
 	Reddit         Reddit         `yaml:"reddit,omitempty" json:"reddit,omitempty"`
@@ -1435,9 +1936,23 @@ type Announce struct {
 	LinkedIn       LinkedIn       `yaml:"linkedin,omitempty" json:"linkedin,omitempty"`
 	Telegram       Telegram       `yaml:"telegram,omitempty" json:"telegram,omitempty"`
 	Webhook        Webhook        `yaml:"webhook,omitempty" json:"webhook,omitempty"`
+	Webhooks       []Webhook      `yaml:"webhooks,omitempty" json:"webhooks,omitempty"`
 	OpenCollective OpenCollective `yaml:"opencollective,omitempty" json:"opencollective,omitempty"`
 	Bluesky        Bluesky        `yaml:"bluesky,omitempty" json:"bluesky,omitempty"`
 	Discourse      Discourse      `yaml:"discourse,omitempty" json:"discourse,omitempty"`
+
+	// v2.18+
+	Matrix           Matrix            `yaml:"matrix,omitempty" json:"matrix,omitempty"`
+	GoogleChat       GoogleChat        `yaml:"googlechat,omitempty" json:"googlechat,omitempty"`
+	Feed             Feed              `yaml:"feed,omitempty" json:"feed,omitempty"`
+	IRC              IRC               `yaml:"irc,omitempty" json:"irc,omitempty"`
+	PagerDuty        PagerDuty         `yaml:"pagerduty,omitempty" json:"pagerduty,omitempty"`
+	Opsgenie         Opsgenie          `yaml:"opsgenie,omitempty" json:"opsgenie,omitempty"`
+	Datadog          Datadog           `yaml:"datadog,omitempty" json:"datadog,omitempty"`
+	NewRelic         NewRelic          `yaml:"newrelic,omitempty" json:"newrelic,omitempty"`
+	Order            []string          `yaml:"order,omitempty" json:"order,omitempty"`
+	FailFast         bool              `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty"`
+	MessageTemplates map[string]string `yaml:"message_templates,omitempty" json:"message_templates,omitempty"`
 }
 
 type Webhook struct {
@@ -1448,6 +1963,32 @@ type Webhook struct {
 	Headers             map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
 	ContentType         string            `yaml:"content_type,omitempty" json:"content_type,omitempty"`
 	ExpectedStatusCodes []int             `yaml:"expected_status_codes,omitempty" json:"expected_status_codes,omitempty"`
+
+	// v2.18+
+	Signature          WebhookSignature `yaml:"signature,omitempty" json:"signature,omitempty"`
+	RetryOnStatusCodes []int            `yaml:"retry_on_status_codes,omitempty" json:"retry_on_status_codes,omitempty"`
+	Retry              Retry            `yaml:"retry,omitempty" json:"retry,omitempty"`
+	ClientCert         string           `yaml:"client_cert,omitempty" json:"client_cert,omitempty"`
+	ClientKey          string           `yaml:"client_key,omitempty" json:"client_key,omitempty"`
+	CAFile             string           `yaml:"ca_file,omitempty" json:"ca_file,omitempty"`
+	IncludeArtifacts   bool             `yaml:"include_artifacts,omitempty" json:"include_artifacts,omitempty"`
+	Format             string           `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=raw,enum=cloudevents,default=raw"`
+	CloudEvents        CloudEvents      `yaml:"cloudevents,omitempty" json:"cloudevents,omitempty"`
+}
+
+// CloudEvents configures the CloudEvents envelope of a webhook.
+type CloudEvents struct {
+	Source  string `yaml:"source,omitempty" json:"source,omitempty"`
+	Type    string `yaml:"type,omitempty" json:"type,omitempty"`
+	Subject string `yaml:"subject,omitempty" json:"subject,omitempty"`
+}
+
+// WebhookSignature configures the HMAC-SHA256 signing of the webhook body.
+type WebhookSignature struct {
+	Secret   string  `yaml:"secret,omitempty" json:"secret,omitempty"`
+	Header   string  `yaml:"header,omitempty" json:"header,omitempty"`
+	Encoding string  `yaml:"encoding,omitempty" json:"encoding,omitempty" jsonschema:"enum=hex,enum=base64,default=hex"`
+	Prefix   *string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
 }
 
 type Twitter struct {
@@ -1459,6 +2000,17 @@ type Mastodon struct {
 	Enabled         string `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
 	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
 	Server          string `yaml:"server" json:"server"`
+
+	// v2.18+
+	SpoilerText string          `yaml:"spoiler_text,omitempty" json:"spoiler_text,omitempty"`
+	Visibility  string          `yaml:"visibility,omitempty" json:"visibility,omitempty" jsonschema:"enum=public,enum=unlisted,enum=private,enum=direct"`
+	Media       []MastodonMedia `yaml:"media,omitempty" json:"media,omitempty"`
+}
+
+// MastodonMedia is an image attached to a Mastodon status.
+type MastodonMedia struct {
+	Path        string `yaml:"path" json:"path"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 }
 
 type Reddit struct {
@@ -1479,6 +2031,10 @@ type Slack struct {
 	IconURL         string            `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
 	Blocks          []SlackBlock      `yaml:"blocks,omitempty" json:"blocks,omitempty"`
 	Attachments     []SlackAttachment `yaml:"attachments,omitempty" json:"attachments,omitempty"`
+
+	// v2.18+
+	UploadReleaseNotes bool `yaml:"upload_release_notes,omitempty" json:"upload_release_notes,omitempty"`
+	ThreadArtifacts    bool `yaml:"thread_artifacts,omitempty" json:"thread_artifacts,omitempty"`
 }
 
 type Discord struct {
@@ -1487,6 +2043,109 @@ type Discord struct {
 	Author          string `yaml:"author,omitempty" json:"author,omitempty"`
 	Color           string `yaml:"color,omitempty" json:"color,omitempty"`
 	IconURL         string `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+
+	// v2.18+
+	ThumbnailURL     string           `yaml:"thumbnail_url,omitempty" json:"thumbnail_url,omitempty"`
+	Fields           []DiscordField   `yaml:"fields,omitempty" json:"fields,omitempty"`
+	IncludeArtifacts bool             `yaml:"include_artifacts,omitempty" json:"include_artifacts,omitempty"`
+	Buttons          []AnnounceButton `yaml:"buttons,omitempty" json:"buttons,omitempty"`
+}
+
+// DiscordField is a field of a Discord embed.
+type DiscordField struct {
+	Name   string `yaml:"name" json:"name"`
+	Value  string `yaml:"value" json:"value"`
+	Inline bool   `yaml:"inline,omitempty" json:"inline,omitempty"`
+}
+
+// AnnounceButton is a button that opens an URL, added to the announcements
+// that support it.
+type AnnounceButton struct {
+	Title string `yaml:"title" json:"title"`
+	URL   string `yaml:"url" json:"url"`
+}
+
+type Feed struct {
+	Enabled               string       `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Format                string       `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=atom,enum=rss,default=atom"`
+	Filename              string       `yaml:"filename,omitempty" json:"filename,omitempty"`
+	URL                   string       `yaml:"url,omitempty" json:"url,omitempty"`
+	TitleTemplate         string       `yaml:"title_template,omitempty" json:"title_template,omitempty"`
+	EntryTitleTemplate    string       `yaml:"entry_title_template,omitempty" json:"entry_title_template,omitempty"`
+	AppendTo              string       `yaml:"append_to,omitempty" json:"append_to,omitempty"`
+	MaxEntries            int          `yaml:"max_entries,omitempty" json:"max_entries,omitempty"`
+	Bucket                string       `yaml:"bucket,omitempty" json:"bucket,omitempty"`
+	Repository            RepoRef      `yaml:"repository,omitempty" json:"repository,omitempty"`
+	Directory             string       `yaml:"directory,omitempty" json:"directory,omitempty"`
+	CommitAuthor          CommitAuthor `yaml:"commit_author,omitempty" json:"commit_author,omitempty"`
+	CommitMessageTemplate string       `yaml:"commit_msg_template,omitempty" json:"commit_msg_template,omitempty"`
+}
+
+type IRC struct {
+	Enabled         string   `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Server          string   `yaml:"server,omitempty" json:"server,omitempty"`
+	Port            int      `yaml:"port,omitempty" json:"port,omitempty"`
+	DisableTLS      bool     `yaml:"disable_tls,omitempty" json:"disable_tls,omitempty"`
+	SkipTLSVerify   bool     `yaml:"skip_tls_verify,omitempty" json:"skip_tls_verify,omitempty"`
+	Nick            string   `yaml:"nick,omitempty" json:"nick,omitempty"`
+	Username        string   `yaml:"username,omitempty" json:"username,omitempty"`
+	Channels        []string `yaml:"channels,omitempty" json:"channels,omitempty"`
+	MessageTemplate string   `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+}
+
+type PagerDuty struct {
+	Enabled         string            `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	API             string            `yaml:"api,omitempty" json:"api,omitempty"`
+	MessageTemplate string            `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	Source          string            `yaml:"source,omitempty" json:"source,omitempty"`
+	CustomDetails   map[string]string `yaml:"custom_details,omitempty" json:"custom_details,omitempty"`
+}
+
+type Opsgenie struct {
+	Enabled             string            `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	API                 string            `yaml:"api,omitempty" json:"api,omitempty"`
+	MessageTemplate     string            `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	DescriptionTemplate string            `yaml:"description_template,omitempty" json:"description_template,omitempty"`
+	Priority            string            `yaml:"priority,omitempty" json:"priority,omitempty" jsonschema:"enum=P1,enum=P2,enum=P3,enum=P4,enum=P5,default=P5"`
+	Tags                []string          `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Details             map[string]string `yaml:"details,omitempty" json:"details,omitempty"`
+}
+
+type Datadog struct {
+	Enabled         string   `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	API             string   `yaml:"api,omitempty" json:"api,omitempty"`
+	Service         string   `yaml:"service,omitempty" json:"service,omitempty"`
+	TitleTemplate   string   `yaml:"title_template,omitempty" json:"title_template,omitempty"`
+	MessageTemplate string   `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	Tags            []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+type NewRelic struct {
+	Enabled             string `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	API                 string `yaml:"api,omitempty" json:"api,omitempty"`
+	EntityGUID          string `yaml:"entity_guid,omitempty" json:"entity_guid,omitempty"`
+	DescriptionTemplate string `yaml:"description_template,omitempty" json:"description_template,omitempty"`
+	ChangelogTemplate   string `yaml:"changelog_template,omitempty" json:"changelog_template,omitempty"`
+	User                string `yaml:"user,omitempty" json:"user,omitempty"`
+}
+
+type GoogleChat struct {
+	Enabled          string           `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	MessageTemplate  string           `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	Format           string           `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=text,enum=card,default=text"`
+	TitleTemplate    string           `yaml:"title_template,omitempty" json:"title_template,omitempty"`
+	SubtitleTemplate string           `yaml:"subtitle_template,omitempty" json:"subtitle_template,omitempty"`
+	IconURL          string           `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+	Buttons          []AnnounceButton `yaml:"buttons,omitempty" json:"buttons,omitempty"`
+}
+
+type Matrix struct {
+	Enabled         string `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Homeserver      string `yaml:"homeserver,omitempty" json:"homeserver,omitempty"`
+	RoomID          string `yaml:"room_id,omitempty" json:"room_id,omitempty"`
+	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	HTMLTemplate    string `yaml:"html_template,omitempty" json:"html_template,omitempty"`
+	MsgType         string `yaml:"msgtype,omitempty" json:"msgtype,omitempty" jsonschema:"enum=m.text,enum=m.notice,default=m.text"`
 }
 
 type Teams struct {
@@ -1495,6 +2154,17 @@ type Teams struct {
 	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
 	Color           string `yaml:"color,omitempty" json:"color,omitempty"`
 	IconURL         string `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+
+	// v2.18+
+	Format  string           `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=messagecard,enum=adaptivecard,default=messagecard"`
+	Facts   []TeamsFact      `yaml:"facts,omitempty" json:"facts,omitempty"`
+	Buttons []AnnounceButton `yaml:"buttons,omitempty" json:"buttons,omitempty"`
+}
+
+// TeamsFact is a key/value pair shown in a Teams Adaptive Card.
+type TeamsFact struct {
+	Title string `yaml:"title" json:"title"`
+	Value string `yaml:"value" json:"value"`
 }
 
 type Mattermost struct {
@@ -1518,6 +2188,12 @@ type SMTP struct {
 	SubjectTemplate    string   `yaml:"subject_template,omitempty" json:"subject_template,omitempty"`
 	BodyTemplate       string   `yaml:"body_template,omitempty" json:"body_template,omitempty"`
 	InsecureSkipVerify bool     `yaml:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty"`
+
+	// v2.18+
+	HTMLBodyTemplate string   `yaml:"html_body_template,omitempty" json:"html_body_template,omitempty"`
+	Cc               []string `yaml:"cc,omitempty" json:"cc,omitempty"`
+	Bcc              []string `yaml:"bcc,omitempty" json:"bcc,omitempty"`
+	AttachChecksums  bool     `yaml:"attach_checksums,omitempty" json:"attach_checksums,omitempty"`
 }
 
 type LinkedIn struct {
@@ -1533,6 +2209,10 @@ type Telegram struct {
 
 	// v2.15+
 	MessageThreadID string `yaml:"message_thread_id,omitempty" json:"message_thread_id,omitempty" jsonschema:"oneof_type=string;integer"`
+
+	// v2.18+
+	DisableNotification   bool `yaml:"disable_notification,omitempty" json:"disable_notification,omitempty"`
+	DisableWebPagePreview bool `yaml:"disable_web_page_preview,omitempty" json:"disable_web_page_preview,omitempty"`
 }
 
 type OpenCollective struct {
@@ -1547,6 +2227,9 @@ type Bluesky struct {
 	Enabled         string `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"oneof_type=string;boolean"`
 	Username        string `yaml:"username,omitempty" json:"username,omitempty"`
 	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+
+	// v2.18+
+	PDSURL string `yaml:"pds_url,omitempty" json:"pds_url,omitempty"`
 }
 
 // Discourse represents the discourse portion of a GoReleaser.
@@ -1569,6 +2252,46 @@ type SlackAttachment struct {
 	Internal any
 }
 
+// NPM contains the npm section.
+type NPM struct {
+	ID          string         `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs         []string       `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Name        string         `yaml:"name,omitempty" json:"name,omitempty"`
+	Description string         `yaml:"description,omitempty" json:"description,omitempty"`
+	Homepage    string         `yaml:"homepage,omitempty" json:"homepage,omitempty"`
+	Keywords    []string       `yaml:"keywords,omitempty" json:"keywords,omitempty"`
+	License     string         `yaml:"license,omitempty" json:"license,omitempty"`
+	Author      string         `yaml:"author,omitempty" json:"author,omitempty"`
+	Repository  string         `yaml:"repository,omitempty" json:"repository,omitempty"`
+	Bugs        string         `yaml:"bugs,omitempty" json:"bugs,omitempty"`
+	Access      string         `yaml:"access,omitempty" json:"access,omitempty" jsonschema:"enum=public,enum=restricted"`
+	Tag         string         `yaml:"tag,omitempty" json:"tag,omitempty"`
+	Goamd64     string         `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	Goarm       string         `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
+	Provenance  bool           `yaml:"provenance,omitempty" json:"provenance,omitempty"`
+	Extra       map[string]any `yaml:"extra,omitempty" json:"extra,omitempty"`
+	Disable     string         `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// PyPI contains the pypi section.
+type PyPI struct {
+	ID          string   `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs         []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Name        string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Version     string   `yaml:"version,omitempty" json:"version,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Homepage    string   `yaml:"homepage,omitempty" json:"homepage,omitempty"`
+	Keywords    []string `yaml:"keywords,omitempty" json:"keywords,omitempty"`
+	License     string   `yaml:"license,omitempty" json:"license,omitempty"`
+	Author      string   `yaml:"author,omitempty" json:"author,omitempty"`
+	Repository  string   `yaml:"repository,omitempty" json:"repository,omitempty"`
+	PublishURL  string   `yaml:"publish_url,omitempty" json:"publish_url,omitempty"`
+	Goamd64     string   `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	Goarm       string   `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
+	SkipUpload  string   `yaml:"skip_upload,omitempty" json:"skip_upload,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Disable     string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
 // Chocolatey contains the chocolatey section.
 type Chocolatey struct {
 	Name                     string                 `yaml:"name,omitempty" json:"name,omitempty"`
@@ -1595,6 +2318,10 @@ type Chocolatey struct {
 	APIKey                   string                 `yaml:"api_key,omitempty" json:"api_key,omitempty"`
 	SourceRepo               string                 `yaml:"source_repo,omitempty" json:"source_repo,omitempty"`
 	Goamd64                  string                 `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+
+	// v2.18+
+	InstallScript   string `yaml:"install_script,omitempty" json:"install_script,omitempty"`
+	UninstallScript string `yaml:"uninstall_script,omitempty" json:"uninstall_script,omitempty"`
 }
 
 // ChocolateyDependency represents Chocolatey dependency.
@@ -1621,6 +2348,9 @@ type Makeself struct {
 	Keywords    []string       `yaml:"keywords,omitempty" json:"keywords,omitempty"`
 	Homepage    string         `yaml:"homepage,omitempty" json:"homepage,omitempty"`
 	License     string         `yaml:"license,omitempty" json:"license,omitempty"`
+
+	// v2.18+
+	SFXModule string `yaml:"sfx_module,omitempty" json:"sfx_module,omitempty"`
 }
 
 // MakeselfFile is a file inside a makeself archive.
@@ -1630,6 +2360,119 @@ type MakeselfFile struct {
 	StripParent bool   `yaml:"strip_parent,omitempty" json:"strip_parent,omitempty"`
 }
 
+// DMG is a macOS disk image configuration.
+type DMG struct {
+	ID           string      `yaml:"id,omitempty" json:"id,omitempty"`
+	Name         string      `yaml:"name,omitempty" json:"name,omitempty"`
+	IDs          []string    `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Use          string      `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=binary,enum=appbundle,default=binary"`
+	Goamd64      string      `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	VolumeName   string      `yaml:"volume_name,omitempty" json:"volume_name,omitempty"`
+	Background   string      `yaml:"background,omitempty" json:"background,omitempty"`
+	ExtraFiles   []ExtraFile `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+	ModTimestamp string      `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
+	Disable      string      `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// AppBundle is a macOS app bundle configuration.
+type AppBundle struct {
+	ID                  string          `yaml:"id,omitempty" json:"id,omitempty"`
+	Name                string          `yaml:"name,omitempty" json:"name,omitempty"`
+	IDs                 []string        `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Icon                string          `yaml:"icon,omitempty" json:"icon,omitempty"`
+	Bundle              string          `yaml:"bundle" json:"bundle"`
+	Entitlements        string          `yaml:"entitlements,omitempty" json:"entitlements,omitempty"`
+	ModTimestamp        string          `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
+	ExtraFiles          []File          `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+	TemplatedExtraFiles []TemplatedFile `yaml:"templated_extra_files,omitempty" json:"templated_extra_files,omitempty"`
+	Disable             string          `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// MSI is a Windows installer configuration.
+type MSI struct {
+	ID           string     `yaml:"id,omitempty" json:"id,omitempty"`
+	Name         string     `yaml:"name,omitempty" json:"name,omitempty"`
+	WXS          string     `yaml:"wxs,omitempty" json:"wxs,omitempty"`
+	IDs          []string   `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Goamd64      string     `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	ExtraFiles   []string   `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+	Extensions   []string   `yaml:"extensions,omitempty" json:"extensions,omitempty"`
+	Version      string     `yaml:"version,omitempty" json:"version,omitempty" jsonschema:"enum=v3,enum=v4"`
+	UpgradeCode  string     `yaml:"upgrade_code,omitempty" json:"upgrade_code,omitempty"`
+	Manufacturer string     `yaml:"manufacturer,omitempty" json:"manufacturer,omitempty"`
+	Service      MSIService `yaml:"service,omitempty" json:"service,omitempty"`
+	ModTimestamp string     `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
+	Disable      string     `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// MSIService is a Windows service installed by the default MSI template.
+type MSIService struct {
+	Name        string `yaml:"name,omitempty" json:"name,omitempty"`
+	DisplayName string `yaml:"display_name,omitempty" json:"display_name,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Arguments   string `yaml:"arguments,omitempty" json:"arguments,omitempty"`
+	Start       string `yaml:"start,omitempty" json:"start,omitempty" jsonschema:"enum=auto,enum=demand,enum=disabled,default=auto"`
+}
+
+// AppImage is a Linux AppImage configuration.
+type AppImage struct {
+	ID                string   `yaml:"id,omitempty" json:"id,omitempty"`
+	Name              string   `yaml:"name,omitempty" json:"name,omitempty"`
+	IDs               []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Goamd64           string   `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	DisplayName       string   `yaml:"display_name,omitempty" json:"display_name,omitempty"`
+	Command           string   `yaml:"command,omitempty" json:"command,omitempty"`
+	Icon              string   `yaml:"icon,omitempty" json:"icon,omitempty"`
+	DesktopFile       string   `yaml:"desktop_file,omitempty" json:"desktop_file,omitempty"`
+	Categories        []string `yaml:"categories,omitempty" json:"categories,omitempty"`
+	Terminal          bool     `yaml:"terminal,omitempty" json:"terminal,omitempty"`
+	UpdateInformation string   `yaml:"update_information,omitempty" json:"update_information,omitempty"`
+	RuntimeFile       string   `yaml:"runtime_file,omitempty" json:"runtime_file,omitempty"`
+	ExtraFiles        []File   `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+	ModTimestamp      string   `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
+	Disable           string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// PackageRepo configures apt, yum, apk, and pacman repositories created from
+// the Linux packages.
+type PackageRepo struct {
+	ID        string               `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs       []string             `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Formats   []string             `yaml:"formats,omitempty" json:"formats,omitempty" jsonschema:"enum=deb,enum=rpm,enum=apk,enum=archlinux"`
+	Deb       PackageRepoDeb       `yaml:"deb,omitempty" json:"deb,omitempty"`
+	APK       PackageRepoAPK       `yaml:"apk,omitempty" json:"apk,omitempty"`
+	Archlinux PackageRepoArchlinux `yaml:"archlinux,omitempty" json:"archlinux,omitempty"`
+	Signature PackageRepoSignature `yaml:"signature,omitempty" json:"signature,omitempty"`
+	Blobs     []Blob               `yaml:"blobs,omitempty" json:"blobs,omitempty"`
+	Uploads   []Upload             `yaml:"uploads,omitempty" json:"uploads,omitempty"`
+	Disable   string               `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// PackageRepoDeb holds the apt specific repository options.
+type PackageRepoDeb struct {
+	Distribution string `yaml:"distribution,omitempty" json:"distribution,omitempty"`
+	Component    string `yaml:"component,omitempty" json:"component,omitempty"`
+	Origin       string `yaml:"origin,omitempty" json:"origin,omitempty"`
+	Label        string `yaml:"label,omitempty" json:"label,omitempty"`
+	Description  string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// PackageRepoAPK holds the apk specific repository options.
+type PackageRepoAPK struct {
+	KeyFile string `yaml:"key_file,omitempty" json:"key_file,omitempty"`
+	KeyName string `yaml:"key_name,omitempty" json:"key_name,omitempty"`
+}
+
+// PackageRepoArchlinux holds the pacman specific repository options.
+type PackageRepoArchlinux struct {
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+}
+
+// PackageRepoSignature configures how the repository metadata is signed.
+type PackageRepoSignature struct {
+	KeyID string `yaml:"key_id,omitempty" json:"key_id,omitempty"`
+}
+
 // MCP server configuration.
 type MCP struct {
 	// Deprecated: Use top-level MCP fields instead of nesting under GitHub.
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/intoto"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 )
 
@@ -66,6 +67,10 @@ const (
 	TokenTypeGitLab TokenType = "gitlab"
 	// TokenTypeGitea defines gitea as type of the token.
 	TokenTypeGitea TokenType = "gitea"
+	// TokenTypeForgejo defines forgejo as type of the token.
+	TokenTypeForgejo TokenType = "forgejo"
+	// TokenTypeBitbucket defines bitbucket as type of the token.
+	TokenTypeBitbucket TokenType = "bitbucket"
 )
 
 type Action uint8
@@ -79,39 +84,48 @@ const (
 // Context carries along some data through the pipes.
 type Context struct {
 	stdctx.Context
-	Action            Action
-	Config            config.Project
-	Env               Env
-	Token             string
-	TokenType         TokenType
-	Git               GitInfo
-	Date              time.Time
-	Artifacts         *artifact.Artifacts
-	ReleaseURL        string
-	ReleaseNotes      string
-	ReleaseNotesFile  string
-	ReleaseNotesTmpl  string
-	ReleaseHeaderFile string
-	ReleaseHeaderTmpl string
-	ReleaseFooterFile string
-	ReleaseFooterTmpl string
-	Version           string
-	ModulePath        string
-	PartialTarget     string
-	Snapshot          bool
-	FailFast          bool
-	Partial           bool
-	SingleTarget      bool
-	SkipTokenCheck    bool
-	Clean             bool
-	PreRelease        bool
-	Deprecated        bool
-	Parallelism       int
-	Semver            Semver
-	Runtime           Runtime
-	Skips             map[string]bool
+	Action             Action
+	Config             config.Project
+	Env                Env
+	Token              string
+	TokenType          TokenType
+	Git                GitInfo
+	Date               time.Time
+	Artifacts          *artifact.Artifacts
+	ReleaseURL         string
+	ReleaseNotes       string
+	ReleaseNotesFile   string
+	ReleaseNotesTmpl   string
+	ReleaseHeaderFile  string
+	ReleaseHeaderTmpl  string
+	ReleaseFooterFile  string
+	ReleaseFooterTmpl  string
+	Version            string
+	ModulePath         string
+	PartialTarget      string
+	Snapshot           bool
+	FailFast           bool
+	Partial            bool
+	SingleTarget       bool
+	SkipTokenCheck     bool
+	Clean              bool
+	CleanCache         bool
+	VerifyReproducible bool
+	PreRelease         bool
+	Deprecated         bool
+	Parallelism        int
+	Semver             Semver
+	Runtime            Runtime
+	Skips              map[string]bool
 
 	NotifiedDeprecations map[string]struct{}
+
+	inToto *intoto.Recorder
+}
+
+// InToto returns the recorder of the release stages as in-toto links.
+func (ctx *Context) InToto() *intoto.Recorder {
+	return ctx.inToto
 }
 
 type Runtime struct {
@@ -144,6 +158,7 @@ func Wrap(ctx stdctx.Context, config config.Project) *Context {
 		Date:                 time.Now(),
 		Skips:                map[string]bool{},
 		NotifiedDeprecations: map[string]struct{}{},
+		inToto:               &intoto.Recorder{},
 		Runtime: Runtime{
 			Goos:   runtime.GOOS,
 			Goarch: runtime.GOARCH,
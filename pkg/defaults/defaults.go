@@ -5,39 +5,64 @@ package defaults
 import (
 	"fmt"
 
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/appbundle"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/appimage"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/archive"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/artifactory"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/asdf"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/aur"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/aursources"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/binaryprocessor"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/blob"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/bluesky"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/brew"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/build"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/buildpacks"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/cask"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/changelog"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/checksums"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/chocolatey"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/datadog"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/debugsymbols"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/discord"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/discourse"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/dist"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/dmg"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/docker"
 	dockerv2 "github.com/goreleaser/goreleaser/v2/internal/pipe/docker/v2"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/dockercleanup"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/dockerdigest"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/feed"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/flatpak"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/gomod"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/googlechat"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/helm"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/irc"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/ko"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/krew"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/licenses"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/linkedin"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/links"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/makeself"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/mastodon"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/matrix"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/mattermost"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/mcp"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/milestone"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/msi"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/newrelic"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nfpm"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nix"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/notary"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/npm"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/ociartifact"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/opencollective"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/opsgenie"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/packagerepo"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/pagerduty"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/project"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/provenance"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/pypi"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/reddit"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/release"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sbom"
@@ -81,16 +106,25 @@ var Defaulters = []Defaulter{
 	gomod.Pipe{},
 	build.Pipe{},
 	universalbinary.Pipe{},
+	debugsymbols.Pipe{},
+	binaryprocessor.Pipe{},
 	upx.Pipe{},
 	sign.BinaryPipe{},
 	notary.MacOS{},
 	sourcearchive.Pipe{},
 	archive.Pipe{},
 	makeself.Pipe{},
+	appbundle.Pipe{},
+	dmg.Pipe{},
+	msi.Pipe{},
 	nfpm.Pipe{},
 	srpm.Pipe{},
 	snapcraft.Pipe{},
 	flatpak.Pipe{},
+	appimage.Pipe{},
+	packagerepo.Pipe{},
+	npm.Pipe{},
+	pypi.Pipe{},
 	checksums.Pipe{},
 	sign.Pipe{},
 	sign.DockerPipe{},
@@ -109,7 +143,15 @@ var Defaulters = []Defaulter{
 	brew.Pipe{},
 	cask.Pipe{},
 	krew.Pipe{},
+	asdf.Pipe{},
 	ko.Pipe{},
+	buildpacks.Pipe{},
+	ociartifact.Pipe{},
+	dockercleanup.Pipe{},
+	helm.Pipe{},
+	provenance.Pipe{},
+	links.Pipe{},
+	licenses.Pipe{},
 	scoop.Pipe{},
 	mcp.Pipe{},
 	discord.Pipe{},
@@ -120,6 +162,7 @@ var Defaulters = []Defaulter{
 	smtp.Pipe{},
 	mastodon.Pipe{},
 	mattermost.Pipe{},
+	matrix.Pipe{},
 	milestone.Pipe{},
 	linkedin.Pipe{},
 	telegram.Pipe{},
@@ -128,4 +171,11 @@ var Defaulters = []Defaulter{
 	opencollective.Pipe{},
 	bluesky.Pipe{},
 	discourse.Pipe{},
+	feed.Pipe{},
+	googlechat.Pipe{},
+	irc.Pipe{},
+	pagerduty.Pipe{},
+	opsgenie.Pipe{},
+	datadog.Pipe{},
+	newrelic.Pipe{},
 }
@@ -5,11 +5,28 @@ package healthcheck
 import (
 	"fmt"
 
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/appimage"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/archive"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/attestation"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/buildpacks"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/checksums"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/chocolatey"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/debugsymbols"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/dmg"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/docker"
 	dockerv2 "github.com/goreleaser/goreleaser/v2/internal/pipe/docker/v2"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/dockercleanup"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/flatpak"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/helm"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/licenses"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/links"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/msi"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nix"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/npm"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/ociartifact"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/packagerepo"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/provenance"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/pypi"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sbom"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sign"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/snapcraft"
@@ -49,6 +66,8 @@ var DependencyCheckers = []DependencyChecker{
 	system{},
 	builds{},
 	snapcraft.Pipe{},
+	archive.Pipe{},
+	checksums.Pipe{},
 	sign.Pipe{},
 	sign.BinaryPipe{},
 	sign.DockerPipe{},
@@ -59,6 +78,21 @@ var DependencyCheckers = []DependencyChecker{
 	chocolatey.Pipe{},
 	nix.New(),
 	flatpak.Pipe{},
+	debugsymbols.Pipe{},
+	dmg.Pipe{},
+	msi.Pipe{},
+	appimage.Pipe{},
+	packagerepo.Pipe{},
+	npm.Pipe{},
+	pypi.Pipe{},
+	buildpacks.Pipe{},
+	ociartifact.Pipe{},
+	dockercleanup.Pipe{},
+	helm.Pipe{},
+	provenance.Pipe{},
+	links.Pipe{},
+	attestation.Pipe{},
+	licenses.Pipe{},
 }
 
 type system struct{}